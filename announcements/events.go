@@ -0,0 +1,39 @@
+package announcements
+
+import (
+	"github.com/irmine/gomine/events"
+	"github.com/irmine/gomine/net"
+)
+
+const (
+	JoinEventName events.Name = "JoinMessageEvent"
+	QuitEventName events.Name = "QuitMessageEvent"
+)
+
+// JoinEvent gets called before the join message of a player gets broadcast.
+// The message can be modified to rewrite it, and cancelling the event suppresses it.
+type JoinEvent struct {
+	events.Cancellable
+	Session *net.MinecraftSession
+	Message string
+	// FirstJoin indicates if the player joined the server for the first time.
+	FirstJoin bool
+}
+
+// GetName returns the name of the event.
+func (event *JoinEvent) GetName() events.Name {
+	return JoinEventName
+}
+
+// QuitEvent gets called before the quit message of a player gets broadcast.
+// The message can be modified to rewrite it, and cancelling the event suppresses it.
+type QuitEvent struct {
+	events.Cancellable
+	Session *net.MinecraftSession
+	Message string
+}
+
+// GetName returns the name of the event.
+func (event *QuitEvent) GetName() events.Name {
+	return QuitEventName
+}
@@ -0,0 +1,109 @@
+package announcements
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/irmine/gomine/events"
+	"github.com/irmine/gomine/net"
+	"github.com/irmine/gomine/players"
+	"github.com/irmine/gomine/text"
+)
+
+const (
+	// DefaultJoinMessage is the join message used if no join message has been set.
+	DefaultJoinMessage = text.Yellow + "{name} has joined the server"
+	// DefaultFirstJoinMessage is the first join message used if no first join message has been set.
+	DefaultFirstJoinMessage = text.Yellow + "Welcome {name} to the server for the first time!"
+	// DefaultQuitMessage is the quit message used if no quit message has been set.
+	DefaultQuitMessage = text.Yellow + "{name} has left the server"
+)
+
+// Manager broadcasts the join and quit messages of players.
+// Messages are templates in which the following placeholders are replaced:
+// {name}: The display name of the player.
+// {username}: The username of the player.
+// {group}: The name of the permission group of the player.
+// {prefix}: The chat prefix of the permission group of the player.
+// {online}: The amount of players online.
+type Manager struct {
+	// JoinMessage is broadcast when a player joins the server.
+	JoinMessage string
+	// FirstJoinMessage is broadcast instead of the join message
+	// when a player joins the server for the first time.
+	FirstJoinMessage string
+	// QuitMessage is broadcast when a player leaves the server.
+	QuitMessage string
+
+	sessionManager *net.SessionManager
+	storage        players.DataStorage
+	eventManager   *events.Manager
+}
+
+// NewManager returns a new announcement manager using the default messages.
+// The first join of players gets saved to the data storage.
+func NewManager(sessionManager *net.SessionManager, storage players.DataStorage, eventManager *events.Manager) *Manager {
+	return &Manager{
+		JoinMessage:      DefaultJoinMessage,
+		FirstJoinMessage: DefaultFirstJoinMessage,
+		QuitMessage:      DefaultQuitMessage,
+		sessionManager:   sessionManager,
+		storage:          storage,
+		eventManager:     eventManager,
+	}
+}
+
+// Format replaces all placeholders in the message with the values of the session.
+func (manager *Manager) Format(session *net.MinecraftSession, message string) string {
+	var group, prefix = "", ""
+	if session.GetPermissionGroup() != nil {
+		group, prefix = session.GetPermissionGroup().GetName(), session.GetPermissionGroup().GetPrefix()
+	}
+	return strings.NewReplacer(
+		"{name}", session.GetDisplayName(),
+		"{username}", session.GetName(),
+		"{group}", group,
+		"{prefix}", prefix,
+		"{online}", strconv.Itoa(manager.sessionManager.GetSessionCount()),
+	).Replace(message)
+}
+
+// Join broadcasts the join message of the session, after calling a join event.
+// Players without a first join time in their data have joined for the first time,
+// in which case the first join message is broadcast and the first join time is saved.
+// Join returns a bool indicating if the player joined for the first time.
+func (manager *Manager) Join(session *net.MinecraftSession) (bool, error) {
+	var data = session.GetPlayer().GetData()
+	var firstJoin = data.FirstJoined == 0
+	var message = manager.JoinMessage
+	if firstJoin {
+		message = manager.FirstJoinMessage
+		data.FirstJoined = time.Now().Unix()
+	}
+
+	var event = &JoinEvent{Session: session, Message: manager.Format(session, message), FirstJoin: firstJoin}
+	if manager.eventManager.Call(event) && event.Message != "" {
+		manager.broadcast(event.Message)
+	}
+	if !firstJoin {
+		return false, nil
+	}
+	return true, manager.storage.Save(data)
+}
+
+// Quit broadcasts the quit message of the session, after calling a quit event.
+func (manager *Manager) Quit(session *net.MinecraftSession) {
+	var event = &QuitEvent{Session: session, Message: manager.Format(session, manager.QuitMessage)}
+	if manager.eventManager.Call(event) && event.Message != "" {
+		manager.broadcast(event.Message)
+	}
+}
+
+// broadcast sends a message to all online players and logs it.
+func (manager *Manager) broadcast(message string) {
+	text.DefaultLogger.LogChat(message)
+	for _, session := range manager.sessionManager.GetSessions() {
+		session.SendMessage(message)
+	}
+}
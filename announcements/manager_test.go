@@ -0,0 +1,42 @@
+package announcements
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/irmine/gomine/events"
+	"github.com/irmine/gomine/net"
+	"github.com/irmine/gomine/players"
+)
+
+func TestJoin(t *testing.T) {
+	var dir, err = ioutil.TempDir("", "announcements")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	var eventManager = events.NewManager()
+	var manager = NewManager(net.NewSessionManager(), players.NewFileDataStorage(dir+"/"), eventManager)
+	var session = net.NewMinecraftSession(nil, nil)
+	session.SetPlayer(players.NewPlayer(uuid.New(), "", 0, "Steve"))
+
+	var messages []string
+	eventManager.Register(JoinEventName, events.NewHandler(func(event events.Event) {
+		var join = event.(*JoinEvent)
+		messages = append(messages, join.Message)
+		join.SetCancelled(true)
+	}))
+
+	if firstJoin, err := manager.Join(session); !firstJoin || err != nil {
+		t.Error("first join was not detected:", err)
+	}
+	if firstJoin, _ := manager.Join(session); firstJoin {
+		t.Error("second join was detected as first join")
+	}
+	if len(messages) != 2 || messages[0] != manager.Format(session, DefaultFirstJoinMessage) || messages[1] != manager.Format(session, DefaultJoinMessage) {
+		t.Error("unexpected join messages:", messages)
+	}
+}
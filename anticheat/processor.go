@@ -0,0 +1,247 @@
+package anticheat
+
+import (
+	"math"
+	"sync"
+	"time"
+
+	"github.com/golang/geo/r3"
+	"github.com/irmine/gomine/events"
+	"github.com/irmine/gomine/net"
+	"github.com/irmine/worlds"
+	"github.com/irmine/worlds/entities/data"
+)
+
+// eyeHeight is the height of the eyes of a player above its feet.
+// Player positions sent by the client are at eye height.
+const eyeHeight = 1.62
+
+// halfWidth is half the width of the collision box of a player.
+const halfWidth = 0.3
+
+// groundTolerance is the maximum distance between the feet of a player
+// and the top of the block below for the player to be on the ground.
+const groundTolerance = 0.1
+
+// stateShards is the amount of shards the states of players are spread over.
+// Movements of players in different shards are processed without contending for the same lock.
+const stateShards = 64
+
+// Thresholds are the limits used by the movement checks.
+// A threshold of 0 or lower disables its check.
+type Thresholds struct {
+	// MaxSpeed is the maximum horizontal speed in blocks per second.
+	MaxSpeed float64
+	// MaxFlySpeed is the maximum horizontal speed in blocks
+	// per second of players that are allowed to fly.
+	MaxFlySpeed float64
+	// MaxAirTicks is the maximum amount of consecutive movements
+	// a player can be in the air without falling, if flight is not allowed.
+	MaxAirTicks int
+	// MaxMoveDistance is the maximum distance of a single movement.
+	MaxMoveDistance float64
+}
+
+// state is the movement state of a single player.
+type state struct {
+	lastMove      time.Time
+	airTicks      int
+	violations    int
+	flightAllowed bool
+	// abilityFlight is true if the player is allowed to fly by its abilities,
+	// for example because it is in creative mode or flight was toggled.
+	abilityFlight bool
+}
+
+// stateShard holds the states of a part of all players.
+type stateShard struct {
+	mutex  sync.Mutex
+	states map[string]*state
+}
+
+// Processor validates the movement of players,
+// before it is synchronized with the server.
+// Movements violating the thresholds are rejected,
+// and the player gets moved back to its previous position.
+type Processor struct {
+	Thresholds
+	// SolidFunction is used by the no-clip and fly checks to determine
+	// if the block at the given position in a dimension is solid.
+	// The no-clip check is disabled if no function is set,
+	// and the fly check then trusts the on ground flag sent by the client.
+	SolidFunction func(dimension *worlds.Dimension, x, y, z int) bool
+
+	eventManager *events.Manager
+	shards       [stateShards]stateShard
+}
+
+// NewProcessor returns a new movement processor with the given thresholds.
+// Violations are called as events on the event manager.
+func NewProcessor(eventManager *events.Manager, thresholds Thresholds) *Processor {
+	var processor = &Processor{Thresholds: thresholds, eventManager: eventManager}
+	for i := range processor.shards {
+		processor.shards[i].states = make(map[string]*state)
+	}
+	return processor
+}
+
+// getShard returns the shard holding the state of the player with the given name.
+// The shard is chosen using the FNV-1a hash of the name, which is computed inline to avoid allocating.
+func (processor *Processor) getShard(name string) *stateShard {
+	var hash uint32 = 2166136261
+	for i := 0; i < len(name); i++ {
+		hash ^= uint32(name[i])
+		hash *= 16777619
+	}
+	return &processor.shards[hash%stateShards]
+}
+
+// getState returns the state of the player with the given name from the shard,
+// creating a new state if it did not yet have one. The mutex of the shard must be locked.
+func (shard *stateShard) getState(name string) *state {
+	var s, ok = shard.states[name]
+	if !ok {
+		s = &state{}
+		shard.states[name] = s
+	}
+	return s
+}
+
+// SetFlightAllowed sets if the player with the given name is allowed to fly,
+// regardless of the abilities of the player.
+func (processor *Processor) SetFlightAllowed(name string, value bool) {
+	var shard = processor.getShard(name)
+	shard.mutex.Lock()
+	shard.getState(name).flightAllowed = value
+	shard.mutex.Unlock()
+}
+
+// IsFlightAllowed checks if the player with the given name is allowed to fly,
+// either by SetFlightAllowed or by the abilities of the player.
+func (processor *Processor) IsFlightAllowed(name string) bool {
+	var shard = processor.getShard(name)
+	shard.mutex.Lock()
+	defer shard.mutex.Unlock()
+	var s = shard.getState(name)
+	return s.flightAllowed || s.abilityFlight
+}
+
+// GetViolationCount returns the total amount of violations of the player with the given name.
+func (processor *Processor) GetViolationCount(name string) int {
+	var shard = processor.getShard(name)
+	shard.mutex.Lock()
+	defer shard.mutex.Unlock()
+	return shard.getState(name).violations
+}
+
+// Remove removes the state of the player with the given name.
+func (processor *Processor) Remove(name string) {
+	var shard = processor.getShard(name)
+	shard.mutex.Lock()
+	delete(shard.states, name)
+	shard.mutex.Unlock()
+}
+
+// Process validates a movement of the player of the session.
+// Valid movements are synchronized with the server and true is returned.
+// On a violation a ViolationEvent gets called, and unless it got cancelled,
+// the player gets moved back to its current server position and false is returned.
+// The on ground flag sent by the client is replaced by the blocks below the player if a solid function is set.
+func (processor *Processor) Process(session *net.MinecraftSession, to r3.Vector, rotation data.Rotation, onGround bool) bool {
+	var player = session.GetPlayer()
+	var from = player.Position
+	if processor.SolidFunction != nil && player.GetDimension() != nil {
+		onGround = processor.isOnGround(player.GetDimension(), to)
+	}
+
+	var violation, violated, count = processor.update(session.GetName(), from, to, onGround, player.GetAllowFlight(), player.GetDimension(), time.Now())
+	if violated {
+		var event = &ViolationEvent{Session: session, Violation: violation, From: from, To: to, Count: count}
+		if processor.eventManager.Call(event) {
+			session.Teleport(from, rotation)
+			return false
+		}
+	}
+	session.SyncMove(to.X, to.Y, to.Z, rotation.Pitch, rotation.Yaw, rotation.HeadYaw, onGround)
+	return true
+}
+
+// update checks a movement of the player with the given name in the dimension and updates its state.
+// The violation is returned if the movement violated a threshold, along with the total amount of violations.
+// Only the shard holding the state of the player is locked, so that players moving at the same time rarely wait for each other.
+func (processor *Processor) update(name string, from, to r3.Vector, onGround, abilityFlight bool, dimension *worlds.Dimension, now time.Time) (Violation, bool, int) {
+	var shard = processor.getShard(name)
+	shard.mutex.Lock()
+	defer shard.mutex.Unlock()
+	var s = shard.getState(name)
+	s.abilityFlight = abilityFlight
+	var violation, violated = processor.check(s, from, to, onGround, now.Sub(s.lastMove))
+	if !violated && processor.SolidFunction != nil && processor.isInsideBlock(dimension, to) {
+		violation, violated = ViolationNoClip, true
+	}
+	s.lastMove = now
+	if violated {
+		s.violations++
+		s.airTicks = 0
+	}
+	return violation, violated, s.violations
+}
+
+// check checks a movement from one position to another against the thresholds,
+// and updates the air ticks of the state. The elapsed duration is the time
+// since the previous movement, and is clamped to the range of one tick to one second.
+func (processor *Processor) check(s *state, from r3.Vector, to r3.Vector, onGround bool, elapsed time.Duration) (Violation, bool) {
+	var delta = to.Sub(from)
+	if processor.MaxMoveDistance > 0 && delta.Norm() > processor.MaxMoveDistance {
+		return ViolationTeleport, true
+	}
+
+	if elapsed < time.Second/20 {
+		elapsed = time.Second / 20
+	} else if elapsed > time.Second {
+		elapsed = time.Second
+	}
+	var flightAllowed = s.flightAllowed || s.abilityFlight
+	var maxSpeed = processor.MaxSpeed
+	if flightAllowed {
+		maxSpeed = processor.MaxFlySpeed
+	}
+	var speed = math.Sqrt(delta.X*delta.X+delta.Z*delta.Z) / elapsed.Seconds()
+	if maxSpeed > 0 && speed > maxSpeed {
+		return ViolationSpeed, true
+	}
+
+	if onGround || flightAllowed || delta.Y < 0 {
+		s.airTicks = 0
+		return 0, false
+	}
+	s.airTicks++
+	if processor.MaxAirTicks > 0 && s.airTicks > processor.MaxAirTicks {
+		return ViolationFly, true
+	}
+	return 0, false
+}
+
+// isOnGround checks if any solid block is right below the feet at the given eye position.
+// All blocks below the collision box are checked, so that players standing on the edge of a block are on the ground.
+func (processor *Processor) isOnGround(dimension *worlds.Dimension, position r3.Vector) bool {
+	var y = int(math.Floor(position.Y - eyeHeight - groundTolerance))
+	for _, x := range [2]float64{position.X - halfWidth, position.X + halfWidth} {
+		for _, z := range [2]float64{position.Z - halfWidth, position.Z + halfWidth} {
+			if processor.SolidFunction(dimension, int(math.Floor(x)), y, int(math.Floor(z))) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// isInsideBlock checks if both the feet and the head
+// at the given eye position are inside solid blocks.
+func (processor *Processor) isInsideBlock(dimension *worlds.Dimension, position r3.Vector) bool {
+	if dimension == nil {
+		return false
+	}
+	var x, y, z = int(math.Floor(position.X)), int(math.Floor(position.Y - eyeHeight)), int(math.Floor(position.Z))
+	return processor.SolidFunction(dimension, x, y, z) && processor.SolidFunction(dimension, x, y+1, z)
+}
@@ -0,0 +1,105 @@
+package anticheat
+
+import (
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang/geo/r3"
+	"github.com/irmine/gomine/events"
+	"github.com/irmine/worlds"
+)
+
+func TestCheck(t *testing.T) {
+	processor := NewProcessor(events.NewManager(), Thresholds{MaxSpeed: 10, MaxFlySpeed: 20, MaxAirTicks: 5, MaxMoveDistance: 8})
+	s := &state{}
+	tick := time.Second / 20
+	from := r3.Vector{X: 0, Y: 10, Z: 0}
+
+	if _, violated := processor.check(s, from, r3.Vector{X: 0.3, Y: 10, Z: 0}, true, tick); violated {
+		t.Error("walking was flagged")
+	}
+	if v, violated := processor.check(s, from, r3.Vector{X: 1, Y: 10, Z: 0}, true, tick); !violated || v != ViolationSpeed {
+		t.Error("moving 20 blocks per second was not flagged as speed")
+	}
+	if v, violated := processor.check(s, from, r3.Vector{X: 9, Y: 10, Z: 0}, true, time.Second); !violated || v != ViolationTeleport {
+		t.Error("moving 9 blocks at once was not flagged as teleport")
+	}
+	for i := 0; i < 5; i++ {
+		if _, violated := processor.check(s, from, from, false, tick); violated {
+			t.Fatal("hovering was flagged too early, at tick", i)
+		}
+	}
+	if v, violated := processor.check(s, from, from, false, tick); !violated || v != ViolationFly {
+		t.Error("hovering was not flagged as fly")
+	}
+	s.flightAllowed = true
+	if _, violated := processor.check(s, from, r3.Vector{X: 0.9, Y: 10, Z: 0}, false, tick); violated {
+		t.Error("flying was flagged while flight is allowed")
+	}
+}
+
+func TestGround(t *testing.T) {
+	processor := NewProcessor(events.NewManager(), Thresholds{})
+	processor.SolidFunction = func(dimension *worlds.Dimension, x, y, z int) bool {
+		return x == 0 && y == 9 && z == 0
+	}
+	dimension := &worlds.Dimension{}
+	if !processor.isOnGround(dimension, r3.Vector{X: 0.5, Y: 10 + eyeHeight, Z: 0.5}) {
+		t.Error("standing on a block was not on the ground")
+	}
+	if !processor.isOnGround(dimension, r3.Vector{X: 1.2, Y: 10 + eyeHeight, Z: 0.5}) {
+		t.Error("standing on the edge of a block was not on the ground")
+	}
+	if processor.isOnGround(dimension, r3.Vector{X: 0.5, Y: 11 + eyeHeight, Z: 0.5}) {
+		t.Error("hovering above a block was on the ground")
+	}
+	if processor.isOnGround(dimension, r3.Vector{X: 1.5, Y: 10 + eyeHeight, Z: 0.5}) {
+		t.Error("standing next to a block was on the ground")
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	processor := NewProcessor(events.NewManager(), Thresholds{MaxSpeed: 10, MaxMoveDistance: 8})
+	now := time.Now()
+	from := r3.Vector{X: 0, Y: 10, Z: 0}
+
+	if _, violated, _ := processor.update("Steve", from, r3.Vector{X: 9, Y: 10, Z: 0}, true, false, nil, now); !violated {
+		t.Error("teleport was not flagged")
+	}
+	if _, violated, count := processor.update("Steve", from, from, true, false, nil, now.Add(time.Second)); violated || count != 1 {
+		t.Error("standing still was flagged, or the violation was not counted:", count)
+	}
+	if processor.GetViolationCount("Steve") != 1 || processor.GetViolationCount("Alex") != 0 {
+		t.Error("violations were not kept per player")
+	}
+	processor.Remove("Steve")
+	if processor.GetViolationCount("Steve") != 0 {
+		t.Error("state was not removed")
+	}
+}
+
+// BenchmarkUpdate measures the movement checks of 200 players moving at the same time.
+func BenchmarkUpdate(b *testing.B) {
+	const movers = 200
+	processor := NewProcessor(events.NewManager(), Thresholds{MaxSpeed: 10, MaxFlySpeed: 20, MaxAirTicks: 5, MaxMoveDistance: 8})
+	start := time.Now()
+
+	b.ResetTimer()
+	var wait sync.WaitGroup
+	wait.Add(movers)
+	for i := 0; i < movers; i++ {
+		name := "Mover" + strconv.Itoa(i)
+		go func() {
+			defer wait.Done()
+			position := r3.Vector{Y: 10}
+			for j := 0; j < b.N/movers+1; j++ {
+				next := position.Add(r3.Vector{X: 0.1})
+				processor.update(name, position, next, true, false, nil, start.Add(time.Duration(j)*time.Second/20))
+				position = next
+			}
+		}()
+	}
+	wait.Wait()
+}
@@ -0,0 +1,59 @@
+package anticheat
+
+import (
+	"github.com/golang/geo/r3"
+	"github.com/irmine/gomine/events"
+	"github.com/irmine/gomine/net"
+)
+
+// Violation is a type of movement violation.
+type Violation byte
+
+const (
+	// ViolationSpeed is a horizontal movement faster than allowed.
+	ViolationSpeed Violation = iota
+	// ViolationFly is staying in the air too long without flight being allowed.
+	ViolationFly
+	// ViolationNoClip is moving into solid blocks.
+	ViolationNoClip
+	// ViolationTeleport is a single movement further than allowed.
+	ViolationTeleport
+)
+
+// String returns a readable name of the violation.
+func (violation Violation) String() string {
+	switch violation {
+	case ViolationSpeed:
+		return "speed"
+	case ViolationFly:
+		return "fly"
+	case ViolationNoClip:
+		return "no-clip"
+	case ViolationTeleport:
+		return "teleport"
+	}
+	return "unknown"
+}
+
+const (
+	ViolationEventName events.Name = "MovementViolationEvent"
+)
+
+// ViolationEvent gets called when a player violates the movement checks.
+// Cancelling the event accepts the movement, rather than
+// moving the player back to its previous position.
+type ViolationEvent struct {
+	events.Cancellable
+	Session   *net.MinecraftSession
+	Violation Violation
+	From      r3.Vector
+	To        r3.Vector
+	// Count is the total amount of violations of the player,
+	// including this violation. It can be used to kick repeat offenders.
+	Count int
+}
+
+// GetName returns the name of the event.
+func (event *ViolationEvent) GetName() events.Name {
+	return ViolationEventName
+}
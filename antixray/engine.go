@@ -0,0 +1,124 @@
+package antixray
+
+import (
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/irmine/worlds"
+	"github.com/irmine/worlds/blocks"
+	"github.com/irmine/worlds/chunks"
+)
+
+// ParseMode returns the mode with the given name, being "disabled", "hide" or "fakes".
+// A bool is returned indicating if the mode exists.
+func ParseMode(name string) (Mode, bool) {
+	switch name {
+	case "disabled":
+		return ModeDisabled, true
+	case "hide":
+		return ModeHide, true
+	case "fakes":
+		return ModeFakes, true
+	}
+	return ModeDisabled, false
+}
+
+// Engine obfuscates the chunks sent to players in all worlds anti-xray is enabled in.
+// Obfuscated chunks are cached until a block in them changes,
+// at which point the real blocks around the changed block are revealed.
+type Engine struct {
+	// CacheSize is the maximum amount of obfuscated chunks kept in the cache.
+	CacheSize int
+
+	mutex    sync.RWMutex
+	settings map[string]Settings
+	cache    map[*chunks.Chunk][]byte
+}
+
+// NewEngine returns a new anti-xray engine without any worlds enabled.
+func NewEngine() *Engine {
+	return &Engine{CacheSize: 2048, settings: make(map[string]Settings), cache: make(map[*chunks.Chunk][]byte)}
+}
+
+// SetSettings sets the anti-xray settings of the level with the given name,
+// and clears all cached chunks.
+func (engine *Engine) SetSettings(levelName string, settings Settings) {
+	engine.mutex.Lock()
+	engine.settings[levelName] = settings
+	engine.cache = make(map[*chunks.Chunk][]byte)
+	engine.mutex.Unlock()
+}
+
+// GetSettings returns the anti-xray settings of the level with the given name.
+// A bool is returned indicating if anti-xray is enabled in the level.
+func (engine *Engine) GetSettings(levelName string) (Settings, bool) {
+	engine.mutex.RLock()
+	defer engine.mutex.RUnlock()
+	var settings, ok = engine.settings[levelName]
+	return settings, ok && settings.Mode != ModeDisabled
+}
+
+// Serialize returns the chunk of the dimension serialized as sent to players.
+// The chunk is obfuscated if anti-xray is enabled in the level of the dimension.
+func (engine *Engine) Serialize(dimension *worlds.Dimension, chunk *chunks.Chunk) []byte {
+	if dimension == nil {
+		return chunk.ToBinary()
+	}
+	var settings, ok = engine.GetSettings(dimension.GetLevel().GetName())
+	if !ok {
+		return chunk.ToBinary()
+	}
+	engine.mutex.RLock()
+	var chunkData, cached = engine.cache[chunk]
+	engine.mutex.RUnlock()
+	if cached {
+		return chunkData
+	}
+
+	var random = rand.New(rand.NewSource(time.Now().UnixNano()))
+	chunkData = Obfuscate(chunk.ToBinary(), settings, random)
+	engine.mutex.Lock()
+	if len(engine.cache) >= engine.CacheSize {
+		for cachedChunk := range engine.cache {
+			delete(engine.cache, cachedChunk)
+			break
+		}
+	}
+	engine.cache[chunk] = chunkData
+	engine.mutex.Unlock()
+	return chunkData
+}
+
+// Invalidate removes the obfuscated chunk from the cache,
+// so that it gets obfuscated again the next time it is sent.
+func (engine *Engine) Invalidate(chunk *chunks.Chunk) {
+	engine.mutex.Lock()
+	delete(engine.cache, chunk)
+	engine.mutex.Unlock()
+}
+
+// Reveal invalidates the chunk after the block at the position changed,
+// and returns the runtime IDs of the real blocks next to the position that may have been obfuscated.
+// These blocks may now be exposed, and should be sent to all viewers of the chunk.
+func (engine *Engine) Reveal(dimension *worlds.Dimension, chunk *chunks.Chunk, position blocks.Position) map[blocks.Position]uint32 {
+	engine.Invalidate(chunk)
+	var revealed = make(map[blocks.Position]uint32)
+	var settings, ok = engine.GetSettings(dimension.GetLevel().GetName())
+	if !ok {
+		return revealed
+	}
+	var chunkData = chunk.ToBinary()
+	var x, y, z = int(position.X & 15), int(position.Y), int(position.Z & 15)
+	for _, side := range [][3]int{{0, -1, 0}, {0, 1, 0}, {-1, 0, 0}, {1, 0, 0}, {0, 0, -1}, {0, 0, 1}} {
+		var id, data, ok = GetBlock(chunkData, x+side[0], y+side[1], z+side[2])
+		if !ok || !IsObfuscated(id, settings) {
+			continue
+		}
+		if runtimeId, ok := blocks.GetRuntimeId(int16(id), int16(data)); ok {
+			var neighbour = blocks.NewPosition(position.X+int32(side[0]), uint32(y+side[1]), position.Z+int32(side[2]))
+			revealed[neighbour] = runtimeId
+		}
+	}
+	return revealed
+}
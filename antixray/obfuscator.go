@@ -0,0 +1,186 @@
+package antixray
+
+import (
+	"math/rand"
+)
+
+// Mode is the mode in which ores get obfuscated.
+type Mode byte
+
+const (
+	// ModeDisabled sends chunks unchanged.
+	ModeDisabled Mode = iota
+	// ModeHide replaces all ores that are not exposed with the replacement block,
+	// so that ores can not be seen through walls.
+	ModeHide
+	// ModeFakes replaces all ores and replacement blocks that are not exposed with random ores,
+	// so that real ores can not be told apart from fake ores.
+	ModeFakes
+)
+
+const (
+	// subChunkVersion is the only version of sub chunks that gets obfuscated,
+	// which stores a block ID and data for every block.
+	subChunkVersion = 0
+	// subChunkBlocks is the amount of blocks in a sub chunk.
+	subChunkBlocks = 16 * 16 * 16
+	// subChunkSize is the size of a serialized sub chunk, including its version.
+	subChunkSize = 1 + subChunkBlocks + subChunkBlocks/2
+)
+
+// Settings are the anti-xray settings of a world.
+type Settings struct {
+	Mode Mode
+	// HiddenBlocks are the IDs of the blocks that get hidden, and used as fake blocks.
+	HiddenBlocks []byte
+	// Replacement is the ID of the block hidden blocks get replaced with.
+	Replacement byte
+	// OccludingBlocks are the IDs of the blocks that can not be seen through.
+	// Blocks are exposed if any block next to them is not an occluding block.
+	OccludingBlocks []byte
+}
+
+// DefaultSettings returns the settings hiding all overworld ores in stone in the given mode.
+func DefaultSettings(mode Mode) Settings {
+	return Settings{
+		Mode: mode,
+		// Gold, iron, coal, lapis, diamond, redstone, lit redstone and emerald ore.
+		HiddenBlocks: []byte{14, 15, 16, 21, 56, 73, 74, 129},
+		Replacement:  1,
+		// Stone, grass, dirt, cobblestone, bedrock, sand, gravel, sandstone, netherrack and all ores.
+		OccludingBlocks: []byte{1, 2, 3, 4, 7, 12, 13, 14, 15, 16, 21, 24, 56, 73, 74, 87, 129, 153},
+	}
+}
+
+// Obfuscate returns a copy of the serialized chunk with all blocks hidden according to the settings.
+// Only sub chunks storing block IDs are supported, and the chunk is returned unchanged
+// if it contains sub chunks of another version.
+// Blocks at the edges of the chunk are always considered exposed, as the neighbouring chunks are not known.
+func Obfuscate(chunkData []byte, settings Settings, random *rand.Rand) []byte {
+	var source, ok = newColumn(chunkData)
+	if !ok || settings.Mode == ModeDisabled || len(settings.HiddenBlocks) == 0 {
+		return chunkData
+	}
+	var hidden, occluding = toSet(settings.HiddenBlocks), toSet(settings.OccludingBlocks)
+	var obfuscated = append([]byte(nil), chunkData...)
+	var result = &column{data: obfuscated, height: source.height}
+
+	for x := 0; x < 16; x++ {
+		for z := 0; z < 16; z++ {
+			for y := 0; y < source.height; y++ {
+				var id = source.getId(x, y, z)
+				switch {
+				case settings.Mode == ModeHide && hidden[id]:
+					if !source.isExposed(x, y, z, occluding) {
+						result.set(x, y, z, settings.Replacement)
+					}
+				case settings.Mode == ModeFakes && (hidden[id] || id == settings.Replacement):
+					if !source.isExposed(x, y, z, occluding) {
+						result.set(x, y, z, settings.HiddenBlocks[random.Intn(len(settings.HiddenBlocks))])
+					}
+				}
+			}
+		}
+	}
+	return obfuscated
+}
+
+// IsObfuscated checks if blocks with the given ID get obfuscated with the settings when they are not exposed.
+func IsObfuscated(id byte, settings Settings) bool {
+	switch settings.Mode {
+	case ModeHide:
+		return toSet(settings.HiddenBlocks)[id]
+	case ModeFakes:
+		return toSet(settings.HiddenBlocks)[id] || id == settings.Replacement
+	}
+	return false
+}
+
+// GetBlock returns the ID and data of the block at the position relative to the serialized chunk.
+// A bool is returned indicating if the chunk has a supported format and the position is in the chunk.
+func GetBlock(chunkData []byte, x, y, z int) (byte, byte, bool) {
+	var chunk, ok = newColumn(chunkData)
+	if !ok || x < 0 || x > 15 || z < 0 || z > 15 || y < 0 || y >= chunk.height {
+		return 0, 0, false
+	}
+	return chunk.getId(x, y, z), chunk.getData(x, y, z), true
+}
+
+// column is the block storage of all sub chunks in a serialized chunk.
+type column struct {
+	data   []byte
+	height int
+}
+
+// newColumn returns the column of the serialized chunk,
+// and a bool indicating if all its sub chunks have a supported version.
+func newColumn(chunkData []byte) (*column, bool) {
+	if len(chunkData) == 0 {
+		return nil, false
+	}
+	var count = int(chunkData[0])
+	if len(chunkData) < 1+count*subChunkSize {
+		return nil, false
+	}
+	for i := 0; i < count; i++ {
+		if chunkData[1+i*subChunkSize] != subChunkVersion {
+			return nil, false
+		}
+	}
+	return &column{data: chunkData, height: count * 16}, true
+}
+
+// offset returns the offset of the block ID at the position in the serialized chunk.
+func (column *column) offset(x, y, z int) int {
+	return 1 + (y>>4)*subChunkSize + 1 + (x<<8 | z<<4 | y&15)
+}
+
+// getId returns the ID of the block at the position.
+func (column *column) getId(x, y, z int) byte {
+	return column.data[column.offset(x, y, z)]
+}
+
+// getData returns the data of the block at the position.
+func (column *column) getData(x, y, z int) byte {
+	var index = x<<8 | z<<4 | y&15
+	var data = column.data[1+(y>>4)*subChunkSize+1+subChunkBlocks+index>>1]
+	if index&1 == 0 {
+		return data & 0x0f
+	}
+	return data >> 4
+}
+
+// set sets the block at the position to the block with the given ID, without data.
+func (column *column) set(x, y, z int, id byte) {
+	column.data[column.offset(x, y, z)] = id
+	var index = x<<8 | z<<4 | y&15
+	var offset = 1 + (y>>4)*subChunkSize + 1 + subChunkBlocks + index>>1
+	if index&1 == 0 {
+		column.data[offset] &= 0xf0
+	} else {
+		column.data[offset] &= 0x0f
+	}
+}
+
+// isExposed checks if any of the blocks next to the position is not an occluding block.
+// Blocks at the edges of the chunk and at the top of the column are always exposed.
+func (column *column) isExposed(x, y, z int, occluding [256]bool) bool {
+	if x == 0 || x == 15 || z == 0 || z == 15 || y == column.height-1 {
+		return true
+	}
+	if y > 0 && !occluding[column.getId(x, y-1, z)] {
+		return true
+	}
+	return !occluding[column.getId(x, y+1, z)] ||
+		!occluding[column.getId(x-1, y, z)] || !occluding[column.getId(x+1, y, z)] ||
+		!occluding[column.getId(x, y, z-1)] || !occluding[column.getId(x, y, z+1)]
+}
+
+// toSet returns a lookup table of the block IDs.
+func toSet(ids []byte) [256]bool {
+	var set [256]bool
+	for _, id := range ids {
+		set[id] = true
+	}
+	return set
+}
@@ -0,0 +1,66 @@
+package antixray
+
+import (
+	"bytes"
+	"math/rand"
+	"testing"
+)
+
+// newChunkData returns a serialized chunk with one sub chunk filled with stone,
+// followed by the given trailing data.
+func newChunkData(trailing []byte) []byte {
+	var chunkData = make([]byte, 1+subChunkSize)
+	chunkData[0] = 1
+	for i := 0; i < subChunkBlocks; i++ {
+		chunkData[2+i] = 1
+	}
+	return append(chunkData, trailing...)
+}
+
+func TestObfuscate(t *testing.T) {
+	var trailing = []byte{1, 2, 3, 4}
+	var chunkData = newChunkData(trailing)
+	var source = &column{data: chunkData, height: 16}
+	source.set(5, 5, 5, 56)
+	source.set(5, 15, 5, 56)
+	source.set(0, 5, 0, 56)
+	source.set(7, 5, 7, 56)
+	source.set(7, 6, 7, 0)
+	// The block at 5, 5, 5 has an odd index, so its data is stored in the high nibble.
+	var index = 5<<8 | 5<<4 | 5
+	chunkData[2+subChunkBlocks+index>>1] |= 3 << 4
+
+	if id, data, ok := GetBlock(chunkData, 5, 5, 5); !ok || id != 56 || data != 3 {
+		t.Fatal("unexpected block:", id, data, ok)
+	}
+
+	var obfuscated = Obfuscate(chunkData, DefaultSettings(ModeHide), rand.New(rand.NewSource(1)))
+	if id, data, _ := GetBlock(obfuscated, 5, 5, 5); id != 1 || data != 0 {
+		t.Error("hidden ore was not replaced:", id, data)
+	}
+	for _, position := range [][3]int{{5, 15, 5}, {0, 5, 0}, {7, 5, 7}} {
+		if id, _, _ := GetBlock(obfuscated, position[0], position[1], position[2]); id != 56 {
+			t.Error("exposed ore was hidden at", position)
+		}
+	}
+	if id, _, _ := GetBlock(chunkData, 5, 5, 5); id != 56 {
+		t.Error("original chunk was modified")
+	}
+	if !bytes.HasSuffix(obfuscated, trailing) {
+		t.Error("data after the sub chunks was not kept")
+	}
+
+	obfuscated = Obfuscate(chunkData, DefaultSettings(ModeFakes), rand.New(rand.NewSource(1)))
+	if id, _, _ := GetBlock(obfuscated, 3, 3, 3); !IsObfuscated(id, DefaultSettings(ModeHide)) {
+		t.Error("hidden stone was not replaced with a fake ore:", id)
+	}
+	if id, _, _ := GetBlock(obfuscated, 7, 7, 7); id != 1 {
+		t.Error("exposed stone was replaced:", id)
+	}
+
+	var unsupported = append([]byte(nil), chunkData...)
+	unsupported[1] = 8
+	if !bytes.Equal(Obfuscate(unsupported, DefaultSettings(ModeHide), rand.New(rand.NewSource(1))), unsupported) {
+		t.Error("chunk with unsupported sub chunk version was changed")
+	}
+}
@@ -0,0 +1,89 @@
+package arena
+
+import (
+	"sync"
+)
+
+// DefaultFreeLimit is the default maximum amount of free objects kept by an arena.
+const DefaultFreeLimit = 4096
+
+// Stats holds the amount of objects created and reused by an arena.
+type Stats struct {
+	Created uint64
+	Reused  uint64
+}
+
+// Arena hands out objects for transient use within a tick, such as packets that are sent once and then forgotten.
+// Objects handed out are recycled after the arena has been reset twice,
+// so objects obtained late during a tick remain valid until the end of the next tick.
+// Objects must not be kept once they are recycled, because they will be handed out again.
+// Arenas are safe for concurrent use.
+type Arena[T any] struct {
+	// FreeLimit is the maximum amount of free objects kept for reuse.
+	// Objects exceeding the limit are left to the garbage collector,
+	// so that a single spike in usage does not hold memory forever.
+	FreeLimit int
+
+	mutex    sync.Mutex
+	create   func() *T
+	clear    func(*T)
+	free     []*T
+	current  []*T
+	previous []*T
+	stats    Stats
+}
+
+// New returns a new arena creating objects with the create function.
+// The clear function is called with every object before it is reused,
+// and should reset the object to the state returned by the create function.
+func New[T any](create func() *T, clear func(*T)) *Arena[T] {
+	return &Arena[T]{FreeLimit: DefaultFreeLimit, create: create, clear: clear}
+}
+
+// Get returns an object of the arena, reusing a recycled object if one is available.
+// The object is valid until the arena has been reset twice.
+func (arena *Arena[T]) Get() *T {
+	arena.mutex.Lock()
+	defer arena.mutex.Unlock()
+	var object *T
+	if n := len(arena.free); n > 0 {
+		object = arena.free[n-1]
+		arena.free[n-1] = nil
+		arena.free = arena.free[:n-1]
+		arena.stats.Reused++
+	} else {
+		object = arena.create()
+		arena.stats.Created++
+	}
+	arena.current = append(arena.current, object)
+	return object
+}
+
+// Reset ends the current generation of the arena, and should be called at the end of every tick.
+// Objects handed out before the previous reset are cleared and recycled.
+func (arena *Arena[T]) Reset() {
+	arena.mutex.Lock()
+	defer arena.mutex.Unlock()
+	for i, object := range arena.previous {
+		if len(arena.free) < arena.FreeLimit {
+			arena.clear(object)
+			arena.free = append(arena.free, object)
+		}
+		arena.previous[i] = nil
+	}
+	arena.previous, arena.current = arena.current, arena.previous[:0]
+}
+
+// GetStats returns the amount of objects created and reused by the arena.
+func (arena *Arena[T]) GetStats() Stats {
+	arena.mutex.Lock()
+	defer arena.mutex.Unlock()
+	return arena.stats
+}
+
+// GetFreeCount returns the amount of objects ready to be reused.
+func (arena *Arena[T]) GetFreeCount() int {
+	arena.mutex.Lock()
+	defer arena.mutex.Unlock()
+	return len(arena.free)
+}
@@ -0,0 +1,89 @@
+package arena
+
+import (
+	"testing"
+)
+
+type transient struct {
+	id     int
+	buffer []byte
+}
+
+func newTransientArena() *Arena[transient] {
+	return New(func() *transient {
+		return &transient{buffer: make([]byte, 0, 64)}
+	}, func(object *transient) {
+		object.id = 0
+		object.buffer = object.buffer[:0]
+	})
+}
+
+func TestArena(t *testing.T) {
+	var arena = newTransientArena()
+	var first = arena.Get()
+	first.id = 1
+	first.buffer = append(first.buffer, 1, 2, 3)
+
+	arena.Reset()
+	if arena.GetFreeCount() != 0 {
+		t.Fatal("expected objects to stay in use until the second reset")
+	}
+	if second := arena.Get(); second == first {
+		t.Fatal("expected an object in use to not be handed out again")
+	}
+
+	arena.Reset()
+	if arena.GetFreeCount() != 1 {
+		t.Fatalf("expected 1 free object, got %v", arena.GetFreeCount())
+	}
+	if first.id != 0 || len(first.buffer) != 0 {
+		t.Fatal("expected recycled object to be cleared")
+	}
+	if reused := arena.Get(); reused != first {
+		t.Fatal("expected recycled object to be reused")
+	}
+	if stats := arena.GetStats(); stats.Created != 2 || stats.Reused != 1 {
+		t.Fatalf("expected 2 created and 1 reused objects, got %+v", stats)
+	}
+}
+
+func TestArenaFreeLimit(t *testing.T) {
+	var arena = newTransientArena()
+	arena.FreeLimit = 2
+	for i := 0; i < 5; i++ {
+		arena.Get()
+	}
+	arena.Reset()
+	arena.Reset()
+	if arena.GetFreeCount() != 2 {
+		t.Fatalf("expected free objects to be limited to 2, got %v", arena.GetFreeCount())
+	}
+}
+
+// benchmarkTick simulates ticks sending a packet to each of 500 players.
+func benchmarkTick(b *testing.B, get func() *transient, reset func()) {
+	const players = 500
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		for j := 0; j < players; j++ {
+			var object = get()
+			object.id = j
+			object.buffer = append(object.buffer, byte(j), byte(j>>8))
+		}
+		reset()
+	}
+}
+
+func BenchmarkTickWithArena(b *testing.B) {
+	var arena = newTransientArena()
+	benchmarkTick(b, arena.Get, arena.Reset)
+}
+
+var sink *transient
+
+func BenchmarkTickWithoutArena(b *testing.B) {
+	benchmarkTick(b, func() *transient {
+		sink = &transient{buffer: make([]byte, 0, 64)}
+		return sink
+	}, func() {})
+}
@@ -0,0 +1,149 @@
+package auth
+
+import (
+	"crypto/ecdsa"
+	"crypto/sha512"
+	"crypto/x509"
+	"encoding/base64"
+	"errors"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/irmine/gomine/net/packets/data"
+	"github.com/irmine/gomine/net/packets/types"
+)
+
+var (
+	// InvalidChain gets returned when the login chain is empty,
+	// or a token in the chain is not signed by the key of the token before it.
+	InvalidChain = errors.New("login chain has an invalid signature")
+	// ExpiredChain gets returned when a token in the login chain has expired or is not yet valid.
+	ExpiredChain = errors.New("login chain has expired")
+	// InvalidKey gets returned when a public key in the login chain could not be parsed.
+	InvalidKey = errors.New("login chain has an invalid public key")
+	// InvalidIdentity gets returned when the login chain holds no identity.
+	InvalidIdentity = errors.New("login chain holds no valid identity")
+	// InvalidClientData gets returned when the client data is not signed by the identity public key.
+	InvalidClientData = errors.New("client data has an invalid signature")
+)
+
+// RootPublicKey is the base64 encoded public key Xbox Live authenticated login chains are signed with.
+var RootPublicKey = data.MojangPublicKey
+
+// Identity is the identity of a player, extracted from a verified login chain.
+type Identity struct {
+	// DisplayName is the name the player logged in with.
+	DisplayName string
+	// UUID is the identity UUID of the player.
+	UUID uuid.UUID
+	// XUID is the Xbox user ID of the player.
+	// XUID is always empty if the player is not authenticated, as it can not be trusted.
+	XUID string
+	// PublicKey is the identity public key of the client,
+	// which the client data is signed with and which is used for encryption.
+	PublicKey *ecdsa.PublicKey
+	// Authenticated specifies if the login chain was signed by Xbox Live.
+	Authenticated bool
+}
+
+// maxChainLength is the maximum amount of tokens in a login chain.
+// Xbox Live authenticated chains hold a token self-signed by the client, a token signed by the root key,
+// and a token holding the identity signed by the key issued in the token before it.
+const maxChainLength = 3
+
+// VerifyChain verifies the signatures and expiration of all tokens in the login chain,
+// each of which must be signed with the public key of the token before it.
+// The identity is extracted from the final token in the chain. The player is only authenticated
+// if the final token is signed by the root key, or by the key issued in the token signed by the root key,
+// so that tokens signed by the client itself can not be appended to an authenticated chain.
+func VerifyChain(chain []types.Chain, now time.Time) (*Identity, error) {
+	if len(chain) == 0 || len(chain) > maxChainLength || chain[0].Header.X5u == "" {
+		return nil, InvalidChain
+	}
+	var identity = &Identity{}
+	var keyRaw = chain[0].Header.X5u
+	var rootSigned = -1
+	for i, token := range chain {
+		var key, err = ParsePublicKey(keyRaw)
+		if err != nil {
+			return nil, err
+		}
+		if !verify(token.Header.Raw+"."+token.Payload.Raw, token.Signature, key) {
+			return nil, InvalidChain
+		}
+		if keyRaw == RootPublicKey {
+			rootSigned = i
+		}
+		var t = now.Unix()
+		if token.Payload.ExpirationTime != 0 && token.Payload.ExpirationTime <= t || token.Payload.NotBefore > t {
+			return nil, ExpiredChain
+		}
+		keyRaw = token.Payload.IdentityPublicKey
+	}
+
+	var extraData = chain[len(chain)-1].Payload.ExtraData
+	if len(extraData) == 0 {
+		return nil, InvalidIdentity
+	}
+	identity.DisplayName, _ = extraData["displayName"].(string)
+	identity.XUID, _ = extraData["XUID"].(string)
+	var rawUUID, _ = extraData["identity"].(string)
+	var err error
+	if identity.UUID, err = uuid.Parse(rawUUID); err != nil || identity.DisplayName == "" {
+		return nil, InvalidIdentity
+	}
+	identity.Authenticated = rootSigned >= 0 && rootSigned >= len(chain)-2
+	if !identity.Authenticated {
+		identity.XUID = ""
+	}
+	key, err := ParsePublicKey(keyRaw)
+	if err != nil {
+		return nil, err
+	}
+	identity.PublicKey = key
+	return identity, nil
+}
+
+// VerifyClientData verifies that the raw client data JWT is signed with the identity public key.
+func VerifyClientData(clientData string, identity *Identity) error {
+	var parts = strings.Split(clientData, ".")
+	if len(parts) != 3 {
+		return InvalidClientData
+	}
+	var signature, err = base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil || !verify(parts[0]+"."+parts[1], string(signature), identity.PublicKey) {
+		return InvalidClientData
+	}
+	return nil
+}
+
+// ParsePublicKey parses a base64 encoded DER public key, as used in login chains.
+func ParsePublicKey(raw string) (*ecdsa.PublicKey, error) {
+	var der, err = base64.RawStdEncoding.DecodeString(strings.TrimRight(raw, "="))
+	if err != nil {
+		return nil, InvalidKey
+	}
+	key, err := x509.ParsePKIXPublicKey(der)
+	if err != nil {
+		return nil, InvalidKey
+	}
+	var publicKey, ok = key.(*ecdsa.PublicKey)
+	if !ok {
+		return nil, InvalidKey
+	}
+	return publicKey, nil
+}
+
+// verify verifies the ES384 signature of the signed data.
+// The signature consists of the R and S values, each taking up half of the signature.
+func verify(signed string, signature string, key *ecdsa.PublicKey) bool {
+	if len(signature) == 0 || len(signature)%2 != 0 {
+		return false
+	}
+	var hash = sha512.Sum384([]byte(signed))
+	var r = new(big.Int).SetBytes([]byte(signature[:len(signature)/2]))
+	var s = new(big.Int).SetBytes([]byte(signature[len(signature)/2:]))
+	return ecdsa.Verify(key, hash[:], r, s)
+}
@@ -0,0 +1,94 @@
+package auth
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha512"
+	"crypto/x509"
+	"encoding/base64"
+	"testing"
+	"time"
+
+	"github.com/irmine/gomine/net/packets/types"
+)
+
+func encodeKey(key *ecdsa.PrivateKey) string {
+	var der, _ = x509.MarshalPKIXPublicKey(&key.PublicKey)
+	return base64.RawStdEncoding.EncodeToString(der)
+}
+
+func sign(signed string, key *ecdsa.PrivateKey) []byte {
+	var hash = sha512.Sum384([]byte(signed))
+	var r, s, _ = ecdsa.Sign(rand.Reader, key, hash[:])
+	var signature = make([]byte, 96)
+	copy(signature[48-len(r.Bytes()):48], r.Bytes())
+	copy(signature[96-len(s.Bytes()):], s.Bytes())
+	return signature
+}
+
+func newToken(signer *ecdsa.PrivateKey, identityKey string, extraData map[string]interface{}) types.Chain {
+	var header, payload = "header", "payload"
+	return types.Chain{
+		Header:    types.ChainHeader{X5u: encodeKey(signer), Alg: "ES384", Raw: header},
+		Payload:   types.ChainPayload{IdentityPublicKey: identityKey, ExpirationTime: time.Now().Add(time.Hour).Unix(), ExtraData: extraData, Raw: payload},
+		Signature: string(sign(header+"."+payload, signer)),
+	}
+}
+
+func TestVerifyChain(t *testing.T) {
+	var client, _ = ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	var root, _ = ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	var extraData = map[string]interface{}{"displayName": "Steve", "identity": "8e4a1e0c-5e1a-4f5a-9c1a-1c2b3c4d5e6f", "XUID": "1234"}
+
+	var identity, err = VerifyChain([]types.Chain{newToken(client, encodeKey(client), extraData)}, time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if identity.Authenticated || identity.XUID != "" || identity.DisplayName != "Steve" {
+		t.Error("self signed chain was trusted:", identity)
+	}
+
+	defer func(key string) { RootPublicKey = key }(RootPublicKey)
+	RootPublicKey = encodeKey(root)
+	identity, err = VerifyChain([]types.Chain{newToken(client, RootPublicKey, nil), newToken(root, encodeKey(client), extraData)}, time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !identity.Authenticated || identity.XUID != "1234" {
+		t.Error("chain signed by root key was not authenticated:", identity)
+	}
+
+	// A token self-signed by the client may not be appended to an authenticated chain to take over another identity.
+	var intermediate, _ = ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	var victim = map[string]interface{}{"displayName": "Alex", "identity": "0f4a1e0c-5e1a-4f5a-9c1a-1c2b3c4d5e6f", "XUID": "5678"}
+	var authenticated = []types.Chain{newToken(client, RootPublicKey, nil), newToken(root, encodeKey(intermediate), nil), newToken(intermediate, encodeKey(client), extraData)}
+	if identity, err := VerifyChain(authenticated, time.Now()); err != nil || !identity.Authenticated || identity.XUID != "1234" {
+		t.Error("chain with an intermediate key was not authenticated:", identity, err)
+	}
+	if _, err := VerifyChain(append(authenticated, newToken(client, encodeKey(client), victim)), time.Now()); err != InvalidChain {
+		t.Error("expected chain with an appended token to be rejected, got:", err)
+	}
+	var appended = append(authenticated[1:len(authenticated):len(authenticated)], newToken(client, encodeKey(client), victim))
+	if identity, err := VerifyChain(appended, time.Now()); err != nil || identity.Authenticated || identity.XUID != "" {
+		t.Error("chain with a token signed by the client after the identity was authenticated:", identity, err)
+	}
+
+	var forged = newToken(client, encodeKey(client), extraData)
+	forged.Payload.Raw = "forged"
+	if _, err := VerifyChain([]types.Chain{forged}, time.Now()); err != InvalidChain {
+		t.Error("expected forged chain to be rejected, got:", err)
+	}
+	if _, err := VerifyChain([]types.Chain{newToken(client, encodeKey(client), extraData)}, time.Now().Add(time.Hour*2)); err != ExpiredChain {
+		t.Error("expected expired chain to be rejected, got:", err)
+	}
+
+	var clientData = "a.b"
+	var jwt = clientData + "." + base64.RawURLEncoding.EncodeToString(sign(clientData, client))
+	if err := VerifyClientData(jwt, identity); err != nil {
+		t.Error("client data signed by identity key was rejected:", err)
+	}
+	if err := VerifyClientData("a.c."+base64.RawURLEncoding.EncodeToString(sign(clientData, client)), identity); err != InvalidClientData {
+		t.Error("expected tampered client data to be rejected, got:", err)
+	}
+}
@@ -0,0 +1,85 @@
+package branding
+
+import (
+	"math/rand"
+	"strconv"
+	"strings"
+
+	"github.com/irmine/gomine/net"
+	"github.com/irmine/gomine/net/packets/data"
+)
+
+// Manager manages the branding shown to players while they join.
+// The following placeholders are replaced in all texts:
+// {name}: The display name of the player.
+// {username}: The username of the player.
+// {online}: The amount of players online.
+// {world}: The name of the world the player joins in.
+type Manager struct {
+	// WorldName is the world name displayed in the pause menu.
+	// The name of the level is displayed if the world name is empty.
+	WorldName string
+	// Title is the title shown when a player spawns.
+	// No title is shown if the title is empty.
+	Title string
+	// Subtitle is the subtitle shown under the title.
+	Subtitle string
+	// Tips are tips of which a random one is shown
+	// in the action bar when a player spawns.
+	Tips []string
+	// FadeIn, Stay and FadeOut are the durations
+	// in ticks the title fades in, stays and fades out.
+	FadeIn, Stay, FadeOut int32
+
+	sessionManager *net.SessionManager
+}
+
+// NewManager returns a new branding manager without any branding.
+func NewManager(sessionManager *net.SessionManager) *Manager {
+	return &Manager{FadeIn: 10, Stay: 70, FadeOut: 20, sessionManager: sessionManager}
+}
+
+// Format replaces all placeholders in the text with the values of the session.
+func (manager *Manager) Format(session *net.MinecraftSession, text string) string {
+	var world = ""
+	if session.GetPlayer() != nil && session.GetPlayer().GetDimension() != nil {
+		world = session.GetPlayer().GetDimension().GetLevel().GetName()
+	}
+	return strings.NewReplacer(
+		"{name}", session.GetDisplayName(),
+		"{username}", session.GetName(),
+		"{online}", strconv.Itoa(manager.sessionManager.GetSessionCount()),
+		"{world}", world,
+	).Replace(text)
+}
+
+// GetWorldName returns the world name displayed for the level with the given name.
+func (manager *Manager) GetWorldName(levelName string) string {
+	if manager.WorldName == "" {
+		return levelName
+	}
+	return strings.Replace(manager.WorldName, "{world}", levelName, -1)
+}
+
+// GetTip returns a random tip, or an empty string if there are no tips.
+func (manager *Manager) GetTip() string {
+	if len(manager.Tips) == 0 {
+		return ""
+	}
+	return manager.Tips[rand.Intn(len(manager.Tips))]
+}
+
+// Join shows the title, subtitle and a random tip to the session.
+// Join should be called once the session has spawned.
+func (manager *Manager) Join(session *net.MinecraftSession) {
+	if manager.Title != "" {
+		session.SendSetTitle(data.TitleTimes, "", manager.FadeIn, manager.Stay, manager.FadeOut)
+		if manager.Subtitle != "" {
+			session.SendSetTitle(data.TitleSubtitle, manager.Format(session, manager.Subtitle), 0, 0, 0)
+		}
+		session.SendSetTitle(data.TitleTitle, manager.Format(session, manager.Title), 0, 0, 0)
+	}
+	if tip := manager.GetTip(); tip != "" {
+		session.SendSetTitle(data.TitleActionBar, manager.Format(session, tip), 0, 0, 0)
+	}
+}
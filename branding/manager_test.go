@@ -0,0 +1,33 @@
+package branding
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/irmine/gomine/net"
+	"github.com/irmine/gomine/players"
+)
+
+func TestFormat(t *testing.T) {
+	var manager = NewManager(net.NewSessionManager())
+	var session = net.NewMinecraftSession(nil, nil)
+	session.SetPlayer(players.NewPlayer(uuid.New(), "", 0, "Steve"))
+
+	if text := manager.Format(session, "Welcome {username}, {online} online"); text != "Welcome Steve, 0 online" {
+		t.Error("unexpected formatted text:", text)
+	}
+	if name := manager.GetWorldName("world"); name != "world" {
+		t.Error("level name was not used without world name:", name)
+	}
+	manager.WorldName = "GoMine - {world}"
+	if name := manager.GetWorldName("world"); name != "GoMine - world" {
+		t.Error("unexpected world name:", name)
+	}
+	if manager.GetTip() != "" {
+		t.Error("tip returned without tips")
+	}
+	manager.Tips = []string{"Tip"}
+	if manager.GetTip() != "Tip" {
+		t.Error("tip was not returned")
+	}
+}
@@ -0,0 +1,47 @@
+package building
+
+import (
+	"github.com/irmine/gomine/events"
+	"github.com/irmine/gomine/items"
+	"github.com/irmine/gomine/net"
+	"github.com/irmine/worlds/blocks"
+)
+
+const (
+	BreakEventName events.Name = "BlockBreakEvent"
+	PlaceEventName events.Name = "BlockPlaceEvent"
+)
+
+// BreakEvent gets called when a player breaks a block.
+// Cancelling the event prevents the block from being broken.
+type BreakEvent struct {
+	events.Cancellable
+	Session  *net.MinecraftSession
+	Position blocks.Position
+	// Drops are the items dropped by the block.
+	// Drops are only given to players in survival or adventure mode.
+	Drops []*items.Stack
+	// Harvested specifies if the block was broken with a tool of the tier required for it to drop loot.
+	// The block has no drops by default if it was not harvested.
+	Harvested bool
+}
+
+// GetName returns the name of the event.
+func (event *BreakEvent) GetName() events.Name {
+	return BreakEventName
+}
+
+// PlaceEvent gets called when a player places a block.
+// Cancelling the event prevents the block from being placed.
+type PlaceEvent struct {
+	events.Cancellable
+	Session  *net.MinecraftSession
+	Position blocks.Position
+	// Item is the item stack the block is placed with.
+	Item *items.Stack
+}
+
+// GetName returns the name of the event.
+func (event *PlaceEvent) GetName() events.Name {
+	return PlaceEventName
+}
@@ -0,0 +1,331 @@
+package building
+
+import (
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/golang/geo/r3"
+	"github.com/irmine/gomine/events"
+	"github.com/irmine/gomine/items"
+	"github.com/irmine/gomine/items/enchantments"
+	"github.com/irmine/gomine/levels"
+	"github.com/irmine/gomine/net"
+	"github.com/irmine/gomine/net/packets/bedrock"
+	"github.com/irmine/gomine/players"
+	"github.com/irmine/worlds"
+	"github.com/irmine/worlds/blocks"
+	"github.com/irmine/worlds/chunks"
+	"github.com/irmine/worlds/utils"
+)
+
+// Faces of a block, as sent by the client.
+const (
+	FaceDown = iota
+	FaceUp
+	FaceNorth
+	FaceSouth
+	FaceWest
+	FaceEast
+)
+
+// Manager handles the breaking and placing of blocks by players.
+// Changed blocks get set in the dimension of the player,
+// and are broadcast to all players that have the chunk loaded.
+type Manager struct {
+	// ChangeFunction gets called with the dimension, chunk and position of the block
+	// every time a block in the chunk got changed.
+	ChangeFunction func(dimension *worlds.Dimension, chunk *chunks.Chunk, position blocks.Position)
+	// DropFunction gets called with the drops of every block broken in survival.
+	// By default the drops are added to the inventory of the player.
+	DropFunction func(session *net.MinecraftSession, position blocks.Position, drops []*items.Stack)
+	// BreakTimeTolerance is the fraction of the time it takes to break a block that must have passed
+	// since a player in survival started breaking it, to allow for latency.
+	// Blocks broken faster are not broken, and their chunk is sent to the player again.
+	BreakTimeTolerance float64
+	// Reach is the maximum distance between a player and the center of a block it places.
+	// Blocks further away are not placed.
+	Reach float64
+
+	mutex          sync.RWMutex
+	sessionManager *net.SessionManager
+	eventManager   *events.Manager
+	breaking       map[string]breakState
+}
+
+// DefaultBreakTimeTolerance is the default fraction of the break time of a block that must have passed to break it.
+const DefaultBreakTimeTolerance = 0.8
+
+// DefaultReach is the default maximum distance between a player and the center of a block it places.
+const DefaultReach = 8
+
+// breakState is the block a player is breaking, and the time the player started breaking it.
+type breakState struct {
+	position blocks.Position
+	started  time.Time
+}
+
+// NewManager returns a new building manager.
+func NewManager(sessionManager *net.SessionManager, eventManager *events.Manager) *Manager {
+	return &Manager{
+		ChangeFunction: func(*worlds.Dimension, *chunks.Chunk, blocks.Position) {},
+		DropFunction: func(session *net.MinecraftSession, position blocks.Position, drops []*items.Stack) {
+			for _, drop := range drops {
+				session.GetPlayer().GetInventory().AddItem(drop)
+			}
+		},
+		BreakTimeTolerance: DefaultBreakTimeTolerance,
+		Reach:              DefaultReach,
+		sessionManager:     sessionManager,
+		eventManager:       eventManager,
+		breaking:           make(map[string]breakState),
+	}
+}
+
+// IsValidFace checks if the face is one of the six faces of a block.
+func IsValidFace(face int32) bool {
+	return face >= FaceDown && face <= FaceEast
+}
+
+// GetSide returns the position of the block next to the position at the given face.
+// The position is returned unchanged if the face is not valid.
+func GetSide(position blocks.Position, face int32) blocks.Position {
+	switch face {
+	case FaceDown:
+		position.Y--
+	case FaceUp:
+		position.Y++
+	case FaceNorth:
+		position.Z--
+	case FaceSouth:
+		position.Z++
+	case FaceWest:
+		position.X--
+	case FaceEast:
+		position.X++
+	}
+	return position
+}
+
+// GetBlock returns the block placed by the item stack, and the runtime ID of the block.
+// A bool is returned indicating if the stack could be placed as block.
+func GetBlock(stack *items.Stack) (*blocks.Block, uint32, bool) {
+	if stack == nil || stack.Count <= 0 {
+		return nil, 0, false
+	}
+	var key, ok = items.TypeToId[fmt.Sprint(stack.Type)]
+	if !ok {
+		return nil, 0, false
+	}
+	var id, data = items.FromKey(key)
+	if id <= 0 || id > 255 {
+		return nil, 0, false
+	}
+	runtimeId, ok := blocks.GetRuntimeId(id, data)
+	if !ok {
+		return nil, 0, false
+	}
+	return blocks.New(blocks.NewBlockState(stack.GetId(), int32(runtimeId), id, data)), runtimeId, true
+}
+
+// StartBreak marks the session as breaking the block at the given position.
+func (manager *Manager) StartBreak(session *net.MinecraftSession, position blocks.Position) {
+	manager.mutex.Lock()
+	manager.breaking[session.GetName()] = breakState{position, time.Now()}
+	manager.mutex.Unlock()
+}
+
+// AbortBreak marks the session as no longer breaking a block.
+func (manager *Manager) AbortBreak(session *net.MinecraftSession) {
+	manager.mutex.Lock()
+	delete(manager.breaking, session.GetName())
+	manager.mutex.Unlock()
+}
+
+// GetBreaking returns the position of the block the player with the given name is breaking.
+// A bool is returned indicating if the player was breaking a block.
+func (manager *Manager) GetBreaking(name string) (blocks.Position, bool) {
+	manager.mutex.RLock()
+	defer manager.mutex.RUnlock()
+	var state, ok = manager.breaking[name]
+	return state.position, ok
+}
+
+// GetBreakConditions returns the circumstances under which the session breaks blocks with the given item.
+// Aqua affinity is not taken into account, as players do not have armor.
+func (manager *Manager) GetBreakConditions(session *net.MinecraftSession, item *items.Stack) levels.BreakConditions {
+	var player = session.GetPlayer()
+	var conditions = levels.BreakConditions{
+		Haste:         player.GetEffectLevel(players.EffectHaste),
+		MiningFatigue: player.GetEffectLevel(players.EffectMiningFatigue),
+		Airborne:      !player.OnGround,
+	}
+	if item != nil {
+		conditions.Efficiency = item.GetEnchantmentLevel(enchantments.Efficiency)
+	}
+	if dimension := player.GetDimension(); dimension != nil {
+		var head = blocks.NewPosition(int32(math.Floor(player.Position.X)), int32(math.Floor(player.Position.Y)), int32(math.Floor(player.Position.Z)))
+		var id, _, _ = levels.GetBlockAt(dimension, int(head.X), int(head.Y), int(head.Z))
+		conditions.Underwater = id == 8 || id == 9
+	}
+	return conditions
+}
+
+// GetDrops returns the items dropped by the block with the given ID and data,
+// which is the item of the block if it has one.
+func GetDrops(id, data byte) []*items.Stack {
+	if id == 0 {
+		return nil
+	}
+	var t, ok = items.IdToType[items.GetKey(int16(id), int16(data))]
+	if !ok {
+		if t, ok = items.IdToType[items.GetKey(int16(id), 0)]; !ok {
+			return nil
+		}
+	}
+	if stack, ok := items.DefaultManager.Get(t.GetId(), 1); ok {
+		return []*items.Stack{stack}
+	}
+	return nil
+}
+
+// Break breaks the block at the given position for the session, after calling a break event.
+// Players in survival must have started breaking the block long enough ago to break it with the item they hold,
+// and only get the drops of the block if they used a tool of the required tier.
+// The chunk gets sent to the session again if the block broke too fast or the event was cancelled.
+// Fake blocks of the session can not be broken, and are sent to the session again.
+// Blocks in chunks that are not loaded can not be broken.
+// A bool is returned indicating if the block was broken.
+func (manager *Manager) Break(session *net.MinecraftSession, position blocks.Position) bool {
+	manager.mutex.RLock()
+	var state, breaking = manager.breaking[session.GetName()]
+	manager.mutex.RUnlock()
+	manager.AbortBreak(session)
+	var dimension = session.GetPlayer().GetDimension()
+	if dimension == nil {
+		return false
+	}
+	if runtimeId, ok := session.GetFakeBlock(position); ok {
+		session.SetFakeBlock(position, runtimeId)
+		return false
+	}
+	var id, data, loaded = levels.GetBlockAt(dimension, int(position.X), int(position.Y), int(position.Z))
+	if !loaded {
+		return false
+	}
+	var harvested = true
+	if session.IsSurvival() {
+		var item, identifier = session.GetPlayer().GetHeldItem(), ""
+		if item != nil {
+			identifier = item.GetId()
+		}
+		var duration, ok = levels.GetBreakTime(id, identifier, manager.GetBreakConditions(session, item))
+		var tooFast = duration > 0 && (!breaking || state.position != position || time.Since(state.started) < time.Duration(float64(duration)*manager.BreakTimeTolerance))
+		if !ok || tooFast {
+			manager.resendChunk(dimension, session, position)
+			return false
+		}
+		harvested = levels.CanHarvest(id, identifier)
+	}
+	var event = &BreakEvent{Session: session, Position: position, Harvested: harvested}
+	if harvested {
+		event.Drops = GetDrops(id, data)
+	}
+	if !manager.eventManager.Call(event) {
+		manager.resendChunk(dimension, session, position)
+		return false
+	}
+	var runtimeId, _ = blocks.GetRuntimeId(0, 0)
+	manager.setBlock(dimension, position, blocks.New(blocks.NewBlockState("minecraft:air", int32(runtimeId), 0, 0)), runtimeId)
+	if session.IsSurvival() && len(event.Drops) != 0 {
+		manager.DropFunction(session, position, event.Drops)
+	}
+	return true
+}
+
+// Place places the block of the item stack against the given face of the clicked block,
+// after calling a place event. The stack is taken from the given inventory slot,
+// and gets used up by one if the session is in survival, but the inventory is not sent.
+// Blocks can not be placed at the position of a fake block of the session, out of reach of the session,
+// in chunks that are not loaded or at the position of a block that is not replaceable.
+// A bool is returned indicating if the block was placed.
+func (manager *Manager) Place(session *net.MinecraftSession, clicked blocks.Position, face int32, stack *items.Stack, slot int) bool {
+	var dimension = session.GetPlayer().GetDimension()
+	var block, runtimeId, ok = GetBlock(stack)
+	if dimension == nil || !ok || !IsValidFace(face) {
+		return false
+	}
+	var position = GetSide(clicked, face)
+	if runtimeId, ok := session.GetFakeBlock(position); ok {
+		session.SetFakeBlock(position, runtimeId)
+		return false
+	}
+	var id, data, loaded = levels.GetBlockAt(dimension, int(position.X), int(position.Y), int(position.Z))
+	if !loaded {
+		return false
+	}
+	var center = r3.Vector{X: float64(position.X) + 0.5, Y: float64(position.Y) + 0.5, Z: float64(position.Z) + 0.5}
+	if !levels.IsReplaceable(id) || session.GetPlayer().Position.Sub(center).Norm() > manager.Reach {
+		var previous, _ = blocks.GetRuntimeId(id, data)
+		session.SendUpdateBlock(position, previous, bedrock.DataLayerNormal)
+		return false
+	}
+	if !manager.eventManager.Call(&PlaceEvent{Session: session, Position: position, Item: stack}) {
+		var airRuntimeId, _ = blocks.GetRuntimeId(0, 0)
+		session.SendUpdateBlock(position, airRuntimeId, bedrock.DataLayerNormal)
+		return false
+	}
+	manager.setBlock(dimension, position, block, runtimeId)
+
+	if session.IsSurvival() {
+		stack.Count--
+		if stack.Count <= 0 {
+			session.GetPlayer().GetInventory().ClearSlot(slot)
+		} else {
+			session.GetPlayer().GetInventory().SetItem(stack, slot)
+		}
+	}
+	return true
+}
+
+// GetViewers returns all sessions in the dimension that have the chunk loaded.
+func (manager *Manager) GetViewers(dimension *worlds.Dimension, chunk *chunks.Chunk) []*net.MinecraftSession {
+	var viewers []*net.MinecraftSession
+	for _, session := range manager.sessionManager.GetSessions() {
+		if session.GetPlayer() == nil || session.GetPlayer().GetDimension() != dimension {
+			continue
+		}
+		for _, loaded := range session.GetChunkLoader().GetLoadedChunks() {
+			if loaded.X == chunk.X && loaded.Z == chunk.Z {
+				viewers = append(viewers, session)
+				break
+			}
+		}
+	}
+	return viewers
+}
+
+// Leave removes the breaking state of the session.
+func (manager *Manager) Leave(session *net.MinecraftSession) {
+	manager.AbortBreak(session)
+}
+
+// resendChunk sends the chunk of the position to the session again, reverting the changes the client made to it.
+func (manager *Manager) resendChunk(dimension *worlds.Dimension, session *net.MinecraftSession, position blocks.Position) {
+	dimension.LoadChunk(position.X>>4, position.Z>>4, func(chunk *chunks.Chunk) {
+		session.SendFullChunkData(chunk)
+	})
+}
+
+// setBlock sets the block at the position in the dimension,
+// and broadcasts the change to all viewers of the chunk.
+func (manager *Manager) setBlock(dimension *worlds.Dimension, position blocks.Position, block *blocks.Block, runtimeId uint32) {
+	dimension.SetBlockAt(utils.PositionToVector(position), block)
+	dimension.LoadChunk(position.X>>4, position.Z>>4, func(chunk *chunks.Chunk) {
+		manager.ChangeFunction(dimension, chunk, position)
+		for _, viewer := range manager.GetViewers(dimension, chunk) {
+			viewer.SendUpdateBlock(position, runtimeId, bedrock.DataLayerNormal)
+		}
+	})
+}
@@ -0,0 +1,57 @@
+package building
+
+import (
+	"testing"
+
+	"github.com/irmine/gomine/items"
+	"github.com/irmine/worlds/blocks"
+)
+
+func TestGetSide(t *testing.T) {
+	var position = blocks.NewPosition(4, 10, -4)
+	if side := GetSide(position, FaceUp); side != blocks.NewPosition(4, 11, -4) {
+		t.Error("unexpected side above block:", side)
+	}
+	if side := GetSide(position, FaceWest); side != blocks.NewPosition(3, 10, -4) {
+		t.Error("unexpected west side of block:", side)
+	}
+	if side := GetSide(position, FaceSouth); side != blocks.NewPosition(4, 10, -3) {
+		t.Error("unexpected south side of block:", side)
+	}
+}
+
+func TestGetBlock(t *testing.T) {
+	var stone, _ = items.DefaultManager.Get("minecraft:stone", 1)
+	if _, _, ok := GetBlock(stone); !ok {
+		t.Error("stone could not be placed as block")
+	}
+	var redstone, _ = items.DefaultManager.Get("minecraft:redstone", 1)
+	if _, _, ok := GetBlock(redstone); ok {
+		t.Error("redstone item was placed as block")
+	}
+	stone.Count = 0
+	if _, _, ok := GetBlock(stone); ok {
+		t.Error("empty stack was placed as block")
+	}
+}
+
+func TestGetDrops(t *testing.T) {
+	if drops := GetDrops(1, 0); len(drops) != 1 || drops[0].GetId() != "minecraft:stone" {
+		t.Error("expected stone to drop itself, got", drops)
+	}
+	if drops := GetDrops(0, 0); len(drops) != 0 {
+		t.Error("air had drops:", drops)
+	}
+}
+
+func TestIsValidFace(t *testing.T) {
+	if !IsValidFace(FaceDown) || !IsValidFace(FaceEast) {
+		t.Error("block face was not valid")
+	}
+	if IsValidFace(-1) || IsValidFace(FaceEast+1) {
+		t.Error("unknown face was valid")
+	}
+	if side := GetSide(blocks.NewPosition(1, 2, 3), 255); side != blocks.NewPosition(1, 2, 3) {
+		t.Error("unknown face changed the position:", side)
+	}
+}
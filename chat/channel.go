@@ -0,0 +1,69 @@
+package chat
+
+import (
+	"github.com/irmine/gomine/net"
+)
+
+// Channel is a chat channel players can talk in.
+// Channels decide which players receive a chat message.
+type Channel interface {
+	// GetName returns the name of the channel.
+	GetName() string
+	// GetReceivers returns all sessions receiving a chat message of the sender.
+	GetReceivers(sender *net.MinecraftSession) []*net.MinecraftSession
+}
+
+// GlobalChannel is a channel in which messages are sent to all players on the server.
+type GlobalChannel struct {
+	sessionManager *net.SessionManager
+}
+
+// NewGlobalChannel returns a new global channel sending to all sessions of the session manager.
+func NewGlobalChannel(sessionManager *net.SessionManager) *GlobalChannel {
+	return &GlobalChannel{sessionManager}
+}
+
+// GetName returns the name of the channel.
+func (channel *GlobalChannel) GetName() string {
+	return "global"
+}
+
+// GetReceivers returns all sessions on the server.
+func (channel *GlobalChannel) GetReceivers(sender *net.MinecraftSession) []*net.MinecraftSession {
+	var receivers []*net.MinecraftSession
+	for _, session := range channel.sessionManager.GetSessions() {
+		receivers = append(receivers, session)
+	}
+	return receivers
+}
+
+// WorldChannel is a channel in which messages are sent
+// to all players in the same level as the sender.
+type WorldChannel struct {
+	sessionManager *net.SessionManager
+}
+
+// NewWorldChannel returns a new world channel sending to sessions of the session manager.
+func NewWorldChannel(sessionManager *net.SessionManager) *WorldChannel {
+	return &WorldChannel{sessionManager}
+}
+
+// GetName returns the name of the channel.
+func (channel *WorldChannel) GetName() string {
+	return "world"
+}
+
+// GetReceivers returns all sessions in the same level as the sender.
+func (channel *WorldChannel) GetReceivers(sender *net.MinecraftSession) []*net.MinecraftSession {
+	var dimension = sender.GetPlayer().GetDimension()
+	if dimension == nil {
+		return []*net.MinecraftSession{sender}
+	}
+	var receivers []*net.MinecraftSession
+	for _, session := range channel.sessionManager.GetSessions() {
+		if other := session.GetPlayer().GetDimension(); other != nil && other.GetLevel() == dimension.GetLevel() {
+			receivers = append(receivers, session)
+		}
+	}
+	return receivers
+}
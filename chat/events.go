@@ -0,0 +1,28 @@
+package chat
+
+import (
+	"github.com/irmine/gomine/events"
+	"github.com/irmine/gomine/net"
+)
+
+const ReceiveEventName events.Name = "PlayerChatReceiveEvent"
+
+// ReceiveEvent gets called for every receiver of a chat message, before the message is sent to it.
+// The format can be changed to show the message differently to the receiver,
+// for example to show the real name of a nicked sender to staff.
+// Cancelling the event hides the message from the receiver.
+type ReceiveEvent struct {
+	events.Cancellable
+	Sender   *net.MinecraftSession
+	Receiver *net.MinecraftSession
+	Channel  Channel
+	// Message is the formatted text of the message, without the chat format applied.
+	Message string
+	// Format is the chat format applied to the message, which is the format of the manager by default.
+	Format string
+}
+
+// GetName returns the name of the event.
+func (event *ReceiveEvent) GetName() events.Name {
+	return ReceiveEventName
+}
@@ -0,0 +1,249 @@
+package chat
+
+import (
+	"errors"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/irmine/gomine/events"
+	"github.com/irmine/gomine/net"
+	"github.com/irmine/gomine/net/packets/data"
+	"github.com/irmine/gomine/net/packets/types"
+	"github.com/irmine/gomine/players"
+	"github.com/irmine/gomine/text"
+)
+
+// DefaultFormat is the chat format used if no format has been set.
+const DefaultFormat = "{prefix}<{name}> {message}"
+
+// FormattingPermission is the permission players need to use formatting codes in their messages.
+// Formatting codes are stripped from messages of players without it.
+const FormattingPermission = "gomine.chat.format"
+
+var (
+	// UnknownChannel gets returned when a channel
+	// with a given name could not be found.
+	UnknownChannel = errors.New("unknown chat channel")
+	// Muted gets returned when a muted player tries to chat.
+	Muted = errors.New("you are muted")
+)
+
+// Manager manages the chat of the server.
+// It keeps track of the chat channel every player talks in,
+// formats chat messages and mutes players.
+type Manager struct {
+	// Format is the format of chat messages. The following placeholders are replaced:
+	// {name}: The display name of the sender.
+	// {username}: The username of the sender.
+	// {group}: The name of the permission group of the sender.
+	// {prefix}: The chat prefix of the permission group of the sender.
+	// {channel}: The name of the channel the message was sent in.
+	// {message}: The message itself.
+	Format string
+	// ColorCodes specifies if &-style formatting codes in messages get translated to § codes,
+	// for players with the formatting permission.
+	ColorCodes bool
+	// Shortcodes is a shortcode name => replacement map of shortcodes expanded in messages,
+	// such as heart => ❤ for :heart:. Replacements should be safe for the Bedrock font.
+	Shortcodes map[string]string
+	// SendFunction gets called with the channel and the formatted message
+	// every time a chat message has been sent to the receivers of a channel.
+	SendFunction func(channel Channel, sender *net.MinecraftSession, formatted string)
+	// FilterFunction gets called for every receiver of a chat message in a channel, before the receive event.
+	// Receivers for which it returns false do not receive the message. All receivers receive messages by default.
+	FilterFunction func(sender *net.MinecraftSession, receiver *net.MinecraftSession) bool
+
+	mutex          sync.RWMutex
+	sessionManager *net.SessionManager
+	storage        players.DataStorage
+	eventManager   *events.Manager
+	defaultChannel Channel
+	channels       map[string]Channel
+	selected       map[string]Channel
+}
+
+// NewManager returns a new chat manager with the global and world channels registered.
+// Mutes of offline players are loaded from and saved to the data storage.
+// A receive event is called on the event manager for every receiver of a chat message.
+func NewManager(sessionManager *net.SessionManager, storage players.DataStorage, eventManager *events.Manager, format string) *Manager {
+	if format == "" {
+		format = DefaultFormat
+	}
+	var global = NewGlobalChannel(sessionManager)
+	var manager = &Manager{Format: format, SendFunction: func(Channel, *net.MinecraftSession, string) {}, FilterFunction: func(*net.MinecraftSession, *net.MinecraftSession) bool { return true }, sessionManager: sessionManager, storage: storage, eventManager: eventManager, defaultChannel: global, channels: make(map[string]Channel), selected: make(map[string]Channel)}
+	manager.RegisterChannel(global)
+	manager.RegisterChannel(NewWorldChannel(sessionManager))
+	return manager
+}
+
+// RegisterChannel registers a new channel players can talk in.
+// Channels with the same name get overwritten.
+func (manager *Manager) RegisterChannel(channel Channel) {
+	manager.mutex.Lock()
+	manager.channels[channel.GetName()] = channel
+	manager.mutex.Unlock()
+}
+
+// GetChannel returns a channel by its name.
+// A bool is returned indicating if the channel was found.
+func (manager *Manager) GetChannel(name string) (Channel, bool) {
+	manager.mutex.RLock()
+	defer manager.mutex.RUnlock()
+	var channel, ok = manager.channels[name]
+	return channel, ok
+}
+
+// GetChannels returns a name => channel map of all channels.
+func (manager *Manager) GetChannels() map[string]Channel {
+	manager.mutex.RLock()
+	defer manager.mutex.RUnlock()
+	var channels = make(map[string]Channel, len(manager.channels))
+	for name, channel := range manager.channels {
+		channels[name] = channel
+	}
+	return channels
+}
+
+// SetChannel sets the channel the player with the given name talks in.
+// UnknownChannel gets returned if no channel with the name was registered.
+func (manager *Manager) SetChannel(name string, channelName string) error {
+	var channel, ok = manager.GetChannel(channelName)
+	if !ok {
+		return UnknownChannel
+	}
+	manager.mutex.Lock()
+	manager.selected[name] = channel
+	manager.mutex.Unlock()
+	return nil
+}
+
+// GetSelectedChannel returns the channel the player with the given name talks in.
+// Players talk in the global channel by default.
+func (manager *Manager) GetSelectedChannel(name string) Channel {
+	manager.mutex.RLock()
+	defer manager.mutex.RUnlock()
+	if channel, ok := manager.selected[name]; ok {
+		return channel
+	}
+	return manager.defaultChannel
+}
+
+// Remove removes the selected channel of the player with the given name.
+func (manager *Manager) Remove(name string) {
+	manager.mutex.Lock()
+	delete(manager.selected, name)
+	manager.mutex.Unlock()
+}
+
+// FormatMessage formats a chat message of the sender in the given channel.
+func (manager *Manager) FormatMessage(sender *net.MinecraftSession, channel Channel, message string) string {
+	return manager.formatMessage(manager.Format, sender, channel, message)
+}
+
+// formatMessage formats a chat message of the sender in the given channel using the format.
+func (manager *Manager) formatMessage(format string, sender *net.MinecraftSession, channel Channel, message string) string {
+	var group, prefix = "", ""
+	if sender.GetPermissionGroup() != nil {
+		group, prefix = sender.GetPermissionGroup().GetName(), sender.GetPermissionGroup().GetPrefix()
+	}
+	return strings.NewReplacer(
+		"{name}", sender.GetDisplayName(),
+		"{username}", sender.GetName(),
+		"{group}", group,
+		"{prefix}", prefix,
+		"{channel}", channel.GetName(),
+		"{message}", message,
+	).Replace(format)
+}
+
+// FormatText formats the text of a message of the sender.
+// Formatting codes are translated if the sender has the formatting permission,
+// and stripped otherwise, after which shortcodes are expanded.
+func (manager *Manager) FormatText(sender *net.MinecraftSession, message string) string {
+	if sender.HasPermission(FormattingPermission) {
+		if manager.ColorCodes {
+			message = text.TranslateColorCodes(message)
+		}
+	} else {
+		message = text.StripFormatting(message)
+	}
+	return text.ExpandShortcodes(message, manager.Shortcodes)
+}
+
+// SendChat sends a chat message of the sender to all receivers
+// of the channel the sender talks in. Muted gets returned if the sender is muted.
+func (manager *Manager) SendChat(sender *net.MinecraftSession, message string) error {
+	if sender.GetPlayer().GetData().IsMuted() {
+		return Muted
+	}
+	var channel = manager.GetSelectedChannel(sender.GetName())
+	message = manager.FormatText(sender, message)
+	var formatted = manager.FormatMessage(sender, channel, message)
+	for _, receiver := range channel.GetReceivers(sender) {
+		if !manager.FilterFunction(sender, receiver) {
+			continue
+		}
+		var event = &ReceiveEvent{Sender: sender, Receiver: receiver, Channel: channel, Message: message, Format: manager.Format}
+		if !manager.eventManager.Call(event) {
+			continue
+		}
+		var received = formatted
+		if event.Format != manager.Format || event.Message != message {
+			received = manager.formatMessage(event.Format, sender, channel, event.Message)
+		}
+		receiver.SendText(types.Text{
+			Message:    received,
+			SourceXUID: sender.GetXUID(),
+			TextType:   data.TextChat,
+		})
+	}
+	text.DefaultLogger.LogChat("[" + channel.GetName() + "] " + formatted)
+	manager.SendFunction(channel, sender, formatted)
+	return nil
+}
+
+// SendPrivate sends a private message of the sender to the receiver.
+// Muted gets returned if the sender is muted.
+func (manager *Manager) SendPrivate(sender *net.MinecraftSession, receiver *net.MinecraftSession, message string) error {
+	if sender.GetPlayer().GetData().IsMuted() {
+		return Muted
+	}
+	message = manager.FormatText(sender, message)
+	receiver.SendTranslation(text.Gray+"%commands.message.display.incoming", sender.GetDisplayName(), message)
+	sender.SendTranslation(text.Gray+"%commands.message.display.outgoing", receiver.GetDisplayName(), message)
+	text.DefaultLogger.LogChat("[" + sender.GetName() + " -> " + receiver.GetName() + "] " + message)
+	return nil
+}
+
+// Mute mutes the player with the given name for the given duration with a reason.
+// A duration of 0 or lower mutes the player permanently.
+// Offline players are muted by modifying their stored data.
+func (manager *Manager) Mute(name string, duration time.Duration, reason string) error {
+	return manager.modifyData(name, func(data *players.Data) {
+		data.Mute(duration, reason)
+	})
+}
+
+// Unmute unmutes the player with the given name.
+func (manager *Manager) Unmute(name string) error {
+	return manager.modifyData(name, func(data *players.Data) {
+		data.Unmute()
+	})
+}
+
+// modifyData modifies the data of the player with the given name and saves it.
+// The data of online players is modified directly, and loaded from the storage otherwise.
+func (manager *Manager) modifyData(name string, f func(data *players.Data)) error {
+	var playerData *players.Data
+	if session, ok := manager.sessionManager.GetSession(name); ok {
+		playerData = session.GetPlayer().GetData()
+	} else {
+		var err error
+		if playerData, err = manager.storage.Load(name); err != nil {
+			return err
+		}
+	}
+	f(playerData)
+	return manager.storage.Save(playerData)
+}
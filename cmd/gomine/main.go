@@ -1,6 +1,7 @@
 package main
 
 import (
+	"flag"
 	"github.com/irmine/gomine"
 	"github.com/irmine/gomine/resources"
 	"github.com/irmine/gomine/text"
@@ -10,28 +11,37 @@ import (
 	"time"
 )
 
+var (
+	headless = flag.Bool("headless", false, "run without networking, ticking as fast as possible")
+	ticks    = flag.Int("ticks", 0, "amount of ticks to run before shutting down, or 0 to run until shutdown")
+)
+
 func main() {
+	flag.Parse()
 	startTime := time.Now()
 	path, err := GetServerPath()
 	must(err)
 	SetUpDirectories(path)
+	if flag.NArg() != 0 {
+		os.Exit(runTool(path, flag.Args()))
+	}
 
 	config := resources.NewGoMineConfig(path)
+	if *headless {
+		config.Headless = true
+	}
 	server := gomine.NewServer(path, config)
 
 	must(server.Start())
 	text.DefaultLogger.Info("Server startup done! Took:", time.Now().Sub(startTime))
 
-	var ticker = time.NewTicker(time.Millisecond * 50)
-	for {
-		select{
-		case <- ticker.C:
-			if !server.IsRunning() {
-				break
-			}
-			server.Tick()
-		}
+	if *ticks > 0 {
+		// A fixed amount of ticks is fast-forwarded, so that offline tooling finishes as soon as possible.
+		server.FastForward(*ticks)
+		server.Shutdown()
+		return
 	}
+	server.Run()
 }
 
 func must(err error) {
@@ -49,7 +59,7 @@ func GetServerPath() (string, error) {
 // SetUpDirectories sets up all directories needed for GoMine.
 func SetUpDirectories(path string) {
 	os.Mkdir(path+"extensions", 0700)
-	os.Mkdir(path+"extensions/plugins", 0700)
+	os.Mkdir(path+"plugins", 0700)
 	os.Mkdir(path+"extensions/behavior_packs", 0700)
 	os.Mkdir(path+"extensions/resource_packs", 0700)
 }
@@ -33,10 +33,5 @@ func StartServer(port uint16) {
 	}
 	text.DefaultLogger.Info("Server startup done! Took:", time.Now().Sub(startTime))
 
-	for range time.NewTicker(time.Second / 20).C {
-		if !server.IsRunning() {
-			break
-		}
-		server.Tick()
-	}
+	server.Run()
 }
@@ -0,0 +1,214 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/signal"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/irmine/gomine"
+	"github.com/irmine/gomine/levels"
+	"github.com/irmine/gomine/migrate"
+	"github.com/irmine/gomine/permissions"
+	"github.com/irmine/gomine/players"
+	"github.com/irmine/gomine/resources"
+	"github.com/irmine/gomine/sdk"
+	"github.com/irmine/gomine/webmap"
+)
+
+// toolUsage is printed when a tool is run with invalid arguments.
+const toolUsage = `Usage:
+  gomine world info [world]                  Shows the level data and disk usage of a world.
+  gomine world pregen [radius]               Generates all chunks within the radius in chunks around the spawn of the default world.
+  gomine world convert <world> <format>      Converts an Anvil world to another format.
+  gomine world render [world]                Renders all saved chunks of an Anvil world to the web map.
+  gomine player export <name> [file]         Exports the data of a player as JSON.
+  gomine import <pocketmine|nukkit> <path>   Imports the configuration, operators, bans, whitelist and PurePerms data of another server.
+  gomine plugin init <name> [directory]      Scaffolds an example plugin project in the directory, named after the plugin by default.`
+
+// runTool runs the tool with the given arguments, such as "world info world",
+// without starting the network server. The exit code of the tool is returned.
+func runTool(path string, args []string) int {
+	var err error
+	switch {
+	case len(args) >= 2 && args[0] == "world" && args[1] == "info":
+		err = worldInfo(path, optionalArgument(args, 2, "world"))
+	case len(args) >= 2 && args[0] == "world" && args[1] == "pregen":
+		var radius, parseErr = strconv.Atoi(optionalArgument(args, 2, "8"))
+		if parseErr != nil || radius < 0 {
+			fmt.Println(toolUsage)
+			return 2
+		}
+		err = pregenerate(path, int32(radius))
+	case len(args) == 4 && args[0] == "world" && args[1] == "convert":
+		err = convert(path, args[2], args[3])
+	case len(args) >= 2 && args[0] == "world" && args[1] == "render":
+		err = render(path, optionalArgument(args, 2, "world"))
+	case len(args) >= 3 && args[0] == "player" && args[1] == "export":
+		err = exportPlayer(path, args[2], optionalArgument(args, 3, ""))
+	case len(args) == 3 && args[0] == "import":
+		err = importServer(path, migrate.Source(strings.ToLower(args[1])), args[2])
+	case len(args) >= 3 && args[0] == "plugin" && args[1] == "init":
+		var directory = optionalArgument(args, 3, args[2])
+		if err = sdk.Scaffold(directory, args[2]); err == nil {
+			fmt.Println("Scaffolded plugin", args[2], "in", directory+". See README.md in the directory for how to build it.")
+		}
+	default:
+		fmt.Println(toolUsage)
+		return 2
+	}
+	if err != nil {
+		fmt.Println("Error:", err)
+		return 1
+	}
+	return 0
+}
+
+// optionalArgument returns the argument at the index, or the default value if there are not enough arguments.
+func optionalArgument(args []string, index int, defaultValue string) string {
+	if len(args) <= index {
+		return defaultValue
+	}
+	return args[index]
+}
+
+// worldInfo prints the level data and disk usage of the world with the given name.
+func worldInfo(path string, levelName string) error {
+	var levelPath = path + "worlds/" + levelName + "/"
+	var data, err = levels.ReadData(levelPath + "level.dat")
+	if err != nil {
+		return err
+	}
+	stats, err := levels.ReadStorageStats(levelPath)
+	if err != nil {
+		return err
+	}
+	fmt.Println("World:", data.Name)
+	fmt.Println("Generator:", levels.GetGeneratorName(data.Generator), data.GeneratorOptions)
+	fmt.Println("Seed:", data.Seed)
+	fmt.Println("Spawn:", data.SpawnX, data.SpawnY, data.SpawnZ)
+	fmt.Println("Time:", data.Time, "Age:", data.CurrentTick)
+	fmt.Println("Size:", stats.Size, "bytes,", stats.Regions, "regions,", stats.Chunks, "chunks")
+	var dimensions = make([]string, 0, len(stats.Dimensions))
+	for name := range stats.Dimensions {
+		dimensions = append(dimensions, name)
+	}
+	sort.Strings(dimensions)
+	for _, name := range dimensions {
+		var dimension = stats.Dimensions[name]
+		fmt.Println("  "+name+":", dimension.Size, "bytes,", dimension.Regions, "regions,", dimension.Chunks, "chunks")
+	}
+	return nil
+}
+
+// pregenerate generates all chunks within the radius around the spawn of the default world,
+// using a headless server so that plugins providing generators and formats are loaded.
+// Interrupting the tool stops generating, saving the chunks generated so far.
+func pregenerate(path string, radius int32) error {
+	var config = resources.NewGoMineConfig(path)
+	config.Headless = true
+	var server = gomine.NewServer(path, config)
+	if err := server.Start(); err != nil {
+		return err
+	}
+	defer server.Shutdown()
+
+	var level = server.LevelManager.GetDefaultLevel()
+	var spawn = server.LevelStorage.GetSpawn(level.GetName())
+	var chunkX, chunkZ = int32(spawn.X) >> 4, int32(spawn.Z) >> 4
+	var ctx, stop = signal.NotifyContext(server.GetContext(), os.Interrupt)
+	defer stop()
+	return server.LevelStorage.Pregenerate(ctx, level.GetDefaultDimension(), chunkX, chunkZ, radius, printProgress("Generated"))
+}
+
+// convert converts the Anvil world with the given name to the format,
+// loading plugins first so that formats registered by plugins can be used.
+// Interrupting the tool stops converting.
+func convert(path string, levelName string, format string) error {
+	var server = gomine.NewServer(path, resources.NewGoMineConfig(path))
+	server.PluginManager.LoadPlugins()
+	var ctx, stop = signal.NotifyContext(server.GetContext(), os.Interrupt)
+	defer stop()
+	if err := server.LevelStorage.Convert(ctx, levelName, format, printProgress("Converted")); err != nil {
+		return err
+	}
+	fmt.Println("Set the World Format in gomine.yml to " + format + " to use the converted world.")
+	return nil
+}
+
+// render renders all chunks saved in the Anvil world with the given name to the web map directory,
+// so that the map shows the whole world and not only the chunks players loaded since the map was enabled.
+// Interrupting the tool stops rendering, keeping the tiles rendered so far.
+func render(path string, levelName string) error {
+	var config = resources.NewGoMineConfig(path)
+	var storage = levels.NewManager(path)
+	var renderer = webmap.NewRenderer(path + config.WebMapDirectory)
+	var ctx, stop = signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	var err = storage.SnapshotStored(ctx, levelName, func(dimensionName string, snapshots []*levels.Snapshot) error {
+		return renderer.Render(levelName+"/"+dimensionName, snapshots)
+	}, printProgress("Rendered"))
+	if err != nil {
+		return err
+	}
+	fmt.Println("Rendered the map of " + levelName + " to " + path + config.WebMapDirectory + ".")
+	return nil
+}
+
+// exportPlayer writes the stored data of the player with the given name as JSON to the file,
+// or prints it if the file is empty.
+func exportPlayer(path string, name string, file string) error {
+	var data, err = players.NewFileDataStorage(path + "players/").Load(name)
+	if err != nil {
+		return err
+	}
+	content, err := json.MarshalIndent(data, "", "  ")
+	if err != nil {
+		return err
+	}
+	if file == "" {
+		_, err = os.Stdout.Write(append(content, '\n'))
+		return err
+	}
+	return ioutil.WriteFile(file, content, 0644)
+}
+
+// importServer imports the data of the PocketMine or Nukkit server in the directory
+// into the GoMine server, saving the changed configuration.
+func importServer(path string, source migrate.Source, from string) error {
+	var config = resources.NewGoMineConfig(path)
+	var permissionManager = permissions.NewManager()
+	permissionManager.SetStore(permissions.NewFileStore(path+"groups.yml", path+"players.yml"))
+	if err := permissionManager.Load(); err != nil {
+		return err
+	}
+	var report, err = migrate.NewImporter(config, permissionManager, players.NewFileDataStorage(path+"players/")).Import(source, from)
+	if err != nil {
+		return err
+	}
+	if err := config.Save(path); err != nil {
+		return err
+	}
+	fmt.Println("Imported", report.Properties, "properties,", report.Operators, "operators,", report.Whitelisted, "whitelisted players and", report.Bans, "bans.")
+	fmt.Println("Imported", report.Groups, "groups and the permissions of", report.Players, "players.")
+	for _, skipped := range report.Skipped {
+		fmt.Println("Skipped", skipped)
+	}
+	return nil
+}
+
+// printProgress returns a progress function printing the amount of chunks done every 10 percent.
+func printProgress(action string) func(done, total int) {
+	var printed = -1
+	return func(done, total int) {
+		if percent := done * 10 / total; percent != printed {
+			printed = percent
+			fmt.Println(action, done, "of", total, "chunks")
+		}
+	}
+}
@@ -0,0 +1,122 @@
+package combat
+
+import (
+	"time"
+
+	"github.com/golang/geo/r3"
+	"github.com/irmine/gomine/events"
+	"github.com/irmine/gomine/net"
+	"github.com/irmine/worlds/entities/data"
+)
+
+const (
+	DamageEventName    events.Name = "EntityDamageEvent"
+	DeathEventName     events.Name = "PlayerDeathEvent"
+	RespawnEventName   events.Name = "PlayerRespawnEvent"
+	KnockbackEventName events.Name = "EntityKnockbackEvent"
+	PreDeathEventName  events.Name = "PlayerPreDeathEvent"
+)
+
+// Causes of damage.
+const (
+	CauseCustom = iota
+	CauseAttack
+	CauseFall
+	CauseVoid
+	CauseStarvation
+)
+
+// DamageEvent gets called when a player takes damage.
+// Cancelling the event prevents the player from taking damage.
+type DamageEvent struct {
+	events.Cancellable
+	Session *net.MinecraftSession
+	// Attacker is the session attacking the player.
+	// Attacker is nil if the damage was not caused by another player.
+	Attacker *net.MinecraftSession
+	Cause    int
+	// Damage is the damage dealt, which may be changed by handlers.
+	Damage float32
+}
+
+// GetName returns the name of the event.
+func (event *DamageEvent) GetName() events.Name {
+	return DamageEventName
+}
+
+// PreDeathEvent gets called when the health of a player drops to 0, before the player dies.
+// Cancelling the event prevents the death of the player, leaving it with the given health.
+// The event is cancelled in advance if the player holds a totem of undying,
+// in which case un-cancelling the event makes the player die without using the totem.
+type PreDeathEvent struct {
+	events.Cancellable
+	Session *net.MinecraftSession
+	// Killer is the session that would kill the player, or nil if the player was not killed by another player.
+	Killer *net.MinecraftSession
+	Cause  int
+	// Health is the health the player is left with if its death is prevented, which may be changed by handlers.
+	Health float32
+	// Totem specifies if a totem held by the player gets used when its death is prevented.
+	// Totem is false if the player does not hold a totem.
+	Totem bool
+}
+
+// GetName returns the name of the event.
+func (event *PreDeathEvent) GetName() events.Name {
+	return PreDeathEventName
+}
+
+// DeathEvent gets called when a player dies.
+type DeathEvent struct {
+	Session *net.MinecraftSession
+	// Killer is the session that killed the player, or nil if the player was not killed by another player.
+	Killer *net.MinecraftSession
+	Cause  int
+	// Message is the death message broadcast to all players.
+	// No message is broadcast if it is empty.
+	Message string
+	// ImmediateRespawn specifies if the player respawns right away,
+	// rather than being shown the respawn screen.
+	ImmediateRespawn bool
+}
+
+// GetName returns the name of the event.
+func (event *DeathEvent) GetName() events.Name {
+	return DeathEventName
+}
+
+// RespawnEvent gets called when a player respawns after dying.
+// Cancelling the event keeps the player dead, until it is respawned again.
+type RespawnEvent struct {
+	events.Cancellable
+	Session *net.MinecraftSession
+	// Position is the position the player respawns at, which may be changed by handlers.
+	Position r3.Vector
+	// Rotation is the rotation the player respawns with, which may be changed by handlers.
+	Rotation data.Rotation
+	// Immunity is the duration the player is immune to damage after respawning,
+	// which may be changed by handlers.
+	Immunity time.Duration
+}
+
+// GetName returns the name of the event.
+func (event *RespawnEvent) GetName() events.Name {
+	return RespawnEventName
+}
+
+// KnockbackEvent gets called when an entity gets knocked back.
+// Cancelling the event prevents the entity from being knocked back.
+type KnockbackEvent struct {
+	events.Cancellable
+	Entity Mover
+	// Attacker is the session knocking the entity back.
+	// Attacker is nil if the knockback was not caused by a player.
+	Attacker *net.MinecraftSession
+	// Motion is the velocity the entity gets, which may be changed by handlers.
+	Motion r3.Vector
+}
+
+// GetName returns the name of the event.
+func (event *KnockbackEvent) GetName() events.Name {
+	return KnockbackEventName
+}
@@ -0,0 +1,113 @@
+package combat
+
+import (
+	"math/rand"
+	"strconv"
+
+	"github.com/golang/geo/r3"
+	"github.com/irmine/gomine/net"
+	"github.com/irmine/gomine/text"
+	"github.com/irmine/worlds/entities"
+	"github.com/irmine/worlds/entities/data"
+)
+
+const (
+	// armorStandType is the entity type of the invisible entities showing damage indicators.
+	armorStandType = 61
+	// entityDataFlags and entityDataNameTag are the keys of the flags and name tag in entity data.
+	entityDataFlags   = 0
+	entityDataNameTag = 4
+	// indicatorFlags are the flags of indicators, making them invisible
+	// and immobile while always showing their name tag.
+	indicatorFlags = 1<<5 | 1<<14 | 1<<15 | 1<<16
+)
+
+// indicator is a floating damage number shown above a player that took damage.
+type indicator struct {
+	*entities.Entity
+	text      string
+	ticksLeft int
+}
+
+// GetEntityData returns the entity data of the indicator,
+// with its text set as name tag.
+func (indicator *indicator) GetEntityData() map[uint32][]interface{} {
+	var entityData = make(map[uint32][]interface{})
+	for key, value := range indicator.Entity.GetEntityData() {
+		entityData[key] = value
+	}
+	entityData[entityDataFlags] = []interface{}{uint32(data.EntityDataLong), int64(indicatorFlags)}
+	entityData[entityDataNameTag] = []interface{}{uint32(data.EntityDataString), indicator.text}
+	return entityData
+}
+
+// isViewer checks if the session views the indicator.
+func (indicator *indicator) isViewer(session *net.MinecraftSession) bool {
+	for _, viewer := range indicator.GetViewers() {
+		if viewer == session {
+			return true
+		}
+	}
+	return false
+}
+
+// FormatDamage returns the text of the damage indicator for the damage dealt,
+// such as "-1.5" in red.
+func FormatDamage(damage float32) string {
+	return text.Red + "-" + strconv.FormatFloat(float64(damage), 'f', -1, 32)
+}
+
+// spawnIndicator spawns a damage indicator slightly above the head of the session,
+// showing the damage dealt to all viewers of the player and the attacker.
+// The indicator despawns after the indicator duration.
+func (manager *Manager) spawnIndicator(session *net.MinecraftSession, attacker *net.MinecraftSession, damage float32) {
+	var dimension = session.GetPlayer().GetDimension()
+	if dimension == nil {
+		return
+	}
+	var entity = &indicator{Entity: entities.New(armorStandType), text: FormatDamage(damage), ticksLeft: manager.IndicatorDuration}
+	var position = session.GetPlayer().Position.Add(r3.Vector{X: rand.Float64() - 0.5, Y: 0.5, Z: rand.Float64() - 0.5})
+	dimension.AddEntity(entity.Entity, position)
+	entity.Position = position
+
+	for _, viewer := range session.GetPlayer().GetViewers() {
+		if viewer, ok := viewer.(*net.MinecraftSession); ok && viewer != session {
+			entity.AddViewer(viewer)
+			viewer.SendAddEntity(entity)
+		}
+	}
+	if attacker != nil && !entity.isViewer(attacker) {
+		entity.AddViewer(attacker)
+		attacker.SendAddEntity(entity)
+	}
+
+	manager.mutex.Lock()
+	manager.indicators = append(manager.indicators, entity)
+	manager.mutex.Unlock()
+}
+
+// Tick despawns all damage indicators of which the duration has passed.
+// Internal. Not to be used by plugins.
+func (manager *Manager) Tick() {
+	var expired []*indicator
+	manager.mutex.Lock()
+	var indicators = manager.indicators[:0]
+	for _, indicator := range manager.indicators {
+		if indicator.ticksLeft--; indicator.ticksLeft <= 0 {
+			expired = append(expired, indicator)
+		} else {
+			indicators = append(indicators, indicator)
+		}
+	}
+	manager.indicators = indicators
+	manager.mutex.Unlock()
+
+	for _, indicator := range expired {
+		for _, viewer := range indicator.GetViewers() {
+			if viewer, ok := viewer.(*net.MinecraftSession); ok {
+				viewer.SendRemoveEntity(indicator.GetUniqueId())
+			}
+		}
+		indicator.Close()
+	}
+}
@@ -0,0 +1,292 @@
+package combat
+
+import (
+	"math"
+	"sync"
+	"time"
+
+	"github.com/golang/geo/r3"
+	"github.com/irmine/gomine/events"
+	"github.com/irmine/gomine/net"
+	"github.com/irmine/gomine/net/packets/bedrock"
+	"github.com/irmine/gomine/net/packets/data"
+	"github.com/irmine/gomine/text"
+)
+
+// Manager handles damage dealt to players, their deaths and respawning.
+// Hurt and death animations are broadcast to the player and all its viewers,
+// and damage indicators are shown above players taking damage if they are enabled.
+type Manager struct {
+	// AttackDamage is the damage dealt by players attacking other players.
+	AttackDamage float32
+	// HitImmunity is the duration players are immune to damage after taking damage.
+	HitImmunity time.Duration
+	// RespawnImmunity is the duration players are immune to damage after respawning.
+	RespawnImmunity time.Duration
+	// Settings are the PvP settings of worlds without settings of their own.
+	Settings Settings
+	// DamageIndicators specifies if floating damage numbers are shown above players taking damage.
+	DamageIndicators bool
+	// IndicatorDuration is the amount of ticks damage indicators are shown.
+	IndicatorDuration int
+	// SpawnFunction returns the position a session respawns at after dying,
+	// if no respawn location provider has a location for the session.
+	SpawnFunction func(session *net.MinecraftSession) r3.Vector
+	// SpawnPoints holds the personal spawn points of players.
+	// Spawn points take precedence over the spawn function, but not over other respawn location providers.
+	SpawnPoints *SpawnPoints
+	// Scatter scatters players respawning without spawn point randomly around the spawn.
+	// Scattered locations take precedence over the spawn function, but not over spawn points.
+	Scatter *SpawnScatter
+
+	sessionManager *net.SessionManager
+	eventManager   *events.Manager
+
+	mutex       sync.RWMutex
+	settings    map[string]Settings
+	lastAttacks map[*net.MinecraftSession]time.Time
+	indicators  []*indicator
+	providers   []namedProvider
+}
+
+// Mover is an entity of which the motion can be set, such as a session or a mob.
+type Mover interface {
+	GetPosition() r3.Vector
+	SetMotion(motion r3.Vector)
+}
+
+// NewManager returns a new combat manager,
+// which respawns players at their spawn point, or at 0, 7, 0 by default.
+// Players are immune to damage for half a second after taking damage,
+// and are not protected after respawning by default.
+func NewManager(sessionManager *net.SessionManager, eventManager *events.Manager) *Manager {
+	var manager = &Manager{
+		AttackDamage:      1,
+		HitImmunity:       time.Millisecond * 500,
+		Settings:          DefaultSettings(),
+		IndicatorDuration: 20,
+		SpawnFunction: func(*net.MinecraftSession) r3.Vector {
+			return r3.Vector{Y: 7}
+		},
+		SpawnPoints:    NewSpawnPoints(),
+		Scatter:        NewSpawnScatter(),
+		sessionManager: sessionManager,
+		eventManager:   eventManager,
+		settings:       make(map[string]Settings),
+		lastAttacks:    make(map[*net.MinecraftSession]time.Time),
+	}
+	manager.AddRespawnProvider("scatter", manager.Scatter)
+	manager.AddRespawnProvider("spawnpoints", manager.SpawnPoints)
+	return manager
+}
+
+// SetSettings sets the PvP settings of the level with the given name.
+func (manager *Manager) SetSettings(levelName string, settings Settings) {
+	manager.mutex.Lock()
+	manager.settings[levelName] = settings
+	manager.mutex.Unlock()
+}
+
+// GetSettings returns the PvP settings of the level with the given name,
+// or the default settings of the manager if the level has no settings of its own.
+func (manager *Manager) GetSettings(levelName string) Settings {
+	manager.mutex.RLock()
+	defer manager.mutex.RUnlock()
+	if settings, ok := manager.settings[levelName]; ok {
+		return settings
+	}
+	return manager.Settings
+}
+
+// Attack makes the attacker deal damage to the victim,
+// using the PvP settings of the level the victim is in.
+// Attacks within the attack cooldown of the previous attack of the attacker are ignored.
+// Attackers falling while not flying deal critical hits, and victims that took damage get knocked back.
+// A bool is returned indicating if the victim took damage.
+func (manager *Manager) Attack(attacker *net.MinecraftSession, victim *net.MinecraftSession, damage float32) bool {
+	if attacker == victim {
+		return false
+	}
+	var settings = manager.Settings
+	if dimension := victim.GetPlayer().GetDimension(); dimension != nil {
+		settings = manager.GetSettings(dimension.GetLevel().GetName())
+	}
+	manager.mutex.Lock()
+	if time.Since(manager.lastAttacks[attacker]) < settings.GetAttackCooldown() {
+		manager.mutex.Unlock()
+		return false
+	}
+	manager.lastAttacks[attacker] = time.Now()
+	manager.mutex.Unlock()
+
+	var critical = !attacker.GetPlayer().OnGround && !attacker.GetPlayer().IsFlying()
+	if critical {
+		damage *= settings.CriticalMultiplier
+	}
+	if !manager.Damage(victim, attacker, CauseAttack, damage) {
+		return false
+	}
+	if critical {
+		manager.broadcastAnimate(victim, bedrock.CriticalHit)
+	}
+	if !victim.GetPlayer().IsDead() {
+		var motion = GetKnockback(attacker.GetPosition(), victim.GetPosition(), KnockbackForce*settings.KnockbackHorizontal, KnockbackHeight*settings.KnockbackVertical)
+		manager.Knockback(victim, attacker, motion)
+	}
+	return true
+}
+
+// Leave removes the last attack and scattered respawn location of the session when it leaves the server.
+func (manager *Manager) Leave(session *net.MinecraftSession) {
+	manager.mutex.Lock()
+	delete(manager.lastAttacks, session)
+	manager.mutex.Unlock()
+	manager.Scatter.Clear(session.GetName())
+}
+
+// Knockback sets the motion of the entity after calling a knockback event.
+// The attacker may be nil if the knockback was not caused by a player.
+// A bool is returned indicating if the entity was knocked back.
+func (manager *Manager) Knockback(entity Mover, attacker *net.MinecraftSession, motion r3.Vector) bool {
+	var event = &KnockbackEvent{Entity: entity, Attacker: attacker, Motion: motion}
+	if !manager.eventManager.Call(event) {
+		return false
+	}
+	entity.SetMotion(event.Motion)
+	return true
+}
+
+// Damage deals damage to the session after calling a damage event.
+// Absorption health is taken before the health of the player,
+// and the player dies once its health drops to 0.
+// Players not in survival or adventure mode, dead players and immune players do not take damage.
+// Players that took damage are immune for the hit immunity duration.
+// A bool is returned indicating if the player took damage.
+func (manager *Manager) Damage(session *net.MinecraftSession, attacker *net.MinecraftSession, cause int, damage float32) bool {
+	var player = session.GetPlayer()
+	if !session.IsSurvival() || player.IsDead() || player.IsImmune() || damage <= 0 {
+		return false
+	}
+	var event = &DamageEvent{Session: session, Attacker: attacker, Cause: cause, Damage: damage}
+	if !manager.eventManager.Call(event) || event.Damage <= 0 {
+		return false
+	}
+	damage = event.Damage
+	if absorption := player.GetAbsorption(); absorption > 0 {
+		if damage <= absorption {
+			player.SetAbsorption(absorption - damage)
+			damage = 0
+		} else {
+			player.SetAbsorption(0)
+			damage -= absorption
+		}
+	}
+	player.SetHealth(player.GetHealth() - damage)
+	player.SetImmunity(manager.HitImmunity)
+	manager.broadcastEvent(session, data.EntityEventHurt)
+	if manager.DamageIndicators {
+		manager.spawnIndicator(session, attacker, event.Damage)
+	}
+
+	if player.IsDead() && !manager.preventDeath(session, attacker, cause) {
+		manager.kill(session, attacker, cause)
+	}
+	return true
+}
+
+// Kill kills the session, regardless of its game mode.
+func (manager *Manager) Kill(session *net.MinecraftSession) {
+	if session.GetPlayer().IsDead() {
+		return
+	}
+	session.GetPlayer().SetHealth(0)
+	manager.kill(session, nil, CauseCustom)
+}
+
+// Respawn respawns the session if it is dead after calling a respawn event,
+// resetting its attributes and teleporting it to its respawn location.
+// The session is immune to damage for the respawn immunity duration.
+// A bool is returned indicating if the session was respawned.
+func (manager *Manager) Respawn(session *net.MinecraftSession) bool {
+	var player = session.GetPlayer()
+	if !player.IsDead() {
+		return false
+	}
+	var location = manager.GetRespawnLocation(session)
+	var event = &RespawnEvent{Session: session, Position: location.Position, Rotation: player.Rotation, Immunity: manager.RespawnImmunity}
+	if location.Rotation != nil {
+		event.Rotation = *location.Rotation
+	}
+	if !manager.eventManager.Call(event) {
+		return false
+	}
+
+	player.ResetAttributes()
+	player.SetImmunity(event.Immunity)
+	session.SendUpdateAttributes(player.GetRuntimeId(), player.GetAttributeMap())
+	session.Teleport(event.Position, event.Rotation)
+	manager.broadcastEvent(session, data.EntityEventRespawn)
+	return true
+}
+
+// kill broadcasts the death of the session and calls a death event,
+// after which the respawn screen is shown to the session,
+// unless the session respawns immediately.
+func (manager *Manager) kill(session *net.MinecraftSession, killer *net.MinecraftSession, cause int) {
+	manager.broadcastEvent(session, data.EntityEventDeath)
+	manager.Scatter.Prepare(session, manager.SpawnFunction(session))
+
+	var event = &DeathEvent{Session: session, Killer: killer, Cause: cause, Message: session.GetDisplayName() + " died"}
+	if killer != nil {
+		event.Message = session.GetDisplayName() + " was slain by " + killer.GetDisplayName()
+	}
+	manager.eventManager.Call(event)
+
+	if event.Message != "" {
+		for _, online := range manager.sessionManager.GetSessions() {
+			online.SendMessage(event.Message)
+		}
+		text.DefaultLogger.Info(event.Message)
+	}
+	session.SendUpdateAttributes(session.GetPlayer().GetRuntimeId(), session.GetPlayer().GetAttributeMap())
+	if event.ImmediateRespawn && manager.Respawn(session) {
+		return
+	}
+	session.SendRespawn(manager.GetRespawnLocation(session).Position)
+}
+
+// GetKnockback returns the motion of an entity at the position knocked back away from the source,
+// with the given horizontal and vertical speed.
+// Entities at the same horizontal position as the source are only knocked up.
+func GetKnockback(source r3.Vector, position r3.Vector, force float64, height float64) r3.Vector {
+	var x, z = position.X - source.X, position.Z - source.Z
+	var distance = math.Hypot(x, z)
+	if distance == 0 {
+		return r3.Vector{Y: height}
+	}
+	return r3.Vector{X: x / distance * force, Y: height, Z: z / distance * force}
+}
+
+// broadcastAnimate sends the animation of the player of the session
+// to the session and all viewers of the player.
+func (manager *Manager) broadcastAnimate(session *net.MinecraftSession, action int32) {
+	var runtimeId = session.GetPlayer().GetRuntimeId()
+	session.SendAnimate(action, runtimeId, 0)
+	for _, viewer := range session.GetPlayer().GetViewers() {
+		if viewer, ok := viewer.(*net.MinecraftSession); ok && viewer != session {
+			viewer.SendAnimate(action, runtimeId, 0)
+		}
+	}
+}
+
+// broadcastEvent sends the entity event of the player of the session
+// to the session and all viewers of the player.
+func (manager *Manager) broadcastEvent(session *net.MinecraftSession, event byte) {
+	var runtimeId = session.GetPlayer().GetRuntimeId()
+	session.SendEntityEvent(runtimeId, event, 0)
+	for _, viewer := range session.GetPlayer().GetViewers() {
+		if viewer, ok := viewer.(*net.MinecraftSession); ok && viewer != session {
+			viewer.SendEntityEvent(runtimeId, event, 0)
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package combat
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/golang/geo/r3"
+	"github.com/irmine/gomine/net"
+	"github.com/irmine/gomine/text"
+	"github.com/irmine/worlds"
+)
+
+func TestGetKnockback(t *testing.T) {
+	var motion = GetKnockback(r3.Vector{X: 1, Z: 1}, r3.Vector{X: 4, Z: 5}, 0.5, 0.4)
+	if math.Abs(motion.X-0.3) > 0.0001 || math.Abs(motion.Z-0.4) > 0.0001 || motion.Y != 0.4 {
+		t.Error("entity was not knocked back away from the source:", motion)
+	}
+	if motion := GetKnockback(r3.Vector{Y: 2}, r3.Vector{}, 0.5, 0.4); motion != (r3.Vector{Y: 0.4}) {
+		t.Error("entity below the source was knocked back horizontally:", motion)
+	}
+}
+
+func TestSettings(t *testing.T) {
+	var settings = DefaultSettings()
+	if settings.GetAttackCooldown() != 0 {
+		t.Error("default settings have an attack cooldown:", settings.GetAttackCooldown())
+	}
+	settings.AttackCooldown = 10
+	if settings.GetAttackCooldown() != time.Millisecond*500 {
+		t.Error("unexpected attack cooldown:", settings.GetAttackCooldown())
+	}
+}
+
+func TestFormatDamage(t *testing.T) {
+	if formatted := FormatDamage(1.5); formatted != text.Red+"-1.5" {
+		t.Error("unexpected damage indicator:", formatted)
+	}
+}
+
+// fixedProvider is a respawn location provider returning the same location for every session.
+type fixedProvider struct {
+	position r3.Vector
+	ok       bool
+}
+
+func (provider fixedProvider) GetRespawnLocation(*net.MinecraftSession) (RespawnLocation, bool) {
+	return RespawnLocation{Position: provider.position}, provider.ok
+}
+
+func TestRespawnProviders(t *testing.T) {
+	var manager = NewManager(nil, nil)
+	manager.RemoveRespawnProvider("spawnpoints")
+	if location := manager.GetRespawnLocation(nil); location.Position != (r3.Vector{Y: 7}) {
+		t.Error("spawn function was not used without providers:", location.Position)
+	}
+	manager.AddRespawnProvider("bed", fixedProvider{r3.Vector{X: 1}, true})
+	manager.AddRespawnProvider("arena", fixedProvider{r3.Vector{X: 2}, true})
+	manager.AddRespawnProvider("empty", fixedProvider{r3.Vector{X: 3}, false})
+	if location := manager.GetRespawnLocation(nil); location.Position.X != 2 {
+		t.Error("latest added provider with a location did not take precedence:", location.Position)
+	}
+	manager.RemoveRespawnProvider("arena")
+	if location := manager.GetRespawnLocation(nil); location.Position.X != 1 {
+		t.Error("removed provider was still used:", location.Position)
+	}
+
+	var points = NewSpawnPoints()
+	points.Set("Steve", RespawnLocation{Position: r3.Vector{X: 5}})
+	if location, ok := points.Get("steve"); !ok || location.Position.X != 5 {
+		t.Error("spawn point was not found:", location)
+	}
+	points.Clear("STEVE")
+	if _, ok := points.Get("Steve"); ok {
+		t.Error("spawn point was not cleared")
+	}
+}
+
+func TestSpawnScatter(t *testing.T) {
+	var scatter = NewSpawnScatter()
+	var spawn = r3.Vector{X: 10, Y: 64, Z: 10}
+	var scattered r3.Vector
+	scatter.Scatter(nil, spawn, func(position r3.Vector) {
+		scattered = position
+	})
+	if scattered != spawn {
+		t.Error("players were scattered without radius:", scattered)
+	}
+
+	scatter.Radius = 16
+	scatter.Scatter(&worlds.Dimension{}, spawn, func(position r3.Vector) {
+		scattered = position
+	})
+	if scattered != spawn {
+		t.Error("spawn was not used without safe location:", scattered)
+	}
+	scatter.SearchFunction = func(dimension *worlds.Dimension, center r3.Vector, radius int32, attempts int, function func(spawn r3.Vector, err error)) {
+		function(center.Add(r3.Vector{X: float64(radius)}), nil)
+	}
+	scatter.Scatter(&worlds.Dimension{}, spawn, func(position r3.Vector) {
+		scattered = position
+	})
+	if scattered.X != 26 {
+		t.Error("safe location was not used:", scattered)
+	}
+}
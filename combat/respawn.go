@@ -0,0 +1,112 @@
+package combat
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/golang/geo/r3"
+	"github.com/irmine/gomine/net"
+	"github.com/irmine/worlds/entities/data"
+)
+
+// RespawnLocation is a location players respawn at after dying.
+type RespawnLocation struct {
+	Position r3.Vector
+	// Rotation is the rotation of the player after respawning.
+	// The player keeps its rotation if Rotation is nil.
+	Rotation *data.Rotation
+}
+
+// RespawnLocationProvider provides the locations players respawn at,
+// such as beds, respawn anchors or the spawn points of minigame arenas.
+type RespawnLocationProvider interface {
+	// GetRespawnLocation returns the location the session respawns at.
+	// A bool is returned indicating if the provider has a location for the session.
+	GetRespawnLocation(session *net.MinecraftSession) (RespawnLocation, bool)
+}
+
+// namedProvider is a respawn location provider registered with a name.
+type namedProvider struct {
+	name     string
+	provider RespawnLocationProvider
+}
+
+// AddRespawnProvider adds a respawn location provider with the given name,
+// replacing the provider previously added with the name.
+// Providers are asked for a location in reverse order of adding,
+// so providers added later take precedence over earlier providers.
+func (manager *Manager) AddRespawnProvider(name string, provider RespawnLocationProvider) {
+	manager.RemoveRespawnProvider(name)
+	manager.mutex.Lock()
+	manager.providers = append(manager.providers, namedProvider{name, provider})
+	manager.mutex.Unlock()
+}
+
+// RemoveRespawnProvider removes the respawn location provider with the given name.
+func (manager *Manager) RemoveRespawnProvider(name string) {
+	manager.mutex.Lock()
+	defer manager.mutex.Unlock()
+	for i, provider := range manager.providers {
+		if provider.name == name {
+			manager.providers = append(manager.providers[:i], manager.providers[i+1:]...)
+			return
+		}
+	}
+}
+
+// GetRespawnLocation returns the location the session respawns at,
+// which is the location of the latest added provider with a location for the session.
+// The spawn function of the manager is used if no provider has a location for the session.
+func (manager *Manager) GetRespawnLocation(session *net.MinecraftSession) RespawnLocation {
+	manager.mutex.RLock()
+	var providers = make([]namedProvider, len(manager.providers))
+	copy(providers, manager.providers)
+	manager.mutex.RUnlock()
+
+	for i := len(providers) - 1; i >= 0; i-- {
+		if location, ok := providers[i].provider.GetRespawnLocation(session); ok {
+			return location
+		}
+	}
+	return RespawnLocation{Position: manager.SpawnFunction(session)}
+}
+
+// SpawnPoints is a respawn location provider holding personal spawn points of players,
+// which are set for example when sleeping in a bed or charging a respawn anchor.
+type SpawnPoints struct {
+	mutex  sync.RWMutex
+	points map[string]RespawnLocation
+}
+
+// NewSpawnPoints returns a new provider without spawn points.
+func NewSpawnPoints() *SpawnPoints {
+	return &SpawnPoints{points: make(map[string]RespawnLocation)}
+}
+
+// Set sets the spawn point of the player with the given name.
+func (points *SpawnPoints) Set(name string, location RespawnLocation) {
+	points.mutex.Lock()
+	points.points[strings.ToLower(name)] = location
+	points.mutex.Unlock()
+}
+
+// Clear removes the spawn point of the player with the given name.
+func (points *SpawnPoints) Clear(name string) {
+	points.mutex.Lock()
+	delete(points.points, strings.ToLower(name))
+	points.mutex.Unlock()
+}
+
+// Get returns the spawn point of the player with the given name.
+// A bool is returned indicating if the player has a spawn point.
+func (points *SpawnPoints) Get(name string) (RespawnLocation, bool) {
+	points.mutex.RLock()
+	defer points.mutex.RUnlock()
+	var location, ok = points.points[strings.ToLower(name)]
+	return location, ok
+}
+
+// GetRespawnLocation returns the spawn point of the session.
+func (points *SpawnPoints) GetRespawnLocation(session *net.MinecraftSession) (RespawnLocation, bool) {
+	return points.Get(session.GetName())
+}
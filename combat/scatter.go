@@ -0,0 +1,91 @@
+package combat
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/golang/geo/r3"
+	"github.com/irmine/gomine/levels"
+	"github.com/irmine/gomine/net"
+	"github.com/irmine/worlds"
+)
+
+// SpawnScatter is a respawn location provider scattering players randomly within a radius around the spawn,
+// like the spawn radius of vanilla, so that players joining or respawning do not pile up on the same block.
+// Safe locations are searched asynchronously, so the location a player respawns at is searched when it dies,
+// and players respawning before the search finished respawn at the spawn itself.
+type SpawnScatter struct {
+	// Radius is the maximum distance in blocks between the spawn and scattered locations.
+	// Players are not scattered if the radius is 0.
+	Radius int32
+	// Attempts is the amount of random positions tried before falling back to the spawn.
+	Attempts int
+	// SearchFunction searches a safe spawn at a random position within the radius around the center,
+	// and calls the function once the search finishes. It is usually set to the FindRandomSpawn
+	// function of a level manager, and returns levels.NoSafeSpawn by default.
+	SearchFunction func(dimension *worlds.Dimension, center r3.Vector, radius int32, attempts int, function func(spawn r3.Vector, err error))
+
+	mutex  sync.Mutex
+	spawns map[string]r3.Vector
+}
+
+// NewSpawnScatter returns a new spawn scatter, which does not scatter players until its radius is set.
+func NewSpawnScatter() *SpawnScatter {
+	return &SpawnScatter{
+		Attempts: 8,
+		SearchFunction: func(dimension *worlds.Dimension, center r3.Vector, radius int32, attempts int, function func(spawn r3.Vector, err error)) {
+			function(center, levels.NoSafeSpawn)
+		},
+		spawns: make(map[string]r3.Vector),
+	}
+}
+
+// Scatter searches a safe location within the radius around the spawn in the dimension,
+// and calls the function with it once the search finishes.
+// The function is called with the spawn itself if players are not scattered or no safe location was found.
+func (scatter *SpawnScatter) Scatter(dimension *worlds.Dimension, spawn r3.Vector, function func(position r3.Vector)) {
+	if scatter.Radius <= 0 || dimension == nil {
+		function(spawn)
+		return
+	}
+	scatter.SearchFunction(dimension, spawn, scatter.Radius, scatter.Attempts, func(position r3.Vector, err error) {
+		if err != nil {
+			position = spawn
+		}
+		function(position)
+	})
+}
+
+// Prepare searches the location the session respawns at within the radius around the spawn,
+// in the dimension the session is in. The location previously searched for the session is cleared.
+func (scatter *SpawnScatter) Prepare(session *net.MinecraftSession, spawn r3.Vector) {
+	var name = strings.ToLower(session.GetName())
+	scatter.Clear(name)
+	if scatter.Radius <= 0 {
+		return
+	}
+	scatter.Scatter(session.GetPlayer().GetDimension(), spawn, func(position r3.Vector) {
+		scatter.mutex.Lock()
+		scatter.spawns[name] = position
+		scatter.mutex.Unlock()
+	})
+}
+
+// Clear removes the location searched for the player with the given name.
+func (scatter *SpawnScatter) Clear(name string) {
+	scatter.mutex.Lock()
+	delete(scatter.spawns, strings.ToLower(name))
+	scatter.mutex.Unlock()
+}
+
+// GetRespawnLocation returns the location searched for the session,
+// if players are scattered and the search started when it died has finished.
+func (scatter *SpawnScatter) GetRespawnLocation(session *net.MinecraftSession) (RespawnLocation, bool) {
+	if scatter.Radius <= 0 {
+		return RespawnLocation{}, false
+	}
+	scatter.mutex.Lock()
+	defer scatter.mutex.Unlock()
+	var position, ok = scatter.spawns[strings.ToLower(session.GetName())]
+	return RespawnLocation{Position: position}, ok
+}
@@ -0,0 +1,40 @@
+package combat
+
+import (
+	"time"
+)
+
+const (
+	// KnockbackForce is the horizontal speed in blocks per tick players attacked by other players get,
+	// before it is multiplied with the horizontal knockback multiplier of the world.
+	KnockbackForce = 0.4
+	// KnockbackHeight is the vertical speed in blocks per tick players attacked by other players get,
+	// before it is multiplied with the vertical knockback multiplier of the world.
+	KnockbackHeight = 0.4
+	// tickDuration is the duration of a server tick.
+	tickDuration = time.Millisecond * 50
+)
+
+// Settings are the PvP parameters of a world.
+type Settings struct {
+	// AttackCooldown is the amount of ticks players have to wait between attacks.
+	AttackCooldown int
+	// KnockbackHorizontal is the multiplier of the horizontal knockback of attacked players.
+	KnockbackHorizontal float64
+	// KnockbackVertical is the multiplier of the vertical knockback of attacked players.
+	KnockbackVertical float64
+	// CriticalMultiplier is the multiplier of the damage of critical hits,
+	// which are dealt by players attacking while falling.
+	CriticalMultiplier float32
+}
+
+// DefaultSettings returns the settings without an attack cooldown,
+// with vanilla knockback and critical hits dealing one and a half times the damage.
+func DefaultSettings() Settings {
+	return Settings{KnockbackHorizontal: 1, KnockbackVertical: 1, CriticalMultiplier: 1.5}
+}
+
+// GetAttackCooldown returns the duration players have to wait between attacks.
+func (settings Settings) GetAttackCooldown() time.Duration {
+	return time.Duration(settings.AttackCooldown) * tickDuration
+}
@@ -0,0 +1,56 @@
+package combat
+
+import (
+	"github.com/irmine/gomine/items/inventory"
+	"github.com/irmine/gomine/net"
+	"github.com/irmine/gomine/net/packets/data"
+)
+
+const (
+	// TotemItem is the ID of the totem of undying, which prevents the death of players holding it.
+	TotemItem = "minecraft:totem"
+	// totemAbsorption is the absorption health players get after a totem prevented their death.
+	// Status effects are not supported, so the regeneration and fire resistance of a totem are not applied.
+	totemAbsorption = 8
+)
+
+// preventDeath calls a pre-death event for the session of which the health dropped to 0,
+// which is cancelled in advance if the session holds a totem of undying.
+// If the event was cancelled, the player is left with the health of the event,
+// and the totem is consumed if it was used, playing the totem animation.
+// A bool is returned indicating if the death was prevented.
+func (manager *Manager) preventDeath(session *net.MinecraftSession, killer *net.MinecraftSession, cause int) bool {
+	var totemInventory, slot, hasTotem = findTotem(session)
+	var event = &PreDeathEvent{Session: session, Killer: killer, Cause: cause, Health: 1, Totem: hasTotem}
+	event.SetCancelled(hasTotem)
+	if manager.eventManager.Call(event) || event.Health <= 0 {
+		return false
+	}
+	var player = session.GetPlayer()
+	player.SetHealth(event.Health)
+	if event.Totem && hasTotem {
+		var stack, _ = totemInventory.GetItem(slot)
+		if stack.Count--; stack.Count <= 0 {
+			totemInventory.ClearSlot(slot)
+		}
+		player.SetAbsorption(totemAbsorption)
+		session.SendInventory()
+		manager.broadcastEvent(session, data.EntityEventTotem)
+	}
+	session.SendUpdateAttributes(player.GetRuntimeId(), player.GetAttributeMap())
+	return true
+}
+
+// findTotem returns the inventory and slot of the totem of undying held by the session,
+// checking the off hand before the main hand.
+// A bool is returned indicating if the session holds a totem.
+func findTotem(session *net.MinecraftSession) (*inventory.Inventory, int, bool) {
+	var player = session.GetPlayer()
+	if stack, _ := player.GetOffHandInventory().GetItem(0); stack != nil && stack.GetId() == TotemItem {
+		return player.GetOffHandInventory(), 0, true
+	}
+	if stack := player.GetHeldItem(); stack != nil && stack.GetId() == TotemItem {
+		return player.GetInventory(), player.GetHeldSlot(), true
+	}
+	return nil, 0, false
+}
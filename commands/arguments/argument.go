@@ -10,6 +10,7 @@ type Argument struct {
 	validationFunction func(argument string) bool
 	conversionFunction func(argument string) interface{}
 	shouldMerge        bool
+	softEnum           string
 }
 
 // GetName returns the name of the argument.
@@ -57,6 +58,12 @@ func (argument *Argument) ShouldMerge() bool {
 	return argument.shouldMerge
 }
 
+// GetSoftEnum returns the name of the soft enum this argument completes with,
+// or an empty string if the argument does not use a soft enum.
+func (argument *Argument) GetSoftEnum() string {
+	return argument.softEnum
+}
+
 // IsValidValue checks if the given value is valid for the argument.
 func (argument *Argument) IsValidValue(value string) bool {
 	return argument.validationFunction(value)
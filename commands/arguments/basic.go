@@ -12,7 +12,7 @@ func NewFloat(name string, optional bool) *Argument {
 	}, func(value string) interface{} {
 		var float, _ = strconv.ParseFloat(value, 64)
 		return float
-	}, false}
+	}, false, ""}
 }
 
 // NewInt returns a new Int argument with the given name and optional value.
@@ -22,7 +22,7 @@ func NewInt(name string, optional bool) *Argument {
 	}, func(value string) interface{} {
 		var i, _ = strconv.ParseInt(value, 10, 64)
 		return i
-	}, false}
+	}, false, ""}
 }
 
 // NewString returns a new String argument with the given name and optional value.
@@ -31,7 +31,7 @@ func NewString(name string, optional bool) *Argument {
 		return true
 	}, func(value string) interface{} {
 		return value
-	}, true}
+	}, true, ""}
 	return arg
 }
 
@@ -46,6 +46,15 @@ func NewStringEnum(name string, optional bool, options []string) *Argument {
 		return false
 	}, func(value string) interface{} {
 		return strings.ToLower(value)
-	}, true}
+	}, true, ""}
+	return arg
+}
+
+// NewSoftEnum returns a new Soft Enum argument with the given name and optional value.
+// Soft enum arguments accept any string, but are completed by clients using the values
+// of the soft enum with the given enum name, which may change while the server runs.
+func NewSoftEnum(name string, optional bool, enumName string) *Argument {
+	var arg = NewString(name, optional)
+	arg.softEnum = enumName
 	return arg
 }
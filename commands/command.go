@@ -6,7 +6,7 @@ import (
 	"strings"
 
 	"github.com/irmine/gomine/commands/arguments"
-	"github.com/irmine/gomine/text"
+	"github.com/irmine/gomine/lang"
 )
 
 type Command struct {
@@ -30,11 +30,16 @@ func NewCommand(name string, description string, permission string, aliases []st
 	return &Command{name: name, permission: permission, aliases: aliases, description: description, executionFunction: function}
 }
 
-// GetUsage returns the usage of this command.
+// GetUsage returns the usage of this command in the default language.
 // The usage will get parsed if it had not yet been.
 func (command *Command) GetUsage() string {
+	return command.GetLocalizedUsage("")
+}
+
+// GetLocalizedUsage returns the usage of this command in the given language.
+func (command *Command) GetLocalizedUsage(language string) string {
 	command.parseUsage()
-	return command.usage
+	return lang.DefaultTranslator.Translate(language, "commands.generic.usage", command.usage)
 }
 
 // ExemptFromPermissionCheck sets the command exempted from permission checking, allowing anybody to use it.
@@ -97,7 +102,7 @@ func (command *Command) AppendArgument(argument *arguments.Argument) {
 // parseUsage parses the usage into a readable and clear one.
 func (command *Command) parseUsage() {
 	if command.usage == "" {
-		var usage = text.Yellow + "Usage: /" + command.GetName() + " "
+		var usage = "/" + command.GetName() + " "
 		for index, argument := range command.GetArguments() {
 			if argument.IsOptional() {
 				usage += "["
@@ -122,17 +127,19 @@ func (command *Command) parseUsage() {
 }
 
 // Execute executes the command with the given sender and command arguments.
-func (command *Command) Execute(sender Sender, commandArgs []string) {
+// A bool is returned indicating if the command succeeded, which is not the case if the sender
+// lacks permission, the arguments are invalid, or the command function returned false.
+func (command *Command) Execute(sender Sender, commandArgs []string) bool {
 	if _, ok := command.parse(sender, commandArgs); !ok {
-		return
+		return false
 	}
-	command.parseArgsAndExecute(sender)
+	return command.parseArgsAndExecute(sender)
 }
 
 // Parse checks and parses the values of a command.
 func (command *Command) parse(sender Sender, commandArgs []string) ([]*arguments.Argument, bool) {
 	if command.IsPermissionChecked() && !sender.HasPermission(command.GetPermission()) {
-		sender.SendMessage("You do not have permission to execute this command.")
+		Tell(sender, "commands.generic.permission")
 		return []*arguments.Argument{}, false
 	}
 
@@ -141,7 +148,7 @@ func (command *Command) parse(sender Sender, commandArgs []string) ([]*arguments
 		if len(command.GetArguments()) == 0 {
 			return command.GetArguments(), true
 		}
-		sender.SendMessage(command.GetUsage())
+		sender.SendMessage(command.GetLocalizedUsage(GetLanguage(sender)))
 		return nil, false
 	}
 	for _, argument := range command.arguments {
@@ -151,14 +158,14 @@ func (command *Command) parse(sender Sender, commandArgs []string) ([]*arguments
 		for i < argument.GetInputAmount() {
 			if len(commandArgs) < stringIndex+i+1 {
 				if !argument.IsOptional() {
-					sender.SendMessage(command.GetUsage())
+					sender.SendMessage(command.GetLocalizedUsage(GetLanguage(sender)))
 					return nil, false
 				}
 			} else {
 				commandArgs[stringIndex+i] = strings.TrimSpace(commandArgs[stringIndex+i])
 
 				if !argument.IsValidValue(commandArgs[stringIndex+i]) {
-					sender.SendMessage(command.GetUsage())
+					sender.SendMessage(command.GetLocalizedUsage(GetLanguage(sender)))
 					return nil, false
 				}
 				output = append(output, commandArgs[stringIndex+i])
@@ -186,8 +193,9 @@ func (command *Command) parse(sender Sender, commandArgs []string) ([]*arguments
 }
 
 // ParseArgsAndExecute parses the arguments into an output able to be typed against.
-// After parsing, the command gets called.
-func (command *Command) parseArgsAndExecute(sender Sender) {
+// After parsing, the command gets called. Command functions may return a bool indicating if they succeeded,
+// and are considered successful otherwise.
+func (command *Command) parseArgsAndExecute(sender Sender) bool {
 	var method = reflect.ValueOf(command.executionFunction)
 	var input = make([]reflect.Value, method.Type().NumIn())
 
@@ -203,5 +211,9 @@ func (command *Command) parseArgsAndExecute(sender Sender) {
 		argOffset++
 	}
 
-	method.Call(input)
+	var output = method.Call(input)
+	if len(output) == 1 && output[0].Kind() == reflect.Bool {
+		return output[0].Bool()
+	}
+	return true
 }
@@ -5,13 +5,25 @@ import (
 )
 
 type Manager struct {
-	commands map[string]*Command
-	aliases  map[string]*Command
+	commands  map[string]*Command
+	aliases   map[string]*Command
+	softEnums softEnums
+
+	// SoftEnumFunction gets called every time the values of a soft enum change.
+	// The update type is one of the soft enum update types in the data package,
+	// and values contains the added, removed or complete new set of values.
+	SoftEnumFunction func(name string, updateType byte, values []string)
 }
 
 // NewManager returns a new Manager struct.
 func NewManager() *Manager {
-	return &Manager{make(map[string]*Command), make(map[string]*Command)}
+	return &Manager{
+		commands:  make(map[string]*Command),
+		aliases:   make(map[string]*Command),
+		softEnums: softEnums{enums: make(map[string]*SoftEnum)},
+		SoftEnumFunction: func(name string, updateType byte, values []string) {
+		},
+	}
 }
 
 // IsCommandRegistered checks if the command has been registered.
@@ -1,5 +1,10 @@
 package selectors
 
+import (
+	"errors"
+	"strings"
+)
+
 const (
 	NearestPlayer = "@p"
 	RandomPlayer  = "@r"
@@ -8,6 +13,9 @@ const (
 	Self          = "@s"
 )
 
+// InvalidSelector gets returned when parsing a target selector with an unknown variable or malformed arguments.
+var InvalidSelector = errors.New("invalid target selector")
+
 type TargetSelector struct {
 	variable  string
 	arguments map[string]string
@@ -16,3 +24,53 @@ type TargetSelector struct {
 func NewTargetSelector(variable string) *TargetSelector {
 	return &TargetSelector{variable, make(map[string]string)}
 }
+
+// IsSelector checks if the input is a target selector, such as @e[type=item], rather than a player name.
+func IsSelector(input string) bool {
+	return strings.HasPrefix(input, "@")
+}
+
+// Parse parses a target selector, such as @e[type=item,r=10].
+// InvalidSelector is returned if the variable is unknown or the arguments are malformed.
+func Parse(input string) (*TargetSelector, error) {
+	if len(input) < 2 {
+		return nil, InvalidSelector
+	}
+	var selector = NewTargetSelector(input[:2])
+	switch selector.variable {
+	case NearestPlayer, RandomPlayer, AllPlayers, AllEntities, Self:
+	default:
+		return nil, InvalidSelector
+	}
+	var arguments = input[2:]
+	if arguments == "" {
+		return selector, nil
+	}
+	if !strings.HasPrefix(arguments, "[") || !strings.HasSuffix(arguments, "]") {
+		return nil, InvalidSelector
+	}
+	arguments = strings.TrimSpace(arguments[1 : len(arguments)-1])
+	if arguments == "" {
+		return selector, nil
+	}
+	for _, argument := range strings.Split(arguments, ",") {
+		var pair = strings.SplitN(argument, "=", 2)
+		if len(pair) != 2 || strings.TrimSpace(pair[0]) == "" {
+			return nil, InvalidSelector
+		}
+		selector.arguments[strings.TrimSpace(pair[0])] = strings.TrimSpace(pair[1])
+	}
+	return selector, nil
+}
+
+// GetVariable returns the variable of the selector, such as @e.
+func (selector *TargetSelector) GetVariable() string {
+	return selector.variable
+}
+
+// GetArgument returns the value of the argument with the given key, such as type.
+// A bool is returned indicating if the selector has the argument.
+func (selector *TargetSelector) GetArgument(key string) (string, bool) {
+	var value, ok = selector.arguments[key]
+	return value, ok
+}
@@ -1,6 +1,30 @@
 package commands
 
+import (
+	"github.com/irmine/gomine/lang"
+)
+
 type Sender interface {
 	HasPermission(string) bool
 	SendMessage(...interface{})
 }
+
+// GetLanguage returns the language of the sender, such as en_US.
+// An empty string is returned for senders without a language, such as the console,
+// which get messages in the default language.
+func GetLanguage(sender Sender) string {
+	if sender, ok := sender.(interface{ GetLanguage() string }); ok {
+		return sender.GetLanguage()
+	}
+	return ""
+}
+
+// Translate translates the message key into the language of the sender.
+func Translate(sender Sender, key string, parameters ...interface{}) string {
+	return lang.DefaultTranslator.Translate(GetLanguage(sender), key, parameters...)
+}
+
+// Tell sends the message with the key to the sender, translated into its language.
+func Tell(sender Sender, key string, parameters ...interface{}) {
+	sender.SendMessage(Translate(sender, key, parameters...))
+}
@@ -0,0 +1,152 @@
+package commands
+
+import (
+	"errors"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/irmine/gomine/net/packets/data"
+)
+
+var (
+	UnknownSoftEnum = errors.New("soft enum is not registered")
+)
+
+// SoftEnum is a named set of values used to complete soft enum arguments.
+// Unlike regular enums, the values of a soft enum may change while the server is running.
+type SoftEnum struct {
+	name   string
+	values map[string]string
+}
+
+// GetName returns the name of the soft enum.
+func (enum *SoftEnum) GetName() string {
+	return enum.name
+}
+
+// GetValues returns the sorted values of the soft enum.
+func (enum *SoftEnum) GetValues() []string {
+	var values = make([]string, 0, len(enum.values))
+	for _, value := range enum.values {
+		values = append(values, value)
+	}
+	sort.Strings(values)
+	return values
+}
+
+// HasValue checks if the soft enum contains the given value, case insensitively.
+func (enum *SoftEnum) HasValue(value string) bool {
+	var _, ok = enum.values[strings.ToLower(value)]
+	return ok
+}
+
+// softEnums holds all soft enums of a command manager.
+type softEnums struct {
+	mutex sync.RWMutex
+	enums map[string]*SoftEnum
+}
+
+// RegisterSoftEnum registers a soft enum with the given name and values.
+// If a soft enum with the name already exists, its values get replaced.
+func (holder *Manager) RegisterSoftEnum(name string, values ...string) *SoftEnum {
+	holder.softEnums.mutex.Lock()
+	var enum, ok = holder.softEnums.enums[name]
+	if !ok {
+		enum = &SoftEnum{name, make(map[string]string)}
+		holder.softEnums.enums[name] = enum
+	}
+	holder.softEnums.mutex.Unlock()
+
+	holder.SetSoftEnum(name, values...)
+	return enum
+}
+
+// GetSoftEnum returns a soft enum by name, and a bool indicating if it was found.
+func (holder *Manager) GetSoftEnum(name string) (*SoftEnum, bool) {
+	holder.softEnums.mutex.RLock()
+	defer holder.softEnums.mutex.RUnlock()
+	var enum, ok = holder.softEnums.enums[name]
+	return enum, ok
+}
+
+// GetSoftEnums returns the values of all registered soft enums, keyed by their name.
+func (holder *Manager) GetSoftEnums() map[string][]string {
+	holder.softEnums.mutex.RLock()
+	defer holder.softEnums.mutex.RUnlock()
+	var enums = make(map[string][]string, len(holder.softEnums.enums))
+	for name, enum := range holder.softEnums.enums {
+		enums[name] = enum.GetValues()
+	}
+	return enums
+}
+
+// SetSoftEnum replaces all values of the soft enum with the given name.
+// The update function gets called with the complete new set of values.
+func (holder *Manager) SetSoftEnum(name string, values ...string) error {
+	holder.softEnums.mutex.Lock()
+	var enum, ok = holder.softEnums.enums[name]
+	if !ok {
+		holder.softEnums.mutex.Unlock()
+		return UnknownSoftEnum
+	}
+	enum.values = make(map[string]string, len(values))
+	for _, value := range values {
+		enum.values[strings.ToLower(value)] = value
+	}
+	var newValues = enum.GetValues()
+	holder.softEnums.mutex.Unlock()
+
+	holder.SoftEnumFunction(name, data.SoftEnumSet, newValues)
+	return nil
+}
+
+// AddSoftEnumValues adds values to the soft enum with the given name.
+// The update function only gets called with values that were not yet in the soft enum.
+func (holder *Manager) AddSoftEnumValues(name string, values ...string) error {
+	holder.softEnums.mutex.Lock()
+	var enum, ok = holder.softEnums.enums[name]
+	if !ok {
+		holder.softEnums.mutex.Unlock()
+		return UnknownSoftEnum
+	}
+	var added []string
+	for _, value := range values {
+		if enum.HasValue(value) {
+			continue
+		}
+		enum.values[strings.ToLower(value)] = value
+		added = append(added, value)
+	}
+	holder.softEnums.mutex.Unlock()
+
+	if len(added) != 0 {
+		holder.SoftEnumFunction(name, data.SoftEnumAdd, added)
+	}
+	return nil
+}
+
+// RemoveSoftEnumValues removes values from the soft enum with the given name.
+// The update function only gets called with values that were in the soft enum.
+func (holder *Manager) RemoveSoftEnumValues(name string, values ...string) error {
+	holder.softEnums.mutex.Lock()
+	var enum, ok = holder.softEnums.enums[name]
+	if !ok {
+		holder.softEnums.mutex.Unlock()
+		return UnknownSoftEnum
+	}
+	var removed []string
+	for _, value := range values {
+		var key = strings.ToLower(value)
+		if original, ok := enum.values[key]; ok {
+			delete(enum.values, key)
+			removed = append(removed, original)
+		}
+	}
+	holder.softEnums.mutex.Unlock()
+
+	if len(removed) != 0 {
+		holder.SoftEnumFunction(name, data.SoftEnumRemove, removed)
+	}
+	return nil
+}
@@ -0,0 +1,130 @@
+package cosmetics
+
+import (
+	"time"
+
+	"github.com/irmine/gomine/items"
+	"github.com/irmine/gomine/net"
+	"github.com/irmine/worlds/entities"
+)
+
+// Category is a category of cosmetics.
+// Players may have one cosmetic of every category equipped.
+type Category string
+
+const (
+	// CategoryTrail is the category of particle trails,
+	// which spawn particles behind players when moving.
+	CategoryTrail Category = "trail"
+	// CategoryPet is the category of pets,
+	// which are entities following their owner around.
+	CategoryPet Category = "pet"
+	// CategoryGadget is the category of gadgets,
+	// which are items doing something when used.
+	CategoryGadget Category = "gadget"
+)
+
+// Categories contains all cosmetic categories.
+var Categories = []Category{CategoryTrail, CategoryPet, CategoryGadget}
+
+// Cosmetic is a single cosmetic of any category.
+type Cosmetic interface {
+	// GetName returns the name of the cosmetic.
+	GetName() string
+	// GetCategory returns the category of the cosmetic.
+	GetCategory() Category
+	// GetPermission returns the permission required to equip the cosmetic.
+	// An empty permission means every player may equip the cosmetic.
+	GetPermission() string
+}
+
+// base is the base of every cosmetic.
+type base struct {
+	name       string
+	permission string
+}
+
+// GetName returns the name of the cosmetic.
+func (cosmetic *base) GetName() string {
+	return cosmetic.name
+}
+
+// GetPermission returns the permission required to equip the cosmetic.
+func (cosmetic *base) GetPermission() string {
+	return cosmetic.permission
+}
+
+// Trail is a cosmetic spawning particles behind a player while moving.
+type Trail struct {
+	base
+	particle string
+}
+
+// NewTrail returns a new trail with the given name and permission,
+// spawning the particle with the given identifier, for example "minecraft:heart_particle".
+func NewTrail(name string, permission string, particle string) *Trail {
+	return &Trail{base{name, permission}, particle}
+}
+
+// GetCategory returns the trail category.
+func (trail *Trail) GetCategory() Category {
+	return CategoryTrail
+}
+
+// GetParticle returns the identifier of the particle spawned by the trail.
+func (trail *Trail) GetParticle() string {
+	return trail.particle
+}
+
+// Pet is a cosmetic entity following its owner around.
+type Pet struct {
+	base
+	create func() *entities.Entity
+}
+
+// NewPet returns a new pet with the given name and permission.
+// The create function gets called to create a new entity every time the pet gets spawned.
+func NewPet(name string, permission string, create func() *entities.Entity) *Pet {
+	return &Pet{base{name, permission}, create}
+}
+
+// GetCategory returns the pet category.
+func (pet *Pet) GetCategory() Category {
+	return CategoryPet
+}
+
+// Gadget is a cosmetic item which does something when used.
+type Gadget struct {
+	base
+	item     *items.Stack
+	cooldown time.Duration
+	// UseFunction gets called when a player uses the gadget item.
+	UseFunction func(session *net.MinecraftSession)
+}
+
+// NewGadget returns a new gadget with the given name, permission, item and cooldown between uses.
+// The use function gets called every time a player uses the gadget.
+func NewGadget(name string, permission string, item *items.Stack, cooldown time.Duration, useFunction func(session *net.MinecraftSession)) *Gadget {
+	return &Gadget{base{name, permission}, item, cooldown, useFunction}
+}
+
+// GetCategory returns the gadget category.
+func (gadget *Gadget) GetCategory() Category {
+	return CategoryGadget
+}
+
+// GetItem returns a copy of the item given to players equipping the gadget.
+func (gadget *Gadget) GetItem() *items.Stack {
+	var item = *gadget.item
+	return &item
+}
+
+// GetCooldown returns the cooldown between uses of the gadget.
+func (gadget *Gadget) GetCooldown() time.Duration {
+	return gadget.cooldown
+}
+
+// IsItem checks if the given item stack is the item of the gadget.
+func (gadget *Gadget) IsItem(stack *items.Stack) bool {
+	return stack != nil && stack.Type.Equals(gadget.item.Type) && stack.DisplayName == gadget.item.DisplayName
+}
@@ -0,0 +1,43 @@
+package cosmetics
+
+import (
+	"math"
+	"time"
+
+	"github.com/golang/geo/r3"
+	"github.com/irmine/gomine/items"
+	"github.com/irmine/gomine/net"
+	"github.com/irmine/gomine/text"
+	"github.com/irmine/worlds/entities"
+)
+
+// Network IDs of the entity types used by the default pets.
+const (
+	chickenType = 10
+	pigType     = 12
+	wolfType    = 14
+)
+
+// RegisterDefaults registers the default cosmetics of GoMine.
+// The hearts trail, pig pet and party popper gadget may be equipped by every player,
+// other cosmetics require the `gomine.cosmetics.<category>.<name>` permission.
+func (manager *Manager) RegisterDefaults() {
+	manager.Register(NewTrail("Hearts", "", "minecraft:heart_particle"))
+	manager.Register(NewTrail("Flames", "gomine.cosmetics.trail.flames", "minecraft:basic_flame_particle"))
+	manager.Register(NewTrail("Notes", "gomine.cosmetics.trail.notes", "minecraft:note_particle"))
+
+	manager.Register(NewPet("Pig", "", func() *entities.Entity { return entities.New(pigType) }))
+	manager.Register(NewPet("Chicken", "gomine.cosmetics.pet.chicken", func() *entities.Entity { return entities.New(chickenType) }))
+	manager.Register(NewPet("Wolf", "gomine.cosmetics.pet.wolf", func() *entities.Entity { return entities.New(wolfType) }))
+
+	if item, ok := items.DefaultManager.Get("minecraft:emerald", 1); ok {
+		item.DisplayName = text.Yellow + "Party Popper"
+		manager.Register(NewGadget("Popper", "", item, time.Second*5, func(session *net.MinecraftSession) {
+			var center = session.GetPlayer().Position
+			for i := 0; i < 16; i++ {
+				var angle = float64(i) / 16 * 2 * math.Pi
+				manager.SpawnParticle(session, center.Add(r3.Vector{X: math.Cos(angle) * 1.5, Z: math.Sin(angle) * 1.5}), "minecraft:villager_happy")
+			}
+		}))
+	}
+}
@@ -0,0 +1,426 @@
+package cosmetics
+
+import (
+	"errors"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang/geo/r3"
+	"github.com/irmine/gomine/items"
+	"github.com/irmine/gomine/net"
+	"github.com/irmine/gomine/players"
+	"github.com/irmine/worlds/entities"
+)
+
+var (
+	// UnknownCosmetic gets returned when a cosmetic
+	// with a given category and name could not be found.
+	UnknownCosmetic = errors.New("unknown cosmetic")
+	// NoPermission gets returned when a player tries to
+	// equip a cosmetic without having its permission.
+	NoPermission = errors.New("no permission to equip this cosmetic")
+	// OnCooldown gets returned when a player uses
+	// a gadget before its cooldown has passed.
+	OnCooldown = errors.New("gadget is on cooldown")
+)
+
+// eyeHeight is the height of the eyes of a player above its feet.
+// Player positions are at eye height, while particles and pets are placed at the feet.
+const eyeHeight = 1.62
+
+// Manager manages all cosmetics and the cosmetics equipped by players.
+// Equipped cosmetics are persisted in the player data.
+type Manager struct {
+	// TrailDistance is the distance a player has to move
+	// before another particle of its trail gets spawned.
+	TrailDistance float64
+	// PetSpeed is the distance in blocks a pet moves every tick.
+	PetSpeed float64
+	// FollowDistance is the distance pets keep from their owner.
+	FollowDistance float64
+	// TeleportDistance is the distance from their owner
+	// at which pets get teleported to their owner.
+	TeleportDistance float64
+
+	mutex          sync.RWMutex
+	sessionManager *net.SessionManager
+	storage        players.DataStorage
+	cosmetics      map[Category]map[string]Cosmetic
+	pets           map[string]*entities.Entity
+	trails         map[string]r3.Vector
+	uses           map[string]time.Time
+}
+
+// NewManager returns a new cosmetics manager, persisting equipped cosmetics in the data storage.
+func NewManager(sessionManager *net.SessionManager, storage players.DataStorage) *Manager {
+	var manager = &Manager{
+		TrailDistance:    0.5,
+		PetSpeed:         0.3,
+		FollowDistance:   2.5,
+		TeleportDistance: 16,
+		sessionManager:   sessionManager,
+		storage:          storage,
+		cosmetics:        make(map[Category]map[string]Cosmetic),
+		pets:             make(map[string]*entities.Entity),
+		trails:           make(map[string]r3.Vector),
+		uses:             make(map[string]time.Time),
+	}
+	for _, category := range Categories {
+		manager.cosmetics[category] = make(map[string]Cosmetic)
+	}
+	return manager
+}
+
+// Register registers a new cosmetic.
+// Cosmetics with the same category and name get overwritten.
+func (manager *Manager) Register(cosmetic Cosmetic) {
+	manager.mutex.Lock()
+	manager.cosmetics[cosmetic.GetCategory()][strings.ToLower(cosmetic.GetName())] = cosmetic
+	manager.mutex.Unlock()
+}
+
+// Deregister removes the cosmetic with the given category and name.
+func (manager *Manager) Deregister(category Category, name string) {
+	manager.mutex.Lock()
+	delete(manager.cosmetics[category], strings.ToLower(name))
+	manager.mutex.Unlock()
+}
+
+// GetCosmetic returns a cosmetic by its category and name.
+// Names are case insensitive.
+func (manager *Manager) GetCosmetic(category Category, name string) (Cosmetic, error) {
+	manager.mutex.RLock()
+	defer manager.mutex.RUnlock()
+	var cosmetic, ok = manager.cosmetics[category][strings.ToLower(name)]
+	if !ok {
+		return nil, UnknownCosmetic
+	}
+	return cosmetic, nil
+}
+
+// GetCosmetics returns all cosmetics of the given category, sorted by name.
+func (manager *Manager) GetCosmetics(category Category) []Cosmetic {
+	manager.mutex.RLock()
+	var cosmetics = make([]Cosmetic, 0, len(manager.cosmetics[category]))
+	for _, cosmetic := range manager.cosmetics[category] {
+		cosmetics = append(cosmetics, cosmetic)
+	}
+	manager.mutex.RUnlock()
+	sort.Slice(cosmetics, func(i, j int) bool {
+		return cosmetics[i].GetName() < cosmetics[j].GetName()
+	})
+	return cosmetics
+}
+
+// CanEquip checks if the session has the permission to equip the cosmetic.
+func (manager *Manager) CanEquip(session *net.MinecraftSession, cosmetic Cosmetic) bool {
+	return cosmetic.GetPermission() == "" || session.HasPermission(cosmetic.GetPermission())
+}
+
+// GetEquipped returns the cosmetic of the given category the session has equipped.
+// A bool is returned indicating if the session had any cosmetic of the category equipped.
+func (manager *Manager) GetEquipped(session *net.MinecraftSession, category Category) (Cosmetic, bool) {
+	var name, ok = session.GetPlayer().GetData().Cosmetics[string(category)]
+	if !ok {
+		return nil, false
+	}
+	var cosmetic, err = manager.GetCosmetic(category, name)
+	if err != nil || !manager.CanEquip(session, cosmetic) {
+		return nil, false
+	}
+	return cosmetic, true
+}
+
+// Equip equips the cosmetic with the given category and name for the session,
+// replacing any previously equipped cosmetic of the same category.
+// NoPermission gets returned if the session does not have the permission of the cosmetic.
+// Equipped gadgets are added to the inventory, but the inventory is not sent.
+func (manager *Manager) Equip(session *net.MinecraftSession, category Category, name string) error {
+	var cosmetic, err = manager.GetCosmetic(category, name)
+	if err != nil {
+		return err
+	}
+	if !manager.CanEquip(session, cosmetic) {
+		return NoPermission
+	}
+	manager.unapply(session, category)
+
+	var data = session.GetPlayer().GetData()
+	data.Cosmetics[string(category)] = cosmetic.GetName()
+	manager.apply(session, cosmetic)
+	return manager.storage.Save(data)
+}
+
+// Unequip unequips the cosmetic of the given category of the session.
+// Gadget items are removed from the inventory, but the inventory is not sent.
+func (manager *Manager) Unequip(session *net.MinecraftSession, category Category) error {
+	manager.unapply(session, category)
+	var data = session.GetPlayer().GetData()
+	delete(data.Cosmetics, string(category))
+	return manager.storage.Save(data)
+}
+
+// apply applies an equipped cosmetic to the session.
+func (manager *Manager) apply(session *net.MinecraftSession, cosmetic Cosmetic) {
+	switch cosmetic := cosmetic.(type) {
+	case *Pet:
+		manager.spawnPet(session, cosmetic)
+	case *Gadget:
+		var inv = session.GetPlayer().GetInventory()
+		for _, stack := range inv.GetAll() {
+			if cosmetic.IsItem(stack) {
+				return
+			}
+		}
+		inv.AddItem(cosmetic.GetItem())
+	}
+}
+
+// unapply removes the effects of the cosmetic of the given category from the session.
+func (manager *Manager) unapply(session *net.MinecraftSession, category Category) {
+	var cosmetic, ok = manager.GetEquipped(session, category)
+	if !ok {
+		return
+	}
+	switch cosmetic := cosmetic.(type) {
+	case *Pet:
+		manager.despawnPet(session.GetName())
+	case *Gadget:
+		var inv = session.GetPlayer().GetInventory()
+		for slot, stack := range inv.GetAll() {
+			if cosmetic.IsItem(stack) {
+				inv.ClearSlot(slot)
+			}
+		}
+	}
+}
+
+// Join spawns the pets of all other players to the session,
+// and applies the cosmetics the session has equipped.
+// Join should be called once the player data of the session has been loaded.
+func (manager *Manager) Join(session *net.MinecraftSession) {
+	manager.mutex.RLock()
+	for owner, pet := range manager.pets {
+		if pet.GetDimension() == session.GetPlayer().GetDimension() && manager.canSeePet(session, owner) {
+			pet.AddViewer(session)
+			session.SendAddEntity(pet)
+		}
+	}
+	manager.mutex.RUnlock()
+
+	for _, category := range Categories {
+		if cosmetic, ok := manager.GetEquipped(session, category); ok {
+			manager.apply(session, cosmetic)
+		}
+	}
+}
+
+// UpdateVisibility spawns or despawns the pets of other players to the session,
+// depending on if the session hides pets. UpdateVisibility should be called
+// every time the pets category gets hidden or shown to the session.
+func (manager *Manager) UpdateVisibility(session *net.MinecraftSession) {
+	manager.mutex.RLock()
+	defer manager.mutex.RUnlock()
+	for owner, pet := range manager.pets {
+		var visible = pet.GetDimension() == session.GetPlayer().GetDimension() && manager.canSeePet(session, owner)
+		if viewing := session.IsViewing(pet); visible && !viewing {
+			pet.AddViewer(session)
+			session.SendAddEntity(pet)
+		} else if !visible && viewing {
+			pet.RemoveViewer(session)
+			session.SendRemoveEntity(pet.GetUniqueId())
+		}
+	}
+}
+
+// canSeePet checks if the session sees the pet of the player with the given name.
+// Players always see their own pet, even if they hide pets.
+func (manager *Manager) canSeePet(session *net.MinecraftSession, owner string) bool {
+	return owner == session.GetName() || !session.IsHidden(net.CategoryPets)
+}
+
+// Leave despawns the pet of the session and removes the session as viewer of other pets.
+func (manager *Manager) Leave(session *net.MinecraftSession) {
+	manager.despawnPet(session.GetName())
+
+	manager.mutex.Lock()
+	for _, pet := range manager.pets {
+		pet.RemoveViewer(session)
+	}
+	delete(manager.trails, session.GetName())
+	manager.mutex.Unlock()
+}
+
+// Move spawns a trail particle behind the session if it has a trail equipped,
+// and has moved far enough since the previous particle.
+func (manager *Manager) Move(session *net.MinecraftSession) {
+	var cosmetic, ok = manager.GetEquipped(session, CategoryTrail)
+	if !ok {
+		return
+	}
+	var position = session.GetPlayer().Position
+	position.Y -= eyeHeight
+
+	manager.mutex.Lock()
+	var last, spawned = manager.trails[session.GetName()]
+	if spawned && last.Sub(position).Norm() < manager.TrailDistance {
+		manager.mutex.Unlock()
+		return
+	}
+	manager.trails[session.GetName()] = position
+	manager.mutex.Unlock()
+
+	manager.SpawnParticle(session, position, cosmetic.(*Trail).GetParticle())
+}
+
+// SpawnParticle spawns a particle at the given position,
+// visible to the session and all viewers of the session that do not hide particles.
+func (manager *Manager) SpawnParticle(session *net.MinecraftSession, position r3.Vector, particle string) {
+	if !session.IsHidden(net.CategoryParticles) {
+		session.SendSpawnParticleEffect(position, particle)
+	}
+	for _, viewer := range session.GetPlayer().GetViewers() {
+		if viewer, ok := viewer.(*net.MinecraftSession); ok && !viewer.IsHidden(net.CategoryParticles) {
+			viewer.SendSpawnParticleEffect(position, particle)
+		}
+	}
+}
+
+// Use uses the gadget the session has equipped, if the stack is the item of the gadget.
+// A bool is returned indicating if the stack was a gadget item,
+// and OnCooldown gets returned if the gadget was used too recently.
+func (manager *Manager) Use(session *net.MinecraftSession, stack *items.Stack) (bool, error) {
+	var cosmetic, ok = manager.GetEquipped(session, CategoryGadget)
+	if !ok || !cosmetic.(*Gadget).IsItem(stack) {
+		return false, nil
+	}
+	var gadget = cosmetic.(*Gadget)
+	if manager.GetCooldown(session, gadget) > 0 {
+		return true, OnCooldown
+	}
+	manager.mutex.Lock()
+	manager.uses[session.GetName()] = time.Now()
+	manager.mutex.Unlock()
+
+	gadget.UseFunction(session)
+	return true, nil
+}
+
+// GetCooldown returns the cooldown left before the session can use the gadget again.
+func (manager *Manager) GetCooldown(session *net.MinecraftSession, gadget *Gadget) time.Duration {
+	manager.mutex.RLock()
+	var used, ok = manager.uses[session.GetName()]
+	manager.mutex.RUnlock()
+	if !ok {
+		return 0
+	}
+	var left = used.Add(gadget.GetCooldown()).Sub(time.Now())
+	if left < 0 {
+		return 0
+	}
+	return left
+}
+
+// spawnPet spawns a new entity of the pet next to the session.
+func (manager *Manager) spawnPet(session *net.MinecraftSession, pet *Pet) {
+	var dimension = session.GetPlayer().GetDimension()
+	if dimension == nil {
+		return
+	}
+	manager.despawnPet(session.GetName())
+
+	var entity = pet.create()
+	var position = session.GetPlayer().Position
+	position.Y -= eyeHeight
+	dimension.AddEntity(entity, position)
+	entity.Position = position
+
+	for _, online := range manager.sessionManager.GetSessions() {
+		if online.GetPlayer().GetDimension() == dimension && manager.canSeePet(online, session.GetName()) {
+			entity.AddViewer(online)
+			online.SendAddEntity(entity)
+		}
+	}
+
+	manager.mutex.Lock()
+	manager.pets[session.GetName()] = entity
+	manager.mutex.Unlock()
+}
+
+// despawnPet despawns the pet of the player with the given name, if it had any.
+func (manager *Manager) despawnPet(name string) {
+	manager.mutex.Lock()
+	var entity, ok = manager.pets[name]
+	delete(manager.pets, name)
+	manager.mutex.Unlock()
+	if !ok {
+		return
+	}
+	for _, viewer := range entity.GetViewers() {
+		if viewer, ok := viewer.(*net.MinecraftSession); ok {
+			viewer.SendRemoveEntity(entity.GetUniqueId())
+		}
+	}
+	entity.Close()
+}
+
+// Tick makes all pets follow their owners.
+// Pets walk towards their owner, and get teleported
+// once their owner is too far away or in another dimension.
+// Internal. Not to be used by plugins.
+func (manager *Manager) Tick() {
+	manager.mutex.RLock()
+	var pets = make(map[string]*entities.Entity, len(manager.pets))
+	for name, entity := range manager.pets {
+		pets[name] = entity
+	}
+	manager.mutex.RUnlock()
+
+	for name, entity := range pets {
+		var owner, ok = manager.sessionManager.GetSession(name)
+		if !ok {
+			continue
+		}
+		if entity.GetDimension() != owner.GetPlayer().GetDimension() {
+			if cosmetic, ok := manager.GetEquipped(owner, CategoryPet); ok {
+				manager.spawnPet(owner, cosmetic.(*Pet))
+			}
+			continue
+		}
+
+		var target = owner.GetPlayer().Position
+		target.Y -= eyeHeight
+		var position, teleport, moved = follow(entity.Position, target, manager.PetSpeed, manager.FollowDistance, manager.TeleportDistance)
+		if !moved {
+			continue
+		}
+		entity.Rotation.Yaw = math.Atan2(-(target.X-position.X), target.Z-position.Z) * 180 / math.Pi
+		entity.Rotation.HeadYaw = entity.Rotation.Yaw
+		entity.Position = position
+
+		for _, viewer := range entity.GetViewers() {
+			if viewer, ok := viewer.(*net.MinecraftSession); ok {
+				viewer.SendMoveEntity(entity.GetRuntimeId(), entity.Position, entity.Rotation, 0, teleport)
+			}
+		}
+	}
+}
+
+// follow returns the next position of a pet following its owner at the target position.
+// Pets move at most speed blocks towards the target, stopping at the follow distance,
+// and teleport to the target once further away than the teleport distance.
+// Bools are returned indicating if the pet teleported and if it moved at all.
+func follow(position r3.Vector, target r3.Vector, speed float64, followDistance float64, teleportDistance float64) (r3.Vector, bool, bool) {
+	var distance = target.Sub(position).Norm()
+	if distance > teleportDistance {
+		return target, true, true
+	}
+	if distance <= followDistance {
+		return position, false, false
+	}
+	var step = math.Min(speed, distance-followDistance)
+	return position.Add(target.Sub(position).Normalize().Mul(step)), false, true
+}
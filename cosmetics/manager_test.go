@@ -0,0 +1,73 @@
+package cosmetics
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/golang/geo/r3"
+	"github.com/google/uuid"
+	"github.com/irmine/gomine/items"
+	"github.com/irmine/gomine/net"
+	"github.com/irmine/gomine/players"
+)
+
+func TestEquip(t *testing.T) {
+	dir, _ := ioutil.TempDir("", "cosmetics")
+	defer os.RemoveAll(dir)
+	storage := players.NewFileDataStorage(dir + "/")
+
+	manager := NewManager(net.NewSessionManager(), storage)
+	manager.Register(NewTrail("Hearts", "", "minecraft:heart_particle"))
+	manager.Register(NewTrail("Flames", "gomine.cosmetics.trail.flames", "minecraft:basic_flame_particle"))
+	emerald, _ := items.DefaultManager.Get("minecraft:emerald", 1)
+	emerald.DisplayName = "Gadget"
+	var uses = 0
+	manager.Register(NewGadget("Gadget", "", emerald, time.Hour, func(*net.MinecraftSession) {
+		uses++
+	}))
+
+	session := net.NewMinecraftSession(nil, nil)
+	session.SetPlayer(players.NewPlayer(uuid.New(), "", 0, "Steve"))
+
+	if err := manager.Equip(session, CategoryTrail, "flames"); err != NoPermission {
+		t.Error("expected no permission, got:", err)
+	}
+	if err := manager.Equip(session, CategoryTrail, "hearts"); err != nil {
+		t.Fatal(err)
+	}
+	data, err := storage.Load("Steve")
+	if err != nil || data.Cosmetics[string(CategoryTrail)] != "Hearts" {
+		t.Error("equipped trail was not persisted:", data.Cosmetics, err)
+	}
+
+	if err := manager.Equip(session, CategoryGadget, "gadget"); err != nil {
+		t.Fatal(err)
+	}
+	stack, _ := session.GetPlayer().GetInventory().GetItem(0)
+	if used, err := manager.Use(session, stack); !used || err != nil || uses != 1 {
+		t.Error("gadget item was not used:", used, err)
+	}
+	if _, err := manager.Use(session, stack); err != OnCooldown {
+		t.Error("expected gadget cooldown, got:", err)
+	}
+	if err := manager.Unequip(session, CategoryGadget); err != nil {
+		t.Fatal(err)
+	}
+	if !session.GetPlayer().GetInventory().IsEmpty(0) {
+		t.Error("gadget item was not removed after unequipping")
+	}
+}
+
+func TestFollow(t *testing.T) {
+	if _, _, moved := follow(r3.Vector{}, r3.Vector{X: 2}, 0.3, 2.5, 16); moved {
+		t.Error("pet within follow distance should not move")
+	}
+	if position, teleport, _ := follow(r3.Vector{}, r3.Vector{X: 10}, 0.3, 2.5, 16); teleport || position.X != 0.3 {
+		t.Error("pet should walk towards its owner, got:", position, teleport)
+	}
+	if position, teleport, _ := follow(r3.Vector{}, r3.Vector{X: 20}, 0.3, 2.5, 16); !teleport || position.X != 20 {
+		t.Error("pet should teleport to its owner, got:", position, teleport)
+	}
+}
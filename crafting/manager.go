@@ -0,0 +1,376 @@
+package crafting
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"os"
+	"sync"
+
+	"github.com/irmine/gomine/items"
+	"github.com/irmine/gomine/items/inventory"
+	"github.com/irmine/gomine/items/inventory/io"
+	"github.com/irmine/gomine/net"
+	"github.com/irmine/gomine/net/packets/types"
+	"github.com/irmine/gomine/tags"
+)
+
+var (
+	// UnknownItem gets returned when a recipe in the recipes file uses an item that is not registered.
+	UnknownItem = errors.New("unknown item in recipe")
+	// InvalidShape gets returned when a shaped recipe has no rows, rows of different lengths,
+	// or characters without an ingredient.
+	InvalidShape = errors.New("invalid recipe shape")
+	// UnknownTag gets returned when a recipe in the recipes file uses an item tag
+	// that is not registered or does not hold any registered item.
+	UnknownTag = errors.New("unknown item tag in recipe")
+)
+
+// recipeFile is the stored form of all recipes in the recipes file.
+type recipeFile struct {
+	Shaped    []shapedRecord    `json:"shaped"`
+	Shapeless []shapelessRecord `json:"shapeless"`
+	Furnace   []furnaceRecord   `json:"furnace"`
+}
+
+// ingredientRecord is the stored form of an ingredient of a recipe,
+// which is either an item or any item of an item tag, such as {"tag": "minecraft:planks"}.
+type ingredientRecord struct {
+	items.Record
+	Tag string `json:"tag,omitempty"`
+}
+
+// shapedRecord is the stored form of a shaped recipe.
+// Every character in the shape rows is a key of an ingredient, with spaces for empty slots.
+type shapedRecord struct {
+	Shape  []string                    `json:"shape"`
+	Keys   map[string]ingredientRecord `json:"keys"`
+	Output []items.Record              `json:"output"`
+}
+
+// shapelessRecord is the stored form of a shapeless recipe.
+type shapelessRecord struct {
+	Input  []ingredientRecord `json:"input"`
+	Output []items.Record     `json:"output"`
+}
+
+// furnaceRecord is the stored form of a furnace recipe.
+type furnaceRecord struct {
+	Input  ingredientRecord `json:"input"`
+	Output items.Record     `json:"output"`
+}
+
+// Manager holds all recipes known to the server.
+// Recipes are sent to players when they spawn so their recipe book works,
+// and crafting transactions of players are only accepted if they craft a known recipe.
+type Manager struct {
+	mutex     sync.RWMutex
+	path      string
+	shaped    []*ShapedRecipe
+	shapeless []*ShapelessRecipe
+	furnace   []*FurnaceRecipe
+}
+
+// NewManager returns a new recipe manager loading recipes from the JSON file at the given path.
+func NewManager(path string) *Manager {
+	return &Manager{path: path}
+}
+
+// Load loads all recipes from the recipes file, adding them to the recipes already registered.
+// An empty recipes file gets created if it does not yet exist.
+// Shaped recipes have rows of keys as shape, with spaces for empty slots, and an item for every key.
+// Counts of ingredients are ignored, and outputs have a count of 1 if no count is set.
+// Ingredients may be an item tag rather than an item, such as {"tag": "minecraft:planks"}.
+func (manager *Manager) Load() error {
+	var file, err = ioutil.ReadFile(manager.path)
+	if os.IsNotExist(err) {
+		var data, _ = json.MarshalIndent(recipeFile{Shaped: []shapedRecord{}, Shapeless: []shapelessRecord{}, Furnace: []furnaceRecord{}}, "", "  ")
+		return ioutil.WriteFile(manager.path, data, 0644)
+	}
+	if err != nil {
+		return err
+	}
+	var recipes recipeFile
+	if err := json.Unmarshal(file, &recipes); err != nil {
+		return err
+	}
+	for _, record := range recipes.Shaped {
+		var recipe, err = record.toRecipe()
+		if err != nil {
+			return err
+		}
+		manager.AddShaped(recipe)
+	}
+	for _, record := range recipes.Shapeless {
+		var input = make([]*items.Stack, len(record.Input))
+		var inputTags = make([]string, len(record.Input))
+		for i, ingredient := range record.Input {
+			var err error
+			if input[i], err = ingredient.toStack(); err != nil {
+				return err
+			}
+			inputTags[i] = ingredient.Tag
+		}
+		output, err := toStacks(record.Output)
+		if err != nil {
+			return err
+		}
+		manager.AddShapeless(NewTaggedShapelessRecipe(input, inputTags, output))
+	}
+	for _, record := range recipes.Furnace {
+		var input, err = record.Input.toStack()
+		if err != nil {
+			return err
+		}
+		output, err := toStack(record.Output)
+		if err != nil {
+			return err
+		}
+		manager.AddFurnace(NewTaggedFurnaceRecipe(input, record.Input.Tag, output))
+	}
+	return nil
+}
+
+// AddShaped registers a shaped recipe.
+func (manager *Manager) AddShaped(recipe *ShapedRecipe) {
+	manager.mutex.Lock()
+	manager.shaped = append(manager.shaped, recipe)
+	manager.mutex.Unlock()
+}
+
+// AddShapeless registers a shapeless recipe.
+func (manager *Manager) AddShapeless(recipe *ShapelessRecipe) {
+	manager.mutex.Lock()
+	manager.shapeless = append(manager.shapeless, recipe)
+	manager.mutex.Unlock()
+}
+
+// AddFurnace registers a furnace recipe.
+func (manager *Manager) AddFurnace(recipe *FurnaceRecipe) {
+	manager.mutex.Lock()
+	manager.furnace = append(manager.furnace, recipe)
+	manager.mutex.Unlock()
+}
+
+// GetCraftingData returns all recipes as sent to players in the crafting data packet.
+func (manager *Manager) GetCraftingData() types.CraftingData {
+	manager.mutex.RLock()
+	defer manager.mutex.RUnlock()
+	var craftingData types.CraftingData
+	for _, recipe := range manager.shaped {
+		craftingData.Shaped = append(craftingData.Shaped, types.ShapedRecipeEntry{Width: int32(recipe.width), Height: int32(recipe.height), Input: recipe.input, Output: recipe.output, UUID: recipe.uuid})
+	}
+	for _, recipe := range manager.shapeless {
+		craftingData.Shapeless = append(craftingData.Shapeless, types.ShapelessRecipeEntry{Input: recipe.input, Output: recipe.output, UUID: recipe.uuid})
+	}
+	for _, recipe := range manager.furnace {
+		craftingData.Furnace = append(craftingData.Furnace, types.FurnaceRecipeEntry{Input: recipe.input, Output: recipe.output})
+	}
+	return craftingData
+}
+
+// GetFurnaceOutput returns the item the input gets smelted into.
+// A bool is returned indicating if a furnace recipe for the input exists.
+func (manager *Manager) GetFurnaceOutput(input *items.Stack) (*items.Stack, bool) {
+	manager.mutex.RLock()
+	defer manager.mutex.RUnlock()
+	for _, recipe := range manager.furnace {
+		if matchesIngredient(recipe.input, recipe.tag, input) {
+			var output = *recipe.output
+			return &output, true
+		}
+	}
+	return nil, false
+}
+
+// Matches checks if crafting the results from the ingredients in the crafting grid with the given width
+// matches a registered recipe. Results may not be more than the output of the recipe.
+func (manager *Manager) Matches(grid []*items.Stack, gridWidth int, results []*items.Stack) bool {
+	manager.mutex.RLock()
+	defer manager.mutex.RUnlock()
+	for _, recipe := range manager.shaped {
+		if recipe.Matches(grid, gridWidth) && matchesOutput(recipe.output, results) {
+			return true
+		}
+	}
+	for _, recipe := range manager.shapeless {
+		if recipe.Matches(grid) && matchesOutput(recipe.output, results) {
+			return true
+		}
+	}
+	return false
+}
+
+// HandleTransaction handles an inventory transaction of a session.
+// Returns false if the transaction does not craft anything, in which case it should be handled as usual.
+// Crafting transactions only get applied if the items the server holds in the crafting grid of the player
+// craft a registered recipe, and the container actions of the transaction add exactly the crafted results.
+// One item of every ingredient gets taken from the crafting grid, regardless of the ingredients claimed by the client.
+// Invalid crafting transactions get rejected with the inventory resent.
+// Internal. Not to be used by plugins.
+func (manager *Manager) HandleTransaction(session *net.MinecraftSession, actions []io.InventoryActionIO) bool {
+	var player = session.GetPlayer()
+	var results []*items.Stack
+	var balance inventory.Balance
+	var crafting, valid = false, true
+	for _, action := range actions {
+		switch {
+		case action.Source == io.TodoSource && action.WindowId == io.CraftingUseIngredientWindow:
+			crafting = true
+		case action.Source == io.TodoSource && action.WindowId == io.CraftingResultWindow:
+			crafting = true
+			var result = action.OldItem
+			if isEmpty(result) {
+				result = action.NewItem
+			}
+			results = append(results, result)
+			balance.Remove(result)
+		case action.Source == io.ContainerSource:
+			var inv, ok = player.GetInventoryById(action.WindowId)
+			if !ok || int(action.InventorySlot) >= inv.GetSize() {
+				valid = false
+				continue
+			}
+			var current, _ = inv.GetItem(int(action.InventorySlot))
+			if !inventory.EqualStacks(current, action.OldItem) {
+				valid = false
+			}
+			balance.Change(action.OldItem, action.NewItem)
+		default:
+			valid = false
+		}
+	}
+	if !crafting {
+		return false
+	}
+
+	var grid = player.GetCraftingGrid().GetAll()
+	var maxSlot = -1
+	for slot, stack := range grid {
+		if !isEmpty(stack) {
+			maxSlot = slot
+		}
+	}
+	grid = grid[:maxSlot+1]
+	// The small crafting grid of the inventory is 2 slots wide, the grid of a crafting table 3.
+	// Grids only using the first 4 slots could be either of them.
+	valid = valid && balance.IsZero() && (manager.Matches(grid, 3, results) || (maxSlot < 4 && manager.Matches(grid, 2, results)))
+	if !valid {
+		session.SendInventory()
+		return true
+	}
+	for slot, stack := range grid {
+		if isEmpty(stack) {
+			continue
+		}
+		if stack.Count <= 1 {
+			player.GetCraftingGrid().ClearSlot(slot)
+			continue
+		}
+		var remaining = *stack
+		remaining.Count--
+		player.GetCraftingGrid().SetItem(&remaining, slot)
+	}
+	for _, action := range actions {
+		if action.Source == io.ContainerSource {
+			player.SetInventorySlot(action.WindowId, int(action.InventorySlot), action.NewItem)
+		}
+	}
+	return true
+}
+
+// matchesOutput checks if all crafted results are part of the output of a recipe.
+func matchesOutput(output []*items.Stack, results []*items.Stack) bool {
+	if len(results) == 0 {
+		return false
+	}
+	for _, result := range results {
+		var found = false
+		for _, stack := range output {
+			if !isEmpty(result) && result.Type.Equals(stack.Type) && result.Count <= stack.Count {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// toRecipe converts the record to a shaped recipe.
+func (record shapedRecord) toRecipe() (*ShapedRecipe, error) {
+	if len(record.Shape) == 0 {
+		return nil, InvalidShape
+	}
+	var width = len(record.Shape[0])
+	var input []*items.Stack
+	var inputTags []string
+	for _, row := range record.Shape {
+		if len(row) != width {
+			return nil, InvalidShape
+		}
+		for _, key := range row {
+			if key == ' ' {
+				input = append(input, nil)
+				inputTags = append(inputTags, "")
+				continue
+			}
+			var ingredient, ok = record.Keys[string(key)]
+			if !ok {
+				return nil, InvalidShape
+			}
+			var stack, err = ingredient.toStack()
+			if err != nil {
+				return nil, err
+			}
+			input = append(input, stack)
+			inputTags = append(inputTags, ingredient.Tag)
+		}
+	}
+	var output, err = toStacks(record.Output)
+	if err != nil {
+		return nil, err
+	}
+	return NewTaggedShapedRecipe(width, input, inputTags, output), nil
+}
+
+// toStack converts an ingredient record to the stack shown in the recipe book.
+// The stack of a tag ingredient is the first registered item of the tag.
+func (record ingredientRecord) toStack() (*items.Stack, error) {
+	if record.Tag == "" {
+		return toStack(record.Record)
+	}
+	for _, id := range tags.DefaultRegistry.GetValues(tags.Items, record.Tag) {
+		if stack, ok := items.DefaultManager.Get(id, 1); ok {
+			return stack, nil
+		}
+	}
+	return nil, UnknownTag
+}
+
+// toStack converts an item record of a recipe to a stack, with a count of 1 if no count was set.
+func toStack(record items.Record) (*items.Stack, error) {
+	if record.Count <= 0 {
+		record.Count = 1
+	}
+	var stack, ok = record.ToStack()
+	if !ok {
+		return nil, UnknownItem
+	}
+	return stack, nil
+}
+
+// toStacks converts all item records of a recipe to stacks.
+func toStacks(records []items.Record) ([]*items.Stack, error) {
+	var stacks = make([]*items.Stack, 0, len(records))
+	for _, record := range records {
+		var stack, err = toStack(record)
+		if err != nil {
+			return nil, err
+		}
+		stacks = append(stacks, stack)
+	}
+	return stacks, nil
+}
@@ -0,0 +1,160 @@
+package crafting
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/irmine/gomine/items"
+	"github.com/irmine/gomine/items/inventory/io"
+	"github.com/irmine/gomine/net"
+	"github.com/irmine/gomine/net/packets/data"
+	"github.com/irmine/gomine/players"
+	"github.com/irmine/gomine/tags"
+)
+
+func TestManager(t *testing.T) {
+	dir, _ := ioutil.TempDir("", "crafting")
+	defer os.RemoveAll(dir)
+
+	manager := NewManager(dir + "/recipes.json")
+	if err := manager.Load(); err != nil {
+		t.Fatal("could not create recipes file:", err)
+	}
+	ioutil.WriteFile(dir+"/recipes.json", []byte(`{
+		"shaped": [{"shape": ["SP", " P"], "keys": {"S": {"id": "minecraft:stone"}, "P": {"id": "minecraft:paper"}}, "output": [{"id": "minecraft:emerald", "count": 2}]}],
+		"shapeless": [{"input": [{"id": "minecraft:redstone"}, {"id": "minecraft:paper"}], "output": [{"id": "minecraft:emerald"}]}],
+		"furnace": [{"input": {"id": "minecraft:stone"}, "output": {"id": "minecraft:redstone"}}]
+	}`), 0644)
+	manager = NewManager(dir + "/recipes.json")
+	if err := manager.Load(); err != nil {
+		t.Fatal("could not load recipes:", err)
+	}
+	craftingData := manager.GetCraftingData()
+	if len(craftingData.Shaped) != 1 || len(craftingData.Shapeless) != 1 || len(craftingData.Furnace) != 1 {
+		t.Fatal("recipes were not loaded:", craftingData)
+	}
+
+	stone, _ := items.DefaultManager.Get("minecraft:stone", 1)
+	paper, _ := items.DefaultManager.Get("minecraft:paper", 1)
+	redstone, _ := items.DefaultManager.Get("minecraft:redstone", 1)
+	emeralds, _ := items.DefaultManager.Get("minecraft:emerald", 2)
+
+	// The shape placed in the bottom right of a crafting table.
+	grid := []*items.Stack{nil, nil, nil, nil, stone, paper, nil, nil, paper}
+	if !manager.Matches(grid, 3, []*items.Stack{emeralds}) {
+		t.Error("shaped recipe did not match")
+	}
+	mirrored := []*items.Stack{paper, stone, paper, nil}
+	if !manager.Matches(mirrored, 2, []*items.Stack{emeralds}) {
+		t.Error("mirrored shaped recipe did not match")
+	}
+	if manager.Matches([]*items.Stack{stone, paper, paper, nil}, 2, []*items.Stack{emeralds}) {
+		t.Error("shaped recipe matched a different shape")
+	}
+	tooMany, _ := items.DefaultManager.Get("minecraft:emerald", 3)
+	if manager.Matches(grid, 3, []*items.Stack{tooMany}) {
+		t.Error("recipe matched more output than it crafts")
+	}
+
+	emerald, _ := items.DefaultManager.Get("minecraft:emerald", 1)
+	if !manager.Matches([]*items.Stack{nil, paper, nil, redstone}, 2, []*items.Stack{emerald}) {
+		t.Error("shapeless recipe did not match")
+	}
+	if manager.Matches([]*items.Stack{paper, paper}, 2, []*items.Stack{emerald}) {
+		t.Error("shapeless recipe matched different ingredients")
+	}
+
+	if output, ok := manager.GetFurnaceOutput(stone); !ok || output.GetId() != "minecraft:redstone" {
+		t.Error("unexpected furnace output:", output)
+	}
+	if _, ok := manager.GetFurnaceOutput(paper); ok {
+		t.Error("paper should not have a furnace recipe")
+	}
+}
+
+func TestTags(t *testing.T) {
+	dir, _ := ioutil.TempDir("", "crafting")
+	defer os.RemoveAll(dir)
+	tags.DefaultRegistry.Register(tags.Items, "test:gems", "minecraft:emerald", "minecraft:redstone")
+	defer tags.DefaultRegistry.Deregister(tags.Items, "test:gems")
+
+	ioutil.WriteFile(dir+"/recipes.json", []byte(`{
+		"shaped": [{"shape": ["G", "P"], "keys": {"G": {"tag": "test:gems"}, "P": {"id": "minecraft:paper"}}, "output": [{"id": "minecraft:totem"}]}],
+		"shapeless": [{"input": [{"tag": "test:gems"}, {"tag": "test:gems"}], "output": [{"id": "minecraft:paper"}]}]
+	}`), 0644)
+	manager := NewManager(dir + "/recipes.json")
+	if err := manager.Load(); err != nil {
+		t.Fatal("could not load recipes:", err)
+	}
+
+	stone, _ := items.DefaultManager.Get("minecraft:stone", 1)
+	paper, _ := items.DefaultManager.Get("minecraft:paper", 1)
+	redstone, _ := items.DefaultManager.Get("minecraft:redstone", 1)
+	emerald, _ := items.DefaultManager.Get("minecraft:emerald", 1)
+	totem, _ := items.DefaultManager.Get("minecraft:totem", 1)
+
+	if !manager.Matches([]*items.Stack{redstone, nil, paper, nil}, 2, []*items.Stack{totem}) {
+		t.Error("shaped recipe did not match an item of the tag")
+	}
+	if manager.Matches([]*items.Stack{stone, nil, paper, nil}, 2, []*items.Stack{totem}) {
+		t.Error("shaped recipe matched an item outside of the tag")
+	}
+	if !manager.Matches([]*items.Stack{emerald, redstone}, 2, []*items.Stack{paper}) {
+		t.Error("shapeless recipe did not match items of the tag")
+	}
+
+	ioutil.WriteFile(dir+"/recipes.json", []byte(`{"shapeless": [{"input": [{"tag": "test:missing"}], "output": [{"id": "minecraft:paper"}]}]}`), 0644)
+	if err := NewManager(dir + "/recipes.json").Load(); err != UnknownTag {
+		t.Error("expected an unknown tag, got", err)
+	}
+}
+
+func TestHandleTransaction(t *testing.T) {
+	dir, _ := ioutil.TempDir("", "crafting")
+	defer os.RemoveAll(dir)
+	ioutil.WriteFile(dir+"/recipes.json", []byte(`{
+		"shapeless": [{"input": [{"id": "minecraft:redstone"}, {"id": "minecraft:paper"}], "output": [{"id": "minecraft:emerald"}]}]
+	}`), 0644)
+	manager := NewManager(dir + "/recipes.json")
+	if err := manager.Load(); err != nil {
+		t.Fatal("could not load recipes:", err)
+	}
+
+	session := net.NewMinecraftSession(nil, nil)
+	session.SetPlayer(players.NewPlayer(uuid.New(), "", 0, "Steve"))
+	grid := session.GetPlayer().GetCraftingGrid()
+	paper, _ := items.DefaultManager.Get("minecraft:paper", 3)
+	redstone, _ := items.DefaultManager.Get("minecraft:redstone", 1)
+	grid.SetItem(paper, 0)
+	grid.SetItem(redstone, 1)
+
+	air, _ := items.DefaultManager.Get("minecraft:air", 0)
+	emerald, _ := items.DefaultManager.Get("minecraft:emerald", 1)
+	claimed, _ := items.DefaultManager.Get("minecraft:diamond", 64)
+	craft := []io.InventoryActionIO{
+		{Source: io.TodoSource, WindowId: io.CraftingUseIngredientWindow, InventorySlot: 0, OldItem: claimed, NewItem: air},
+		{Source: io.TodoSource, WindowId: io.CraftingResultWindow, InventorySlot: 0, OldItem: emerald, NewItem: air},
+		{Source: io.ContainerSource, WindowId: data.ContainerCursor, InventorySlot: 0, OldItem: air, NewItem: emerald},
+	}
+	if !manager.HandleTransaction(session, craft) {
+		t.Fatal("crafting transaction was not handled")
+	}
+	if cursor, _ := session.GetPlayer().GetCursorInventory().GetItem(0); cursor == nil || cursor.GetId() != "minecraft:emerald" {
+		t.Error("crafted result was not put on the cursor:", cursor)
+	}
+	if stack, _ := grid.GetItem(0); stack == nil || stack.Count != 2 {
+		t.Error("expected one paper to be taken from the grid, got", stack)
+	}
+	if !grid.IsEmpty(1) {
+		t.Error("expected the redstone to be taken from the grid")
+	}
+
+	move := []io.InventoryActionIO{
+		{Source: io.ContainerSource, WindowId: data.ContainerCursor, InventorySlot: 0, OldItem: emerald, NewItem: air},
+	}
+	if manager.HandleTransaction(session, move) {
+		t.Error("transaction without crafting actions was handled as crafting")
+	}
+}
@@ -0,0 +1,245 @@
+package crafting
+
+import (
+	"github.com/google/uuid"
+	"github.com/irmine/gomine/items"
+)
+
+// ShapedRecipe is a crafting recipe of which the ingredients must be placed in a shape.
+// The shape may be placed anywhere in the crafting grid, and may be mirrored horizontally.
+type ShapedRecipe struct {
+	width, height int
+	input         []*items.Stack
+	tags          []string
+	output        []*items.Stack
+	uuid          uuid.UUID
+}
+
+// NewShapedRecipe returns a new shaped recipe with the given width.
+// The input holds the ingredients row by row, with nil for empty slots.
+// Empty rows and columns around the ingredients are removed from the shape.
+func NewShapedRecipe(width int, input []*items.Stack, output []*items.Stack) *ShapedRecipe {
+	return NewTaggedShapedRecipe(width, input, nil, output)
+}
+
+// NewTaggedShapedRecipe returns a new shaped recipe with the given width, of which ingredients may be item tags.
+// The tags hold the item tag of every ingredient in the input, or an empty string for ingredients that are a single item.
+// Any item of the tag of an ingredient matches it, while the stack of the ingredient is the item shown in the recipe book.
+func NewTaggedShapedRecipe(width int, input []*items.Stack, ingredientTags []string, output []*items.Stack) *ShapedRecipe {
+	var minX, minY, maxX, maxY = getBounds(input, width)
+	var shape = trim(input, width, minX, minY, maxX, maxY)
+	var shapeTags = trim(fillTags(ingredientTags, len(input)), width, minX, minY, maxX, maxY)
+	return &ShapedRecipe{maxX - minX + 1, maxY - minY + 1, shape, shapeTags, output, uuid.New()}
+}
+
+// GetSize returns the width and height of the shape of the recipe.
+func (recipe *ShapedRecipe) GetSize() (int, int) {
+	return recipe.width, recipe.height
+}
+
+// GetInput returns the ingredients of the recipe row by row, with nil for empty slots.
+func (recipe *ShapedRecipe) GetInput() []*items.Stack {
+	return recipe.input
+}
+
+// GetTags returns the item tags of the ingredients of the recipe row by row,
+// with an empty string for ingredients that are a single item.
+func (recipe *ShapedRecipe) GetTags() []string {
+	return recipe.tags
+}
+
+// GetOutput returns the items crafted by the recipe.
+func (recipe *ShapedRecipe) GetOutput() []*items.Stack {
+	return recipe.output
+}
+
+// Matches checks if the ingredients in the crafting grid with the given width match the recipe.
+// The grid holds the ingredients row by row, with nil or air for empty slots.
+func (recipe *ShapedRecipe) Matches(grid []*items.Stack, gridWidth int) bool {
+	var minX, minY, maxX, maxY = getBounds(grid, gridWidth)
+	var shape, width, height = trim(grid, gridWidth, minX, minY, maxX, maxY), maxX - minX + 1, maxY - minY + 1
+	if width != recipe.width || height != recipe.height {
+		return false
+	}
+	var matches, mirrored = true, true
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			var ingredient, tag = recipe.input[y*width+x], recipe.tags[y*width+x]
+			if !matchesIngredient(ingredient, tag, shape[y*width+x]) {
+				matches = false
+			}
+			if !matchesIngredient(ingredient, tag, shape[y*width+width-1-x]) {
+				mirrored = false
+			}
+		}
+	}
+	return matches || mirrored
+}
+
+// ShapelessRecipe is a crafting recipe of which the ingredients may be placed anywhere.
+type ShapelessRecipe struct {
+	input  []*items.Stack
+	tags   []string
+	output []*items.Stack
+	uuid   uuid.UUID
+}
+
+// NewShapelessRecipe returns a new shapeless recipe with the given ingredients, one for every slot.
+func NewShapelessRecipe(input []*items.Stack, output []*items.Stack) *ShapelessRecipe {
+	return NewTaggedShapelessRecipe(input, nil, output)
+}
+
+// NewTaggedShapelessRecipe returns a new shapeless recipe with the given ingredients, of which ingredients may be item tags.
+// The tags hold the item tag of every ingredient in the input, or an empty string for ingredients that are a single item.
+func NewTaggedShapelessRecipe(input []*items.Stack, ingredientTags []string, output []*items.Stack) *ShapelessRecipe {
+	return &ShapelessRecipe{input, fillTags(ingredientTags, len(input)), output, uuid.New()}
+}
+
+// GetInput returns the ingredients of the recipe.
+func (recipe *ShapelessRecipe) GetInput() []*items.Stack {
+	return recipe.input
+}
+
+// GetTags returns the item tags of the ingredients of the recipe,
+// with an empty string for ingredients that are a single item.
+func (recipe *ShapelessRecipe) GetTags() []string {
+	return recipe.tags
+}
+
+// GetOutput returns the items crafted by the recipe.
+func (recipe *ShapelessRecipe) GetOutput() []*items.Stack {
+	return recipe.output
+}
+
+// Matches checks if the ingredients in the crafting grid match the recipe, regardless of where they are placed.
+func (recipe *ShapelessRecipe) Matches(grid []*items.Stack) bool {
+	var left []*items.Stack
+	for _, stack := range grid {
+		if !isEmpty(stack) {
+			left = append(left, stack)
+		}
+	}
+	if len(left) != len(recipe.input) {
+		return false
+	}
+	for i, ingredient := range recipe.input {
+		var found = false
+		for j, stack := range left {
+			if matchesIngredient(ingredient, recipe.tags[i], stack) {
+				left = append(left[:j], left[j+1:]...)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// FurnaceRecipe is a recipe smelting an item into another item.
+type FurnaceRecipe struct {
+	input  *items.Stack
+	tag    string
+	output *items.Stack
+}
+
+// NewFurnaceRecipe returns a new furnace recipe smelting the input into the output.
+func NewFurnaceRecipe(input *items.Stack, output *items.Stack) *FurnaceRecipe {
+	return NewTaggedFurnaceRecipe(input, "", output)
+}
+
+// NewTaggedFurnaceRecipe returns a new furnace recipe smelting any item of the item tag into the output.
+// The input is the item shown in the recipe book.
+func NewTaggedFurnaceRecipe(input *items.Stack, tag string, output *items.Stack) *FurnaceRecipe {
+	return &FurnaceRecipe{input, tag, output}
+}
+
+// GetInput returns the item smelted by the recipe.
+func (recipe *FurnaceRecipe) GetInput() *items.Stack {
+	return recipe.input
+}
+
+// GetTag returns the item tag smelted by the recipe,
+// or an empty string if only the input is smelted by it.
+func (recipe *FurnaceRecipe) GetTag() string {
+	return recipe.tag
+}
+
+// GetOutput returns the item produced by the recipe.
+func (recipe *FurnaceRecipe) GetOutput() *items.Stack {
+	return recipe.output
+}
+
+// isEmpty checks if the stack is an empty slot.
+func isEmpty(stack *items.Stack) bool {
+	return stack == nil || stack.Count == 0 || stack.GetId() == "minecraft:air"
+}
+
+// matchesIngredient checks if the stack in a crafting grid slot matches the ingredient.
+// Ingredients must be of the same type, or hold the item tag of the ingredient if it has one,
+// while the count of the stack does not matter.
+func matchesIngredient(ingredient *items.Stack, tag string, stack *items.Stack) bool {
+	if isEmpty(ingredient) || isEmpty(stack) {
+		return isEmpty(ingredient) == isEmpty(stack)
+	}
+	if tag != "" {
+		return stack.HasTag(tag)
+	}
+	return ingredient.Type.Equals(stack.Type)
+}
+
+// fillTags returns the ingredient tags extended with empty tags up to the amount of ingredients.
+func fillTags(ingredientTags []string, ingredients int) []string {
+	var filled = make([]string, ingredients)
+	copy(filled, ingredientTags)
+	return filled
+}
+
+// getBounds returns the bounds of the ingredients in the grid with the given width.
+// The maximum X and Y are lower than the minimum if the grid holds no ingredients.
+func getBounds(grid []*items.Stack, width int) (minX, minY, maxX, maxY int) {
+	if width <= 0 {
+		return 0, 0, -1, -1
+	}
+	var height = (len(grid) + width - 1) / width
+	minX, minY, maxX, maxY = width, height, -1, -1
+	for i, stack := range grid {
+		if isEmpty(stack) {
+			continue
+		}
+		var x, y = i % width, i / width
+		if x < minX {
+			minX = x
+		}
+		if x > maxX {
+			maxX = x
+		}
+		if y < minY {
+			minY = y
+		}
+		if y > maxY {
+			maxY = y
+		}
+	}
+	if maxX < 0 {
+		return 0, 0, -1, -1
+	}
+	return minX, minY, maxX, maxY
+}
+
+// trim removes all rows and columns outside of the bounds from the grid with the given width.
+func trim[T any](grid []T, width int, minX, minY, maxX, maxY int) []T {
+	var trimmed []T
+	for y := minY; y <= maxY; y++ {
+		for x := minX; x <= maxX; x++ {
+			var value T
+			if i := y*width + x; i < len(grid) {
+				value = grid[i]
+			}
+			trimmed = append(trimmed, value)
+		}
+	}
+	return trimmed
+}
@@ -0,0 +1,187 @@
+package gomine
+
+import (
+	"bytes"
+	"context"
+	"runtime"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/irmine/gomine/diagnostics"
+	"github.com/irmine/worlds/chunks"
+)
+
+// DebugLogLines is the amount of recent log lines kept for debug dumps.
+const DebugLogLines = 1000
+
+// debugTimings holds the tick timings of the server at the moment of a debug dump.
+type debugTimings struct {
+	TickRate            int     `yaml:"Tick Rate"`
+	CurrentTick         int64   `yaml:"Current Tick"`
+	TPS                 float64 `yaml:"TPS"`
+	AverageTickDuration string  `yaml:"Average Tick Duration"`
+	Goroutines          int     `yaml:"Goroutines"`
+	HeapAlloc           uint64  `yaml:"Heap Allocated"`
+	HeapObjects         uint64  `yaml:"Heap Objects"`
+	GCCount             uint32  `yaml:"GC Count"`
+}
+
+// debugPlugin holds the information of a loaded plugin in a debug dump.
+type debugPlugin struct {
+	Name       string `yaml:"Name"`
+	Version    string `yaml:"Version"`
+	Author     string `yaml:"Author"`
+	APIVersion string `yaml:"API Version"`
+	Enabled    bool   `yaml:"Enabled"`
+}
+
+// debugWorld holds the statistics of a loaded world in a debug dump.
+type debugWorld struct {
+	Name         string            `yaml:"Name"`
+	CurrentTick  int64             `yaml:"Current Tick"`
+	Players      int               `yaml:"Players"`
+	LoadedChunks int               `yaml:"Loaded Chunks"`
+	Storage      string            `yaml:"Storage,omitempty"`
+	Dimensions   map[string]string `yaml:"Dimensions,omitempty"`
+	Error        string            `yaml:"Error,omitempty"`
+}
+
+// CreateDebugDump creates a debug dump archive in the debug directory of the server,
+// holding the configuration with secrets redacted, timings, the TPS history, loaded plugins,
+// world statistics, goroutine stacks and recent logs.
+// The state of the server is captured at the start of the next tick, so that all files
+// describe the same moment. The archive is written asynchronously, after which the callback
+// is called on the main tick with the path of the archive.
+func (server *Server) CreateDebugDump(callback func(path string, err error)) {
+	server.Scheduler.ScheduleDelayed(func() {
+		var dump, levels, err = server.snapshotDebugDump()
+		if err != nil {
+			callback("", err)
+			return
+		}
+		server.Scheduler.RunAsyncContext(server.ctx, func(ctx context.Context) interface{} {
+			// Storage statistics are read from disk, so they are added outside of the tick.
+			for i, world := range levels {
+				if err := ctx.Err(); err != nil {
+					return err
+				}
+				var stats, err = server.LevelStorage.GetStorageStats(world.Name)
+				if err != nil {
+					levels[i].Error = err.Error()
+					continue
+				}
+				levels[i].Storage = formatDumpStats(stats.StorageStats.Size, stats.Regions, stats.Chunks)
+				levels[i].Dimensions = make(map[string]string, len(stats.Dimensions))
+				for name, dimension := range stats.Dimensions {
+					levels[i].Dimensions[name] = formatDumpStats(dimension.Size, dimension.Regions, dimension.Chunks)
+				}
+			}
+			if err := dump.AddYAML("worlds.yml", levels); err != nil {
+				return err
+			}
+			var path, err = dump.Save(server.ServerPath + "debug/")
+			if err != nil {
+				return err
+			}
+			return path
+		}, func(result interface{}) {
+			if err, ok := result.(error); ok {
+				callback("", err)
+				return
+			}
+			callback(result.(string), nil)
+		})
+	}, 0)
+}
+
+// snapshotDebugDump captures the in-memory state of the server into a new debug dump.
+// The worlds returned still need their storage statistics to be read.
+func (server *Server) snapshotDebugDump() (*diagnostics.Dump, []debugWorld, error) {
+	var dump = diagnostics.NewDump(time.Now())
+	if err := dump.AddGoroutines("goroutines.txt"); err != nil {
+		return nil, nil, err
+	}
+	if err := dump.AddConfig("config.yml", server.Config); err != nil {
+		return nil, nil, err
+	}
+
+	var memStats runtime.MemStats
+	runtime.ReadMemStats(&memStats)
+	var timings = debugTimings{
+		TickRate:            server.GetTickRate(),
+		CurrentTick:         server.GetCurrentTick(),
+		TPS:                 server.TickMeter.GetTPS(),
+		AverageTickDuration: server.TickMeter.GetAverageTickDuration().String(),
+		Goroutines:          runtime.NumGoroutine(),
+		HeapAlloc:           memStats.HeapAlloc,
+		HeapObjects:         memStats.HeapObjects,
+		GCCount:             memStats.NumGC,
+	}
+	if err := dump.AddYAML("timings.yml", timings); err != nil {
+		return nil, nil, err
+	}
+	var metrics = bytes.NewBuffer(nil)
+	if _, err := server.Metrics.WriteTo(metrics); err != nil {
+		return nil, nil, err
+	}
+	dump.AddFile("metrics.txt", metrics.Bytes())
+
+	var history = server.TickMeter.GetHistory()
+	var lines = make([]string, 0, len(history)+1)
+	lines = append(lines, "time tps average_tick_ms")
+	for _, sample := range history {
+		var milliseconds = strconv.FormatFloat(sample.AverageTickDuration.Seconds()*1000, 'f', 2, 64)
+		lines = append(lines, sample.Time.Format(time.RFC3339)+" "+strconv.FormatFloat(sample.TPS, 'f', 0, 64)+" "+milliseconds)
+	}
+	dump.AddText("tps.txt", lines)
+
+	var plugins = make([]debugPlugin, 0)
+	for name, plugin := range server.PluginManager.GetPlugins() {
+		plugins = append(plugins, debugPlugin{Name: name, Version: plugin.GetVersion(), Author: plugin.GetAuthor(), APIVersion: plugin.GetAPIVersion(), Enabled: server.PluginManager.IsPluginEnabled(name)})
+	}
+	sort.Slice(plugins, func(i, j int) bool {
+		return plugins[i].Name < plugins[j].Name
+	})
+	if err := dump.AddYAML("plugins.yml", plugins); err != nil {
+		return nil, nil, err
+	}
+
+	var levels = make([]debugWorld, 0)
+	for _, level := range server.LevelManager.GetLevels() {
+		levels = append(levels, debugWorld{Name: level.GetName(), CurrentTick: level.GetCurrentTick()})
+	}
+	sort.Slice(levels, func(i, j int) bool {
+		return levels[i].Name < levels[j].Name
+	})
+	var indices = make(map[string]int, len(levels))
+	var loaded = make(map[string]map[*chunks.Chunk]bool, len(levels))
+	for i, world := range levels {
+		indices[world.Name] = i
+		loaded[world.Name] = make(map[*chunks.Chunk]bool)
+	}
+	for _, session := range server.SessionManager.GetSessions() {
+		var dimension = session.GetPlayer().GetDimension()
+		if dimension == nil {
+			continue
+		}
+		var name = dimension.GetLevel().GetName()
+		if i, ok := indices[name]; ok {
+			levels[i].Players++
+			for _, chunk := range session.GetChunkLoader().GetLoadedChunks() {
+				loaded[name][chunk] = true
+			}
+		}
+	}
+	for name, i := range indices {
+		levels[i].LoadedChunks = len(loaded[name])
+	}
+
+	dump.AddText("logs.txt", server.LogBuffer.GetLines())
+	return dump, levels, nil
+}
+
+// formatDumpStats returns the size, region count and chunk count as readable text.
+func formatDumpStats(size int64, regions int, chunks int) string {
+	return strconv.FormatFloat(float64(size)/1024/1024, 'f', 2, 64) + " MB, " + strconv.Itoa(regions) + " regions, " + strconv.Itoa(chunks) + " chunks"
+}
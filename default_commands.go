@@ -1,12 +1,34 @@
 package gomine
 
 import (
+	"github.com/golang/geo/r3"
 	"github.com/irmine/gomine/commands"
+	"github.com/irmine/gomine/commands/arguments"
+	"github.com/irmine/gomine/commands/selectors"
+	"github.com/irmine/gomine/cosmetics"
+	"github.com/irmine/gomine/friends"
+	"github.com/irmine/gomine/items"
+	"github.com/irmine/gomine/kits"
+	"github.com/irmine/gomine/lang"
+	"github.com/irmine/gomine/levels"
+	"github.com/irmine/gomine/mobs"
 	"github.com/irmine/gomine/net"
+	"github.com/irmine/gomine/parties"
+	"github.com/irmine/gomine/tags"
+	"github.com/irmine/gomine/teleports"
 	"github.com/irmine/gomine/text"
+	"github.com/irmine/worlds"
+	"github.com/irmine/worlds/chunks"
+	"sort"
 	"strconv"
+	"strings"
+	"time"
 )
 
+// KitSoftEnum is the name of the soft enum holding the names of all kits,
+// which is used to complete the kit argument of the kit command.
+const KitSoftEnum = "Kit"
+
 func NewTest(_ *Server) *commands.Command {
 	cmd := commands.NewCommand("chunk", "Lists the current chunk", "none", []string{}, func(sender commands.Sender) {
 		if session, ok := sender.(*net.MinecraftSession); ok {
@@ -20,12 +42,10 @@ func NewTest(_ *Server) *commands.Command {
 
 func NewList(server *Server) *commands.Command {
 	var list = commands.NewCommand("list", "Lists all players online", "gomine.list", []string{}, func(sender commands.Sender) {
-		var s = "s"
+		var playerList = commands.Translate(sender, "commands.list.header", len(server.SessionManager.GetSessions())) + "\n"
 		if len(server.SessionManager.GetSessions()) == 1 {
-			s = ""
+			playerList = commands.Translate(sender, "commands.list.header.one") + "\n"
 		}
-
-		var playerList = text.BrightGreen + "-----" + text.White + " Player List (" + strconv.Itoa(len(server.SessionManager.GetSessions())) + " Player" + s + ") " + text.BrightGreen + "-----\n"
 		for name, player := range server.SessionManager.GetSessions() {
 			playerList += text.BrightGreen + name + ": " + text.Yellow + text.Bold + strconv.Itoa(int(player.GetPing())) + "ms" + text.Reset + "\n"
 		}
@@ -38,9 +58,9 @@ func NewList(server *Server) *commands.Command {
 func NewPing() *commands.Command {
 	var ping = commands.NewCommand("ping", "Returns your latency", "gomine.ping", []string{}, func(sender commands.Sender) {
 		if session, ok := sender.(*net.MinecraftSession); ok {
-			session.SendMessage(text.Yellow+"Your current latency/ping is:", session.GetPing())
+			commands.Tell(session, "commands.ping.latency", session.GetPing())
 		} else {
-			sender.SendMessage(text.Red + "Please run this command as a player.")
+			commands.Tell(sender, "commands.generic.playerOnly")
 		}
 	})
 	ping.ExemptFromPermissionCheck(true)
@@ -56,3 +76,1141 @@ func NewStop(server *Server) *commands.Command {
 		server.Shutdown()
 	})
 }
+
+func NewParty(server *Server) *commands.Command {
+	var party = commands.NewCommand("party", "Manages your party", "gomine.party", []string{"p"}, func(sender commands.Sender, action string, target string) {
+		var session, ok = sender.(*net.MinecraftSession)
+		if !ok {
+			commands.Tell(sender, "commands.generic.playerOnly")
+			return
+		}
+		var err error
+		switch action {
+		case "create":
+			if _, err = server.PartyManager.Create(session); err == nil {
+				commands.Tell(session, "commands.party.created")
+			}
+		case "invite":
+			var invited, online = server.SessionManager.GetSession(target)
+			if !online {
+				commands.Tell(session, "commands.generic.offline", target)
+				return
+			}
+			if _, inParty := server.PartyManager.GetParty(session.GetName()); !inParty {
+				if _, err = server.PartyManager.Create(session); err != nil {
+					break
+				}
+			}
+			if err = server.PartyManager.Invite(session, invited); err == nil {
+				commands.Tell(session, "commands.party.invited", invited.GetDisplayName())
+				commands.Tell(invited, "commands.party.invitation", session.GetDisplayName(), session.GetName())
+			}
+		case "accept":
+			if err = server.PartyManager.Accept(session, target); err == nil {
+				var p, _ = server.PartyManager.GetParty(session.GetName())
+				for _, member := range p.GetMembers() {
+					commands.Tell(member, "commands.party.joined", session.GetDisplayName())
+				}
+			}
+		case "leave":
+			var p, inParty = server.PartyManager.GetParty(session.GetName())
+			if err = server.PartyManager.Leave(session.GetName()); err == nil {
+				commands.Tell(session, "commands.party.left")
+				if inParty {
+					for _, member := range p.GetMembers() {
+						commands.Tell(member, "commands.party.memberLeft", session.GetDisplayName())
+					}
+				}
+			}
+		case "kick":
+			if err = server.PartyManager.Kick(session, target); err == nil {
+				commands.Tell(session, "commands.party.kicked", target)
+				if kicked, online := server.SessionManager.GetSession(target); online {
+					commands.Tell(kicked, "commands.party.wasKicked")
+				}
+			}
+		case "disband":
+			var p, inParty = server.PartyManager.GetParty(session.GetName())
+			if !inParty {
+				err = parties.NotInParty
+				break
+			}
+			var members = p.GetMembers()
+			if err = server.PartyManager.Disband(session); err == nil {
+				for _, member := range members {
+					commands.Tell(member, "commands.party.disbanded")
+				}
+			}
+		case "chat":
+			if _, inParty := server.PartyManager.GetParty(session.GetName()); !inParty {
+				err = parties.NotInParty
+				break
+			}
+			var value = !server.PartyManager.IsPartyChat(session.GetName())
+			server.PartyManager.SetPartyChat(session.GetName(), value)
+			if value {
+				commands.Tell(session, "commands.party.chat.party")
+			} else {
+				commands.Tell(session, "commands.party.chat.everybody")
+			}
+		case "tp":
+			if err = server.PartyManager.Teleport(session); err == nil {
+				commands.Tell(session, "commands.party.teleported")
+			}
+		case "list":
+			var p, inParty = server.PartyManager.GetParty(session.GetName())
+			if !inParty {
+				err = parties.NotInParty
+				break
+			}
+			var list = commands.Translate(session, "commands.party.list.header", p.GetMemberCount()) + "\n"
+			for name := range p.GetMembers() {
+				if p.IsLeader(name) {
+					list += commands.Translate(session, "commands.party.list.leader", name) + "\n"
+				} else {
+					list += text.Yellow + name + "\n"
+				}
+			}
+			session.SendMessage(list)
+		}
+		if err != nil {
+			commands.Tell(session, "commands.party.failed", action, err)
+		}
+	})
+	party.AppendArgument(arguments.NewStringEnum("action", false, []string{"create", "invite", "accept", "leave", "kick", "disband", "chat", "tp", "list"}))
+	party.AppendArgument(arguments.NewString("player", true))
+	party.ExemptFromPermissionCheck(true)
+	return party
+}
+
+func NewFriend(server *Server) *commands.Command {
+	var friend = commands.NewCommand("friend", "Manages your friends", "gomine.friend", []string{"f"}, func(sender commands.Sender, action string, target string) {
+		var session, ok = sender.(*net.MinecraftSession)
+		if !ok {
+			commands.Tell(sender, "commands.generic.playerOnly")
+			return
+		}
+		var err error
+		switch action {
+		case "add":
+			var other, online = server.SessionManager.GetSession(target)
+			if !online {
+				commands.Tell(session, "commands.generic.offline", target)
+				return
+			}
+			if err = server.FriendManager.SendRequest(session, other); err == nil {
+				commands.Tell(session, "commands.friend.requestSent", other.GetDisplayName())
+			}
+		case "accept":
+			if err = server.FriendManager.Accept(session, target); err == nil {
+				commands.Tell(session, "commands.friend.accepted", target)
+			}
+		case "deny":
+			if err = server.FriendManager.Deny(session, target); err == nil {
+				commands.Tell(session, "commands.friend.denied", target)
+			}
+		case "remove":
+			if err = server.FriendManager.Remove(session, target); err == nil {
+				commands.Tell(session, "commands.friend.removed", target)
+			}
+		case "list":
+			var names map[string]string
+			if names, err = server.FriendManager.GetFriends(session.GetXUID()); err != nil {
+				break
+			}
+			var list = commands.Translate(session, "commands.friend.list.header", len(names)) + "\n"
+			for xuid, name := range names {
+				if server.FriendManager.IsOnline(xuid) {
+					list += commands.Translate(session, "commands.friend.list.online", name) + "\n"
+				} else {
+					list += commands.Translate(session, "commands.friend.list.offline", name) + "\n"
+				}
+			}
+			session.SendMessage(list)
+		case "requests":
+			var list *friends.List
+			if list, err = server.FriendManager.GetList(session.GetXUID()); err != nil {
+				break
+			}
+			var requests = commands.Translate(session, "commands.friend.requests.header") + "\n"
+			for _, name := range list.Requests {
+				requests += text.Yellow + name + "\n"
+			}
+			session.SendMessage(requests)
+		}
+		if err != nil {
+			commands.Tell(session, "commands.friend.failed", action, err)
+		}
+	})
+	friend.AppendArgument(arguments.NewStringEnum("action", false, []string{"add", "accept", "deny", "remove", "list", "requests"}))
+	friend.AppendArgument(arguments.NewString("player", true))
+	friend.ExemptFromPermissionCheck(true)
+	return friend
+}
+
+func NewTrade(server *Server) *commands.Command {
+	var trade = commands.NewCommand("trade", "Trades items with another player", "gomine.trade", []string{}, func(sender commands.Sender, action string, target string) {
+		var session, ok = sender.(*net.MinecraftSession)
+		if !ok {
+			commands.Tell(sender, "commands.generic.playerOnly")
+			return
+		}
+		var err error
+		switch action {
+		case "request", "accept":
+			var other, online = server.SessionManager.GetSession(target)
+			if !online {
+				commands.Tell(session, "commands.generic.offline", target)
+				return
+			}
+			if action == "accept" {
+				err = server.TradeManager.Accept(session, other)
+			} else if err = server.TradeManager.Request(session, other); err == nil {
+				if _, trading := server.TradeManager.GetTrade(session.GetName()); !trading {
+					commands.Tell(session, "commands.trade.requestSent", other.GetDisplayName())
+				}
+			}
+		case "deny":
+			if err = server.TradeManager.Deny(session, target); err == nil {
+				commands.Tell(session, "commands.trade.denied", target)
+			}
+		case "cancel":
+			err = server.TradeManager.Cancel(session.GetName())
+		}
+		if err != nil {
+			commands.Tell(session, "commands.trade.failed", action, err)
+		}
+	})
+	trade.AppendArgument(arguments.NewStringEnum("action", false, []string{"request", "accept", "deny", "cancel"}))
+	trade.AppendArgument(arguments.NewString("player", true))
+	trade.ExemptFromPermissionCheck(true)
+	return trade
+}
+
+func NewMarket(server *Server) *commands.Command {
+	var market = commands.NewCommand("market", "Buys and sells items on the player market", "gomine.market", []string{"ah"}, func(sender commands.Sender, action string) {
+		var session, ok = sender.(*net.MinecraftSession)
+		if !ok {
+			commands.Tell(sender, "commands.generic.playerOnly")
+			return
+		}
+		switch action {
+		case "browse":
+			server.MarketManager.OpenBrowser(session)
+		case "sell":
+			server.MarketManager.OpenSellForm(session)
+		case "listings":
+			server.MarketManager.OpenOwnListings(session)
+		case "reclaim":
+			var count, err = server.MarketManager.Reclaim(session)
+			session.SendInventory()
+			if count > 0 {
+				commands.Tell(session, "commands.market.reclaimed", count)
+			}
+			if err != nil {
+				commands.Tell(session, "commands.market.reclaimFailed", err)
+			} else if count == 0 {
+				commands.Tell(session, "commands.market.nothingToReclaim")
+			}
+		}
+	})
+	market.AppendArgument(arguments.NewStringEnum("action", false, []string{"browse", "sell", "listings", "reclaim"}))
+	market.ExemptFromPermissionCheck(true)
+	return market
+}
+
+func NewMessage(server *Server) *commands.Command {
+	var msg = commands.NewCommand("msg", "Sends a private message to a player", "gomine.msg", []string{"tell", "w"}, func(sender commands.Sender, target string, message string) {
+		var session, ok = sender.(*net.MinecraftSession)
+		if !ok {
+			commands.Tell(sender, "commands.generic.playerOnly")
+			return
+		}
+		var receiver, online = server.SessionManager.GetSession(target)
+		if !online {
+			commands.Tell(session, "commands.generic.offline", target)
+			return
+		}
+		if message == "" {
+			commands.Tell(session, "commands.msg.empty")
+			return
+		}
+		if err := server.ChatManager.SendPrivate(session, receiver, message); err != nil {
+			commands.Tell(session, "commands.msg.failed", err)
+		}
+	})
+	var message = arguments.NewString("message", true)
+	message.SetInputAmount(256)
+	msg.AppendArgument(arguments.NewString("player", false))
+	msg.AppendArgument(message)
+	msg.ExemptFromPermissionCheck(true)
+	return msg
+}
+
+func NewChat(server *Server) *commands.Command {
+	var chat = commands.NewCommand("chat", "Switches the chat channel you talk in", "gomine.chat", []string{}, func(sender commands.Sender, channel string) {
+		var session, ok = sender.(*net.MinecraftSession)
+		if !ok {
+			commands.Tell(sender, "commands.generic.playerOnly")
+			return
+		}
+		if err := server.ChatManager.SetChannel(session.GetName(), channel); err != nil {
+			commands.Tell(session, "commands.chat.failed", err)
+			return
+		}
+		commands.Tell(session, "commands.chat.switched", channel)
+	})
+	chat.AppendArgument(arguments.NewString("channel", false))
+	chat.ExemptFromPermissionCheck(true)
+	return chat
+}
+
+func NewMute(server *Server) *commands.Command {
+	var mute = commands.NewCommand("mute", "Mutes a player", "gomine.mute", []string{}, func(sender commands.Sender, target string, duration string, reason string) {
+		var length time.Duration
+		if duration != "" && duration != "permanent" {
+			var err error
+			if length, err = time.ParseDuration(duration); err != nil || length <= 0 {
+				commands.Tell(sender, "commands.mute.invalidDuration", duration)
+				return
+			}
+		}
+		if err := server.ChatManager.Mute(target, length, reason); err != nil {
+			commands.Tell(sender, "commands.mute.failed", target, err)
+			return
+		}
+		var session, online = server.SessionManager.GetSession(target)
+		if length > 0 {
+			commands.Tell(sender, "commands.mute.temporary", target, length)
+			if online {
+				commands.Tell(session, "commands.mute.notify.temporary", length, reason)
+			}
+			return
+		}
+		commands.Tell(sender, "commands.mute.permanent", target)
+		if online {
+			commands.Tell(session, "commands.mute.notify.permanent", reason)
+		}
+	})
+	var reason = arguments.NewString("reason", true)
+	reason.SetInputAmount(256)
+	mute.AppendArgument(arguments.NewString("player", false))
+	mute.AppendArgument(arguments.NewString("duration", true))
+	mute.AppendArgument(reason)
+	return mute
+}
+
+func NewUnmute(server *Server) *commands.Command {
+	var unmute = commands.NewCommand("unmute", "Unmutes a player", "gomine.unmute", []string{}, func(sender commands.Sender, target string) {
+		if err := server.ChatManager.Unmute(target); err != nil {
+			commands.Tell(sender, "commands.unmute.failed", target, err)
+			return
+		}
+		commands.Tell(sender, "commands.unmute.success", target)
+		if session, ok := server.SessionManager.GetSession(target); ok {
+			commands.Tell(session, "commands.unmute.notify")
+		}
+	})
+	unmute.AppendArgument(arguments.NewString("player", false))
+	return unmute
+}
+
+func NewKit(server *Server) *commands.Command {
+	var kit = commands.NewCommand("kit", "Claims a kit, or creates one from your inventory", "gomine.kit", []string{"kits"}, func(sender commands.Sender, action string, name string, cooldown string, permission string) {
+		var session, ok = sender.(*net.MinecraftSession)
+		if !ok {
+			commands.Tell(sender, "commands.generic.playerOnly")
+			return
+		}
+		switch action {
+		case "list":
+			commands.Tell(session, "commands.kit.list", strings.Join(server.KitManager.GetKitNames(), ", "))
+		case "create", "delete":
+			if !session.HasPermission("gomine.kit.edit") {
+				commands.Tell(session, "commands.kit.noEditPermission")
+				return
+			}
+			if name == "" {
+				commands.Tell(session, "commands.kit.noName")
+				return
+			}
+			if action == "delete" {
+				server.KitManager.RemoveKit(name)
+				server.CommandManager.RemoveSoftEnumValues(KitSoftEnum, name)
+			} else {
+				var length time.Duration
+				if cooldown != "" {
+					var err error
+					if length, err = time.ParseDuration(cooldown); err != nil {
+						commands.Tell(session, "commands.kit.invalidCooldown", cooldown)
+						return
+					}
+				}
+				var stacks []*items.Stack
+				for _, stack := range session.GetPlayer().GetInventory().GetAll() {
+					if stack != nil {
+						var c = *stack
+						stacks = append(stacks, &c)
+					}
+				}
+				server.KitManager.AddKit(kits.NewKit(name, permission, length, stacks))
+				server.CommandManager.AddSoftEnumValues(KitSoftEnum, name)
+			}
+			if err := server.KitManager.Save(); err != nil {
+				commands.Tell(session, "commands.kit.saveFailed", err)
+				return
+			}
+			commands.Tell(session, "commands.kit."+action+"d", name)
+		default:
+			var err = server.KitManager.Claim(session, action)
+			if err == kits.OnCooldown {
+				var kit, _ = server.KitManager.GetKit(action)
+				commands.Tell(session, "commands.kit.cooldown", server.KitManager.GetCooldown(session, kit).Round(time.Second))
+				return
+			}
+			if err != nil {
+				commands.Tell(session, "commands.kit.claimFailed", err)
+				return
+			}
+			commands.Tell(session, "commands.kit.claimed", action)
+		}
+	})
+	kit.AppendArgument(arguments.NewSoftEnum("kit", false, KitSoftEnum))
+	kit.AppendArgument(arguments.NewString("name", true))
+	kit.AppendArgument(arguments.NewString("cooldown", true))
+	kit.AppendArgument(arguments.NewString("permission", true))
+	kit.ExemptFromPermissionCheck(true)
+	return kit
+}
+
+func NewRewards(server *Server) *commands.Command {
+	var rewards = commands.NewCommand("rewards", "Shows your daily and playtime rewards", "gomine.rewards", []string{"daily"}, func(sender commands.Sender) {
+		if session, ok := sender.(*net.MinecraftSession); ok {
+			server.RewardManager.OpenMenu(session)
+		} else {
+			commands.Tell(sender, "commands.generic.playerOnly")
+		}
+	})
+	rewards.ExemptFromPermissionCheck(true)
+	return rewards
+}
+
+func NewTop(server *Server) *commands.Command {
+	var top = commands.NewCommand("top", "Shows the top players of a leaderboard", "gomine.top", []string{"leaderboard"}, func(sender commands.Sender, stat string, display string) {
+		var session, isSession = sender.(*net.MinecraftSession)
+		if stat == "hide" && isSession {
+			session.RemoveScoreboard()
+			return
+		}
+		var lines, err = server.LeaderboardManager.GetLines(stat, 10)
+		if err != nil {
+			commands.Tell(sender, "commands.top.unknown", strings.Join(server.LeaderboardManager.GetNames(), ", "))
+			return
+		}
+		if display == "sidebar" {
+			if !isSession {
+				commands.Tell(sender, "commands.generic.playerOnly")
+				return
+			}
+			session.SetScoreboard(commands.Translate(session, "commands.top.title", stat), lines)
+			return
+		}
+		if len(lines) == 0 {
+			commands.Tell(sender, "commands.top.empty")
+			return
+		}
+		commands.Tell(sender, "commands.top.header", stat)
+		for _, line := range lines {
+			sender.SendMessage(line)
+		}
+		if isSession {
+			if entry, ok := server.LeaderboardManager.GetRank(stat, session.GetName()); ok {
+				commands.Tell(sender, "commands.top.rank", entry.Rank)
+			}
+		}
+	})
+	top.AppendArgument(arguments.NewString("stat", false))
+	top.AppendArgument(arguments.NewStringEnum("display", true, []string{"chat", "sidebar"}))
+	top.ExemptFromPermissionCheck(true)
+	return top
+}
+
+func NewCosmetics(server *Server) *commands.Command {
+	var cosmetic = commands.NewCommand("cosmetics", "Equips particle trails, pets and gadgets", "gomine.cosmetics", []string{"cosmetic"}, func(sender commands.Sender, category string, name string) {
+		var session, ok = sender.(*net.MinecraftSession)
+		if !ok {
+			commands.Tell(sender, "commands.generic.playerOnly")
+			return
+		}
+		var c = cosmetics.Category(category)
+		switch name {
+		case "":
+			var names []string
+			for _, cosmetic := range server.CosmeticManager.GetCosmetics(c) {
+				if server.CosmeticManager.CanEquip(session, cosmetic) {
+					names = append(names, text.BrightGreen+cosmetic.GetName())
+				} else {
+					names = append(names, text.Gray+cosmetic.GetName())
+				}
+			}
+			commands.Tell(session, "commands.cosmetics.available", category, strings.Join(names, text.White+", "))
+		case "off":
+			if err := server.CosmeticManager.Unequip(session, c); err != nil {
+				text.DefaultLogger.LogError(err)
+			}
+			session.SendInventory()
+			commands.Tell(session, "commands.cosmetics.unequipped", category)
+		default:
+			var err = server.CosmeticManager.Equip(session, c, name)
+			if err == cosmetics.UnknownCosmetic || err == cosmetics.NoPermission {
+				commands.Tell(session, "commands.cosmetics.equipFailed", name, err)
+				return
+			}
+			text.DefaultLogger.LogError(err)
+			session.SendInventory()
+			commands.Tell(session, "commands.cosmetics.equipped", name, category)
+		}
+	})
+	cosmetic.AppendArgument(arguments.NewStringEnum("category", false, []string{"trail", "pet", "gadget"}))
+	cosmetic.AppendArgument(arguments.NewString("name", true))
+	cosmetic.ExemptFromPermissionCheck(true)
+	return cosmetic
+}
+
+func NewSummon(server *Server) *commands.Command {
+	var summon = commands.NewCommand("summon", "Summons a mob at your position", "gomine.summon", []string{}, func(sender commands.Sender, identifier string) {
+		var session, ok = sender.(*net.MinecraftSession)
+		if !ok {
+			commands.Tell(sender, "commands.generic.playerOnly")
+			return
+		}
+		if !strings.Contains(identifier, ":") {
+			identifier = "minecraft:" + identifier
+		}
+		var position = session.GetPlayer().Position
+		position.Y -= 1.62
+		if _, err := server.MobManager.SpawnEntity(session.GetPlayer().GetDimension(), identifier, position); err == mobs.CapReached {
+			commands.Tell(session, "commands.summon.cap", server.MobManager.Cap)
+			return
+		} else if err != nil {
+			commands.Tell(session, "commands.summon.unknown", strings.Join(server.MobManager.Registry.GetIdentifiers(), ", "))
+			return
+		}
+		commands.Tell(session, "commands.summon.summoned", identifier)
+	})
+	summon.AppendArgument(arguments.NewString("entity", false))
+	return summon
+}
+
+func NewButcher(server *Server) *commands.Command {
+	var butcher = commands.NewCommand("butcher", "Removes mobs of a type, optionally within a radius or world", "gomine.butcher", []string{}, func(sender commands.Sender, identifier string, radius float64, levelName string) {
+		var query = mobs.Query{Level: levelName, Identifier: identifier}
+		if identifier == "all" {
+			query.Identifier = ""
+		} else if !strings.Contains(identifier, ":") {
+			query.Identifier = "minecraft:" + identifier
+		}
+		var session, ok = sender.(*net.MinecraftSession)
+		if radius > 0 {
+			if !ok {
+				commands.Tell(sender, "commands.butcher.radiusPlayerOnly")
+				return
+			}
+			query.Dimension, query.Center, query.Radius = session.GetPlayer().GetDimension(), session.GetPlayer().Position, radius
+		} else if levelName == "" && ok {
+			query.Dimension = session.GetPlayer().GetDimension()
+		}
+		commands.Tell(sender, "commands.butcher.removed", server.MobManager.Butcher(query))
+	})
+	butcher.AppendArgument(arguments.NewString("type", false))
+	butcher.AppendArgument(arguments.NewFloat("radius", true))
+	butcher.AppendArgument(arguments.NewString("world", true))
+	return butcher
+}
+
+func NewKill(server *Server) *commands.Command {
+	var kill = commands.NewCommand("kill", "Kills players or removes entities, such as @e[type=item]", "gomine.kill", []string{}, func(sender commands.Sender, target string) {
+		if target == "" {
+			target = selectors.Self
+		}
+		var entities, err = server.SelectEntities(sender, target)
+		if err != nil {
+			commands.Tell(sender, "commands.kill.invalidSelector", target)
+			return
+		}
+		if len(entities) == 0 {
+			commands.Tell(sender, "commands.kill.noTargets", target)
+			return
+		}
+		var killed = 0
+		for _, entity := range entities {
+			if server.KillEntity(entity) {
+				killed++
+			}
+		}
+		commands.Tell(sender, "commands.kill.killed", killed)
+	})
+	kill.AppendArgument(arguments.NewString("target", true))
+	return kill
+}
+
+func NewScoreboard(server *Server) *commands.Command {
+	var scoreboard *commands.Command
+	scoreboard = commands.NewCommand("scoreboard", "Manages scoreboard objectives and scores", "gomine.scoreboard", []string{}, func(sender commands.Sender, category string, action string, name string, objective string, value string) {
+		var usage bool
+		var err error
+		switch category + " " + action {
+		case "objectives add":
+			// The criteria follows the name of the objective, followed by an optional display name.
+			if name == "" || objective == "" {
+				usage = true
+				break
+			}
+			if _, err = server.ScoreboardManager.AddObjective(name, objective, value); err == nil {
+				commands.Tell(sender, "commands.scoreboard.objectives.added", name, objective)
+			}
+		case "objectives remove":
+			if name == "" {
+				usage = true
+				break
+			}
+			if err = server.ScoreboardManager.RemoveObjective(name); err == nil {
+				commands.Tell(sender, "commands.scoreboard.objectives.removed", name)
+			}
+		case "objectives list":
+			var objectives = server.ScoreboardManager.GetObjectives()
+			commands.Tell(sender, "commands.scoreboard.objectives.header", len(objectives))
+			for _, objective := range objectives {
+				commands.Tell(sender, "commands.scoreboard.objectives.entry", objective.GetName(), objective.GetDisplayName(), objective.GetCriteria())
+			}
+		case "objectives setdisplay":
+			// Only the sidebar is supported as display slot, and leaving out the objective clears it.
+			if name != "sidebar" {
+				usage = true
+				break
+			}
+			if err = server.ScoreboardManager.SetSidebar(objective); err == nil && objective == "" {
+				commands.Tell(sender, "commands.scoreboard.objectives.cleared")
+			} else if err == nil {
+				commands.Tell(sender, "commands.scoreboard.objectives.displayed", objective)
+			}
+		case "players set", "players add", "players remove":
+			var score, parseErr = strconv.Atoi(value)
+			if name == "" || objective == "" || parseErr != nil {
+				usage = true
+				break
+			}
+			switch action {
+			case "set":
+				err = server.ScoreboardManager.SetScore(name, objective, int32(score))
+			case "add":
+				err = server.ScoreboardManager.AddScore(name, objective, int32(score))
+			case "remove":
+				err = server.ScoreboardManager.AddScore(name, objective, -int32(score))
+			}
+			if err == nil {
+				commands.Tell(sender, "commands.scoreboard.players.set", objective, name, server.ScoreboardManager.GetScores(name)[objective])
+			}
+		case "players reset":
+			if name == "" {
+				usage = true
+				break
+			}
+			if err = server.ScoreboardManager.ResetScore(name, objective); err == nil {
+				commands.Tell(sender, "commands.scoreboard.players.reset", name)
+			}
+		case "players list":
+			if session, ok := sender.(*net.MinecraftSession); ok && name == "" {
+				name = session.GetName()
+			}
+			if name == "" {
+				usage = true
+				break
+			}
+			var scores = server.ScoreboardManager.GetScores(name)
+			var names = make([]string, 0, len(scores))
+			for objective := range scores {
+				names = append(names, objective)
+			}
+			sort.Strings(names)
+			commands.Tell(sender, "commands.scoreboard.players.header", name, len(scores))
+			for _, objective := range names {
+				commands.Tell(sender, "commands.scoreboard.players.entry", objective, scores[objective])
+			}
+		default:
+			usage = true
+		}
+		if usage {
+			sender.SendMessage(scoreboard.GetLocalizedUsage(commands.GetLanguage(sender)))
+		} else if err != nil {
+			commands.Tell(sender, "commands.scoreboard.failed", err)
+		}
+	})
+	scoreboard.AppendArgument(arguments.NewStringEnum("category", false, []string{"objectives", "players"}))
+	scoreboard.AppendArgument(arguments.NewStringEnum("action", false, []string{"add", "remove", "list", "setdisplay", "set", "reset"}))
+	scoreboard.AppendArgument(arguments.NewString("name", true))
+	scoreboard.AppendArgument(arguments.NewString("objective", true))
+	scoreboard.AppendArgument(arguments.NewString("value", true))
+	return scoreboard
+}
+
+func NewTags() *commands.Command {
+	var tagsCommand = commands.NewCommand("tags", "Lists item and block tags, the values of a tag, or the tags of an item or block", "gomine.tags", []string{}, func(sender commands.Sender, kind string, name string) {
+		var tagKind = tags.Kind(kind)
+		switch {
+		case name == "":
+			var names = tags.DefaultRegistry.GetNames(tagKind)
+			commands.Tell(sender, "commands.tags.list", len(names), strings.Join(names, ", "))
+		case tags.DefaultRegistry.IsRegistered(tagKind, name):
+			commands.Tell(sender, "commands.tags.values", name, strings.Join(tags.DefaultRegistry.GetValues(tagKind, name), ", "))
+		default:
+			var names = tags.DefaultRegistry.GetTags(tagKind, name)
+			if len(names) == 0 {
+				commands.Tell(sender, "commands.tags.none", name)
+				return
+			}
+			commands.Tell(sender, "commands.tags.of", name, strings.Join(names, ", "))
+		}
+	})
+	tagsCommand.AppendArgument(arguments.NewStringEnum("kind", false, []string{string(tags.Items), string(tags.Blocks)}))
+	tagsCommand.AppendArgument(arguments.NewString("name", true))
+	return tagsCommand
+}
+
+func NewFly(server *Server) *commands.Command {
+	var fly = commands.NewCommand("fly", "Toggles flight of yourself or another player", "gomine.fly", []string{}, func(sender commands.Sender, target string) {
+		var session, ok = sender.(*net.MinecraftSession)
+		if target != "" {
+			if session, ok = server.SessionManager.GetSession(target); !ok {
+				commands.Tell(sender, "commands.generic.offline", target)
+				return
+			}
+		} else if !ok {
+			commands.Tell(sender, "commands.fly.noTarget")
+			return
+		}
+		var allowed = !session.GetPlayer().GetAllowFlight()
+		session.SetAllowFlight(allowed)
+		var key = "commands.fly.disabled"
+		if allowed {
+			key = "commands.fly.enabled"
+		}
+		if session != sender {
+			commands.Tell(sender, key+".other", session.GetName())
+		}
+		commands.Tell(session, key)
+	})
+	fly.AppendArgument(arguments.NewString("player", true))
+	return fly
+}
+
+func NewGod(server *Server) *commands.Command {
+	var god = commands.NewCommand("god", "Toggles invulnerability of yourself or another player", "gomine.god", []string{}, func(sender commands.Sender, target string) {
+		var session, ok = sender.(*net.MinecraftSession)
+		if target != "" {
+			if session, ok = server.SessionManager.GetSession(target); !ok {
+				commands.Tell(sender, "commands.generic.offline", target)
+				return
+			}
+		} else if !ok {
+			commands.Tell(sender, "commands.god.noTarget")
+			return
+		}
+		var invulnerable = !session.GetPlayer().IsInvulnerable()
+		session.GetPlayer().SetInvulnerable(invulnerable)
+		var key = "commands.god.disabled"
+		if invulnerable {
+			key = "commands.god.enabled"
+		}
+		if session != sender {
+			commands.Tell(sender, key+".other", session.GetName())
+		}
+		commands.Tell(session, key)
+	})
+	god.AppendArgument(arguments.NewString("player", true))
+	return god
+}
+
+func NewFreeze(server *Server) *commands.Command {
+	var freeze = commands.NewCommand("freeze", "Freezes or unfreezes a player", "gomine.freeze", []string{}, func(sender commands.Sender, target string) {
+		var session, ok = server.SessionManager.GetSession(target)
+		if !ok {
+			commands.Tell(sender, "commands.generic.offline", target)
+			return
+		}
+		var frozen = !session.GetPlayer().IsFrozen()
+		if !server.Freeze(session, frozen) {
+			commands.Tell(sender, "commands.freeze.failed", session.GetName())
+			return
+		}
+		if frozen {
+			commands.Tell(sender, "commands.freeze.froze", session.GetName())
+			commands.Tell(session, "commands.freeze.frozen")
+		} else {
+			commands.Tell(sender, "commands.freeze.unfroze", session.GetName())
+			commands.Tell(session, "commands.freeze.unfrozen")
+		}
+	})
+	freeze.AppendArgument(arguments.NewString("player", false))
+	return freeze
+}
+
+func NewSort(server *Server) *commands.Command {
+	var sortCommand = commands.NewCommand("sort", "Sorts your inventory, optionally including the hotbar", "gomine.sort", []string{}, func(sender commands.Sender, slots string) {
+		var session, ok = sender.(*net.MinecraftSession)
+		if !ok {
+			commands.Tell(sender, "commands.generic.playerOnly")
+			return
+		}
+		if !server.SortInventory(session, slots == "all") {
+			commands.Tell(sender, "commands.sort.failed")
+			return
+		}
+		commands.Tell(sender, "commands.sort.sorted")
+	})
+	sortCommand.AppendArgument(arguments.NewStringEnum("slots", true, []string{"all"}))
+	return sortCommand
+}
+
+func NewRandomTeleport(server *Server) *commands.Command {
+	return commands.NewCommand("rtp", "Teleports you to a random safe location", "gomine.rtp", []string{"wild"}, func(sender commands.Sender) {
+		var session, ok = sender.(*net.MinecraftSession)
+		if !ok {
+			commands.Tell(sender, "commands.generic.playerOnly")
+			return
+		}
+		if server.TeleportManager.IsSearching(session) {
+			commands.Tell(session, "commands.rtp.searching")
+			return
+		}
+		if cooldown := server.TeleportManager.GetCooldown(session); cooldown > 0 {
+			commands.Tell(session, "commands.rtp.cooldown", cooldown.Round(time.Second))
+			return
+		}
+		commands.Tell(session, "commands.rtp.search")
+		server.TeleportManager.Teleport(session, session.GetPlayer().GetDimension(), server.getSpawn(session), func(position r3.Vector, err error) {
+			switch err {
+			case nil:
+				commands.Tell(session, "commands.rtp.teleported", int(position.X), int(position.Y), int(position.Z))
+			case teleports.OnCooldown:
+				commands.Tell(session, "commands.rtp.cooldown", server.TeleportManager.GetCooldown(session).Round(time.Second))
+			case teleports.Searching:
+				commands.Tell(session, "commands.rtp.searching")
+			case levels.NoSafeSpawn:
+				commands.Tell(session, "commands.rtp.noSafe")
+			case net.SessionClosed:
+			default:
+				commands.Tell(session, "commands.rtp.failed")
+			}
+		})
+	})
+}
+
+func NewTransfer(server *Server) *commands.Command {
+	var transfer = commands.NewCommand("transfer", "Transfers a player to another server", "gomine.transfer", []string{}, func(sender commands.Sender, target string, destination string, port string) {
+		var session, ok = server.SessionManager.GetSession(target)
+		if !ok {
+			commands.Tell(sender, "commands.generic.offline", target)
+			return
+		}
+		var address, transferPort = destination, uint64(19132)
+		if status, ok := server.NetworkBridge.GetServer(destination); ok {
+			if status.Address == "" {
+				commands.Tell(sender, "commands.transfer.noAddress", destination)
+				return
+			}
+			address, transferPort = status.Address, uint64(status.Port)
+		} else if port != "" {
+			var err error
+			if transferPort, err = strconv.ParseUint(port, 10, 16); err != nil {
+				commands.Tell(sender, "commands.transfer.invalidPort", port)
+				return
+			}
+		}
+		session.Transfer(address, uint16(transferPort))
+		commands.Tell(sender, "commands.transfer.transferred", session.GetName(), destination)
+	})
+	transfer.AppendArgument(arguments.NewString("player", false))
+	transfer.AppendArgument(arguments.NewString("server", false))
+	transfer.AppendArgument(arguments.NewString("port", true))
+	return transfer
+}
+
+func NewServers(server *Server) *commands.Command {
+	var servers = commands.NewCommand("servers", "Lists all servers in the network", "gomine.servers", []string{}, func(sender commands.Sender) {
+		var statuses = server.NetworkBridge.GetServers()
+		var online = server.SessionManager.GetSessionCount()
+		var list = commands.Translate(sender, "commands.servers.header", server.NetworkBridge.GetNetworkPlayerCount(online)) + "\n"
+		list += text.BrightGreen + server.NetworkBridge.GetName() + ": " + text.Yellow + strconv.Itoa(online) + "/" + strconv.Itoa(int(server.Config.MaximumPlayers)) + text.Reset + "\n"
+		for name, status := range statuses {
+			list += text.BrightGreen + name + ": " + text.Yellow + strconv.Itoa(status.OnlinePlayers) + "/" + strconv.Itoa(status.MaximumPlayers) + text.Reset + "\n"
+		}
+		sender.SendMessage(list)
+	})
+	servers.ExemptFromPermissionCheck(true)
+	return servers
+}
+
+func NewNetworkCommand(server *Server) *commands.Command {
+	var netCommand = commands.NewCommand("netcommand", "Executes a command on another server in the network", "gomine.netcommand", []string{}, func(sender commands.Sender, target string, command string) {
+		if command == "" {
+			commands.Tell(sender, "commands.netcommand.empty")
+			return
+		}
+		var all = target == "*"
+		if all {
+			target = ""
+		}
+		if err := server.NetworkBridge.SendCommand(target, command); err != nil {
+			commands.Tell(sender, "commands.netcommand.failed", err)
+			return
+		}
+		if all {
+			commands.Tell(sender, "commands.netcommand.sent.all")
+			return
+		}
+		commands.Tell(sender, "commands.netcommand.sent", target)
+	})
+	var command = arguments.NewString("command", true)
+	command.SetInputAmount(256)
+	netCommand.AppendArgument(arguments.NewString("server", false))
+	netCommand.AppendArgument(command)
+	return netCommand
+}
+
+func NewHide(server *Server) *commands.Command {
+	var categories = make([]string, len(net.UpdateCategories))
+	for i, category := range net.UpdateCategories {
+		categories[i] = string(category)
+	}
+	var hide = commands.NewCommand("hide", "Toggles hiding players, pets, particles or mobs", "gomine.hide", []string{}, func(sender commands.Sender, category string) {
+		var session, ok = sender.(*net.MinecraftSession)
+		if !ok {
+			commands.Tell(sender, "commands.generic.playerOnly")
+			return
+		}
+		var hidden = !session.IsHidden(net.UpdateCategory(category))
+		server.SetHidden(session, net.UpdateCategory(category), hidden)
+		if hidden {
+			commands.Tell(session, "commands.hide.hidden", category)
+		} else {
+			commands.Tell(session, "commands.hide.shown", category)
+		}
+	})
+	hide.AppendArgument(arguments.NewStringEnum("category", false, categories))
+	hide.ExemptFromPermissionCheck(true)
+	return hide
+}
+
+func NewWorld(server *Server) *commands.Command {
+	var world = commands.NewCommand("world", "Moves you to the spawn of another world", "gomine.world", []string{}, func(sender commands.Sender, levelName string) {
+		var session, ok = sender.(*net.MinecraftSession)
+		if !ok {
+			commands.Tell(sender, "commands.generic.playerOnly")
+			return
+		}
+		var dimension *worlds.Dimension
+		for _, level := range server.LevelManager.GetLevels() {
+			if strings.EqualFold(level.GetName(), levelName) {
+				dimension = level.GetDefaultDimension()
+				levelName = level.GetName()
+			}
+		}
+		if dimension == nil {
+			commands.Tell(session, "commands.world.unknown", levelName)
+			return
+		}
+		if cooldown := server.DimensionManager.GetChangeCooldown(session); cooldown > 0 {
+			commands.Tell(session, "commands.world.cooldown", cooldown.Round(time.Second))
+			return
+		}
+		var spawn = server.LevelStorage.GetSpawn(levelName)
+		dimension.LoadChunk(int32(spawn.X)>>4, int32(spawn.Z)>>4, func(chunk *chunks.Chunk) {
+			switch server.DimensionManager.ChangeDimension(session, dimension, spawn) {
+			case nil:
+				commands.Tell(session, "commands.world.moved", levelName)
+			case teleports.OnCooldown:
+				commands.Tell(session, "commands.world.cooldown", server.DimensionManager.GetChangeCooldown(session).Round(time.Second))
+			default:
+				commands.Tell(session, "commands.world.failed", levelName)
+			}
+		})
+	})
+	world.AppendArgument(arguments.NewString("world", false))
+	return world
+}
+
+func NewWorldInfo(server *Server) *commands.Command {
+	var worldInfo = commands.NewCommand("worldinfo", "Shows the disk usage of a world", "gomine.worldinfo", []string{}, func(sender commands.Sender, levelName string) {
+		if levelName == "" {
+			levelName = server.Config.DefaultLevel
+			if session, ok := sender.(*net.MinecraftSession); ok && session.GetPlayer().GetDimension() != nil {
+				levelName = session.GetPlayer().GetDimension().GetLevel().GetName()
+			}
+		}
+		var stats, err = server.LevelStorage.GetStorageStats(levelName)
+		if err != nil {
+			commands.Tell(sender, "commands.worldinfo.failed", levelName, err)
+			return
+		}
+		var info = commands.Translate(sender, "commands.worldinfo.header", levelName) + "\n"
+		info += commands.Translate(sender, "commands.worldinfo.total", formatStorageStats(sender, stats.StorageStats)) + text.Reset + "\n"
+		var dimensions = make([]string, 0, len(stats.Dimensions))
+		for name := range stats.Dimensions {
+			dimensions = append(dimensions, name)
+		}
+		sort.Strings(dimensions)
+		for _, name := range dimensions {
+			info += text.BrightGreen + name + ": " + text.Yellow + formatStorageStats(sender, stats.Dimensions[name]) + text.Reset + "\n"
+		}
+		sender.SendMessage(info)
+	})
+	worldInfo.AppendArgument(arguments.NewString("world", true))
+	return worldInfo
+}
+
+func NewLanguage(server *Server) *commands.Command {
+	var language = commands.NewCommand("language", "Sets the language of server messages", "gomine.language", []string{"lang"}, func(sender commands.Sender, name string) {
+		var session, ok = sender.(*net.MinecraftSession)
+		if !ok {
+			commands.Tell(sender, "commands.generic.playerOnly")
+			return
+		}
+		var languages = strings.Join(lang.DefaultTranslator.GetLanguages(), ", ")
+		var data = session.GetPlayer().GetData()
+		switch name {
+		case "":
+			commands.Tell(session, "commands.language.current", session.GetLanguage(), languages)
+			return
+		case "auto":
+			data.Language = ""
+			session.SetLanguage(session.GetClientLanguage())
+			commands.Tell(session, "commands.language.reset")
+		default:
+			var language, registered = lang.DefaultTranslator.GetLanguage(name)
+			if !registered {
+				commands.Tell(session, "commands.language.unknown", name, languages)
+				return
+			}
+			data.Language = language
+			session.SetLanguage(language)
+			commands.Tell(session, "commands.language.set", language)
+		}
+		text.DefaultLogger.LogError(server.PlayerStorage.Save(data))
+	})
+	language.AppendArgument(arguments.NewString("language", true))
+	language.ExemptFromPermissionCheck(true)
+	return language
+}
+
+func NewNick(server *Server) *commands.Command {
+	var nick = commands.NewCommand("nick", "Sets the nickname of yourself or another player", "gomine.nick", []string{"nickname"}, func(sender commands.Sender, nickname string, target string) {
+		var session, ok = sender.(*net.MinecraftSession)
+		if target != "" {
+			if !sender.HasPermission("gomine.nick.other") {
+				commands.Tell(sender, "commands.generic.permission")
+				return
+			}
+			if session, ok = server.SessionManager.GetSession(target); !ok {
+				commands.Tell(sender, "commands.generic.offline", target)
+				return
+			}
+		} else if !ok {
+			commands.Tell(sender, "commands.generic.playerOnly")
+			return
+		}
+		if nickname == "off" {
+			nickname = ""
+		}
+		if err := server.NicknameManager.SetNickname(session, nickname); err != nil {
+			commands.Tell(sender, "commands.nick.failed", err)
+			return
+		}
+		var key = "commands.nick.removed"
+		if session.GetDisplayName() != session.GetName() {
+			key = "commands.nick.set"
+		}
+		if session != sender {
+			commands.Tell(sender, key+".other", session.GetName(), session.GetDisplayName())
+		}
+		commands.Tell(session, key, session.GetDisplayName())
+	})
+	nick.AppendArgument(arguments.NewString("nickname", false))
+	nick.AppendArgument(arguments.NewString("player", true))
+	return nick
+}
+
+func NewDebug(server *Server) *commands.Command {
+	var debug = commands.NewCommand("debug", "Creates a debug dump to attach to bug reports", "gomine.debug", []string{}, func(sender commands.Sender, action string) {
+		if action != "dump" {
+			commands.Tell(sender, "commands.debug.unknown", action)
+			return
+		}
+		commands.Tell(sender, "commands.debug.creating")
+		server.CreateDebugDump(func(path string, err error) {
+			if err != nil {
+				commands.Tell(sender, "commands.debug.failed", err)
+				return
+			}
+			commands.Tell(sender, "commands.debug.created", path)
+		})
+	})
+	debug.AppendArgument(arguments.NewString("action", false))
+	return debug
+}
+
+func NewTracePackets(server *Server) *commands.Command {
+	var trace = commands.NewCommand("tracepackets", "Logs the packets of a player to a file for a limited duration", "gomine.tracepackets", []string{}, func(sender commands.Sender, target string, duration string, filter string) {
+		var session, ok = server.SessionManager.GetSession(target)
+		if !ok {
+			commands.Tell(sender, "commands.generic.offline", target)
+			return
+		}
+		if duration == "stop" {
+			if !session.IsTracingPackets() {
+				commands.Tell(sender, "commands.tracepackets.notTracing", session.GetName())
+				return
+			}
+			session.StopPacketTrace()
+			commands.Tell(sender, "commands.tracepackets.stopped", session.GetName())
+			return
+		}
+		var traceDuration = DefaultPacketTraceDuration
+		if duration != "" {
+			var seconds, err = strconv.Atoi(duration)
+			if err == nil {
+				traceDuration = time.Duration(seconds) * time.Second
+			} else if traceDuration, err = time.ParseDuration(duration); err != nil {
+				traceDuration = 0
+			}
+			if traceDuration <= 0 {
+				commands.Tell(sender, "commands.tracepackets.invalidDuration", duration)
+				return
+			}
+		}
+		var packets []string
+		if filter != "" {
+			packets = strings.Split(filter, ",")
+		}
+		if traceDuration > MaxPacketTraceDuration {
+			traceDuration = MaxPacketTraceDuration
+		}
+		var path, err = server.TracePackets(session, traceDuration, packets)
+		if err != nil {
+			commands.Tell(sender, "commands.tracepackets.failed", err)
+			return
+		}
+		commands.Tell(sender, "commands.tracepackets.started", session.GetName(), traceDuration, path)
+	})
+	trace.AppendArgument(arguments.NewString("player", false))
+	trace.AppendArgument(arguments.NewString("duration", true))
+	trace.AppendArgument(arguments.NewString("packets", true))
+	return trace
+}
+
+// formatStorageStats returns the size, region count and chunk count of the stats as readable text,
+// in the language of the sender.
+func formatStorageStats(sender commands.Sender, stats levels.StorageStats) string {
+	var size = strconv.FormatFloat(float64(stats.Size)/1024/1024, 'f', 2, 64)
+	return commands.Translate(sender, "commands.worldinfo.statistics", size, stats.Regions, stats.Chunks)
+}
@@ -0,0 +1,148 @@
+package diagnostics
+
+import (
+	"archive/zip"
+	"bytes"
+	"os"
+	"path/filepath"
+	"runtime/pprof"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v2"
+)
+
+// Redacted replaces the values of secret configuration entries in a dump.
+const Redacted = "<redacted>"
+
+// SecretKeys holds the words that mark a configuration key as secret, in lower case.
+var SecretKeys = []string{"secret", "password", "token"}
+
+// Dump is a debug dump, holding files with information about the server to attach to bug reports.
+// All files are written to a single zip archive.
+type Dump struct {
+	Time  time.Time
+	names []string
+	files map[string][]byte
+}
+
+// NewDump returns a new empty debug dump created at the given time.
+func NewDump(time time.Time) *Dump {
+	return &Dump{Time: time, files: make(map[string][]byte)}
+}
+
+// AddFile adds a file with the content to the dump, replacing any file with the same name.
+func (dump *Dump) AddFile(name string, content []byte) {
+	if _, ok := dump.files[name]; !ok {
+		dump.names = append(dump.names, name)
+	}
+	dump.files[name] = content
+}
+
+// AddText adds a text file with the lines to the dump.
+func (dump *Dump) AddText(name string, lines []string) {
+	dump.AddFile(name, []byte(strings.Join(lines, "\n")+"\n"))
+}
+
+// AddYAML adds a YAML file with the value marshaled to the dump.
+func (dump *Dump) AddYAML(name string, value interface{}) error {
+	var content, err = yaml.Marshal(value)
+	if err != nil {
+		return err
+	}
+	dump.AddFile(name, content)
+	return nil
+}
+
+// AddConfig adds the configuration as YAML file to the dump,
+// with the values of all secret keys redacted.
+func (dump *Dump) AddConfig(name string, config interface{}) error {
+	var redacted, err = Redact(config)
+	if err != nil {
+		return err
+	}
+	return dump.AddYAML(name, redacted)
+}
+
+// AddGoroutines adds the stacks of all goroutines at this moment to the dump.
+func (dump *Dump) AddGoroutines(name string) error {
+	var buffer = bytes.NewBuffer(nil)
+	if err := pprof.Lookup("goroutine").WriteTo(buffer, 2); err != nil {
+		return err
+	}
+	dump.AddFile(name, buffer.Bytes())
+	return nil
+}
+
+// GetFiles returns the names of all files in the dump, in the order they were added.
+func (dump *Dump) GetFiles() []string {
+	return append([]string(nil), dump.names...)
+}
+
+// Save writes the dump as zip archive to the directory, which is created if it does not exist.
+// The name of the archive holds the time of the dump. The path of the archive is returned.
+func (dump *Dump) Save(directory string) (string, error) {
+	if err := os.MkdirAll(directory, 0700); err != nil {
+		return "", err
+	}
+	var path = filepath.Join(directory, "debug-"+dump.Time.Format("2006-01-02_15-04-05")+".zip")
+	var file, err = os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return "", err
+	}
+	var writer = zip.NewWriter(file)
+	for _, name := range dump.names {
+		var entry, err = writer.CreateHeader(&zip.FileHeader{Name: name, Method: zip.Deflate, Modified: dump.Time})
+		if err == nil {
+			_, err = entry.Write(dump.files[name])
+		}
+		if err != nil {
+			file.Close()
+			return "", err
+		}
+	}
+	if err := writer.Close(); err != nil {
+		file.Close()
+		return "", err
+	}
+	return path, file.Close()
+}
+
+// Redact returns the configuration as ordered YAML map, with the non-empty values
+// of all keys holding one of the secret keys replaced. Nested maps are redacted too.
+func Redact(config interface{}) (yaml.MapSlice, error) {
+	var content, err = yaml.Marshal(config)
+	if err != nil {
+		return nil, err
+	}
+	var slice yaml.MapSlice
+	if err := yaml.Unmarshal(content, &slice); err != nil {
+		return nil, err
+	}
+	redact(slice)
+	return slice, nil
+}
+
+// redact replaces the values of secret keys in the map slice.
+func redact(slice yaml.MapSlice) {
+	for i, item := range slice {
+		if nested, ok := item.Value.(yaml.MapSlice); ok {
+			redact(nested)
+			continue
+		}
+		if key, ok := item.Key.(string); ok && isSecret(key) && item.Value != nil && item.Value != "" {
+			slice[i].Value = Redacted
+		}
+	}
+}
+
+// isSecret checks if the key holds one of the secret keys, ignoring case.
+func isSecret(key string) bool {
+	key = strings.ToLower(key)
+	for _, secret := range SecretKeys {
+		if strings.Contains(key, secret) {
+			return true
+		}
+	}
+	return false
+}
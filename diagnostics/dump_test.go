@@ -0,0 +1,71 @@
+package diagnostics
+
+import (
+	"archive/zip"
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"gopkg.in/yaml.v2"
+)
+
+type config struct {
+	Name    string            `yaml:"Server Name"`
+	Secret  string            `yaml:"Network Secret"`
+	Empty   string            `yaml:"Database Password"`
+	Servers map[string]string `yaml:"Servers"`
+}
+
+func TestRedact(t *testing.T) {
+	slice, err := Redact(config{Name: "GoMine", Secret: "hunter2", Servers: map[string]string{"Lobby Token": "abc"}})
+	if err != nil {
+		t.Fatal("could not redact config:", err)
+	}
+	values := make(map[interface{}]interface{})
+	for _, item := range slice {
+		values[item.Key] = item.Value
+	}
+	if values["Server Name"] != "GoMine" || values["Network Secret"] != Redacted || values["Database Password"] != "" {
+		t.Error("unexpected redacted config:", values)
+	}
+	if len(slice) != 4 || slice[0].Key != "Server Name" {
+		t.Error("order of the config was not kept:", slice)
+	}
+	if nested, ok := slice[3].Value.(yaml.MapSlice); !ok || nested[0].Value != Redacted {
+		t.Error("nested secret was not redacted:", slice[3].Value)
+	}
+}
+
+func TestSave(t *testing.T) {
+	dir, _ := ioutil.TempDir("", "diagnostics")
+	defer os.RemoveAll(dir)
+
+	dump := NewDump(time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC))
+	dump.AddText("logs.txt", []string{"first", "second"})
+	if err := dump.AddGoroutines("goroutines.txt"); err != nil {
+		t.Fatal("could not add goroutines:", err)
+	}
+	dump.AddFile("logs.txt", []byte("replaced\n"))
+	path, err := dump.Save(dir)
+	if err != nil {
+		t.Fatal("could not save dump:", err)
+	}
+	if !strings.HasSuffix(path, "debug-2020-01-02_03-04-05.zip") {
+		t.Error("unexpected dump path:", path)
+	}
+	reader, err := zip.OpenReader(path)
+	if err != nil {
+		t.Fatal("could not open dump:", err)
+	}
+	defer reader.Close()
+	if len(reader.File) != 2 || reader.File[0].Name != "logs.txt" || reader.File[1].Name != "goroutines.txt" {
+		t.Fatal("unexpected files in dump:", reader.File)
+	}
+	file, _ := reader.File[0].Open()
+	content, _ := ioutil.ReadAll(file)
+	if string(content) != "replaced\n" {
+		t.Error("unexpected file content:", string(content))
+	}
+}
@@ -0,0 +1,39 @@
+package drops
+
+import (
+	"github.com/irmine/gomine/events"
+	"github.com/irmine/gomine/items"
+	"github.com/irmine/gomine/net"
+)
+
+const (
+	DropEventName   events.Name = "PlayerDropItemEvent"
+	PickupEventName events.Name = "PlayerPickupItemEvent"
+)
+
+// DropEvent gets called when a player drops an item, for example by pressing the drop key.
+// Cancelling the event returns the item to the inventory of the player.
+type DropEvent struct {
+	events.Cancellable
+	Session *net.MinecraftSession
+	// Stack is the dropped item stack.
+	Stack *items.Stack
+}
+
+// GetName returns the name of the event.
+func (event *DropEvent) GetName() events.Name {
+	return DropEventName
+}
+
+// PickupEvent gets called when a player picks up an item lying on the ground.
+// Cancelling the event leaves the item on the ground.
+type PickupEvent struct {
+	events.Cancellable
+	Session *net.MinecraftSession
+	Item    *Item
+}
+
+// GetName returns the name of the event.
+func (event *PickupEvent) GetName() events.Name {
+	return PickupEventName
+}
@@ -0,0 +1,103 @@
+package drops
+
+import (
+	"math"
+
+	"github.com/golang/geo/r3"
+	"github.com/irmine/gomine/ecs"
+	"github.com/irmine/gomine/items"
+	"github.com/irmine/worlds/entities"
+)
+
+// Identifier is the identifier of the entity type of items.
+const Identifier = "minecraft:item"
+
+const (
+	// itemType is the entity type of item entities.
+	itemType = 64
+	// gravity is the speed in blocks per tick items fall faster every tick.
+	gravity = 0.04
+	// drag is the factor the motion of items gets multiplied with every tick.
+	drag = 0.98
+)
+
+// Item is an item stack lying on the ground, which players can pick up.
+type Item struct {
+	*entities.Entity
+	// Stack is the item stack players picking up the item get.
+	Stack *items.Stack
+	// Owner is the name of the player that may pick up the item while the owner window lasts.
+	// Owner is empty if every player may pick up the item.
+	Owner string
+
+	pickupDelay int
+	ownerTicks  int
+	age         int
+	motion      r3.Vector
+	groundY     float64
+}
+
+// NewItem returns a new item of the stack, which can be picked up after the pickup delay in ticks.
+// If owner is not empty, only the owner can pick up the item during the owner window in ticks.
+func NewItem(stack *items.Stack, owner string, pickupDelay, ownerWindow int) *Item {
+	return &Item{Entity: entities.New(itemType), Stack: stack, Owner: owner, pickupDelay: pickupDelay, ownerTicks: ownerWindow}
+}
+
+// GetMotion returns the motion the item was spawned with.
+func (item *Item) GetMotion() r3.Vector {
+	return item.motion
+}
+
+// GetIdentifier returns the identifier of the entity type of items, minecraft:item.
+func (item *Item) GetIdentifier() string {
+	return Identifier
+}
+
+// HasComponent checks if the item has the component with the given name.
+// Items only have the item component, holding their stack.
+func (item *Item) HasComponent(component string) bool {
+	return component == ecs.ComponentItem
+}
+
+// CanPickup checks if the player with the given name may pick up the item,
+// which is the case once its pickup delay has passed, and the player owns the item
+// or the owner window has passed.
+func (item *Item) CanPickup(name string) bool {
+	if item.pickupDelay > 0 {
+		return false
+	}
+	return item.Owner == "" || item.ownerTicks <= 0 || item.Owner == name
+}
+
+// tick moves the item by its motion and counts down its pickup delay and owner window.
+// Items do not collide with blocks, but land at the height they were given as ground.
+func (item *Item) tick() {
+	item.age++
+	if item.pickupDelay > 0 {
+		item.pickupDelay--
+	}
+	if item.ownerTicks > 0 {
+		item.ownerTicks--
+	}
+	if item.motion == (r3.Vector{}) {
+		return
+	}
+	item.Position = item.Position.Add(item.motion)
+	item.motion = item.motion.Mul(drag)
+	item.motion.Y -= gravity
+	if item.Position.Y <= item.groundY && item.motion.Y <= 0 {
+		item.Position.Y = item.groundY
+		item.motion = r3.Vector{}
+	}
+}
+
+// GetThrowMotion returns the motion of an item thrown with the given speed
+// by an entity looking in the direction of the yaw and pitch, in degrees.
+func GetThrowMotion(yaw, pitch, speed float64) r3.Vector {
+	yaw, pitch = yaw*math.Pi/180, pitch*math.Pi/180
+	return r3.Vector{
+		X: -math.Sin(yaw) * math.Cos(pitch) * speed,
+		Y: -math.Sin(pitch)*speed + 0.1,
+		Z: math.Cos(yaw) * math.Cos(pitch) * speed,
+	}
+}
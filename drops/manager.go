@@ -0,0 +1,316 @@
+package drops
+
+import (
+	"math/rand"
+	"sort"
+	"sync"
+
+	"github.com/golang/geo/r3"
+	"github.com/irmine/gomine/ecs"
+	"github.com/irmine/gomine/events"
+	"github.com/irmine/gomine/items"
+	"github.com/irmine/gomine/net"
+	"github.com/irmine/worlds"
+)
+
+const (
+	// eyeHeight is the height of the eyes of players above their feet.
+	eyeHeight = 1.62
+	// mergeInterval is the amount of ticks between attempts to merge items lying close to each other.
+	mergeInterval = 10
+)
+
+// Manager manages all items lying on the ground.
+// Items get spawned to every player in the same dimension,
+// and are picked up by players standing close to them once their pickup delay has passed.
+type Manager struct {
+	// PickupDelay is the amount of ticks items dropped by players can not be picked up.
+	PickupDelay int
+	// OwnerWindow is the amount of ticks only the owner of an item can pick it up.
+	// Items spawned without owner can be picked up by anyone after the pickup delay.
+	OwnerWindow int
+	// PickupRadius is the distance in blocks from which players pick up items.
+	PickupRadius float64
+	// Lifetime is the amount of ticks after which items despawn if nobody picked them up.
+	Lifetime int
+	// ThrowSpeed is the speed in blocks per tick items dropped by players get thrown with.
+	ThrowSpeed float64
+	// MergeRadius is the distance in blocks within which landed items of the same kind merge into one.
+	// Items never merge if the merge radius is 0.
+	MergeRadius float64
+	// MergeFunction gets called to check if items in the dimension may merge.
+	// Items merge in all dimensions by default.
+	MergeFunction func(dimension *worlds.Dimension) bool
+
+	mutex          sync.RWMutex
+	sessionManager *net.SessionManager
+	eventManager   *events.Manager
+	items          map[uint64]*Item
+	ticks          int
+}
+
+// NewManager returns a new drop manager.
+// Items dropped by players can be picked up after two seconds by default,
+// and items despawn after five minutes.
+func NewManager(sessionManager *net.SessionManager, eventManager *events.Manager) *Manager {
+	return &Manager{
+		PickupDelay:    40,
+		PickupRadius:   1.5,
+		Lifetime:       6000,
+		ThrowSpeed:     0.3,
+		MergeRadius:    0.5,
+		MergeFunction:  func(*worlds.Dimension) bool { return true },
+		sessionManager: sessionManager,
+		eventManager:   eventManager,
+		items:          make(map[uint64]*Item),
+	}
+}
+
+// Drop calls a drop event for the stack dropped by the session,
+// and throws the stack in the direction the player looks if the event was not cancelled.
+// If random is true, the stack is thrown in a random direction instead.
+// A bool is returned indicating if the stack was dropped.
+func (manager *Manager) Drop(session *net.MinecraftSession, stack *items.Stack, random bool) bool {
+	var player = session.GetPlayer()
+	var dimension = player.GetDimension()
+	if dimension == nil || stack == nil || stack.Count <= 0 {
+		return false
+	}
+	var event = &DropEvent{Session: session, Stack: stack}
+	if !manager.eventManager.Call(event) || event.Stack == nil {
+		return false
+	}
+	var yaw, pitch = player.Rotation.Yaw, player.Rotation.Pitch
+	if random {
+		yaw, pitch = rand.Float64()*360, -rand.Float64()*45
+	}
+	var item = NewItem(event.Stack, "", manager.PickupDelay, 0)
+	var position = player.Position.Sub(r3.Vector{Y: 0.3})
+	manager.Spawn(dimension, item, position, GetThrowMotion(yaw, pitch, manager.ThrowSpeed), player.Position.Y-eyeHeight)
+	return true
+}
+
+// SpawnStack spawns an item of the stack at the given position in the dimension,
+// which can be picked up immediately. If owner is not empty,
+// only the owner can pick up the item during the owner window of the manager.
+func (manager *Manager) SpawnStack(dimension *worlds.Dimension, stack *items.Stack, position r3.Vector, owner string) *Item {
+	var item = NewItem(stack, owner, 0, manager.OwnerWindow)
+	var motion = r3.Vector{X: (rand.Float64() - 0.5) * 0.2, Y: 0.2, Z: (rand.Float64() - 0.5) * 0.2}
+	manager.Spawn(dimension, item, position, motion, position.Y)
+	return item
+}
+
+// Spawn spawns the item at the given position in the dimension with the given motion,
+// and sends it to all players in the dimension. The item lands at the given ground height.
+func (manager *Manager) Spawn(dimension *worlds.Dimension, item *Item, position, motion r3.Vector, groundY float64) {
+	dimension.AddEntity(item.Entity, position)
+	item.Position = position
+	item.motion = motion
+	item.groundY = groundY
+
+	for _, session := range manager.sessionManager.GetSessions() {
+		if session.GetPlayer().GetDimension() == dimension {
+			manager.spawnTo(session, item)
+		}
+	}
+
+	manager.mutex.Lock()
+	manager.items[item.GetRuntimeId()] = item
+	manager.mutex.Unlock()
+}
+
+// spawnTo spawns the item to the session.
+func (manager *Manager) spawnTo(session *net.MinecraftSession, item *Item) {
+	item.AddViewer(session)
+	session.SendAddItemEntity(item.GetUniqueId(), item.GetRuntimeId(), item.Stack, item.Position, item.motion)
+}
+
+// RemoveItem despawns the item for all its viewers and closes it.
+func (manager *Manager) RemoveItem(item *Item) {
+	manager.mutex.Lock()
+	delete(manager.items, item.GetRuntimeId())
+	manager.mutex.Unlock()
+
+	for _, viewer := range item.GetViewers() {
+		if session, ok := viewer.(*net.MinecraftSession); ok {
+			session.SendRemoveEntity(item.GetUniqueId())
+		}
+	}
+	item.Close()
+}
+
+// GetItems returns a runtime ID => item map of all spawned items.
+func (manager *Manager) GetItems() map[uint64]*Item {
+	manager.mutex.RLock()
+	defer manager.mutex.RUnlock()
+	var spawned = make(map[uint64]*Item, len(manager.items))
+	for runtimeId, item := range manager.items {
+		spawned[runtimeId] = item
+	}
+	return spawned
+}
+
+// EachEntity calls the function for every spawned item, until the function returns false.
+// The function must not spawn or remove items.
+func (manager *Manager) EachEntity(function func(entity ecs.Entity) bool) {
+	manager.mutex.RLock()
+	defer manager.mutex.RUnlock()
+	for _, item := range manager.items {
+		if !function(item) {
+			return
+		}
+	}
+}
+
+// Join spawns all items in the dimension of the session to the session.
+func (manager *Manager) Join(session *net.MinecraftSession) {
+	for _, item := range manager.GetItems() {
+		if item.GetDimension() == session.GetPlayer().GetDimension() {
+			manager.spawnTo(session, item)
+		}
+	}
+}
+
+// Leave removes the session as viewer of all items.
+func (manager *Manager) Leave(session *net.MinecraftSession) {
+	for _, item := range manager.GetItems() {
+		item.RemoveViewer(session)
+	}
+}
+
+// Tick moves all items, lets players close to items pick them up,
+// despawns items that exceeded their lifetime and merges items lying close to each other.
+// Internal. Not to be used by plugins.
+func (manager *Manager) Tick() {
+	manager.ticks++
+	if manager.MergeRadius > 0 && manager.ticks%mergeInterval == 0 {
+		manager.mergeItems()
+	}
+	for _, item := range manager.GetItems() {
+		item.tick()
+		if item.age >= manager.Lifetime {
+			manager.RemoveItem(item)
+			continue
+		}
+		for _, session := range manager.sessionManager.GetSessions() {
+			if manager.pickup(session, item) {
+				break
+			}
+		}
+	}
+}
+
+// mergeItems merges all landed items with other items of the same kind within the merge radius,
+// in dimensions where the merge function allows merging.
+func (manager *Manager) mergeItems() {
+	var spawned = manager.GetItems()
+	var landed = make([]*Item, 0, len(spawned))
+	var allowed = make(map[*worlds.Dimension]bool)
+	for _, item := range spawned {
+		var dimension = item.GetDimension()
+		if dimension == nil || item.motion != (r3.Vector{}) {
+			continue
+		}
+		if _, ok := allowed[dimension]; !ok {
+			allowed[dimension] = manager.MergeFunction(dimension)
+		}
+		if allowed[dimension] {
+			landed = append(landed, item)
+		}
+	}
+	// Items are sorted so that items always merge into the oldest spawned item.
+	sort.Slice(landed, func(i, j int) bool {
+		return landed[i].GetRuntimeId() < landed[j].GetRuntimeId()
+	})
+	var removed = make(map[*Item]bool)
+	for i, target := range landed {
+		if removed[target] {
+			continue
+		}
+		var merged bool
+		for _, source := range landed[i+1:] {
+			if removed[source] || !canMerge(target, source, manager.MergeRadius) {
+				continue
+			}
+			if merge(target, source) {
+				merged = true
+			}
+			if source.Stack.Count <= 0 {
+				removed[source] = true
+				manager.RemoveItem(source)
+			}
+		}
+		if merged {
+			manager.respawn(target)
+		}
+	}
+}
+
+// canMerge checks if the source item may merge into the target item,
+// which is the case if they lie within the radius in the same dimension,
+// hold stacks of the same kind, and are not owned by different players.
+func canMerge(target, source *Item, radius float64) bool {
+	if target.GetDimension() != source.GetDimension() || target.Position.Distance(source.Position) > radius {
+		return false
+	}
+	if target.Owner != source.Owner && (target.ownerTicks > 0 || source.ownerTicks > 0) {
+		return false
+	}
+	var ok, count = source.Stack.CanStackOn(target.Stack)
+	return ok && count > 0
+}
+
+// merge stacks the source item onto the target item as far as the stack size allows.
+// The target keeps the longest pickup delay and the youngest age of both items.
+// A bool is returned indicating if any of the source stack was merged.
+func merge(target, source *Item) bool {
+	var ok, _, count = source.Stack.StackOn(target.Stack)
+	if !ok || count == 0 {
+		return false
+	}
+	if source.pickupDelay > target.pickupDelay {
+		target.pickupDelay = source.pickupDelay
+	}
+	if source.age < target.age {
+		target.age = source.age
+	}
+	return true
+}
+
+// respawn spawns the item again to all its viewers, so that they see the new count of its stack.
+func (manager *Manager) respawn(item *Item) {
+	for _, viewer := range item.GetViewers() {
+		if session, ok := viewer.(*net.MinecraftSession); ok {
+			session.SendRemoveEntity(item.GetUniqueId())
+			session.SendAddItemEntity(item.GetUniqueId(), item.GetRuntimeId(), item.Stack, item.Position, item.motion)
+		}
+	}
+}
+
+// pickup lets the session pick up the item if it is close enough and allowed to pick it up,
+// after calling a pickup event. Items are only picked up if the complete stack fits in the inventory.
+// A bool is returned indicating if the item was picked up.
+func (manager *Manager) pickup(session *net.MinecraftSession, item *Item) bool {
+	var player = session.GetPlayer()
+	if player.GetDimension() != item.GetDimension() || player.IsDead() {
+		return false
+	}
+	if player.Position.Sub(r3.Vector{Y: eyeHeight}).Distance(item.Position) > manager.PickupRadius || !item.CanPickup(player.GetName()) {
+		return false
+	}
+	if !player.GetInventory().CanAddItems(item.Stack) || !manager.eventManager.Call(&PickupEvent{Session: session, Item: item}) {
+		return false
+	}
+	var stack = *item.Stack
+	player.GetInventory().AddItem(&stack)
+	session.SendInventory()
+
+	session.SendTakeItemEntity(item.GetRuntimeId(), player.GetRuntimeId())
+	for _, viewer := range item.GetViewers() {
+		if viewer, ok := viewer.(*net.MinecraftSession); ok && viewer != session {
+			viewer.SendTakeItemEntity(item.GetRuntimeId(), player.GetRuntimeId())
+		}
+	}
+	manager.RemoveItem(item)
+	return true
+}
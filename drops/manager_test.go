@@ -0,0 +1,79 @@
+package drops
+
+import (
+	"math"
+	"testing"
+
+	"github.com/golang/geo/r3"
+	"github.com/irmine/gomine/items"
+)
+
+func TestCanPickup(t *testing.T) {
+	var stone, _ = items.DefaultManager.Get("minecraft:stone", 1)
+	var item = NewItem(stone, "Steve", 2, 4)
+	if item.CanPickup("Steve") {
+		t.Error("item could be picked up during its pickup delay")
+	}
+	item.tick()
+	item.tick()
+	if !item.CanPickup("Steve") || item.CanPickup("Alex") {
+		t.Error("only the owner should be able to pick up the item during the owner window")
+	}
+	item.tick()
+	item.tick()
+	if !item.CanPickup("Alex") {
+		t.Error("item could not be picked up by others after the owner window")
+	}
+}
+
+func TestThrow(t *testing.T) {
+	var motion = GetThrowMotion(0, 0, 0.3)
+	if math.Abs(motion.X) > 1e-9 || math.Abs(motion.Z-0.3) > 1e-9 || motion.Y <= 0 {
+		t.Error("item thrown looking south should move south:", motion)
+	}
+	if motion = GetThrowMotion(90, 0, 0.3); math.Abs(motion.X+0.3) > 1e-9 {
+		t.Error("item thrown looking west should move west:", motion)
+	}
+
+	var stone, _ = items.DefaultManager.Get("minecraft:stone", 1)
+	var item = NewItem(stone, "", 0, 0)
+	item.Position = r3.Vector{Y: 5}
+	item.motion, item.groundY = GetThrowMotion(0, 0, 0.3), 4
+	for i := 0; i < 100; i++ {
+		item.tick()
+	}
+	if item.Position.Y != 4 || item.motion != (r3.Vector{}) || item.Position.Z <= 0 {
+		t.Error("thrown item did not land in front of the thrower:", item.Position)
+	}
+}
+
+func TestMerge(t *testing.T) {
+	var stone, _ = items.DefaultManager.Get("minecraft:stone", 40)
+	var moreStone, _ = items.DefaultManager.Get("minecraft:stone", 40)
+	var dirt, _ = items.DefaultManager.Get("minecraft:dirt", 1)
+	var target, source = NewItem(stone, "", 0, 0), NewItem(moreStone, "", 20, 0)
+	target.age = 100
+	source.Position = r3.Vector{X: 0.4}
+
+	if canMerge(target, NewItem(dirt, "", 0, 0), 0.5) {
+		t.Error("items of a different kind could merge")
+	}
+	if canMerge(target, source, 0.3) {
+		t.Error("items outside the merge radius could merge")
+	}
+	if canMerge(NewItem(stone, "Steve", 0, 10), NewItem(moreStone, "Alex", 0, 10), 0.5) {
+		t.Error("items owned by different players could merge")
+	}
+	if !canMerge(target, source, 0.5) || !merge(target, source) {
+		t.Fatal("items of the same kind within the merge radius did not merge")
+	}
+	if target.Stack.Count != 64 || source.Stack.Count != 16 {
+		t.Error("merged stack exceeded the max stack size:", target.Stack.Count, source.Stack.Count)
+	}
+	if target.pickupDelay != 20 || target.age != 0 {
+		t.Error("merged item did not keep the longest pickup delay and youngest age:", target.pickupDelay, target.age)
+	}
+	if canMerge(target, source, 0.5) {
+		t.Error("items could merge into a full stack")
+	}
+}
@@ -0,0 +1,26 @@
+package economy
+
+import (
+	"errors"
+)
+
+// InsufficientFunds gets returned when a player tries
+// to pay more money than the player has.
+var InsufficientFunds = errors.New("insufficient funds")
+
+// Economy is a service managing the money of players.
+// GoMine does not implement an economy itself:
+// plugins implement this interface, for example backed by a database,
+// and set it on the server using Server.SetEconomy.
+type Economy interface {
+	// GetBalance returns the balance of the player with the given name.
+	GetBalance(name string) (float64, error)
+	// Withdraw withdraws the amount from the balance of the player with the given name.
+	// InsufficientFunds should be returned if the balance is too low.
+	Withdraw(name string, amount float64) error
+	// Deposit deposits the amount to the balance of the player with the given name.
+	// Deposits should also succeed for players that are offline.
+	Deposit(name string, amount float64) error
+	// Format formats the amount of money as displayed to players, for example "$12.50".
+	Format(amount float64) string
+}
@@ -0,0 +1,56 @@
+// Package ecs provides queries selecting entities of all kinds, such as players, mobs and items,
+// by composable predicates on their type, position, tags and components.
+// Entities are provided by sources, such as the mob manager, which are iterated in place without copying them.
+package ecs
+
+import (
+	"github.com/golang/geo/r3"
+	"github.com/irmine/worlds"
+)
+
+// Components entities may have, checked using Has.
+const (
+	// ComponentAI is the component of entities driven by behaviors, such as mobs.
+	ComponentAI = "ai"
+	// ComponentNameTag is the component of entities with a name tag.
+	ComponentNameTag = "name_tag"
+	// ComponentPersistent is the component of entities that are never despawned.
+	ComponentPersistent = "persistent"
+	// ComponentItem is the component of entities holding an item stack, such as dropped items.
+	ComponentItem = "item"
+	// ComponentInventory is the component of entities with an inventory, such as players.
+	ComponentInventory = "inventory"
+)
+
+// Entity is an entity that can be queried.
+type Entity interface {
+	GetRuntimeId() uint64
+	// GetIdentifier returns the identifier of the type of the entity, such as minecraft:zombie.
+	GetIdentifier() string
+	// GetDimension returns the dimension the entity is in, which is nil if the entity was not spawned.
+	GetDimension() *worlds.Dimension
+	GetPosition() r3.Vector
+}
+
+// ComponentHolder is an entity with components, such as ComponentAI.
+// Entities that are not component holders have no components.
+type ComponentHolder interface {
+	Entity
+	// HasComponent checks if the entity has the component with the given name.
+	HasComponent(component string) bool
+}
+
+// TagHolder is an entity with tags, which are set by commands or plugins to group entities.
+// Entities that are not tag holders have no tags.
+type TagHolder interface {
+	Entity
+	// HasTag checks if the entity has the given tag.
+	HasTag(tag string) bool
+}
+
+// Source provides entities to queries, such as the mob manager.
+type Source interface {
+	// EachEntity calls the function for every entity of the source, until the function returns false.
+	// The function is called while the source is locked, so it must not spawn or remove entities of the source.
+	EachEntity(function func(entity Entity) bool)
+}
@@ -0,0 +1,115 @@
+package ecs
+
+import (
+	"github.com/golang/geo/r3"
+	"github.com/irmine/worlds"
+)
+
+// Predicate checks if an entity is selected.
+type Predicate func(entity Entity) bool
+
+// Filter selects the entities returned by a query.
+// The zero value of a field matches all entities.
+type Filter struct {
+	// Dimension is the dimension the entities are in. It is checked before the predicate,
+	// so that entities in other dimensions are skipped cheaply.
+	Dimension *worlds.Dimension
+	// Predicate is the predicate entities must match, usually composed using And, Or and Not.
+	Predicate Predicate
+	// Limit is the maximum amount of entities selected. The query stops once the limit is reached.
+	Limit int
+}
+
+// Matches checks if the entity is selected by the filter, disregarding the limit.
+func (filter Filter) Matches(entity Entity) bool {
+	if filter.Dimension != nil && entity.GetDimension() != filter.Dimension {
+		return false
+	}
+	return filter.Predicate == nil || filter.Predicate(entity)
+}
+
+// All matches all entities.
+func All() Predicate {
+	return func(Entity) bool {
+		return true
+	}
+}
+
+// And matches entities matching all the predicates.
+func And(predicates ...Predicate) Predicate {
+	return func(entity Entity) bool {
+		for _, predicate := range predicates {
+			if !predicate(entity) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// Or matches entities matching any of the predicates.
+func Or(predicates ...Predicate) Predicate {
+	return func(entity Entity) bool {
+		for _, predicate := range predicates {
+			if predicate(entity) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// Not matches entities not matching the predicate.
+func Not(predicate Predicate) Predicate {
+	return func(entity Entity) bool {
+		return !predicate(entity)
+	}
+}
+
+// Type matches entities with any of the identifiers, such as minecraft:item.
+func Type(identifiers ...string) Predicate {
+	return func(entity Entity) bool {
+		var identifier = entity.GetIdentifier()
+		for _, id := range identifiers {
+			if id == identifier {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// InLevel matches entities in any dimension of the level with the given name.
+func InLevel(levelName string) Predicate {
+	return func(entity Entity) bool {
+		var dimension = entity.GetDimension()
+		return dimension != nil && dimension.GetLevel().GetName() == levelName
+	}
+}
+
+// Within matches entities in the dimension within the radius around the center.
+func Within(dimension *worlds.Dimension, center r3.Vector, radius float64) Predicate {
+	var squared = radius * radius
+	return func(entity Entity) bool {
+		if entity.GetDimension() != dimension {
+			return false
+		}
+		return entity.GetPosition().Sub(center).Norm2() <= squared
+	}
+}
+
+// Tag matches entities with the given tag.
+func Tag(tag string) Predicate {
+	return func(entity Entity) bool {
+		var holder, ok = entity.(TagHolder)
+		return ok && holder.HasTag(tag)
+	}
+}
+
+// Has matches entities with the component with the given name.
+func Has(component string) Predicate {
+	return func(entity Entity) bool {
+		var holder, ok = entity.(ComponentHolder)
+		return ok && holder.HasComponent(component)
+	}
+}
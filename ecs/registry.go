@@ -0,0 +1,99 @@
+package ecs
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/golang/geo/r3"
+)
+
+// namedSource is an entity source registered with a name.
+type namedSource struct {
+	name   string
+	source Source
+}
+
+// Registry holds the entity sources of the server, and queries entities of all of them.
+type Registry struct {
+	mutex   sync.RWMutex
+	sources []namedSource
+}
+
+// NewRegistry returns a new registry without sources.
+func NewRegistry() *Registry {
+	return &Registry{}
+}
+
+// AddSource adds an entity source with the given name, such as mobs,
+// replacing the source previously added with the name.
+func (registry *Registry) AddSource(name string, source Source) {
+	registry.RemoveSource(name)
+	registry.mutex.Lock()
+	registry.sources = append(registry.sources, namedSource{name, source})
+	registry.mutex.Unlock()
+}
+
+// RemoveSource removes the entity source with the given name.
+func (registry *Registry) RemoveSource(name string) {
+	registry.mutex.Lock()
+	defer registry.mutex.Unlock()
+	for i, source := range registry.sources {
+		if source.name == name {
+			registry.sources = append(registry.sources[:i], registry.sources[i+1:]...)
+			return
+		}
+	}
+}
+
+// Each calls the function for every entity selected by the filter, in order of the sources,
+// until the function returns false or the limit of the filter is reached.
+// The function must not spawn or remove entities, which should be done with the result of QueryEntities instead.
+func (registry *Registry) Each(filter Filter, function func(entity Entity) bool) {
+	registry.mutex.RLock()
+	var sources = make([]namedSource, len(registry.sources))
+	copy(sources, registry.sources)
+	registry.mutex.RUnlock()
+
+	var count = 0
+	for _, source := range sources {
+		var stopped = false
+		source.source.EachEntity(func(entity Entity) bool {
+			if !filter.Matches(entity) {
+				return true
+			}
+			count++
+			stopped = !function(entity) || (filter.Limit > 0 && count >= filter.Limit)
+			return !stopped
+		})
+		if stopped {
+			return
+		}
+	}
+}
+
+// QueryEntities returns all entities selected by the filter.
+func (registry *Registry) QueryEntities(filter Filter) []Entity {
+	var selected []Entity
+	registry.Each(filter, func(entity Entity) bool {
+		selected = append(selected, entity)
+		return true
+	})
+	return selected
+}
+
+// Count returns the amount of entities selected by the filter.
+func (registry *Registry) Count(filter Filter) int {
+	var count = 0
+	registry.Each(filter, func(Entity) bool {
+		count++
+		return true
+	})
+	return count
+}
+
+// SortByDistance sorts the entities by their distance to the center, from nearest to farthest.
+func SortByDistance(entities []Entity, center r3.Vector) {
+	sort.SliceStable(entities, func(i, j int) bool {
+		return entities[i].GetPosition().Sub(center).Norm2() < entities[j].GetPosition().Sub(center).Norm2()
+	})
+}
@@ -0,0 +1,79 @@
+package ecs
+
+import (
+	"testing"
+
+	"github.com/golang/geo/r3"
+	"github.com/irmine/worlds"
+)
+
+type testEntity struct {
+	runtimeId  uint64
+	identifier string
+	dimension  *worlds.Dimension
+	position   r3.Vector
+	tags       map[string]bool
+}
+
+func (entity *testEntity) GetRuntimeId() uint64            { return entity.runtimeId }
+func (entity *testEntity) GetIdentifier() string           { return entity.identifier }
+func (entity *testEntity) GetDimension() *worlds.Dimension { return entity.dimension }
+func (entity *testEntity) GetPosition() r3.Vector          { return entity.position }
+func (entity *testEntity) HasTag(tag string) bool          { return entity.tags[tag] }
+func (entity *testEntity) HasComponent(component string) bool {
+	return component == ComponentItem && entity.identifier == "minecraft:item"
+}
+
+type testSource []Entity
+
+func (source testSource) EachEntity(function func(entity Entity) bool) {
+	for _, entity := range source {
+		if !function(entity) {
+			return
+		}
+	}
+}
+
+func TestQueryEntities(t *testing.T) {
+	var overworld, nether = &worlds.Dimension{}, &worlds.Dimension{}
+	var registry = NewRegistry()
+	registry.AddSource("mobs", testSource{
+		&testEntity{1, "minecraft:zombie", overworld, r3.Vector{X: 5}, map[string]bool{"boss": true}},
+		&testEntity{2, "minecraft:cow", overworld, r3.Vector{X: 50}, nil},
+		&testEntity{3, "minecraft:zombie", nether, r3.Vector{}, nil},
+	})
+	registry.AddSource("items", testSource{
+		&testEntity{4, "minecraft:item", overworld, r3.Vector{X: 2}, nil},
+		&testEntity{5, "minecraft:item", overworld, r3.Vector{X: 20}, nil},
+	})
+
+	if count := registry.Count(Filter{}); count != 5 {
+		t.Error("empty filter did not match all entities:", count)
+	}
+	if count := registry.Count(Filter{Predicate: Type("minecraft:item")}); count != 2 {
+		t.Error("type predicate matched", count, "entities")
+	}
+	if count := registry.Count(Filter{Dimension: overworld, Predicate: Type("minecraft:zombie")}); count != 1 {
+		t.Error("dimension was not filtered:", count)
+	}
+	if count := registry.Count(Filter{Predicate: And(Within(overworld, r3.Vector{}, 10), Not(Has(ComponentItem)))}); count != 1 {
+		t.Error("composed predicate matched", count, "entities")
+	}
+	if count := registry.Count(Filter{Predicate: Or(Tag("boss"), Type("minecraft:cow"))}); count != 2 {
+		t.Error("or predicate matched", count, "entities")
+	}
+	if entities := registry.QueryEntities(Filter{Limit: 3}); len(entities) != 3 {
+		t.Error("limit was not applied:", len(entities))
+	}
+
+	var entities = registry.QueryEntities(Filter{Dimension: overworld})
+	SortByDistance(entities, r3.Vector{X: 19})
+	if entities[0].GetRuntimeId() != 5 || entities[len(entities)-1].GetRuntimeId() != 2 {
+		t.Error("entities were not sorted by distance")
+	}
+
+	registry.RemoveSource("items")
+	if count := registry.Count(Filter{}); count != 3 {
+		t.Error("removed source was still queried:", count)
+	}
+}
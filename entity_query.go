@@ -0,0 +1,132 @@
+package gomine
+
+import (
+	"math/rand"
+	"strconv"
+	"strings"
+
+	"github.com/irmine/gomine/commands"
+	"github.com/irmine/gomine/commands/selectors"
+	"github.com/irmine/gomine/drops"
+	"github.com/irmine/gomine/ecs"
+	"github.com/irmine/gomine/mobs"
+	"github.com/irmine/gomine/net"
+	"github.com/irmine/gomine/players"
+)
+
+// QueryEntities returns all players, mobs and items selected by the filter.
+func (server *Server) QueryEntities(filter ecs.Filter) []ecs.Entity {
+	return server.EntityRegistry.QueryEntities(filter)
+}
+
+// SelectEntities returns the entities selected by the target of a command, which is either the name of a player
+// or a target selector such as @e[type=item,r=10]. Selectors support the type, tag and has (component) arguments,
+// which may be negated with a leading !, and the r (radius) and c (count) arguments.
+// selectors.InvalidSelector is returned if the selector is malformed, or uses a radius without being sent by a player.
+func (server *Server) SelectEntities(sender commands.Sender, target string) ([]ecs.Entity, error) {
+	if !selectors.IsSelector(target) {
+		if session, ok := server.SessionManager.GetSession(target); ok && session.GetPlayer().GetDimension() != nil {
+			return []ecs.Entity{session.GetPlayer()}, nil
+		}
+		return nil, nil
+	}
+	var selector, err = selectors.Parse(target)
+	if err != nil {
+		return nil, err
+	}
+	var session, isPlayer = sender.(*net.MinecraftSession)
+	var filter ecs.Filter
+	var predicates []ecs.Predicate
+	switch selector.GetVariable() {
+	case selectors.Self:
+		if !isPlayer {
+			return nil, nil
+		}
+		var player = session.GetPlayer()
+		predicates = append(predicates, func(entity ecs.Entity) bool {
+			return entity == player
+		})
+	case selectors.NearestPlayer, selectors.RandomPlayer, selectors.AllPlayers:
+		predicates = append(predicates, ecs.Type(players.Identifier))
+	}
+	if value, ok := selector.GetArgument("type"); ok {
+		predicates = append(predicates, negatable(value, func(identifier string) ecs.Predicate {
+			if !strings.Contains(identifier, ":") {
+				identifier = "minecraft:" + identifier
+			}
+			return ecs.Type(identifier)
+		}))
+	}
+	if value, ok := selector.GetArgument("tag"); ok {
+		predicates = append(predicates, negatable(value, ecs.Tag))
+	}
+	if value, ok := selector.GetArgument("has"); ok {
+		predicates = append(predicates, negatable(value, ecs.Has))
+	}
+	if value, ok := selector.GetArgument("r"); ok {
+		var radius, err = strconv.ParseFloat(value, 64)
+		if err != nil || radius < 0 || !isPlayer {
+			return nil, selectors.InvalidSelector
+		}
+		filter.Dimension = session.GetPlayer().GetDimension()
+		predicates = append(predicates, ecs.Within(filter.Dimension, session.GetPosition(), radius))
+	}
+	var limit = 0
+	if value, ok := selector.GetArgument("c"); ok {
+		if limit, err = strconv.Atoi(value); err != nil || limit < 0 {
+			return nil, selectors.InvalidSelector
+		}
+	}
+	filter.Predicate = ecs.And(predicates...)
+
+	switch selector.GetVariable() {
+	case selectors.NearestPlayer, selectors.RandomPlayer:
+		// The nearest or random players are picked from all players, so the limit is applied afterwards.
+		var entities = server.QueryEntities(filter)
+		if selector.GetVariable() == selectors.RandomPlayer {
+			rand.Shuffle(len(entities), func(i, j int) {
+				entities[i], entities[j] = entities[j], entities[i]
+			})
+		} else if isPlayer {
+			ecs.SortByDistance(entities, session.GetPosition())
+		}
+		if limit == 0 {
+			limit = 1
+		}
+		if len(entities) > limit {
+			entities = entities[:limit]
+		}
+		return entities, nil
+	}
+	filter.Limit = limit
+	return server.QueryEntities(filter), nil
+}
+
+// negatable returns the predicate created for the value of a selector argument,
+// which gets negated if the value starts with !.
+func negatable(value string, predicate func(value string) ecs.Predicate) ecs.Predicate {
+	if strings.HasPrefix(value, "!") {
+		return ecs.Not(predicate(value[1:]))
+	}
+	return predicate(value)
+}
+
+// KillEntity kills the entity if it is a player, or removes it if it is a mob or item.
+// A bool is returned indicating if the entity was killed.
+func (server *Server) KillEntity(entity ecs.Entity) bool {
+	switch entity := entity.(type) {
+	case *players.Player:
+		var session, ok = server.SessionManager.GetSessionByRuntimeId(entity.GetRuntimeId())
+		if !ok || entity.IsDead() {
+			return false
+		}
+		server.CombatManager.Kill(session)
+	case *mobs.Mob:
+		server.MobManager.RemoveEntity(entity)
+	case *drops.Item:
+		server.DropManager.RemoveItem(entity)
+	default:
+		return false
+	}
+	return true
+}
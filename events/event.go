@@ -0,0 +1,33 @@
+package events
+
+// Name is the name of an event.
+// Handlers get registered on event names.
+type Name string
+
+// Event is an interface satisfied by every event.
+type Event interface {
+	GetName() Name
+}
+
+// CancellableEvent is an interface satisfied by every event that can be cancelled.
+type CancellableEvent interface {
+	Event
+	IsCancelled() bool
+	SetCancelled(bool)
+}
+
+// Cancellable can be embedded in events to make them cancellable.
+type Cancellable struct {
+	cancelled bool
+}
+
+// IsCancelled checks if the event has been cancelled.
+func (cancellable *Cancellable) IsCancelled() bool {
+	return cancellable.cancelled
+}
+
+// SetCancelled sets the event cancelled or not cancelled.
+// Cancelled events still get passed on to handlers of a higher priority.
+func (cancellable *Cancellable) SetCancelled(value bool) {
+	cancellable.cancelled = value
+}
@@ -0,0 +1,29 @@
+package events
+
+// Handler handles events with the name it was registered on.
+// Every handler has a handling function that handles the event.
+type Handler struct {
+	function func(event Event)
+	priority int
+}
+
+// NewHandler returns a new event handler with the given handling function.
+// NewHandler will by default use a priority of 5.
+func NewHandler(function func(event Event)) *Handler {
+	return &Handler{function, 5}
+}
+
+// SetPriority sets the priority of this handler in an integer 0 - 10.
+// 0 is executed first, 10 is executed last.
+func (handler *Handler) SetPriority(priority int) bool {
+	if priority > 10 || priority < 0 {
+		return false
+	}
+	handler.priority = priority
+	return true
+}
+
+// GetPriority returns the priority of this handler in an integer 0 - 10.
+func (handler *Handler) GetPriority() int {
+	return handler.priority
+}
@@ -0,0 +1,61 @@
+package events
+
+import (
+	"sync"
+)
+
+// Manager manages all event handlers,
+// and calls events on the handlers registered for them.
+type Manager struct {
+	mutex    sync.RWMutex
+	handlers map[Name][][]*Handler
+}
+
+// NewManager returns a new event manager.
+func NewManager() *Manager {
+	return &Manager{handlers: make(map[Name][][]*Handler)}
+}
+
+// Register registers a new handler to listen for events with the given name.
+// This function uses the priority of the handler.
+func (manager *Manager) Register(name Name, handler *Handler) {
+	manager.mutex.Lock()
+	defer manager.mutex.Unlock()
+	if manager.handlers[name] == nil {
+		manager.handlers[name] = make([][]*Handler, 11)
+	}
+	manager.handlers[name][handler.GetPriority()] = append(manager.handlers[name][handler.GetPriority()], handler)
+}
+
+// Deregister deregisters all handlers listening for events with the given name, on the given priority.
+func (manager *Manager) Deregister(name Name, priority int) {
+	manager.mutex.Lock()
+	defer manager.mutex.Unlock()
+	if manager.handlers[name] == nil || priority > 10 || priority < 0 {
+		return
+	}
+	manager.handlers[name][priority] = []*Handler{}
+}
+
+// GetHandlers returns all handlers registered for the given event name, ordered by priority.
+func (manager *Manager) GetHandlers(name Name) []*Handler {
+	manager.mutex.RLock()
+	defer manager.mutex.RUnlock()
+	var handlers []*Handler
+	for _, priorityHandlers := range manager.handlers[name] {
+		handlers = append(handlers, priorityHandlers...)
+	}
+	return handlers
+}
+
+// Call calls the event on all handlers registered for it.
+// Returns false if the event is cancellable and was cancelled by any of the handlers.
+func (manager *Manager) Call(event Event) bool {
+	for _, handler := range manager.GetHandlers(event.GetName()) {
+		handler.function(event)
+	}
+	if cancellable, ok := event.(CancellableEvent); ok {
+		return !cancellable.IsCancelled()
+	}
+	return true
+}
@@ -0,0 +1,34 @@
+package events
+
+import (
+	"testing"
+)
+
+type testEvent struct {
+	Cancellable
+}
+
+func (event *testEvent) GetName() Name {
+	return "TestEvent"
+}
+
+func TestManager(t *testing.T) {
+	manager := NewManager()
+	var order []int
+	late := NewHandler(func(event Event) {
+		order = append(order, 10)
+	})
+	late.SetPriority(10)
+	manager.Register("TestEvent", late)
+	manager.Register("TestEvent", NewHandler(func(event Event) {
+		order = append(order, 5)
+		event.(CancellableEvent).SetCancelled(true)
+	}))
+
+	if manager.Call(&testEvent{}) {
+		t.Error("cancelled event was not reported as cancelled")
+	}
+	if len(order) != 2 || order[0] != 5 || order[1] != 10 {
+		t.Error("handlers were not called in order of priority:", order)
+	}
+}
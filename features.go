@@ -0,0 +1,50 @@
+package gomine
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/irmine/gomine/features"
+	"github.com/irmine/gomine/text"
+)
+
+// featureState holds the feature flags checked by the subsystems of the server when it started.
+type featureState struct {
+	serverAuthMovement   bool
+	parallelChunkTicking bool
+}
+
+// applyFeatures checks the feature flags of the subsystems of the server,
+// and logs the enabled experimental features and the flags that are not registered.
+func (server *Server) applyFeatures() {
+	server.featureState = featureState{
+		serverAuthMovement:   server.Features.IsEnabled(features.ServerAuthMovement),
+		parallelChunkTicking: server.Features.IsEnabled(features.ParallelChunkTicking),
+	}
+	if enabled := server.Features.GetEnabled(); len(enabled) != 0 {
+		text.DefaultLogger.Warning("Experimental features enabled: " + strings.Join(enabled, ", "))
+	}
+	for _, name := range server.Features.GetUnknown() {
+		text.DefaultLogger.Warning("Unknown feature flag " + name + " in gomine.yml, it is ignored.")
+	}
+}
+
+// tickLevels ticks all levels, each in its own goroutine if parallel chunk ticking is enabled.
+func (server *Server) tickLevels() {
+	if !server.featureState.parallelChunkTicking {
+		for _, level := range server.LevelManager.GetLevels() {
+			level.Tick()
+		}
+		return
+	}
+	var wait sync.WaitGroup
+	for _, level := range server.LevelManager.GetLevels() {
+		var level = level
+		wait.Add(1)
+		go func() {
+			level.Tick()
+			wait.Done()
+		}()
+	}
+	wait.Wait()
+}
@@ -0,0 +1,141 @@
+// Package features provides feature flags, which toggle experimental subsystems per server.
+// Flags are registered with a description and a default, set from the Features section in gomine.yml,
+// and checked by subsystems when they get initialized, so that big experimental features can ship disabled.
+// Plugins may check the built-in flags, and register flags of their own.
+package features
+
+import (
+	"errors"
+	"sort"
+	"sync"
+)
+
+// UnknownFlag gets returned when setting a feature flag that was not registered.
+var UnknownFlag = errors.New("unknown feature flag")
+
+// Names of the built-in feature flags.
+const (
+	// ServerAuthMovement makes the server authoritative over the movement of players:
+	// every movement is validated and corrected by the movement processor, even if movement checks are disabled.
+	ServerAuthMovement = "server-auth-movement"
+	// ParallelChunkTicking ticks the chunks of all levels in parallel, rather than one level after another.
+	ParallelChunkTicking = "parallel-chunk-ticking"
+)
+
+// Flag is a feature flag toggling a subsystem.
+type Flag struct {
+	// Name is the name of the flag in the configuration, such as parallel-chunk-ticking.
+	Name string
+	// Description describes what enabling the flag does.
+	Description string
+	// Default specifies if the flag is enabled if it is not set in the configuration.
+	Default bool
+}
+
+// Flags holds the registered feature flags and the values they were set to.
+type Flags struct {
+	mutex  sync.RWMutex
+	flags  map[string]Flag
+	values map[string]bool
+}
+
+// NewFlags returns a new set of feature flags without registered flags.
+func NewFlags() *Flags {
+	return &Flags{flags: make(map[string]Flag), values: make(map[string]bool)}
+}
+
+// RegisterDefaults registers the built-in feature flags, which are all disabled by default.
+func (flags *Flags) RegisterDefaults() {
+	flags.Register(Flag{ServerAuthMovement, "Validates and corrects every movement of players on the server.", false})
+	flags.Register(Flag{ParallelChunkTicking, "Ticks the chunks of all levels in parallel.", false})
+}
+
+// Register registers the feature flag, replacing the flag previously registered with its name.
+// Values set before the flag was registered are kept, so that flags of plugins can be set in the configuration.
+func (flags *Flags) Register(flag Flag) {
+	flags.mutex.Lock()
+	flags.flags[flag.Name] = flag
+	flags.mutex.Unlock()
+}
+
+// IsRegistered checks if a feature flag with the given name is registered.
+func (flags *Flags) IsRegistered(name string) bool {
+	flags.mutex.RLock()
+	defer flags.mutex.RUnlock()
+	var _, ok = flags.flags[name]
+	return ok
+}
+
+// IsEnabled checks if the feature flag with the given name is enabled,
+// which is its default if it was not set. Unknown flags are disabled unless they were set.
+func (flags *Flags) IsEnabled(name string) bool {
+	flags.mutex.RLock()
+	defer flags.mutex.RUnlock()
+	if value, ok := flags.values[name]; ok {
+		return value
+	}
+	return flags.flags[name].Default
+}
+
+// Set enables or disables the registered feature flag with the given name.
+// Subsystems check flags when they get initialized, so changing a flag may require a restart.
+// UnknownFlag is returned if no flag with the name is registered.
+func (flags *Flags) Set(name string, enabled bool) error {
+	flags.mutex.Lock()
+	defer flags.mutex.Unlock()
+	if _, ok := flags.flags[name]; !ok {
+		return UnknownFlag
+	}
+	flags.values[name] = enabled
+	return nil
+}
+
+// Load sets the feature flags to the values of the name => enabled map, usually read from the configuration.
+// Values of flags that are not registered are kept, as they may be registered later by plugins.
+func (flags *Flags) Load(values map[string]bool) {
+	flags.mutex.Lock()
+	defer flags.mutex.Unlock()
+	for name, enabled := range values {
+		flags.values[name] = enabled
+	}
+}
+
+// GetFlags returns all registered feature flags, sorted by name.
+func (flags *Flags) GetFlags() []Flag {
+	flags.mutex.RLock()
+	var list = make([]Flag, 0, len(flags.flags))
+	for _, flag := range flags.flags {
+		list = append(list, flag)
+	}
+	flags.mutex.RUnlock()
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].Name < list[j].Name
+	})
+	return list
+}
+
+// GetEnabled returns the names of all enabled feature flags, sorted by name.
+func (flags *Flags) GetEnabled() []string {
+	var names []string
+	for _, flag := range flags.GetFlags() {
+		if flags.IsEnabled(flag.Name) {
+			names = append(names, flag.Name)
+		}
+	}
+	return names
+}
+
+// GetUnknown returns the names of the feature flags that were set, but were never registered, sorted by name.
+// These are usually misspelled in the configuration.
+func (flags *Flags) GetUnknown() []string {
+	flags.mutex.RLock()
+	var names []string
+	for name := range flags.values {
+		if _, ok := flags.flags[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	flags.mutex.RUnlock()
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,37 @@
+package features
+
+import (
+	"testing"
+)
+
+func TestFlags(t *testing.T) {
+	var flags = NewFlags()
+	flags.RegisterDefaults()
+	if flags.IsEnabled(ParallelChunkTicking) {
+		t.Error("built-in flag was enabled by default")
+	}
+	if err := flags.Set(ParallelChunkTicking, true); err != nil || !flags.IsEnabled(ParallelChunkTicking) {
+		t.Error("flag was not enabled:", err)
+	}
+	if err := flags.Set("unknown-flag", true); err != UnknownFlag {
+		t.Error("setting an unknown flag did not fail:", err)
+	}
+
+	flags.Load(map[string]bool{ServerAuthMovement: true, "plugin-flag": false, "typo-flag": true})
+	if !flags.IsEnabled(ServerAuthMovement) {
+		t.Error("loaded flag was not enabled")
+	}
+	if !flags.IsEnabled("typo-flag") {
+		t.Error("loaded unknown flag was not kept")
+	}
+	flags.Register(Flag{Name: "plugin-flag", Description: "A flag of a plugin.", Default: true})
+	if flags.IsEnabled("plugin-flag") {
+		t.Error("flag registered after loading did not keep its loaded value")
+	}
+	if unknown := flags.GetUnknown(); len(unknown) != 1 || unknown[0] != "typo-flag" {
+		t.Error("unexpected unknown flags:", unknown)
+	}
+	if enabled := flags.GetEnabled(); len(enabled) != 2 || enabled[0] != ParallelChunkTicking || enabled[1] != ServerAuthMovement {
+		t.Error("unexpected enabled flags:", enabled)
+	}
+}
@@ -0,0 +1,221 @@
+package forms
+
+import (
+	"encoding/json"
+)
+
+// Element is an element of a custom form.
+// Every element converts the raw JSON value
+// the client sent for it into a Go value.
+type Element interface {
+	json.Marshaler
+	// ConvertValue converts the raw response value of the element.
+	// Returns false if the value was not valid for the element.
+	ConvertValue(value interface{}) (interface{}, bool)
+}
+
+// CustomForm is a form with a list of elements,
+// such as inputs, toggles, sliders and dropdowns.
+// The response of a custom form are the values of all elements.
+type CustomForm struct {
+	Title    string
+	Elements []Element
+}
+
+// NewCustomForm returns a new custom form with the given title.
+func NewCustomForm(title string) *CustomForm {
+	return &CustomForm{title, []Element{}}
+}
+
+// AddElement adds an element to the form.
+// The index of the element is the index of its value in the response.
+func (form *CustomForm) AddElement(element Element) {
+	form.Elements = append(form.Elements, element)
+}
+
+// GetType returns the type of the form.
+func (form *CustomForm) GetType() string {
+	return TypeCustom
+}
+
+// GetTitle returns the title of the form.
+func (form *CustomForm) GetTitle() string {
+	return form.Title
+}
+
+// MarshalJSON returns the JSON data of the form.
+func (form *CustomForm) MarshalJSON() ([]byte, error) {
+	return json.Marshal(map[string]interface{}{
+		"type":    TypeCustom,
+		"title":   form.Title,
+		"content": form.Elements,
+	})
+}
+
+// ParseResponse parses the response of the client, which is a list of values of all elements.
+func (form *CustomForm) ParseResponse(data string) (*Response, error) {
+	if isClosed(data) {
+		return &Response{Closed: true}, nil
+	}
+	var raw []interface{}
+	if err := json.Unmarshal([]byte(data), &raw); err != nil || len(raw) != len(form.Elements) {
+		return nil, InvalidResponse
+	}
+	var values = make([]interface{}, len(raw))
+	for i, element := range form.Elements {
+		var value, ok = element.ConvertValue(raw[i])
+		if !ok {
+			return nil, InvalidResponse
+		}
+		values[i] = value
+	}
+	return &Response{Values: values}, nil
+}
+
+// Label is an element displaying text. Labels have no value.
+type Label struct {
+	Text string
+}
+
+// NewLabel returns a new label with the given text.
+func NewLabel(text string) *Label {
+	return &Label{text}
+}
+
+// MarshalJSON returns the JSON data of the label.
+func (label *Label) MarshalJSON() ([]byte, error) {
+	return json.Marshal(map[string]interface{}{"type": "label", "text": label.Text})
+}
+
+// ConvertValue returns nil, as labels have no value.
+func (label *Label) ConvertValue(value interface{}) (interface{}, bool) {
+	return nil, true
+}
+
+// Input is a text input element. The value of an input is a string.
+type Input struct {
+	Text        string
+	Placeholder string
+	Default     string
+}
+
+// NewInput returns a new input with the given text, placeholder and default value.
+func NewInput(text string, placeholder string, def string) *Input {
+	return &Input{text, placeholder, def}
+}
+
+// MarshalJSON returns the JSON data of the input.
+func (input *Input) MarshalJSON() ([]byte, error) {
+	return json.Marshal(map[string]interface{}{"type": "input", "text": input.Text, "placeholder": input.Placeholder, "default": input.Default})
+}
+
+// ConvertValue converts the value to a string.
+func (input *Input) ConvertValue(value interface{}) (interface{}, bool) {
+	var s, ok = value.(string)
+	return s, ok
+}
+
+// Toggle is an on/off switch element. The value of a toggle is a bool.
+type Toggle struct {
+	Text    string
+	Default bool
+}
+
+// NewToggle returns a new toggle with the given text and default value.
+func NewToggle(text string, def bool) *Toggle {
+	return &Toggle{text, def}
+}
+
+// MarshalJSON returns the JSON data of the toggle.
+func (toggle *Toggle) MarshalJSON() ([]byte, error) {
+	return json.Marshal(map[string]interface{}{"type": "toggle", "text": toggle.Text, "default": toggle.Default})
+}
+
+// ConvertValue converts the value to a bool.
+func (toggle *Toggle) ConvertValue(value interface{}) (interface{}, bool) {
+	var b, ok = value.(bool)
+	return b, ok
+}
+
+// Slider is an element to select a number in a range. The value of a slider is a float64.
+type Slider struct {
+	Text    string
+	Min     float64
+	Max     float64
+	Step    float64
+	Default float64
+}
+
+// NewSlider returns a new slider with the given text, range, step and default value.
+func NewSlider(text string, min float64, max float64, step float64, def float64) *Slider {
+	return &Slider{text, min, max, step, def}
+}
+
+// MarshalJSON returns the JSON data of the slider.
+func (slider *Slider) MarshalJSON() ([]byte, error) {
+	return json.Marshal(map[string]interface{}{"type": "slider", "text": slider.Text, "min": slider.Min, "max": slider.Max, "step": slider.Step, "default": slider.Default})
+}
+
+// ConvertValue converts the value to a float64 within the range of the slider.
+func (slider *Slider) ConvertValue(value interface{}) (interface{}, bool) {
+	var f, ok = value.(float64)
+	if !ok || f < slider.Min || f > slider.Max {
+		return nil, false
+	}
+	return f, true
+}
+
+// StepSlider is a slider to select one of the given steps.
+// The value of a step slider is the int index of the selected step.
+type StepSlider struct {
+	Text    string
+	Steps   []string
+	Default int
+}
+
+// NewStepSlider returns a new step slider with the given text, steps and default step index.
+func NewStepSlider(text string, steps []string, def int) *StepSlider {
+	return &StepSlider{text, steps, def}
+}
+
+// MarshalJSON returns the JSON data of the step slider.
+func (slider *StepSlider) MarshalJSON() ([]byte, error) {
+	return json.Marshal(map[string]interface{}{"type": "step_slider", "text": slider.Text, "steps": slider.Steps, "default": slider.Default})
+}
+
+// ConvertValue converts the value to the int index of the selected step.
+func (slider *StepSlider) ConvertValue(value interface{}) (interface{}, bool) {
+	return convertIndex(value, len(slider.Steps))
+}
+
+// Dropdown is an element to select one of the given options.
+// The value of a dropdown is the int index of the selected option.
+type Dropdown struct {
+	Text    string
+	Options []string
+	Default int
+}
+
+// NewDropdown returns a new dropdown with the given text, options and default option index.
+func NewDropdown(text string, options []string, def int) *Dropdown {
+	return &Dropdown{text, options, def}
+}
+
+// MarshalJSON returns the JSON data of the dropdown.
+func (dropdown *Dropdown) MarshalJSON() ([]byte, error) {
+	return json.Marshal(map[string]interface{}{"type": "dropdown", "text": dropdown.Text, "options": dropdown.Options, "default": dropdown.Default})
+}
+
+// ConvertValue converts the value to the int index of the selected option.
+func (dropdown *Dropdown) ConvertValue(value interface{}) (interface{}, bool) {
+	return convertIndex(value, len(dropdown.Options))
+}
+
+// convertIndex converts a JSON number to an index within the given length.
+func convertIndex(value interface{}, length int) (interface{}, bool) {
+	var f, ok = value.(float64)
+	if !ok || f < 0 || int(f) >= length || f != float64(int(f)) {
+		return nil, false
+	}
+	return int(f), true
+}
@@ -0,0 +1,93 @@
+package forms
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+)
+
+const (
+	TypeSimple = "form"
+	TypeModal  = "modal"
+	TypeCustom = "custom_form"
+)
+
+// InvalidResponse gets returned when the response
+// of a client could not be parsed for a form.
+var InvalidResponse = errors.New("invalid form response")
+
+// Form is an interface satisfied by every form.
+// Forms get serialized to the JSON format the client expects,
+// and parse the JSON response the client sends back.
+type Form interface {
+	json.Marshaler
+	// GetType returns the type of the form.
+	GetType() string
+	// GetTitle returns the title of the form.
+	GetTitle() string
+	// ParseResponse parses the raw JSON response data of the client.
+	ParseResponse(data string) (*Response, error)
+}
+
+// Response is the response of a player to a form.
+// Depending on the type of form, different fields are set.
+type Response struct {
+	// Closed is true if the player closed the form without responding.
+	// No other fields are set if the form was closed.
+	Closed bool
+	// Button is the index of the button clicked in a simple form,
+	// or the index of the item clicked in a paginated menu.
+	Button int
+	// Navigated is true if a button to open another page of a paginated menu was clicked.
+	// Page is the index of the page to open.
+	Navigated bool
+	Page      int
+	// Accepted is true if the first button of a modal form was clicked.
+	Accepted bool
+	// Values are the values of all elements of a custom form, in order of the elements.
+	// Labels have a nil value, inputs a string, toggles a bool, sliders a float64,
+	// and step sliders and dropdowns the int index of the selected option.
+	Values []interface{}
+}
+
+// GetString returns the string value of the element at the given index of a custom form.
+func (response *Response) GetString(index int) string {
+	if index >= len(response.Values) {
+		return ""
+	}
+	var value, _ = response.Values[index].(string)
+	return value
+}
+
+// GetBool returns the bool value of the element at the given index of a custom form.
+func (response *Response) GetBool(index int) bool {
+	if index >= len(response.Values) {
+		return false
+	}
+	var value, _ = response.Values[index].(bool)
+	return value
+}
+
+// GetFloat returns the float value of the element at the given index of a custom form.
+func (response *Response) GetFloat(index int) float64 {
+	if index >= len(response.Values) {
+		return 0
+	}
+	var value, _ = response.Values[index].(float64)
+	return value
+}
+
+// GetInt returns the int value of the element at the given index of a custom form.
+func (response *Response) GetInt(index int) int {
+	if index >= len(response.Values) {
+		return 0
+	}
+	var value, _ = response.Values[index].(int)
+	return value
+}
+
+// isClosed checks if the raw response data indicates the form was closed.
+func isClosed(data string) bool {
+	var trimmed = strings.TrimSpace(data)
+	return trimmed == "" || trimmed == "null"
+}
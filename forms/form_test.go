@@ -0,0 +1,106 @@
+package forms
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestCustomForm(t *testing.T) {
+	form := NewCustomForm("Settings")
+	form.AddElement(NewLabel("Change your settings below."))
+	form.AddElement(NewInput("Nickname", "Steve", ""))
+	form.AddElement(NewToggle("Notifications", true))
+	form.AddElement(NewDropdown("Language", []string{"English", "Dutch"}, 0))
+
+	data, err := json.Marshal(form)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded map[string]interface{}
+	json.Unmarshal(data, &decoded)
+	if decoded["type"] != TypeCustom || len(decoded["content"].([]interface{})) != 4 {
+		t.Error("unexpected form JSON:", string(data))
+	}
+
+	response, err := form.ParseResponse(`[null,"Alex",false,1]`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if response.GetString(1) != "Alex" || response.GetBool(2) || response.GetInt(3) != 1 {
+		t.Error("unexpected response values:", response.Values)
+	}
+	if _, err := form.ParseResponse(`[null,"Alex",false,5]`); err == nil {
+		t.Error("out of range dropdown index was accepted")
+	}
+	if response, _ := form.ParseResponse("null\n"); !response.Closed {
+		t.Error("closed form was not detected")
+	}
+}
+
+func TestSimpleForm(t *testing.T) {
+	form := NewSimpleForm("Menu", "Pick one")
+	form.AddButton("Play")
+	form.AddImageButton("Shop", ImagePath, "textures/items/emerald")
+
+	response, err := form.ParseResponse("1")
+	if err != nil || response.Button != 1 {
+		t.Error("unexpected button response:", response, err)
+	}
+	if _, err := form.ParseResponse("2"); err == nil {
+		t.Error("out of range button was accepted")
+	}
+}
+
+func TestPaginatedMenu(t *testing.T) {
+	menu := NewPaginatedMenu("Warps", "Pick a warp")
+	menu.ItemsPerPage = 2
+	for _, name := range []string{"Spawn", "Shop", "Arena", "Farm", "Nether"} {
+		menu.AddItem(name)
+	}
+	if menu.GetPageCount() != 3 {
+		t.Fatalf("expected 3 pages, got %v", menu.GetPageCount())
+	}
+
+	first := menu.GetPage(0)
+	if first.Title != "Warps (1/3)" || len(first.Buttons) != 3 || first.Buttons[2].Text != menu.NextText {
+		t.Error("unexpected first page:", first.Title, first.Buttons)
+	}
+	if response, _ := first.ParseResponse("1"); response.Navigated || response.Button != 1 {
+		t.Error("expected second item to be clicked, got", response)
+	}
+	if response, _ := first.ParseResponse("2"); !response.Navigated || response.Page != 1 {
+		t.Error("expected navigation to the second page, got", response)
+	}
+
+	second := menu.GetPage(1)
+	if len(second.Buttons) != 4 || second.Buttons[0].Text != menu.PreviousText {
+		t.Error("unexpected second page:", second.Buttons)
+	}
+	if response, _ := second.ParseResponse("0"); !response.Navigated || response.Page != 0 {
+		t.Error("expected navigation to the first page, got", response)
+	}
+	if response, _ := second.ParseResponse("2"); response.Navigated || response.Button != 3 {
+		t.Error("expected fourth item to be clicked, got", response)
+	}
+
+	last := menu.GetPage(10)
+	if last.Page != 2 || len(last.Buttons) != 2 || last.Buttons[1].Text != "Nether" {
+		t.Error("unexpected last page:", last.Page, last.Buttons)
+	}
+	if response, _ := last.ParseResponse("1"); response.Button != 4 {
+		t.Error("expected last item to be clicked, got", response)
+	}
+	if _, err := last.ParseResponse("2"); err == nil {
+		t.Error("out of range button was accepted")
+	}
+
+	data, err := json.Marshal(last)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded map[string]interface{}
+	json.Unmarshal(data, &decoded)
+	if decoded["type"] != TypeSimple || decoded["title"] != "Warps (3/3)" {
+		t.Error("unexpected page JSON:", string(data))
+	}
+}
@@ -0,0 +1,55 @@
+package forms
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// ModalForm is a form with exactly two buttons, usually yes and no.
+// The response of a modal form is whether the first button was clicked.
+type ModalForm struct {
+	Title   string
+	Content string
+	Button1 string
+	Button2 string
+}
+
+// NewModalForm returns a new modal form with the given title, content and button texts.
+func NewModalForm(title string, content string, button1 string, button2 string) *ModalForm {
+	return &ModalForm{title, content, button1, button2}
+}
+
+// GetType returns the type of the form.
+func (form *ModalForm) GetType() string {
+	return TypeModal
+}
+
+// GetTitle returns the title of the form.
+func (form *ModalForm) GetTitle() string {
+	return form.Title
+}
+
+// MarshalJSON returns the JSON data of the form.
+func (form *ModalForm) MarshalJSON() ([]byte, error) {
+	return json.Marshal(map[string]interface{}{
+		"type":    TypeModal,
+		"title":   form.Title,
+		"content": form.Content,
+		"button1": form.Button1,
+		"button2": form.Button2,
+	})
+}
+
+// ParseResponse parses the response of the client, which is true if the first button was clicked.
+func (form *ModalForm) ParseResponse(data string) (*Response, error) {
+	if isClosed(data) {
+		return &Response{Closed: true}, nil
+	}
+	switch strings.TrimSpace(data) {
+	case "true":
+		return &Response{Accepted: true}, nil
+	case "false":
+		return &Response{Accepted: false}, nil
+	}
+	return nil, InvalidResponse
+}
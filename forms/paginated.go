@@ -0,0 +1,127 @@
+package forms
+
+import (
+	"fmt"
+)
+
+// DefaultItemsPerPage is the default maximum amount of items on every page of a paginated menu.
+const DefaultItemsPerPage = 8
+
+// PaginatedMenu is a menu of items split over pages, each page being sent as simple form.
+// Long simple forms are hard to scroll through with a controller, so paginated menus
+// show a limited amount of items per page, with buttons to go to the previous and next page.
+type PaginatedMenu struct {
+	Title   string
+	Content string
+	Items   []Button
+	// ItemsPerPage is the maximum amount of items on every page.
+	ItemsPerPage int
+	// PreviousText is the text of the button opening the previous page,
+	// which is the first button of every page but the first.
+	PreviousText string
+	// NextText is the text of the button opening the next page,
+	// which is the last button of every page but the last.
+	NextText string
+}
+
+// NewPaginatedMenu returns a new paginated menu with the given title and content.
+func NewPaginatedMenu(title string, content string) *PaginatedMenu {
+	return &PaginatedMenu{title, content, []Button{}, DefaultItemsPerPage, "< Previous Page", "Next Page >"}
+}
+
+// AddItem adds an item with the given text to the menu.
+func (menu *PaginatedMenu) AddItem(text string) {
+	menu.Items = append(menu.Items, Button{Text: text})
+}
+
+// AddImageItem adds an item with the given text and image to the menu.
+// The image type should be either ImageURL or ImagePath.
+func (menu *PaginatedMenu) AddImageItem(text string, imageType string, data string) {
+	menu.Items = append(menu.Items, Button{Text: text, Image: &Image{imageType, data}})
+}
+
+// getItemsPerPage returns the amount of items per page, which is at least 1.
+func (menu *PaginatedMenu) getItemsPerPage() int {
+	if menu.ItemsPerPage < 1 {
+		return 1
+	}
+	return menu.ItemsPerPage
+}
+
+// GetPageCount returns the amount of pages of the menu.
+// Menus without items have one empty page.
+func (menu *PaginatedMenu) GetPageCount() int {
+	var perPage = menu.getItemsPerPage()
+	if len(menu.Items) == 0 {
+		return 1
+	}
+	return (len(menu.Items) + perPage - 1) / perPage
+}
+
+// GetPage returns the form of the page with the given index, starting at 0.
+// The page index is clamped to the pages of the menu.
+func (menu *PaginatedMenu) GetPage(page int) *MenuPage {
+	var count = menu.GetPageCount()
+	if page >= count {
+		page = count - 1
+	}
+	if page < 0 {
+		page = 0
+	}
+	var perPage = menu.getItemsPerPage()
+	var first = page * perPage
+	var last = first + perPage
+	if last > len(menu.Items) {
+		last = len(menu.Items)
+	}
+
+	var form = &MenuPage{SimpleForm: NewSimpleForm(menu.Title, menu.Content), Page: page, FirstItem: first}
+	if count > 1 {
+		form.Title = fmt.Sprintf("%v (%v/%v)", menu.Title, page+1, count)
+	}
+	if page > 0 {
+		form.hasPrevious = true
+		form.AddButton(menu.PreviousText)
+	}
+	form.Buttons = append(form.Buttons, menu.Items[first:last]...)
+	if page < count-1 {
+		form.hasNext = true
+		form.AddButton(menu.NextText)
+	}
+	return form
+}
+
+// MenuPage is the simple form of a single page of a paginated menu.
+// The response of a menu page is either the index of the clicked item in the menu,
+// or the page to open if a button to go to another page was clicked.
+type MenuPage struct {
+	*SimpleForm
+	// Page is the index of the page in the menu.
+	Page int
+	// FirstItem is the index of the first item of the page in the menu.
+	FirstItem int
+
+	hasPrevious bool
+	hasNext     bool
+}
+
+// ParseResponse parses the response of the client to the page.
+// The button of the response is the index of the clicked item in the menu,
+// unless the player navigated to another page.
+func (form *MenuPage) ParseResponse(data string) (*Response, error) {
+	var response, err = form.SimpleForm.ParseResponse(data)
+	if err != nil || response.Closed {
+		return response, err
+	}
+	var button = response.Button
+	if form.hasPrevious {
+		if button == 0 {
+			return &Response{Navigated: true, Page: form.Page - 1}, nil
+		}
+		button--
+	}
+	if form.hasNext && response.Button == len(form.Buttons)-1 {
+		return &Response{Navigated: true, Page: form.Page + 1}, nil
+	}
+	return &Response{Button: form.FirstItem + button}, nil
+}
@@ -0,0 +1,82 @@
+package forms
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+)
+
+const (
+	ImageURL  = "url"
+	ImagePath = "path"
+)
+
+// Button is a button of a simple form.
+// Buttons may have an image, either loaded from
+// an URL or from a path in a resource pack.
+type Button struct {
+	Text  string `json:"text"`
+	Image *Image `json:"image,omitempty"`
+}
+
+// Image is the image of a button.
+type Image struct {
+	Type string `json:"type"`
+	Data string `json:"data"`
+}
+
+// SimpleForm is a form with a list of buttons.
+// The response of a simple form is the index of the clicked button.
+type SimpleForm struct {
+	Title   string
+	Content string
+	Buttons []Button
+}
+
+// NewSimpleForm returns a new simple form with the given title and content.
+func NewSimpleForm(title string, content string) *SimpleForm {
+	return &SimpleForm{title, content, []Button{}}
+}
+
+// AddButton adds a button with the given text to the form.
+func (form *SimpleForm) AddButton(text string) {
+	form.Buttons = append(form.Buttons, Button{Text: text})
+}
+
+// AddImageButton adds a button with the given text and image to the form.
+// The image type should be either ImageURL or ImagePath.
+func (form *SimpleForm) AddImageButton(text string, imageType string, data string) {
+	form.Buttons = append(form.Buttons, Button{Text: text, Image: &Image{imageType, data}})
+}
+
+// GetType returns the type of the form.
+func (form *SimpleForm) GetType() string {
+	return TypeSimple
+}
+
+// GetTitle returns the title of the form.
+func (form *SimpleForm) GetTitle() string {
+	return form.Title
+}
+
+// MarshalJSON returns the JSON data of the form.
+func (form *SimpleForm) MarshalJSON() ([]byte, error) {
+	return json.Marshal(map[string]interface{}{
+		"type":    TypeSimple,
+		"title":   form.Title,
+		"content": form.Content,
+		"buttons": form.Buttons,
+	})
+}
+
+// ParseResponse parses the response of the client, which is the index of the clicked button.
+func (form *SimpleForm) ParseResponse(data string) (*Response, error) {
+	if isClosed(data) {
+		return &Response{Closed: true}, nil
+	}
+	var button, err = strconv.Atoi(strings.TrimSpace(data))
+	if err != nil || button < 0 || button >= len(form.Buttons) {
+		return nil, InvalidResponse
+	}
+	return &Response{Button: button}, nil
+}
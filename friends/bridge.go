@@ -0,0 +1,12 @@
+package friends
+
+// Bridge is used to propagate the presence of players
+// between multiple servers, for example over Redis pub/sub.
+// Without a bridge, only players on this server are seen as online.
+type Bridge interface {
+	// PublishPresence publishes that the player with the given XUID and name went online or offline.
+	PublishPresence(xuid string, name string, online bool)
+	// SetPresenceFunction sets the function called when presence
+	// of a player on another server was received.
+	SetPresenceFunction(func(xuid string, name string, online bool))
+}
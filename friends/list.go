@@ -0,0 +1,50 @@
+package friends
+
+// List is the friend list of a single player.
+// Friends and pending requests are stored as XUID => name maps,
+// where the name is the last known name of the player.
+type List struct {
+	XUID     string            `yaml:"XUID"`
+	Name     string            `yaml:"Name"`
+	Friends  map[string]string `yaml:"Friends"`
+	Requests map[string]string `yaml:"Requests"`
+}
+
+// NewList returns a new empty friend list for the player with the given XUID and name.
+func NewList(xuid string, name string) *List {
+	return &List{xuid, name, make(map[string]string), make(map[string]string)}
+}
+
+// HasFriend checks if the player with the given XUID is a friend.
+func (list *List) HasFriend(xuid string) bool {
+	var _, ok = list.Friends[xuid]
+	return ok
+}
+
+// HasRequest checks if the player with the given XUID sent a friend request.
+func (list *List) HasRequest(xuid string) bool {
+	var _, ok = list.Requests[xuid]
+	return ok
+}
+
+// GetFriendXUID returns the XUID of the friend with the given name.
+// A bool is returned indicating if a friend with the name was found.
+func (list *List) GetFriendXUID(name string) (string, bool) {
+	return findName(list.Friends, name)
+}
+
+// GetRequestXUID returns the XUID of the player with the given name that sent a friend request.
+// A bool is returned indicating if a request of the name was found.
+func (list *List) GetRequestXUID(name string) (string, bool) {
+	return findName(list.Requests, name)
+}
+
+// findName finds the XUID of a name in the given XUID => name map.
+func findName(names map[string]string, name string) (string, bool) {
+	for xuid, n := range names {
+		if n == name {
+			return xuid, true
+		}
+	}
+	return "", false
+}
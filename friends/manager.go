@@ -0,0 +1,299 @@
+package friends
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/irmine/gomine/net"
+	"github.com/irmine/gomine/text"
+)
+
+var (
+	// NoXUID gets returned when a player without XUID,
+	// for example when not logged into XBOX Live, uses friends.
+	NoXUID = errors.New("player has no XUID")
+	// SelfRequest gets returned when a player tries to befriend themselves.
+	SelfRequest = errors.New("player can not befriend themselves")
+	// AlreadyFriends gets returned when two players are already friends.
+	AlreadyFriends = errors.New("players are already friends")
+	// AlreadyRequested gets returned when a friend request has already been sent.
+	AlreadyRequested = errors.New("friend request has already been sent")
+	// NoRequest gets returned when no friend request of a player could be found.
+	NoRequest = errors.New("no friend request of player")
+	// NotFriends gets returned when two players are not friends.
+	NotFriends = errors.New("players are not friends")
+)
+
+// Manager manages the friend lists of all players.
+// Friend lists of online players are kept loaded,
+// and get saved to the storage every time they change.
+type Manager struct {
+	mutex   sync.RWMutex
+	storage Storage
+	bridge  Bridge
+	lists   map[string]*List
+	online  map[string]*net.MinecraftSession
+	remote  map[string]string
+}
+
+// NewManager returns a new friend manager loading and saving friend lists using the given storage.
+func NewManager(storage Storage) *Manager {
+	return &Manager{storage: storage, lists: make(map[string]*List), online: make(map[string]*net.MinecraftSession), remote: make(map[string]string)}
+}
+
+// SetBridge sets the bridge used to propagate presence between servers.
+func (manager *Manager) SetBridge(bridge Bridge) {
+	manager.mutex.Lock()
+	manager.bridge = bridge
+	manager.mutex.Unlock()
+	bridge.SetPresenceFunction(manager.handleRemotePresence)
+}
+
+// GetStorage returns the storage used to load and save friend lists.
+func (manager *Manager) GetStorage() Storage {
+	return manager.storage
+}
+
+// Load loads the friend list of a session that joined the server,
+// and notifies all online friends.
+// Internal. Not to be used by plugins.
+func (manager *Manager) Load(session *net.MinecraftSession) error {
+	if session.GetXUID() == "" {
+		return NoXUID
+	}
+	var list, err = manager.storage.Load(session.GetXUID())
+	if err != nil {
+		return err
+	}
+	manager.mutex.Lock()
+	list.Name = session.GetName()
+	manager.lists[session.GetXUID()] = list
+	manager.online[session.GetXUID()] = session
+	manager.mutex.Unlock()
+
+	manager.notifyPresence(session.GetXUID(), session.GetDisplayName(), true)
+	if len(list.Requests) > 0 {
+		session.SendMessage(text.Yellow+"You have", len(list.Requests), "pending friend request(s). Use /friend requests to view them.")
+	}
+	return manager.storage.Save(list)
+}
+
+// Unload saves and unloads the friend list of a session that left the server,
+// and notifies all online friends.
+// Internal. Not to be used by plugins.
+func (manager *Manager) Unload(session *net.MinecraftSession) error {
+	manager.mutex.Lock()
+	var list, ok = manager.lists[session.GetXUID()]
+	delete(manager.lists, session.GetXUID())
+	delete(manager.online, session.GetXUID())
+	manager.mutex.Unlock()
+	if !ok {
+		return nil
+	}
+	manager.notifyPresence(session.GetXUID(), session.GetDisplayName(), false)
+	return manager.storage.Save(list)
+}
+
+// GetList returns the friend list of the player with the given XUID.
+// The list gets loaded from the storage if the player is not online.
+func (manager *Manager) GetList(xuid string) (*List, error) {
+	manager.mutex.RLock()
+	var list, ok = manager.lists[xuid]
+	manager.mutex.RUnlock()
+	if ok {
+		return list, nil
+	}
+	return manager.storage.Load(xuid)
+}
+
+// GetFriends returns an XUID => name map of all friends of the player with the given XUID.
+func (manager *Manager) GetFriends(xuid string) (map[string]string, error) {
+	var list, err = manager.GetList(xuid)
+	if err != nil {
+		return nil, err
+	}
+	manager.mutex.RLock()
+	defer manager.mutex.RUnlock()
+	var friends = make(map[string]string, len(list.Friends))
+	for friend, name := range list.Friends {
+		friends[friend] = name
+	}
+	return friends, nil
+}
+
+// AreFriends checks if the players with the given XUIDs are friends.
+func (manager *Manager) AreFriends(xuid string, otherXUID string) bool {
+	var list, err = manager.GetList(xuid)
+	if err != nil {
+		return false
+	}
+	manager.mutex.RLock()
+	defer manager.mutex.RUnlock()
+	return list.HasFriend(otherXUID)
+}
+
+// IsOnline checks if the player with the given XUID is online,
+// either on this server or on another server connected through the bridge.
+func (manager *Manager) IsOnline(xuid string) bool {
+	manager.mutex.RLock()
+	defer manager.mutex.RUnlock()
+	var _, local = manager.online[xuid]
+	var _, remote = manager.remote[xuid]
+	return local || remote
+}
+
+// SendRequest sends a friend request from the sender to the target.
+// If the target already sent a request to the sender, the request gets accepted instead.
+func (manager *Manager) SendRequest(sender *net.MinecraftSession, target *net.MinecraftSession) error {
+	if sender.GetXUID() == "" || target.GetXUID() == "" {
+		return NoXUID
+	}
+	if sender.GetXUID() == target.GetXUID() {
+		return SelfRequest
+	}
+	var senderList, err = manager.GetList(sender.GetXUID())
+	if err != nil {
+		return err
+	}
+	manager.mutex.RLock()
+	var friends, requested = senderList.HasFriend(target.GetXUID()), senderList.HasRequest(target.GetXUID())
+	manager.mutex.RUnlock()
+	if friends {
+		return AlreadyFriends
+	}
+	if requested {
+		return manager.Accept(sender, target.GetName())
+	}
+
+	targetList, err := manager.GetList(target.GetXUID())
+	if err != nil {
+		return err
+	}
+	manager.mutex.Lock()
+	if targetList.HasRequest(sender.GetXUID()) {
+		manager.mutex.Unlock()
+		return AlreadyRequested
+	}
+	targetList.Requests[sender.GetXUID()] = sender.GetName()
+	manager.mutex.Unlock()
+
+	target.SendMessage(text.Yellow + sender.GetDisplayName() + " sent you a friend request. Use /friend accept " + sender.GetName() + " to accept it.")
+	return manager.storage.Save(targetList)
+}
+
+// Accept accepts a friend request of the player with the given name.
+func (manager *Manager) Accept(session *net.MinecraftSession, name string) error {
+	var list, err = manager.GetList(session.GetXUID())
+	if err != nil {
+		return err
+	}
+	manager.mutex.RLock()
+	var xuid, ok = list.GetRequestXUID(name)
+	manager.mutex.RUnlock()
+	if !ok {
+		return NoRequest
+	}
+	otherList, err := manager.GetList(xuid)
+	if err != nil {
+		return err
+	}
+	manager.mutex.Lock()
+	delete(list.Requests, xuid)
+	delete(otherList.Requests, session.GetXUID())
+	list.Friends[xuid] = name
+	otherList.Friends[session.GetXUID()] = session.GetName()
+	var other, online = manager.online[xuid]
+	manager.mutex.Unlock()
+
+	if online {
+		other.SendMessage(text.BrightGreen + session.GetDisplayName() + " accepted your friend request.")
+	}
+	if err := manager.storage.Save(list); err != nil {
+		return err
+	}
+	return manager.storage.Save(otherList)
+}
+
+// Deny denies a friend request of the player with the given name.
+func (manager *Manager) Deny(session *net.MinecraftSession, name string) error {
+	var list, err = manager.GetList(session.GetXUID())
+	if err != nil {
+		return err
+	}
+	manager.mutex.Lock()
+	var xuid, ok = list.GetRequestXUID(name)
+	delete(list.Requests, xuid)
+	manager.mutex.Unlock()
+	if !ok {
+		return NoRequest
+	}
+	return manager.storage.Save(list)
+}
+
+// Remove removes the friend with the given name from the friend list of the session,
+// and the session from the friend list of the friend.
+func (manager *Manager) Remove(session *net.MinecraftSession, name string) error {
+	var list, err = manager.GetList(session.GetXUID())
+	if err != nil {
+		return err
+	}
+	manager.mutex.RLock()
+	var xuid, ok = list.GetFriendXUID(name)
+	manager.mutex.RUnlock()
+	if !ok {
+		return NotFriends
+	}
+	otherList, err := manager.GetList(xuid)
+	if err != nil {
+		return err
+	}
+	manager.mutex.Lock()
+	delete(list.Friends, xuid)
+	delete(otherList.Friends, session.GetXUID())
+	manager.mutex.Unlock()
+
+	if err := manager.storage.Save(list); err != nil {
+		return err
+	}
+	return manager.storage.Save(otherList)
+}
+
+// notifyPresence notifies all online friends of the player with the given XUID,
+// and publishes the presence over the bridge if one is set.
+func (manager *Manager) notifyPresence(xuid string, name string, online bool) {
+	manager.mutex.RLock()
+	var bridge = manager.bridge
+	manager.mutex.RUnlock()
+	if bridge != nil {
+		bridge.PublishPresence(xuid, name, online)
+	}
+	manager.sendPresence(xuid, name, online)
+}
+
+// sendPresence sends a presence message to all friends on this server
+// of the player with the given XUID.
+func (manager *Manager) sendPresence(xuid string, name string, online bool) {
+	var message = text.Yellow + "Your friend " + name + " went offline."
+	if online {
+		message = text.BrightGreen + "Your friend " + name + " is now online."
+	}
+	manager.mutex.RLock()
+	defer manager.mutex.RUnlock()
+	for friend, session := range manager.online {
+		if manager.lists[friend].HasFriend(xuid) {
+			session.SendMessage(message)
+		}
+	}
+}
+
+// handleRemotePresence handles presence of a player on another server.
+func (manager *Manager) handleRemotePresence(xuid string, name string, online bool) {
+	manager.mutex.Lock()
+	if online {
+		manager.remote[xuid] = name
+	} else {
+		delete(manager.remote, xuid)
+	}
+	manager.mutex.Unlock()
+	manager.sendPresence(xuid, name, online)
+}
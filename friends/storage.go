@@ -0,0 +1,63 @@
+package friends
+
+import (
+	"io/ioutil"
+	"os"
+
+	"gopkg.in/yaml.v2"
+)
+
+// Storage is used to load and save friend lists.
+// Storages can be swapped out in order to share
+// friend lists between multiple servers, for example using a database.
+type Storage interface {
+	// Load loads the friend list of the player with the given XUID.
+	// A new empty list gets returned if the player has no stored list.
+	Load(xuid string) (*List, error)
+	// Save saves the given friend list.
+	Save(list *List) error
+}
+
+// FileStorage is a storage saving every friend list
+// in a separate YAML file, named after the XUID of the player.
+type FileStorage struct {
+	path string
+}
+
+// NewFileStorage returns a new file storage saving in the given directory.
+// The directory gets created if it does not yet exist.
+func NewFileStorage(path string) *FileStorage {
+	os.MkdirAll(path, 0700)
+	return &FileStorage{path}
+}
+
+// Load loads the friend list of the player with the given XUID.
+func (storage *FileStorage) Load(xuid string) (*List, error) {
+	var file, err = ioutil.ReadFile(storage.path + xuid + ".yml")
+	if os.IsNotExist(err) {
+		return NewList(xuid, ""), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var list = NewList(xuid, "")
+	if err := yaml.Unmarshal(file, list); err != nil {
+		return nil, err
+	}
+	if list.Friends == nil {
+		list.Friends = make(map[string]string)
+	}
+	if list.Requests == nil {
+		list.Requests = make(map[string]string)
+	}
+	return list, nil
+}
+
+// Save saves the given friend list to its file.
+func (storage *FileStorage) Save(list *List) error {
+	var data, err = yaml.Marshal(list)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(storage.path+list.XUID+".yml", data, 0644)
+}
@@ -0,0 +1,32 @@
+package friends
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+)
+
+func TestFileStorage(t *testing.T) {
+	dir, err := ioutil.TempDir("", "friends")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	storage := NewFileStorage(dir + "/")
+	list := NewList("1234", "Steve")
+	list.Friends["5678"] = "Alex"
+	if err := storage.Save(list); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := storage.Load("1234")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if loaded.Name != "Steve" || !loaded.HasFriend("5678") {
+		t.Error("unexpected loaded list:", loaded)
+	}
+	if id, ok := loaded.GetFriendXUID("Alex"); !ok || id != "5678" {
+		t.Error("friend Alex could not be found by name")
+	}
+}
@@ -0,0 +1,223 @@
+package gs4
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"math/rand"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	TypeHandshake byte = 0x09
+	TypeStat      byte = 0x00
+)
+
+// Header is the header every query packet starts with.
+var Header = []byte{0xfe, 0xfd}
+
+// InvalidPacket gets returned if a packet is not a valid query packet.
+var InvalidPacket = errors.New("invalid query packet")
+
+// InvalidToken gets returned if a stat request was sent with a challenge token
+// that is not the current or previous token.
+var InvalidToken = errors.New("invalid challenge token")
+
+// Status is the status of the server that is reported in stat responses.
+type Status struct {
+	Motd           string
+	GameType       string
+	GameId         string
+	Version        string
+	ServerEngine   string
+	WorldName      string
+	ListPlugins    bool
+	PluginNames    []string
+	PlayerNames    []string
+	OnlinePlayers  int
+	MaximumPlayers int
+	Whitelist      string
+	Address        string
+	Port           uint16
+}
+
+// Server answers GS4 query requests, which are used by server list sites and tools
+// to retrieve the status of the server.
+// Stat requests must contain a challenge token obtained by a handshake.
+// Challenge tokens are regenerated every token interval, and the previous token stays valid
+// until the next regeneration, so clients that just did a handshake are not rejected.
+type Server struct {
+	// TokenInterval is the interval on which challenge tokens are regenerated.
+	TokenInterval time.Duration
+
+	mutex         sync.RWMutex
+	status        Status
+	token         int32
+	previousToken int32
+	tokenTime     time.Time
+	conn          *net.UDPConn
+}
+
+// NewServer returns a new query server regenerating challenge tokens every 30 seconds.
+func NewServer() *Server {
+	return &Server{TokenInterval: time.Second * 30, token: rand.Int31(), previousToken: rand.Int31(), tokenTime: time.Now()}
+}
+
+// SetStatus sets the status reported in stat responses.
+func (server *Server) SetStatus(status Status) {
+	server.mutex.Lock()
+	server.status = status
+	server.mutex.Unlock()
+}
+
+// GetStatus returns the status reported in stat responses.
+func (server *Server) GetStatus() Status {
+	server.mutex.RLock()
+	defer server.mutex.RUnlock()
+	return server.status
+}
+
+// Handle handles a query packet received at the given time and returns the response to it.
+// Stat requests padded with 4 bytes are answered with a full stat response,
+// other stat requests with a basic stat response.
+func (server *Server) Handle(packet []byte, now time.Time) ([]byte, error) {
+	if len(packet) < 7 || !bytes.Equal(packet[:2], Header) {
+		return nil, InvalidPacket
+	}
+	var sessionId = packet[3:7]
+
+	server.mutex.Lock()
+	if now.Sub(server.tokenTime) >= server.TokenInterval {
+		server.previousToken = server.token
+		server.token = rand.Int31()
+		server.tokenTime = now
+	}
+	var token, previousToken, status = server.token, server.previousToken, server.status
+	server.mutex.Unlock()
+
+	switch packet[2] {
+	case TypeHandshake:
+		var response = append([]byte{TypeHandshake}, sessionId...)
+		response = append(response, strconv.Itoa(int(token))...)
+		return append(response, 0), nil
+	case TypeStat:
+		if len(packet) < 11 {
+			return nil, InvalidPacket
+		}
+		var requestToken = int32(binary.BigEndian.Uint32(packet[7:11]))
+		if requestToken != token && requestToken != previousToken {
+			return nil, InvalidToken
+		}
+		if len(packet) >= 15 {
+			return EncodeFullStat(sessionId, status), nil
+		}
+		return EncodeBasicStat(sessionId, status), nil
+	}
+	return nil, InvalidPacket
+}
+
+// Listen starts answering query packets sent to the given UDP address.
+// Packets are handled on a separate goroutine until the server is closed.
+func (server *Server) Listen(address string) error {
+	var udpAddress, err = net.ResolveUDPAddr("udp", address)
+	if err != nil {
+		return err
+	}
+	conn, err := net.ListenUDP("udp", udpAddress)
+	if err != nil {
+		return err
+	}
+	server.mutex.Lock()
+	server.conn = conn
+	server.mutex.Unlock()
+
+	go func() {
+		var buffer = make([]byte, 1500)
+		for {
+			var n, addr, err = conn.ReadFromUDP(buffer)
+			if err != nil {
+				if err, ok := err.(net.Error); ok && err.Temporary() {
+					continue
+				}
+				return
+			}
+			if response, err := server.Handle(buffer[:n], time.Now()); err == nil {
+				conn.WriteToUDP(response, addr)
+			}
+		}
+	}()
+	return nil
+}
+
+// Close stops answering query packets, if the server was listening.
+func (server *Server) Close() error {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+	if server.conn == nil {
+		return nil
+	}
+	var err = server.conn.Close()
+	server.conn = nil
+	return err
+}
+
+// EncodeBasicStat returns a basic stat response with the given session ID.
+func EncodeBasicStat(sessionId []byte, status Status) []byte {
+	var buffer = bytes.NewBuffer([]byte{TypeStat})
+	buffer.Write(sessionId)
+	for _, value := range []string{status.Motd, status.GameType, status.WorldName, strconv.Itoa(status.OnlinePlayers), strconv.Itoa(status.MaximumPlayers)} {
+		writeString(buffer, value)
+	}
+	binary.Write(buffer, binary.LittleEndian, status.Port)
+	writeString(buffer, status.Address)
+	return buffer.Bytes()
+}
+
+// EncodeFullStat returns a full stat response with the given session ID,
+// which contains key value pairs of the status and the names of all players.
+func EncodeFullStat(sessionId []byte, status Status) []byte {
+	var buffer = bytes.NewBuffer([]byte{TypeStat})
+	buffer.Write(sessionId)
+	buffer.WriteString("splitnum\x00\x80\x00")
+
+	var plugins string
+	if status.ListPlugins {
+		plugins = status.ServerEngine
+		if len(status.PluginNames) != 0 {
+			plugins += ": " + strings.Join(status.PluginNames, "; ")
+		}
+	}
+	for _, pair := range [][2]string{
+		{"hostname", status.Motd},
+		{"gametype", status.GameType},
+		{"game_id", status.GameId},
+		{"version", status.Version},
+		{"server_engine", status.ServerEngine},
+		{"plugins", plugins},
+		{"map", status.WorldName},
+		{"numplayers", strconv.Itoa(status.OnlinePlayers)},
+		{"maxplayers", strconv.Itoa(status.MaximumPlayers)},
+		{"whitelist", status.Whitelist},
+		{"hostip", status.Address},
+		{"hostport", strconv.Itoa(int(status.Port))},
+	} {
+		writeString(buffer, pair[0])
+		writeString(buffer, pair[1])
+	}
+	buffer.WriteString("\x00\x01player_\x00\x00")
+	for _, name := range status.PlayerNames {
+		writeString(buffer, name)
+	}
+	buffer.WriteByte(0)
+	return buffer.Bytes()
+}
+
+// writeString writes a null terminated string to the buffer.
+func writeString(buffer *bytes.Buffer, value string) {
+	buffer.WriteString(value)
+	buffer.WriteByte(0)
+}
@@ -0,0 +1,49 @@
+package gs4
+
+import (
+	"bytes"
+	"encoding/binary"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestQuery(t *testing.T) {
+	var server = NewServer()
+	server.SetStatus(Status{Motd: "GoMine", GameType: "SMP", WorldName: "world", PlayerNames: []string{"Steve"}, OnlinePlayers: 1, MaximumPlayers: 20, Address: "0.0.0.0", Port: 19132})
+	var sessionId = []byte{1, 2, 3, 4}
+	var now = time.Now()
+
+	if _, err := server.Handle([]byte{0xfe, 0xfd, TypeHandshake}, now); err != InvalidPacket {
+		t.Error("short packet was not rejected:", err)
+	}
+	var response, err = server.Handle(append([]byte{0xfe, 0xfd, TypeHandshake}, sessionId...), now)
+	if err != nil || response[0] != TypeHandshake || !bytes.Equal(response[1:5], sessionId) {
+		t.Fatal("invalid handshake response:", response, err)
+	}
+	token, err := strconv.Atoi(string(response[5 : len(response)-1]))
+	if err != nil {
+		t.Fatal("challenge token is not a number:", err)
+	}
+	var stat = append([]byte{0xfe, 0xfd, TypeStat}, sessionId...)
+	stat = append(stat, 0, 0, 0, 0)
+	binary.BigEndian.PutUint32(stat[7:], uint32(token))
+
+	response, err = server.Handle(stat, now)
+	var basic = "\x00\x01\x02\x03\x04GoMine\x00SMP\x00world\x001\x0020\x00\xbc\x4a0.0.0.0\x00"
+	if err != nil || string(response) != basic {
+		t.Errorf("invalid basic stat response: %q %v", response, err)
+	}
+	response, err = server.Handle(append(stat, 0, 0, 0, 0), now)
+	if err != nil || !bytes.Contains(response, []byte("hostname\x00GoMine\x00")) || !bytes.HasSuffix(response, []byte("\x01player_\x00\x00Steve\x00\x00")) {
+		t.Errorf("invalid full stat response: %q %v", response, err)
+	}
+
+	// The previous token stays valid for one more interval.
+	if _, err = server.Handle(stat, now.Add(server.TokenInterval)); err != nil {
+		t.Error("previous token was rejected:", err)
+	}
+	if _, err = server.Handle(stat, now.Add(server.TokenInterval*2)); err != InvalidToken {
+		t.Error("expired token was not rejected:", err)
+	}
+}
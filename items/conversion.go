@@ -11,16 +11,43 @@ import (
 // an ID + item data combination to item type.
 // The keys of these maps are created using the
 // getKey method.
-var IdToType = map[string]Type{
-	GetKey(0, 0): DefaultManager.stringIds["minecraft:air"],
-	GetKey(1, 0): DefaultManager.stringIds["minecraft:stone"],
-}
+var IdToType = map[string]Type{}
 
 // TypeToId is a map used to convert
 // a block state to an ID + data combination.
-var TypeToId = map[string]string{
-	fmt.Sprint(DefaultManager.stringIds["minecraft:air"]):   GetKey(0, 0),
-	fmt.Sprint(DefaultManager.stringIds["minecraft:stone"]): GetKey(1, 0),
+var TypeToId = map[string]string{}
+
+// RegisterConversion registers the ID + data combination
+// of an item type, used to convert the type from and to network.
+// Types without registered conversion can not be sent over network.
+func RegisterConversion(id int16, data int16, t Type) {
+	IdToType[GetKey(id, data)] = t
+	TypeToId[fmt.Sprint(t)] = GetKey(id, data)
+}
+
+// registerDefaultConversions registers the conversions
+// of all default items of the default item manager.
+func registerDefaultConversions() {
+	RegisterConversion(0, 0, DefaultManager.stringIds["minecraft:air"])
+	RegisterConversion(1, 0, DefaultManager.stringIds["minecraft:stone"])
+	RegisterConversion(331, 0, DefaultManager.stringIds["minecraft:redstone"])
+	RegisterConversion(339, 0, DefaultManager.stringIds["minecraft:paper"])
+	RegisterConversion(388, 0, DefaultManager.stringIds["minecraft:emerald"])
+	RegisterConversion(450, 0, DefaultManager.stringIds["minecraft:totem"])
+}
+
+// GetNetworkId returns the ID + data combination of the type of the stack.
+// A bool is returned indicating if the stack was not nil and its type had a registered conversion.
+func GetNetworkId(stack *Stack) (int16, int16, bool) {
+	if stack == nil {
+		return 0, 0, false
+	}
+	key, ok := TypeToId[fmt.Sprint(stack.Type)]
+	if !ok {
+		return 0, 0, false
+	}
+	id, data := FromKey(key)
+	return id, data, true
 }
 
 // getKey returns the key of an ID + data combination,
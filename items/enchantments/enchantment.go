@@ -8,6 +8,12 @@ type Type struct {
 	id       int16
 }
 
+// NewType returns a new enchantment type
+// with the given string ID and enchantment ID.
+func NewType(stringId string, id int16) Type {
+	return Type{stringId, id}
+}
+
 // GetStringId returns the string ID of a type.
 // This string ID may be used to identify
 // enchantments by user output.
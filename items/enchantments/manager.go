@@ -33,5 +33,43 @@ func NewManager() *Manager {
 // This function should be called whenever a new manager
 // is made, in order to have all default enchantments registered.
 func (manager *Manager) RegisterDefaults() {
+	for id, stringId := range []string{
+		"minecraft:protection", "minecraft:fire_protection", "minecraft:feather_falling", "minecraft:blast_protection",
+		"minecraft:projectile_protection", "minecraft:thorns", "minecraft:respiration", "minecraft:depth_strider",
+		"minecraft:aqua_affinity", "minecraft:sharpness", "minecraft:smite", "minecraft:bane_of_arthropods",
+		"minecraft:knockback", "minecraft:fire_aspect", "minecraft:looting", "minecraft:efficiency",
+		"minecraft:silk_touch", "minecraft:unbreaking", "minecraft:fortune", "minecraft:power",
+		"minecraft:punch", "minecraft:flame", "minecraft:infinity", "minecraft:luck_of_the_sea",
+		"minecraft:lure", "minecraft:frost_walker", "minecraft:mending",
+	} {
+		manager.Register(NewType(stringId, int16(id)))
+	}
+}
+
+// Register registers an enchantment type,
+// overwriting any type registered with the same IDs.
+func (manager *Manager) Register(t Type) {
+	manager.stringIds[t.stringId] = t
+	manager.byteIds[byte(t.id)] = t
+}
+
+// IsRegistered checks if an enchantment type
+// with the given string ID is registered.
+func (manager *Manager) IsRegistered(stringId string) bool {
+	var _, ok = manager.stringIds[stringId]
+	return ok
+}
+
+// GetByStringId returns the enchantment type with the given string ID.
+// A bool is returned indicating if the type was registered.
+func (manager *Manager) GetByStringId(stringId string) (Type, bool) {
+	var t, ok = manager.stringIds[stringId]
+	return t, ok
+}
 
+// GetById returns the enchantment type with the given ID.
+// A bool is returned indicating if the type was registered.
+func (manager *Manager) GetById(id byte) (Type, bool) {
+	var t, ok = manager.byteIds[id]
+	return t, ok
 }
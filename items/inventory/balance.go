@@ -0,0 +1,77 @@
+package inventory
+
+import (
+	"github.com/irmine/gomine/items"
+)
+
+// Balance is the net count of every kind of item stack moved by the actions of a transaction.
+// Stacks are of the same kind only if they are exactly equal apart from their count,
+// so that swapping the enchantments, lore or durability of a stack does not balance out.
+// Transactions that neither create nor destroy items have a zero balance.
+type Balance struct {
+	entries []balanceEntry
+}
+
+// balanceEntry is the net count of a kind of stack.
+type balanceEntry struct {
+	stack *items.Stack
+	count int
+}
+
+// Add adds the count of the stack to the balance of its kind.
+// Nil and air stacks are ignored.
+func (balance *Balance) Add(stack *items.Stack) {
+	balance.add(stack, 1)
+}
+
+// Remove subtracts the count of the stack from the balance of its kind.
+// Nil and air stacks are ignored.
+func (balance *Balance) Remove(stack *items.Stack) {
+	balance.add(stack, -1)
+}
+
+// Change adds the change of a slot holding the old stack to holding the new stack.
+func (balance *Balance) Change(old *items.Stack, new *items.Stack) {
+	balance.Remove(old)
+	balance.Add(new)
+}
+
+// IsZero checks if the counts of all kinds of stacks balance out.
+func (balance *Balance) IsZero() bool {
+	for _, entry := range balance.entries {
+		if entry.count != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// add adds the count of the stack multiplied by the sign to the balance of its kind.
+func (balance *Balance) add(stack *items.Stack, sign int) {
+	if IsAir(stack) {
+		return
+	}
+	for i, entry := range balance.entries {
+		var kind = *stack
+		kind.Count = entry.stack.Count
+		if kind.EqualsExact(entry.stack) {
+			balance.entries[i].count += stack.Count * sign
+			return
+		}
+	}
+	balance.entries = append(balance.entries, balanceEntry{stack, stack.Count * sign})
+}
+
+// IsAir checks if the stack is nil or an air stack, which both stand for an empty slot.
+func IsAir(stack *items.Stack) bool {
+	return stack == nil || stack.Count == 0 || stack.GetId() == "minecraft:air"
+}
+
+// EqualStacks checks if two stacks are exactly equal,
+// treating nil stacks and air stacks as equal.
+func EqualStacks(stack *items.Stack, stack2 *items.Stack) bool {
+	if IsAir(stack) || IsAir(stack2) {
+		return IsAir(stack) && IsAir(stack2)
+	}
+	return stack.EqualsExact(stack2)
+}
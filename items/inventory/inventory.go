@@ -3,6 +3,7 @@ package inventory
 import (
 	"errors"
 	"github.com/irmine/gomine/items"
+	"sort"
 	"strings"
 )
 
@@ -37,6 +38,12 @@ func NewInventory(size int) *Inventory {
 	return &Inventory{make([]*items.Stack, size)}
 }
 
+// GetSize returns the amount of slots of the inventory.
+// The size of an inventory never changes.
+func (inventory *Inventory) GetSize() int {
+	return len(inventory.items)
+}
+
 // IsEmpty checks if a slot in the inventory is empty.
 // True gets returned if no item was in the slot.
 // True is also returned when the slot exceeds the
@@ -115,6 +122,38 @@ func (inventory *Inventory) AddItem(item *items.Stack) error {
 	return FullInventory
 }
 
+// AddItemReturningLeftovers adds a copy of an item to the inventory,
+// stacking it onto existing stacks first like AddItem.
+// The given item is not modified. The items that did not fit
+// in the inventory are returned as a new stack,
+// or nil if the complete item was added.
+func (inventory *Inventory) AddItemReturningLeftovers(item *items.Stack) *items.Stack {
+	c := *item
+	if inventory.AddItem(&c) == nil {
+		return nil
+	}
+	return &c
+}
+
+// CanAddItems checks if all given items fit in the inventory,
+// without modifying the inventory or the given items.
+func (inventory *Inventory) CanAddItems(stacks ...*items.Stack) bool {
+	simulated := NewInventory(len(inventory.items))
+	for slot, item := range inventory.items {
+		if item != nil {
+			c := *item
+			simulated.items[slot] = &c
+		}
+	}
+	for _, stack := range stacks {
+		c := *stack
+		if simulated.AddItem(&c) != nil {
+			return false
+		}
+	}
+	return true
+}
+
 // RemoveItem removes an item from an inventory.
 // A given item gets searched in the inventory,
 // removing every equal stack until the count
@@ -203,6 +242,97 @@ func (inventory *Inventory) Contains(searched *items.Stack) bool {
 	return false
 }
 
+// Compact merges stacks of equal items in the inventory into as few stacks as possible,
+// respecting the maximum stack size of the items.
+// Items are moved into the first stack of their kind, leaving empty slots behind.
+func (inventory *Inventory) Compact() {
+	inventory.CompactSlots(0, len(inventory.items))
+}
+
+// CompactSlots merges stacks of equal items in the slots from the first slot
+// up to, but not including the last slot, leaving all other slots untouched.
+// The slots are limited to the size of the inventory.
+func (inventory *Inventory) CompactSlots(from, to int) {
+	from, to = inventory.clampSlots(from, to)
+	for slot := from; slot < to; slot++ {
+		item := inventory.items[slot]
+		if item == nil {
+			continue
+		}
+		for target := from; target < slot && item.Count > 0; target++ {
+			if inventory.items[target] != nil {
+				item.StackOn(inventory.items[target])
+			}
+		}
+		if item.Count <= 0 {
+			inventory.items[slot] = nil
+		}
+	}
+}
+
+// Sort compacts the inventory and sorts its items using the less function,
+// moving all empty slots to the end of the inventory.
+// Items are sorted with ByType if the less function is nil.
+func (inventory *Inventory) Sort(less func(a, b *items.Stack) bool) {
+	inventory.SortSlots(0, len(inventory.items), less)
+}
+
+// SortSlots compacts and sorts the items in the slots from the first slot
+// up to, but not including the last slot, leaving all other slots untouched.
+// This may be used to sort the inventory of a player without changing its hotbar.
+func (inventory *Inventory) SortSlots(from, to int, less func(a, b *items.Stack) bool) {
+	if less == nil {
+		less = ByType
+	}
+	from, to = inventory.clampSlots(from, to)
+	inventory.CompactSlots(from, to)
+	var stacks []*items.Stack
+	for _, item := range inventory.items[from:to] {
+		if item != nil {
+			stacks = append(stacks, item)
+		}
+	}
+	sort.SliceStable(stacks, func(i, j int) bool {
+		return less(stacks[i], stacks[j])
+	})
+	for slot := from; slot < to; slot++ {
+		inventory.items[slot] = nil
+		if slot-from < len(stacks) {
+			inventory.items[slot] = stacks[slot-from]
+		}
+	}
+}
+
+// clampSlots limits the slot range to the size of the inventory.
+func (inventory *Inventory) clampSlots(from, to int) (int, int) {
+	if from < 0 {
+		from = 0
+	}
+	if to > len(inventory.items) {
+		to = len(inventory.items)
+	}
+	if to < from {
+		to = from
+	}
+	return from, to
+}
+
+// ByType orders item stacks by their ID, display name and durability,
+// with larger stacks of the same item before smaller ones.
+// It may be passed to Sort to sort an inventory.
+func ByType(a, b *items.Stack) bool {
+	if a.GetId() != b.GetId() {
+		return a.GetId() < b.GetId()
+	}
+	if a.GetDisplayName() != b.GetDisplayName() {
+		return a.GetDisplayName() < b.GetDisplayName()
+	}
+	if a.Durability != b.Durability {
+		return a.Durability > b.Durability
+	}
+	return a.Count > b.Count
+}
+
 // String returns a string representation of an inventory.
 // String implements the fmt.Stringer interface.
 func (inventory *Inventory) String() string {
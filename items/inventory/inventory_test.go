@@ -47,3 +47,115 @@ func Test(t *testing.T) {
 
 	fmt.Println(inv)
 }
+
+func TestAddItemReturningLeftovers(t *testing.T) {
+	manager := items.NewManager()
+	manager.Register(items.NewType("minecraft:emerald"), true)
+
+	inv := NewInventory(2)
+	item, _ := manager.Get("minecraft:emerald", 100)
+	if leftover := inv.AddItemReturningLeftovers(item); leftover != nil {
+		t.Fatal("expected all emeralds to fit, got leftover", leftover)
+	}
+	if item.Count != 100 {
+		t.Error("expected the added item to not be modified, got count", item.Count)
+	}
+	first, _ := inv.GetItem(0)
+	second, _ := inv.GetItem(1)
+	if first.Count != 64 || second.Count != 36 {
+		t.Error("expected stacks of 64 and 36 emeralds, got", first.Count, second.Count)
+	}
+
+	item, _ = manager.Get("minecraft:emerald", 40)
+	leftover := inv.AddItemReturningLeftovers(item)
+	if leftover == nil || leftover.Count != 12 {
+		t.Fatal("expected 12 emeralds to be left over, got", leftover)
+	}
+}
+
+func TestSort(t *testing.T) {
+	manager := items.NewManager()
+	manager.Register(items.NewType("minecraft:emerald"), true)
+	manager.Register(items.NewType("minecraft:apple"), true)
+	manager.Register(items.NewBreakable("minecraft:diamond_sword"), true)
+
+	inv := NewInventory(8)
+	emerald, _ := manager.Get("minecraft:emerald", 40)
+	inv.SetItem(emerald, 1)
+	apple, _ := manager.Get("minecraft:apple", 10)
+	inv.SetItem(apple, 3)
+	emerald, _ = manager.Get("minecraft:emerald", 40)
+	inv.SetItem(emerald, 5)
+	sword, _ := manager.Get("minecraft:diamond_sword", 1)
+	inv.SetItem(sword, 6)
+	sword, _ = manager.Get("minecraft:diamond_sword", 1)
+	sword.Durability = 20
+	inv.SetItem(sword, 7)
+
+	inv.Compact()
+	if stack, _ := inv.GetItem(1); stack.Count != 64 {
+		t.Error("expected the first emerald stack to be filled, got", stack.Count)
+	}
+	if stack, _ := inv.GetItem(5); stack.Count != 16 {
+		t.Error("expected 16 emeralds to be left in the second stack, got", stack.Count)
+	}
+	if inv.IsEmpty(6) || inv.IsEmpty(7) {
+		t.Error("expected swords with different durability to not be merged")
+	}
+
+	inv.Sort(nil)
+	var expected = []struct {
+		id    string
+		count int
+	}{{"minecraft:apple", 10}, {"minecraft:diamond_sword", 1}, {"minecraft:diamond_sword", 1}, {"minecraft:emerald", 64}, {"minecraft:emerald", 16}}
+	for slot, e := range expected {
+		stack, err := inv.GetItem(slot)
+		if err != nil || stack.GetId() != e.id || stack.Count != e.count {
+			t.Fatalf("expected x%v %v in slot %v, got %v", e.count, e.id, slot, inv.GetAll())
+		}
+	}
+	if stack, _ := inv.GetItem(1); stack.Durability != 20 {
+		t.Error("expected the sword with the most durability first")
+	}
+	for slot := len(expected); slot < inv.GetSize(); slot++ {
+		if !inv.IsEmpty(slot) {
+			t.Error("expected empty slots at the end of the inventory")
+		}
+	}
+
+	inv.SortSlots(1, 3, func(a, b *items.Stack) bool { return a.Durability < b.Durability })
+	if stack, _ := inv.GetItem(1); stack.Durability != 0 {
+		t.Error("expected slots to be sorted with the given order")
+	}
+	if stack, _ := inv.GetItem(0); stack.GetId() != "minecraft:apple" {
+		t.Error("expected slots outside of the range to be untouched")
+	}
+}
+
+func TestBalance(t *testing.T) {
+	manager := items.NewManager()
+	manager.Register(items.NewType("minecraft:emerald"), true)
+	manager.Register(items.NewBreakable("minecraft:diamond_sword"), true)
+
+	var balance Balance
+	emerald, _ := manager.Get("minecraft:emerald", 16)
+	split, _ := manager.Get("minecraft:emerald", 8)
+	balance.Change(emerald, split)
+	balance.Change(nil, split)
+	if !balance.IsZero() {
+		t.Error("expected splitting a stack to balance out")
+	}
+	balance.Add(split)
+	if balance.IsZero() {
+		t.Error("expected creating emeralds to not balance out")
+	}
+
+	balance = Balance{}
+	sword, _ := manager.Get("minecraft:diamond_sword", 1)
+	repaired, _ := manager.Get("minecraft:diamond_sword", 1)
+	sword.Durability = 20
+	balance.Change(sword, repaired)
+	if balance.IsZero() {
+		t.Error("expected repairing a sword to not balance out")
+	}
+}
@@ -8,7 +8,23 @@ import (
 const (
 	ContainerSource = iota + 0
 	WorldSource = 2
-	//CreativeSource = 3
+	CreativeSource = 3
+	CraftingGridSource = 100
+	TodoSource = 99999
+)
+
+// Window IDs of actions with the todo source used for crafting.
+const (
+	CraftingAddIngredientWindow = -2
+	CraftingRemoveIngredientWindow = -3
+	CraftingResultWindow = -4
+	CraftingUseIngredientWindow = -5
+)
+
+// Source flags of actions with the world source.
+const (
+	// DropFlag is set for actions of items dropped into the world.
+	DropFlag = 0
 )
 
 type InventoryActionIO struct {
@@ -28,7 +44,7 @@ func (IO *InventoryActionIO) WriteToBuffer(bs *packets.MinecraftStream) {
 	bs.PutUnsignedVarInt(IO.Source)
 
 	switch IO.Source {
-	case ContainerSource:
+	case ContainerSource, CraftingGridSource, TodoSource:
 		bs.PutVarInt(IO.WindowId)
 		break
 	case WorldSource:
@@ -45,7 +61,7 @@ func (IO *InventoryActionIO) ReadFromBuffer(bs *packets.MinecraftStream) Invento
 	IO.Source = bs.GetUnsignedVarInt()
 
 	switch IO.Source {
-	case ContainerSource:
+	case ContainerSource, CraftingGridSource, TodoSource:
 		IO.WindowId = bs.GetVarInt()
 		break
 	case WorldSource:
@@ -1,8 +1,11 @@
 package items
 
 import (
+	"errors"
 	"fmt"
 	"testing"
+
+	"github.com/irmine/gomine/items/enchantments"
 )
 
 func Test(t *testing.T) {
@@ -17,3 +20,66 @@ func Test(t *testing.T) {
 
 	fmt.Println(emerald.name, emerald.Count)
 }
+
+func TestSerialization(t *testing.T) {
+	DefaultManager.Register(NewType("minecraft:emerald"), true)
+	emerald, _ := DefaultManager.Get("minecraft:emerald", 12)
+	emerald.DisplayName = "Shiny"
+	emerald.Lore = []string{"Found in a cave", "Very rare"}
+	efficiency, _ := enchantments.DefaultManager.GetById(enchantments.Efficiency)
+	emerald.AddEnchantment(enchantments.Instance{Type: efficiency, Level: 3})
+	if emerald.GetEnchantmentLevel(enchantments.Efficiency) != 3 {
+		t.Error("enchantment was not applied:", emerald.GetEnchantments())
+	}
+
+	data, err := EncodeJSON(emerald)
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoded, err := DecodeJSON(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !decoded.EqualsExact(emerald) {
+		t.Error("JSON stack was not decoded back:", string(data), decoded)
+	}
+	if _, err := DecodeJSON([]byte(`{"version":99,"id":"minecraft:emerald","count":1}`)); !errors.Is(err, UnsupportedVersion) {
+		t.Error("expected unsupported version, got", err)
+	}
+	if _, err := DecodeJSON([]byte(`{"id":"minecraft:unknown","count":1}`)); !errors.Is(err, UnknownItem) {
+		t.Error("expected unknown item, got", err)
+	}
+
+	emerald.cachedNBT.SetString("Owner", "Steve")
+	data, err = EncodeJSON(emerald)
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoded, err = DecodeJSON(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if decoded.cachedNBT.GetString("Owner", "") != "Steve" {
+		t.Error("custom NBT was not decoded back from JSON:", string(data))
+	}
+	if _, err := DecodeJSON([]byte(`{"id":"minecraft:emerald","count":1,"tags":"not base64!"}`)); !errors.Is(err, InvalidItemData) {
+		t.Error("expected invalid item data, got", err)
+	}
+	encoded, err := EncodeBase64(emerald)
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoded, err = DecodeBase64(encoded)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !decoded.EqualsExact(emerald) {
+		t.Error("base64 stack was not decoded back:", decoded)
+	}
+	if decoded.GetEnchantmentLevel(enchantments.Efficiency) != 3 || decoded.cachedNBT.GetString("Owner", "") != "Steve" {
+		t.Error("enchantments and custom NBT were not decoded back:", decoded.GetEnchantments(), decoded.cachedNBT)
+	}
+	if _, err := DecodeBase64("not base64!"); !errors.Is(err, InvalidItemData) {
+		t.Error("expected invalid item data, got", err)
+	}
+}
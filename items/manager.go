@@ -25,6 +25,7 @@ var DefaultManager = NewManager()
 // of the default item manager.
 func init() {
 	DefaultManager.RegisterDefaults()
+	registerDefaultConversions()
 }
 
 // NewManager returns a new item registry.
@@ -137,4 +138,8 @@ func (registry *Manager) GetTypes() map[string]Type {
 func (registry *Manager) RegisterDefaults() {
 	registry.Register(NewType("minecraft:air"), false)
 	registry.Register(NewType("minecraft:stone"), true)
+	registry.Register(NewType("minecraft:redstone"), true)
+	registry.Register(NewType("minecraft:paper"), true)
+	registry.Register(NewType("minecraft:emerald"), true)
+	registry.Register(NewType("minecraft:totem"), true)
 }
@@ -1,6 +1,11 @@
 package items
 
 const (
+	StackName   = "Name"
+	StackCount  = "Count"
+	StackDamage = "Damage"
+	StackTag    = "tag"
+
 	Display     = "display"
 	DisplayName = "Name"
 	DisplayLore = "Lore"
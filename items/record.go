@@ -0,0 +1,86 @@
+package items
+
+import (
+	"fmt"
+
+	"github.com/irmine/gomine/items/enchantments"
+	"github.com/irmine/gonbt"
+)
+
+// Record is a serializable representation of an item stack,
+// used to store item stacks in configuration and data files.
+// Records without version were written before records were versioned,
+// and are read as records of version 1.
+type Record struct {
+	Version     int      `yaml:"Version,omitempty" json:"version,omitempty"`
+	Id          string   `yaml:"Id" json:"id"`
+	Count       int      `yaml:"Count" json:"count"`
+	Durability  int16    `yaml:"Durability,omitempty" json:"durability,omitempty"`
+	DisplayName string   `yaml:"Display Name,omitempty" json:"displayName,omitempty"`
+	Lore        []string `yaml:"Lore,omitempty" json:"lore,omitempty"`
+	// Enchantments are the levels of the enchantments of the stack, indexed by their string IDs.
+	Enchantments map[string]byte `yaml:"Enchantments,omitempty" json:"enchantments,omitempty"`
+	// Tags are the custom NBT tags of the stack, such as tags set by plugins,
+	// as little endian NBT compound encoded in base64.
+	Tags string `yaml:"Tags,omitempty" json:"tags,omitempty"`
+}
+
+// NewRecord returns a new record of the given stack, with the current record version.
+func NewRecord(stack *Stack) Record {
+	var record = Record{RecordVersion, stack.GetId(), stack.Count, stack.Durability, stack.DisplayName, stack.Lore, nil, ""}
+	if tags := customTags(stack.cachedNBT); len(tags) > 0 {
+		record.Tags = encodeCompound(gonbt.NewCompound("", tags))
+	}
+	for _, instance := range stack.GetEnchantments() {
+		if record.Enchantments == nil {
+			record.Enchantments = make(map[string]byte)
+		}
+		record.Enchantments[instance.GetStringId()] = instance.Level
+	}
+	return record
+}
+
+// ToStack converts the record back to an item stack.
+// Enchantments that are not registered and custom tags that can not be decoded are left out.
+// A bool is returned indicating if the item type of the record was registered.
+func (record Record) ToStack() (*Stack, bool) {
+	var stack, ok = DefaultManager.Get(record.Id, record.Count)
+	if !ok {
+		return nil, false
+	}
+	stack.Durability = record.Durability
+	if record.DisplayName != "" {
+		stack.DisplayName = record.DisplayName
+	}
+	stack.Lore = record.Lore
+	for stringId, level := range record.Enchantments {
+		if t, ok := enchantments.DefaultManager.GetByStringId(stringId); ok {
+			stack.AddEnchantment(enchantments.Instance{Type: t, Level: level})
+		}
+	}
+	if record.Tags != "" {
+		if tags, err := decodeCompound(record.Tags); err == nil {
+			stack.cachedNBT = tags
+		}
+	}
+	return stack, true
+}
+
+// Decode converts the record back to an item stack.
+// UnsupportedVersion gets returned if the record was written by a newer version,
+// UnknownItem if the item type of the record is not registered, and InvalidItemData if its custom tags can not be decoded.
+func (record Record) Decode() (*Stack, error) {
+	if record.Version > RecordVersion {
+		return nil, fmt.Errorf("%w: %v", UnsupportedVersion, record.Version)
+	}
+	if record.Tags != "" {
+		if _, err := decodeCompound(record.Tags); err != nil {
+			return nil, err
+		}
+	}
+	var stack, ok = record.ToStack()
+	if !ok {
+		return nil, fmt.Errorf("%w: %v", UnknownItem, record.Id)
+	}
+	return stack, nil
+}
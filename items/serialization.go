@@ -0,0 +1,167 @@
+package items
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/irmine/binutils"
+	"github.com/irmine/gonbt"
+)
+
+// RecordVersion is the current version of serialized item stacks.
+// It gets increased whenever the serialized form changes,
+// so that stacks written by older versions can still be converted.
+// Version 2 added the custom NBT tags to records.
+const RecordVersion = 2
+
+// VersionTag is the name of the tag holding the record version in NBT serialized stacks.
+const VersionTag = "GoMineVersion"
+
+var (
+	// UnknownItem gets returned when decoding a stack of which the item type is not registered.
+	UnknownItem = errors.New("unknown item type")
+	// UnsupportedVersion gets returned when decoding a stack written by a newer version.
+	UnsupportedVersion = errors.New("unsupported item serialization version")
+	// InvalidItemData gets returned when decoding data that does not hold a serialized stack.
+	InvalidItemData = errors.New("invalid item data")
+)
+
+// EncodeJSON returns the JSON representation of the record of the stack,
+// which can be stored in databases and read by external tools.
+// Custom NBT tags of the stack are kept in the record as base64 encoded NBT, like EncodeBase64 keeps them.
+func EncodeJSON(stack *Stack) ([]byte, error) {
+	return json.Marshal(NewRecord(stack))
+}
+
+// DecodeJSON returns the stack of which the record is held by the JSON data.
+// UnknownItem or UnsupportedVersion are returned if the stack could not be converted.
+func DecodeJSON(data []byte) (*Stack, error) {
+	var record Record
+	if err := json.Unmarshal(data, &record); err != nil {
+		return nil, fmt.Errorf("%w: %v", InvalidItemData, err)
+	}
+	return record.Decode()
+}
+
+// EncodeBase64 returns the stack as little endian NBT compound encoded in base64,
+// in the layout Bedrock Edition stores items in, with the record version added.
+// Unlike records, the compound keeps the custom NBT tags of the stack along with its enchantments.
+// Stacks are stored with a byte count, so counts above 255 can not be stored.
+func EncodeBase64(stack *Stack) (string, error) {
+	var record = NewRecord(stack)
+	if record.Count < 0 || record.Count > 255 {
+		return "", fmt.Errorf("%w: count %v out of range", InvalidItemData, record.Count)
+	}
+	var compound = gonbt.NewCompound("", make(map[string]gonbt.INamedTag))
+	compound.SetInt(VersionTag, int32(record.Version))
+	compound.SetString(StackName, record.Id)
+	compound.SetByte(StackCount, byte(record.Count))
+	compound.SetShort(StackDamage, record.Durability)
+	if tags := customTags(stack.cachedNBT); len(tags) > 0 {
+		compound.SetCompound(StackTag, tags)
+	}
+	if record.DisplayName != "" || len(record.Lore) > 0 {
+		if !compound.HasTagWithType(StackTag, gonbt.TAG_Compound) {
+			compound.SetCompound(StackTag, make(map[string]gonbt.INamedTag))
+		}
+		compound.GetCompound(StackTag).SetCompound(Display, make(map[string]gonbt.INamedTag))
+		var display = compound.GetCompound(StackTag).GetCompound(Display)
+		if record.DisplayName != "" {
+			display.SetString(DisplayName, record.DisplayName)
+		}
+		var lore []gonbt.INamedTag
+		for _, line := range record.Lore {
+			lore = append(lore, gonbt.NewString("", line))
+		}
+		display.SetList(DisplayLore, gonbt.TAG_String, lore)
+	}
+	if len(record.Enchantments) > 0 {
+		if !compound.HasTagWithType(StackTag, gonbt.TAG_Compound) {
+			compound.SetCompound(StackTag, make(map[string]gonbt.INamedTag))
+		}
+		compound.GetCompound(StackTag).SetList(Ench, gonbt.TAG_Compound, emitEnchantments(stack))
+	}
+
+	return encodeCompound(compound), nil
+}
+
+// DecodeBase64 returns the stack held by the base64 encoded NBT compound.
+// Compounds without record version are read as written by Bedrock Edition.
+// UnknownItem or UnsupportedVersion are returned if the stack could not be converted.
+func DecodeBase64(data string) (*Stack, error) {
+	var compound, err = decodeCompound(data)
+	if err != nil {
+		return nil, err
+	}
+	var record = Record{
+		Version:    int(compound.GetInt(VersionTag, 0)),
+		Id:         compound.GetString(StackName, ""),
+		Count:      int(compound.GetByte(StackCount, 0)),
+		Durability: compound.GetShort(StackDamage, 0),
+	}
+	if compound.HasTagWithType(StackTag, gonbt.TAG_Compound) && compound.GetCompound(StackTag).HasTagWithType(Display, gonbt.TAG_Compound) {
+		var display = compound.GetCompound(StackTag).GetCompound(Display)
+		record.DisplayName = display.GetString(DisplayName, "")
+		for _, tag := range display.GetList(DisplayLore, gonbt.TAG_String).GetTags() {
+			record.Lore = append(record.Lore, tag.Interface().(string))
+		}
+	}
+	stack, err := record.Decode()
+	if err != nil {
+		return nil, err
+	}
+	if compound.HasTagWithType(StackTag, gonbt.TAG_Compound) {
+		var tag = compound.GetCompound(StackTag)
+		if tag.HasTagWithType(Ench, gonbt.TAG_List) {
+			parseEnchantments(tag.GetList(Ench, gonbt.TAG_Compound).GetTags(), stack)
+		}
+		stack.cachedNBT = gonbt.NewCompound("", customTags(tag))
+	}
+	return stack, nil
+}
+
+// encodeCompound returns the NBT compound as little endian NBT encoded in base64.
+func encodeCompound(compound *gonbt.Compound) string {
+	var writer = gonbt.NewWriter(false, binutils.LittleEndian)
+	writer.WriteUncompressedCompound(compound)
+	return base64.StdEncoding.EncodeToString(writer.GetBuffer())
+}
+
+// decodeCompound returns the NBT compound encoded in base64 by encodeCompound.
+// InvalidItemData is returned if the data does not hold an NBT compound.
+func decodeCompound(data string) (compound *gonbt.Compound, err error) {
+	var raw, decodeErr = base64.StdEncoding.DecodeString(data)
+	if decodeErr != nil {
+		return nil, fmt.Errorf("%w: %v", InvalidItemData, decodeErr)
+	}
+	if len(raw) == 0 {
+		return nil, InvalidItemData
+	}
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			compound, err = nil, fmt.Errorf("%w: %v", InvalidItemData, recovered)
+		}
+	}()
+	if compound = gonbt.NewReader(raw, false, binutils.LittleEndian).ReadUncompressedIntoCompound(); compound == nil {
+		return nil, InvalidItemData
+	}
+	return compound, nil
+}
+
+// customTags returns the tags of the NBT compound of a stack other than its display and enchantment tags,
+// such as tags set by plugins, so that they are kept when serializing the stack.
+// The display and enchantment tags are always written from the fields of the stack instead.
+func customTags(compound *gonbt.Compound) map[string]gonbt.INamedTag {
+	var tags = make(map[string]gonbt.INamedTag)
+	if compound == nil {
+		return tags
+	}
+	for name, tag := range compound.GetTags() {
+		if name != Display && name != Ench {
+			tags[name] = tag
+		}
+	}
+	return tags
+}
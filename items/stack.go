@@ -62,13 +62,14 @@ func (stack Stack) String() string {
 }
 
 // CanStackWith checks if two stacks can stack with each other.
+// Stacks only stack if their type, display name, enchantments and lore, and the durability of breakable items, are equal.
 // A bool is returned which indicates if the two can stack,
 // and an integer is returned which specifies the count of
 // of the item that can still be stacked on this stack.
 // The returned integer may be 0, if the stack is already
 // at the max stack size.
 func (stack Stack) CanStackOn(stack2 *Stack) (bool, int) {
-	if !stack.Type.Equals(stack2.Type) || stack.DisplayName != stack2.DisplayName || !stack.EqualsEnchantments(stack2) || !stack.EqualsLore(stack2) {
+	if !stack.Type.Equals(stack2.Type) || (stack.IsBreakable() && stack.Durability != stack2.Durability) || stack.DisplayName != stack2.DisplayName || !stack.EqualsEnchantments(stack2) || !stack.EqualsLore(stack2) {
 		return false, 0
 	}
 	count := stack2.maxStackSize - stack2.Count
@@ -76,6 +77,9 @@ func (stack Stack) CanStackOn(stack2 *Stack) (bool, int) {
 	if countLeft < count {
 		count = countLeft
 	}
+	if count < 0 {
+		count = 0
+	}
 	return true, count
 }
 
@@ -138,3 +142,37 @@ func (stack Stack) EqualsEnchantments(stack2 *Stack) bool {
 	}
 	return true
 }
+
+// AddEnchantment applies the enchantment instance on the stack,
+// replacing any enchantment of the same type applied before.
+func (stack *Stack) AddEnchantment(instance enchantments.Instance) {
+	if stack.enchantments == nil {
+		stack.enchantments = make(map[string]enchantments.Instance)
+	}
+	stack.enchantments[instance.GetStringId()] = instance
+}
+
+// RemoveEnchantment removes the enchantment with the given string ID from the stack.
+func (stack *Stack) RemoveEnchantment(stringId string) {
+	delete(stack.enchantments, stringId)
+}
+
+// GetEnchantments returns all enchantment instances applied on the stack.
+func (stack Stack) GetEnchantments() []enchantments.Instance {
+	var instances = make([]enchantments.Instance, 0, len(stack.enchantments))
+	for _, instance := range stack.enchantments {
+		instances = append(instances, instance)
+	}
+	return instances
+}
+
+// GetEnchantmentLevel returns the level of the enchantment with the given ID applied on the stack,
+// such as enchantments.Efficiency, or 0 if the stack does not have the enchantment.
+func (stack Stack) GetEnchantmentLevel(id byte) byte {
+	for _, instance := range stack.enchantments {
+		if instance.GetId() == int16(id) {
+			return instance.Level
+		}
+	}
+	return 0
+}
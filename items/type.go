@@ -2,6 +2,8 @@ package items
 
 import (
 	"fmt"
+	"github.com/irmine/gomine/items/enchantments"
+	"github.com/irmine/gomine/tags"
 	"github.com/irmine/gonbt"
 	"strings"
 )
@@ -60,6 +62,7 @@ func NewType(stringId string) Type {
 func NewBreakable(stringId string) Type {
 	t := NewType(stringId)
 	t.breakable = true
+	t.maxStackSize = 1
 	return t
 }
 
@@ -113,6 +116,13 @@ func (t Type) Equals(t2 Type) bool {
 	return t.stringId == t2.stringId
 }
 
+// HasTag checks if the item type is part of the item tag with the given name,
+// such as minecraft:logs, in the default tag registry.
+// Item tags are unrelated to the NBT tags of item stacks.
+func (t Type) HasTag(tag string) bool {
+	return tags.DefaultRegistry.Has(tags.Items, tag, t.stringId)
+}
+
 // ParseNBT implements default behaviour for parsing NBT.
 // This is the default function passed in for `NBTParseFunction`.
 // The cached NBT gets set when parsing NBT.
@@ -123,6 +133,9 @@ func ParseNBT(compound *gonbt.Compound, stack *Stack) {
 			stack.Lore = append(stack.Lore, tag.Interface().(string))
 		}
 	}
+	if compound.HasTagWithType(Ench, gonbt.TAG_List) {
+		parseEnchantments(compound.GetList(Ench, gonbt.TAG_Compound).GetTags(), stack)
+	}
 	stack.cachedNBT = compound
 }
 
@@ -140,4 +153,33 @@ func EmitNBT(compound *gonbt.Compound, stack *Stack) {
 		}
 		compound.GetCompound(Display).SetList(DisplayLore, gonbt.TAG_String, list)
 	}
+	if len(stack.enchantments) > 0 {
+		compound.SetList(Ench, gonbt.TAG_Compound, emitEnchantments(stack))
+	}
+}
+
+// parseEnchantments applies the enchantments of the NBT enchantment list on the stack.
+// Enchantments with an unregistered ID are ignored.
+func parseEnchantments(list []gonbt.INamedTag, stack *Stack) {
+	for _, tag := range list {
+		var enchantment, ok = tag.(*gonbt.Compound)
+		if !ok {
+			continue
+		}
+		if t, ok := enchantments.DefaultManager.GetById(byte(enchantment.GetShort(EnchId, -1))); ok {
+			stack.AddEnchantment(enchantments.Instance{Type: t, Level: byte(enchantment.GetShort(EnchLevel, 1))})
+		}
+	}
+}
+
+// emitEnchantments returns the NBT enchantment list of the enchantments of the stack.
+func emitEnchantments(stack *Stack) []gonbt.INamedTag {
+	var list []gonbt.INamedTag
+	for _, instance := range stack.GetEnchantments() {
+		list = append(list, gonbt.NewCompound("", map[string]gonbt.INamedTag{
+			EnchId:    gonbt.NewShort(EnchId, instance.GetId()),
+			EnchLevel: gonbt.NewShort(EnchLevel, int16(instance.Level)),
+		}))
+	}
+	return list
 }
@@ -0,0 +1,50 @@
+package kits
+
+import (
+	"time"
+
+	"github.com/irmine/gomine/items"
+)
+
+// Kit is a named set of items players can claim.
+// Kits can require a permission and have a cooldown
+// between claims of the same player.
+type Kit struct {
+	name       string
+	permission string
+	cooldown   time.Duration
+	items      []*items.Stack
+}
+
+// NewKit returns a new kit with the given name, permission, cooldown and items.
+// An empty permission means every player can claim the kit,
+// and a cooldown of 0 means the kit can be claimed at any time.
+func NewKit(name string, permission string, cooldown time.Duration, stacks []*items.Stack) *Kit {
+	return &Kit{name, permission, cooldown, stacks}
+}
+
+// GetName returns the name of the kit.
+func (kit *Kit) GetName() string {
+	return kit.name
+}
+
+// GetPermission returns the permission required to claim the kit.
+func (kit *Kit) GetPermission() string {
+	return kit.permission
+}
+
+// GetCooldown returns the cooldown between claims of the kit.
+func (kit *Kit) GetCooldown() time.Duration {
+	return kit.cooldown
+}
+
+// GetItems returns copies of all items of the kit,
+// which can be modified without modifying the kit.
+func (kit *Kit) GetItems() []*items.Stack {
+	var stacks = make([]*items.Stack, len(kit.items))
+	for i, stack := range kit.items {
+		var c = *stack
+		stacks[i] = &c
+	}
+	return stacks
+}
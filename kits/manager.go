@@ -0,0 +1,214 @@
+package kits
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/irmine/gomine/items"
+	"github.com/irmine/gomine/items/inventory"
+	"github.com/irmine/gomine/net"
+	"github.com/irmine/gomine/players"
+	"github.com/irmine/gomine/text"
+	"gopkg.in/yaml.v2"
+)
+
+var (
+	// UnknownKit gets returned when a kit
+	// with a given name could not be found.
+	UnknownKit = errors.New("unknown kit")
+	// NoPermission gets returned when a player tries
+	// to claim a kit without having its permission.
+	NoPermission = errors.New("no permission to claim this kit")
+	// OnCooldown gets returned when a player tries
+	// to claim a kit before its cooldown has passed.
+	OnCooldown = errors.New("kit is on cooldown")
+)
+
+// kitRecord is the stored form of a kit in the kits file.
+// Items are stored as JSON, so that they can still be edited by hand.
+type kitRecord struct {
+	Permission string   `yaml:"Permission"`
+	Cooldown   string   `yaml:"Cooldown"`
+	Items      []string `yaml:"Items"`
+}
+
+// Manager manages all kits of the server.
+// Kits are loaded from and saved to a YAML file,
+// and the claims of players are kept in their player data.
+type Manager struct {
+	mutex   sync.RWMutex
+	path    string
+	storage players.DataStorage
+	kits    map[string]*Kit
+}
+
+// NewManager returns a new kit manager using the kits file at the given path.
+// Claims of players get saved to the data storage.
+func NewManager(path string, storage players.DataStorage) *Manager {
+	return &Manager{path: path, storage: storage, kits: make(map[string]*Kit)}
+}
+
+// Load loads all kits from the kits file.
+// A file with a default starter kit gets created if it does not yet exist.
+func (manager *Manager) Load() error {
+	var file, err = ioutil.ReadFile(manager.path)
+	if os.IsNotExist(err) {
+		var stone, _ = items.DefaultManager.Get("minecraft:stone", 32)
+		manager.AddKit(NewKit("starter", "", time.Hour*24, []*items.Stack{stone}))
+		return manager.Save()
+	}
+	if err != nil {
+		return err
+	}
+	var records map[string]kitRecord
+	if err := yaml.Unmarshal(file, &records); err != nil {
+		return err
+	}
+	for name, record := range records {
+		var cooldown time.Duration
+		if record.Cooldown != "" {
+			if cooldown, err = time.ParseDuration(record.Cooldown); err != nil {
+				return err
+			}
+		}
+		var stacks []*items.Stack
+		for _, item := range record.Items {
+			var stack, err = items.DecodeJSON([]byte(item))
+			if err != nil {
+				text.DefaultLogger.Error("Skipping item of kit", name+":", err)
+				continue
+			}
+			stacks = append(stacks, stack)
+		}
+		manager.AddKit(NewKit(name, record.Permission, cooldown, stacks))
+	}
+	return nil
+}
+
+// Save saves all kits to the kits file.
+func (manager *Manager) Save() error {
+	var records = make(map[string]kitRecord)
+	for name, kit := range manager.GetKits() {
+		var record = kitRecord{Permission: kit.GetPermission()}
+		if kit.GetCooldown() > 0 {
+			record.Cooldown = kit.GetCooldown().String()
+		}
+		for _, stack := range kit.items {
+			var item, err = items.EncodeJSON(stack)
+			if err != nil {
+				return err
+			}
+			record.Items = append(record.Items, string(item))
+		}
+		records[name] = record
+	}
+	var data, err = yaml.Marshal(records)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(manager.path, data, 0644)
+}
+
+// AddKit adds a kit to the manager.
+// Kits with the same name get overwritten.
+func (manager *Manager) AddKit(kit *Kit) {
+	manager.mutex.Lock()
+	manager.kits[strings.ToLower(kit.GetName())] = kit
+	manager.mutex.Unlock()
+}
+
+// RemoveKit removes a kit with the given name from the manager.
+func (manager *Manager) RemoveKit(name string) {
+	manager.mutex.Lock()
+	delete(manager.kits, strings.ToLower(name))
+	manager.mutex.Unlock()
+}
+
+// GetKit returns a kit by its name, and an error if it could not be found.
+func (manager *Manager) GetKit(name string) (*Kit, error) {
+	manager.mutex.RLock()
+	defer manager.mutex.RUnlock()
+	var kit, ok = manager.kits[strings.ToLower(name)]
+	if !ok {
+		return nil, UnknownKit
+	}
+	return kit, nil
+}
+
+// GetKits returns a name => kit map of all kits.
+func (manager *Manager) GetKits() map[string]*Kit {
+	manager.mutex.RLock()
+	defer manager.mutex.RUnlock()
+	var kits = make(map[string]*Kit, len(manager.kits))
+	for name, kit := range manager.kits {
+		kits[name] = kit
+	}
+	return kits
+}
+
+// GetKitNames returns the sorted names of all kits.
+func (manager *Manager) GetKitNames() []string {
+	var names []string
+	for _, kit := range manager.GetKits() {
+		names = append(names, kit.GetName())
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Apply gives all items of the kit to the session,
+// without checking the permission and cooldown of the kit.
+// It is used by plugins to give kits, for example at the start of a minigame.
+// inventory.FullInventory gets returned if not all items fit in the inventory,
+// in which case no items are given.
+func (manager *Manager) Apply(session *net.MinecraftSession, kit *Kit) error {
+	var inv = session.GetPlayer().GetInventory()
+	if !inv.CanAddItems(kit.GetItems()...) {
+		return inventory.FullInventory
+	}
+	for _, stack := range kit.GetItems() {
+		inv.AddItemReturningLeftovers(stack)
+	}
+	session.SendInventory()
+	return nil
+}
+
+// GetCooldown returns the cooldown left before the session can claim the kit again.
+func (manager *Manager) GetCooldown(session *net.MinecraftSession, kit *Kit) time.Duration {
+	var claimed, ok = session.GetPlayer().GetData().KitClaims[strings.ToLower(kit.GetName())]
+	if !ok {
+		return 0
+	}
+	var left = time.Unix(claimed, 0).Add(kit.GetCooldown()).Sub(time.Now())
+	if left < 0 {
+		return 0
+	}
+	return left
+}
+
+// Claim makes the session claim the kit with the given name.
+// The permission and cooldown of the kit are checked,
+// after which the kit gets applied and the claim is saved in the player data.
+func (manager *Manager) Claim(session *net.MinecraftSession, name string) error {
+	var kit, err = manager.GetKit(name)
+	if err != nil {
+		return err
+	}
+	if kit.GetPermission() != "" && !session.HasPermission(kit.GetPermission()) {
+		return NoPermission
+	}
+	if manager.GetCooldown(session, kit) > 0 {
+		return OnCooldown
+	}
+	if err := manager.Apply(session, kit); err != nil {
+		return err
+	}
+	var data = session.GetPlayer().GetData()
+	data.KitClaims[strings.ToLower(kit.GetName())] = time.Now().Unix()
+	return manager.storage.Save(data)
+}
@@ -0,0 +1,48 @@
+package kits
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/irmine/gomine/items"
+	"github.com/irmine/gomine/net"
+	"github.com/irmine/gomine/players"
+)
+
+func TestManager(t *testing.T) {
+	dir, _ := ioutil.TempDir("", "kits")
+	defer os.RemoveAll(dir)
+	storage := players.NewFileDataStorage(dir + "/players/")
+
+	manager := NewManager(dir+"/kits.yml", storage)
+	if err := manager.Load(); err != nil {
+		t.Fatal("could not create default kits:", err)
+	}
+	paper, _ := items.DefaultManager.Get("minecraft:paper", 3)
+	manager.AddKit(NewKit("VIP", "gomine.kit.vip", time.Hour, []*items.Stack{paper}))
+	if err := manager.Save(); err != nil {
+		t.Fatal("could not save kits:", err)
+	}
+
+	manager = NewManager(dir+"/kits.yml", storage)
+	if err := manager.Load(); err != nil {
+		t.Fatal("could not load kits:", err)
+	}
+	kit, err := manager.GetKit("vip")
+	if err != nil || kit.GetCooldown() != time.Hour || len(kit.GetItems()) != 1 || kit.GetItems()[0].Count != 3 {
+		t.Fatal("kit was not persisted:", kit, err)
+	}
+
+	session := net.NewMinecraftSession(nil, nil)
+	session.SetPlayer(players.NewPlayer(uuid.New(), "", 0, "Steve"))
+	if err := manager.Claim(session, "vip"); err != NoPermission {
+		t.Error("expected no permission, got:", err)
+	}
+	session.GetPlayer().GetData().KitClaims["vip"] = time.Now().Unix()
+	if left := manager.GetCooldown(session, kit); left <= 0 || left > time.Hour {
+		t.Error("unexpected cooldown after claim:", left)
+	}
+}
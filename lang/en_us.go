@@ -0,0 +1,197 @@
+package lang
+
+import (
+	"github.com/irmine/gomine/text"
+)
+
+// english holds the built-in messages in the default language.
+// Translations registered for other languages may override any of these keys.
+var english = map[string]string{
+	"commands.generic.notFound":   "Command could not be found.",
+	"commands.generic.permission": "You do not have permission to execute this command.",
+	"commands.generic.usage":      text.Yellow + "Usage: {0}",
+	"commands.generic.playerOnly": text.Red + "Please run this command as a player.",
+	"commands.generic.offline":    text.Red + "Player {0} is not online.",
+
+	"commands.list.header":     text.BrightGreen + "-----" + text.White + " Player List ({0} Players) " + text.BrightGreen + "-----",
+	"commands.list.header.one": text.BrightGreen + "-----" + text.White + " Player List (1 Player) " + text.BrightGreen + "-----",
+	"commands.ping.latency":    text.Yellow + "Your current latency/ping is: {0}",
+
+	"commands.party.created":        text.BrightGreen + "You created a new party.",
+	"commands.party.invited":        text.BrightGreen + "You invited {0} to your party.",
+	"commands.party.invitation":     text.Yellow + "{0} invited you to their party. Use /party accept {1} to join.",
+	"commands.party.joined":         text.Yellow + "{0} joined the party.",
+	"commands.party.left":           text.Yellow + "You left the party.",
+	"commands.party.memberLeft":     text.Yellow + "{0} left the party.",
+	"commands.party.kicked":         text.Yellow + "You kicked {0} from the party.",
+	"commands.party.wasKicked":      text.Yellow + "You were kicked from the party.",
+	"commands.party.disbanded":      text.Yellow + "The party has been disbanded.",
+	"commands.party.chat.party":     text.Yellow + "Your chat messages are now sent to your party only.",
+	"commands.party.chat.everybody": text.Yellow + "Your chat messages are now sent to everybody.",
+	"commands.party.teleported":     text.BrightGreen + "Teleported all party members to you.",
+	"commands.party.list.header":    text.BrightGreen + "-----" + text.White + " Party ({0}) " + text.BrightGreen + "-----",
+	"commands.party.list.leader":    text.Orange + "{0} (Leader)",
+	"commands.party.failed":         text.Red + "Could not {0}: {1}",
+
+	"commands.friend.requestSent":     text.BrightGreen + "You sent a friend request to {0}.",
+	"commands.friend.accepted":        text.BrightGreen + "You are now friends with {0}.",
+	"commands.friend.denied":          text.Yellow + "You denied the friend request of {0}.",
+	"commands.friend.removed":         text.Yellow + "You are no longer friends with {0}.",
+	"commands.friend.list.header":     text.BrightGreen + "-----" + text.White + " Friends ({0}) " + text.BrightGreen + "-----",
+	"commands.friend.list.online":     text.BrightGreen + "{0}: online",
+	"commands.friend.list.offline":    text.Gray + "{0}: offline",
+	"commands.friend.requests.header": text.BrightGreen + "-----" + text.White + " Friend Requests " + text.BrightGreen + "-----",
+	"commands.friend.failed":          text.Red + "Could not {0} friend: {1}",
+
+	"commands.trade.requestSent": text.BrightGreen + "You sent a trade request to {0}.",
+	"commands.trade.denied":      text.Yellow + "You denied the trade request of {0}.",
+	"commands.trade.failed":      text.Red + "Could not {0} trade: {1}",
+
+	"commands.market.reclaimed":        text.BrightGreen + "Reclaimed {0} expired listings.",
+	"commands.market.reclaimFailed":    text.Red + "Could not reclaim all listings: {0}",
+	"commands.market.nothingToReclaim": text.Yellow + "You have no expired listings to reclaim.",
+
+	"commands.msg.empty":  text.Red + "Please enter a message.",
+	"commands.msg.failed": text.Red + "Could not send message: {0}",
+
+	"commands.chat.failed":   text.Red + "Could not switch channel: {0}",
+	"commands.chat.switched": text.BrightGreen + "You are now talking in the {0} channel.",
+
+	"commands.mute.invalidDuration":  text.Red + "Invalid duration: {0}. Use for example 30m or 2h.",
+	"commands.mute.failed":           text.Red + "Could not mute {0}: {1}",
+	"commands.mute.permanent":        text.BrightGreen + "Muted {0} permanently.",
+	"commands.mute.temporary":        text.BrightGreen + "Muted {0} for {1}.",
+	"commands.mute.notify.permanent": text.Red + "You have been muted permanently. {0}",
+	"commands.mute.notify.temporary": text.Red + "You have been muted for {0}. {1}",
+	"commands.unmute.failed":         text.Red + "Could not unmute {0}: {1}",
+	"commands.unmute.success":        text.BrightGreen + "Unmuted {0}.",
+	"commands.unmute.notify":         text.BrightGreen + "You have been unmuted.",
+
+	"commands.kit.list":             text.Yellow + "Kits: {0}",
+	"commands.kit.noEditPermission": text.Red + "You do not have permission to edit kits.",
+	"commands.kit.noName":           text.Red + "Please enter the name of the kit.",
+	"commands.kit.invalidCooldown":  text.Red + "Invalid cooldown: {0}. Use for example 30m or 24h.",
+	"commands.kit.saveFailed":       text.Red + "Could not save kits: {0}",
+	"commands.kit.created":          text.BrightGreen + "Kit {0} has been created.",
+	"commands.kit.deleted":          text.BrightGreen + "Kit {0} has been deleted.",
+	"commands.kit.cooldown":         text.Red + "You can claim this kit again in {0}.",
+	"commands.kit.claimFailed":      text.Red + "Could not claim kit: {0}",
+	"commands.kit.claimed":          text.BrightGreen + "You claimed the {0} kit.",
+
+	"commands.top.unknown": text.Red + "Unknown leaderboard. Leaderboards: {0}",
+	"commands.top.empty":   text.Yellow + "Nobody has been ranked on this leaderboard yet.",
+	"commands.top.title":   text.Yellow + "Top {0}",
+	"commands.top.header":  text.Yellow + "Top {0}:",
+	"commands.top.rank":    text.Gray + "Your rank: " + text.Yellow + "#{0}",
+
+	"commands.cosmetics.available":   text.Yellow + "Available {0}s: {1}",
+	"commands.cosmetics.unequipped":  text.Yellow + "You unequipped your {0}.",
+	"commands.cosmetics.equipFailed": text.Red + "Could not equip {0}: {1}",
+	"commands.cosmetics.equipped":    text.BrightGreen + "You equipped the {0} {1}.",
+
+	"commands.summon.unknown":  text.Red + "Unknown mob. Mobs: {0}",
+	"commands.summon.summoned": text.BrightGreen + "Summoned {0}.",
+	"commands.summon.cap":      text.Red + "This world has reached the mob cap of {0}.",
+
+	"commands.butcher.removed":          text.BrightGreen + "Removed {0} mobs.",
+	"commands.butcher.radiusPlayerOnly": text.Red + "Only players can butcher mobs within a radius.",
+
+	"commands.kill.killed":          text.BrightGreen + "Killed {0} entities.",
+	"commands.kill.noTargets":       text.Red + "No entities matched {0}.",
+	"commands.kill.invalidSelector": text.Red + "Invalid target selector {0}. Radius selectors can only be used by players.",
+
+	"commands.scoreboard.objectives.added":     text.BrightGreen + "Added objective {0} with criteria {1}.",
+	"commands.scoreboard.objectives.removed":   text.Yellow + "Removed objective {0}.",
+	"commands.scoreboard.objectives.header":    text.Yellow + "There are {0} objectives:",
+	"commands.scoreboard.objectives.entry":     text.White + "{0}" + text.Gray + " ({1}): {2}",
+	"commands.scoreboard.objectives.displayed": text.BrightGreen + "Showing objective {0} on the sidebar.",
+	"commands.scoreboard.objectives.cleared":   text.Yellow + "Cleared the sidebar.",
+	"commands.scoreboard.players.set":          text.BrightGreen + "Set {0} of {1} to {2}.",
+	"commands.scoreboard.players.reset":        text.Yellow + "Reset the scores of {0}.",
+	"commands.scoreboard.players.header":       text.Yellow + "{0} has {1} scores:",
+	"commands.scoreboard.players.entry":        text.White + "{0}" + text.Gray + ": {1}",
+	"commands.scoreboard.failed":               text.Red + "Could not change the scoreboard: {0}",
+
+	"commands.tags.list":   text.Yellow + "There are {0} tags: " + text.White + "{1}",
+	"commands.tags.values": text.Yellow + "Values of {0}: " + text.White + "{1}",
+	"commands.tags.of":     text.Yellow + "Tags of {0}: " + text.White + "{1}",
+	"commands.tags.none":   text.Red + "{0} is not a tag, and is not part of any tag.",
+
+	"commands.fly.noTarget":       text.Red + "Please specify a player to toggle flight of.",
+	"commands.fly.enabled":        text.BrightGreen + "Flight enabled.",
+	"commands.fly.disabled":       text.BrightGreen + "Flight disabled.",
+	"commands.fly.enabled.other":  text.BrightGreen + "Flight enabled for {0}.",
+	"commands.fly.disabled.other": text.BrightGreen + "Flight disabled for {0}.",
+
+	"commands.god.noTarget":       text.Red + "Please specify a player to toggle invulnerability of.",
+	"commands.god.enabled":        text.BrightGreen + "God mode enabled.",
+	"commands.god.disabled":       text.BrightGreen + "God mode disabled.",
+	"commands.god.enabled.other":  text.BrightGreen + "God mode enabled for {0}.",
+	"commands.god.disabled.other": text.BrightGreen + "God mode disabled for {0}.",
+
+	"commands.freeze.failed":   text.Red + "Could not freeze {0}.",
+	"commands.freeze.froze":    text.BrightGreen + "Froze {0}.",
+	"commands.freeze.frozen":   text.Red + "You have been frozen.",
+	"commands.freeze.unfroze":  text.BrightGreen + "Unfroze {0}.",
+	"commands.freeze.unfrozen": text.BrightGreen + "You have been unfrozen.",
+
+	"commands.sort.sorted": text.BrightGreen + "Your inventory has been sorted.",
+	"commands.sort.failed": text.Red + "Your inventory could not be sorted.",
+
+	"commands.rtp.search":     text.Yellow + "Searching a safe location...",
+	"commands.rtp.searching":  text.Red + "A location is already being searched for you.",
+	"commands.rtp.teleported": text.BrightGreen + "Teleported to {0}, {1}, {2}.",
+	"commands.rtp.cooldown":   text.Red + "You can teleport randomly again in {0}.",
+	"commands.rtp.noSafe":     text.Red + "No safe location could be found. Please try again.",
+	"commands.rtp.failed":     text.Red + "You could not be teleported.",
+
+	"commands.world.unknown":  text.Red + "World {0} is not loaded.",
+	"commands.world.cooldown": text.Red + "You can change worlds again in {0}.",
+	"commands.world.moved":    text.BrightGreen + "Moved to world {0}.",
+	"commands.world.failed":   text.Red + "You could not be moved to world {0}.",
+
+	"commands.transfer.noAddress":   text.Red + "Server {0} has no public address.",
+	"commands.transfer.invalidPort": text.Red + "Invalid port {0}.",
+	"commands.transfer.transferred": text.BrightGreen + "Transferred {0} to {1}.",
+
+	"commands.servers.header":       text.BrightGreen + "-----" + text.White + " Servers ({0} Players) " + text.BrightGreen + "-----",
+	"commands.netcommand.empty":     text.Red + "Please enter a command.",
+	"commands.netcommand.failed":    text.Red + "Could not send command: {0}",
+	"commands.netcommand.sent":      text.BrightGreen + "Sent command to {0}.",
+	"commands.netcommand.sent.all":  text.BrightGreen + "Sent command to all servers.",
+	"commands.hide.hidden":          text.BrightGreen + "You no longer see {0}.",
+	"commands.hide.shown":           text.BrightGreen + "You now see {0} again.",
+	"commands.worldinfo.failed":     text.Red + "Could not read world {0}: {1}",
+	"commands.worldinfo.header":     text.BrightGreen + "-----" + text.White + " World {0} " + text.BrightGreen + "-----",
+	"commands.worldinfo.total":      text.BrightGreen + "Total: " + text.Yellow + "{0}",
+	"commands.worldinfo.statistics": "{0} MB, {1} regions, {2} chunks",
+
+	"commands.language.current": text.Yellow + "Your language is {0}. Languages: {1}",
+	"commands.language.unknown": text.Red + "Unknown language {0}. Languages: {1}",
+	"commands.language.set":     text.BrightGreen + "Your language has been set to {0}.",
+	"commands.language.reset":   text.BrightGreen + "Your language now follows your game language again.",
+
+	"commands.nick.failed":        text.Red + "Could not change nickname: {0}",
+	"commands.nick.set":           text.BrightGreen + "Your nickname is now {0}" + text.BrightGreen + ".",
+	"commands.nick.removed":       text.BrightGreen + "Your nickname has been removed.",
+	"commands.nick.set.other":     text.BrightGreen + "The nickname of {0} is now {1}" + text.BrightGreen + ".",
+	"commands.nick.removed.other": text.BrightGreen + "The nickname of {0} has been removed.",
+
+	"commands.debug.unknown":  text.Red + "Unknown action {0}. Use /debug dump.",
+	"commands.debug.creating": text.Yellow + "Creating debug dump...",
+	"commands.debug.created":  text.BrightGreen + "Debug dump saved to {0}.",
+	"commands.debug.failed":   text.Red + "Could not create debug dump: {0}",
+
+	"disconnect.outdatedClient": "Your game version {0} is outdated. This server supports versions {1} to {2}.",
+	"disconnect.outdatedServer": "This server does not support game version {0} yet. It supports versions {1} to {2}.",
+
+	"commands.tracepackets.started":         text.BrightGreen + "Tracing packets of {0} for {1} to {2}.",
+	"commands.tracepackets.stopped":         text.BrightGreen + "Stopped tracing packets of {0}.",
+	"commands.tracepackets.notTracing":      text.Red + "Packets of {0} are not being traced.",
+	"commands.tracepackets.invalidDuration": text.Red + "Invalid duration {0}. Use seconds, such as 30, or a duration, such as 2m.",
+	"commands.tracepackets.failed":          text.Red + "Could not trace packets: {0}",
+
+	"limits.alert.loadedChunks": text.Red + "{0} chunks are loaded, exceeding the soft limit of {1}.",
+	"limits.alert.entities":     text.Red + "{0} entities exist, exceeding the soft limit of {1}.",
+	"limits.alert.playerChunks": text.Red + "{0} has {1} chunks loaded, exceeding the soft limit of {2}.",
+}
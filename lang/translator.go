@@ -0,0 +1,157 @@
+package lang
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"gopkg.in/yaml.v2"
+)
+
+// DefaultLanguage is the language of the built-in messages,
+// which is used when no translation is available in the language of a player.
+const DefaultLanguage = "en_US"
+
+// DefaultTranslator is the translator used for all built-in messages.
+// It holds the built-in English messages, and translations loaded by the server.
+var DefaultTranslator = newDefaultTranslator()
+
+// Translator translates message keys into the language of players.
+// A message may hold parameters such as {0}, which get replaced with the parameters of the translation.
+type Translator struct {
+	mutex           sync.RWMutex
+	defaultLanguage string
+	// languages is a lower case language => key => message map.
+	languages map[string]map[string]string
+	// names is a lower case language => language map, keeping the original name of every language.
+	names map[string]string
+}
+
+// NewTranslator returns a new translator without messages,
+// falling back to the given language if a translation is not available.
+func NewTranslator(defaultLanguage string) *Translator {
+	return &Translator{defaultLanguage: defaultLanguage, languages: make(map[string]map[string]string), names: make(map[string]string)}
+}
+
+// newDefaultTranslator returns a new translator holding the built-in English messages.
+func newDefaultTranslator() *Translator {
+	var translator = NewTranslator(DefaultLanguage)
+	translator.Register(DefaultLanguage, english)
+	return translator
+}
+
+// Register registers the messages of the given language, such as "nl_NL".
+// Messages already registered for the language with the same key are overwritten.
+func (translator *Translator) Register(language string, messages map[string]string) {
+	var key = normalize(language)
+	translator.mutex.Lock()
+	defer translator.mutex.Unlock()
+	if _, ok := translator.languages[key]; !ok {
+		translator.languages[key] = make(map[string]string, len(messages))
+		translator.names[key] = language
+	}
+	for messageKey, message := range messages {
+		translator.languages[key][messageKey] = message
+	}
+}
+
+// LoadDirectory registers the messages of all YAML files in the directory,
+// each holding a key => message map. The name of every file is its language, such as nl_NL.yml.
+// No error is returned if the directory does not exist.
+func (translator *Translator) LoadDirectory(path string) error {
+	var files, err = ioutil.ReadDir(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	for _, file := range files {
+		if file.IsDir() || filepath.Ext(file.Name()) != ".yml" {
+			continue
+		}
+		var content, err = ioutil.ReadFile(filepath.Join(path, file.Name()))
+		if err != nil {
+			return err
+		}
+		var messages = make(map[string]string)
+		if err := yaml.Unmarshal(content, &messages); err != nil {
+			return fmt.Errorf("invalid language file %v: %v", file.Name(), err)
+		}
+		translator.Register(strings.TrimSuffix(file.Name(), ".yml"), messages)
+	}
+	return nil
+}
+
+// GetLanguages returns the names of all registered languages, sorted alphabetically.
+func (translator *Translator) GetLanguages() []string {
+	translator.mutex.RLock()
+	var languages = make([]string, 0, len(translator.names))
+	for _, name := range translator.names {
+		languages = append(languages, name)
+	}
+	translator.mutex.RUnlock()
+	sort.Strings(languages)
+	return languages
+}
+
+// GetLanguage returns the registered name of the language, ignoring case and
+// accepting dashes in place of underscores, such as "en_US" for "en-us".
+// A bool is returned indicating if the language is registered.
+func (translator *Translator) GetLanguage(language string) (string, bool) {
+	translator.mutex.RLock()
+	defer translator.mutex.RUnlock()
+	var name, ok = translator.names[normalize(language)]
+	return name, ok
+}
+
+// Translate translates the message key into the language, replacing the parameters.
+// If the language has no message for the key, the message of another variant of
+// the language is used, such as nl_BE for nl_NL, followed by the default language.
+// The key itself is returned if no language has a message for it.
+func (translator *Translator) Translate(language string, key string, parameters ...interface{}) string {
+	var message, ok = translator.getMessage(language, key)
+	if !ok {
+		return key
+	}
+	for i, parameter := range parameters {
+		message = strings.Replace(message, "{"+strconv.Itoa(i)+"}", fmt.Sprint(parameter), -1)
+	}
+	return message
+}
+
+// getMessage returns the message with the key following the fallback chain of the language.
+func (translator *Translator) getMessage(language string, key string) (string, bool) {
+	translator.mutex.RLock()
+	defer translator.mutex.RUnlock()
+	language = normalize(language)
+	if message, ok := translator.languages[language][key]; ok {
+		return message, true
+	}
+	var base = strings.SplitN(language, "_", 2)[0]
+	var variants = make([]string, 0, len(translator.languages))
+	for name := range translator.languages {
+		if name != language && strings.SplitN(name, "_", 2)[0] == base {
+			variants = append(variants, name)
+		}
+	}
+	// Variants are sorted so that the same variant is used every time.
+	sort.Strings(variants)
+	for _, name := range variants {
+		if message, ok := translator.languages[name][key]; ok {
+			return message, true
+		}
+	}
+	var message, ok = translator.languages[normalize(translator.defaultLanguage)][key]
+	return message, ok
+}
+
+// normalize returns the language in lower case with underscores, such as en_us.
+func normalize(language string) string {
+	return strings.ToLower(strings.Replace(strings.TrimSpace(language), "-", "_", -1))
+}
@@ -0,0 +1,50 @@
+package lang
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestTranslate(t *testing.T) {
+	translator := NewTranslator("en_US")
+	translator.Register("en_US", map[string]string{"greeting": "Hello {0}, you have {1} messages.", "bye": "Bye"})
+	translator.Register("nl_BE", map[string]string{"greeting": "Hallo {0}, je hebt {1} berichten."})
+
+	if message := translator.Translate("en_US", "greeting", "Steve", 3); message != "Hello Steve, you have 3 messages." {
+		t.Error("unexpected translation:", message)
+	}
+	if message := translator.Translate("nl_NL", "greeting", "Steve", 3); message != "Hallo Steve, je hebt 3 berichten." {
+		t.Error("variant of the language was not used:", message)
+	}
+	if message := translator.Translate("nl-be", "bye"); message != "Bye" {
+		t.Error("default language was not used:", message)
+	}
+	if message := translator.Translate("de_DE", "unknown"); message != "unknown" {
+		t.Error("key was not returned for an unknown message:", message)
+	}
+}
+
+func TestLoadDirectory(t *testing.T) {
+	dir, _ := ioutil.TempDir("", "lang")
+	defer os.RemoveAll(dir)
+	ioutil.WriteFile(filepath.Join(dir, "de_DE.yml"), []byte("commands.generic.notFound: Befehl nicht gefunden.\n"), 0644)
+
+	translator := newDefaultTranslator()
+	if err := translator.LoadDirectory(dir); err != nil {
+		t.Fatal("could not load languages:", err)
+	}
+	if name, ok := translator.GetLanguage("DE-de"); !ok || name != "de_DE" {
+		t.Error("loaded language was not registered:", name)
+	}
+	if message := translator.Translate("de_DE", "commands.generic.notFound"); message != "Befehl nicht gefunden." {
+		t.Error("loaded message was not used:", message)
+	}
+	if message := translator.Translate("de_DE", "commands.generic.permission"); message != english["commands.generic.permission"] {
+		t.Error("built-in message was not used as fallback:", message)
+	}
+	if err := translator.LoadDirectory(filepath.Join(dir, "missing")); err != nil {
+		t.Error("missing directory returned an error:", err)
+	}
+}
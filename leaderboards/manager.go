@@ -0,0 +1,210 @@
+package leaderboards
+
+import (
+	"errors"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/irmine/gomine/text"
+)
+
+// UnknownLeaderboard gets returned when a leaderboard
+// with a given name could not be found.
+var UnknownLeaderboard = errors.New("unknown leaderboard")
+
+// Entry is the rank of a single player on a leaderboard.
+type Entry struct {
+	Rank  int
+	Name  string
+	Value float64
+}
+
+// Manager manages all leaderboards of the server.
+// Leaderboards are recomputed periodically off the server thread,
+// and the last computed results are kept in memory.
+type Manager struct {
+	// Interval is the interval at which leaderboards are recomputed.
+	Interval time.Duration
+	// MaxEntries is the maximum amount of entries kept per leaderboard.
+	MaxEntries int
+	// UpdateFunction gets called once a leaderboard got recomputed,
+	// for example to update holograms or scoreboards showing it.
+	// UpdateFunction gets called off the server thread.
+	UpdateFunction func(provider Provider, entries []Entry)
+
+	mutex       sync.RWMutex
+	providers   map[string]Provider
+	results     map[string][]Entry
+	lastCompute time.Time
+	computing   bool
+}
+
+// NewManager returns a new leaderboard manager.
+func NewManager() *Manager {
+	return &Manager{
+		Interval:       time.Minute * 5,
+		MaxEntries:     100,
+		UpdateFunction: func(Provider, []Entry) {},
+		providers:      make(map[string]Provider),
+		results:        make(map[string][]Entry),
+	}
+}
+
+// Register registers a provider as a new leaderboard.
+// Leaderboards with the same name get overwritten.
+// The leaderboard gets computed during the next recomputation.
+func (manager *Manager) Register(provider Provider) {
+	manager.mutex.Lock()
+	manager.providers[provider.GetName()] = provider
+	manager.mutex.Unlock()
+}
+
+// Deregister removes the leaderboard with the given name.
+func (manager *Manager) Deregister(name string) {
+	manager.mutex.Lock()
+	delete(manager.providers, name)
+	delete(manager.results, name)
+	manager.mutex.Unlock()
+}
+
+// GetProvider returns the provider of the leaderboard with the given name.
+func (manager *Manager) GetProvider(name string) (Provider, error) {
+	manager.mutex.RLock()
+	defer manager.mutex.RUnlock()
+	var provider, ok = manager.providers[name]
+	if !ok {
+		return nil, UnknownLeaderboard
+	}
+	return provider, nil
+}
+
+// GetNames returns the sorted names of all leaderboards.
+func (manager *Manager) GetNames() []string {
+	manager.mutex.RLock()
+	var names = make([]string, 0, len(manager.providers))
+	for name := range manager.providers {
+		names = append(names, name)
+	}
+	manager.mutex.RUnlock()
+	sort.Strings(names)
+	return names
+}
+
+// GetTop returns the top entries of the leaderboard with the given name,
+// as computed during the last recomputation.
+func (manager *Manager) GetTop(name string, count int) ([]Entry, error) {
+	manager.mutex.RLock()
+	defer manager.mutex.RUnlock()
+	if _, ok := manager.providers[name]; !ok {
+		return nil, UnknownLeaderboard
+	}
+	var entries = manager.results[name]
+	if count < len(entries) {
+		entries = entries[:count]
+	}
+	return append([]Entry{}, entries...), nil
+}
+
+// GetRank returns the entry of the player with the given name on a leaderboard.
+// A bool is returned indicating if the player was ranked.
+func (manager *Manager) GetRank(name string, player string) (Entry, bool) {
+	manager.mutex.RLock()
+	defer manager.mutex.RUnlock()
+	for _, entry := range manager.results[name] {
+		if entry.Name == player {
+			return entry, true
+		}
+	}
+	return Entry{}, false
+}
+
+// GetLines returns the top entries of a leaderboard as formatted lines,
+// such as "#1 Steve: 25", ready to be shown on a scoreboard or hologram.
+func (manager *Manager) GetLines(name string, count int) ([]string, error) {
+	var provider, err = manager.GetProvider(name)
+	if err != nil {
+		return nil, err
+	}
+	entries, err := manager.GetTop(name, count)
+	if err != nil {
+		return nil, err
+	}
+	var lines = make([]string, len(entries))
+	for i, entry := range entries {
+		lines[i] = text.Yellow + "#" + strconv.Itoa(entry.Rank) + " " + text.White + entry.Name + text.Gray + ": " + provider.Format(entry.Value)
+	}
+	return lines, nil
+}
+
+// Recompute recomputes all leaderboards immediately on the calling goroutine.
+func (manager *Manager) Recompute() {
+	manager.mutex.RLock()
+	var providers = make([]Provider, 0, len(manager.providers))
+	for _, provider := range manager.providers {
+		providers = append(providers, provider)
+	}
+	manager.mutex.RUnlock()
+
+	for _, provider := range providers {
+		var values, err = provider.GetValues()
+		if err != nil {
+			text.DefaultLogger.Error("Could not compute leaderboard", provider.GetName()+":", err)
+			continue
+		}
+		var entries = rank(values, manager.MaxEntries)
+		manager.mutex.Lock()
+		if _, ok := manager.providers[provider.GetName()]; ok {
+			manager.results[provider.GetName()] = entries
+		}
+		manager.mutex.Unlock()
+		manager.UpdateFunction(provider, entries)
+	}
+}
+
+// Tick starts recomputing all leaderboards off the server thread
+// once the interval has passed since the previous recomputation.
+// Internal. Not to be used by plugins.
+func (manager *Manager) Tick() {
+	manager.mutex.Lock()
+	if manager.computing || time.Now().Sub(manager.lastCompute) < manager.Interval {
+		manager.mutex.Unlock()
+		return
+	}
+	manager.computing = true
+	manager.lastCompute = time.Now()
+	manager.mutex.Unlock()
+
+	go func() {
+		manager.Recompute()
+		manager.mutex.Lock()
+		manager.computing = false
+		manager.mutex.Unlock()
+	}()
+}
+
+// rank sorts the values from highest to lowest and returns at most max entries.
+// Players with equal values share the same rank.
+func rank(values map[string]float64, max int) []Entry {
+	var entries = make([]Entry, 0, len(values))
+	for name, value := range values {
+		entries = append(entries, Entry{Name: name, Value: value})
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].Value == entries[j].Value {
+			return entries[i].Name < entries[j].Name
+		}
+		return entries[i].Value > entries[j].Value
+	})
+	if max > 0 && len(entries) > max {
+		entries = entries[:max]
+	}
+	for i := range entries {
+		entries[i].Rank = i + 1
+		if i > 0 && entries[i].Value == entries[i-1].Value {
+			entries[i].Rank = entries[i-1].Rank
+		}
+	}
+	return entries
+}
@@ -0,0 +1,27 @@
+package leaderboards
+
+import (
+	"testing"
+)
+
+func TestRecompute(t *testing.T) {
+	var manager = NewManager()
+	manager.Register(NewFunctionProvider("kills", func() (map[string]float64, error) {
+		return map[string]float64{"Steve": 3, "Alex": 10, "Notch": 3}, nil
+	}, FormatInt))
+	manager.Recompute()
+
+	var entries, err = manager.GetTop("kills", 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 2 || entries[0].Name != "Alex" || entries[1].Rank != 2 {
+		t.Error("unexpected top entries:", entries)
+	}
+	if entry, ok := manager.GetRank("kills", "Steve"); !ok || entry.Rank != 2 {
+		t.Error("players with equal values should share their rank, got:", entry)
+	}
+	if _, err := manager.GetTop("deaths", 10); err != UnknownLeaderboard {
+		t.Error("expected unknown leaderboard, got:", err)
+	}
+}
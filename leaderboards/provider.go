@@ -0,0 +1,76 @@
+package leaderboards
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/irmine/gomine/players"
+)
+
+// Provider provides the values of a single stat for all players,
+// by which players get ranked on a leaderboard.
+type Provider interface {
+	// GetName returns the name of the stat, for example "kills".
+	GetName() string
+	// GetValues returns a player name => value map of all players.
+	// GetValues gets called off the server thread.
+	GetValues() (map[string]float64, error)
+	// Format formats a value as displayed on the leaderboard.
+	Format(value float64) string
+}
+
+// FunctionProvider is a provider using functions
+// to provide and format the values of a stat.
+type FunctionProvider struct {
+	name   string
+	values func() (map[string]float64, error)
+	format func(value float64) string
+}
+
+// NewFunctionProvider returns a new provider with the given name, values function and format function.
+func NewFunctionProvider(name string, values func() (map[string]float64, error), format func(value float64) string) *FunctionProvider {
+	return &FunctionProvider{name, values, format}
+}
+
+// GetName returns the name of the stat.
+func (provider *FunctionProvider) GetName() string {
+	return provider.name
+}
+
+// GetValues returns the values of all players.
+func (provider *FunctionProvider) GetValues() (map[string]float64, error) {
+	return provider.values()
+}
+
+// Format formats a value using the format function of the provider.
+func (provider *FunctionProvider) Format(value float64) string {
+	return provider.format(value)
+}
+
+// NewStatProvider returns a new provider ranking players by a stat kept in their player data,
+// such as "kills". Plugins keep these stats in the Stats map of the player data.
+func NewStatProvider(stat string, storage players.DataStorage) *FunctionProvider {
+	return NewFunctionProvider(stat, func() (map[string]float64, error) {
+		var all, err = storage.LoadAll()
+		if err != nil {
+			return nil, err
+		}
+		var values = make(map[string]float64)
+		for _, data := range all {
+			if value, ok := data.Stats[stat]; ok {
+				values[data.Name] = value
+			}
+		}
+		return values, nil
+	}, FormatInt)
+}
+
+// FormatInt formats a value as a whole number.
+func FormatInt(value float64) string {
+	return strconv.FormatInt(int64(value), 10)
+}
+
+// FormatDuration formats a value in seconds as a duration, for example "2h15m".
+func FormatDuration(value float64) string {
+	return (time.Duration(value) * time.Second).Round(time.Minute).String()
+}
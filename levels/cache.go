@@ -0,0 +1,138 @@
+package levels
+
+import (
+	lru "container/list"
+	"sync"
+
+	"github.com/irmine/worlds"
+	"github.com/irmine/worlds/chunks"
+)
+
+// DefaultChunkCacheSize is the default amount of decoded chunks kept in a chunk cache.
+const DefaultChunkCacheSize = 256
+
+// ChunkCache is a least recently used cache of decoded chunks, shared by the dimensions of a manager.
+// Chunks loaded again shortly after being unloaded are taken from the cache,
+// so that they do not have to be read and decoded again. The amount of cached chunks is bounded
+// by the size of the cache, which keeps memory usage predictable on exploration heavy servers.
+type ChunkCache struct {
+	mutex   sync.Mutex
+	size    int
+	order   *lru.List
+	entries map[chunkKey]*lru.Element
+}
+
+// cacheEntry is a chunk held by a chunk cache.
+type cacheEntry struct {
+	key   chunkKey
+	chunk *chunks.Chunk
+}
+
+// NewChunkCache returns a new chunk cache holding at most the given amount of chunks.
+// A size of 0 or lower disables caching.
+func NewChunkCache(size int) *ChunkCache {
+	return &ChunkCache{size: size, order: lru.New(), entries: make(map[chunkKey]*lru.Element)}
+}
+
+// Get returns the cached chunk at the chunk coordinates of the dimension,
+// marking it as most recently used. A bool is returned indicating if the chunk was cached.
+func (cache *ChunkCache) Get(dimension *worlds.Dimension, x, z int32) (*chunks.Chunk, bool) {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+	var element, ok = cache.entries[chunkKey{dimension, x, z}]
+	if !ok {
+		return nil, false
+	}
+	cache.order.MoveToFront(element)
+	return element.Value.(*cacheEntry).chunk, true
+}
+
+// Add adds the chunk of the dimension to the cache as most recently used,
+// evicting the least recently used chunks once the cache is full.
+func (cache *ChunkCache) Add(dimension *worlds.Dimension, chunk *chunks.Chunk) {
+	if cache.size <= 0 {
+		return
+	}
+	var key = chunkKey{dimension, chunk.X, chunk.Z}
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+	if element, ok := cache.entries[key]; ok {
+		element.Value.(*cacheEntry).chunk = chunk
+		cache.order.MoveToFront(element)
+		return
+	}
+	cache.entries[key] = cache.order.PushFront(&cacheEntry{key, chunk})
+	for cache.order.Len() > cache.size {
+		var oldest = cache.order.Back()
+		cache.order.Remove(oldest)
+		delete(cache.entries, oldest.Value.(*cacheEntry).key)
+	}
+}
+
+// RemoveDimension removes all cached chunks of the dimension.
+func (cache *ChunkCache) RemoveDimension(dimension *worlds.Dimension) {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+	for key, element := range cache.entries {
+		if key.dimension == dimension {
+			cache.order.Remove(element)
+			delete(cache.entries, key)
+		}
+	}
+}
+
+// GetSize returns the maximum amount of chunks held by the cache.
+func (cache *ChunkCache) GetSize() int {
+	return cache.size
+}
+
+// GetLength returns the amount of chunks currently held by the cache.
+func (cache *ChunkCache) GetLength() int {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+	return cache.order.Len()
+}
+
+// CachedProvider wraps the provider of a dimension, taking chunks from a chunk cache
+// when they are loaded and adding chunks to it once they are loaded or generated.
+type CachedProvider struct {
+	provider  Provider
+	cache     *ChunkCache
+	dimension *worlds.Dimension
+}
+
+// NewCachedProvider returns a new cached provider for the dimension, loading chunks
+// that are not cached using the given provider.
+func NewCachedProvider(provider Provider, cache *ChunkCache, dimension *worlds.Dimension) *CachedProvider {
+	return &CachedProvider{provider: provider, cache: cache, dimension: dimension}
+}
+
+// Load calls the function with the cached chunk at the chunk coordinates,
+// or loads it using the wrapped provider if it was not cached.
+func (cached *CachedProvider) Load(dimension *worlds.Dimension, x, z int32, function func(*chunks.Chunk)) {
+	if chunk, ok := cached.cache.Get(dimension, x, z); ok {
+		function(chunk)
+		return
+	}
+	cached.provider.Load(dimension, x, z, func(chunk *chunks.Chunk) {
+		cached.cache.Add(dimension, chunk)
+		function(chunk)
+	})
+}
+
+// Save saves the chunk using the wrapped provider.
+func (cached *CachedProvider) Save(chunk *chunks.Chunk) {
+	cached.provider.Save(chunk)
+}
+
+// SavePartial saves the changed parts of the chunk using the wrapped provider,
+// or the whole chunk if it does not support partial saves.
+func (cached *CachedProvider) SavePartial(chunk *chunks.Chunk, dirty Dirty) {
+	saveDirty(cached.provider, chunk, dirty)
+}
+
+// Close removes all cached chunks of the dimension and closes the wrapped provider.
+func (cached *CachedProvider) Close() {
+	cached.cache.RemoveDimension(cached.dimension)
+	cached.provider.Close()
+}
@@ -0,0 +1,185 @@
+package levels
+
+import (
+	"sync"
+
+	"github.com/irmine/worlds/blocks"
+	"github.com/irmine/worlds/chunks"
+)
+
+// Tile entity IDs of the tile entities provided by GoMine.
+const (
+	TileEntitySign = "Sign"
+)
+
+// TileEntity is a block entity placed at a block in a chunk,
+// for example a sign holding text.
+type TileEntity interface {
+	// GetId returns the tile entity ID, for example "Sign".
+	GetId() string
+	// GetPosition returns the position of the block the tile entity is at.
+	GetPosition() blocks.Position
+}
+
+// Sign is a sign tile entity holding four lines of text.
+type Sign struct {
+	Position blocks.Position
+	Lines    [4]string
+}
+
+// NewSign returns a new empty sign at the given position.
+func NewSign(position blocks.Position) *Sign {
+	return &Sign{Position: position}
+}
+
+// GetId returns the tile entity ID of signs.
+func (sign *Sign) GetId() string {
+	return TileEntitySign
+}
+
+// GetPosition returns the position of the sign.
+func (sign *Sign) GetPosition() blocks.Position {
+	return sign.Position
+}
+
+// ChangeType is the type of a change made in a chunk.
+type ChangeType byte
+
+const (
+	// ChangeBlock is a change of a block in the chunk.
+	ChangeBlock ChangeType = iota
+	// ChangeTileEntity is a tile entity being set in the chunk.
+	ChangeTileEntity
+	// ChangeTileEntityRemoved is a tile entity being removed from the chunk.
+	ChangeTileEntityRemoved
+)
+
+// Change is a change made in a chunk, passed to the change listeners of the chunk.
+type Change struct {
+	Type     ChangeType
+	Position blocks.Position
+	// TileEntity is the tile entity set or removed.
+	// It is nil for block changes.
+	TileEntity TileEntity
+}
+
+// Chunk is a chunk of a dimension with the tile entities placed in it.
+// It provides iteration over the entities and tile entities of the chunk,
+// and calls listeners every time something in the chunk changes.
+// Tile entities are only kept in memory and are not saved with the chunk.
+type Chunk struct {
+	*chunks.Chunk
+
+	mutex        sync.RWMutex
+	tileEntities map[blocks.Position]TileEntity
+	listeners    []func(Change)
+	// stored is set once the chunk was saved by the manager, or if it was loaded from a provider storing it.
+	// It is guarded by the mutex of the manager, like the checksums of the chunk.
+	stored bool
+	// hashes are the checksums of the chunk since it was loaded or last checked for changes.
+	hashes chunkHashes
+	hashed bool
+}
+
+// NewChunk returns a new chunk without tile entities wrapping the given chunk.
+func NewChunk(chunk *chunks.Chunk) *Chunk {
+	return &Chunk{Chunk: chunk, tileEntities: make(map[blocks.Position]TileEntity)}
+}
+
+// ForEachEntity calls the function for every entity in the chunk,
+// until the function returns false.
+func (chunk *Chunk) ForEachEntity(function func(entity chunks.ChunkEntity) bool) {
+	for _, entity := range chunk.GetEntities() {
+		if !function(entity) {
+			return
+		}
+	}
+}
+
+// ForEachTileEntity calls the function for every tile entity in the chunk,
+// until the function returns false.
+func (chunk *Chunk) ForEachTileEntity(function func(tileEntity TileEntity) bool) {
+	for _, tileEntity := range chunk.GetTileEntities() {
+		if !function(tileEntity) {
+			return
+		}
+	}
+}
+
+// ForEachSign calls the function for every sign in the chunk,
+// until the function returns false.
+func (chunk *Chunk) ForEachSign(function func(sign *Sign) bool) {
+	chunk.ForEachTileEntity(func(tileEntity TileEntity) bool {
+		if sign, ok := tileEntity.(*Sign); ok {
+			return function(sign)
+		}
+		return true
+	})
+}
+
+// GetTileEntities returns all tile entities in the chunk.
+func (chunk *Chunk) GetTileEntities() []TileEntity {
+	chunk.mutex.RLock()
+	defer chunk.mutex.RUnlock()
+	var tileEntities = make([]TileEntity, 0, len(chunk.tileEntities))
+	for _, tileEntity := range chunk.tileEntities {
+		tileEntities = append(tileEntities, tileEntity)
+	}
+	return tileEntities
+}
+
+// GetTileEntity returns the tile entity at the given position.
+// A bool is returned indicating if a tile entity was found.
+func (chunk *Chunk) GetTileEntity(position blocks.Position) (TileEntity, bool) {
+	chunk.mutex.RLock()
+	defer chunk.mutex.RUnlock()
+	var tileEntity, ok = chunk.tileEntities[position]
+	return tileEntity, ok
+}
+
+// SetTileEntity sets the tile entity at its position in the chunk,
+// replacing the tile entity previously at that position.
+func (chunk *Chunk) SetTileEntity(tileEntity TileEntity) {
+	chunk.mutex.Lock()
+	chunk.tileEntities[tileEntity.GetPosition()] = tileEntity
+	chunk.mutex.Unlock()
+	chunk.notify(Change{Type: ChangeTileEntity, Position: tileEntity.GetPosition(), TileEntity: tileEntity})
+}
+
+// RemoveTileEntity removes the tile entity at the given position.
+// A bool is returned indicating if a tile entity was removed.
+func (chunk *Chunk) RemoveTileEntity(position blocks.Position) bool {
+	chunk.mutex.Lock()
+	var tileEntity, ok = chunk.tileEntities[position]
+	delete(chunk.tileEntities, position)
+	chunk.mutex.Unlock()
+	if ok {
+		chunk.notify(Change{Type: ChangeTileEntityRemoved, Position: position, TileEntity: tileEntity})
+	}
+	return ok
+}
+
+// AddChangeListener adds a function that gets called every time
+// a block or tile entity in the chunk changes.
+func (chunk *Chunk) AddChangeListener(function func(change Change)) {
+	chunk.mutex.Lock()
+	chunk.listeners = append(chunk.listeners, function)
+	chunk.mutex.Unlock()
+}
+
+// BlockChanged notifies the change listeners of the chunk of a changed block,
+// removing the tile entity at the position of the block if there was one.
+func (chunk *Chunk) BlockChanged(position blocks.Position) {
+	chunk.RemoveTileEntity(position)
+	chunk.notify(Change{Type: ChangeBlock, Position: position})
+}
+
+// notify calls all change listeners of the chunk with the change.
+func (chunk *Chunk) notify(change Change) {
+	chunk.mutex.RLock()
+	var listeners = chunk.listeners
+	chunk.mutex.RUnlock()
+	for _, listener := range listeners {
+		listener(change)
+	}
+}
@@ -0,0 +1,174 @@
+package levels
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io/ioutil"
+	"math"
+	"math/rand"
+	"strings"
+	"time"
+
+	"github.com/golang/geo/r3"
+)
+
+// StorageVersion is the storage version written in the header of level.dat files.
+const StorageVersion = 8
+
+const (
+	GeneratorLegacy   = 0
+	GeneratorInfinite = 1
+	GeneratorFlat     = 2
+)
+
+// generatorNames are the names of the generators, by their ID.
+var generatorNames = map[int32]string{
+	GeneratorLegacy:   "legacy",
+	GeneratorInfinite: "infinite",
+	GeneratorFlat:     "flat",
+}
+
+// DefaultSpawn is the spawn point of levels without level data.
+var DefaultSpawn = r3.Vector{X: 0, Y: 7, Z: 0}
+
+// GetGeneratorName returns the name of the generator with the given ID,
+// or "unknown" if no generator has the ID.
+func GetGeneratorName(generator int32) string {
+	if name, ok := generatorNames[generator]; ok {
+		return name
+	}
+	return "unknown"
+}
+
+// ParseGenerator returns the ID of the generator with the given name, ignoring case.
+// A bool is returned indicating if a generator has the name.
+func ParseGenerator(name string) (int32, bool) {
+	for generator, generatorName := range generatorNames {
+		if strings.EqualFold(generatorName, name) {
+			return generator, true
+		}
+	}
+	return 0, false
+}
+
+// Data is the metadata of a level, stored in its level.dat file.
+// The file is stored in the format of Bedrock Edition,
+// and tags not known by GoMine are kept when it is saved again.
+type Data struct {
+	Name       string
+	Seed       int64
+	SpawnX     int32
+	SpawnY     int32
+	SpawnZ     int32
+	Time       int64
+	LastPlayed int64
+	Generator  int32
+	// GeneratorOptions are the settings of the generator, such as the layers of flat levels.
+	GeneratorOptions string
+	// BiomeOverride is the only biome generated in the level, if it is not empty.
+	BiomeOverride string
+	// CurrentTick is the age of the level in ticks.
+	CurrentTick int64
+
+	RainTime       int32
+	RainLevel      float32
+	LightningTime  int32
+	LightningLevel float32
+
+	tags map[string]interface{}
+}
+
+// NewData returns new level data for a level with the given name,
+// with a random seed and the spawn point at the default spawn.
+func NewData(name string) *Data {
+	var data = &Data{Name: name, Seed: rand.Int63(), Generator: GeneratorFlat, tags: make(map[string]interface{})}
+	data.SetSpawn(DefaultSpawn)
+	return data
+}
+
+// GetSpawn returns the spawn point of the level as vector.
+func (data *Data) GetSpawn() r3.Vector {
+	return r3.Vector{X: float64(data.SpawnX), Y: float64(data.SpawnY), Z: float64(data.SpawnZ)}
+}
+
+// SetSpawn sets the spawn point of the level to the block the vector is in.
+func (data *Data) SetSpawn(spawn r3.Vector) {
+	data.SpawnX, data.SpawnY, data.SpawnZ = int32(math.Floor(spawn.X)), int32(math.Floor(spawn.Y)), int32(math.Floor(spawn.Z))
+}
+
+// ReadData reads the level data from the level.dat file at the given path.
+func ReadData(path string) (*Data, error) {
+	var file, err = ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if len(file) < 8 {
+		return nil, InvalidLevelData
+	}
+	tags, err := readCompound(bytes.NewReader(file[8:]))
+	if err != nil {
+		return nil, err
+	}
+	var data = &Data{tags: tags}
+	data.Name, _ = tags["LevelName"].(string)
+	data.Seed, _ = tags["RandomSeed"].(int64)
+	data.SpawnX, _ = tags["SpawnX"].(int32)
+	data.SpawnY, _ = tags["SpawnY"].(int32)
+	data.SpawnZ, _ = tags["SpawnZ"].(int32)
+	data.Time, _ = tags["Time"].(int64)
+	data.LastPlayed, _ = tags["LastPlayed"].(int64)
+	data.Generator, _ = tags["Generator"].(int32)
+	data.GeneratorOptions, _ = tags["generatorOptions"].(string)
+	data.BiomeOverride, _ = tags["BiomeOverride"].(string)
+	data.CurrentTick, _ = tags["currentTick"].(int64)
+	data.RainTime, _ = tags["rainTime"].(int32)
+	data.RainLevel, _ = tags["rainLevel"].(float32)
+	data.LightningTime, _ = tags["lightningTime"].(int32)
+	data.LightningLevel, _ = tags["lightningLevel"].(float32)
+	return data, nil
+}
+
+// Write writes the level data to the level.dat file at the given path.
+// The last played time gets set to the current time.
+func (data *Data) Write(path string) error {
+	var file, err = data.encode()
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, file, 0644)
+}
+
+// encode returns the level data encoded as level.dat file.
+// The last played time gets set to the current time.
+func (data *Data) encode() ([]byte, error) {
+	data.LastPlayed = time.Now().Unix()
+	data.tags["LevelName"] = data.Name
+	data.tags["RandomSeed"] = data.Seed
+	data.tags["SpawnX"] = data.SpawnX
+	data.tags["SpawnY"] = data.SpawnY
+	data.tags["SpawnZ"] = data.SpawnZ
+	data.tags["Time"] = data.Time
+	data.tags["LastPlayed"] = data.LastPlayed
+	data.tags["Generator"] = data.Generator
+	data.tags["generatorOptions"] = data.GeneratorOptions
+	if data.BiomeOverride != "" {
+		data.tags["BiomeOverride"] = data.BiomeOverride
+	} else {
+		delete(data.tags, "BiomeOverride")
+	}
+	data.tags["currentTick"] = data.CurrentTick
+	data.tags["rainTime"] = data.RainTime
+	data.tags["rainLevel"] = data.RainLevel
+	data.tags["lightningTime"] = data.LightningTime
+	data.tags["lightningLevel"] = data.LightningLevel
+	data.tags["StorageVersion"] = int32(StorageVersion)
+
+	var buffer = bytes.NewBuffer(nil)
+	if err := writeCompound(buffer, data.tags); err != nil {
+		return nil, err
+	}
+	var header = make([]byte, 8)
+	binary.LittleEndian.PutUint32(header, StorageVersion)
+	binary.LittleEndian.PutUint32(header[4:], uint32(buffer.Len()))
+	return append(header, buffer.Bytes()...), nil
+}
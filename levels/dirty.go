@@ -0,0 +1,159 @@
+package levels
+
+import (
+	"hash/crc32"
+
+	"github.com/irmine/worlds/chunks"
+)
+
+// SubChunkCount is the amount of sub chunks of 16 blocks high in a chunk.
+const SubChunkCount = 16
+
+// Layer is a set of data layers of a chunk that changed.
+type Layer byte
+
+const (
+	// LayerBlocks is set when blocks of a sub chunk changed.
+	LayerBlocks Layer = 1 << iota
+	// LayerBiomes is set when the biomes of a chunk changed.
+	LayerBiomes
+	// LayerTileEntities is set when tile entities in a sub chunk changed.
+	LayerTileEntities
+	// LayerAll holds all layers.
+	LayerAll = LayerBlocks | LayerBiomes | LayerTileEntities
+)
+
+// Dirty holds the parts of a chunk that changed since it was last saved,
+// so that providers storing sub chunks separately only have to write the changed sections.
+type Dirty struct {
+	// SubChunks is a bit mask of the sub chunks that changed, with bit 0 for the lowest sub chunk.
+	SubChunks uint16
+	// Layers holds the data layers that changed.
+	Layers Layer
+	// full is set if the whole chunk has to be saved.
+	full bool
+}
+
+// DirtyAll returns a dirty state marking the whole chunk as changed.
+func DirtyAll() Dirty {
+	return Dirty{SubChunks: 1<<SubChunkCount - 1, Layers: LayerAll, full: true}
+}
+
+// Mark marks the layers of the sub chunk as changed.
+// Layers of the whole chunk column, such as biomes, may be marked with a sub chunk of -1.
+func (dirty *Dirty) Mark(subChunk int, layers Layer) {
+	if subChunk >= 0 && subChunk < SubChunkCount {
+		dirty.SubChunks |= 1 << uint(subChunk)
+	}
+	dirty.Layers |= layers
+}
+
+// Merge marks all parts changed in the other dirty state as changed.
+func (dirty *Dirty) Merge(other Dirty) {
+	dirty.SubChunks |= other.SubChunks
+	dirty.Layers |= other.Layers
+	dirty.full = dirty.full || other.full
+}
+
+// IsFull checks if the whole chunk has to be saved.
+func (dirty Dirty) IsFull() bool {
+	return dirty.full
+}
+
+// IsSubChunkDirty checks if the sub chunk at the index changed.
+func (dirty Dirty) IsSubChunkDirty(subChunk int) bool {
+	return dirty.SubChunks&(1<<uint(subChunk)) != 0
+}
+
+// HasLayer checks if any of the layers changed.
+func (dirty Dirty) HasLayer(layers Layer) bool {
+	return dirty.Layers&layers != 0
+}
+
+// PartialProvider is a provider able to save only the changed parts of a chunk,
+// such as a provider storing every sub chunk under its own key.
+// Chunks of which the whole chunk has to be saved are always saved using Save.
+type PartialProvider interface {
+	Provider
+	// SavePartial saves the parts of the chunk marked as changed in the dirty state.
+	SavePartial(chunk *chunks.Chunk, dirty Dirty)
+}
+
+// saveDirty saves the changed parts of the chunk if the provider supports partial saves,
+// or the whole chunk otherwise.
+func saveDirty(provider Provider, chunk *chunks.Chunk, dirty Dirty) {
+	if partial, ok := provider.(PartialProvider); ok && !dirty.IsFull() {
+		partial.SavePartial(chunk, dirty)
+		return
+	}
+	provider.Save(chunk)
+}
+
+// StoredProvider is a provider able to tell if it stores a chunk,
+// so that chunks loaded from it are not saved again until they change.
+// Chunks loaded from other providers are saved as a whole once, as they may have been generated.
+type StoredProvider interface {
+	Provider
+	// IsStored checks if the chunk at the chunk coordinates is stored by the provider.
+	IsStored(x, z int32) bool
+}
+
+// isStored checks if the provider stores the chunk at the chunk coordinates.
+// Returns false if the provider can not tell if it stores chunks.
+func isStored(provider Provider, x, z int32) bool {
+	if stored, ok := provider.(StoredProvider); ok {
+		return stored.IsStored(x, z)
+	}
+	return false
+}
+
+// chunkHashes are the checksums of the serialized sub chunks of a chunk and of the rest of the chunk column,
+// used to find the parts of a chunk that changed without being marked as changed.
+type chunkHashes struct {
+	subChunks [SubChunkCount]uint32
+	column    uint32
+	count     int
+	// whole is set if the chunk has sub chunks that do not store block IDs, which can not be told apart.
+	// The column checksum then is the checksum of the whole chunk.
+	whole bool
+}
+
+// hashChunk returns the checksums of the parts of the serialized chunk.
+func hashChunk(chunkData []byte) chunkHashes {
+	var hashes chunkHashes
+	var column, ok = newBlockColumn(chunkData)
+	if !ok {
+		hashes.whole = true
+		hashes.column = crc32.ChecksumIEEE(chunkData)
+		return hashes
+	}
+	hashes.count = column.height / 16
+	if hashes.count > SubChunkCount {
+		hashes.count = SubChunkCount
+	}
+	for i := 0; i < hashes.count; i++ {
+		hashes.subChunks[i] = crc32.ChecksumIEEE(chunkData[1+i*subChunkSize : 1+(i+1)*subChunkSize])
+	}
+	hashes.column = crc32.ChecksumIEEE(chunkData[1+hashes.count*subChunkSize:])
+	return hashes
+}
+
+// diff returns the dirty state marking the parts of the chunk of which the checksums differ.
+func (hashes chunkHashes) diff(other chunkHashes) Dirty {
+	if hashes.whole || other.whole {
+		if hashes != other {
+			return DirtyAll()
+		}
+		return Dirty{}
+	}
+	var dirty Dirty
+	for i := 0; i < SubChunkCount; i++ {
+		if hashes.subChunks[i] != other.subChunks[i] || (i < hashes.count) != (i < other.count) {
+			dirty.Mark(i, LayerBlocks)
+		}
+	}
+	if hashes.column != other.column {
+		dirty.Mark(-1, LayerBiomes)
+	}
+	return dirty
+}
@@ -0,0 +1,176 @@
+package levels
+
+import (
+	"encoding/binary"
+	"errors"
+
+	"github.com/df-mc/goleveldb/leveldb"
+	"github.com/df-mc/goleveldb/leveldb/opt"
+	"github.com/irmine/worlds"
+	"github.com/irmine/worlds/chunks"
+)
+
+// Tags of the values of a chunk stored in a LevelDB database, following the chunk coordinates in their key.
+const (
+	tagData2D         = 0x2d
+	tagSubChunk       = 0x2f
+	tagFinalizedState = 0x36
+	tagVersion        = 0x76
+)
+
+const (
+	// levelDBChunkVersion is the version of chunks written to LevelDB databases,
+	// which store their sub chunks with block IDs and data.
+	levelDBChunkVersion = 3
+	// finalizedPopulated is the finalized state of chunks that were fully generated.
+	finalizedPopulated = 2
+)
+
+// UnsupportedChunk gets returned when a chunk stores its blocks in a format other than block IDs and data,
+// which LevelDB databases can not store or read.
+var UnsupportedChunk = errors.New("chunk does not store block IDs")
+
+// LevelDB is a provider storing the chunks of a dimension in a LevelDB database,
+// using the keys and values of Bedrock Edition worlds with every sub chunk stored under its own key.
+// Only sub chunks storing block IDs and data are supported. Tile entities are not stored.
+type LevelDB struct {
+	// ErrorFunction gets called with every error reading or writing chunks. It does nothing by default.
+	// Levels opened by the manager report errors to the error function of its writer.
+	ErrorFunction func(err error)
+
+	db *leveldb.DB
+}
+
+// NewLevelDB opens the LevelDB database in the given directory, creating it if it does not exist.
+func NewLevelDB(path string) (*LevelDB, error) {
+	var db, err = leveldb.OpenFile(path, &opt.Options{Compression: opt.FlateCompression, BlockSize: 16 * 1024})
+	if err != nil {
+		return nil, err
+	}
+	return &LevelDB{ErrorFunction: func(error) {}, db: db}, nil
+}
+
+// Load loads the chunk at the given chunk coordinates,
+// generating it with the generator of the dimension if it was never saved.
+func (provider *LevelDB) Load(dimension *worlds.Dimension, x, z int32, function func(*chunks.Chunk)) {
+	var chunk, err = provider.read(x, z)
+	if err != nil {
+		provider.ErrorFunction(err)
+	}
+	if chunk == nil {
+		chunk = dimension.GetGenerator().GenerateNewChunk(x, z)
+	}
+	function(chunk)
+}
+
+// Save saves all sub chunks, the height map and the biomes of the chunk.
+func (provider *LevelDB) Save(chunk *chunks.Chunk) {
+	provider.SavePartial(chunk, DirtyAll())
+}
+
+// SavePartial saves the sub chunks marked as changed in the dirty state,
+// and the height map and biomes if any blocks or biomes changed.
+func (provider *LevelDB) SavePartial(chunk *chunks.Chunk, dirty Dirty) {
+	var chunkData = chunk.ToBinary()
+	var column, ok = newBlockColumn(chunkData)
+	if !ok {
+		provider.ErrorFunction(UnsupportedChunk)
+		return
+	}
+	var count = column.height / 16
+	var batch = new(leveldb.Batch)
+	batch.Put(levelDBKey(chunk.X, chunk.Z, tagVersion), []byte{levelDBChunkVersion})
+	for i := 0; i < SubChunkCount; i++ {
+		if !dirty.IsSubChunkDirty(i) {
+			continue
+		}
+		if i < count {
+			batch.Put(subChunkKey(chunk.X, chunk.Z, i), chunkData[1+i*subChunkSize:1+(i+1)*subChunkSize])
+		} else {
+			batch.Delete(subChunkKey(chunk.X, chunk.Z, i))
+		}
+	}
+	var offset = 1 + count*subChunkSize
+	if dirty.HasLayer(LayerBlocks|LayerBiomes) && len(chunkData) >= offset+heightMapSize+256 {
+		batch.Put(levelDBKey(chunk.X, chunk.Z, tagData2D), chunkData[offset:offset+heightMapSize+256])
+	}
+	var state = make([]byte, 4)
+	binary.LittleEndian.PutUint32(state, finalizedPopulated)
+	batch.Put(levelDBKey(chunk.X, chunk.Z, tagFinalizedState), state)
+	if err := provider.db.Write(batch, nil); err != nil {
+		provider.ErrorFunction(err)
+	}
+}
+
+// IsStored checks if the chunk at the chunk coordinates was saved in the database.
+func (provider *LevelDB) IsStored(x, z int32) bool {
+	var ok, err = provider.db.Has(levelDBKey(x, z, tagVersion), nil)
+	return ok && err == nil
+}
+
+// Close closes the database. The provider may no longer be used after closing.
+func (provider *LevelDB) Close() {
+	if err := provider.db.Close(); err != nil {
+		provider.ErrorFunction(err)
+	}
+}
+
+// read reads the chunk at the given chunk coordinates from the database.
+// A nil chunk is returned if the chunk was never saved.
+func (provider *LevelDB) read(x, z int32) (*chunks.Chunk, error) {
+	if ok, err := provider.db.Has(levelDBKey(x, z, tagVersion), nil); !ok || err != nil {
+		return nil, err
+	}
+	var chunk = chunks.New(x, z)
+	for i := 0; i < SubChunkCount; i++ {
+		var data, err = provider.db.Get(subChunkKey(x, z, i), nil)
+		if err == leveldb.ErrNotFound {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		// Sub chunks written by Bedrock Edition may hold light after the block data, which is not read.
+		if len(data) < subChunkSize || data[0] != 0 {
+			return nil, UnsupportedChunk
+		}
+		for index := 0; index < subChunkBlocks; index++ {
+			var id, blockData = data[1+index], data[1+subChunkBlocks+index>>1]
+			if index&1 == 0 {
+				blockData &= 0x0f
+			} else {
+				blockData >>= 4
+			}
+			if id == 0 && blockData == 0 {
+				continue
+			}
+			var blockX, blockY, blockZ = index >> 8, i<<4 | index&15, index >> 4 & 15
+			chunk.SetBlockId(blockX, blockY, blockZ, id)
+			chunk.SetBlockData(blockX, blockY, blockZ, blockData)
+		}
+	}
+	var data2D, err = provider.db.Get(levelDBKey(x, z, tagData2D), nil)
+	if err != nil && err != leveldb.ErrNotFound {
+		return nil, err
+	}
+	if len(data2D) >= heightMapSize+256 {
+		for column := 0; column < 256; column++ {
+			chunk.SetBiome(column>>4, column&15, data2D[heightMapSize+column])
+		}
+	}
+	return chunk, nil
+}
+
+// levelDBKey returns the key of the value of the chunk with the given tag.
+func levelDBKey(x, z int32, tag byte) []byte {
+	var key = make([]byte, 9)
+	binary.LittleEndian.PutUint32(key, uint32(x))
+	binary.LittleEndian.PutUint32(key[4:], uint32(z))
+	key[8] = tag
+	return key
+}
+
+// subChunkKey returns the key of the sub chunk of the chunk at the given index, with 0 being the lowest sub chunk.
+func subChunkKey(x, z int32, index int) []byte {
+	return append(levelDBKey(x, z, tagSubChunk), byte(index))
+}
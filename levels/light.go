@@ -0,0 +1,168 @@
+package levels
+
+import (
+	"math"
+
+	"github.com/golang/geo/r3"
+	"github.com/irmine/worlds"
+	"github.com/irmine/worlds/chunks"
+)
+
+// MaxLight is the highest light level, which is the level of sky light and of the brightest light sources.
+const MaxLight = 15
+
+// blockColumn is the block storage of all sub chunks of a serialized chunk storing block IDs.
+type blockColumn struct {
+	data   []byte
+	height int
+}
+
+// newBlockColumn returns the block column of the serialized chunk,
+// and a bool indicating if all sub chunks of the chunk store block IDs.
+func newBlockColumn(chunkData []byte) (*blockColumn, bool) {
+	if len(chunkData) == 0 {
+		return nil, false
+	}
+	var count = int(chunkData[0])
+	if len(chunkData) < 1+count*subChunkSize {
+		return nil, false
+	}
+	for i := 0; i < count; i++ {
+		if chunkData[1+i*subChunkSize] != 0 {
+			return nil, false
+		}
+	}
+	return &blockColumn{data: chunkData, height: count * 16}, true
+}
+
+// getId returns the ID of the block at the position relative to the chunk.
+// Positions above the highest sub chunk hold air.
+func (column *blockColumn) getId(x, y, z int) byte {
+	if y >= column.height {
+		return 0
+	}
+	return column.data[1+(y>>4)*subChunkSize+1+(x<<8|z<<4|y&15)]
+}
+
+// contains checks if the position relative to the chunk is within the chunk.
+func (column *blockColumn) contains(x, y, z int) bool {
+	return x >= 0 && x < 16 && z >= 0 && z < 16 && y >= 0 && y < column.height
+}
+
+// GetBlockId returns the ID of the block at the position relative to the serialized chunk.
+// A bool is returned indicating if the chunk stores block IDs and the position is in the chunk.
+func GetBlockId(chunkData []byte, x, y, z int) (byte, bool) {
+	var column, ok = newBlockColumn(chunkData)
+	if !ok || x < 0 || x > 15 || z < 0 || z > 15 || y < 0 {
+		return 0, false
+	}
+	return column.getId(x, y, z), true
+}
+
+// GetHighestBlock returns the Y of the highest block that is not air
+// in the column at the position relative to the serialized chunk.
+// A bool is returned indicating if the column has any blocks.
+func GetHighestBlock(chunkData []byte, x, z int) (int, bool) {
+	var column, ok = newBlockColumn(chunkData)
+	if !ok || x < 0 || x > 15 || z < 0 || z > 15 {
+		return 0, false
+	}
+	for y := column.height - 1; y >= 0; y-- {
+		if column.getId(x, y, z) != 0 {
+			return y, true
+		}
+	}
+	return 0, false
+}
+
+// GetSkyLight returns the sky light level at the position relative to the serialized chunk.
+// Sky light is at its maximum above all blocks of the column, and decreases by the light filter
+// of every block it passes through going down. Sky light spreading sideways is not taken into account.
+func GetSkyLight(chunkData []byte, x, y, z int) byte {
+	var column, ok = newBlockColumn(chunkData)
+	if !ok || x < 0 || x > 15 || z < 0 || z > 15 {
+		return MaxLight
+	}
+	var light = MaxLight
+	for current := column.height - 1; current >= y && light > 0; current-- {
+		light -= int(GetBlockProperties(column.getId(x, current, z)).LightFilter)
+	}
+	if light < 0 {
+		return 0
+	}
+	return byte(light)
+}
+
+// GetBlockLight returns the level of light emitted by blocks at the position relative to the serialized chunk.
+// Light decreases by one level for every block it spreads, or by the light filter of the block if higher,
+// and does not pass through opaque blocks. Only light sources within the same chunk are taken into account.
+func GetBlockLight(chunkData []byte, x, y, z int) byte {
+	var column, ok = newBlockColumn(chunkData)
+	if !ok || !column.contains(x, y, z) {
+		return 0
+	}
+	var properties = GetBlockProperties(column.getId(x, y, z))
+	var light = int(properties.LightEmission)
+	if properties.LightFilter >= MaxLight {
+		return byte(light)
+	}
+
+	// Search outwards from the position for the light sources reaching it, cheapest paths first.
+	// The distance of a block is the amount of light levels lost spreading from it to the position.
+	type position struct{ x, y, z int }
+	var distances = map[position]int{{x, y, z}: 0}
+	var queue [MaxLight][]position
+	queue[0] = []position{{x, y, z}}
+	for distance := 0; distance < MaxLight && distance < MaxLight-light; distance++ {
+		for i := 0; i < len(queue[distance]); i++ {
+			var current = queue[distance][i]
+			if distances[current] != distance {
+				continue
+			}
+			var filter = int(GetBlockProperties(column.getId(current.x, current.y, current.z)).LightFilter)
+			if filter >= MaxLight {
+				continue
+			}
+			var next = distance + 1
+			if filter > 1 {
+				next = distance + filter
+			}
+			for _, side := range [6]position{{1, 0, 0}, {-1, 0, 0}, {0, 1, 0}, {0, -1, 0}, {0, 0, 1}, {0, 0, -1}} {
+				var neighbour = position{current.x + side.x, current.y + side.y, current.z + side.z}
+				if !column.contains(neighbour.x, neighbour.y, neighbour.z) {
+					continue
+				}
+				if known, ok := distances[neighbour]; ok && known <= next {
+					continue
+				}
+				var emission = int(GetBlockProperties(column.getId(neighbour.x, neighbour.y, neighbour.z)).LightEmission)
+				if emission-next > light {
+					light = emission - next
+				}
+				if next < MaxLight {
+					distances[neighbour] = next
+					queue[next] = append(queue[next], neighbour)
+				}
+			}
+		}
+	}
+	return byte(light)
+}
+
+// GetLight loads the chunk at the position in the dimension, and calls the function
+// with the block light and sky light levels at the position.
+func (manager *Manager) GetLight(worldsDimension *worlds.Dimension, position r3.Vector, function func(blockLight, skyLight byte)) {
+	var x, y, z = int32(math.Floor(position.X)), int(math.Floor(position.Y)), int32(math.Floor(position.Z))
+	worldsDimension.LoadChunk(x>>4, z>>4, func(chunk *chunks.Chunk) {
+		var chunkData = chunk.ToBinary()
+		function(GetBlockLight(chunkData, int(x&15), y, int(z&15)), GetSkyLight(chunkData, int(x&15), y, int(z&15)))
+	})
+}
+
+// GetHighestBlockAt loads the chunk of the column at the position in the dimension, and calls the function
+// with the Y of the highest block that is not air in the column, and a bool indicating if the column has any blocks.
+func (manager *Manager) GetHighestBlockAt(worldsDimension *worlds.Dimension, x, z int32, function func(y int, ok bool)) {
+	worldsDimension.LoadChunk(x>>4, z>>4, func(chunk *chunks.Chunk) {
+		function(GetHighestBlock(chunk.ToBinary(), int(x&15), int(z&15)))
+	})
+}
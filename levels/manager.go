@@ -0,0 +1,509 @@
+package levels
+
+import (
+	"compress/zlib"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/golang/geo/r3"
+	"github.com/irmine/worlds"
+	"github.com/irmine/worlds/blocks"
+	"github.com/irmine/worlds/chunks"
+)
+
+// UnknownLevel gets returned when a level
+// with a given name has not been opened.
+var UnknownLevel = errors.New("unknown level")
+
+// level is a level opened by the manager.
+type level struct {
+	level   *worlds.Level
+	data    *Data
+	format  string
+	world   *World
+	created bool
+}
+
+// dimension is a dimension of which the chunks get saved by the manager.
+type dimension struct {
+	level    *level
+	provider *AsyncProvider
+	dirty    map[*chunks.Chunk]Dirty
+}
+
+// chunkKey is the key of a chunk of a dimension.
+type chunkKey struct {
+	dimension *worlds.Dimension
+	x, z      int32
+}
+
+// Manager manages the saving of levels.
+// It keeps the level data of every level, provides chunk providers
+// for their dimensions and saves changed chunks on an interval.
+// All chunks and level data are written by a single writer goroutine, so that saving never stalls the tick.
+type Manager struct {
+	// Interval is the interval at which levels get saved automatically.
+	// An interval of 0 or lower disables autosaving.
+	Interval time.Duration
+	// SaveFunction gets called before levels get saved,
+	// so that loaded chunks can be marked as changed.
+	SaveFunction func()
+	// TimeInterval is the amount of ticks between sending the time of levels.
+	TimeInterval int64
+	// TimeFunction gets called with the time of a level when it is set,
+	// and every time interval while the time progresses.
+	TimeFunction func(levelName string, time int64)
+	// WeatherFunction gets called when the weather of a level changes.
+	WeatherFunction func(levelName string, weather Weather)
+	// GameRuleFunction gets called when a game rule of a level is set.
+	GameRuleFunction func(levelName string, name string, value interface{})
+	// CompressionLevel is the zlib compression level all region files get recompressed with
+	// once the manager closes. Region files are left as written by their provider
+	// if it is zlib.DefaultCompression.
+	CompressionLevel int
+	// DefaultGenerator is the ID of the generator stored in the level data of newly created levels.
+	DefaultGenerator int32
+	// DefaultPreset is the name of the generator preset of newly created levels with the infinite generator.
+	DefaultPreset string
+	// DefaultBiome is the biome generated in newly created levels if the default preset generates a single biome.
+	DefaultBiome string
+	// ChunkCache is the cache of decoded chunks shared by all dimensions.
+	// It must be set before dimensions are added.
+	ChunkCache *ChunkCache
+
+	mutex      sync.Mutex
+	serverPath string
+	path       string
+	levels     map[string]*level
+	dimensions map[*worlds.Dimension]*dimension
+	chunks     map[chunkKey]*Chunk
+	lastSave   time.Time
+	writer     *Writer
+}
+
+// NewManager returns a new level save manager,
+// storing levels in the worlds directory in the server path.
+func NewManager(serverPath string) *Manager {
+	return &Manager{
+		Interval:         time.Minute * 5,
+		SaveFunction:     func() {},
+		TimeInterval:     200,
+		TimeFunction:     func(string, int64) {},
+		WeatherFunction:  func(string, Weather) {},
+		GameRuleFunction: func(string, string, interface{}) {},
+		CompressionLevel: zlib.DefaultCompression,
+		DefaultGenerator: GeneratorFlat,
+		DefaultPreset:    PresetDefault,
+		ChunkCache:       NewChunkCache(DefaultChunkCacheSize),
+		serverPath:       serverPath,
+		path:             serverPath + "worlds/",
+		levels:           make(map[string]*level),
+		dimensions:       make(map[*worlds.Dimension]*dimension),
+		chunks:           make(map[chunkKey]*Chunk),
+		lastSave:         time.Now(),
+		writer:           NewWriter(WriteQueueSize),
+	}
+}
+
+// GetWriter returns the writer performing all disk writes of the manager.
+func (manager *Manager) GetWriter() *Writer {
+	return manager.writer
+}
+
+// GetPath returns the directory of the level with the given name.
+func (manager *Manager) GetPath(levelName string) string {
+	return manager.path + levelName + "/"
+}
+
+// Open opens a level, storing its chunks in the given format.
+// The level data gets read from the level.dat file of the level,
+// or gets created if the level does not yet have one.
+func (manager *Manager) Open(worldsLevel *worlds.Level, format string) (*Data, error) {
+	if !IsFormatRegistered(format) {
+		return nil, UnknownFormat
+	}
+	var path = manager.GetPath(worldsLevel.GetName())
+	os.MkdirAll(path, 0700)
+
+	var data, err = ReadData(path + "level.dat")
+	var created = os.IsNotExist(err)
+	if created {
+		data = NewData(worldsLevel.GetName())
+		data.Generator = manager.DefaultGenerator
+		if preset, ok := GetPreset(manager.DefaultPreset); ok && data.Generator == GeneratorInfinite {
+			data.SetPreset(preset, manager.DefaultBiome)
+		}
+		err = data.Write(path + "level.dat")
+	}
+	if err != nil {
+		return nil, err
+	}
+	manager.mutex.Lock()
+	manager.levels[worldsLevel.GetName()] = &level{worldsLevel, data, format, newWorld(manager, worldsLevel.GetName(), data), created}
+	manager.mutex.Unlock()
+	return data, nil
+}
+
+// GetData returns the level data of the opened level with the given name.
+// A bool is returned indicating if the level was opened.
+func (manager *Manager) GetData(levelName string) (*Data, bool) {
+	manager.mutex.Lock()
+	defer manager.mutex.Unlock()
+	var level, ok = manager.levels[levelName]
+	if !ok {
+		return nil, false
+	}
+	return level.data, true
+}
+
+// GetWorld returns the world of the opened level with the given name,
+// which holds the time, weather and game rules of the level.
+// A bool is returned indicating if the level was opened.
+func (manager *Manager) GetWorld(levelName string) (*World, bool) {
+	manager.mutex.Lock()
+	defer manager.mutex.Unlock()
+	var level, ok = manager.levels[levelName]
+	if !ok {
+		return nil, false
+	}
+	return level.world, true
+}
+
+// IsCreated checks if the level data of the opened level with the given name
+// was created when it was opened, rather than read from an existing level.dat file.
+func (manager *Manager) IsCreated(levelName string) bool {
+	manager.mutex.Lock()
+	defer manager.mutex.Unlock()
+	var level, ok = manager.levels[levelName]
+	return ok && level.created
+}
+
+// GetSpawn returns the spawn point of the opened level with the given name,
+// or the default spawn if the level was not opened.
+func (manager *Manager) GetSpawn(levelName string) r3.Vector {
+	if world, ok := manager.GetWorld(levelName); ok {
+		return world.GetSpawn()
+	}
+	return DefaultSpawn
+}
+
+// AddDimension sets the chunk provider of a dimension of an opened level,
+// storing its chunks in a directory with the name of the dimension.
+func (manager *Manager) AddDimension(worldsDimension *worlds.Dimension, name string) error {
+	var levelName = worldsDimension.GetLevel().GetName()
+	manager.mutex.Lock()
+	defer manager.mutex.Unlock()
+	var level, ok = manager.levels[levelName]
+	if !ok {
+		return UnknownLevel
+	}
+	var path = manager.GetPath(levelName) + name + "/"
+	os.MkdirAll(path, 0700)
+	var provider, err = NewProvider(level.format, path)
+	if err != nil {
+		return err
+	}
+	if db, ok := provider.(*LevelDB); ok {
+		db.ErrorFunction = func(err error) {
+			manager.writer.ErrorFunction(err)
+		}
+	}
+	var async = NewAsyncProvider(NewCachedProvider(provider, manager.ChunkCache, worldsDimension), manager.writer)
+	async.LoadFunction = func(chunk *chunks.Chunk) {
+		manager.chunkLoaded(worldsDimension, provider, chunk)
+	}
+	manager.dimensions[worldsDimension] = &dimension{level, async, make(map[*chunks.Chunk]Dirty)}
+	worldsDimension.SetChunkProvider(async)
+	return nil
+}
+
+// MarkDirty marks the whole chunk of a dimension as changed,
+// so that it gets saved on the next save.
+func (manager *Manager) MarkDirty(worldsDimension *worlds.Dimension, chunk *chunks.Chunk) {
+	manager.markDirty(worldsDimension, chunk, DirtyAll())
+}
+
+// MarkDirtyLayers marks the layers of a sub chunk of a chunk of a dimension as changed,
+// so that only the changed parts get saved on the next save by providers supporting partial saves.
+// Layers of the whole chunk column, such as biomes, may be marked with a sub chunk of -1.
+func (manager *Manager) MarkDirtyLayers(worldsDimension *worlds.Dimension, chunk *chunks.Chunk, subChunk int, layers Layer) {
+	var dirty Dirty
+	dirty.Mark(subChunk, layers)
+	manager.markDirty(worldsDimension, chunk, dirty)
+}
+
+// MarkLoaded marks the parts of a loaded chunk of a dimension that changed since it was loaded or last marked,
+// so that changes that were not marked as changed, such as blocks set by plugins, get saved.
+// Changes are found by comparing checksums of the sub chunks, so the chunk is only saved again once it changes.
+// The whole chunk is marked as changed if it was neither stored by its provider nor saved by the manager,
+// so that generated chunks get saved.
+func (manager *Manager) MarkLoaded(worldsDimension *worlds.Dimension, chunk *chunks.Chunk) {
+	var hashes = hashChunk(chunk.ToBinary())
+	manager.mutex.Lock()
+	defer manager.mutex.Unlock()
+	var dimension, ok = manager.dimensions[worldsDimension]
+	if !ok {
+		return
+	}
+	var c = manager.getChunk(worldsDimension, chunk)
+	var changed Dirty
+	if !c.stored {
+		changed = DirtyAll()
+	} else if c.hashed {
+		changed = c.hashes.diff(hashes)
+	}
+	c.hashes, c.hashed = hashes, true
+	if changed == (Dirty{}) {
+		return
+	}
+	var dirty = dimension.dirty[chunk]
+	dirty.Merge(changed)
+	dimension.dirty[chunk] = dirty
+}
+
+// chunkLoaded takes the checksums of a chunk of a dimension that was just loaded using the provider,
+// and marks the chunk as stored if the provider stores it.
+func (manager *Manager) chunkLoaded(worldsDimension *worlds.Dimension, provider Provider, chunk *chunks.Chunk) {
+	var hashes = hashChunk(chunk.ToBinary())
+	var stored = isStored(provider, chunk.X, chunk.Z)
+	manager.mutex.Lock()
+	var c = manager.getChunk(worldsDimension, chunk)
+	c.hashes, c.hashed = hashes, true
+	c.stored = c.stored || stored
+	manager.mutex.Unlock()
+}
+
+// markDirty merges the dirty state into the dirty state of the chunk of the dimension.
+func (manager *Manager) markDirty(worldsDimension *worlds.Dimension, chunk *chunks.Chunk, dirty Dirty) {
+	manager.mutex.Lock()
+	if dimension, ok := manager.dimensions[worldsDimension]; ok {
+		var current = dimension.dirty[chunk]
+		current.Merge(dirty)
+		dimension.dirty[chunk] = current
+	}
+	manager.mutex.Unlock()
+}
+
+// GetChunk returns the given chunk of the dimension along with its tile entities.
+// The same chunk is returned for the chunk coordinates until the dimension is closed,
+// even if the chunk got unloaded and loaded again in the meantime.
+// Changes of tile entities in the chunk mark the chunk as changed.
+func (manager *Manager) GetChunk(worldsDimension *worlds.Dimension, chunk *chunks.Chunk) *Chunk {
+	manager.mutex.Lock()
+	defer manager.mutex.Unlock()
+	return manager.getChunk(worldsDimension, chunk)
+}
+
+// getChunk returns the given chunk of the dimension, creating it if it did not yet exist.
+// The mutex of the manager must be locked.
+func (manager *Manager) getChunk(worldsDimension *worlds.Dimension, chunk *chunks.Chunk) *Chunk {
+	var key = chunkKey{worldsDimension, chunk.X, chunk.Z}
+	var c, ok = manager.chunks[key]
+	if !ok {
+		c = NewChunk(chunk)
+		c.AddChangeListener(func(change Change) {
+			if change.Type == ChangeTileEntity || change.Type == ChangeTileEntityRemoved {
+				manager.MarkDirtyLayers(worldsDimension, c.Chunk, int(change.Position.Y>>4), LayerTileEntities)
+			}
+		})
+		manager.chunks[key] = c
+	}
+	c.Chunk = chunk
+	return c
+}
+
+// GetTileEntityAt returns the tile entity at the given position in the dimension.
+// A bool is returned indicating if a tile entity was found.
+func (manager *Manager) GetTileEntityAt(worldsDimension *worlds.Dimension, position blocks.Position) (TileEntity, bool) {
+	manager.mutex.Lock()
+	var chunk, ok = manager.chunks[chunkKey{worldsDimension, position.X >> 4, position.Z >> 4}]
+	manager.mutex.Unlock()
+	if !ok {
+		return nil, false
+	}
+	return chunk.GetTileEntity(position)
+}
+
+// GetSignAt returns the sign at the given position in the dimension.
+// A bool is returned indicating if a sign was found.
+func (manager *Manager) GetSignAt(worldsDimension *worlds.Dimension, position blocks.Position) (*Sign, bool) {
+	var tileEntity, ok = manager.GetTileEntityAt(worldsDimension, position)
+	if !ok {
+		return nil, false
+	}
+	sign, ok := tileEntity.(*Sign)
+	return sign, ok
+}
+
+// BlockChanged marks the blocks of the sub chunk holding the position as changed after the block
+// at the given position changed, and notifies the change listeners of the chunk.
+func (manager *Manager) BlockChanged(worldsDimension *worlds.Dimension, chunk *chunks.Chunk, position blocks.Position) {
+	manager.MarkDirtyLayers(worldsDimension, chunk, int(position.Y>>4), LayerBlocks)
+	manager.GetChunk(worldsDimension, chunk).BlockChanged(position)
+}
+
+// Save queues all changed chunks and the level data of all opened levels to be written,
+// blocking while the write queue is full. Errors of the writes are passed to the error function of the writer.
+func (manager *Manager) Save() error {
+	return manager.save(true)
+}
+
+// save queues all changed chunks and the level data of all opened levels to be written.
+// If wait is false, writes that do not fit in the write queue are skipped,
+// and their chunks stay marked as changed until the next save.
+func (manager *Manager) save(wait bool) error {
+	manager.SaveFunction()
+
+	manager.mutex.Lock()
+	defer manager.mutex.Unlock()
+	manager.lastSave = time.Now()
+	for worldsDimension, dimension := range manager.dimensions {
+		for chunk, dirty := range dimension.dirty {
+			if wait {
+				dimension.provider.SavePartial(chunk, dirty)
+			} else if !dimension.provider.TrySave(chunk, dirty) {
+				continue
+			}
+			delete(dimension.dirty, chunk)
+			if c, ok := manager.chunks[chunkKey{worldsDimension, chunk.X, chunk.Z}]; ok {
+				c.stored = true
+			}
+		}
+	}
+	var err error
+	for name, level := range manager.levels {
+		level.world.store(level.data)
+		var file, encodeErr = level.data.encode()
+		if encodeErr != nil {
+			err = encodeErr
+			continue
+		}
+		var path = manager.GetPath(name) + "level.dat"
+		var write = func() error {
+			return ioutil.WriteFile(path, file, 0644)
+		}
+		if wait {
+			manager.writer.Write(write)
+		} else {
+			manager.writer.TryWrite(write)
+		}
+	}
+	return err
+}
+
+// Close saves all levels, waits until all chunks and level data have been written and closes all providers.
+func (manager *Manager) Close() error {
+	var err = manager.Save()
+	manager.mutex.Lock()
+	for worldsDimension, dimension := range manager.dimensions {
+		dimension.provider.Close()
+		delete(manager.dimensions, worldsDimension)
+	}
+	for key := range manager.chunks {
+		delete(manager.chunks, key)
+	}
+	manager.mutex.Unlock()
+	manager.writer.Flush()
+	if manager.CompressionLevel == zlib.DefaultCompression {
+		return err
+	}
+	manager.mutex.Lock()
+	var levelNames = make([]string, 0, len(manager.levels))
+	for name := range manager.levels {
+		levelNames = append(levelNames, name)
+	}
+	manager.mutex.Unlock()
+	for _, name := range levelNames {
+		if _, recompressErr := manager.Recompress(name); recompressErr != nil {
+			err = recompressErr
+		}
+	}
+	return err
+}
+
+// CloseLevel closes the opened level with the given name and the providers of its dimensions without saving it,
+// so that the directory of the level may be replaced or removed afterwards. Changes that were not saved are discarded.
+// UnknownLevel is returned if the level was not opened. The dimensions of the level may no longer be used after closing.
+func (manager *Manager) CloseLevel(levelName string) error {
+	manager.mutex.Lock()
+	if _, ok := manager.levels[levelName]; !ok {
+		manager.mutex.Unlock()
+		return UnknownLevel
+	}
+	delete(manager.levels, levelName)
+	var providers []*AsyncProvider
+	for worldsDimension, dimension := range manager.dimensions {
+		if worldsDimension.GetLevel().GetName() == levelName {
+			providers = append(providers, dimension.provider)
+			delete(manager.dimensions, worldsDimension)
+		}
+	}
+	for key := range manager.chunks {
+		if key.dimension.GetLevel().GetName() == levelName {
+			delete(manager.chunks, key)
+		}
+	}
+	manager.mutex.Unlock()
+
+	// Closing waits for the chunks queued before, so nothing gets written to the directory of the level afterwards.
+	for _, provider := range providers {
+		provider.Close()
+	}
+	return nil
+}
+
+// Recompress recompresses all region files of the level with the given name with the compression level
+// of the manager. The amount of bytes saved gets returned.
+// Levels may only be recompressed while none of their dimensions have a provider, for example after closing.
+func (manager *Manager) Recompress(levelName string) (int64, error) {
+	var saved int64
+	var err = filepath.Walk(manager.GetPath(levelName), func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() || !isRegionFile(path) {
+			return err
+		}
+		var regionSaved, recompressErr = RecompressRegion(path, manager.CompressionLevel)
+		saved += regionSaved
+		return recompressErr
+	})
+	return saved, err
+}
+
+// GetStorageStats returns the sizes and chunk counts of all files stored on disk by the opened level
+// with the given name. UnknownLevel gets returned if the level was not opened.
+func (manager *Manager) GetStorageStats(levelName string) (LevelStats, error) {
+	manager.mutex.Lock()
+	var _, ok = manager.levels[levelName]
+	manager.mutex.Unlock()
+	if !ok {
+		return LevelStats{}, UnknownLevel
+	}
+	return ReadStorageStats(manager.GetPath(levelName))
+}
+
+// Tick progresses the time and weather of all levels,
+// and saves all levels once the autosave interval has passed.
+// Autosaving is postponed while the writer is busy, and never blocks on the write queue.
+// Internal. Not to be used by plugins.
+func (manager *Manager) Tick() error {
+	manager.mutex.Lock()
+	var levelWorlds = make([]*World, 0, len(manager.levels))
+	for _, level := range manager.levels {
+		levelWorlds = append(levelWorlds, level.world)
+	}
+	manager.mutex.Unlock()
+	for _, world := range levelWorlds {
+		world.tick()
+	}
+
+	manager.mutex.Lock()
+	var save = manager.Interval > 0 && time.Since(manager.lastSave) >= manager.Interval
+	manager.mutex.Unlock()
+	if !save || manager.writer.IsBusy() {
+		return nil
+	}
+	return manager.save(false)
+}
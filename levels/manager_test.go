@@ -0,0 +1,825 @@
+package levels
+
+import (
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"context"
+	"encoding/binary"
+	"io/ioutil"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang/geo/r3"
+	"github.com/irmine/gomine/tags"
+	"github.com/irmine/worlds"
+	"github.com/irmine/worlds/blocks"
+	"github.com/irmine/worlds/chunks"
+)
+
+type memoryProvider struct {
+	mutex sync.Mutex
+	saved []*chunks.Chunk
+}
+
+func (provider *memoryProvider) Load(dimension *worlds.Dimension, x, z int32, function func(*chunks.Chunk)) {
+	function(chunks.New(x, z))
+}
+
+func (provider *memoryProvider) Save(chunk *chunks.Chunk) {
+	provider.mutex.Lock()
+	provider.saved = append(provider.saved, chunk)
+	provider.mutex.Unlock()
+}
+
+func (provider *memoryProvider) Close() {}
+
+func TestDataRoundTrip(t *testing.T) {
+	var dir, err = ioutil.TempDir("", "levels")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	var data = NewData("world")
+	data.SpawnX, data.SpawnZ, data.Time = 16, -32, 6000
+	data.GeneratorOptions, data.CurrentTick = "{}", 120000
+	data.tags["GameRules"] = map[string]interface{}{"doDaylightCycle": int8(1), "names": list{tagString, []interface{}{"a", "b"}}}
+	if err := data.Write(dir + "/level.dat"); err != nil {
+		t.Fatal(err)
+	}
+	read, err := ReadData(dir + "/level.dat")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if read.Name != "world" || read.Seed != data.Seed || read.SpawnX != 16 || read.SpawnY != 7 || read.SpawnZ != -32 || read.Time != 6000 ||
+		read.GeneratorOptions != "{}" || read.CurrentTick != 120000 {
+		t.Error("level data was not read back:", read)
+	}
+	if rules, ok := read.tags["GameRules"].(map[string]interface{}); !ok || rules["doDaylightCycle"] != int8(1) || len(rules["names"].(list).values) != 2 {
+		t.Error("unknown tags were not kept:", read.tags["GameRules"])
+	}
+}
+
+func TestSave(t *testing.T) {
+	var dir, err = ioutil.TempDir("", "levels")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	var provider = &memoryProvider{}
+	RegisterFormat("memory", func(path string) Provider {
+		return provider
+	})
+	var manager = NewManager(dir + "/")
+	var level = worlds.NewLevel("world", dir+"/")
+	if _, err := manager.Open(level, "unknown"); err != UnknownFormat {
+		t.Error("expected unknown format error, got:", err)
+	}
+	if _, err := manager.Open(level, "memory"); err != nil {
+		t.Fatal(err)
+	}
+	var dimension = worlds.NewDimension("overworld", level, worlds.OverworldId)
+	if err := manager.AddDimension(dimension, "overworld"); err != nil {
+		t.Fatal(err)
+	}
+	var chunk = chunks.New(0, 0)
+	manager.MarkDirty(dimension, chunk)
+	manager.MarkDirty(dimension, chunk)
+	if err := manager.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if len(provider.saved) != 1 || provider.saved[0] != chunk {
+		t.Error("dirty chunk was not saved once:", provider.saved)
+	}
+	if _, err := os.Stat(dir + "/worlds/world/level.dat"); err != nil {
+		t.Error("level data was not written:", err)
+	}
+}
+
+type partialProvider struct {
+	memoryProvider
+	partial []Dirty
+}
+
+func (provider *partialProvider) SavePartial(chunk *chunks.Chunk, dirty Dirty) {
+	provider.mutex.Lock()
+	provider.partial = append(provider.partial, dirty)
+	provider.mutex.Unlock()
+}
+
+func TestPartialSave(t *testing.T) {
+	var dir, err = ioutil.TempDir("", "levels")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	var provider = &partialProvider{}
+	RegisterFormat("partial", func(path string) Provider {
+		return provider
+	})
+	var manager = NewManager(dir + "/")
+	var level = worlds.NewLevel("world", dir+"/")
+	if _, err := manager.Open(level, "partial"); err != nil {
+		t.Fatal(err)
+	}
+	var dimension = worlds.NewDimension("overworld", level, worlds.OverworldId)
+	if err := manager.AddDimension(dimension, "overworld"); err != nil {
+		t.Fatal(err)
+	}
+	var chunk = chunks.New(0, 0)
+	manager.MarkLoaded(dimension, chunk)
+	manager.Save()
+	manager.GetWriter().Flush()
+	manager.MarkLoaded(dimension, chunk)
+	manager.BlockChanged(dimension, chunk, blocks.NewPosition(1, 40, 1))
+	manager.GetChunk(dimension, chunk).SetTileEntity(NewSign(blocks.NewPosition(1, 70, 1)))
+	manager.Save()
+	manager.GetWriter().Flush()
+
+	if len(provider.saved) != 1 || provider.saved[0] != chunk {
+		t.Error("generated chunk was not saved fully once:", provider.saved)
+	}
+	if len(provider.partial) != 1 {
+		t.Fatal("changed chunk was not saved partially:", provider.partial)
+	}
+	var dirty = provider.partial[0]
+	if !dirty.IsSubChunkDirty(2) || !dirty.IsSubChunkDirty(4) || dirty.IsSubChunkDirty(0) || dirty.IsFull() {
+		t.Error("unexpected dirty sub chunks:", dirty.SubChunks)
+	}
+	if !dirty.HasLayer(LayerBlocks) || !dirty.HasLayer(LayerTileEntities) || dirty.HasLayer(LayerBiomes) {
+		t.Error("unexpected dirty layers:", dirty.Layers)
+	}
+
+	// Blocks set without marking the chunk as changed, like blocks set by plugins, are found by their checksums.
+	chunk.SetBlockId(1, 20, 1, 1)
+	manager.MarkLoaded(dimension, chunk)
+	manager.Save()
+	manager.GetWriter().Flush()
+	if len(provider.partial) != 2 || !provider.partial[1].IsSubChunkDirty(1) || provider.partial[1].IsSubChunkDirty(2) {
+		t.Fatal("block set without marking the chunk was not saved:", provider.partial)
+	}
+	manager.MarkLoaded(dimension, chunk)
+	manager.Save()
+	manager.GetWriter().Flush()
+	if len(provider.partial) != 2 || len(provider.saved) != 1 {
+		t.Error("unchanged chunk was saved again")
+	}
+}
+
+type storedProvider struct {
+	memoryProvider
+}
+
+func (provider *storedProvider) IsStored(x, z int32) bool {
+	return x == 0 && z == 0
+}
+
+func TestStoredChunks(t *testing.T) {
+	var dir, err = ioutil.TempDir("", "levels")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	var provider = &storedProvider{}
+	var manager = NewManager(dir + "/")
+	var level = worlds.NewLevel("world", dir+"/")
+	var worldsDimension = worlds.NewDimension("overworld", level, worlds.OverworldId)
+	manager.dimensions[worldsDimension] = &dimension{dirty: make(map[*chunks.Chunk]Dirty)}
+
+	var stored, generated = chunks.New(0, 0), chunks.New(1, 0)
+	manager.chunkLoaded(worldsDimension, provider, stored)
+	manager.chunkLoaded(worldsDimension, provider, generated)
+	manager.MarkLoaded(worldsDimension, stored)
+	manager.MarkLoaded(worldsDimension, generated)
+	if _, ok := manager.dimensions[worldsDimension].dirty[stored]; ok {
+		t.Error("chunk loaded from the provider was marked as changed")
+	}
+	if dirty, ok := manager.dimensions[worldsDimension].dirty[generated]; !ok || !dirty.IsFull() {
+		t.Error("generated chunk was not marked as changed as a whole")
+	}
+}
+
+func TestCloseLevel(t *testing.T) {
+	var dir, err = ioutil.TempDir("", "levels")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	var provider = &partialProvider{}
+	RegisterFormat("closing", func(path string) Provider {
+		return provider
+	})
+	var manager = NewManager(dir + "/")
+	var level = worlds.NewLevel("arena", dir+"/")
+	if _, err := manager.Open(level, "closing"); err != nil {
+		t.Fatal(err)
+	}
+	var worldsDimension = worlds.NewDimension("overworld", level, worlds.OverworldId)
+	if err := manager.AddDimension(worldsDimension, "overworld"); err != nil {
+		t.Fatal(err)
+	}
+	var chunk = chunks.New(0, 0)
+	manager.MarkLoaded(worldsDimension, chunk)
+	if err := manager.CloseLevel("arena"); err != nil {
+		t.Fatal(err)
+	}
+	manager.Save()
+	manager.GetWriter().Flush()
+	if len(provider.saved) != 0 || len(provider.partial) != 0 {
+		t.Error("changes of the closed level were saved")
+	}
+	if _, ok := manager.GetData("arena"); ok {
+		t.Error("closed level is still opened")
+	}
+	if err := manager.CloseLevel("arena"); err != UnknownLevel {
+		t.Error("closing a closed level did not return UnknownLevel:", err)
+	}
+}
+
+func TestChunkTileEntities(t *testing.T) {
+	var manager = NewManager(os.TempDir() + "/")
+	var level = worlds.NewLevel("world", os.TempDir()+"/")
+	var dimension = worlds.NewDimension("overworld", level, worlds.OverworldId)
+	var chunk = manager.GetChunk(dimension, chunks.New(1, -1))
+
+	var changes []Change
+	chunk.AddChangeListener(func(change Change) {
+		changes = append(changes, change)
+	})
+	var sign = NewSign(blocks.NewPosition(20, 64, -5))
+	sign.Lines[0] = "[Shop]"
+	chunk.SetTileEntity(sign)
+
+	if found, ok := manager.GetSignAt(dimension, sign.Position); !ok || found.Lines[0] != "[Shop]" {
+		t.Error("sign was not found in the chunk:", found)
+	}
+	if manager.GetChunk(dimension, chunks.New(1, -1)) != chunk {
+		t.Error("reloaded chunk did not keep its tile entities")
+	}
+	var count int
+	chunk.ForEachSign(func(*Sign) bool {
+		count++
+		return true
+	})
+	if count != 1 {
+		t.Error("expected one sign in the chunk, got:", count)
+	}
+
+	manager.BlockChanged(dimension, chunk.Chunk, sign.Position)
+	if _, ok := manager.GetTileEntityAt(dimension, sign.Position); ok {
+		t.Error("tile entity was not removed after its block changed")
+	}
+	if len(changes) != 3 || changes[0].Type != ChangeTileEntity || changes[1].Type != ChangeTileEntityRemoved || changes[2].Type != ChangeBlock {
+		t.Error("unexpected chunk changes:", changes)
+	}
+}
+
+func TestWorld(t *testing.T) {
+	var manager = NewManager(os.TempDir() + "/")
+	manager.TimeInterval = 2
+	var times []int64
+	var weathers []Weather
+	manager.TimeFunction = func(levelName string, time int64) {
+		times = append(times, time)
+	}
+	manager.WeatherFunction = func(levelName string, weather Weather) {
+		weathers = append(weathers, weather)
+	}
+	var data = NewData("world")
+	data.Time = 1000
+	var world = newWorld(manager, "world", data)
+
+	world.tick()
+	world.tick()
+	if world.GetTime() != 1002 || len(times) != 1 || times[0] != 1002 {
+		t.Error("time was not progressed and sent every interval:", world.GetTime(), times)
+	}
+	if err := world.SetGameRule(GameRuleDaylightCycle, false); err != nil {
+		t.Fatal(err)
+	}
+	world.tick()
+	if world.GetTime() != 1002 {
+		t.Error("time progressed with the daylight cycle disabled")
+	}
+	if err := world.SetGameRule("naturalregeneration", 1); err != InvalidGameRule {
+		t.Error("game rule with an invalid value was set:", err)
+	}
+
+	world.SetWeather(WeatherRain, 1)
+	world.tick()
+	if world.GetWeather() != WeatherClear || len(weathers) != 2 || weathers[1] != WeatherClear {
+		t.Error("weather did not change once its duration passed:", weathers)
+	}
+	if world.GetAge() != 4 {
+		t.Error("age did not progress every tick:", world.GetAge())
+	}
+	world.SetSpawn(r3.Vector{X: 8.5, Y: 70, Z: -3.5})
+	world.SetSeed(42)
+	world.SetGenerator(GeneratorInfinite, "")
+	world.store(data)
+	if data.Time != 1002 || data.RainLevel != 0 || data.RainTime <= 0 {
+		t.Error("time and weather were not stored:", data)
+	}
+	if data.Seed != 42 || data.Generator != GeneratorInfinite || data.CurrentTick != 4 || data.SpawnX != 8 || data.SpawnY != 70 || data.SpawnZ != -4 {
+		t.Error("metadata was not stored:", data)
+	}
+	if generator, ok := ParseGenerator("Flat"); !ok || generator != GeneratorFlat || GetGeneratorName(generator) != "flat" {
+		t.Error("generator was not parsed:", generator, ok)
+	}
+}
+
+func TestWriter(t *testing.T) {
+	var writer = NewWriter(4)
+	var errs = make(chan error, 1)
+	writer.ErrorFunction = func(err error) {
+		errs <- err
+	}
+	var blocked, release = make(chan struct{}), make(chan struct{})
+	writer.Write(func() error {
+		close(blocked)
+		<-release
+		return nil
+	})
+	<-blocked
+
+	var order []int
+	for i := 0; i < 4; i++ {
+		var i = i
+		if !writer.TryWrite(func() error {
+			order = append(order, i)
+			return nil
+		}) {
+			t.Fatal("write could not be queued with room in the queue")
+		}
+	}
+	if !writer.IsBusy() || writer.GetPending() != 4 {
+		t.Error("full writer was not busy:", writer.GetPending())
+	}
+	if writer.TryWrite(func() error { return nil }) {
+		t.Error("write was queued in a full queue")
+	}
+	close(release)
+	writer.Flush()
+	if len(order) != 4 || order[0] != 0 || order[3] != 3 {
+		t.Error("writes were not performed in order:", order)
+	}
+
+	writer.Write(func() error {
+		return UnknownLevel
+	})
+	writer.Close()
+	if err := <-errs; err != UnknownLevel {
+		t.Error("unexpected write error:", err)
+	}
+}
+
+func TestRecompressRegion(t *testing.T) {
+	var dir, err = ioutil.TempDir("", "levels")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	// A region file with an uncompressed chunk at index 0 and a gzip compressed chunk at index 33,
+	// separated by an unused sector.
+	var chunk = bytes.Repeat([]byte("chunk"), 1000)
+	var gzipped = bytes.NewBuffer(nil)
+	var writer = gzip.NewWriter(gzipped)
+	writer.Write(chunk)
+	writer.Close()
+	var file = make([]byte, sectorSize*6)
+	binary.BigEndian.PutUint32(file, 2<<8|2)
+	binary.BigEndian.PutUint32(file[33*4:], 5<<8|1)
+	binary.BigEndian.PutUint32(file[sectorSize+33*4:], 1234)
+	binary.BigEndian.PutUint32(file[2*sectorSize:], uint32(len(chunk)+1))
+	file[2*sectorSize+4] = CompressionNone
+	copy(file[2*sectorSize+5:], chunk)
+	binary.BigEndian.PutUint32(file[5*sectorSize:], uint32(gzipped.Len()+1))
+	file[5*sectorSize+4] = CompressionGzip
+	copy(file[5*sectorSize+5:], gzipped.Bytes())
+	os.MkdirAll(dir+"/overworld/region", 0700)
+	var path = dir + "/overworld/region/r.0.0.mca"
+	ioutil.WriteFile(path, file, 0644)
+	ioutil.WriteFile(dir+"/level.dat", []byte("level"), 0644)
+
+	if saved, err := RecompressRegion(path, zlib.BestCompression); err != nil || saved <= 0 {
+		t.Fatal("region was not recompressed:", saved, err)
+	}
+	recompressed, _ := ioutil.ReadFile(path)
+	for _, index := range []int{0, 33} {
+		var location = binary.BigEndian.Uint32(recompressed[index*4:])
+		if data, err := readRegionChunk(recompressed, int(location>>8)*sectorSize); err != nil || !bytes.Equal(data, chunk) {
+			t.Error("chunk was not kept:", index, err)
+		}
+	}
+	if binary.BigEndian.Uint32(recompressed[sectorSize+33*4:]) != 1234 {
+		t.Error("timestamps were not kept")
+	}
+
+	stats, err := ReadStorageStats(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stats.Chunks != 2 || stats.Regions != 1 || stats.Size != int64(len(recompressed))+5 {
+		t.Error("unexpected level stats:", stats.StorageStats)
+	}
+	if dimension := stats.Dimensions["overworld"]; dimension.Chunks != 2 || dimension.Size != int64(len(recompressed)) {
+		t.Error("unexpected dimension stats:", dimension)
+	}
+	if region, ok := stats.RegionFiles["overworld/region/r.0.0.mca"]; !ok || region.Chunks != 2 {
+		t.Error("unexpected region stats:", stats.RegionFiles)
+	}
+}
+
+func TestListRegionChunks(t *testing.T) {
+	var dir, err = ioutil.TempDir("", "levels")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	var file = make([]byte, sectorSize*2)
+	binary.BigEndian.PutUint32(file, 2<<8|1)
+	binary.BigEndian.PutUint32(file[33*4:], 2<<8|1)
+	ioutil.WriteFile(dir+"/r.-1.2.mca", file, 0644)
+	coordinates, err := ListRegionChunks(dir + "/r.-1.2.mca")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(coordinates) != 2 || coordinates[0] != [2]int32{-32, 64} || coordinates[1] != [2]int32{-31, 65} {
+		t.Error("unexpected chunk coordinates:", coordinates)
+	}
+	ioutil.WriteFile(dir+"/region.mca", file, 0644)
+	if _, err := ListRegionChunks(dir + "/region.mca"); err != InvalidRegion {
+		t.Error("expected invalid region error for a file without coordinates, got:", err)
+	}
+}
+
+func TestOpenRegion(t *testing.T) {
+	var dir, err = ioutil.TempDir("", "levels")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	var chunk = bytes.Repeat([]byte("chunk"), 1000)
+	var compressed = bytes.NewBuffer(nil)
+	var writer = zlib.NewWriter(compressed)
+	writer.Write(chunk)
+	writer.Close()
+	var file = make([]byte, sectorSize*4)
+	binary.BigEndian.PutUint32(file[5*4:], 2<<8|1)
+	binary.BigEndian.PutUint32(file[sectorSize+5*4:], 42)
+	binary.BigEndian.PutUint32(file[6*4:], 3<<8|1)
+	binary.BigEndian.PutUint32(file[2*sectorSize:], uint32(compressed.Len()+1))
+	file[2*sectorSize+4] = CompressionZlib
+	copy(file[2*sectorSize+5:], compressed.Bytes())
+	binary.BigEndian.PutUint32(file[3*sectorSize:], 6)
+	file[3*sectorSize+4] = CompressionNone
+	copy(file[3*sectorSize+5:], "plain")
+	var path = dir + "/r.0.0.mca"
+	ioutil.WriteFile(path, file, 0644)
+
+	for _, mode := range []string{ReadModeEager, ReadModeStream, ReadModeMemoryMap} {
+		var reader, err = OpenRegion(path, mode)
+		if err != nil {
+			t.Fatal("could not open region:", mode, err)
+		}
+		if !reader.HasChunk(5) || reader.HasChunk(0) || reader.GetTimestamp(5) != 42 {
+			t.Error("unexpected header:", mode)
+		}
+		if data, err := reader.ReadChunk(5); err != nil || !bytes.Equal(data, chunk) {
+			t.Error("compressed chunk was not read:", mode, err)
+		}
+		plain, err := reader.ReadChunk(6)
+		if data, err := reader.ReadChunk(0); err != ChunkNotGenerated || data != nil {
+			t.Error("missing chunk returned data:", mode, err)
+		}
+		if err := reader.Close(); err != nil {
+			t.Error("could not close region:", mode, err)
+		}
+		if err != nil || string(plain) != "plain" {
+			t.Error("uncompressed chunk was not kept after closing:", mode, err)
+		}
+	}
+	if _, err := OpenRegion(path, "lazy"); err != UnknownReadMode {
+		t.Error("expected unknown read mode error, got:", err)
+	}
+	ioutil.WriteFile(path, file[:sectorSize], 0644)
+	if _, err := OpenRegion(path, ReadModeStream); err != InvalidRegion {
+		t.Error("expected invalid region error for a truncated header, got:", err)
+	}
+}
+
+func TestChunkCache(t *testing.T) {
+	var level = worlds.NewLevel("world", "")
+	var dimension = worlds.NewDimension("overworld", level, worlds.OverworldId)
+	var cache = NewChunkCache(2)
+	var provider = NewCachedProvider(&memoryProvider{}, cache, dimension)
+
+	var first *chunks.Chunk
+	provider.Load(dimension, 0, 0, func(chunk *chunks.Chunk) { first = chunk })
+	provider.Load(dimension, 1, 0, func(*chunks.Chunk) {})
+	provider.Load(dimension, 0, 0, func(chunk *chunks.Chunk) {
+		if chunk != first {
+			t.Error("cached chunk was not returned")
+		}
+	})
+	provider.Load(dimension, 2, 0, func(*chunks.Chunk) {})
+	if _, ok := cache.Get(dimension, 1, 0); ok {
+		t.Error("least recently used chunk was not evicted")
+	}
+	if _, ok := cache.Get(dimension, 0, 0); !ok || cache.GetLength() != 2 {
+		t.Error("recently used chunk was evicted")
+	}
+
+	provider.Close()
+	if cache.GetLength() != 0 {
+		t.Error("chunks of the closed dimension were not removed:", cache.GetLength())
+	}
+	var disabled = NewChunkCache(0)
+	disabled.Add(dimension, chunks.New(0, 0))
+	if disabled.GetLength() != 0 {
+		t.Error("disabled cache held a chunk")
+	}
+}
+
+func TestPregenerate(t *testing.T) {
+	var dir, err = ioutil.TempDir("", "levels")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	var provider = &memoryProvider{}
+	RegisterFormat("pregenerate", func(path string) Provider {
+		return provider
+	})
+	var manager = NewManager(dir + "/")
+	var level = worlds.NewLevel("world", dir+"/")
+	if _, err := manager.Open(level, "pregenerate"); err != nil {
+		t.Fatal(err)
+	}
+	var dimension = worlds.NewDimension("overworld", level, worlds.OverworldId)
+	if err := manager.Pregenerate(context.Background(), dimension, 0, 0, 1, func(int, int) {}); err != UnknownLevel {
+		t.Error("expected unknown level error for a dimension that was not added, got:", err)
+	}
+	manager.AddDimension(dimension, "overworld")
+	var last, total int
+	if err := manager.Pregenerate(context.Background(), dimension, 5, -5, 1, func(done, all int) {
+		last, total = done, all
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if last != 9 || total != 9 {
+		t.Error("unexpected progress:", last, total)
+	}
+	var ctx, cancel = context.WithCancel(context.Background())
+	cancel()
+	if err := manager.Pregenerate(ctx, dimension, 20, 20, 1, func(int, int) {
+		t.Error("chunk was generated after the context was cancelled")
+	}); err != context.Canceled {
+		t.Error("expected context error after cancelling, got:", err)
+	}
+	manager.Close()
+	if len(provider.saved) != 9 {
+		t.Error("pregenerated chunks were not saved:", len(provider.saved))
+	}
+}
+
+func TestFindSafeSpawn(t *testing.T) {
+	var chunkData = make([]byte, 1+subChunkSize+heightMapSize+256)
+	chunkData[0] = 1
+	for x := 0; x < 16; x++ {
+		for z := 0; z < 16; z++ {
+			for y := 0; y < 4; y++ {
+				chunkData[2+(x<<8|z<<4|y)] = 1
+			}
+			// The western half of the chunk is ocean.
+			if x >= 8 {
+				chunkData[1+subChunkSize+heightMapSize+(x<<4|z)] = 1
+			}
+		}
+	}
+	// Water in the center of the chunk.
+	chunkData[2+(8<<8|8<<4|4)] = 9
+
+	if x, y, z, ok := FindSafeSpawn(chunkData); !ok || x != 8 || y != 4 || z != 7 {
+		t.Error("unexpected safe spawn:", x, y, z, ok)
+	}
+	for i := 1 + subChunkSize + heightMapSize; i < len(chunkData); i++ {
+		chunkData[i] = 0
+	}
+	if _, _, _, ok := FindSafeSpawn(chunkData); ok {
+		t.Error("safe spawn was found in an ocean")
+	}
+}
+
+func TestLight(t *testing.T) {
+	var chunkData = make([]byte, 1+subChunkSize+heightMapSize+256)
+	chunkData[0] = 1
+	var setId = func(x, y, z int, id byte) {
+		chunkData[2+(x<<8|z<<4|y)] = id
+	}
+	for x := 0; x < 16; x++ {
+		for z := 0; z < 16; z++ {
+			for y := 0; y < 4; y++ {
+				setId(x, y, z, 1)
+			}
+		}
+	}
+	// A torch on the ground, next to a stone wall.
+	setId(8, 4, 8, 50)
+	for y := 4; y < 16; y++ {
+		for z := 0; z < 16; z++ {
+			setId(10, y, z, 1)
+		}
+	}
+
+	if y, ok := GetHighestBlock(chunkData, 8, 8); !ok || y != 4 {
+		t.Error("unexpected highest block:", y, ok)
+	}
+	if light := GetBlockLight(chunkData, 8, 4, 8); light != 14 {
+		t.Error("expected torch to emit light level 14, got", light)
+	}
+	if light := GetBlockLight(chunkData, 8, 3, 8); light != 0 {
+		t.Error("expected no light in opaque blocks, got", light)
+	}
+	if light := GetBlockLight(chunkData, 8, 4, 11); light != 11 {
+		t.Error("expected light level 11 three blocks from the torch, got", light)
+	}
+	if light := GetBlockLight(chunkData, 11, 4, 8); light != 0 {
+		t.Error("expected light to not pass through walls, got", light)
+	}
+	if light := GetSkyLight(chunkData, 8, 4, 8); light != MaxLight {
+		t.Error("expected full sky light above ground, got", light)
+	}
+	if light := GetSkyLight(chunkData, 8, 3, 8); light != 0 {
+		t.Error("expected no sky light underground, got", light)
+	}
+	if !IsSolid(1) || IsSolid(0) || !IsTransparent(20) || GetHardness(7) != -1 {
+		t.Error("unexpected block properties")
+	}
+	if !BlockHasTag(17, tags.Logs) || BlockHasTag(1, tags.Logs) {
+		t.Error("expected logs to be tagged by their block ID")
+	}
+	if !IsCorrectTool(1, "minecraft:stone_pickaxe") || IsCorrectTool(1, "minecraft:stone_axe") || !IsCorrectTool(50, "minecraft:stone_axe") {
+		t.Error("unexpected correct tools for stone and torches")
+	}
+}
+
+func TestSnapshot(t *testing.T) {
+	var chunkData = make([]byte, 1+subChunkSize+heightMapSize+256)
+	chunkData[0] = 1
+	chunkData[2+(3<<8|4<<4|5)] = 35
+	chunkData[2+subChunkBlocks+(3<<8|4<<4|5)>>1] = 0xe0
+	chunkData[1+subChunkSize+heightMapSize+(3<<4|4)] = 2
+
+	var snapshot = NewBinarySnapshot(1, -2, chunkData)
+	chunkData[2+(3<<8|4<<4|5)] = 0
+	if snapshot.GetBlockId(3, 5, 4) != 35 || snapshot.GetBlockData(3, 5, 4) != 14 {
+		t.Error("expected snapshot to keep the block it was taken with, got", snapshot.GetBlockId(3, 5, 4), snapshot.GetBlockData(3, 5, 4))
+	}
+	if biome, ok := snapshot.GetBiome(3, 4); !ok || biome != 2 {
+		t.Error("unexpected biome:", biome, ok)
+	}
+	if y, ok := snapshot.GetHighestBlock(3, 4); !ok || y != 5 {
+		t.Error("unexpected highest block:", y, ok)
+	}
+	if snapshot.GetBlockId(16, 0, 0) != 0 || snapshot.GetBlockId(0, 16, 0) != 0 {
+		t.Error("expected air outside of the chunk")
+	}
+}
+
+func TestLevelDB(t *testing.T) {
+	var dir, err = ioutil.TempDir("", "levels")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	provider, err := NewLevelDB(dir + "/db")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var chunk = chunks.New(2, -3)
+	chunk.SetBlockId(3, 21, 4, 35)
+	chunk.SetBlockData(3, 21, 4, 14)
+	chunk.SetBiome(3, 4, 2)
+	provider.Save(chunk)
+	provider.Close()
+
+	if provider, err = NewLevelDB(dir + "/db"); err != nil {
+		t.Fatal(err)
+	}
+	defer provider.Close()
+	var loaded *chunks.Chunk
+	provider.Load(nil, 2, -3, func(chunk *chunks.Chunk) {
+		loaded = chunk
+	})
+	var snapshot = NewSnapshot(loaded)
+	if snapshot.GetBlockId(3, 21, 4) != 35 || snapshot.GetBlockData(3, 21, 4) != 14 {
+		t.Error("block was not read back:", snapshot.GetBlockId(3, 21, 4), snapshot.GetBlockData(3, 21, 4))
+	}
+	if biome, ok := snapshot.GetBiome(3, 4); !ok || biome != 2 {
+		t.Error("biome was not read back:", biome, ok)
+	}
+}
+
+func TestPresets(t *testing.T) {
+	if err := ValidatePreset("Amplified", ""); err != nil {
+		t.Error("expected amplified preset to be valid:", err)
+	}
+	if err := ValidatePreset("mountains", ""); err != UnknownPreset {
+		t.Error("expected unknown preset, got", err)
+	}
+	if err := ValidatePreset(PresetSingleBiome, ""); err != MissingPresetBiome {
+		t.Error("expected missing preset biome, got", err)
+	}
+
+	var dir, err = ioutil.TempDir("", "levels")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	var data = NewData("world")
+	var preset, _ = GetPreset(PresetSingleBiome)
+	data.SetPreset(preset, "desert")
+	if err := data.Write(dir + "/level.dat"); err != nil {
+		t.Fatal(err)
+	}
+	read, err := ReadData(dir + "/level.dat")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if read.Generator != GeneratorInfinite || read.GetPreset().Name != PresetSingleBiome || read.BiomeOverride != "desert" {
+		t.Error("preset was not read back:", read.Generator, read.GeneratorOptions, read.BiomeOverride)
+	}
+
+	read.SetPreset(presets[PresetDefault], "desert")
+	if read.GeneratorOptions != "" || read.BiomeOverride != "" || read.GetPreset().Name != PresetDefault {
+		t.Error("expected default preset to be stored as a regular infinite level:", read.GeneratorOptions, read.BiomeOverride)
+	}
+	if err := read.Write(dir + "/level.dat"); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := read.tags["BiomeOverride"]; ok {
+		t.Error("expected biome override to be removed")
+	}
+}
+
+func TestBreakTime(t *testing.T) {
+	for id := range harvestTiers {
+		if _, ok := GetBlockName(id); !ok || !BlockHasTag(id, tags.MineablePickaxe) {
+			t.Error("block requiring a pickaxe is not mineable by pickaxes:", id)
+		}
+	}
+	if tier, speed := GetToolTier("minecraft:golden_pickaxe"); tier != TierWood || speed != 12 {
+		t.Error("unexpected tier of golden pickaxe:", tier, speed)
+	}
+	if tier, _ := GetToolTier("minecraft:stone"); tier != TierNone {
+		t.Error("stone was considered a tool of tier", tier)
+	}
+	if CanHarvest(15, "minecraft:wooden_pickaxe") || !CanHarvest(15, "minecraft:stone_pickaxe") || CanHarvest(15, "minecraft:diamond_axe") {
+		t.Error("iron ore harvest tier was not respected")
+	}
+	if !CanHarvest(3, "") {
+		t.Error("dirt could not be harvested by hand")
+	}
+
+	var cases = []struct {
+		id         byte
+		item       string
+		conditions BreakConditions
+		expected   time.Duration
+	}{
+		{1, "", BreakConditions{}, 150 * TickDuration},
+		{1, "minecraft:wooden_pickaxe", BreakConditions{}, 23 * TickDuration},
+		{1, "minecraft:diamond_pickaxe", BreakConditions{Efficiency: 5}, 2 * TickDuration},
+		{1, "minecraft:wooden_pickaxe", BreakConditions{Underwater: true}, 113 * TickDuration},
+		{1, "minecraft:wooden_pickaxe", BreakConditions{Underwater: true, AquaAffinity: true}, 23 * TickDuration},
+		{1, "minecraft:wooden_pickaxe", BreakConditions{Airborne: true}, 113 * TickDuration},
+		{1, "minecraft:wooden_pickaxe", BreakConditions{Haste: 2}, 17 * TickDuration},
+		{1, "minecraft:wooden_pickaxe", BreakConditions{MiningFatigue: 1}, 75 * TickDuration},
+		{3, "", BreakConditions{}, 15 * TickDuration},
+		{3, "minecraft:iron_shovel", BreakConditions{}, 3 * TickDuration},
+		{3, "minecraft:iron_pickaxe", BreakConditions{}, 15 * TickDuration},
+		{6, "", BreakConditions{}, 0},
+	}
+	for _, c := range cases {
+		if duration, ok := GetBreakTime(c.id, c.item, c.conditions); !ok || duration != c.expected {
+			t.Error("unexpected break time of block", c.id, "with", c.item, c.conditions, "expected", c.expected, "got", duration, ok)
+		}
+	}
+	if _, ok := GetBreakTime(7, "minecraft:diamond_pickaxe", BreakConditions{}); ok {
+		t.Error("bedrock could be broken")
+	}
+}
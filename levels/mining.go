@@ -0,0 +1,180 @@
+package levels
+
+import (
+	"math"
+	"strings"
+	"time"
+
+	"github.com/irmine/gomine/tags"
+)
+
+// ToolTier is the harvest level of a tool, which decides which blocks drop loot when broken with it.
+type ToolTier int
+
+// Tool tiers, from the lowest to the highest harvest level.
+// Golden tools have the harvest level of wooden tools.
+const (
+	TierNone ToolTier = iota
+	TierWood
+	TierStone
+	TierIron
+	TierDiamond
+)
+
+// TickDuration is the duration of a game tick, in which blocks take damage while being broken.
+const TickDuration = time.Second / 20
+
+// toolMaterial is the harvest level and mining speed of the tools of a material.
+type toolMaterial struct {
+	tier  ToolTier
+	speed float64
+}
+
+// toolMaterials are the materials of tools, indexed by the prefix of the tool identifiers,
+// such as diamond for minecraft:diamond_pickaxe.
+var toolMaterials = map[string]toolMaterial{
+	"wooden":  {TierWood, 2},
+	"stone":   {TierStone, 4},
+	"iron":    {TierIron, 6},
+	"diamond": {TierDiamond, 8},
+	"golden":  {TierWood, 12},
+}
+
+// harvestTiers are the tool tiers required for blocks to drop loot, indexed by block ID.
+// Blocks not in the map drop loot when broken with any item.
+var harvestTiers = func() map[byte]ToolTier {
+	var tiers = make(map[byte]ToolTier)
+	for _, id := range []byte{1, 4, 16, 23, 24, 43, 44, 45, 48, 61, 62, 67, 87, 98, 101, 108, 109, 112, 113, 114, 116, 117,
+		118, 121, 128, 130, 139, 145, 152, 153, 154, 155, 156, 159, 168, 172, 173, 179, 180, 181, 182, 201, 203} {
+		tiers[id] = TierWood
+	}
+	for _, id := range []byte{15, 21, 22, 42, 71, 167} {
+		tiers[id] = TierStone
+	}
+	for _, id := range []byte{14, 41, 56, 57, 73, 74, 129, 133} {
+		tiers[id] = TierIron
+	}
+	tiers[49] = TierDiamond
+	return tiers
+}()
+
+// fatigueMultipliers are the factors by which mining fatigue multiplies the mining speed, indexed by level minus one.
+// Levels above the highest level use the last factor.
+var fatigueMultipliers = []float64{0.3, 0.09, 0.0027, 0.00081}
+
+// BreakConditions are the circumstances of a player breaking a block, which change how fast the block breaks.
+type BreakConditions struct {
+	// Efficiency is the level of the efficiency enchantment of the tool.
+	Efficiency byte
+	// Haste and MiningFatigue are the levels of the status effects applied on the player.
+	Haste, MiningFatigue int32
+	// Underwater specifies if the head of the player is in water,
+	// which slows down breaking unless the player has aqua affinity.
+	Underwater, AquaAffinity bool
+	// Airborne specifies if the player is not standing on the ground, which slows down breaking.
+	Airborne bool
+}
+
+// GetToolTier returns the tier and mining speed of the tool with the given identifier.
+// TierNone and a speed of 1 are returned for items that are not tools.
+func GetToolTier(item string) (ToolTier, float64) {
+	if !isTool(item) {
+		return TierNone, 1
+	}
+	var name = item[strings.Index(item, ":")+1:]
+	if separator := strings.Index(name, "_"); separator > 0 {
+		if material, ok := toolMaterials[name[:separator]]; ok {
+			return material.tier, material.speed
+		}
+	}
+	return TierNone, 1
+}
+
+// GetHarvestTier returns the tool tier required for the block with the given ID to drop loot.
+// A bool is returned indicating if the block requires a tool at all.
+func GetHarvestTier(id byte) (ToolTier, bool) {
+	var tier, ok = harvestTiers[id]
+	return tier, ok
+}
+
+// CanHarvest checks if the block with the given ID drops loot when broken with the item with the given identifier,
+// which is the case if the block does not require a tool, or the item is the correct tool of a high enough tier.
+func CanHarvest(id byte, item string) bool {
+	var required, ok = harvestTiers[id]
+	if !ok {
+		return true
+	}
+	var tier, _ = GetToolTier(item)
+	return tier >= required && IsCorrectTool(id, item)
+}
+
+// GetBreakTime returns the time it takes to break the block with the given ID using the item with the given identifier.
+// Blocks that break instantly take no time. A bool is returned indicating if the block can be broken at all,
+// which is not the case for unbreakable blocks such as bedrock.
+func GetBreakTime(id byte, item string, conditions BreakConditions) (time.Duration, bool) {
+	var hardness = GetHardness(id)
+	if hardness < 0 {
+		return 0, false
+	}
+	if hardness == 0 {
+		return 0, true
+	}
+	var speed = 1.0
+	if isEffectiveTool(id, item) {
+		_, speed = GetToolTier(item)
+		if conditions.Efficiency > 0 {
+			speed += float64(conditions.Efficiency)*float64(conditions.Efficiency) + 1
+		}
+	}
+	if conditions.Haste > 0 {
+		speed *= 1 + 0.2*float64(conditions.Haste)
+	}
+	if conditions.MiningFatigue > 0 {
+		var level = int(conditions.MiningFatigue)
+		if level > len(fatigueMultipliers) {
+			level = len(fatigueMultipliers)
+		}
+		speed *= fatigueMultipliers[level-1]
+	}
+	if conditions.Underwater && !conditions.AquaAffinity {
+		speed /= 5
+	}
+	if conditions.Airborne {
+		speed /= 5
+	}
+
+	var damage = speed / hardness
+	if CanHarvest(id, item) {
+		damage /= 30
+	} else {
+		damage /= 100
+	}
+	if damage > 1 {
+		return 0, true
+	}
+	return time.Duration(math.Ceil(1/damage)) * TickDuration, true
+}
+
+// isTool checks if the item with the given identifier is part of any of the tool tags.
+func isTool(item string) bool {
+	if tags.DefaultRegistry.Has(tags.Items, tags.Swords, item) {
+		return true
+	}
+	for _, itemTag := range tags.ToolTags {
+		if tags.DefaultRegistry.Has(tags.Items, itemTag, item) {
+			return true
+		}
+	}
+	return false
+}
+
+// isEffectiveTool checks if the item with the given identifier is a tool that speeds up breaking the block with the given ID,
+// which is the case if the block is mineable by the type of the tool.
+func isEffectiveTool(id byte, item string) bool {
+	for blockTag, itemTag := range tags.ToolTags {
+		if BlockHasTag(id, blockTag) && tags.DefaultRegistry.Has(tags.Items, itemTag, item) {
+			return true
+		}
+	}
+	return false
+}
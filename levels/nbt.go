@@ -0,0 +1,265 @@
+package levels
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+)
+
+const (
+	tagEnd byte = iota
+	tagByte
+	tagShort
+	tagInt
+	tagLong
+	tagFloat
+	tagDouble
+	tagByteArray
+	tagString
+	tagList
+	tagCompound
+	tagIntArray
+	tagLongArray
+)
+
+// maxDepth is the maximum depth of nested lists and compounds,
+// so that malformed files can not exhaust the stack.
+const maxDepth = 512
+
+// InvalidLevelData gets returned when a level.dat file could not be parsed.
+var InvalidLevelData = errors.New("invalid level data")
+
+// list is an NBT list, which keeps the type of its values
+// so that empty lists can be written back unchanged.
+type list struct {
+	tagType byte
+	values  []interface{}
+}
+
+// readCompound reads a little endian root compound of level NBT.
+// Tags get read into Go values:
+// int8, int16, int32, int64, float32, float64, []byte, string,
+// list, map[string]interface{}, []int32 and []int64.
+func readCompound(reader io.Reader) (map[string]interface{}, error) {
+	var tagType byte
+	if err := binary.Read(reader, binary.LittleEndian, &tagType); err != nil {
+		return nil, err
+	}
+	if tagType != tagCompound {
+		return nil, InvalidLevelData
+	}
+	if _, err := readString(reader); err != nil {
+		return nil, err
+	}
+	var value, err = readPayload(reader, tagCompound, 0)
+	if err != nil {
+		return nil, err
+	}
+	return value.(map[string]interface{}), nil
+}
+
+// readPayload reads the payload of a tag with the given type.
+func readPayload(reader io.Reader, tagType byte, depth int) (interface{}, error) {
+	if depth > maxDepth {
+		return nil, InvalidLevelData
+	}
+	switch tagType {
+	case tagByte:
+		var value int8
+		return value, binary.Read(reader, binary.LittleEndian, &value)
+	case tagShort:
+		var value int16
+		return value, binary.Read(reader, binary.LittleEndian, &value)
+	case tagInt:
+		var value int32
+		return value, binary.Read(reader, binary.LittleEndian, &value)
+	case tagLong:
+		var value int64
+		return value, binary.Read(reader, binary.LittleEndian, &value)
+	case tagFloat:
+		var value float32
+		return value, binary.Read(reader, binary.LittleEndian, &value)
+	case tagDouble:
+		var value float64
+		return value, binary.Read(reader, binary.LittleEndian, &value)
+	case tagString:
+		return readString(reader)
+	case tagByteArray:
+		var length, err = readLength(reader)
+		if err != nil {
+			return nil, err
+		}
+		var value = make([]byte, length)
+		_, err = io.ReadFull(reader, value)
+		return value, err
+	case tagIntArray:
+		var length, err = readLength(reader)
+		if err != nil {
+			return nil, err
+		}
+		var value = make([]int32, length)
+		return value, binary.Read(reader, binary.LittleEndian, value)
+	case tagLongArray:
+		var length, err = readLength(reader)
+		if err != nil {
+			return nil, err
+		}
+		var value = make([]int64, length)
+		return value, binary.Read(reader, binary.LittleEndian, value)
+	case tagList:
+		var value = list{}
+		if err := binary.Read(reader, binary.LittleEndian, &value.tagType); err != nil {
+			return nil, err
+		}
+		var length, err = readLength(reader)
+		if err != nil {
+			return nil, err
+		}
+		for i := 0; i < length; i++ {
+			var element, err = readPayload(reader, value.tagType, depth+1)
+			if err != nil {
+				return nil, err
+			}
+			value.values = append(value.values, element)
+		}
+		return value, nil
+	case tagCompound:
+		var value = make(map[string]interface{})
+		for {
+			var elementType byte
+			if err := binary.Read(reader, binary.LittleEndian, &elementType); err != nil {
+				return nil, err
+			}
+			if elementType == tagEnd {
+				return value, nil
+			}
+			var name, err = readString(reader)
+			if err != nil {
+				return nil, err
+			}
+			value[name], err = readPayload(reader, elementType, depth+1)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+	return nil, fmt.Errorf("unknown NBT tag type %v", tagType)
+}
+
+// readLength reads the length of an array or list.
+func readLength(reader io.Reader) (int, error) {
+	var length int32
+	if err := binary.Read(reader, binary.LittleEndian, &length); err != nil {
+		return 0, err
+	}
+	if length < 0 {
+		return 0, InvalidLevelData
+	}
+	return int(length), nil
+}
+
+// readString reads a string prefixed with its length.
+func readString(reader io.Reader) (string, error) {
+	var length uint16
+	if err := binary.Read(reader, binary.LittleEndian, &length); err != nil {
+		return "", err
+	}
+	var value = make([]byte, length)
+	_, err := io.ReadFull(reader, value)
+	return string(value), err
+}
+
+// writeCompound writes a little endian root compound of level NBT.
+func writeCompound(buffer *bytes.Buffer, compound map[string]interface{}) error {
+	buffer.WriteByte(tagCompound)
+	writeString(buffer, "")
+	return writePayload(buffer, compound)
+}
+
+// writePayload writes the payload of a value read by readPayload.
+func writePayload(buffer *bytes.Buffer, value interface{}) error {
+	switch value := value.(type) {
+	case string:
+		writeString(buffer, value)
+	case []byte:
+		binary.Write(buffer, binary.LittleEndian, int32(len(value)))
+		buffer.Write(value)
+	case []int32:
+		binary.Write(buffer, binary.LittleEndian, int32(len(value)))
+		binary.Write(buffer, binary.LittleEndian, value)
+	case []int64:
+		binary.Write(buffer, binary.LittleEndian, int32(len(value)))
+		binary.Write(buffer, binary.LittleEndian, value)
+	case list:
+		buffer.WriteByte(value.tagType)
+		binary.Write(buffer, binary.LittleEndian, int32(len(value.values)))
+		for _, element := range value.values {
+			if err := writePayload(buffer, element); err != nil {
+				return err
+			}
+		}
+	case map[string]interface{}:
+		var names = make([]string, 0, len(value))
+		for name := range value {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			var tagType, err = typeOf(value[name])
+			if err != nil {
+				return err
+			}
+			buffer.WriteByte(tagType)
+			writeString(buffer, name)
+			if err := writePayload(buffer, value[name]); err != nil {
+				return err
+			}
+		}
+		buffer.WriteByte(tagEnd)
+	case int8, int16, int32, int64, float32, float64:
+		binary.Write(buffer, binary.LittleEndian, value)
+	default:
+		return fmt.Errorf("unsupported NBT value %T", value)
+	}
+	return nil
+}
+
+// writeString writes a string prefixed with its length.
+func writeString(buffer *bytes.Buffer, value string) {
+	binary.Write(buffer, binary.LittleEndian, uint16(len(value)))
+	buffer.WriteString(value)
+}
+
+// typeOf returns the NBT tag type of a value read by readPayload.
+func typeOf(value interface{}) (byte, error) {
+	switch value.(type) {
+	case int8:
+		return tagByte, nil
+	case int16:
+		return tagShort, nil
+	case int32:
+		return tagInt, nil
+	case int64:
+		return tagLong, nil
+	case float32:
+		return tagFloat, nil
+	case float64:
+		return tagDouble, nil
+	case []byte:
+		return tagByteArray, nil
+	case string:
+		return tagString, nil
+	case list:
+		return tagList, nil
+	case map[string]interface{}:
+		return tagCompound, nil
+	case []int32:
+		return tagIntArray, nil
+	case []int64:
+		return tagLongArray, nil
+	}
+	return 0, fmt.Errorf("unsupported NBT value %T", value)
+}
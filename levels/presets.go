@@ -0,0 +1,102 @@
+package levels
+
+import (
+	"errors"
+	"sort"
+	"strings"
+)
+
+const (
+	PresetDefault     = "default"
+	PresetAmplified   = "amplified"
+	PresetLargeBiomes = "large_biomes"
+	PresetSingleBiome = "single_biome"
+)
+
+var (
+	// UnknownPreset gets returned when validating a preset name that no preset has.
+	UnknownPreset = errors.New("unknown generator preset")
+	// MissingPresetBiome gets returned when validating a preset generating a single biome without a biome.
+	MissingPresetBiome = errors.New("generator preset requires a biome")
+)
+
+// Preset is a parameterization of the infinite generator, also known as the Normal generator.
+// Presets only change the parameters the terrain is generated with from the seed of a level,
+// so levels generated with a preset are still read as regular infinite levels by other software.
+type Preset struct {
+	// Name is the name of the preset, which gets stored as generator options of a level.
+	Name string
+	// HeightScale scales the height differences of the terrain, 1 being the default terrain.
+	HeightScale float64
+	// BiomeScale scales the size of biomes, 1 being the default biome size.
+	BiomeScale float64
+	// SingleBiome specifies if only the biome override of the level gets generated.
+	SingleBiome bool
+}
+
+// presets are all generator presets, by their name.
+var presets = map[string]Preset{
+	PresetDefault:     {Name: PresetDefault, HeightScale: 1, BiomeScale: 1},
+	PresetAmplified:   {Name: PresetAmplified, HeightScale: 2, BiomeScale: 1},
+	PresetLargeBiomes: {Name: PresetLargeBiomes, HeightScale: 1, BiomeScale: 4},
+	PresetSingleBiome: {Name: PresetSingleBiome, HeightScale: 1, BiomeScale: 1, SingleBiome: true},
+}
+
+// GetPreset returns the generator preset with the given name, ignoring case.
+// A bool is returned indicating if a preset has the name.
+func GetPreset(name string) (Preset, bool) {
+	var preset, ok = presets[strings.ToLower(name)]
+	return preset, ok
+}
+
+// GetPresetNames returns the sorted names of all generator presets.
+func GetPresetNames() []string {
+	var names = make([]string, 0, len(presets))
+	for name := range presets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ValidatePreset checks if a preset has the given name, and if the biome required by it is set.
+// UnknownPreset or MissingPresetBiome is returned if not.
+func ValidatePreset(name, biome string) error {
+	var preset, ok = GetPreset(name)
+	if !ok {
+		return UnknownPreset
+	}
+	if preset.SingleBiome && biome == "" {
+		return MissingPresetBiome
+	}
+	return nil
+}
+
+// GetPreset returns the generator preset of the level.
+// The default preset is returned for levels not generated with a preset.
+func (data *Data) GetPreset() Preset {
+	return getPreset(data.Generator, data.GeneratorOptions)
+}
+
+// getPreset returns the preset of a level with the given generator and generator options.
+func getPreset(generator int32, options string) Preset {
+	if generator == GeneratorInfinite {
+		if preset, ok := GetPreset(options); ok {
+			return preset
+		}
+	}
+	return presets[PresetDefault]
+}
+
+// SetPreset sets the generator of the level to the infinite generator with the given preset.
+// The biome is stored as biome override of the level if the preset generates a single biome.
+// Chunks that were already generated are not affected.
+func (data *Data) SetPreset(preset Preset, biome string) {
+	data.Generator, data.GeneratorOptions, data.BiomeOverride = GeneratorInfinite, "", ""
+	if preset.Name != PresetDefault {
+		data.GeneratorOptions = preset.Name
+	}
+	if preset.SingleBiome {
+		data.BiomeOverride = biome
+	}
+}
@@ -0,0 +1,232 @@
+package levels
+
+import (
+	"sync"
+
+	"github.com/irmine/worlds"
+)
+
+// BlockProperties are the physical properties of a type of block,
+// used to check how blocks interact with entities, light and explosions.
+type BlockProperties struct {
+	// Solid specifies if entities collide with the block.
+	Solid bool
+	// Hardness is the time factor of breaking the block. Unbreakable blocks have a hardness of -1.
+	Hardness float64
+	// BlastResistance is the resistance of the block against explosions.
+	BlastResistance float64
+	// LightEmission is the light level emitted by the block, from 0 to 15.
+	LightEmission byte
+	// LightFilter is the amount of light levels absorbed by light passing through the block,
+	// from 0 to 15. Blocks filtering 15 light levels are opaque.
+	LightFilter byte
+	// Replaceable specifies if placing a block at the position of the block replaces it, like for air and liquids.
+	Replaceable bool
+}
+
+// opaque returns the properties of a solid, opaque block with the given hardness and blast resistance.
+func opaque(hardness, blastResistance float64) BlockProperties {
+	return BlockProperties{Solid: true, Hardness: hardness, BlastResistance: blastResistance, LightFilter: MaxLight}
+}
+
+// transparent returns the properties of a block with the given hardness and blast resistance that lets light through.
+func transparent(solid bool, hardness, blastResistance float64) BlockProperties {
+	return BlockProperties{Solid: solid, Hardness: hardness, BlastResistance: blastResistance}
+}
+
+// emitting returns the properties with the given light emission.
+func emitting(properties BlockProperties, lightEmission byte) BlockProperties {
+	properties.LightEmission = lightEmission
+	return properties
+}
+
+// filtering returns the properties with the given light filter.
+func filtering(properties BlockProperties, lightFilter byte) BlockProperties {
+	properties.LightFilter = lightFilter
+	return properties
+}
+
+// replaceable returns the properties with the block being replaceable.
+func replaceable(properties BlockProperties) BlockProperties {
+	properties.Replaceable = true
+	return properties
+}
+
+var blockMutex sync.RWMutex
+
+// blockProperties are the properties of all block IDs.
+// Blocks without registered properties are solid, opaque blocks with a hardness and blast resistance of 1.
+var blockProperties = func() (properties [256]BlockProperties) {
+	for id := range properties {
+		properties[id] = opaque(1, 1)
+	}
+	for id, p := range map[byte]BlockProperties{
+		0:   replaceable(transparent(false, 0, 0)),
+		1:   opaque(1.5, 6),
+		2:   opaque(0.6, 0.6),
+		3:   opaque(0.5, 0.5),
+		4:   opaque(2, 6),
+		5:   opaque(2, 3),
+		6:   transparent(false, 0, 0),
+		7:   opaque(-1, 3600000),
+		8:   replaceable(filtering(transparent(false, 100, 100), 2)),
+		9:   replaceable(filtering(transparent(false, 100, 100), 2)),
+		10:  replaceable(emitting(transparent(false, 100, 100), 15)),
+		11:  replaceable(emitting(transparent(false, 100, 100), 15)),
+		12:  opaque(0.5, 0.5),
+		13:  opaque(0.6, 0.6),
+		14:  opaque(3, 3),
+		15:  opaque(3, 3),
+		16:  opaque(3, 3),
+		17:  opaque(2, 2),
+		18:  filtering(transparent(true, 0.2, 0.2), 1),
+		20:  transparent(true, 0.3, 0.3),
+		21:  opaque(3, 3),
+		24:  opaque(0.8, 0.8),
+		30:  transparent(false, 4, 4),
+		31:  replaceable(transparent(false, 0, 0)),
+		32:  replaceable(transparent(false, 0, 0)),
+		35:  opaque(0.8, 0.8),
+		37:  transparent(false, 0, 0),
+		38:  transparent(false, 0, 0),
+		39:  emitting(transparent(false, 0, 0), 1),
+		40:  transparent(false, 0, 0),
+		41:  opaque(3, 6),
+		42:  opaque(5, 6),
+		44:  transparent(true, 2, 6),
+		45:  opaque(2, 6),
+		46:  opaque(0, 0),
+		47:  opaque(1.5, 1.5),
+		48:  opaque(2, 6),
+		49:  opaque(50, 1200),
+		50:  emitting(transparent(false, 0, 0), 14),
+		51:  replaceable(emitting(transparent(false, 0, 0), 15)),
+		52:  transparent(true, 5, 5),
+		53:  transparent(true, 2, 3),
+		54:  transparent(true, 2.5, 2.5),
+		56:  opaque(3, 3),
+		57:  opaque(5, 6),
+		58:  opaque(2.5, 2.5),
+		59:  transparent(false, 0, 0),
+		60:  transparent(true, 0.6, 0.6),
+		61:  opaque(3.5, 3.5),
+		62:  emitting(opaque(3.5, 3.5), 13),
+		63:  transparent(false, 1, 1),
+		64:  transparent(true, 3, 3),
+		65:  transparent(false, 0.4, 0.4),
+		66:  transparent(false, 0.7, 0.7),
+		67:  transparent(true, 2, 6),
+		68:  transparent(false, 1, 1),
+		71:  transparent(true, 5, 5),
+		73:  opaque(3, 3),
+		74:  emitting(opaque(3, 3), 9),
+		76:  emitting(transparent(false, 0, 0), 7),
+		78:  replaceable(transparent(false, 0.1, 0.1)),
+		79:  filtering(transparent(true, 0.5, 0.5), 2),
+		80:  opaque(0.2, 0.2),
+		81:  transparent(true, 0.4, 0.4),
+		82:  opaque(0.6, 0.6),
+		83:  transparent(false, 0, 0),
+		85:  transparent(true, 2, 3),
+		86:  opaque(1, 1),
+		87:  opaque(0.4, 0.4),
+		88:  opaque(0.5, 0.5),
+		89:  emitting(transparent(true, 0.3, 0.3), 15),
+		90:  emitting(transparent(false, -1, 0), 11),
+		91:  emitting(opaque(1, 1), 15),
+		98:  opaque(1.5, 6),
+		101: transparent(true, 5, 6),
+		102: transparent(true, 0.3, 0.3),
+		106: replaceable(transparent(false, 0.2, 0.2)),
+		107: transparent(true, 2, 3),
+		111: transparent(true, 0, 0),
+		112: opaque(2, 6),
+		116: transparent(true, 5, 1200),
+		119: emitting(transparent(false, -1, 3600000), 15),
+		120: emitting(transparent(true, -1, 3600000), 1),
+		121: opaque(3, 9),
+		123: opaque(0.3, 0.3),
+		124: emitting(opaque(0.3, 0.3), 15),
+		129: opaque(3, 3),
+		130: emitting(transparent(true, 22.5, 600), 7),
+		133: opaque(5, 6),
+		138: emitting(transparent(true, 3, 3), 15),
+		145: transparent(true, 5, 1200),
+		152: opaque(5, 6),
+		155: opaque(0.8, 0.8),
+		159: opaque(1.25, 4.2),
+		161: filtering(transparent(true, 0.2, 0.2), 1),
+		162: opaque(2, 2),
+		169: emitting(opaque(0.3, 0.3), 15),
+		170: opaque(0.5, 0.5),
+		171: transparent(true, 0.1, 0.1),
+		172: opaque(1.25, 4.2),
+		173: opaque(5, 6),
+		174: opaque(0.5, 0.5),
+		175: transparent(false, 0, 0),
+		213: emitting(opaque(0.5, 0.5), 3),
+	} {
+		properties[id] = p
+	}
+	return properties
+}()
+
+// GetBlockProperties returns the properties of the block with the given ID.
+func GetBlockProperties(id byte) BlockProperties {
+	blockMutex.RLock()
+	defer blockMutex.RUnlock()
+	return blockProperties[id]
+}
+
+// RegisterBlockProperties sets the properties of the block with the given ID,
+// for example to make a custom block emit light.
+func RegisterBlockProperties(id byte, properties BlockProperties) {
+	blockMutex.Lock()
+	blockProperties[id] = properties
+	blockMutex.Unlock()
+}
+
+// IsSolid checks if entities collide with the block with the given ID.
+func IsSolid(id byte) bool {
+	return GetBlockProperties(id).Solid
+}
+
+// IsReplaceable checks if placing a block at the position of the block with the given ID replaces it.
+func IsReplaceable(id byte) bool {
+	return GetBlockProperties(id).Replaceable
+}
+
+// GetBlockAt returns the ID and data of the block at the position in the dimension, read directly from its loaded chunk.
+// A bool is returned indicating if the chunk of the position is loaded. Chunks that are not loaded are never loaded.
+func GetBlockAt(worldsDimension *worlds.Dimension, x, y, z int) (id byte, data byte, ok bool) {
+	var chunk, loaded = worldsDimension.GetChunk(int32(x>>4), int32(z>>4))
+	if !loaded {
+		return 0, 0, false
+	}
+	if y < 0 || y >= SubChunkCount*16 {
+		return 0, 0, true
+	}
+	return chunk.GetBlockId(x&15, y, z&15), chunk.GetBlockData(x&15, y, z&15), true
+}
+
+// IsSolidAt checks if the block at the position in the dimension is solid.
+// Blocks in chunks that are not loaded are not solid.
+func IsSolidAt(worldsDimension *worlds.Dimension, x, y, z int) bool {
+	var id, _, ok = GetBlockAt(worldsDimension, x, y, z)
+	return ok && IsSolid(id)
+}
+
+// IsTransparent checks if light passes through the block with the given ID.
+func IsTransparent(id byte) bool {
+	return GetBlockProperties(id).LightFilter < MaxLight
+}
+
+// GetHardness returns the hardness of the block with the given ID, which is -1 for unbreakable blocks.
+func GetHardness(id byte) float64 {
+	return GetBlockProperties(id).Hardness
+}
+
+// GetBlastResistance returns the resistance against explosions of the block with the given ID.
+func GetBlastResistance(id byte) float64 {
+	return GetBlockProperties(id).BlastResistance
+}
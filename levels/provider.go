@@ -0,0 +1,159 @@
+package levels
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/irmine/worlds"
+	"github.com/irmine/worlds/chunks"
+	"github.com/irmine/worlds/providers"
+)
+
+const (
+	// FormatAnvil stores chunks in Anvil region files.
+	FormatAnvil = "anvil"
+	// FormatLevelDB stores chunks in a LevelDB database, native to Bedrock Edition.
+	FormatLevelDB = "leveldb"
+)
+
+// UnknownFormat gets returned when a provider
+// for a level format could not be found.
+var UnknownFormat = errors.New("unknown level format")
+
+// Provider loads and saves the chunks of a dimension.
+type Provider interface {
+	// Load loads the chunk at the given chunk coordinates,
+	// and calls the function once it has been loaded or generated.
+	Load(dimension *worlds.Dimension, x, z int32, function func(*chunks.Chunk))
+	// Save saves the given chunk.
+	Save(chunk *chunks.Chunk)
+	// Close closes the provider, releasing all files it had opened.
+	Close()
+}
+
+var (
+	formatMutex sync.RWMutex
+	formats     = map[string]func(path string) (Provider, error){
+		FormatAnvil: func(path string) (Provider, error) {
+			return &anvil{Anvil: providers.NewAnvil(path + "region/"), path: path + "region/"}, nil
+		},
+		FormatLevelDB: func(path string) (Provider, error) {
+			return NewLevelDB(path + "db/")
+		},
+	}
+)
+
+// anvil is the provider of Anvil levels, storing chunks in the region files in its directory.
+type anvil struct {
+	*providers.Anvil
+	path string
+}
+
+// IsStored checks if the region file holding the chunk at the chunk coordinates stores the chunk.
+func (provider *anvil) IsStored(x, z int32) bool {
+	var reader, err = OpenRegion(fmt.Sprintf("%vr.%v.%v.mca", provider.path, x>>5, z>>5), ReadModeStream)
+	if err != nil {
+		return false
+	}
+	defer reader.Close()
+	return reader.HasChunk(int(x&31 + (z&31)*32))
+}
+
+// RegisterFormat registers a function creating a provider for the format with the given name,
+// replacing the provider previously registered for the format.
+// The function receives the directory of the dimension the provider is for.
+func RegisterFormat(name string, create func(path string) Provider) {
+	formatMutex.Lock()
+	formats[name] = func(path string) (Provider, error) {
+		return create(path), nil
+	}
+	formatMutex.Unlock()
+}
+
+// IsFormatRegistered checks if a provider was registered for the format with the given name.
+func IsFormatRegistered(name string) bool {
+	formatMutex.RLock()
+	defer formatMutex.RUnlock()
+	var _, ok = formats[name]
+	return ok
+}
+
+// NewProvider returns a new provider of the format with the given name,
+// storing chunks in the given directory.
+// UnknownFormat gets returned if no provider was registered for the format,
+// and an error is returned if the provider could not be opened.
+func NewProvider(format string, path string) (Provider, error) {
+	formatMutex.RLock()
+	var create, ok = formats[format]
+	formatMutex.RUnlock()
+	if !ok {
+		return nil, UnknownFormat
+	}
+	return create(path)
+}
+
+// AsyncProvider wraps a provider, saving chunks on the goroutine of a writer
+// so that saving does not block the server tick.
+type AsyncProvider struct {
+	// LoadFunction gets called with every chunk loaded by the provider, before the chunk is passed on.
+	// It does nothing by default.
+	LoadFunction func(chunk *chunks.Chunk)
+
+	provider Provider
+	writer   *Writer
+}
+
+// NewAsyncProvider returns a new asynchronous provider saving chunks using the given provider,
+// queueing the saves on the given writer.
+func NewAsyncProvider(provider Provider, writer *Writer) *AsyncProvider {
+	return &AsyncProvider{LoadFunction: func(*chunks.Chunk) {}, provider: provider, writer: writer}
+}
+
+// Load loads the chunk at the given chunk coordinates using the wrapped provider.
+func (async *AsyncProvider) Load(dimension *worlds.Dimension, x, z int32, function func(*chunks.Chunk)) {
+	async.provider.Load(dimension, x, z, func(chunk *chunks.Chunk) {
+		async.LoadFunction(chunk)
+		function(chunk)
+	})
+}
+
+// Save queues the chunk to be saved, blocking while the queue of the writer is full.
+func (async *AsyncProvider) Save(chunk *chunks.Chunk) {
+	async.writer.Write(async.save(chunk, DirtyAll()))
+}
+
+// SavePartial queues the changed parts of the chunk to be saved, blocking while the queue of the writer is full.
+// The whole chunk is saved if the wrapped provider does not support partial saves.
+func (async *AsyncProvider) SavePartial(chunk *chunks.Chunk, dirty Dirty) {
+	async.writer.Write(async.save(chunk, dirty))
+}
+
+// TrySave queues the changed parts of the chunk to be saved without blocking.
+// A bool is returned indicating if the chunk was queued, which it is not if the queue of the writer is full.
+func (async *AsyncProvider) TrySave(chunk *chunks.Chunk, dirty Dirty) bool {
+	return async.writer.TryWrite(async.save(chunk, dirty))
+}
+
+// Flush blocks until all queued chunks have been saved.
+func (async *AsyncProvider) Flush() {
+	async.writer.Flush()
+}
+
+// Close saves all queued chunks and closes the wrapped provider.
+// The provider may no longer be used after closing.
+func (async *AsyncProvider) Close() {
+	async.writer.Write(func() error {
+		async.provider.Close()
+		return nil
+	})
+	async.writer.Flush()
+}
+
+// save returns the write saving the changed parts of the chunk using the wrapped provider.
+func (async *AsyncProvider) save(chunk *chunks.Chunk, dirty Dirty) func() error {
+	return func() error {
+		saveDirty(async.provider, chunk, dirty)
+		return nil
+	}
+}
@@ -0,0 +1,257 @@
+package levels
+
+import (
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+const (
+	// sectorSize is the size of a sector in a region file.
+	sectorSize = 4096
+	// regionChunks is the amount of chunks in a region file.
+	regionChunks = 32 * 32
+)
+
+// Compression types of chunks stored in region files.
+const (
+	CompressionGzip = 1
+	CompressionZlib = 2
+	CompressionNone = 3
+)
+
+// InvalidRegion gets returned when a region file is corrupted.
+var InvalidRegion = errors.New("invalid region file")
+
+// StorageStats are the sizes of files stored on disk.
+type StorageStats struct {
+	// Size is the total size of all files in bytes.
+	Size int64
+	// Chunks is the amount of chunks stored in region files.
+	Chunks int
+	// Regions is the amount of region files.
+	Regions int
+}
+
+// add adds the sizes of the stats to the stats.
+func (stats *StorageStats) add(other StorageStats) {
+	stats.Size += other.Size
+	stats.Chunks += other.Chunks
+	stats.Regions += other.Regions
+}
+
+// LevelStats are the sizes of all files of a level stored on disk.
+type LevelStats struct {
+	StorageStats
+	// Dimensions holds the stats of the directory of every dimension of the level.
+	Dimensions map[string]StorageStats
+	// RegionFiles holds the stats of every region file of the level, by their path relative to the level.
+	RegionFiles map[string]StorageStats
+}
+
+// ReadRegionStats returns the size and amount of chunks of the region file at the given path.
+func ReadRegionStats(path string) (StorageStats, error) {
+	var file, err = os.Open(path)
+	if err != nil {
+		return StorageStats{}, err
+	}
+	defer file.Close()
+	info, err := file.Stat()
+	if err != nil {
+		return StorageStats{}, err
+	}
+	var stats = StorageStats{Size: info.Size(), Regions: 1}
+	if info.Size() == 0 {
+		return stats, nil
+	}
+	var locations = make([]byte, regionChunks*4)
+	if _, err := file.ReadAt(locations, 0); err != nil {
+		return stats, InvalidRegion
+	}
+	for i := 0; i < regionChunks; i++ {
+		if binary.BigEndian.Uint32(locations[i*4:]) != 0 {
+			stats.Chunks++
+		}
+	}
+	return stats, nil
+}
+
+// ListRegionChunks returns the chunk coordinates of all chunks stored in the region file at the given path.
+// The coordinates of the region are read from the name of the file, such as r.-1.2.mca.
+func ListRegionChunks(path string) ([][2]int32, error) {
+	var fragments = strings.Split(filepath.Base(path), ".")
+	if len(fragments) != 4 || fragments[0] != "r" {
+		return nil, InvalidRegion
+	}
+	var regionX, errX = strconv.Atoi(fragments[1])
+	var regionZ, errZ = strconv.Atoi(fragments[2])
+	if errX != nil || errZ != nil {
+		return nil, InvalidRegion
+	}
+	var file, err = os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	var locations = make([]byte, regionChunks*4)
+	if _, err := file.ReadAt(locations, 0); err != nil {
+		return nil, InvalidRegion
+	}
+	var coordinates [][2]int32
+	for i := 0; i < regionChunks; i++ {
+		if binary.BigEndian.Uint32(locations[i*4:]) != 0 {
+			coordinates = append(coordinates, [2]int32{int32(regionX*32 + i%32), int32(regionZ*32 + i/32)})
+		}
+	}
+	return coordinates, nil
+}
+
+// ReadStorageStats returns the stats of all files in the directory of a level,
+// with the stats of every dimension directory and region file in it.
+func ReadStorageStats(path string) (LevelStats, error) {
+	var stats = LevelStats{Dimensions: make(map[string]StorageStats), RegionFiles: make(map[string]StorageStats)}
+	var err = filepath.Walk(path, func(filePath string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return err
+		}
+		var fileStats = StorageStats{Size: info.Size()}
+		var relative, _ = filepath.Rel(path, filePath)
+		relative = filepath.ToSlash(relative)
+		if isRegionFile(filePath) {
+			if fileStats, err = ReadRegionStats(filePath); err != nil {
+				return err
+			}
+			stats.RegionFiles[relative] = fileStats
+		}
+		stats.add(fileStats)
+		if fragments := strings.SplitN(relative, "/", 2); len(fragments) == 2 {
+			var dimension = stats.Dimensions[fragments[0]]
+			dimension.add(fileStats)
+			stats.Dimensions[fragments[0]] = dimension
+		}
+		return nil
+	})
+	return stats, err
+}
+
+// RecompressRegion rewrites the region file at the given path with all chunks compressed
+// using zlib at the given compression level, removing unused sectors in the process.
+// Chunks are streamed from the region file one at a time, so that large region files are never fully read into memory.
+// The amount of bytes saved gets returned, which is negative if the region file grew.
+// The region file may not be opened by a provider while it gets recompressed.
+func RecompressRegion(path string, level int) (int64, error) {
+	if info, err := os.Stat(path); err != nil || info.Size() == 0 {
+		return 0, err
+	}
+	var reader, err = OpenRegion(path, ReadModeStream)
+	if err != nil {
+		return 0, err
+	}
+	defer reader.Close()
+	var temporary = path + ".tmp"
+	output, err := os.OpenFile(temporary, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		return 0, err
+	}
+	var size, writeErr = writeRecompressedRegion(reader, output, level)
+	if closeErr := output.Close(); writeErr == nil {
+		writeErr = closeErr
+	}
+	if writeErr != nil {
+		os.Remove(temporary)
+		return 0, fmt.Errorf("recompress %v: %w", path, writeErr)
+	}
+	var saved = reader.size - size
+	reader.Close()
+	if err := os.Rename(temporary, path); err != nil {
+		return 0, err
+	}
+	return saved, nil
+}
+
+// writeRecompressedRegion writes all chunks of the region reader compressed at the level to the output,
+// followed by the header. The size of the written region file is returned.
+func writeRecompressedRegion(reader *RegionReader, output *os.File, level int) (int64, error) {
+	var header = make([]byte, sectorSize*2)
+	copy(header[sectorSize:], reader.header[sectorSize:])
+	if _, err := output.Write(header); err != nil {
+		return 0, err
+	}
+	var size = int64(len(header))
+	for i := 0; i < regionChunks; i++ {
+		var chunk, err = reader.ReadChunk(i)
+		if err == ChunkNotGenerated {
+			continue
+		}
+		if err != nil {
+			return 0, fmt.Errorf("chunk %v: %w", i, err)
+		}
+		var compressed = bytes.NewBuffer(nil)
+		var writer, _ = zlib.NewWriterLevel(compressed, level)
+		writer.Write(chunk)
+		writer.Close()
+
+		var payload = make([]byte, 5, 5+compressed.Len())
+		binary.BigEndian.PutUint32(payload, uint32(compressed.Len()+1))
+		payload[4] = CompressionZlib
+		payload = append(payload, compressed.Bytes()...)
+		var sectors = (len(payload) + sectorSize - 1) / sectorSize
+		if sectors > 255 {
+			return 0, InvalidRegion
+		}
+		binary.BigEndian.PutUint32(header[i*4:], uint32(size/sectorSize)<<8|uint32(sectors))
+		if _, err := output.Write(append(payload, make([]byte, sectors*sectorSize-len(payload))...)); err != nil {
+			return 0, err
+		}
+		size += int64(sectors * sectorSize)
+	}
+	_, err := output.WriteAt(header, 0)
+	return size, err
+}
+
+// readRegionChunk returns the decompressed data of the chunk at the offset in the region file.
+func readRegionChunk(file []byte, offset int) ([]byte, error) {
+	if offset+5 > len(file) {
+		return nil, InvalidRegion
+	}
+	var length = int(binary.BigEndian.Uint32(file[offset:]))
+	if length < 1 || offset+4+length > len(file) {
+		return nil, InvalidRegion
+	}
+	return decompressChunk(file[offset+4], file[offset+5:offset+4+length])
+}
+
+// decompressChunk returns the chunk data decompressed using the compression type.
+func decompressChunk(compression byte, data []byte) ([]byte, error) {
+	switch compression {
+	case CompressionGzip:
+		var reader, err = gzip.NewReader(bytes.NewReader(data))
+		if err != nil {
+			return nil, err
+		}
+		return ioutil.ReadAll(reader)
+	case CompressionZlib:
+		var reader, err = zlib.NewReader(bytes.NewReader(data))
+		if err != nil {
+			return nil, err
+		}
+		return ioutil.ReadAll(reader)
+	case CompressionNone:
+		return data, nil
+	}
+	return nil, InvalidRegion
+}
+
+// isRegionFile checks if the file at the path is a region file.
+func isRegionFile(path string) bool {
+	var extension = filepath.Ext(path)
+	return extension == ".mca" || extension == ".mcr"
+}
@@ -0,0 +1,19 @@
+//go:build linux || darwin || freebsd || netbsd || openbsd
+// +build linux darwin freebsd netbsd openbsd
+
+package levels
+
+import (
+	"os"
+	"syscall"
+)
+
+// mapFile maps the file with the given size into memory as read only.
+func mapFile(file *os.File, size int64) ([]byte, error) {
+	return syscall.Mmap(int(file.Fd()), 0, int(size), syscall.PROT_READ, syscall.MAP_SHARED)
+}
+
+// unmapFile unmaps the data of a file mapped into memory.
+func unmapFile(data []byte) error {
+	return syscall.Munmap(data)
+}
@@ -0,0 +1,18 @@
+//go:build !linux && !darwin && !freebsd && !netbsd && !openbsd
+// +build !linux,!darwin,!freebsd,!netbsd,!openbsd
+
+package levels
+
+import (
+	"os"
+)
+
+// mapFile returns MemoryMapUnsupported, as memory mapping is not supported on this platform.
+func mapFile(file *os.File, size int64) ([]byte, error) {
+	return nil, MemoryMapUnsupported
+}
+
+// unmapFile does nothing, as files are never mapped on this platform.
+func unmapFile(data []byte) error {
+	return nil
+}
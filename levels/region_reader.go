@@ -0,0 +1,150 @@
+package levels
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+	"io/ioutil"
+	"os"
+)
+
+// Read modes of region files, which control how chunks are read from region files.
+const (
+	// ReadModeEager reads the whole region file into memory when it is opened.
+	ReadModeEager = "eager"
+	// ReadModeStream only reads the header when a region file is opened,
+	// and reads the sectors of a chunk from disk when the chunk is read.
+	ReadModeStream = "stream"
+	// ReadModeMemoryMap maps the region file into memory, leaving it to the
+	// operating system to page in the sectors of chunks that are read.
+	// Platforms that do not support memory mapping fall back to streaming.
+	ReadModeMemoryMap = "mmap"
+)
+
+var (
+	// UnknownReadMode gets returned when a region file is opened with an unknown read mode.
+	UnknownReadMode = errors.New("unknown region read mode")
+	// MemoryMapUnsupported gets returned when memory mapping files is not supported on the platform.
+	MemoryMapUnsupported = errors.New("memory mapping is not supported on this platform")
+	// ChunkNotGenerated gets returned when reading a chunk
+	// that has never been generated and saved in a region file.
+	ChunkNotGenerated = errors.New("chunk has not been generated")
+)
+
+// IsReadMode checks if the mode is one of the region read modes.
+func IsReadMode(mode string) bool {
+	return mode == ReadModeEager || mode == ReadModeStream || mode == ReadModeMemoryMap
+}
+
+// RegionReader reads chunks from a region file, using one of the read modes.
+// Only the header of the region file is kept in memory when streaming.
+type RegionReader struct {
+	file   *os.File
+	size   int64
+	header []byte
+	// data holds the whole region file if it was read eagerly or memory mapped.
+	data   []byte
+	mapped bool
+}
+
+// OpenRegion opens the region file at the path using the read mode.
+// InvalidRegion gets returned if the file is too small to hold a header,
+// and UnknownReadMode if the read mode is not one of the read modes.
+// The reader must be closed once it is no longer used.
+func OpenRegion(path string, mode string) (*RegionReader, error) {
+	if !IsReadMode(mode) {
+		return nil, UnknownReadMode
+	}
+	var file, err = os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+	var reader = &RegionReader{file: file, size: info.Size()}
+	if reader.size < sectorSize*2 {
+		file.Close()
+		return nil, InvalidRegion
+	}
+	switch mode {
+	case ReadModeEager:
+		reader.data, err = ioutil.ReadAll(file)
+	case ReadModeMemoryMap:
+		if reader.data, err = mapFile(file, reader.size); err == nil {
+			reader.mapped = true
+		} else if err == MemoryMapUnsupported {
+			err = nil
+		}
+	}
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+	if reader.data != nil {
+		reader.header = reader.data[:sectorSize*2]
+		return reader, nil
+	}
+	reader.header = make([]byte, sectorSize*2)
+	if _, err := file.ReadAt(reader.header, 0); err != nil {
+		file.Close()
+		return nil, InvalidRegion
+	}
+	return reader, nil
+}
+
+// HasChunk checks if the region file holds a chunk at the index in the region.
+func (reader *RegionReader) HasChunk(index int) bool {
+	return binary.BigEndian.Uint32(reader.header[index*4:]) != 0
+}
+
+// GetTimestamp returns the timestamp of the last time the chunk at the index was saved.
+func (reader *RegionReader) GetTimestamp(index int) uint32 {
+	return binary.BigEndian.Uint32(reader.header[sectorSize+index*4:])
+}
+
+// ReadChunk returns the decompressed data of the chunk at the index in the region.
+// ChunkNotGenerated is returned if the region file does not hold the chunk.
+func (reader *RegionReader) ReadChunk(index int) ([]byte, error) {
+	var location = binary.BigEndian.Uint32(reader.header[index*4:])
+	if location == 0 {
+		return nil, ChunkNotGenerated
+	}
+	var offset = int64(location>>8) * sectorSize
+	if reader.data != nil {
+		var chunk, err = readRegionChunk(reader.data, int(offset))
+		if err == nil && reader.mapped && reader.data[offset+4] == CompressionNone {
+			// Uncompressed chunks point into the mapped file, which is unmapped once the reader closes.
+			chunk = append([]byte(nil), chunk...)
+		}
+		return chunk, err
+	}
+	var prefix = make([]byte, 5)
+	if _, err := reader.file.ReadAt(prefix, offset); err != nil {
+		return nil, InvalidRegion
+	}
+	var length = int64(binary.BigEndian.Uint32(prefix))
+	if length < 1 || offset+4+length > reader.size {
+		return nil, InvalidRegion
+	}
+	var data = make([]byte, length-1)
+	if _, err := reader.file.ReadAt(data, offset+5); err != nil && err != io.EOF {
+		return nil, err
+	}
+	return decompressChunk(prefix[4], data)
+}
+
+// Close releases the region file, unmapping it if it was memory mapped.
+func (reader *RegionReader) Close() error {
+	var err error
+	if reader.mapped {
+		err = unmapFile(reader.data)
+	}
+	reader.data, reader.mapped = nil, false
+	if closeErr := reader.file.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
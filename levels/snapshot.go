@@ -0,0 +1,115 @@
+package levels
+
+import (
+	"github.com/irmine/worlds"
+	"github.com/irmine/worlds/chunks"
+)
+
+// Snapshot is an immutable copy of the blocks and biomes of a chunk, as they were when the snapshot was taken.
+// Snapshots may be read from any goroutine, for example by async tasks rendering maps or scanning worlds,
+// without holding locks of the world or racing the tick changing the chunk.
+// Only snapshots of chunks of which all sub chunks store block IDs support reading blocks.
+type Snapshot struct {
+	X, Z int32
+
+	data   []byte
+	column *blockColumn
+}
+
+// NewSnapshot returns a snapshot of the chunk. The snapshot should be taken on the tick,
+// after which it may be passed to other goroutines.
+func NewSnapshot(chunk *chunks.Chunk) *Snapshot {
+	return NewBinarySnapshot(chunk.X, chunk.Z, chunk.ToBinary())
+}
+
+// NewBinarySnapshot returns a snapshot of the chunk at the chunk coordinates holding a copy of the serialized chunk,
+// for example of a chunk read from a region file.
+func NewBinarySnapshot(x, z int32, chunkData []byte) *Snapshot {
+	var snapshot = &Snapshot{X: x, Z: z, data: append([]byte(nil), chunkData...)}
+	snapshot.column, _ = newBlockColumn(snapshot.data)
+	return snapshot
+}
+
+// Snapshot returns a snapshot of the blocks and biomes of the chunk.
+// The snapshot should be taken on the tick, after which it may be passed to other goroutines.
+func (chunk *Chunk) Snapshot() *Snapshot {
+	return NewSnapshot(chunk.Chunk)
+}
+
+// IsSupported checks if the blocks of the snapshot can be read,
+// which is the case if all sub chunks of the chunk store block IDs.
+func (snapshot *Snapshot) IsSupported() bool {
+	return snapshot.column != nil
+}
+
+// GetHeight returns the height of the highest sub chunk of the snapshot in blocks.
+func (snapshot *Snapshot) GetHeight() int {
+	if snapshot.column == nil {
+		return 0
+	}
+	return snapshot.column.height
+}
+
+// GetBlockId returns the ID of the block at the position relative to the chunk.
+// Air is returned for positions outside of the chunk and for unsupported snapshots.
+func (snapshot *Snapshot) GetBlockId(x, y, z int) byte {
+	if snapshot.column == nil || !snapshot.column.contains(x, y, z) {
+		return 0
+	}
+	return snapshot.column.getId(x, y, z)
+}
+
+// GetBlockData returns the data of the block at the position relative to the chunk.
+func (snapshot *Snapshot) GetBlockData(x, y, z int) byte {
+	if snapshot.column == nil || !snapshot.column.contains(x, y, z) {
+		return 0
+	}
+	var index = x<<8 | z<<4 | y&15
+	var data = snapshot.data[1+(y>>4)*subChunkSize+1+subChunkBlocks+index>>1]
+	if index&1 == 0 {
+		return data & 0x0f
+	}
+	return data >> 4
+}
+
+// GetBiome returns the ID of the biome of the column at the position relative to the chunk.
+// A bool is returned indicating if the snapshot holds the biomes of the chunk.
+func (snapshot *Snapshot) GetBiome(x, z int) (byte, bool) {
+	if snapshot.column == nil || x < 0 || x > 15 || z < 0 || z > 15 {
+		return 0, false
+	}
+	var offset = 1 + snapshot.column.height/16*subChunkSize + heightMapSize + (x<<4 | z)
+	if offset >= len(snapshot.data) {
+		return 0, false
+	}
+	return snapshot.data[offset], true
+}
+
+// GetHighestBlock returns the Y of the highest block that is not air in the column at the position relative to the chunk.
+// A bool is returned indicating if the column has any blocks.
+func (snapshot *Snapshot) GetHighestBlock(x, z int) (int, bool) {
+	return GetHighestBlock(snapshot.data, x, z)
+}
+
+// GetBlockLight returns the level of light emitted by blocks in the chunk at the position relative to the chunk.
+func (snapshot *Snapshot) GetBlockLight(x, y, z int) byte {
+	return GetBlockLight(snapshot.data, x, y, z)
+}
+
+// GetSkyLight returns the sky light level at the position relative to the chunk.
+func (snapshot *Snapshot) GetSkyLight(x, y, z int) byte {
+	return GetSkyLight(snapshot.data, x, y, z)
+}
+
+// ToBinary returns a copy of the serialized chunk the snapshot holds.
+func (snapshot *Snapshot) ToBinary() []byte {
+	return append([]byte(nil), snapshot.data...)
+}
+
+// SnapshotChunk loads the chunk at the chunk coordinates in the dimension,
+// and calls the function with a snapshot of it taken right after loading.
+func (manager *Manager) SnapshotChunk(worldsDimension *worlds.Dimension, chunkX, chunkZ int32, function func(snapshot *Snapshot)) {
+	worldsDimension.LoadChunk(chunkX, chunkZ, func(chunk *chunks.Chunk) {
+		function(NewSnapshot(chunk))
+	})
+}
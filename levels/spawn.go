@@ -0,0 +1,164 @@
+package levels
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+
+	"github.com/golang/geo/r3"
+	"github.com/irmine/worlds"
+	"github.com/irmine/worlds/chunks"
+)
+
+const (
+	// subChunkBlocks is the amount of blocks in a sub chunk.
+	subChunkBlocks = 16 * 16 * 16
+	// subChunkSize is the size of a serialized sub chunk storing block IDs and data, including its version.
+	subChunkSize = 1 + subChunkBlocks + subChunkBlocks/2
+	// heightMapSize is the size of the height map following the sub chunks of a serialized chunk.
+	heightMapSize = 16 * 16 * 2
+)
+
+// NoSafeSpawn gets returned when no safe spawn
+// could be found within the search radius.
+var NoSafeSpawn = errors.New("no safe spawn found")
+
+// unsafeBlocks are the IDs of blocks players may not spawn on:
+// air, water, lava, fire, cactus and magma.
+var unsafeBlocks = map[byte]bool{0: true, 8: true, 9: true, 10: true, 11: true, 51: true, 81: true, 213: true}
+
+// passableBlocks are the IDs of blocks players can spawn in:
+// air, tall grass, flowers, snow layers and double plants.
+var passableBlocks = map[byte]bool{0: true, 31: true, 37: true, 38: true, 78: true, 175: true}
+
+// oceanBiomes are the IDs of all ocean biomes, in which players may not spawn.
+var oceanBiomes = map[byte]bool{0: true, 10: true, 24: true, 44: true, 45: true, 46: true, 47: true, 48: true, 49: true, 50: true}
+
+// FindSafeSpawn returns the position relative to the serialized chunk of the safe spawn closest to its center.
+// A spawn is safe if the highest block of its column is solid ground, the two blocks above it are passable
+// and the column is not in an ocean biome.
+// Only chunks of which all sub chunks store block IDs are supported,
+// and a bool is returned indicating if a safe spawn was found.
+func FindSafeSpawn(chunkData []byte) (int, int, int, bool) {
+	return findSafeSpawn(chunkData, 8, 8)
+}
+
+// findSafeSpawn returns the position relative to the serialized chunk of the safe spawn
+// closest to the column at the given position relative to the chunk.
+// A bool is returned indicating if a safe spawn was found.
+func findSafeSpawn(chunkData []byte, centerX, centerZ int) (int, int, int, bool) {
+	if len(chunkData) == 0 {
+		return 0, 0, 0, false
+	}
+	var count = int(chunkData[0])
+	var biomes = 1 + count*subChunkSize + heightMapSize
+	if len(chunkData) < biomes+256 {
+		return 0, 0, 0, false
+	}
+	for i := 0; i < count; i++ {
+		if chunkData[1+i*subChunkSize] != 0 {
+			return 0, 0, 0, false
+		}
+	}
+	var getId = func(x, y, z int) byte {
+		if y >= count*16 {
+			return 0
+		}
+		return chunkData[1+(y>>4)*subChunkSize+1+(x<<8|z<<4|y&15)]
+	}
+
+	var spawnX, spawnY, spawnZ, distance = 0, 0, 0, -1
+	for x := 0; x < 16; x++ {
+		for z := 0; z < 16; z++ {
+			if oceanBiomes[chunkData[biomes+(x<<4|z)]] {
+				continue
+			}
+			var y = count*16 - 1
+			for y >= 0 && passableBlocks[getId(x, y, z)] {
+				y--
+			}
+			if y < 0 || unsafeBlocks[getId(x, y, z)] || !passableBlocks[getId(x, y+1, z)] || !passableBlocks[getId(x, y+2, z)] {
+				continue
+			}
+			var columnDistance = (x-centerX)*(x-centerX) + (z-centerZ)*(z-centerZ)
+			if distance == -1 || columnDistance < distance {
+				spawnX, spawnY, spawnZ, distance = x, y+1, z, columnDistance
+			}
+		}
+	}
+	return spawnX, spawnY, spawnZ, distance != -1
+}
+
+// FindSpawn searches the chunks of the dimension within the radius in chunks around the spawn of its level
+// for a safe spawn, starting with the chunks closest to the spawn.
+// Chunks are loaded one after another, and the spawn of the world gets set to the first safe spawn found.
+// The function gets called once the search finishes, with the spawn of the world and an error,
+// which is UnknownLevel if the level was not opened, or NoSafeSpawn if no safe spawn was found.
+func (manager *Manager) FindSpawn(worldsDimension *worlds.Dimension, radius int32, function func(spawn r3.Vector, err error)) {
+	var world, ok = manager.GetWorld(worldsDimension.GetLevel().GetName())
+	if !ok {
+		function(DefaultSpawn, UnknownLevel)
+		return
+	}
+	var spawn = world.GetSpawn()
+	var centerX, centerZ = int32(spawn.X) >> 4, int32(spawn.Z) >> 4
+	var positions [][2]int32
+	for ring := int32(0); ring <= radius; ring++ {
+		for x := -ring; x <= ring; x++ {
+			for z := -ring; z <= ring; z++ {
+				if x == -ring || x == ring || z == -ring || z == ring {
+					positions = append(positions, [2]int32{centerX + x, centerZ + z})
+				}
+			}
+		}
+	}
+
+	var search func(index int)
+	search = func(index int) {
+		if index == len(positions) {
+			function(world.GetSpawn(), NoSafeSpawn)
+			return
+		}
+		var chunkX, chunkZ = positions[index][0], positions[index][1]
+		worldsDimension.LoadChunk(chunkX, chunkZ, func(chunk *chunks.Chunk) {
+			var x, y, z, ok = FindSafeSpawn(chunk.ToBinary())
+			if !ok {
+				search(index + 1)
+				return
+			}
+			var safeSpawn = r3.Vector{X: float64(chunkX<<4+int32(x)) + 0.5, Y: float64(y), Z: float64(chunkZ<<4+int32(z)) + 0.5}
+			world.SetSpawn(safeSpawn)
+			function(safeSpawn, nil)
+		})
+	}
+	search(0)
+}
+
+// FindRandomSpawn searches a safe spawn at a random position within the radius in blocks around the center,
+// for example to teleport players to a random location in the wild.
+// The chunk of every random position is loaded, or generated if it does not yet exist,
+// and the safe spawn in it closest to the random position is used.
+// The function gets called once the search finishes, with the safe spawn found and an error,
+// which is NoSafeSpawn if none of the attempted positions had a safe spawn.
+func (manager *Manager) FindRandomSpawn(worldsDimension *worlds.Dimension, center r3.Vector, radius int32, attempts int, function func(spawn r3.Vector, err error)) {
+	var search func(attempt int)
+	search = func(attempt int) {
+		if attempt >= attempts {
+			function(center, NoSafeSpawn)
+			return
+		}
+		var distance = float64(radius) * math.Sqrt(rand.Float64())
+		var angle = rand.Float64() * 2 * math.Pi
+		var x, z = int32(math.Floor(center.X + distance*math.Cos(angle))), int32(math.Floor(center.Z + distance*math.Sin(angle)))
+		var chunkX, chunkZ = x >> 4, z >> 4
+		worldsDimension.LoadChunk(chunkX, chunkZ, func(chunk *chunks.Chunk) {
+			var spawnX, spawnY, spawnZ, ok = findSafeSpawn(chunk.ToBinary(), int(x&15), int(z&15))
+			if !ok {
+				search(attempt + 1)
+				return
+			}
+			function(r3.Vector{X: float64(chunkX<<4+int32(spawnX)) + 0.5, Y: float64(spawnY), Z: float64(chunkZ<<4+int32(spawnZ)) + 0.5}, nil)
+		})
+	}
+	search(0)
+}
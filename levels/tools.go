@@ -0,0 +1,185 @@
+package levels
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"path/filepath"
+	"sync"
+
+	"github.com/irmine/worlds"
+	"github.com/irmine/worlds/chunks"
+)
+
+// SameFormat gets returned when a level gets converted to the format it is already stored in.
+var SameFormat = errors.New("level is already stored in the format")
+
+// Pregenerate loads all chunks of the dimension within the radius in chunks around the given chunk,
+// generating the chunks that were not yet stored, and saves them.
+// Pregenerating chunks lets players join without waiting for chunks to be generated.
+// The progress function is called with the amount of chunks done after every chunk.
+// UnknownLevel gets returned if the dimension was not added to the manager.
+// Chunks are generated one row at a time. Once the context is done, no further rows are generated,
+// the chunks generated so far are saved and the error of the context is returned.
+func (manager *Manager) Pregenerate(ctx context.Context, worldsDimension *worlds.Dimension, centerX, centerZ, radius int32, progress func(done, total int)) error {
+	manager.mutex.Lock()
+	var _, ok = manager.dimensions[worldsDimension]
+	manager.mutex.Unlock()
+	if !ok {
+		return UnknownLevel
+	}
+	var total = int(2*radius+1) * int(2*radius+1)
+	var done int
+	var mutex sync.Mutex
+	for x := centerX - radius; x <= centerX+radius && ctx.Err() == nil; x++ {
+		var wait sync.WaitGroup
+		wait.Add(int(2*radius + 1))
+		for z := centerZ - radius; z <= centerZ+radius; z++ {
+			worldsDimension.LoadChunk(x, z, func(chunk *chunks.Chunk) {
+				manager.MarkDirty(worldsDimension, chunk)
+				mutex.Lock()
+				done++
+				progress(done, total)
+				mutex.Unlock()
+				wait.Done()
+			})
+		}
+		wait.Wait()
+	}
+	if err := manager.Save(); err != nil {
+		return err
+	}
+	return ctx.Err()
+}
+
+// Convert copies all chunks stored in the Anvil region files of the level with the given name
+// into providers of the given format, storing them in the same dimension directories.
+// Only Anvil levels can be converted, as the chunks stored in other formats can not be listed.
+// The level may not be opened while it gets converted.
+// The progress function is called with the amount of chunks converted after every chunk.
+// Converting stops once the context is done, returning the error of the context.
+func (manager *Manager) Convert(ctx context.Context, levelName, format string, progress func(done, total int)) error {
+	if !IsFormatRegistered(format) {
+		return UnknownFormat
+	}
+	if format == FormatAnvil {
+		return SameFormat
+	}
+	var path = manager.GetPath(levelName)
+	var files, err = ioutil.ReadDir(path)
+	if err != nil {
+		return err
+	}
+	var dimensionChunks = make(map[string][][2]int32)
+	var total int
+	for _, file := range files {
+		if !file.IsDir() {
+			continue
+		}
+		var regions, _ = filepath.Glob(path + file.Name() + "/region/r.*.*.mca")
+		for _, region := range regions {
+			var coordinates, err = ListRegionChunks(region)
+			if err != nil {
+				return err
+			}
+			dimensionChunks[file.Name()] = append(dimensionChunks[file.Name()], coordinates...)
+			total += len(coordinates)
+		}
+	}
+
+	var level = worlds.NewLevel(levelName, manager.serverPath)
+	var done int
+	for name, coordinates := range dimensionChunks {
+		var source, _ = NewProvider(FormatAnvil, path+name+"/")
+		var target, err = NewProvider(format, path+name+"/")
+		if err != nil {
+			source.Close()
+			return err
+		}
+		// The dimension is only used to load stored chunks, so its ID does not matter.
+		var dimension = worlds.NewDimension(name, level, worlds.OverworldId)
+		for _, chunk := range coordinates {
+			if err := ctx.Err(); err != nil {
+				source.Close()
+				target.Close()
+				return err
+			}
+			var wait sync.WaitGroup
+			wait.Add(1)
+			source.Load(dimension, chunk[0], chunk[1], func(chunk *chunks.Chunk) {
+				target.Save(chunk)
+				wait.Done()
+			})
+			wait.Wait()
+			done++
+			progress(done, total)
+		}
+		source.Close()
+		target.Close()
+	}
+	return nil
+}
+
+// SnapshotStored takes snapshots of all chunks stored in the Anvil region files of the level with the given name,
+// calling the function with the name of the dimension directory and the snapshots of every region file.
+// Only Anvil levels can be read, as the chunks stored in other formats can not be listed.
+// The progress function is called with the amount of chunks read after every chunk.
+// Reading stops once the context is done, returning the error of the context.
+func (manager *Manager) SnapshotStored(ctx context.Context, levelName string, function func(dimensionName string, snapshots []*Snapshot) error, progress func(done, total int)) error {
+	var path = manager.GetPath(levelName)
+	var files, err = ioutil.ReadDir(path)
+	if err != nil {
+		return err
+	}
+	var regions = make(map[string][][][2]int32)
+	var total int
+	for _, file := range files {
+		if !file.IsDir() {
+			continue
+		}
+		var paths, _ = filepath.Glob(path + file.Name() + "/region/r.*.*.mca")
+		for _, region := range paths {
+			var coordinates, err = ListRegionChunks(region)
+			if err != nil {
+				return err
+			}
+			regions[file.Name()] = append(regions[file.Name()], coordinates)
+			total += len(coordinates)
+		}
+	}
+
+	var level = worlds.NewLevel(levelName, manager.serverPath)
+	var done int
+	for name, dimensionRegions := range regions {
+		var source, err = NewProvider(FormatAnvil, path+name+"/")
+		if err != nil {
+			return err
+		}
+		// The dimension is only used to load stored chunks, so its ID does not matter.
+		var dimension = worlds.NewDimension(name, level, worlds.OverworldId)
+		for _, coordinates := range dimensionRegions {
+			var snapshots = make([]*Snapshot, 0, len(coordinates))
+			for _, chunk := range coordinates {
+				if err := ctx.Err(); err != nil {
+					source.Close()
+					return err
+				}
+				var wait sync.WaitGroup
+				wait.Add(1)
+				source.Load(dimension, chunk[0], chunk[1], func(chunk *chunks.Chunk) {
+					snapshots = append(snapshots, NewSnapshot(chunk))
+					wait.Done()
+				})
+				wait.Wait()
+				done++
+				progress(done, total)
+			}
+			if err := function(name, snapshots); err != nil {
+				source.Close()
+				return err
+			}
+		}
+		source.Close()
+	}
+	return nil
+}
@@ -0,0 +1,288 @@
+package levels
+
+import (
+	"errors"
+	"math/rand"
+	"sync"
+
+	"github.com/golang/geo/r3"
+)
+
+// Weather is the weather of a level.
+type Weather byte
+
+const (
+	WeatherClear Weather = iota
+	WeatherRain
+	WeatherThunder
+)
+
+// Game rules controlling the time and weather cycles of a level.
+// Both cycles are enabled if the game rule is not set.
+const (
+	GameRuleDaylightCycle = "dodaylightcycle"
+	GameRuleWeatherCycle  = "doweathercycle"
+)
+
+// DayLength is the amount of ticks in a day.
+const DayLength = 24000
+
+// InvalidGameRule gets returned when a game rule is set
+// to a value that is not a bool, uint32 or float32.
+var InvalidGameRule = errors.New("game rule value must be a bool, uint32 or float32")
+
+// World is the metadata, time, weather and game rules of an opened level.
+// The time progresses every tick while the daylight cycle is enabled,
+// and the weather changes randomly while the weather cycle is enabled.
+// The age of the world progresses every tick, regardless of the game rules.
+// Changes are passed to the functions of the manager, so they can be sent to the viewers of the level.
+type World struct {
+	mutex       sync.Mutex
+	name        string
+	manager     *Manager
+	time        int64
+	age         int64
+	weather     Weather
+	weatherTime int64
+	gameRules   map[string]interface{}
+
+	seed             int64
+	spawn            r3.Vector
+	generator        int32
+	generatorOptions string
+}
+
+// newWorld returns a new world of the level with the given name,
+// with the metadata, time and weather stored in the level data.
+func newWorld(manager *Manager, name string, data *Data) *World {
+	var world = &World{name: name, manager: manager, time: data.Time, age: data.CurrentTick, weatherTime: int64(data.RainTime), gameRules: make(map[string]interface{})}
+	world.seed, world.spawn = data.Seed, data.GetSpawn()
+	world.generator, world.generatorOptions = data.Generator, data.GeneratorOptions
+	if data.LightningLevel > 0 {
+		world.weather = WeatherThunder
+	} else if data.RainLevel > 0 {
+		world.weather = WeatherRain
+	}
+	if world.weatherTime <= 0 {
+		world.weatherTime = randomWeatherTime(world.weather)
+	}
+	return world
+}
+
+// GetName returns the name of the level of the world.
+func (world *World) GetName() string {
+	return world.name
+}
+
+// GetTime returns the time of the world in ticks.
+func (world *World) GetTime() int64 {
+	world.mutex.Lock()
+	defer world.mutex.Unlock()
+	return world.time
+}
+
+// SetTime sets the time of the world in ticks and sends it to all viewers.
+func (world *World) SetTime(time int64) {
+	world.mutex.Lock()
+	world.time = time
+	world.mutex.Unlock()
+	world.manager.TimeFunction(world.name, time)
+}
+
+// GetAge returns the amount of ticks the world has been running for.
+func (world *World) GetAge() int64 {
+	world.mutex.Lock()
+	defer world.mutex.Unlock()
+	return world.age
+}
+
+// GetSeed returns the seed of the world.
+func (world *World) GetSeed() int64 {
+	world.mutex.Lock()
+	defer world.mutex.Unlock()
+	return world.seed
+}
+
+// SetSeed sets the seed of the world.
+// Chunks that were already generated are not affected.
+func (world *World) SetSeed(seed int64) {
+	world.mutex.Lock()
+	world.seed = seed
+	world.mutex.Unlock()
+}
+
+// GetSpawn returns the spawn point of the world.
+func (world *World) GetSpawn() r3.Vector {
+	world.mutex.Lock()
+	defer world.mutex.Unlock()
+	return world.spawn
+}
+
+// SetSpawn sets the spawn point of the world.
+// The spawn point is rounded down to whole blocks once the world is saved.
+func (world *World) SetSpawn(spawn r3.Vector) {
+	world.mutex.Lock()
+	world.spawn = spawn
+	world.mutex.Unlock()
+}
+
+// GetGenerator returns the ID of the generator of the world.
+func (world *World) GetGenerator() int32 {
+	world.mutex.Lock()
+	defer world.mutex.Unlock()
+	return world.generator
+}
+
+// GetGeneratorOptions returns the settings of the generator of the world.
+func (world *World) GetGeneratorOptions() string {
+	world.mutex.Lock()
+	defer world.mutex.Unlock()
+	return world.generatorOptions
+}
+
+// SetGenerator sets the ID and settings of the generator of the world.
+// Chunks that were already generated are not affected.
+func (world *World) SetGenerator(generator int32, options string) {
+	world.mutex.Lock()
+	world.generator, world.generatorOptions = generator, options
+	world.mutex.Unlock()
+}
+
+// GetPreset returns the generator preset of the world,
+// which is the default preset unless the world uses the infinite generator with a preset.
+func (world *World) GetPreset() Preset {
+	world.mutex.Lock()
+	defer world.mutex.Unlock()
+	return getPreset(world.generator, world.generatorOptions)
+}
+
+// GetWeather returns the current weather of the world.
+func (world *World) GetWeather() Weather {
+	world.mutex.Lock()
+	defer world.mutex.Unlock()
+	return world.weather
+}
+
+// SetWeather sets the weather of the world for the given amount of ticks and sends it to all viewers.
+// The weather changes once the duration has passed if the weather cycle is enabled.
+// A duration of 0 or lower picks a random duration.
+func (world *World) SetWeather(weather Weather, duration int64) {
+	if duration <= 0 {
+		duration = randomWeatherTime(weather)
+	}
+	world.mutex.Lock()
+	var previous = world.weather
+	world.weather = weather
+	world.weatherTime = duration
+	world.mutex.Unlock()
+	if previous != weather {
+		world.manager.WeatherFunction(world.name, weather)
+	}
+}
+
+// GetGameRules returns a copy of the game rules set in the world.
+func (world *World) GetGameRules() map[string]interface{} {
+	world.mutex.Lock()
+	defer world.mutex.Unlock()
+	var gameRules = make(map[string]interface{}, len(world.gameRules))
+	for name, value := range world.gameRules {
+		gameRules[name] = value
+	}
+	return gameRules
+}
+
+// GetGameRule returns the value of the game rule with the given name.
+// A bool is returned indicating if the game rule was set.
+func (world *World) GetGameRule(name string) (interface{}, bool) {
+	world.mutex.Lock()
+	defer world.mutex.Unlock()
+	var value, ok = world.gameRules[name]
+	return value, ok
+}
+
+// SetGameRule sets the game rule with the given name and sends it to all viewers.
+// The value must be a bool, uint32 or float32, otherwise InvalidGameRule is returned.
+func (world *World) SetGameRule(name string, value interface{}) error {
+	switch value.(type) {
+	case bool, uint32, float32:
+	default:
+		return InvalidGameRule
+	}
+	world.mutex.Lock()
+	world.gameRules[name] = value
+	world.mutex.Unlock()
+	world.manager.GameRuleFunction(world.name, name, value)
+	return nil
+}
+
+// tick progresses the time and weather of the world.
+// The time is sent to all viewers every time interval of the manager.
+func (world *World) tick() {
+	world.mutex.Lock()
+	world.age++
+	var time, broadcastTime = world.time, false
+	if world.isEnabled(GameRuleDaylightCycle) {
+		world.time++
+		time = world.time
+		broadcastTime = world.manager.TimeInterval > 0 && time%world.manager.TimeInterval == 0
+	}
+	var weather, weatherChanged = world.weather, false
+	if world.isEnabled(GameRuleWeatherCycle) {
+		if world.weatherTime--; world.weatherTime <= 0 {
+			weather = WeatherClear
+			if world.weather == WeatherClear {
+				weather = WeatherRain
+				if rand.Intn(4) == 0 {
+					weather = WeatherThunder
+				}
+			}
+			world.weather = weather
+			world.weatherTime = randomWeatherTime(weather)
+			weatherChanged = true
+		}
+	}
+	world.mutex.Unlock()
+
+	if broadcastTime {
+		world.manager.TimeFunction(world.name, time)
+	}
+	if weatherChanged {
+		world.manager.WeatherFunction(world.name, weather)
+	}
+}
+
+// store stores the metadata, time and weather of the world in the level data.
+func (world *World) store(data *Data) {
+	world.mutex.Lock()
+	defer world.mutex.Unlock()
+	data.Seed = world.seed
+	data.SetSpawn(world.spawn)
+	data.Generator, data.GeneratorOptions = world.generator, world.generatorOptions
+	data.Time = world.time
+	data.CurrentTick = world.age
+	data.RainTime = int32(world.weatherTime)
+	data.LightningTime = int32(world.weatherTime)
+	data.RainLevel, data.LightningLevel = 0, 0
+	if world.weather != WeatherClear {
+		data.RainLevel = 1
+	}
+	if world.weather == WeatherThunder {
+		data.LightningLevel = 1
+	}
+}
+
+// isEnabled checks if the bool game rule with the given name is enabled,
+// which it is if it was not set.
+func (world *World) isEnabled(gameRule string) bool {
+	var value, ok = world.gameRules[gameRule].(bool)
+	return !ok || value
+}
+
+// randomWeatherTime returns a random amount of ticks the weather lasts.
+// Clear weather lasts half a day to seven and a half days, rain and thunder half a day to a day.
+func randomWeatherTime(weather Weather) int64 {
+	if weather == WeatherClear {
+		return DayLength/2 + rand.Int63n(DayLength*7)
+	}
+	return DayLength/2 + rand.Int63n(DayLength/2)
+}
@@ -0,0 +1,77 @@
+package levels
+
+// WriteQueueSize is the amount of writes that can be queued before writing blocks.
+const WriteQueueSize = 1024
+
+// Writer performs disk writes on a dedicated goroutine, in the order they were queued.
+// Writes are queued in a bounded write-behind queue, so that disk latency does not stall the server tick.
+// Once the queue fills up, the writer signals it is busy so that autosaving can be postponed.
+type Writer struct {
+	// ErrorFunction gets called on the writer goroutine with every error returned by a write.
+	ErrorFunction func(err error)
+
+	queue chan func() error
+	done  chan struct{}
+}
+
+// NewWriter returns a new writer with a queue of the given size, and starts its goroutine.
+func NewWriter(size int) *Writer {
+	var writer = &Writer{ErrorFunction: func(error) {}, queue: make(chan func() error, size), done: make(chan struct{})}
+	go writer.process()
+	return writer
+}
+
+// Write queues the write, blocking until there is room in the queue.
+func (writer *Writer) Write(write func() error) {
+	writer.queue <- write
+}
+
+// TryWrite queues the write without blocking.
+// A bool is returned indicating if the write was queued, which it is not if the queue is full.
+func (writer *Writer) TryWrite(write func() error) bool {
+	select {
+	case writer.queue <- write:
+		return true
+	default:
+		return false
+	}
+}
+
+// GetPending returns the amount of queued writes that have not yet been started.
+func (writer *Writer) GetPending() int {
+	return len(writer.queue)
+}
+
+// IsBusy checks if the queue is filled for more than three quarters,
+// meaning the disk can not keep up with the writes queued.
+func (writer *Writer) IsBusy() bool {
+	return len(writer.queue) >= cap(writer.queue)*3/4
+}
+
+// Flush blocks until all writes queued before calling Flush have been performed.
+// Flush may not be called from within a write.
+func (writer *Writer) Flush() {
+	var flushed = make(chan struct{})
+	writer.Write(func() error {
+		close(flushed)
+		return nil
+	})
+	<-flushed
+}
+
+// Close performs all queued writes and stops the goroutine of the writer.
+// The writer may no longer be used after closing.
+func (writer *Writer) Close() {
+	close(writer.queue)
+	<-writer.done
+}
+
+// process performs all queued writes until the writer gets closed.
+func (writer *Writer) process() {
+	for write := range writer.queue {
+		if err := write(); err != nil {
+			writer.ErrorFunction(err)
+		}
+	}
+	close(writer.done)
+}
@@ -0,0 +1,121 @@
+package limits
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// Kinds of soft limits.
+const (
+	// KindLoadedChunks is the limit of the total amount of chunks loaded by all players.
+	KindLoadedChunks = "loadedChunks"
+	// KindEntities is the limit of the total amount of entities.
+	KindEntities = "entities"
+	// KindPlayerChunks is the limit of the amount of chunks loaded by a single player.
+	KindPlayerChunks = "playerChunks"
+)
+
+// DefaultAlertInterval is the default minimum interval between alerts of the same violation.
+const DefaultAlertInterval = time.Minute
+
+// Limits holds the soft limits of the server. A limit of 0 or lower disables it.
+// Soft limits are never enforced, but exceeding them alerts operators and may degrade the server.
+type Limits struct {
+	LoadedChunks int
+	Entities     int
+	PlayerChunks int
+}
+
+// Usage is the usage of the resources bounded by soft limits at a moment.
+type Usage struct {
+	LoadedChunks int
+	Entities     int
+	// PlayerChunks is a player name => amount of chunks loaded map.
+	PlayerChunks map[string]int
+}
+
+// Violation is a soft limit being exceeded.
+type Violation struct {
+	Kind string
+	// Name is the name of the player exceeding a per player limit, or empty for server wide limits.
+	Name  string
+	Value int
+	Limit int
+}
+
+// Monitor checks the usage of the server against soft limits.
+// Violations are passed to the alert function, at most once every alert interval per violation.
+type Monitor struct {
+	Limits
+	// AlertInterval is the minimum interval between alerts of the same violation.
+	AlertInterval time.Duration
+	// AlertFunction gets called for every violation that was not alerted within the alert interval.
+	AlertFunction func(violation Violation)
+	// OverloadFunction gets called every time the server becomes overloaded or recovers,
+	// which is when any server wide limit starts or stops being exceeded.
+	OverloadFunction func(overloaded bool)
+
+	mutex      sync.Mutex
+	lastAlerts map[Violation]time.Time
+	overloaded bool
+}
+
+// NewMonitor returns a new monitor checking against the given limits.
+func NewMonitor(limits Limits) *Monitor {
+	return &Monitor{Limits: limits, AlertInterval: DefaultAlertInterval, AlertFunction: func(Violation) {}, OverloadFunction: func(bool) {}, lastAlerts: make(map[Violation]time.Time)}
+}
+
+// Check checks the usage against the limits, alerting violations and updating the overloaded state.
+// All violations are returned, including those that were not alerted again.
+func (monitor *Monitor) Check(usage Usage, now time.Time) []Violation {
+	var violations []Violation
+	if monitor.LoadedChunks > 0 && usage.LoadedChunks > monitor.LoadedChunks {
+		violations = append(violations, Violation{Kind: KindLoadedChunks, Value: usage.LoadedChunks, Limit: monitor.LoadedChunks})
+	}
+	if monitor.Entities > 0 && usage.Entities > monitor.Entities {
+		violations = append(violations, Violation{Kind: KindEntities, Value: usage.Entities, Limit: monitor.Entities})
+	}
+	var overloaded = len(violations) > 0
+	if monitor.PlayerChunks > 0 {
+		var names = make([]string, 0, len(usage.PlayerChunks))
+		for name, chunks := range usage.PlayerChunks {
+			if chunks > monitor.PlayerChunks {
+				names = append(names, name)
+			}
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			violations = append(violations, Violation{Kind: KindPlayerChunks, Name: name, Value: usage.PlayerChunks[name], Limit: monitor.PlayerChunks})
+		}
+	}
+
+	var alerts []Violation
+	monitor.mutex.Lock()
+	for _, violation := range violations {
+		// Violations are throttled by their kind and player, not by their value.
+		var key = Violation{Kind: violation.Kind, Name: violation.Name}
+		if last, ok := monitor.lastAlerts[key]; !ok || now.Sub(last) >= monitor.AlertInterval {
+			monitor.lastAlerts[key] = now
+			alerts = append(alerts, violation)
+		}
+	}
+	var changed = overloaded != monitor.overloaded
+	monitor.overloaded = overloaded
+	monitor.mutex.Unlock()
+
+	for _, violation := range alerts {
+		monitor.AlertFunction(violation)
+	}
+	if changed {
+		monitor.OverloadFunction(overloaded)
+	}
+	return violations
+}
+
+// IsOverloaded checks if a server wide limit was exceeded during the last check.
+func (monitor *Monitor) IsOverloaded() bool {
+	monitor.mutex.Lock()
+	defer monitor.mutex.Unlock()
+	return monitor.overloaded
+}
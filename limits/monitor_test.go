@@ -0,0 +1,46 @@
+package limits
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCheck(t *testing.T) {
+	var alerts []Violation
+	var states []bool
+	monitor := NewMonitor(Limits{LoadedChunks: 100, Entities: 50, PlayerChunks: 20})
+	monitor.AlertFunction = func(violation Violation) {
+		alerts = append(alerts, violation)
+	}
+	monitor.OverloadFunction = func(overloaded bool) {
+		states = append(states, overloaded)
+	}
+	start := time.Now()
+
+	violations := monitor.Check(Usage{LoadedChunks: 120, Entities: 10, PlayerChunks: map[string]int{"Steve": 30, "Alex": 10}}, start)
+	if len(violations) != 2 || violations[0].Kind != KindLoadedChunks || violations[1].Name != "Steve" || violations[1].Value != 30 {
+		t.Error("unexpected violations:", violations)
+	}
+	if len(alerts) != 2 || !monitor.IsOverloaded() || len(states) != 1 || !states[0] {
+		t.Error("violations were not alerted:", alerts, states)
+	}
+
+	monitor.Check(Usage{LoadedChunks: 130, PlayerChunks: map[string]int{"Steve": 30}}, start.Add(time.Second))
+	if len(alerts) != 2 {
+		t.Error("violations were alerted again within the alert interval:", alerts)
+	}
+	monitor.Check(Usage{LoadedChunks: 130, PlayerChunks: map[string]int{"Steve": 30}}, start.Add(DefaultAlertInterval))
+	if len(alerts) != 4 || alerts[2].Value != 130 {
+		t.Error("violations were not alerted again after the alert interval:", alerts)
+	}
+
+	monitor.Check(Usage{LoadedChunks: 10, PlayerChunks: map[string]int{"Steve": 30}}, start.Add(DefaultAlertInterval))
+	if monitor.IsOverloaded() || len(states) != 2 || states[1] {
+		t.Error("player limit marked the server as overloaded:", states)
+	}
+
+	disabled := NewMonitor(Limits{})
+	if violations := disabled.Check(Usage{LoadedChunks: 1000, Entities: 1000, PlayerChunks: map[string]int{"Steve": 1000}}, start); len(violations) != 0 {
+		t.Error("disabled limits were violated:", violations)
+	}
+}
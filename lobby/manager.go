@@ -0,0 +1,191 @@
+package lobby
+
+import (
+	"io/ioutil"
+	"os"
+	"sync"
+
+	"github.com/irmine/gomine/items"
+	"github.com/irmine/gomine/net"
+	"github.com/irmine/gomine/net/packets/data"
+	"github.com/irmine/gomine/text"
+	"gopkg.in/yaml.v2"
+)
+
+// Manager manages the lobby profiles of all worlds.
+// Profiles are loaded from a YAML file, indexed by world name.
+type Manager struct {
+	mutex    sync.RWMutex
+	path     string
+	profiles map[string]*Profile
+}
+
+// NewManager returns a new lobby manager using the lobby file at the given path.
+func NewManager(path string) *Manager {
+	return &Manager{path: path, profiles: make(map[string]*Profile)}
+}
+
+// Load loads all lobby profiles from the lobby file.
+// A file with a disabled example profile for the default world gets created if it does not yet exist.
+func (manager *Manager) Load() error {
+	var file, err = ioutil.ReadFile(manager.path)
+	if os.IsNotExist(err) {
+		manager.SetProfile("world", defaultProfile())
+		return manager.Save()
+	}
+	if err != nil {
+		return err
+	}
+	var profiles map[string]*Profile
+	if err := yaml.Unmarshal(file, &profiles); err != nil {
+		return err
+	}
+	for world, profile := range profiles {
+		for _, item := range profile.Hotbar {
+			if _, ok := item.Item.ToStack(); !ok {
+				text.DefaultLogger.Error("Unknown item", item.Item.Id, "in lobby hotbar of", world)
+			}
+		}
+		manager.SetProfile(world, profile)
+	}
+	return nil
+}
+
+// Save saves all lobby profiles to the lobby file.
+func (manager *Manager) Save() error {
+	manager.mutex.RLock()
+	var content, err = yaml.Marshal(manager.profiles)
+	manager.mutex.RUnlock()
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(manager.path, content, 0644)
+}
+
+// SetProfile sets the lobby profile of the world with the given name.
+func (manager *Manager) SetProfile(world string, profile *Profile) {
+	manager.mutex.Lock()
+	manager.profiles[world] = profile
+	manager.mutex.Unlock()
+}
+
+// GetProfile returns the enabled lobby profile of the world with the given name.
+// A bool is returned indicating if the world had an enabled lobby profile.
+func (manager *Manager) GetProfile(world string) (*Profile, bool) {
+	manager.mutex.RLock()
+	defer manager.mutex.RUnlock()
+	var profile, ok = manager.profiles[world]
+	if !ok || !profile.Enabled {
+		return nil, false
+	}
+	return profile, true
+}
+
+// GetProfileOf returns the enabled lobby profile of the world the session is in.
+func (manager *Manager) GetProfileOf(session *net.MinecraftSession) (*Profile, bool) {
+	var dimension = session.GetPlayer().GetDimension()
+	if dimension == nil {
+		return nil, false
+	}
+	return manager.GetProfile(dimension.GetLevel().GetName())
+}
+
+// Apply applies the lobby profile of the world the session is in.
+// The inventory gets cleared if configured and the hotbar items are set,
+// but the inventory is not sent. Apply should be called on join and respawn.
+// A bool is returned indicating if the world had a lobby profile.
+func (manager *Manager) Apply(session *net.MinecraftSession) bool {
+	var profile, ok = manager.GetProfileOf(session)
+	if !ok {
+		return false
+	}
+	var inv = session.GetPlayer().GetInventory()
+	if profile.ClearInventory {
+		inv.SetAll(make([]*items.Stack, inv.GetSize()))
+	}
+	for _, item := range profile.Hotbar {
+		if stack, ok := item.Item.ToStack(); ok {
+			inv.SetItem(stack, item.Slot)
+		}
+	}
+	if profile.DisableBlockChanges {
+		session.SetGameMode(data.GameModeAdventure)
+	}
+	return true
+}
+
+// GetCommand returns the command of the hotbar item the session used.
+// A bool is returned indicating if the stack was a hotbar item with a command.
+func (manager *Manager) GetCommand(session *net.MinecraftSession, stack *items.Stack) (string, bool) {
+	var profile, ok = manager.GetProfileOf(session)
+	if !ok {
+		return "", false
+	}
+	for _, item := range profile.Hotbar {
+		if item.Command != "" && item.IsItem(stack) {
+			return item.Command, true
+		}
+	}
+	return "", false
+}
+
+// AllowsBlockChanges checks if the session may break and place blocks in its world.
+func (manager *Manager) AllowsBlockChanges(session *net.MinecraftSession) bool {
+	var profile, ok = manager.GetProfileOf(session)
+	return !ok || !profile.DisableBlockChanges
+}
+
+// AllowsDamage checks if the session may take damage in its world.
+func (manager *Manager) AllowsDamage(session *net.MinecraftSession) bool {
+	var profile, ok = manager.GetProfileOf(session)
+	return !ok || !profile.DisableDamage
+}
+
+// AllowsHunger checks if the session may lose food in its world.
+func (manager *Manager) AllowsHunger(session *net.MinecraftSession) bool {
+	var profile, ok = manager.GetProfileOf(session)
+	return !ok || !profile.DisableHunger
+}
+
+// AllowsEntityAI checks if mobs may run their behaviors in the world with the given name.
+func (manager *Manager) AllowsEntityAI(world string) bool {
+	var profile, ok = manager.GetProfile(world)
+	return !ok || !profile.DisableEntityAI
+}
+
+// AllowsItemMerging checks if items may merge in the world with the given name.
+func (manager *Manager) AllowsItemMerging(world string) bool {
+	var profile, ok = manager.GetProfile(world)
+	return !ok || !profile.DisableItemMerging
+}
+
+// AllowsRedstone checks if redstone may be simulated in the world with the given name.
+func (manager *Manager) AllowsRedstone(world string) bool {
+	var profile, ok = manager.GetProfile(world)
+	return !ok || !profile.DisableRedstone
+}
+
+// AllowsRandomTicks checks if blocks may be randomly ticked in the world with the given name.
+func (manager *Manager) AllowsRandomTicks(world string) bool {
+	var profile, ok = manager.GetProfile(world)
+	return !ok || !profile.DisableRandomTicks
+}
+
+// defaultProfile returns the example lobby profile written to new lobby files.
+// The profile is disabled, and gives a cosmetics menu and rewards item.
+func defaultProfile() *Profile {
+	return &Profile{
+		DisableHunger:       true,
+		DisableDamage:       true,
+		DisableBlockChanges: true,
+		DisableEntityAI:     true,
+		DisableItemMerging:  true,
+		DisableRedstone:     true,
+		DisableRandomTicks:  true,
+		ClearInventory:      true,
+		Hotbar: []HotbarItem{
+			{0, items.Record{Id: "minecraft:paper", Count: 1, DisplayName: text.Yellow + "Cosmetics"}, "cosmetics trail"},
+			{8, items.Record{Id: "minecraft:emerald", Count: 1, DisplayName: text.BrightGreen + "Rewards"}, "rewards"},
+		},
+	}
+}
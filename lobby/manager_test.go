@@ -0,0 +1,50 @@
+package lobby
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/irmine/gomine/items"
+)
+
+func TestLoad(t *testing.T) {
+	dir, _ := ioutil.TempDir("", "lobby")
+	defer os.RemoveAll(dir)
+
+	manager := NewManager(dir + "/lobby.yml")
+	if err := manager.Load(); err != nil {
+		t.Fatal("could not create default lobby file:", err)
+	}
+	if _, ok := manager.GetProfile("world"); ok {
+		t.Error("default lobby profile should be disabled")
+	}
+
+	profile := defaultProfile()
+	profile.Enabled = true
+	manager.SetProfile("hub", profile)
+	if err := manager.Save(); err != nil {
+		t.Fatal(err)
+	}
+	manager = NewManager(dir + "/lobby.yml")
+	if err := manager.Load(); err != nil {
+		t.Fatal(err)
+	}
+	loaded, ok := manager.GetProfile("hub")
+	if !ok || !loaded.DisableBlockChanges || len(loaded.Hotbar) != 2 {
+		t.Fatal("lobby profile was not persisted:", loaded)
+	}
+
+	if manager.AllowsEntityAI("hub") || manager.AllowsItemMerging("hub") || !manager.AllowsEntityAI("world") {
+		t.Error("simulation switches were not applied to the enabled lobby only")
+	}
+
+	paper, _ := loaded.Hotbar[0].Item.ToStack()
+	if !loaded.Hotbar[0].IsItem(paper) {
+		t.Error("hotbar item should match its own stack")
+	}
+	plain, _ := items.DefaultManager.Get("minecraft:paper", 1)
+	if loaded.Hotbar[0].IsItem(plain) {
+		t.Error("hotbar item should not match paper without its display name")
+	}
+}
@@ -0,0 +1,53 @@
+package lobby
+
+import (
+	"github.com/irmine/gomine/items"
+)
+
+// Profile is the lobby profile of a world.
+// Worlds with an enabled lobby profile protect their players and blocks,
+// and give every player the configured hotbar items.
+type Profile struct {
+	// Enabled specifies if the lobby profile is active in the world.
+	Enabled bool `yaml:"Enabled"`
+	// DisableHunger disables hunger of players in the world.
+	DisableHunger bool `yaml:"Disable Hunger"`
+	// DisableDamage disables damage to players in the world.
+	DisableDamage bool `yaml:"Disable Damage"`
+	// DisableBlockChanges disables breaking and placing blocks in the world.
+	// Players are put in adventure mode so blocks can not be changed client side either.
+	DisableBlockChanges bool `yaml:"Disable Block Changes"`
+	// DisableEntityAI stops the behaviors of mobs in the world. Mobs are still moved by knockback.
+	DisableEntityAI bool `yaml:"Disable Entity AI"`
+	// DisableItemMerging stops items lying on the ground from merging in the world.
+	DisableItemMerging bool `yaml:"Disable Item Merging"`
+	// DisableRedstone and DisableRandomTicks mark redstone and random block ticks as disabled in the world.
+	// The server does not simulate either itself, but plugins that do should check them.
+	DisableRedstone    bool `yaml:"Disable Redstone"`
+	DisableRandomTicks bool `yaml:"Disable Random Ticks"`
+	// ClearInventory clears the inventory of players before the hotbar items are set.
+	ClearInventory bool `yaml:"Clear Inventory"`
+	// Hotbar contains the items given to players in the world.
+	Hotbar []HotbarItem `yaml:"Hotbar"`
+}
+
+// HotbarItem is an item set in the hotbar of players in a lobby,
+// which runs a command when used.
+type HotbarItem struct {
+	// Slot is the hotbar slot of the item, ranging from 0 to 8.
+	Slot int `yaml:"Slot"`
+	// Item is the item set in the slot.
+	Item items.Record `yaml:"Item"`
+	// Command is the command run by the player when using the item,
+	// without leading slash. An empty command does nothing.
+	Command string `yaml:"Command,omitempty"`
+}
+
+// IsItem checks if the given item stack is the item of the hotbar item.
+func (item HotbarItem) IsItem(stack *items.Stack) bool {
+	if stack == nil {
+		return false
+	}
+	var hotbarStack, ok = item.Item.ToStack()
+	return ok && stack.Type.Equals(hotbarStack.Type) && stack.DisplayName == hotbarStack.DisplayName
+}
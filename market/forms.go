@@ -0,0 +1,149 @@
+package market
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/irmine/gomine/forms"
+	"github.com/irmine/gomine/items"
+	"github.com/irmine/gomine/net"
+	"github.com/irmine/gomine/text"
+)
+
+// FormatPrice formats a price using the economy service of the manager.
+func (manager *Manager) FormatPrice(price float64) string {
+	if economy, ok := manager.GetEconomy(); ok {
+		return economy.Format(price)
+	}
+	return strconv.FormatFloat(price, 'f', 2, 64)
+}
+
+// OpenBrowser sends a form to the session listing all active listings.
+// Clicking a listing opens a confirmation form to buy it.
+func (manager *Manager) OpenBrowser(session *net.MinecraftSession) {
+	var listings = manager.GetActiveListings()
+	var menu = forms.NewPaginatedMenu("Market", fmt.Sprint(len(listings), " items for sale"))
+	menu.ItemsPerPage = manager.ItemsPerPage
+	for _, listing := range listings {
+		menu.AddItem(describe(listing.Item) + "\n" + manager.FormatPrice(listing.Price) + " - " + listing.Seller)
+	}
+	manager.sendMenu(session, menu, func(response *forms.Response) {
+		if response.Closed || response.Button >= len(listings) {
+			return
+		}
+		manager.openListing(session, listings[response.Button])
+	})
+}
+
+// OpenOwnListings sends a form to the session listing all its own listings.
+// Clicking an active listing opens a confirmation form to cancel it.
+func (manager *Manager) OpenOwnListings(session *net.MinecraftSession) {
+	var listings = manager.GetListingsOf(session.GetName())
+	var menu = forms.NewPaginatedMenu("My Listings", fmt.Sprint(len(listings), "/", manager.MaxListings, " listings"))
+	menu.ItemsPerPage = manager.ItemsPerPage
+	var now = time.Now()
+	for _, listing := range listings {
+		var status = "Expires in " + listing.Expires.Sub(now).Round(time.Minute).String()
+		if listing.IsExpired(now) {
+			status = "Expired - reclaim with /market reclaim"
+		}
+		menu.AddItem(describe(listing.Item) + " - " + manager.FormatPrice(listing.Price) + "\n" + status)
+	}
+	manager.sendMenu(session, menu, func(response *forms.Response) {
+		if response.Closed || response.Button >= len(listings) {
+			return
+		}
+		manager.openListing(session, listings[response.Button])
+	})
+}
+
+// OpenSellForm sends a form to the session to list an item of its inventory.
+func (manager *Manager) OpenSellForm(session *net.MinecraftSession) {
+	var slots []int
+	var options []string
+	for slot, item := range session.GetPlayer().GetInventory().GetAll() {
+		if item != nil && item.Count > 0 {
+			slots = append(slots, slot)
+			options = append(options, describe(item))
+		}
+	}
+	if len(slots) == 0 {
+		session.SendMessage(text.Red + "You have no items to sell.")
+		return
+	}
+	var form = forms.NewCustomForm("Sell Item")
+	form.AddElement(forms.NewDropdown("Item", options, 0))
+	form.AddElement(forms.NewInput("Price", "100", ""))
+	manager.sendForm(session, form, func(response *forms.Response) {
+		if response.Closed {
+			return
+		}
+		var price, err = strconv.ParseFloat(response.GetString(1), 64)
+		if err != nil {
+			session.SendMessage(text.Red + "Invalid price: " + response.GetString(1))
+			return
+		}
+		var index = response.GetInt(0)
+		if index < 0 || index >= len(slots) {
+			return
+		}
+		listing, err := manager.Sell(session, slots[index], price)
+		session.SendInventory()
+		if err != nil {
+			session.SendMessage(text.Red + "Could not list item: " + err.Error())
+			return
+		}
+		session.SendMessage(text.BrightGreen+"Listed", describe(listing.Item), "for", manager.FormatPrice(listing.Price)+".")
+	})
+}
+
+// openListing sends a form to the session to confirm buying the listing,
+// or to cancel the listing if the session is the seller.
+func (manager *Manager) openListing(session *net.MinecraftSession, listing *Listing) {
+	if listing.Seller == session.GetName() {
+		if listing.IsExpired(time.Now()) {
+			return
+		}
+		var form = forms.NewModalForm("Cancel Listing", "Cancel your listing of "+describe(listing.Item)+"?\nThe item can be reclaimed with /market reclaim.", "Cancel Listing", "Back")
+		manager.sendForm(session, form, func(response *forms.Response) {
+			if !response.Accepted {
+				return
+			}
+			if err := manager.Cancel(session, listing.Id); err != nil {
+				session.SendMessage(text.Red + "Could not cancel listing: " + err.Error())
+				return
+			}
+			session.SendMessage(text.Yellow + "Your listing has been cancelled.")
+		})
+		return
+	}
+	var form = forms.NewModalForm("Buy Item", "Buy "+describe(listing.Item)+" from "+listing.Seller+" for "+manager.FormatPrice(listing.Price)+"?", "Buy", "Back")
+	manager.sendForm(session, form, func(response *forms.Response) {
+		if !response.Accepted {
+			return
+		}
+		var err = manager.Buy(session, listing.Id)
+		session.SendInventory()
+		if err != nil {
+			session.SendMessage(text.Red + "Could not buy item: " + err.Error())
+			return
+		}
+		session.SendMessage(text.BrightGreen+"Bought", describe(listing.Item), "for", manager.FormatPrice(listing.Price)+".")
+	})
+}
+
+// sendForm sends a form to the session and logs any error.
+func (manager *Manager) sendForm(session *net.MinecraftSession, form forms.Form, callback func(response *forms.Response)) {
+	text.DefaultLogger.LogError(session.SendForm(form, callback))
+}
+
+// sendMenu sends a paginated menu to the session and logs any error.
+func (manager *Manager) sendMenu(session *net.MinecraftSession, menu *forms.PaginatedMenu, callback func(response *forms.Response)) {
+	text.DefaultLogger.LogError(session.SendMenu(menu, callback))
+}
+
+// describe returns a short description of an item stack, such as "x16 Stone".
+func describe(stack *items.Stack) string {
+	return fmt.Sprint("x", stack.Count, " ", stack.GetDisplayName())
+}
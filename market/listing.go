@@ -0,0 +1,36 @@
+package market
+
+import (
+	"time"
+
+	"github.com/irmine/gomine/items"
+)
+
+// State is the state of a listing.
+type State byte
+
+const (
+	// StateActive is the state of listings that can be bought.
+	StateActive State = iota
+	// StateExpired is the state of listings that expired or got cancelled,
+	// and of which the item can be reclaimed by the seller.
+	StateExpired
+)
+
+// Listing is an item listed on the market by a player.
+// The item of a listing is held in escrow by the market,
+// until it is bought or reclaimed by the seller.
+type Listing struct {
+	Id      int64
+	Seller  string
+	Item    *items.Stack
+	Price   float64
+	Created time.Time
+	Expires time.Time
+	State   State
+}
+
+// IsExpired checks if the listing expired at the given time.
+func (listing *Listing) IsExpired(now time.Time) bool {
+	return listing.State == StateExpired || now.After(listing.Expires)
+}
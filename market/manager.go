@@ -0,0 +1,304 @@
+package market
+
+import (
+	"errors"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/irmine/gomine/economy"
+	"github.com/irmine/gomine/forms"
+	"github.com/irmine/gomine/items/inventory"
+	"github.com/irmine/gomine/net"
+	"github.com/irmine/gomine/text"
+)
+
+var (
+	// NoEconomy gets returned when trying to use the market
+	// while no economy service has been set.
+	NoEconomy = errors.New("no economy service has been set")
+	// InvalidPrice gets returned when listing an item for a price of 0 or lower, or a price that is not a finite number.
+	InvalidPrice = errors.New("price must be a finite number higher than 0")
+	// TooManyListings gets returned when a player tries
+	// to list an item while having reached the max listings.
+	TooManyListings = errors.New("maximum amount of listings reached")
+	// UnknownListing gets returned when a listing could not be found,
+	// or is no longer available.
+	UnknownListing = errors.New("listing is no longer available")
+	// OwnListing gets returned when a player tries to buy its own listing.
+	OwnListing = errors.New("cannot buy own listing")
+	// NotSeller gets returned when a player tries to cancel
+	// a listing of another player.
+	NotSeller = errors.New("listing belongs to another player")
+)
+
+// Manager manages all listings of the market.
+// Items listed on the market are held in escrow by the manager,
+// until they are bought or reclaimed by the seller after expiry.
+type Manager struct {
+	// ListingDuration is the duration listings are available for
+	// before they expire, after which the seller can reclaim the item.
+	ListingDuration time.Duration
+	// MaxListings is the maximum amount of listings a player can have,
+	// including expired listings that have not yet been reclaimed.
+	MaxListings int
+	// ItemsPerPage is the maximum amount of listings on every page of the market menus.
+	ItemsPerPage int
+	// SoldFunction gets called once a listing has been bought,
+	// with the listing and the name of the buyer.
+	SoldFunction func(listing *Listing, buyer string)
+
+	mutex     sync.RWMutex
+	storage   Storage
+	economy   economy.Economy
+	listings  map[int64]*Listing
+	lastCheck time.Time
+}
+
+// NewManager returns a new market manager using the given storage.
+// Listings do not get loaded until Load gets called.
+func NewManager(storage Storage) *Manager {
+	return &Manager{
+		ListingDuration: time.Hour * 48,
+		MaxListings:     10,
+		ItemsPerPage:    forms.DefaultItemsPerPage,
+		SoldFunction:    func(*Listing, string) {},
+		storage:         storage,
+		listings:        make(map[int64]*Listing),
+	}
+}
+
+// Load loads all listings from the storage of the manager.
+func (manager *Manager) Load() error {
+	var listings, err = manager.storage.Load()
+	if err != nil {
+		return err
+	}
+	manager.mutex.Lock()
+	for _, listing := range listings {
+		manager.listings[listing.Id] = listing
+	}
+	manager.mutex.Unlock()
+	return nil
+}
+
+// GetStorage returns the storage of the manager.
+func (manager *Manager) GetStorage() Storage {
+	return manager.storage
+}
+
+// SetStorage sets the storage of the manager.
+// The storage should be set before listings get loaded.
+func (manager *Manager) SetStorage(storage Storage) {
+	manager.mutex.Lock()
+	manager.storage = storage
+	manager.mutex.Unlock()
+}
+
+// SetEconomy sets the economy service used to pay for listings.
+func (manager *Manager) SetEconomy(economy economy.Economy) {
+	manager.mutex.Lock()
+	manager.economy = economy
+	manager.mutex.Unlock()
+}
+
+// GetEconomy returns the economy service of the manager,
+// and a bool indicating if one has been set.
+func (manager *Manager) GetEconomy() (economy.Economy, bool) {
+	manager.mutex.RLock()
+	defer manager.mutex.RUnlock()
+	return manager.economy, manager.economy != nil
+}
+
+// GetListing returns a listing by its ID.
+// A bool is returned indicating if the listing was found.
+func (manager *Manager) GetListing(id int64) (*Listing, bool) {
+	manager.mutex.RLock()
+	defer manager.mutex.RUnlock()
+	var listing, ok = manager.listings[id]
+	return listing, ok
+}
+
+// GetActiveListings returns all listings that can currently be bought,
+// sorted from newest to oldest.
+func (manager *Manager) GetActiveListings() []*Listing {
+	var now = time.Now()
+	return manager.filter(func(listing *Listing) bool {
+		return !listing.IsExpired(now)
+	})
+}
+
+// GetListingsOf returns all listings of the seller with the given name,
+// including expired listings, sorted from newest to oldest.
+func (manager *Manager) GetListingsOf(seller string) []*Listing {
+	return manager.filter(func(listing *Listing) bool {
+		return listing.Seller == seller
+	})
+}
+
+// filter returns all listings for which the function returns true,
+// sorted from newest to oldest.
+func (manager *Manager) filter(f func(listing *Listing) bool) []*Listing {
+	manager.mutex.RLock()
+	var listings []*Listing
+	for _, listing := range manager.listings {
+		if f(listing) {
+			listings = append(listings, listing)
+		}
+	}
+	manager.mutex.RUnlock()
+	sort.Slice(listings, func(i, j int) bool {
+		return listings[i].Id > listings[j].Id
+	})
+	return listings
+}
+
+// Sell lists the item in the given inventory slot of the session for the given price.
+// The item gets removed from the inventory and held in escrow by the market.
+// The inventory of the session should be sent after listing an item.
+func (manager *Manager) Sell(session *net.MinecraftSession, slot int, price float64) (*Listing, error) {
+	if _, ok := manager.GetEconomy(); !ok {
+		return nil, NoEconomy
+	}
+	if price <= 0 || math.IsNaN(price) || math.IsInf(price, 0) {
+		return nil, InvalidPrice
+	}
+	if len(manager.GetListingsOf(session.GetName())) >= manager.MaxListings {
+		return nil, TooManyListings
+	}
+	var inv = session.GetPlayer().GetInventory()
+	var item, err = inv.GetItem(slot)
+	if err != nil {
+		return nil, err
+	}
+	if item == nil || item.Count <= 0 {
+		return nil, inventory.EmptySlot
+	}
+	var now = time.Now()
+	var c = *item
+	var listing = &Listing{0, session.GetName(), &c, price, now, now.Add(manager.ListingDuration), StateActive}
+	if err := manager.storage.Save(listing); err != nil {
+		return nil, err
+	}
+	inv.ClearSlot(slot)
+
+	manager.mutex.Lock()
+	manager.listings[listing.Id] = listing
+	manager.mutex.Unlock()
+	return listing, nil
+}
+
+// Buy buys the listing with the given ID for the session.
+// The price gets withdrawn from the buyer and deposited to the seller,
+// after which the item gets added to the inventory of the buyer.
+// The inventory of the session should be sent after buying.
+func (manager *Manager) Buy(session *net.MinecraftSession, id int64) error {
+	var listing, err = manager.buy(session, id)
+	if err != nil {
+		return err
+	}
+	// The sold function is called without holding the lock, as it may use the manager, such as to format the price.
+	manager.SoldFunction(listing, session.GetName())
+	return nil
+}
+
+// buy buys the listing with the given ID for the session while holding the lock of the manager,
+// and returns the listing bought.
+func (manager *Manager) buy(session *net.MinecraftSession, id int64) (*Listing, error) {
+	manager.mutex.Lock()
+	defer manager.mutex.Unlock()
+	if manager.economy == nil {
+		return nil, NoEconomy
+	}
+	var listing, ok = manager.listings[id]
+	if !ok || listing.IsExpired(time.Now()) {
+		return nil, UnknownListing
+	}
+	if listing.Seller == session.GetName() {
+		return nil, OwnListing
+	}
+	if !session.GetPlayer().GetInventory().CanAddItems(listing.Item) {
+		return nil, inventory.FullInventory
+	}
+	if err := manager.economy.Withdraw(session.GetName(), listing.Price); err != nil {
+		return nil, err
+	}
+	if err := manager.economy.Deposit(listing.Seller, listing.Price); err != nil {
+		text.DefaultLogger.LogError(manager.economy.Deposit(session.GetName(), listing.Price))
+		return nil, err
+	}
+	delete(manager.listings, id)
+	text.DefaultLogger.LogError(manager.storage.Delete(id))
+
+	var c = *listing.Item
+	session.GetPlayer().GetInventory().AddItem(&c)
+	return listing, nil
+}
+
+// Cancel cancels an active listing of the session.
+// The listing expires immediately, after which the item can be reclaimed.
+func (manager *Manager) Cancel(session *net.MinecraftSession, id int64) error {
+	manager.mutex.Lock()
+	defer manager.mutex.Unlock()
+	var listing, ok = manager.listings[id]
+	if !ok || listing.IsExpired(time.Now()) {
+		return UnknownListing
+	}
+	if listing.Seller != session.GetName() {
+		return NotSeller
+	}
+	listing.State = StateExpired
+	return manager.storage.Save(listing)
+}
+
+// Reclaim returns the items of all expired listings of the session to its inventory.
+// The amount of reclaimed listings is returned. FullInventory gets returned
+// if not all items could fit in the inventory, in which case the remaining
+// listings can be reclaimed later. The inventory of the session should be sent after reclaiming.
+func (manager *Manager) Reclaim(session *net.MinecraftSession) (int, error) {
+	var now = time.Now()
+	var expired = manager.filter(func(listing *Listing) bool {
+		return listing.Seller == session.GetName() && listing.IsExpired(now)
+	})
+	var inv = session.GetPlayer().GetInventory()
+	var reclaimed = 0
+	for _, listing := range expired {
+		if !inv.CanAddItems(listing.Item) {
+			return reclaimed, inventory.FullInventory
+		}
+		manager.mutex.Lock()
+		if _, ok := manager.listings[listing.Id]; !ok {
+			manager.mutex.Unlock()
+			continue
+		}
+		delete(manager.listings, listing.Id)
+		manager.mutex.Unlock()
+		text.DefaultLogger.LogError(manager.storage.Delete(listing.Id))
+
+		var c = *listing.Item
+		inv.AddItem(&c)
+		reclaimed++
+	}
+	return reclaimed, nil
+}
+
+// Tick expires all listings that have passed their expiry time.
+// Listings are checked once every second.
+// Internal. Not to be used by plugins.
+func (manager *Manager) Tick() {
+	var now = time.Now()
+	if now.Sub(manager.lastCheck) < time.Second {
+		return
+	}
+	manager.lastCheck = now
+
+	manager.mutex.Lock()
+	defer manager.mutex.Unlock()
+	for _, listing := range manager.listings {
+		if listing.State == StateActive && listing.IsExpired(now) {
+			listing.State = StateExpired
+			text.DefaultLogger.LogError(manager.storage.Save(listing))
+		}
+	}
+}
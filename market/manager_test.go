@@ -0,0 +1,89 @@
+package market
+
+import (
+	"io/ioutil"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/irmine/gomine/economy"
+	"github.com/irmine/gomine/items"
+	"github.com/irmine/gomine/net"
+	"github.com/irmine/gomine/players"
+)
+
+type testEconomy map[string]float64
+
+func (e testEconomy) GetBalance(name string) (float64, error) { return e[name], nil }
+func (e testEconomy) Deposit(name string, amount float64) error {
+	e[name] += amount
+	return nil
+}
+func (e testEconomy) Withdraw(name string, amount float64) error {
+	if e[name] < amount {
+		return economy.InsufficientFunds
+	}
+	e[name] -= amount
+	return nil
+}
+func (e testEconomy) Format(amount float64) string { return "$" }
+
+func newSession(name string) *net.MinecraftSession {
+	session := net.NewMinecraftSession(nil, nil)
+	session.SetPlayer(players.NewPlayer(uuid.New(), "", 0, name))
+	return session
+}
+
+func TestMarket(t *testing.T) {
+	dir, _ := ioutil.TempDir("", "market")
+	defer os.RemoveAll(dir)
+	manager := NewManager(NewFileStorage(filepath.Join(dir, "market.yml")))
+	money := testEconomy{"Alex": 50}
+	manager.SetEconomy(money)
+	steve, alex := newSession("Steve"), newSession("Alex")
+
+	stone, _ := items.DefaultManager.Get("minecraft:stone", 16)
+	steve.GetPlayer().GetInventory().SetItem(stone, 0)
+	for _, price := range []float64{0, math.NaN(), math.Inf(1)} {
+		if _, err := manager.Sell(steve, 0, price); err != InvalidPrice {
+			t.Error("expected invalid price", price, "to be rejected, got:", err)
+		}
+	}
+	listing, err := manager.Sell(steve, 0, 100)
+	if err != nil {
+		t.Fatal("could not list item:", err)
+	}
+	if !steve.GetPlayer().GetInventory().IsEmpty(0) {
+		t.Error("listed item was not escrowed")
+	}
+	if err := manager.Buy(alex, listing.Id); err != economy.InsufficientFunds {
+		t.Error("expected insufficient funds, got:", err)
+	}
+	money["Alex"] = 100
+	var sold = false
+	manager.SoldFunction = func(listing *Listing, buyer string) {
+		// The sold function may use the manager without deadlocking it.
+		manager.FormatPrice(listing.Price)
+		sold = true
+	}
+	if err := manager.Buy(alex, listing.Id); err != nil || !sold {
+		t.Fatal("could not buy listing:", err)
+	}
+	if money["Steve"] != 100 || money["Alex"] != 0 || alex.GetPlayer().GetInventory().IsEmpty(0) {
+		t.Error("listing was not paid for or delivered:", money)
+	}
+
+	stone, _ = items.DefaultManager.Get("minecraft:stone", 8)
+	steve.GetPlayer().GetInventory().SetItem(stone, 0)
+	manager.ListingDuration = -time.Second
+	manager.Sell(steve, 0, 10)
+	if err := manager.Load(); err != nil || len(manager.GetActiveListings()) != 0 {
+		t.Error("expired listing is still active:", err)
+	}
+	if count, err := manager.Reclaim(steve); count != 1 || err != nil || steve.GetPlayer().GetInventory().IsEmpty(0) {
+		t.Error("expired listing was not reclaimed:", count, err)
+	}
+}
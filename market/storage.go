@@ -0,0 +1,218 @@
+package market
+
+import (
+	"database/sql"
+	"errors"
+	"io/ioutil"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/irmine/gomine/items"
+	"github.com/irmine/gomine/text"
+	"gopkg.in/yaml.v2"
+)
+
+// Storage is used to load and save listings of the market.
+type Storage interface {
+	// Load loads all listings that have not been bought or reclaimed.
+	Load() ([]*Listing, error)
+	// Save saves a listing. Listings with an ID of 0 are new,
+	// and get assigned a new unique ID by the storage.
+	Save(listing *Listing) error
+	// Delete deletes the listing with the given ID,
+	// once it has been bought or reclaimed.
+	Delete(id int64) error
+}
+
+// listingRecord is the stored form of a listing in a file storage.
+// The item is stored as base64 encoded NBT, like in the SQL storage.
+type listingRecord struct {
+	Id      int64   `yaml:"Id"`
+	Seller  string  `yaml:"Seller"`
+	Item    string  `yaml:"Item"`
+	Price   float64 `yaml:"Price"`
+	Created int64   `yaml:"Created"`
+	Expires int64   `yaml:"Expires"`
+	State   State   `yaml:"State"`
+}
+
+// FileStorage is a storage saving all listings in a single YAML file.
+type FileStorage struct {
+	mutex    sync.Mutex
+	path     string
+	lastId   int64
+	listings map[int64]listingRecord
+}
+
+// NewFileStorage returns a new file storage saving to the file at the given path.
+func NewFileStorage(path string) *FileStorage {
+	return &FileStorage{path: path, listings: make(map[int64]listingRecord)}
+}
+
+// Load loads all listings from the file.
+func (storage *FileStorage) Load() ([]*Listing, error) {
+	storage.mutex.Lock()
+	defer storage.mutex.Unlock()
+	var file, err = ioutil.ReadFile(storage.path)
+	if os.IsNotExist(err) {
+		return []*Listing{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var records []listingRecord
+	if err := yaml.Unmarshal(file, &records); err != nil {
+		return nil, err
+	}
+	var listings []*Listing
+	for _, record := range records {
+		storage.listings[record.Id] = record
+		if record.Id > storage.lastId {
+			storage.lastId = record.Id
+		}
+		var stack, err = items.DecodeBase64(record.Item)
+		if err != nil {
+			text.DefaultLogger.Error("Skipping market listing", record.Id, "with invalid item:", err)
+			continue
+		}
+		listings = append(listings, &Listing{record.Id, record.Seller, stack, record.Price, time.Unix(record.Created, 0), time.Unix(record.Expires, 0), record.State})
+	}
+	return listings, nil
+}
+
+// Save saves the listing and writes the file.
+func (storage *FileStorage) Save(listing *Listing) error {
+	storage.mutex.Lock()
+	defer storage.mutex.Unlock()
+	var item, err = items.EncodeBase64(listing.Item)
+	if err != nil {
+		return err
+	}
+	if listing.Id == 0 {
+		storage.lastId++
+		listing.Id = storage.lastId
+	}
+	storage.listings[listing.Id] = listingRecord{listing.Id, listing.Seller, item, listing.Price, listing.Created.Unix(), listing.Expires.Unix(), listing.State}
+	return storage.write()
+}
+
+// Delete deletes the listing and writes the file.
+func (storage *FileStorage) Delete(id int64) error {
+	storage.mutex.Lock()
+	defer storage.mutex.Unlock()
+	delete(storage.listings, id)
+	return storage.write()
+}
+
+// write writes all listings to the file.
+func (storage *FileStorage) write() error {
+	var records = make([]listingRecord, 0, len(storage.listings))
+	for _, record := range storage.listings {
+		records = append(records, record)
+	}
+	var data, err = yaml.Marshal(records)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(storage.path, data, 0644)
+}
+
+// Dialects of the SQL databases supported by the SQL storage.
+const (
+	DialectMySQL  = "mysql"
+	DialectSQLite = "sqlite"
+)
+
+// UnknownDialect gets returned when creating an SQL storage for a dialect that is not supported.
+var UnknownDialect = errors.New("unknown SQL dialect")
+
+// idColumns are the definitions of the auto incrementing ID column of the listings table per dialect.
+var idColumns = map[string]string{
+	DialectMySQL:  "id BIGINT PRIMARY KEY AUTO_INCREMENT",
+	DialectSQLite: "id INTEGER PRIMARY KEY AUTOINCREMENT",
+}
+
+// SQLStorage is a storage saving listings in an SQL database.
+// The database should be opened by a plugin with a driver of choice.
+// Queries use ? placeholders, as supported by MySQL and SQLite.
+// Items are stored as base64 encoded NBT, so that their enchantments, lore and custom tags are kept.
+type SQLStorage struct {
+	db *sql.DB
+}
+
+// NewSQLStorage returns a new SQL storage using the given database of the given dialect.
+// The listings table gets created if it does not yet exist.
+// UnknownDialect is returned if the dialect is neither DialectMySQL nor DialectSQLite.
+func NewSQLStorage(db *sql.DB, dialect string) (*SQLStorage, error) {
+	var idColumn, ok = idColumns[dialect]
+	if !ok {
+		return nil, UnknownDialect
+	}
+	var _, err = db.Exec(`CREATE TABLE IF NOT EXISTS market_listings (
+		` + idColumn + `,
+		seller VARCHAR(32) NOT NULL,
+		item TEXT NOT NULL,
+		price DOUBLE NOT NULL,
+		created BIGINT NOT NULL,
+		expires BIGINT NOT NULL,
+		state INTEGER NOT NULL
+	)`)
+	if err != nil {
+		return nil, err
+	}
+	return &SQLStorage{db}, nil
+}
+
+// Load loads all listings from the database.
+func (storage *SQLStorage) Load() ([]*Listing, error) {
+	var rows, err = storage.db.Query("SELECT id, seller, item, price, created, expires, state FROM market_listings")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var listings []*Listing
+	for rows.Next() {
+		var listing = &Listing{}
+		var item string
+		var created, expires int64
+		if err := rows.Scan(&listing.Id, &listing.Seller, &item, &listing.Price, &created, &expires, &listing.State); err != nil {
+			return nil, err
+		}
+		var stack, err = items.DecodeBase64(item)
+		if err != nil {
+			text.DefaultLogger.Error("Skipping market listing", listing.Id, "with invalid item:", err)
+			continue
+		}
+		listing.Item = stack
+		listing.Created, listing.Expires = time.Unix(created, 0), time.Unix(expires, 0)
+		listings = append(listings, listing)
+	}
+	return listings, rows.Err()
+}
+
+// Save inserts or updates the listing in the database.
+func (storage *SQLStorage) Save(listing *Listing) error {
+	if listing.Id != 0 {
+		var _, err = storage.db.Exec("UPDATE market_listings SET price = ?, expires = ?, state = ? WHERE id = ?", listing.Price, listing.Expires.Unix(), listing.State, listing.Id)
+		return err
+	}
+	var item, err = items.EncodeBase64(listing.Item)
+	if err != nil {
+		return err
+	}
+	result, err := storage.db.Exec("INSERT INTO market_listings (seller, item, price, created, expires, state) VALUES (?, ?, ?, ?, ?, ?)",
+		listing.Seller, item, listing.Price, listing.Created.Unix(), listing.Expires.Unix(), listing.State)
+	if err != nil {
+		return err
+	}
+	listing.Id, err = result.LastInsertId()
+	return err
+}
+
+// Delete deletes the listing from the database.
+func (storage *SQLStorage) Delete(id int64) error {
+	var _, err = storage.db.Exec("DELETE FROM market_listings WHERE id = ?", id)
+	return err
+}
@@ -0,0 +1,78 @@
+package metrics
+
+import (
+	"math"
+	"sort"
+	"sync"
+	"sync/atomic"
+)
+
+// Counter is a metric that only ever increases.
+// Counters are safe to increment from multiple goroutines.
+type Counter struct {
+	value uint64
+}
+
+// Add increments the counter by the given amount.
+func (counter *Counter) Add(amount uint64) {
+	atomic.AddUint64(&counter.value, amount)
+}
+
+// Get returns the current value of the counter.
+func (counter *Counter) Get() uint64 {
+	return atomic.LoadUint64(&counter.value)
+}
+
+// Gauge is a metric with a value that may go up and down.
+// Gauges are safe to set from multiple goroutines.
+type Gauge struct {
+	bits uint64
+}
+
+// Set sets the value of the gauge.
+func (gauge *Gauge) Set(value float64) {
+	atomic.StoreUint64(&gauge.bits, math.Float64bits(value))
+}
+
+// Get returns the current value of the gauge.
+func (gauge *Gauge) Get() float64 {
+	return math.Float64frombits(atomic.LoadUint64(&gauge.bits))
+}
+
+// Histogram counts observed values in cumulative buckets.
+type Histogram struct {
+	mutex   sync.Mutex
+	buckets []float64
+	counts  []uint64
+	sum     float64
+	count   uint64
+}
+
+// NewHistogram returns a new histogram with the given upper bounds of its buckets.
+// A bucket for values above all bounds is always present.
+func NewHistogram(buckets []float64) *Histogram {
+	var sorted = append([]float64(nil), buckets...)
+	sort.Float64s(sorted)
+	return &Histogram{buckets: sorted, counts: make([]uint64, len(sorted))}
+}
+
+// Observe adds the value to the histogram.
+func (histogram *Histogram) Observe(value float64) {
+	histogram.mutex.Lock()
+	defer histogram.mutex.Unlock()
+	for i, bound := range histogram.buckets {
+		if value <= bound {
+			histogram.counts[i]++
+		}
+	}
+	histogram.sum += value
+	histogram.count++
+}
+
+// Snapshot returns the upper bounds of the buckets, the cumulative count of every bucket,
+// the sum of all observed values and the total amount of observed values.
+func (histogram *Histogram) Snapshot() (buckets []float64, counts []uint64, sum float64, count uint64) {
+	histogram.mutex.Lock()
+	defer histogram.mutex.Unlock()
+	return histogram.buckets, append([]uint64(nil), histogram.counts...), histogram.sum, histogram.count
+}
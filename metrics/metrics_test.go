@@ -0,0 +1,110 @@
+package metrics
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRegistry(t *testing.T) {
+	var registry = NewRegistry()
+	var counter, _ = registry.NewCounter("packets_total", "Packets.")
+	var gauge, _ = registry.NewGauge("players", "Players.")
+	var histogram, _ = registry.NewHistogram("tick_seconds", "Ticks.", []float64{0.05, 0.01})
+	if _, err := registry.NewGauge("players", "Players."); err != DuplicateMetric {
+		t.Error("registering a metric twice should fail")
+	}
+
+	counter.Add(3)
+	counter.Add(2)
+	gauge.Set(1.5)
+	histogram.Observe(0.005)
+	histogram.Observe(0.02)
+	histogram.Observe(0.1)
+
+	var buffer = &bytes.Buffer{}
+	if _, err := registry.WriteTo(buffer); err != nil {
+		t.Fatal(err)
+	}
+	var expected = `# HELP packets_total Packets.
+# TYPE packets_total counter
+packets_total 5
+# HELP players Players.
+# TYPE players gauge
+players 1.5
+# HELP tick_seconds Ticks.
+# TYPE tick_seconds histogram
+tick_seconds_bucket{le="0.01"} 1
+tick_seconds_bucket{le="0.05"} 2
+tick_seconds_bucket{le="+Inf"} 3
+tick_seconds_sum 0.125
+tick_seconds_count 3
+`
+	if buffer.String() != expected {
+		t.Errorf("unexpected output:\n%v", buffer.String())
+	}
+}
+
+func TestListen(t *testing.T) {
+	var registry = NewRegistry()
+	if err := registry.RegisterRuntimeMetrics(); err != nil {
+		t.Fatal(err)
+	}
+	var ctx, cancel = context.WithCancel(context.Background())
+	if err := registry.Listen(ctx, "127.0.0.1:0", false); err != nil {
+		t.Fatal(err)
+	}
+	defer registry.Close()
+
+	var response, err = http.Get("http://" + registry.GetAddress().String() + "/metrics")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var body, _ = ioutil.ReadAll(response.Body)
+	response.Body.Close()
+	if !strings.Contains(string(body), "go_goroutines ") || !strings.Contains(string(body), "go_memstats_heap_alloc_bytes ") {
+		t.Errorf("runtime metrics missing:\n%v", string(body))
+	}
+
+	if response, err = http.Get("http://" + registry.GetAddress().String() + "/debug/pprof/"); err != nil {
+		t.Fatal(err)
+	}
+	response.Body.Close()
+	if response.StatusCode != http.StatusNotFound {
+		t.Error("profiles should not be served if profiling is disabled")
+	}
+
+	cancel()
+	for i := 0; i < 100 && registry.GetAddress() != nil; i++ {
+		time.Sleep(time.Millisecond)
+	}
+	if registry.GetAddress() != nil {
+		t.Error("metrics are still served after the context was cancelled")
+	}
+}
+
+func TestTickMeter(t *testing.T) {
+	var meter = NewTickMeter()
+	var start = time.Now()
+	for i := 0; i < 40; i++ {
+		meter.Tick(start.Add(time.Millisecond*50*time.Duration(i)), time.Millisecond*10)
+	}
+	if tps := meter.GetTPS(); tps != 20 {
+		t.Error("unexpected TPS:", tps)
+	}
+	if duration := meter.GetAverageTickDuration(); duration != time.Millisecond*10 {
+		t.Error("unexpected average tick duration:", duration)
+	}
+
+	for i := 0; i < HistorySize+5; i++ {
+		meter.RecordSample(start.Add(time.Second * time.Duration(i)))
+	}
+	var history = meter.GetHistory()
+	if len(history) != HistorySize || !history[0].Time.Equal(start.Add(time.Second*5)) || history[0].TPS != 20 {
+		t.Error("unexpected tick history:", len(history), history[0])
+	}
+}
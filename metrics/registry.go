@@ -0,0 +1,287 @@
+package metrics
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/pprof"
+	"runtime"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// DuplicateMetric gets returned when a metric is registered with a name that is already in use.
+var DuplicateMetric = errors.New("metric is already registered")
+
+// DefaultRequestTimeout is the default duration after which requests for the metrics are aborted.
+const DefaultRequestTimeout = time.Second * 10
+
+// Types of metrics as exported in the Prometheus text format.
+const (
+	TypeCounter   = "counter"
+	TypeGauge     = "gauge"
+	TypeHistogram = "histogram"
+)
+
+// metric is a single registered metric.
+// The value function is used for counters and gauges, the histogram for histograms.
+type metric struct {
+	name      string
+	help      string
+	kind      string
+	value     func() float64
+	histogram *Histogram
+}
+
+// Registry holds all metrics of the server and exports them in the Prometheus text format.
+// Metrics are exported in the order they were registered.
+type Registry struct {
+	// RequestTimeout is the duration after which requests for the metrics are aborted.
+	// Requests for profiles are not limited, as they may run for a duration given by the client.
+	RequestTimeout time.Duration
+
+	mutex    sync.RWMutex
+	metrics  []metric
+	names    map[string]bool
+	listener net.Listener
+	server   *http.Server
+}
+
+// NewRegistry returns a new registry without any metrics.
+func NewRegistry() *Registry {
+	return &Registry{RequestTimeout: DefaultRequestTimeout, names: make(map[string]bool)}
+}
+
+// NewCounter registers and returns a new counter with the given name and help text.
+func (registry *Registry) NewCounter(name string, help string) (*Counter, error) {
+	var counter = &Counter{}
+	return counter, registry.register(metric{name: name, help: help, kind: TypeCounter, value: func() float64 {
+		return float64(counter.Get())
+	}})
+}
+
+// NewGauge registers and returns a new gauge with the given name and help text.
+func (registry *Registry) NewGauge(name string, help string) (*Gauge, error) {
+	var gauge = &Gauge{}
+	return gauge, registry.register(metric{name: name, help: help, kind: TypeGauge, value: gauge.Get})
+}
+
+// NewHistogram registers and returns a new histogram with the given name, help text and bucket bounds.
+func (registry *Registry) NewHistogram(name string, help string, buckets []float64) (*Histogram, error) {
+	var histogram = NewHistogram(buckets)
+	return histogram, registry.register(metric{name: name, help: help, kind: TypeHistogram, histogram: histogram})
+}
+
+// RegisterCounterFunc registers a counter of which the value is returned by the function.
+// The function gets called every time the metrics are exported, and must be safe to call from any goroutine.
+func (registry *Registry) RegisterCounterFunc(name string, help string, value func() float64) error {
+	return registry.register(metric{name: name, help: help, kind: TypeCounter, value: value})
+}
+
+// RegisterGaugeFunc registers a gauge of which the value is returned by the function.
+// The function gets called every time the metrics are exported, and must be safe to call from any goroutine.
+func (registry *Registry) RegisterGaugeFunc(name string, help string, value func() float64) error {
+	return registry.register(metric{name: name, help: help, kind: TypeGauge, value: value})
+}
+
+// RegisterRuntimeMetrics registers gauges for the amount of goroutines, heap usage and garbage collection.
+// Memory statistics are read at most once per second.
+func (registry *Registry) RegisterRuntimeMetrics() error {
+	var stats = &memStats{}
+	var errs = []error{
+		registry.RegisterGaugeFunc("go_goroutines", "Number of goroutines that currently exist.", func() float64 {
+			return float64(runtime.NumGoroutine())
+		}),
+		registry.RegisterGaugeFunc("go_memstats_heap_alloc_bytes", "Number of heap bytes allocated and still in use.", func() float64 {
+			return float64(stats.read().HeapAlloc)
+		}),
+		registry.RegisterGaugeFunc("go_memstats_heap_inuse_bytes", "Number of heap bytes that are in use.", func() float64 {
+			return float64(stats.read().HeapInuse)
+		}),
+		registry.RegisterGaugeFunc("go_memstats_heap_objects", "Number of allocated heap objects.", func() float64 {
+			return float64(stats.read().HeapObjects)
+		}),
+		registry.RegisterGaugeFunc("go_memstats_sys_bytes", "Number of bytes obtained from the system.", func() float64 {
+			return float64(stats.read().Sys)
+		}),
+		registry.RegisterCounterFunc("go_gc_cycles_total", "Number of completed garbage collection cycles.", func() float64 {
+			return float64(stats.read().NumGC)
+		}),
+	}
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WriteTo writes all metrics to the writer in the Prometheus text format.
+func (registry *Registry) WriteTo(w io.Writer) (int64, error) {
+	registry.mutex.RLock()
+	var metrics = append([]metric(nil), registry.metrics...)
+	registry.mutex.RUnlock()
+
+	var writer = &countingWriter{Writer: bufio.NewWriter(w)}
+	for _, metric := range metrics {
+		writer.WriteString("# HELP " + metric.name + " " + metric.help + "\n")
+		writer.WriteString("# TYPE " + metric.name + " " + metric.kind + "\n")
+		if metric.histogram == nil {
+			writer.WriteString(metric.name + " " + formatFloat(metric.value()) + "\n")
+			continue
+		}
+		var buckets, counts, sum, count = metric.histogram.Snapshot()
+		for i, bound := range buckets {
+			writer.WriteString(metric.name + "_bucket{le=\"" + formatFloat(bound) + "\"} " + strconv.FormatUint(counts[i], 10) + "\n")
+		}
+		writer.WriteString(metric.name + "_bucket{le=\"+Inf\"} " + strconv.FormatUint(count, 10) + "\n")
+		writer.WriteString(metric.name + "_sum " + formatFloat(sum) + "\n")
+		writer.WriteString(metric.name + "_count " + strconv.FormatUint(count, 10) + "\n")
+	}
+	var err = writer.Flush()
+	if writer.err != nil {
+		err = writer.err
+	}
+	return writer.written, err
+}
+
+// ServeHTTP writes all metrics in the Prometheus text format as response.
+func (registry *Registry) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	registry.WriteTo(w)
+}
+
+// Listen starts serving the metrics over HTTP on the given address at /metrics.
+// If profiling is true, the runtime profiles of the server are served at /debug/pprof/ as well.
+// The contexts of requests are derived from the given context, and serving stops once it is done.
+func (registry *Registry) Listen(ctx context.Context, address string, profiling bool) error {
+	var listener, err = net.Listen("tcp", address)
+	if err != nil {
+		return err
+	}
+	var mux = http.NewServeMux()
+	mux.Handle("/metrics", http.TimeoutHandler(registry, registry.RequestTimeout, "metrics request timed out"))
+	if profiling {
+		mux.HandleFunc("/debug/pprof/", pprof.Index)
+		mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+		mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+		mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+		mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+	}
+	var server = &http.Server{Handler: mux, ReadTimeout: time.Second * 10, BaseContext: func(net.Listener) context.Context {
+		return ctx
+	}}
+
+	registry.mutex.Lock()
+	registry.listener, registry.server = listener, server
+	registry.mutex.Unlock()
+	context.AfterFunc(ctx, func() {
+		if registry.detach(server) {
+			server.Close()
+		}
+	})
+	go server.Serve(listener)
+	return nil
+}
+
+// GetAddress returns the address the metrics are served on, or nil if they are not served.
+func (registry *Registry) GetAddress() net.Addr {
+	registry.mutex.RLock()
+	defer registry.mutex.RUnlock()
+	if registry.listener == nil {
+		return nil
+	}
+	return registry.listener.Addr()
+}
+
+// Close stops serving the metrics over HTTP, closing all connections immediately.
+func (registry *Registry) Close() error {
+	registry.mutex.RLock()
+	var server = registry.server
+	registry.mutex.RUnlock()
+	if !registry.detach(server) {
+		return nil
+	}
+	return server.Close()
+}
+
+// Shutdown stops serving the metrics over HTTP, waiting for requests in progress
+// to finish until the context is done.
+func (registry *Registry) Shutdown(ctx context.Context) error {
+	registry.mutex.RLock()
+	var server = registry.server
+	registry.mutex.RUnlock()
+	if !registry.detach(server) {
+		return nil
+	}
+	return server.Shutdown(ctx)
+}
+
+// detach removes the server from the registry if the metrics are still served by it.
+// A bool is returned indicating if the server was removed, after which it should be stopped.
+func (registry *Registry) detach(server *http.Server) bool {
+	registry.mutex.Lock()
+	defer registry.mutex.Unlock()
+	if server == nil || registry.server != server {
+		return false
+	}
+	registry.listener, registry.server = nil, nil
+	return true
+}
+
+// register adds the metric to the registry, unless a metric with the same name exists.
+func (registry *Registry) register(metric metric) error {
+	registry.mutex.Lock()
+	defer registry.mutex.Unlock()
+	if registry.names[metric.name] {
+		return DuplicateMetric
+	}
+	registry.names[metric.name] = true
+	registry.metrics = append(registry.metrics, metric)
+	return nil
+}
+
+// formatFloat formats a float as used in the Prometheus text format.
+func formatFloat(value float64) string {
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
+
+// countingWriter is a buffered writer keeping track of the bytes written and the first error.
+type countingWriter struct {
+	*bufio.Writer
+	written int64
+	err     error
+}
+
+// WriteString writes the string, unless a previous write failed.
+func (writer *countingWriter) WriteString(s string) {
+	if writer.err != nil {
+		return
+	}
+	var n, err = writer.Writer.WriteString(s)
+	writer.written += int64(n)
+	writer.err = err
+}
+
+// memStats caches the memory statistics of the runtime,
+// as reading them stops the world.
+type memStats struct {
+	mutex    sync.Mutex
+	stats    runtime.MemStats
+	lastRead time.Time
+}
+
+// read returns the memory statistics, reading them if they are older than a second.
+func (stats *memStats) read() runtime.MemStats {
+	stats.mutex.Lock()
+	defer stats.mutex.Unlock()
+	if time.Since(stats.lastRead) > time.Second {
+		runtime.ReadMemStats(&stats.stats)
+		stats.lastRead = time.Now()
+	}
+	return stats.stats
+}
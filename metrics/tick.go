@@ -0,0 +1,84 @@
+package metrics
+
+import (
+	"sync"
+	"time"
+)
+
+// HistorySize is the amount of samples kept in the history of a tick meter.
+// With a sample every second, the history spans the last 15 minutes.
+const HistorySize = 900
+
+// TickSample is a sample of the ticks per second and average tick duration at a point in time.
+type TickSample struct {
+	Time                time.Time
+	TPS                 float64
+	AverageTickDuration time.Duration
+}
+
+// TickMeter measures the ticks per second and the duration of ticks of the server.
+type TickMeter struct {
+	mutex     sync.Mutex
+	starts    []time.Time
+	durations []time.Duration
+	history   []TickSample
+}
+
+// NewTickMeter returns a new tick meter without any measured ticks.
+func NewTickMeter() *TickMeter {
+	return &TickMeter{}
+}
+
+// Tick records a tick that started at the given time and took the given duration.
+// Only ticks of the last second are kept.
+func (meter *TickMeter) Tick(start time.Time, duration time.Duration) {
+	meter.mutex.Lock()
+	defer meter.mutex.Unlock()
+	meter.starts = append(meter.starts, start)
+	meter.durations = append(meter.durations, duration)
+	var i = 0
+	for i < len(meter.starts) && start.Sub(meter.starts[i]) >= time.Second {
+		i++
+	}
+	meter.starts, meter.durations = meter.starts[i:], meter.durations[i:]
+}
+
+// GetTPS returns the amount of ticks that started within the second before the last tick.
+func (meter *TickMeter) GetTPS() float64 {
+	meter.mutex.Lock()
+	defer meter.mutex.Unlock()
+	return float64(len(meter.starts))
+}
+
+// GetAverageTickDuration returns the average duration of the ticks within the last second.
+func (meter *TickMeter) GetAverageTickDuration() time.Duration {
+	meter.mutex.Lock()
+	defer meter.mutex.Unlock()
+	if len(meter.durations) == 0 {
+		return 0
+	}
+	var total time.Duration
+	for _, duration := range meter.durations {
+		total += duration
+	}
+	return total / time.Duration(len(meter.durations))
+}
+
+// RecordSample adds a sample of the current ticks per second and average tick duration to the history.
+// The oldest sample is dropped once the history holds HistorySize samples.
+func (meter *TickMeter) RecordSample(now time.Time) {
+	var sample = TickSample{Time: now, TPS: meter.GetTPS(), AverageTickDuration: meter.GetAverageTickDuration()}
+	meter.mutex.Lock()
+	defer meter.mutex.Unlock()
+	if len(meter.history) == HistorySize {
+		meter.history = append(meter.history[:0], meter.history[1:]...)
+	}
+	meter.history = append(meter.history, sample)
+}
+
+// GetHistory returns a copy of the recorded samples, oldest first.
+func (meter *TickMeter) GetHistory() []TickSample {
+	meter.mutex.Lock()
+	defer meter.mutex.Unlock()
+	return append([]TickSample(nil), meter.history...)
+}
@@ -0,0 +1,225 @@
+package migrate
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/irmine/gomine/permissions"
+	"github.com/irmine/gomine/players"
+	"github.com/irmine/gomine/resources"
+)
+
+// Source is a server software data can be imported from.
+type Source string
+
+const (
+	PocketMine Source = "pocketmine"
+	Nukkit     Source = "nukkit"
+)
+
+// OperatorGroup is the group operators of the imported server are put in.
+const OperatorGroup = "operator"
+
+// UnknownSource gets returned when importing from a server software that is not supported.
+var UnknownSource = errors.New("unknown server software, expected pocketmine or nukkit")
+
+// Report holds the amount of entries imported from another server.
+type Report struct {
+	Properties  int
+	Operators   int
+	Whitelisted int
+	Bans        int
+	Groups      int
+	Players     int
+	// Skipped holds the entries that could not be imported, such as negated permissions.
+	Skipped []string
+}
+
+// Importer imports the configuration, operators, bans, whitelist
+// and permission plugin data of a PocketMine or Nukkit server into GoMine.
+type Importer struct {
+	config      *resources.GoMineConfig
+	permissions *permissions.Manager
+	storage     players.DataStorage
+}
+
+// NewImporter returns a new importer importing into the configuration,
+// permission manager and player data storage.
+func NewImporter(config *resources.GoMineConfig, permissionManager *permissions.Manager, storage players.DataStorage) *Importer {
+	return &Importer{config, permissionManager, storage}
+}
+
+// Import imports all data of the server of the source in the directory at the path.
+// Files that do not exist are skipped. The configuration is changed,
+// but not saved, as it is owned by the caller.
+func (importer *Importer) Import(source Source, path string) (Report, error) {
+	var report = Report{}
+	if source != PocketMine && source != Nukkit {
+		return report, UnknownSource
+	}
+
+	if properties, err := ReadProperties(filepath.Join(path, "server.properties")); err == nil {
+		report.Properties = ApplyProperties(importer.config, properties)
+	} else if !os.IsNotExist(err) {
+		return report, err
+	}
+
+	var err error
+	if report.Operators, err = importer.importOperators(filepath.Join(path, "ops.txt")); err != nil {
+		return report, err
+	}
+	if report.Whitelisted, err = importer.importWhitelist(filepath.Join(path, "white-list.txt")); err != nil {
+		return report, err
+	}
+
+	var bans []Ban
+	if source == PocketMine {
+		bans, err = ReadPocketMineBans(filepath.Join(path, "banned-players.txt"))
+	} else {
+		bans, err = ReadNukkitBans(filepath.Join(path, "banned-players.json"))
+	}
+	if err != nil && !os.IsNotExist(err) {
+		return report, err
+	}
+	if report.Bans, err = importer.importBans(bans); err != nil {
+		return report, err
+	}
+
+	var purePermsPath = filepath.Join(path, "plugin_data", "PurePerms")
+	if source == Nukkit {
+		purePermsPath = filepath.Join(path, "plugins", "PurePerms")
+	}
+	groups, permissionPlayers, err := ReadPurePerms(purePermsPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return report, nil
+		}
+		return report, err
+	}
+	return report, importer.importPermissions(groups, permissionPlayers, &report)
+}
+
+// importOperators puts all players in the ops list in the operator group.
+func (importer *Importer) importOperators(path string) (int, error) {
+	var names, err = ReadList(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return 0, nil
+		}
+		return 0, err
+	}
+	for _, name := range names {
+		if err := importer.permissions.SetPlayerGroup(name, OperatorGroup); err != nil {
+			return 0, err
+		}
+	}
+	return len(names), nil
+}
+
+// importWhitelist whitelists all players in the whitelist.
+func (importer *Importer) importWhitelist(path string) (int, error) {
+	var names, err = ReadList(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return 0, nil
+		}
+		return 0, err
+	}
+	for _, name := range names {
+		var data, err = importer.storage.Load(name)
+		if err != nil {
+			return 0, err
+		}
+		data.Whitelisted = true
+		if err := importer.storage.Save(data); err != nil {
+			return 0, err
+		}
+	}
+	return len(names), nil
+}
+
+// importBans bans all players of the bans. Bans that have already expired are skipped.
+func (importer *Importer) importBans(bans []Ban) (int, error) {
+	var count int
+	for _, ban := range bans {
+		var duration time.Duration
+		if !ban.Expiry.IsZero() {
+			if duration = time.Until(ban.Expiry); duration <= 0 {
+				continue
+			}
+		}
+		var data, err = importer.storage.Load(ban.Name)
+		if err != nil {
+			return count, err
+		}
+		data.Ban(duration, ban.Reason)
+		if err := importer.storage.Save(data); err != nil {
+			return count, err
+		}
+		count++
+	}
+	return count, nil
+}
+
+// importPermissions creates the groups and applies the groups and permissions of the players.
+// Groups that already exist are extended with the imported permissions and parents.
+func (importer *Importer) importPermissions(groups []PermissionGroup, permissionPlayers []PermissionPlayer, report *Report) error {
+	var manager = importer.permissions
+	for _, group := range groups {
+		if !manager.GroupExists(group.Name) {
+			if _, err := manager.CreateGroup(group.Name, permissions.LevelMember); err != nil {
+				return err
+			}
+		}
+		for _, permission := range group.Permissions {
+			var name, ok = MapPermission(permission)
+			if !ok {
+				report.Skipped = append(report.Skipped, "permission "+permission+" of group "+group.Name)
+				continue
+			}
+			if err := manager.AddGroupPermission(group.Name, name); err != nil {
+				return err
+			}
+		}
+		report.Groups++
+	}
+	for _, group := range groups {
+		for _, parent := range group.Inherits {
+			if !manager.GroupExists(parent) {
+				report.Skipped = append(report.Skipped, "parent "+parent+" of group "+group.Name)
+				continue
+			}
+			if err := manager.AddGroupParent(group.Name, parent); err != nil {
+				return err
+			}
+		}
+		if group.Default {
+			var defaultGroup, _ = manager.GetGroup(group.Name)
+			manager.SetDefaultGroup(defaultGroup)
+		}
+	}
+
+	for _, player := range permissionPlayers {
+		if player.Group != "" {
+			if err := manager.SetPlayerGroup(player.Name, player.Group); err == permissions.UnknownGroup {
+				report.Skipped = append(report.Skipped, "group "+player.Group+" of player "+player.Name)
+			} else if err != nil {
+				return err
+			}
+		}
+		for _, permission := range player.Permissions {
+			var name, ok = MapPermission(permission)
+			if !ok {
+				report.Skipped = append(report.Skipped, "permission "+permission+" of player "+player.Name)
+				continue
+			}
+			if err := manager.AddPlayerPermission(player.Name, name); err != nil {
+				return err
+			}
+		}
+		report.Players++
+	}
+	return manager.Save()
+}
@@ -0,0 +1,105 @@
+package migrate
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/irmine/gomine/permissions"
+	"github.com/irmine/gomine/players"
+	"github.com/irmine/gomine/resources"
+)
+
+func TestApplyProperties(t *testing.T) {
+	config := &resources.GoMineConfig{}
+	applied := ApplyProperties(config, map[string]string{
+		"motd":        "Old Server",
+		"server-port": "19133",
+		"gamemode":    "survival",
+		"white-list":  "on",
+		"level-type":  "DEFAULT",
+		"spawn-mobs":  "true",
+	})
+	if applied != 4 {
+		t.Error("expected 4 applied properties, got", applied)
+	}
+	if config.ServerMotd != "Old Server" || config.ServerPort != 19133 || config.DefaultGameMode != 0 || !config.Whitelist {
+		t.Error("properties were not applied:", config)
+	}
+}
+
+func TestMapPermission(t *testing.T) {
+	tests := map[string]string{
+		"pocketmine.command.list": "gomine.list",
+		"nukkit.command.ban":      "gomine.ban",
+		"myplugin.fly":            "myplugin.fly",
+		"*":                       "gomine.*",
+	}
+	for permission, expected := range tests {
+		if name, ok := MapPermission(permission); !ok || name != expected {
+			t.Errorf("%v mapped to %v, expected %v", permission, name, expected)
+		}
+	}
+	if _, ok := MapPermission("-pocketmine.command.say"); ok {
+		t.Error("negated permission was mapped")
+	}
+}
+
+func TestImport(t *testing.T) {
+	dir, _ := ioutil.TempDir("", "migrate")
+	defer os.RemoveAll(dir)
+	source := filepath.Join(dir, "pocketmine")
+	os.MkdirAll(filepath.Join(source, "plugin_data", "PurePerms", "players"), 0700)
+
+	expiry := time.Now().Add(time.Hour).Format(banTimeLayout)
+	files := map[string]string{
+		"server.properties": "#Properties Config file\nmotd=Old Server\nmax-players=50\n",
+		"ops.txt":           "Steve\n",
+		"white-list.txt":    "alex\nSteve\n",
+		"banned-players.txt": "# Updated\ngriefer|2020-01-01 00:00:00 +0000|Steve|Forever|Griefing\n" +
+			"spammer|2020-01-01 00:00:00 +0000|Steve|" + expiry + "|Spam\n" +
+			"expired|2020-01-01 00:00:00 +0000|Steve|2020-01-02 00:00:00 +0000|Old\n",
+		"plugin_data/PurePerms/groups.yml": "Guest:\n  isDefault: true\n  permissions:\n  - pocketmine.command.list\n" +
+			"VIP:\n  inheritance:\n  - Guest\n  permissions:\n  - -pocketmine.command.say\n  - vip.fly\n",
+		"plugin_data/PurePerms/players/alex.yml": "userName: alex\ngroup: VIP\npermissions:\n- pocketmine.command.tell\n",
+	}
+	for name, content := range files {
+		ioutil.WriteFile(filepath.Join(source, name), []byte(content), 0644)
+	}
+
+	config := &resources.GoMineConfig{}
+	manager := permissions.NewManager()
+	manager.AddGroup(permissions.NewGroup(OperatorGroup, permissions.LevelOperator))
+	storage := players.NewFileDataStorage(filepath.Join(dir, "players") + "/")
+
+	report, err := NewImporter(config, manager, storage).Import(PocketMine, source)
+	if err != nil {
+		t.Fatal("import failed:", err)
+	}
+	if report.Properties != 2 || report.Operators != 1 || report.Whitelisted != 2 || report.Bans != 2 || report.Groups != 2 || report.Players != 1 || len(report.Skipped) != 1 {
+		t.Error("unexpected import report:", report)
+	}
+	if config.ServerMotd != "Old Server" || config.MaximumPlayers != 50 {
+		t.Error("server properties were not imported:", config)
+	}
+	if manager.GetPlayerGroup("steve").GetName() != OperatorGroup {
+		t.Error("operator was not put in the operator group")
+	}
+	if manager.GetDefaultGroup().GetName() != "Guest" || !manager.GetPlayerGroup("alex").HasPermission("gomine.list") {
+		t.Error("groups were not imported")
+	}
+	if data, _ := storage.Load("alex"); !data.Whitelisted {
+		t.Error("whitelisted player was not whitelisted")
+	}
+	if data, _ := storage.Load("griefer"); !data.IsBanned() || data.BanExpiry != 0 || data.BanReason != "Griefing" {
+		t.Error("permanent ban was not imported:", data)
+	}
+	if data, _ := storage.Load("spammer"); !data.IsBanned() || data.BanExpiry == 0 {
+		t.Error("temporary ban was not imported:", data)
+	}
+	if data, _ := storage.Load("expired"); data.Banned {
+		t.Error("expired ban was imported")
+	}
+}
@@ -0,0 +1,108 @@
+package migrate
+
+import (
+	"bufio"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"strings"
+	"time"
+)
+
+// banTimeLayout is the layout of the creation and expiry times of bans,
+// which is the same for PocketMine and Nukkit.
+const banTimeLayout = "2006-01-02 15:04:05 -0700"
+
+// Ban is a player ban read from a PocketMine or Nukkit ban list.
+type Ban struct {
+	Name   string
+	Reason string
+	// Expiry is the time at which the ban expires.
+	// A zero expiry means the ban is permanent.
+	Expiry time.Time
+}
+
+// ReadList reads a list of player names, such as ops.txt or white-list.txt.
+// Names are returned in lower case, and comments and empty lines are skipped.
+func ReadList(path string) ([]string, error) {
+	var file, err = os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var names []string
+	var scanner = bufio.NewScanner(file)
+	for scanner.Scan() {
+		var line = strings.TrimSpace(scanner.Text())
+		if line == "" || line[0] == '#' {
+			continue
+		}
+		names = append(names, strings.ToLower(line))
+	}
+	return names, scanner.Err()
+}
+
+// ReadPocketMineBans reads the banned-players.txt file of PocketMine.
+// Every line holds the name, creation time, source, expiry and reason of a ban,
+// separated by a pipe.
+func ReadPocketMineBans(path string) ([]Ban, error) {
+	var file, err = os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var bans []Ban
+	var scanner = bufio.NewScanner(file)
+	for scanner.Scan() {
+		var line = strings.TrimSpace(scanner.Text())
+		if line == "" || line[0] == '#' {
+			continue
+		}
+		var fragments = strings.Split(line, "|")
+		var ban = Ban{Name: strings.ToLower(fragments[0])}
+		if len(fragments) > 3 {
+			ban.Expiry = parseBanExpiry(fragments[3])
+		}
+		if len(fragments) > 4 {
+			ban.Reason = strings.Join(fragments[4:], "|")
+		}
+		bans = append(bans, ban)
+	}
+	return bans, scanner.Err()
+}
+
+// nukkitBan is a ban in the banned-players.json file of Nukkit.
+type nukkitBan struct {
+	Name       string `json:"name"`
+	ExpireDate string `json:"expireDate"`
+	Reason     string `json:"reason"`
+}
+
+// ReadNukkitBans reads the banned-players.json file of Nukkit.
+func ReadNukkitBans(path string) ([]Ban, error) {
+	var content, err = ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var entries []nukkitBan
+	if err := json.Unmarshal(content, &entries); err != nil {
+		return nil, err
+	}
+	var bans = make([]Ban, len(entries))
+	for i, entry := range entries {
+		bans[i] = Ban{Name: strings.ToLower(entry.Name), Reason: entry.Reason, Expiry: parseBanExpiry(entry.ExpireDate)}
+	}
+	return bans, nil
+}
+
+// parseBanExpiry parses the expiry of a ban.
+// A zero time is returned for permanent bans, which have "Forever" as expiry.
+func parseBanExpiry(value string) time.Time {
+	var expiry, err = time.Parse(banTimeLayout, strings.TrimSpace(value))
+	if err != nil {
+		return time.Time{}
+	}
+	return expiry
+}
@@ -0,0 +1,119 @@
+package migrate
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+// PermissionGroup is a group read from the data of a permission plugin.
+type PermissionGroup struct {
+	Name string
+	// Default specifies if new players are put in the group.
+	Default     bool
+	Inherits    []string
+	Permissions []string
+}
+
+// PermissionPlayer is the group and permissions of a player
+// read from the data of a permission plugin.
+type PermissionPlayer struct {
+	Name        string
+	Group       string
+	Permissions []string
+}
+
+// purePermsGroup is a group in the groups.yml file of PurePerms.
+type purePermsGroup struct {
+	IsDefault   bool     `yaml:"isDefault"`
+	Inheritance []string `yaml:"inheritance"`
+	Permissions []string `yaml:"permissions"`
+}
+
+// purePermsPlayer is a player in the player files of PurePerms.
+type purePermsPlayer struct {
+	UserName    string   `yaml:"userName"`
+	Group       string   `yaml:"group"`
+	Permissions []string `yaml:"permissions"`
+}
+
+// ReadPurePerms reads the groups and players of the PurePerms plugin from its data directory.
+// Players are read from both the players directory and the players.yml file,
+// which are used by the different YAML providers of PurePerms.
+func ReadPurePerms(path string) ([]PermissionGroup, []PermissionPlayer, error) {
+	var content, err = ioutil.ReadFile(filepath.Join(path, "groups.yml"))
+	if err != nil {
+		return nil, nil, err
+	}
+	var groupRecords = make(map[string]purePermsGroup)
+	if err := yaml.Unmarshal(content, &groupRecords); err != nil {
+		return nil, nil, err
+	}
+	var groups []PermissionGroup
+	for name, record := range groupRecords {
+		groups = append(groups, PermissionGroup{Name: name, Default: record.IsDefault, Inherits: record.Inheritance, Permissions: record.Permissions})
+	}
+
+	var players []PermissionPlayer
+	if content, err := ioutil.ReadFile(filepath.Join(path, "players.yml")); err == nil {
+		var records = make(map[string]purePermsPlayer)
+		if err := yaml.Unmarshal(content, &records); err != nil {
+			return nil, nil, err
+		}
+		for name, record := range records {
+			players = append(players, PermissionPlayer{Name: strings.ToLower(name), Group: record.Group, Permissions: record.Permissions})
+		}
+	} else if !os.IsNotExist(err) {
+		return nil, nil, err
+	}
+
+	files, err := ioutil.ReadDir(filepath.Join(path, "players"))
+	if err != nil && !os.IsNotExist(err) {
+		return nil, nil, err
+	}
+	for _, file := range files {
+		if file.IsDir() || !strings.HasSuffix(file.Name(), ".yml") {
+			continue
+		}
+		content, err := ioutil.ReadFile(filepath.Join(path, "players", file.Name()))
+		if err != nil {
+			return nil, nil, err
+		}
+		var record purePermsPlayer
+		if err := yaml.Unmarshal(content, &record); err != nil {
+			return nil, nil, err
+		}
+		if record.UserName == "" {
+			record.UserName = strings.TrimSuffix(file.Name(), ".yml")
+		}
+		players = append(players, PermissionPlayer{Name: strings.ToLower(record.UserName), Group: record.Group, Permissions: record.Permissions})
+	}
+	return groups, players, nil
+}
+
+// MapPermission maps a PocketMine or Nukkit permission to its GoMine equivalent.
+// Command permissions such as "pocketmine.command.list" map to "gomine.list",
+// and permissions of plugins are kept as is.
+// A bool is returned indicating if the permission can be mapped.
+// Negated permissions, which GoMine does not support, can not be mapped.
+func MapPermission(permission string) (string, bool) {
+	permission = strings.ToLower(strings.TrimSpace(permission))
+	if permission == "" || permission[0] == '-' {
+		return "", false
+	}
+	for _, prefix := range []string{"pocketmine.", "nukkit."} {
+		if !strings.HasPrefix(permission, prefix) {
+			continue
+		}
+		permission = strings.TrimPrefix(permission, prefix)
+		permission = strings.TrimPrefix(permission, "command.")
+		return "gomine." + permission, true
+	}
+	if permission == "*" {
+		return "gomine.*", true
+	}
+	return permission, true
+}
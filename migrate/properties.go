@@ -0,0 +1,128 @@
+package migrate
+
+import (
+	"bufio"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/irmine/gomine/resources"
+)
+
+// ReadProperties reads a server.properties file of PocketMine or Nukkit
+// into a key => value map. Comments and empty lines are skipped.
+func ReadProperties(path string) (map[string]string, error) {
+	var file, err = os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var properties = make(map[string]string)
+	var scanner = bufio.NewScanner(file)
+	for scanner.Scan() {
+		var line = strings.TrimSpace(scanner.Text())
+		if line == "" || line[0] == '#' {
+			continue
+		}
+		var fragments = strings.SplitN(line, "=", 2)
+		if len(fragments) != 2 {
+			continue
+		}
+		properties[strings.TrimSpace(fragments[0])] = strings.TrimSpace(fragments[1])
+	}
+	return properties, scanner.Err()
+}
+
+// ApplyProperties maps the server properties onto the GoMine configuration.
+// Properties without a GoMine equivalent, and properties with invalid values, are ignored.
+// The amount of applied properties is returned.
+func ApplyProperties(config *resources.GoMineConfig, properties map[string]string) int {
+	var applied int
+	for key, value := range properties {
+		if applyProperty(config, key, value) {
+			applied++
+		}
+	}
+	return applied
+}
+
+// applyProperty applies a single property to the configuration.
+// A bool is returned indicating if the property was applied.
+func applyProperty(config *resources.GoMineConfig, key string, value string) bool {
+	switch key {
+	case "motd":
+		config.ServerMotd = value
+		config.ServerName = value
+	case "sub-motd":
+		config.SubMotd = value
+	case "server-ip":
+		config.ServerIp = value
+	case "server-port":
+		var port, err = strconv.ParseUint(value, 10, 16)
+		if err != nil {
+			return false
+		}
+		config.ServerPort = uint16(port)
+	case "max-players":
+		var players, err = strconv.ParseUint(value, 10, 32)
+		if err != nil {
+			return false
+		}
+		config.MaximumPlayers = uint(players)
+	case "gamemode":
+		var gameMode, ok = parseGameMode(value)
+		if !ok {
+			return false
+		}
+		config.DefaultGameMode = gameMode
+	case "level-name":
+		config.DefaultLevel = value
+	case "level-type":
+		// Flat is the only generator PocketMine and Nukkit share with GoMine.
+		if !strings.EqualFold(value, "flat") {
+			return false
+		}
+		config.DefaultGenerator = "Flat"
+	case "view-distance":
+		var distance, err = strconv.ParseInt(value, 10, 32)
+		if err != nil {
+			return false
+		}
+		config.MaxViewDistance = int32(distance)
+	case "xbox-auth":
+		config.XBOXLiveAuth = parseBool(value)
+	case "white-list":
+		config.Whitelist = parseBool(value)
+	case "enable-query":
+		config.AllowQuery = parseBool(value)
+	default:
+		return false
+	}
+	return true
+}
+
+// parseGameMode parses a game mode, which is either a number or a name such as "survival".
+func parseGameMode(value string) (byte, bool) {
+	switch strings.ToLower(value) {
+	case "0", "survival":
+		return 0, true
+	case "1", "creative":
+		return 1, true
+	case "2", "adventure":
+		return 2, true
+	case "3", "spectator", "view":
+		return 3, true
+	}
+	return 0, false
+}
+
+// parseBool parses a boolean property value.
+// PocketMine writes booleans as "on" and "off", while Nukkit writes "true" and "false".
+func parseBool(value string) bool {
+	switch strings.ToLower(value) {
+	case "on", "true", "yes", "1":
+		return true
+	}
+	return false
+}
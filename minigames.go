@@ -0,0 +1,70 @@
+package gomine
+
+import (
+	"github.com/irmine/gomine/minigames"
+	"github.com/irmine/gomine/net"
+	"github.com/irmine/worlds"
+	"github.com/irmine/worlds/generation/defaults"
+)
+
+// NewArena returns a new arena of which the instances are copied from the template level directory
+// and opened as level with the given name in the worlds directory of the server.
+// Players still in an instance when it gets unloaded are moved to the spawn of the default level.
+func (server *Server) NewArena(levelName, template string) *minigames.Arena {
+	var arena = minigames.NewArena(levelName, template, server.ServerPath+"worlds/")
+	arena.LoadFunction = server.loadArena
+	arena.UnloadFunction = server.unloadArena
+	return arena
+}
+
+// loadArena opens the level with the given name as an instance of an arena.
+func (server *Server) loadArena(levelName string) error {
+	var level = worlds.NewLevel(levelName, server.ServerPath)
+	if _, err := server.LevelStorage.Open(level, server.getWorldFormat()); err != nil {
+		return err
+	}
+	var dimension = worlds.NewDimension("overworld", level, worlds.OverworldId)
+	if err := server.LevelStorage.AddDimension(dimension, "overworld"); err != nil {
+		server.LevelStorage.CloseLevel(levelName)
+		return err
+	}
+	level.SetDefaultDimension(dimension)
+	dimension.SetGenerator(defaults.NewFlatGenerator())
+	server.LevelManager.AddLevel(level)
+	return nil
+}
+
+// unloadArena moves all players out of the instance of an arena with the given level name and closes the level.
+func (server *Server) unloadArena(levelName string) error {
+	var defaultLevel = server.LevelManager.GetDefaultLevel()
+	for _, session := range server.SessionManager.GetSessions() {
+		var dimension = session.GetPlayer().GetDimension()
+		if dimension != nil && dimension.GetLevel().GetName() == levelName {
+			session.ChangeDimension(defaultLevel.GetDefaultDimension(), server.LevelStorage.GetSpawn(defaultLevel.GetName()))
+		}
+	}
+	for _, level := range server.LevelManager.GetLevels() {
+		if level.GetName() == levelName {
+			server.LevelManager.RemoveLevel(level)
+		}
+	}
+	return server.LevelStorage.CloseLevel(levelName)
+}
+
+// receivesChat checks if the receiver receives chat sent by the sender.
+// Players in a game only receive chat of the players in the same game.
+func (server *Server) receivesChat(sender, receiver *net.MinecraftSession) bool {
+	var game, ok = server.MinigameManager.GetGameOf(receiver.GetName())
+	if !ok {
+		_, ok = server.MinigameManager.GetGameOf(sender.GetName())
+		return !ok
+	}
+	return game.HasPlayer(sender.GetName())
+}
+
+// showsServerScoreboard checks if the session is shown the scoreboard of the server,
+// which is not the case while the session is in a game that shows its own scoreboard.
+func (server *Server) showsServerScoreboard(session *net.MinecraftSession) bool {
+	var game, ok = server.MinigameManager.GetGameOf(session.GetName())
+	return !ok || !game.HasScoreboard()
+}
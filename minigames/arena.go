@@ -0,0 +1,124 @@
+package minigames
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// Arena is the world a game is played in.
+// Every round is played in a fresh instance of the arena, copied from a template level directory,
+// so that changes made to the world during a round are discarded once the game resets.
+// All instances of an arena use the same level name.
+type Arena struct {
+	// LoadFunction gets called with the level name of a new instance once the template was copied,
+	// and should open the level. It does nothing by default.
+	LoadFunction func(levelName string) error
+	// UnloadFunction gets called with the level name of the current instance before its directory gets removed,
+	// and should move all players out of the level and close it. It does nothing by default.
+	UnloadFunction func(levelName string) error
+
+	mutex     sync.Mutex
+	levelName string
+	template  string
+	path      string
+	loaded    bool
+}
+
+// NewArena returns a new arena of which the instances are copied from the template directory
+// to the directory of the level with the given name in the worlds directory.
+func NewArena(levelName, template, worldsPath string) *Arena {
+	return &Arena{
+		LoadFunction:   func(string) error { return nil },
+		UnloadFunction: func(string) error { return nil },
+		levelName:      levelName,
+		template:       template,
+		path:           filepath.Join(worldsPath, levelName),
+	}
+}
+
+// GetLevelName returns the level name of the instances of the arena.
+func (arena *Arena) GetLevelName() string {
+	return arena.levelName
+}
+
+// IsLoaded checks if an instance of the arena is currently loaded.
+func (arena *Arena) IsLoaded() bool {
+	arena.mutex.Lock()
+	defer arena.mutex.Unlock()
+	return arena.loaded
+}
+
+// NewInstance unloads and removes the current instance of the arena, if any,
+// and loads a fresh copy of the template as new instance.
+func (arena *Arena) NewInstance() error {
+	arena.mutex.Lock()
+	defer arena.mutex.Unlock()
+	if err := arena.close(); err != nil {
+		return err
+	}
+	if err := copyDirectory(arena.template, arena.path); err != nil {
+		return err
+	}
+	if err := arena.LoadFunction(arena.levelName); err != nil {
+		return err
+	}
+	arena.loaded = true
+	return nil
+}
+
+// Close unloads and removes the current instance of the arena, if any.
+func (arena *Arena) Close() error {
+	arena.mutex.Lock()
+	defer arena.mutex.Unlock()
+	return arena.close()
+}
+
+// close unloads and removes the current instance of the arena.
+// The mutex of the arena must be locked.
+func (arena *Arena) close() error {
+	if arena.loaded {
+		if err := arena.UnloadFunction(arena.levelName); err != nil {
+			return err
+		}
+		arena.loaded = false
+	}
+	return os.RemoveAll(arena.path)
+}
+
+// copyDirectory copies all files in the source directory and its sub directories to the destination directory.
+func copyDirectory(source, destination string) error {
+	return filepath.Walk(source, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		var relative, relErr = filepath.Rel(source, path)
+		if relErr != nil {
+			return relErr
+		}
+		var target = filepath.Join(destination, relative)
+		if info.IsDir() {
+			return os.MkdirAll(target, 0700)
+		}
+		return copyFile(path, target)
+	})
+}
+
+// copyFile copies the file at the source path to the destination path.
+func copyFile(source, destination string) error {
+	var in, err = os.Open(source)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	out, err := os.Create(destination)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
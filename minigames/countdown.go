@@ -0,0 +1,145 @@
+package minigames
+
+import (
+	"sync"
+
+	"github.com/irmine/gomine/scheduler"
+)
+
+// TicksPerSecond is the amount of server ticks in one second.
+// Countdowns are ticked every server tick,
+// and use this to convert seconds to ticks.
+const TicksPerSecond = 20
+
+// Countdown counts down a given amount of seconds.
+// Countdowns with a scheduler are ticked by a task of the scheduler while they run,
+// and countdowns without one must be ticked every server tick using Tick.
+// Countdowns call their functions every second and
+// once the countdown reaches zero.
+type Countdown struct {
+	// TickFunction gets called every second while
+	// the countdown is running, with the seconds left.
+	TickFunction func(secondsLeft int)
+	// FinishFunction gets called once the countdown
+	// reaches zero. The countdown stops running after.
+	FinishFunction func()
+
+	mutex     sync.Mutex
+	seconds   int
+	ticksLeft int
+	running   bool
+	scheduler *scheduler.Scheduler
+	task      *scheduler.Task
+}
+
+// NewCountdown returns a new countdown of the given seconds.
+// The countdown does not run until Start gets called.
+func NewCountdown(seconds int) *Countdown {
+	return &Countdown{TickFunction: func(int) {}, FinishFunction: func() {}, seconds: seconds, ticksLeft: seconds * TicksPerSecond}
+}
+
+// SetScheduler sets the scheduler ticking the countdown while it runs.
+// The change takes effect the next time the countdown gets started.
+func (countdown *Countdown) SetScheduler(scheduler *scheduler.Scheduler) {
+	countdown.mutex.Lock()
+	countdown.scheduler = scheduler
+	countdown.mutex.Unlock()
+}
+
+// GetSeconds returns the total seconds the countdown counts down from.
+func (countdown *Countdown) GetSeconds() int {
+	countdown.mutex.Lock()
+	defer countdown.mutex.Unlock()
+	return countdown.seconds
+}
+
+// SetSeconds sets the total seconds the countdown counts down from.
+// The change takes effect the next time the countdown gets started.
+func (countdown *Countdown) SetSeconds(seconds int) {
+	countdown.mutex.Lock()
+	countdown.seconds = seconds
+	countdown.mutex.Unlock()
+}
+
+// GetSecondsLeft returns the seconds left on the countdown.
+func (countdown *Countdown) GetSecondsLeft() int {
+	countdown.mutex.Lock()
+	defer countdown.mutex.Unlock()
+	return (countdown.ticksLeft + TicksPerSecond - 1) / TicksPerSecond
+}
+
+// IsRunning checks if the countdown is currently running.
+func (countdown *Countdown) IsRunning() bool {
+	countdown.mutex.Lock()
+	defer countdown.mutex.Unlock()
+	return countdown.running
+}
+
+// Start starts the countdown from the total seconds.
+// A running countdown gets restarted.
+func (countdown *Countdown) Start() {
+	countdown.mutex.Lock()
+	defer countdown.mutex.Unlock()
+	countdown.ticksLeft = countdown.seconds * TicksPerSecond
+	countdown.running = true
+	countdown.cancelTask()
+	if countdown.scheduler != nil {
+		countdown.task = countdown.scheduler.ScheduleRepeating(countdown.tick, 1, 1)
+	}
+}
+
+// Stop stops the countdown without calling the finish function.
+func (countdown *Countdown) Stop() {
+	countdown.mutex.Lock()
+	countdown.running = false
+	countdown.cancelTask()
+	countdown.mutex.Unlock()
+}
+
+// Tick ticks the countdown if it is not ticked by a scheduler.
+// Internal. Not to be used by plugins.
+func (countdown *Countdown) Tick() {
+	countdown.mutex.Lock()
+	var scheduled = countdown.task != nil
+	countdown.mutex.Unlock()
+	if !scheduled {
+		countdown.tick()
+	}
+}
+
+// tick counts down one tick, calling the tick function every second and the finish function once it reaches zero.
+// The functions are called without holding the mutex, as they commonly start or stop countdowns.
+func (countdown *Countdown) tick() {
+	countdown.mutex.Lock()
+	if !countdown.running {
+		countdown.mutex.Unlock()
+		return
+	}
+	var secondsLeft = -1
+	if countdown.ticksLeft%TicksPerSecond == 0 && countdown.ticksLeft > 0 {
+		secondsLeft = countdown.ticksLeft / TicksPerSecond
+	}
+	countdown.ticksLeft--
+	var finished = countdown.ticksLeft <= 0
+	if finished {
+		countdown.running = false
+		countdown.cancelTask()
+	}
+	countdown.mutex.Unlock()
+
+	if secondsLeft != -1 {
+		countdown.TickFunction(secondsLeft)
+	}
+	if finished {
+		countdown.FinishFunction()
+	}
+}
+
+// cancelTask cancels the task ticking the countdown, if any.
+// The mutex of the countdown must be locked.
+func (countdown *Countdown) cancelTask() {
+	if countdown.task != nil {
+		countdown.task.Cancel()
+		countdown.task = nil
+	}
+}
@@ -0,0 +1,94 @@
+package minigames
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestCountdown(t *testing.T) {
+	countdown := NewCountdown(3)
+	var seconds []int
+	finished := false
+	countdown.TickFunction = func(secondsLeft int) {
+		seconds = append(seconds, secondsLeft)
+	}
+	countdown.FinishFunction = func() {
+		finished = true
+	}
+	countdown.Start()
+	for i := 0; i < 3*TicksPerSecond; i++ {
+		countdown.Tick()
+	}
+	if !finished || countdown.IsRunning() {
+		t.Error("countdown did not finish after 3 seconds")
+	}
+	if len(seconds) != 3 || seconds[0] != 3 || seconds[2] != 1 {
+		t.Error("unexpected countdown seconds:", seconds)
+	}
+}
+
+func TestGameReset(t *testing.T) {
+	game := NewGame("Test", 2, 8)
+	var states []State
+	game.StateChangeFunction = func(old State, new State) {
+		states = append(states, new)
+	}
+	game.Start()
+	game.End()
+	for i := 0; i < game.EndCountdown.GetSeconds()*TicksPerSecond; i++ {
+		game.Tick()
+	}
+	if game.GetState() != StateWaiting {
+		t.Error("game did not reset to waiting, got:", game.GetState())
+	}
+	if len(states) != 3 {
+		t.Error("unexpected state changes:", states)
+	}
+}
+
+func TestArenaInstance(t *testing.T) {
+	var dir, err = ioutil.TempDir("", "minigames")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	var template, worlds = filepath.Join(dir, "template"), filepath.Join(dir, "worlds")
+	os.MkdirAll(template, 0700)
+	if err := ioutil.WriteFile(filepath.Join(template, "level.dat"), []byte("template"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	arena := NewArena("arena", template, worlds)
+	var loads, unloads int
+	arena.LoadFunction = func(string) error {
+		loads++
+		return nil
+	}
+	arena.UnloadFunction = func(string) error {
+		unloads++
+		return nil
+	}
+	if err := arena.NewInstance(); err != nil {
+		t.Fatal(err)
+	}
+	var path = filepath.Join(worlds, "arena", "level.dat")
+	if err := ioutil.WriteFile(path, []byte("changed"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := arena.NewInstance(); err != nil {
+		t.Fatal(err)
+	}
+	if data, err := ioutil.ReadFile(path); err != nil || string(data) != "template" {
+		t.Error("new instance was not copied from the template:", string(data), err)
+	}
+	if err := arena.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) || arena.IsLoaded() {
+		t.Error("closed instance was not removed")
+	}
+	if loads != 2 || unloads != 2 {
+		t.Error("unexpected loads and unloads:", loads, unloads)
+	}
+}
@@ -0,0 +1,355 @@
+package minigames
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/irmine/gomine/net"
+	"github.com/irmine/gomine/net/packets/data"
+	"github.com/irmine/gomine/net/packets/types"
+	"github.com/irmine/gomine/scheduler"
+	"github.com/irmine/gomine/text"
+)
+
+var (
+	// GameFull gets returned when a player tries
+	// to join a game that has reached its max players.
+	GameFull = errors.New("game is full")
+	// GameInProgress gets returned when a player tries
+	// to join a game that is already running or ending.
+	GameInProgress = errors.New("game is already in progress")
+)
+
+// Game is a single round based minigame.
+// Games keep track of their players, teams and state,
+// and move through their states automatically using
+// the start and end countdowns.
+type Game struct {
+	// StartCountdown is the countdown used once enough
+	// players have joined, before the game starts running.
+	StartCountdown *Countdown
+	// EndCountdown is the countdown used once the game
+	// has ended, before the game gets reset.
+	EndCountdown *Countdown
+	// Arena is the arena the game is played in, of which a fresh instance
+	// is loaded every time the game resets. Games have no arena by default.
+	Arena *Arena
+
+	// StateChangeFunction gets called every time the
+	// state of the game changes, with the old and new state.
+	StateChangeFunction func(old State, new State)
+	// ResetFunction gets called when the game gets reset,
+	// before the state is set back to waiting.
+	// Games with an arena have a fresh instance of it loaded
+	// when the function gets called, so plugins can move
+	// the players of the game into it here.
+	ResetFunction func(game *Game)
+
+	mutex      sync.RWMutex
+	name       string
+	state      State
+	minPlayers int
+	maxPlayers int
+	players    map[string]*net.MinecraftSession
+	teams      []*Team
+	scoreboard *scoreboard
+}
+
+// scoreboard is the sidebar scoreboard shown to the players of a game.
+type scoreboard struct {
+	title string
+	lines []string
+}
+
+// NewGame returns a new game with the given name, minimum and maximum players.
+// The game starts counting down as soon as the minimum amount of players joined.
+// A max players of 0 or lower means the game has no limit.
+func NewGame(name string, minPlayers int, maxPlayers int) *Game {
+	var game = &Game{
+		StartCountdown:      NewCountdown(30),
+		EndCountdown:        NewCountdown(10),
+		StateChangeFunction: func(State, State) {},
+		ResetFunction:       func(*Game) {},
+		name:                name,
+		minPlayers:          minPlayers,
+		maxPlayers:          maxPlayers,
+		players:             make(map[string]*net.MinecraftSession),
+	}
+	game.StartCountdown.FinishFunction = game.Start
+	game.EndCountdown.FinishFunction = game.Reset
+	return game
+}
+
+// SetScheduler sets the scheduler ticking the countdowns of the game.
+func (game *Game) SetScheduler(scheduler *scheduler.Scheduler) {
+	game.StartCountdown.SetScheduler(scheduler)
+	game.EndCountdown.SetScheduler(scheduler)
+}
+
+// GetName returns the name of the game.
+func (game *Game) GetName() string {
+	return game.name
+}
+
+// GetState returns the current state of the game.
+func (game *Game) GetState() State {
+	game.mutex.RLock()
+	defer game.mutex.RUnlock()
+	return game.state
+}
+
+// SetState sets the state of the game,
+// and calls the state change function.
+func (game *Game) SetState(state State) {
+	game.mutex.Lock()
+	var old = game.state
+	game.state = state
+	game.mutex.Unlock()
+
+	if old != state {
+		game.StateChangeFunction(old, state)
+	}
+}
+
+// GetMinPlayers returns the minimum amount of players required to start.
+func (game *Game) GetMinPlayers() int {
+	return game.minPlayers
+}
+
+// GetMaxPlayers returns the maximum amount of players of the game.
+func (game *Game) GetMaxPlayers() int {
+	return game.maxPlayers
+}
+
+// GetPlayers returns a name => session map of all players in the game.
+func (game *Game) GetPlayers() map[string]*net.MinecraftSession {
+	game.mutex.RLock()
+	defer game.mutex.RUnlock()
+	var players = make(map[string]*net.MinecraftSession, len(game.players))
+	for name, session := range game.players {
+		players[name] = session
+	}
+	return players
+}
+
+// GetPlayerCount returns the amount of players in the game.
+func (game *Game) GetPlayerCount() int {
+	game.mutex.RLock()
+	defer game.mutex.RUnlock()
+	return len(game.players)
+}
+
+// HasPlayer checks if the game has a player with the given name.
+func (game *Game) HasPlayer(name string) bool {
+	game.mutex.RLock()
+	defer game.mutex.RUnlock()
+	var _, ok = game.players[name]
+	return ok
+}
+
+// Join adds a player to the game.
+// GameInProgress gets returned if the game is running or ending,
+// and GameFull if the game has reached its max players.
+// The start countdown gets started once enough players have joined.
+func (game *Game) Join(session *net.MinecraftSession) error {
+	var state = game.GetState()
+	if state == StateRunning || state == StateEnding {
+		return GameInProgress
+	}
+	game.mutex.Lock()
+	if game.maxPlayers > 0 && len(game.players) >= game.maxPlayers {
+		game.mutex.Unlock()
+		return GameFull
+	}
+	game.players[session.GetName()] = session
+	var count = len(game.players)
+	var scoreboard = game.scoreboard
+	game.mutex.Unlock()
+
+	if scoreboard != nil {
+		session.SetScoreboard(scoreboard.title, scoreboard.lines)
+	}
+
+	if state == StateWaiting && count >= game.minPlayers {
+		game.SetState(StateStarting)
+		game.StartCountdown.Start()
+	}
+	return nil
+}
+
+// Leave removes a player with the given name from the game and its team.
+// The scoreboard of the game gets removed for the player,
+// and the start countdown gets stopped if too few players are left.
+func (game *Game) Leave(name string) {
+	game.mutex.Lock()
+	var session, ok = game.players[name]
+	delete(game.players, name)
+	var count = len(game.players)
+	var scoreboard = game.scoreboard
+	game.mutex.Unlock()
+
+	if ok && scoreboard != nil {
+		session.RemoveScoreboard()
+	}
+
+	for _, team := range game.GetTeams() {
+		team.RemovePlayer(name)
+	}
+	if game.GetState() == StateStarting && count < game.minPlayers {
+		game.StartCountdown.Stop()
+		game.SetState(StateWaiting)
+	}
+}
+
+// AddTeam adds a team to the game.
+func (game *Game) AddTeam(team *Team) {
+	game.mutex.Lock()
+	game.teams = append(game.teams, team)
+	game.mutex.Unlock()
+}
+
+// GetTeams returns all teams of the game.
+func (game *Game) GetTeams() []*Team {
+	game.mutex.RLock()
+	defer game.mutex.RUnlock()
+	var teams = make([]*Team, len(game.teams))
+	copy(teams, game.teams)
+	return teams
+}
+
+// GetTeam returns the team a player with the given name is in.
+// A bool is returned indicating if the player had a team.
+func (game *Game) GetTeam(name string) (*Team, bool) {
+	for _, team := range game.GetTeams() {
+		if team.HasPlayer(name) {
+			return team, true
+		}
+	}
+	return nil, false
+}
+
+// AssignTeams assigns all players without a team to the team
+// with the least players, keeping the teams balanced.
+// Players that can not fit in any team remain without a team.
+func (game *Game) AssignTeams() {
+	var teams = game.GetTeams()
+	if len(teams) == 0 {
+		return
+	}
+	for name, session := range game.GetPlayers() {
+		if _, ok := game.GetTeam(name); ok {
+			continue
+		}
+		var smallest *Team
+		for _, team := range teams {
+			if team.IsFull() {
+				continue
+			}
+			if smallest == nil || team.GetPlayerCount() < smallest.GetPlayerCount() {
+				smallest = team
+			}
+		}
+		if smallest == nil {
+			return
+		}
+		smallest.AddPlayer(session)
+	}
+}
+
+// Start starts the game immediately.
+// Teams get assigned, after which the state is set to running.
+func (game *Game) Start() {
+	game.StartCountdown.Stop()
+	game.AssignTeams()
+	game.SetState(StateRunning)
+}
+
+// End ends the game and starts the end countdown,
+// after which the game gets reset.
+func (game *Game) End() {
+	if game.GetState() == StateEnding {
+		return
+	}
+	game.SetState(StateEnding)
+	game.EndCountdown.Start()
+}
+
+// Reset resets the game for a new round.
+// A fresh instance of the arena gets loaded, the reset function
+// gets called and all teams are cleared. Players remain in the game,
+// and the start countdown gets started again if enough players are left.
+func (game *Game) Reset() {
+	game.StartCountdown.Stop()
+	game.EndCountdown.Stop()
+	if game.Arena != nil {
+		text.DefaultLogger.LogError(game.Arena.NewInstance())
+	}
+	game.ResetFunction(game)
+	for _, team := range game.GetTeams() {
+		team.Clear()
+	}
+	game.SetState(StateWaiting)
+
+	if game.GetPlayerCount() >= game.minPlayers && game.GetPlayerCount() > 0 {
+		game.SetState(StateStarting)
+		game.StartCountdown.Start()
+	}
+}
+
+// BroadcastMessage broadcasts a message to all players in the game.
+func (game *Game) BroadcastMessage(message ...interface{}) {
+	for _, session := range game.GetPlayers() {
+		session.SendMessage(message...)
+	}
+}
+
+// SetScoreboard shows a sidebar scoreboard with the given title and lines to all players in the game,
+// including players joining later. It replaces the sidebar of the server for the players in the game.
+func (game *Game) SetScoreboard(title string, lines []string) {
+	game.mutex.Lock()
+	game.scoreboard = &scoreboard{title, append([]string{}, lines...)}
+	game.mutex.Unlock()
+	for _, session := range game.GetPlayers() {
+		session.SetScoreboard(title, lines)
+	}
+}
+
+// RemoveScoreboard removes the sidebar scoreboard of the game for all players in the game.
+func (game *Game) RemoveScoreboard() {
+	game.mutex.Lock()
+	game.scoreboard = nil
+	game.mutex.Unlock()
+	for _, session := range game.GetPlayers() {
+		session.RemoveScoreboard()
+	}
+}
+
+// HasScoreboard checks if the game shows a scoreboard to its players.
+func (game *Game) HasScoreboard() bool {
+	game.mutex.RLock()
+	defer game.mutex.RUnlock()
+	return game.scoreboard != nil
+}
+
+// SendChat sends a chat message of a player to all players in the game.
+// Chat of players in a game is scoped to that game only.
+func (game *Game) SendChat(sender *net.MinecraftSession, message string) {
+	var prefix = ""
+	if team, ok := game.GetTeam(sender.GetName()); ok {
+		prefix = team.GetColor() + "[" + team.GetName() + "] "
+	}
+	for _, session := range game.GetPlayers() {
+		session.SendText(types.Text{
+			Message:    prefix + "<" + sender.GetDisplayName() + "> " + message,
+			SourceXUID: sender.GetXUID(),
+			TextType:   data.TextChat,
+		})
+	}
+}
+
+// Tick ticks the countdowns of the game that are not ticked by a scheduler.
+// Internal. Not to be used by plugins.
+func (game *Game) Tick() {
+	game.StartCountdown.Tick()
+	game.EndCountdown.Tick()
+}
@@ -0,0 +1,136 @@
+package minigames
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/irmine/gomine/net"
+	"github.com/irmine/gomine/scheduler"
+	"github.com/irmine/gomine/text"
+)
+
+var (
+	// UnknownGame gets returned when a game
+	// with a given name could not be found.
+	UnknownGame = errors.New("unknown game")
+	// AlreadyInGame gets returned when a player tries
+	// to join a game while already being in a game.
+	AlreadyInGame = errors.New("player is already in a game")
+)
+
+// Manager manages all games on the server.
+// It schedules the countdowns of all games, and keeps track
+// of which game every player is in.
+type Manager struct {
+	// LeaveFunction gets called with the session of every player
+	// that left a game through the manager. It does nothing by default.
+	LeaveFunction func(session *net.MinecraftSession)
+
+	mutex     sync.RWMutex
+	games     map[string]*Game
+	scheduler *scheduler.Scheduler
+}
+
+// NewManager returns a new minigame manager, of which the countdowns of all games are ticked by the scheduler.
+// Games of a manager without scheduler must be ticked using Tick.
+func NewManager(scheduler *scheduler.Scheduler) *Manager {
+	return &Manager{LeaveFunction: func(*net.MinecraftSession) {}, games: make(map[string]*Game), scheduler: scheduler}
+}
+
+// AddGame adds a game to the manager.
+// Games with the same name get overwritten.
+// The first instance of the arena of the game gets loaded if it was not yet loaded.
+func (manager *Manager) AddGame(game *Game) {
+	if manager.scheduler != nil {
+		game.SetScheduler(manager.scheduler)
+	}
+	if game.Arena != nil && !game.Arena.IsLoaded() {
+		text.DefaultLogger.LogError(game.Arena.NewInstance())
+	}
+	manager.mutex.Lock()
+	manager.games[game.GetName()] = game
+	manager.mutex.Unlock()
+}
+
+// RemoveGame removes a game with the given name from the manager.
+// The countdowns of the game are stopped and the instance of its arena is closed.
+func (manager *Manager) RemoveGame(name string) {
+	manager.mutex.Lock()
+	var game, ok = manager.games[name]
+	delete(manager.games, name)
+	manager.mutex.Unlock()
+	if !ok {
+		return
+	}
+	game.StartCountdown.Stop()
+	game.EndCountdown.Stop()
+	if game.Arena != nil {
+		text.DefaultLogger.LogError(game.Arena.Close())
+	}
+}
+
+// GetGame returns a game by its name, and an error if it could not be found.
+func (manager *Manager) GetGame(name string) (*Game, error) {
+	manager.mutex.RLock()
+	defer manager.mutex.RUnlock()
+	var game, ok = manager.games[name]
+	if !ok {
+		return nil, UnknownGame
+	}
+	return game, nil
+}
+
+// GetGames returns a name => game map of all games.
+func (manager *Manager) GetGames() map[string]*Game {
+	manager.mutex.RLock()
+	defer manager.mutex.RUnlock()
+	var games = make(map[string]*Game, len(manager.games))
+	for name, game := range manager.games {
+		games[name] = game
+	}
+	return games
+}
+
+// GetGameOf returns the game a player with the given name is in.
+// A bool is returned indicating if the player was in any game.
+func (manager *Manager) GetGameOf(name string) (*Game, bool) {
+	for _, game := range manager.GetGames() {
+		if game.HasPlayer(name) {
+			return game, true
+		}
+	}
+	return nil, false
+}
+
+// Join makes a player join the game with the given name.
+// AlreadyInGame gets returned if the player was already in a game.
+func (manager *Manager) Join(session *net.MinecraftSession, gameName string) error {
+	if _, ok := manager.GetGameOf(session.GetName()); ok {
+		return AlreadyInGame
+	}
+	var game, err = manager.GetGame(gameName)
+	if err != nil {
+		return err
+	}
+	return game.Join(session)
+}
+
+// Leave removes a player with the given name from the game it is in,
+// after which the leave function gets called.
+func (manager *Manager) Leave(name string) {
+	if game, ok := manager.GetGameOf(name); ok {
+		var session, inGame = game.GetPlayers()[name]
+		game.Leave(name)
+		if inGame {
+			manager.LeaveFunction(session)
+		}
+	}
+}
+
+// Tick ticks the countdowns of all games of a manager without scheduler.
+// Internal. Not to be used by plugins.
+func (manager *Manager) Tick() {
+	for _, game := range manager.GetGames() {
+		game.Tick()
+	}
+}
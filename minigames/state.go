@@ -0,0 +1,39 @@
+package minigames
+
+// State is the state a game is currently in.
+// Games move from waiting to starting, from starting
+// to running and from running to ending, after which
+// they get reset to waiting for the next round.
+type State byte
+
+const (
+	// StateWaiting is the state of a game that is
+	// waiting for enough players to join.
+	StateWaiting State = iota
+	// StateStarting is the state of a game that has
+	// enough players and is counting down to start.
+	StateStarting
+	// StateRunning is the state of a game that is
+	// currently being played.
+	StateRunning
+	// StateEnding is the state of a game that has
+	// finished and is counting down to reset.
+	StateEnding
+)
+
+// String returns a readable representation of a state.
+// It implements fmt.Stringer, and returns a string as such:
+// Running
+func (state State) String() string {
+	switch state {
+	case StateWaiting:
+		return "Waiting"
+	case StateStarting:
+		return "Starting"
+	case StateRunning:
+		return "Running"
+	case StateEnding:
+		return "Ending"
+	}
+	return "Unknown"
+}
@@ -0,0 +1,108 @@
+package minigames
+
+import (
+	"sync"
+
+	"github.com/irmine/gomine/net"
+)
+
+// Team is a group of players within a game.
+// Every team has a name, a color used to prefix
+// the name and a maximum amount of players.
+type Team struct {
+	mutex      sync.RWMutex
+	name       string
+	color      string
+	maxPlayers int
+	players    map[string]*net.MinecraftSession
+}
+
+// NewTeam returns a new team with the given name, color and max players.
+// A max players of 0 or lower means the team has no limit.
+func NewTeam(name string, color string, maxPlayers int) *Team {
+	return &Team{name: name, color: color, maxPlayers: maxPlayers, players: make(map[string]*net.MinecraftSession)}
+}
+
+// GetName returns the name of the team.
+func (team *Team) GetName() string {
+	return team.name
+}
+
+// GetColor returns the color code of the team.
+func (team *Team) GetColor() string {
+	return team.color
+}
+
+// GetColoredName returns the name of the team prefixed with its color.
+func (team *Team) GetColoredName() string {
+	return team.color + team.name
+}
+
+// GetMaxPlayers returns the maximum amount of players of the team.
+func (team *Team) GetMaxPlayers() int {
+	return team.maxPlayers
+}
+
+// GetPlayers returns a name => session map of all players in the team.
+func (team *Team) GetPlayers() map[string]*net.MinecraftSession {
+	team.mutex.RLock()
+	defer team.mutex.RUnlock()
+	var players = make(map[string]*net.MinecraftSession, len(team.players))
+	for name, session := range team.players {
+		players[name] = session
+	}
+	return players
+}
+
+// GetPlayerCount returns the amount of players in the team.
+func (team *Team) GetPlayerCount() int {
+	team.mutex.RLock()
+	defer team.mutex.RUnlock()
+	return len(team.players)
+}
+
+// IsFull checks if the team has reached its maximum amount of players.
+func (team *Team) IsFull() bool {
+	return team.maxPlayers > 0 && team.GetPlayerCount() >= team.maxPlayers
+}
+
+// HasPlayer checks if the team has a player with the given name.
+func (team *Team) HasPlayer(name string) bool {
+	team.mutex.RLock()
+	defer team.mutex.RUnlock()
+	var _, ok = team.players[name]
+	return ok
+}
+
+// AddPlayer adds a player to the team.
+// Returns false if the team was already full.
+func (team *Team) AddPlayer(session *net.MinecraftSession) bool {
+	if team.IsFull() {
+		return false
+	}
+	team.mutex.Lock()
+	team.players[session.GetName()] = session
+	team.mutex.Unlock()
+	return true
+}
+
+// RemovePlayer removes a player with the given name from the team.
+func (team *Team) RemovePlayer(name string) {
+	team.mutex.Lock()
+	delete(team.players, name)
+	team.mutex.Unlock()
+}
+
+// Clear removes all players from the team.
+func (team *Team) Clear() {
+	team.mutex.Lock()
+	team.players = make(map[string]*net.MinecraftSession)
+	team.mutex.Unlock()
+}
+
+// BroadcastMessage broadcasts a message to all players in the team.
+func (team *Team) BroadcastMessage(message ...interface{}) {
+	for _, session := range team.GetPlayers() {
+		session.SendMessage(message...)
+	}
+}
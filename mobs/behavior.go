@@ -0,0 +1,77 @@
+package mobs
+
+import (
+	"math"
+	"math/rand"
+
+	"github.com/golang/geo/r3"
+)
+
+// Behavior is a behavior of a mob, which gets ticked every server tick.
+// Behaviors are the AI of mobs: they move and rotate the mob they belong to.
+type Behavior interface {
+	// Tick ticks the behavior of the given mob.
+	Tick(mob *Mob)
+}
+
+// Wander is a behavior making mobs walk to random positions around the position
+// they were at when first ticked, pausing for a while after every walk.
+// Mobs wander horizontally, and do not check for blocks in their way.
+type Wander struct {
+	radius  float64
+	speed   float64
+	home    r3.Vector
+	hasHome bool
+	target  r3.Vector
+	walking bool
+	pause   int
+}
+
+// NewWander returns a new wander behavior.
+// Mobs walk to positions up to radius blocks away from home, moving speed blocks per tick.
+func NewWander(radius float64, speed float64) *Wander {
+	return &Wander{radius: radius, speed: speed, pause: rand.Intn(100)}
+}
+
+// Tick moves the mob towards its target, or picks a new target once the pause is over.
+func (wander *Wander) Tick(mob *Mob) {
+	if !wander.hasHome {
+		wander.home, wander.hasHome = mob.Position, true
+	}
+	if !wander.walking {
+		if wander.pause > 0 {
+			wander.pause--
+			return
+		}
+		var angle = rand.Float64() * 2 * math.Pi
+		var distance = rand.Float64() * wander.radius
+		wander.target = wander.home.Add(r3.Vector{X: math.Cos(angle) * distance, Z: math.Sin(angle) * distance})
+		wander.walking = true
+	}
+	var delta = wander.target.Sub(mob.Position)
+	if delta.Norm() <= wander.speed {
+		mob.MoveTo(wander.target)
+		wander.walking = false
+		wander.pause = 60 + rand.Intn(140)
+		return
+	}
+	mob.MoveTo(mob.Position.Add(delta.Normalize().Mul(wander.speed)))
+}
+
+// LookAtPlayer is a behavior making mobs look at the nearest player within a distance.
+type LookAtPlayer struct {
+	distance float64
+}
+
+// NewLookAtPlayer returns a new look at player behavior,
+// looking at players within the given distance.
+func NewLookAtPlayer(distance float64) *LookAtPlayer {
+	return &LookAtPlayer{distance}
+}
+
+// Tick makes the mob look at the eyes of the nearest player.
+func (look *LookAtPlayer) Tick(mob *Mob) {
+	if session, ok := mob.GetNearestPlayer(look.distance); ok {
+		mob.LookAt(session.GetPlayer().Position)
+	}
+}
@@ -0,0 +1,205 @@
+package mobs
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/golang/geo/r3"
+	"github.com/irmine/gomine/net"
+	"github.com/irmine/worlds"
+)
+
+// despawnInterval is the interval in ticks at which mobs far away from players get despawned.
+const despawnInterval = 20
+
+// CapReached gets returned when spawning a mob in a dimension that has reached the mob cap.
+var CapReached = errors.New("mob cap reached")
+
+// Manager manages all spawned mobs.
+// Mobs get spawned to every player in the same dimension,
+// and their behaviors get ticked every server tick.
+type Manager struct {
+	// Registry is the registry used to look up mob types when spawning mobs.
+	Registry *Registry
+	// AIFunction gets called every tick to check if mobs in the dimension may run their behaviors.
+	// Mobs without AI still move by their motion, such as knockback. Mobs run their AI in all dimensions by default.
+	AIFunction func(dimension *worlds.Dimension) bool
+	// Cap is the maximum amount of mobs that are not persistent in a single dimension.
+	// Mobs can not be spawned by SpawnEntity once the cap is reached. A cap of 0 disables the cap.
+	Cap int
+	// DespawnDistance is the distance in blocks despawnable mobs may be away from all players before they despawn.
+	// A despawn distance of 0 disables despawning.
+	DespawnDistance float64
+
+	mutex          sync.RWMutex
+	sessionManager *net.SessionManager
+	mobs           map[uint64]*Mob
+	ticks          int
+}
+
+// NewManager returns a new mob manager, using the default registry.
+// The manager has a cap of 200 mobs per dimension and despawns mobs 128 blocks away from players by default.
+func NewManager(sessionManager *net.SessionManager) *Manager {
+	return &Manager{Registry: DefaultRegistry, AIFunction: func(*worlds.Dimension) bool { return true }, Cap: 200, DespawnDistance: 128, sessionManager: sessionManager, mobs: make(map[uint64]*Mob)}
+}
+
+// SpawnEntity spawns a new mob with the given identifier, for example "minecraft:zombie",
+// at the given position in the dimension. UnknownType gets returned if the identifier was not registered,
+// and CapReached if the dimension has reached the mob cap.
+func (manager *Manager) SpawnEntity(dimension *worlds.Dimension, identifier string, position r3.Vector) (*Mob, error) {
+	var t, err = manager.Registry.Get(identifier)
+	if err != nil {
+		return nil, err
+	}
+	if !manager.CanSpawn(dimension) {
+		return nil, CapReached
+	}
+	var mob = t.New()
+	manager.Spawn(dimension, mob, position)
+	return mob, nil
+}
+
+// Spawn spawns the mob at the given position in the dimension,
+// and sends it to all players in the dimension.
+func (manager *Manager) Spawn(dimension *worlds.Dimension, mob *Mob, position r3.Vector) {
+	dimension.AddEntity(mob.Entity, position)
+	mob.Position = position
+
+	for _, session := range manager.sessionManager.GetSessions() {
+		if session.GetPlayer().GetDimension() == dimension && !session.IsHidden(net.CategoryMobs) {
+			mob.AddViewer(session)
+			session.SendAddEntity(mob)
+		}
+	}
+
+	manager.mutex.Lock()
+	manager.mobs[mob.GetRuntimeId()] = mob
+	manager.mutex.Unlock()
+}
+
+// CanSpawn checks if the dimension has not yet reached the mob cap.
+// Persistent mobs do not count towards the cap.
+func (manager *Manager) CanSpawn(dimension *worlds.Dimension) bool {
+	if manager.Cap <= 0 {
+		return true
+	}
+	var count = 0
+	manager.eachMob(func(mob *Mob) bool {
+		if mob.GetDimension() == dimension && !mob.IsPersistent() {
+			count++
+		}
+		return count < manager.Cap
+	})
+	return count < manager.Cap
+}
+
+// RemoveEntity despawns the mob for all its viewers and closes it.
+func (manager *Manager) RemoveEntity(mob *Mob) {
+	manager.mutex.Lock()
+	delete(manager.mobs, mob.GetRuntimeId())
+	manager.mutex.Unlock()
+
+	for _, viewer := range mob.GetViewers() {
+		if session, ok := viewer.(*net.MinecraftSession); ok {
+			session.SendRemoveEntity(mob.GetUniqueId())
+		}
+	}
+	mob.Close()
+}
+
+// GetMob returns a spawned mob by its runtime ID.
+// A bool is returned indicating if the mob was found.
+func (manager *Manager) GetMob(runtimeId uint64) (*Mob, bool) {
+	manager.mutex.RLock()
+	defer manager.mutex.RUnlock()
+	var mob, ok = manager.mobs[runtimeId]
+	return mob, ok
+}
+
+// GetMobs returns a runtime ID => mob map of all spawned mobs.
+func (manager *Manager) GetMobs() map[uint64]*Mob {
+	manager.mutex.RLock()
+	defer manager.mutex.RUnlock()
+	var mobs = make(map[uint64]*Mob, len(manager.mobs))
+	for runtimeId, mob := range manager.mobs {
+		mobs[runtimeId] = mob
+	}
+	return mobs
+}
+
+// Join spawns all mobs in the dimension of the session to the session,
+// unless the session hides mobs.
+func (manager *Manager) Join(session *net.MinecraftSession) {
+	if session.IsHidden(net.CategoryMobs) {
+		return
+	}
+	for _, mob := range manager.GetMobs() {
+		if mob.GetDimension() == session.GetPlayer().GetDimension() {
+			mob.AddViewer(session)
+			session.SendAddEntity(mob)
+		}
+	}
+}
+
+// UpdateVisibility spawns or despawns all mobs to the session, depending on if the session hides mobs.
+// UpdateVisibility should be called every time the mobs category gets hidden or shown to the session.
+func (manager *Manager) UpdateVisibility(session *net.MinecraftSession) {
+	var hidden = session.IsHidden(net.CategoryMobs)
+	for _, mob := range manager.GetMobs() {
+		var visible = !hidden && mob.GetDimension() == session.GetPlayer().GetDimension()
+		if viewing := session.IsViewing(mob.Entity); visible && !viewing {
+			mob.AddViewer(session)
+			session.SendAddEntity(mob)
+		} else if !visible && viewing {
+			mob.RemoveViewer(session)
+			session.SendRemoveEntity(mob.GetUniqueId())
+		}
+	}
+}
+
+// Leave removes the session as viewer of all mobs.
+func (manager *Manager) Leave(session *net.MinecraftSession) {
+	for _, mob := range manager.GetMobs() {
+		mob.RemoveViewer(session)
+	}
+}
+
+// Tick ticks the behaviors of all mobs in dimensions where the AI function allows AI,
+// and moves all mobs by their motion. Mobs that can despawn are despawned every second
+// if no player is within the despawn distance.
+// Internal. Not to be used by plugins.
+func (manager *Manager) Tick() {
+	manager.ticks++
+	if manager.ticks%despawnInterval == 0 && manager.DespawnDistance > 0 {
+		manager.despawn()
+	}
+	var allowed = make(map[*worlds.Dimension]bool)
+	for _, mob := range manager.GetMobs() {
+		var dimension = mob.GetDimension()
+		if _, ok := allowed[dimension]; !ok {
+			allowed[dimension] = dimension != nil && manager.AIFunction(dimension)
+		}
+		mob.tick(allowed[dimension])
+	}
+}
+
+// despawn removes all mobs that can despawn and have no player within the despawn distance.
+func (manager *Manager) despawn() {
+	var sessions = manager.sessionManager.GetSessions()
+	for _, mob := range manager.GetMobs() {
+		if !mob.CanDespawn() {
+			continue
+		}
+		var near = false
+		for _, session := range sessions {
+			var player = session.GetPlayer()
+			if player != nil && player.GetDimension() == mob.GetDimension() && player.Position.Sub(mob.Position).Norm() <= manager.DespawnDistance {
+				near = true
+				break
+			}
+		}
+		if !near {
+			manager.RemoveEntity(mob)
+		}
+	}
+}
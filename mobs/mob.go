@@ -0,0 +1,172 @@
+package mobs
+
+import (
+	"math"
+
+	"github.com/golang/geo/r3"
+	"github.com/irmine/gomine/net"
+	"github.com/irmine/gomine/net/packets/data"
+	"github.com/irmine/gomine/players"
+	"github.com/irmine/worlds/entities"
+)
+
+// eyeHeight is the height of the eyes of mobs above their feet.
+const eyeHeight = 1.62
+
+const (
+	// gravity is the speed in blocks per tick mobs fall faster every tick.
+	gravity = 0.08
+	// drag is the factor the vertical motion of mobs gets multiplied with every tick.
+	drag = 0.98
+	// friction is the factor the horizontal motion of mobs gets multiplied with every tick.
+	friction = 0.91
+)
+
+// Mob is a spawned entity driven by behaviors.
+// Every tick the behaviors of the mob get ticked,
+// after which the movement of the mob is sent to its viewers.
+type Mob struct {
+	*entities.Entity
+	mobType     *Type
+	behaviors   []Behavior
+	motion      r3.Vector
+	groundY     float64
+	movement    *players.MovementTracker
+	persistence Persistence
+	nameTag     string
+	tags        map[string]bool
+}
+
+// GetType returns the type of the mob.
+func (mob *Mob) GetType() *Type {
+	return mob.mobType
+}
+
+// GetBehaviors returns all behaviors of the mob.
+func (mob *Mob) GetBehaviors() []Behavior {
+	return mob.behaviors
+}
+
+// AddBehavior adds a behavior to the mob.
+// Behaviors get ticked in the order they were added.
+func (mob *Mob) AddBehavior(behavior Behavior) {
+	mob.behaviors = append(mob.behaviors, behavior)
+}
+
+// ClearBehaviors removes all behaviors of the mob,
+// leaving the mob standing still.
+func (mob *Mob) ClearBehaviors() {
+	mob.behaviors = nil
+}
+
+// SetFlag sets a metadata flag of the mob, such as data.EntityDataOnFire,
+// and sends the updated metadata to all viewers.
+func (mob *Mob) SetFlag(flag uint32, value bool) {
+	mob.SetEntityProperty(flag, value)
+	mob.BroadcastUpdatedEntityData()
+}
+
+// MoveTo moves the mob to the given position, facing the direction it moves in.
+// The movement gets sent to the viewers of the mob at the end of the tick.
+func (mob *Mob) MoveTo(position r3.Vector) {
+	var delta = position.Sub(mob.Position)
+	if delta.X != 0 || delta.Z != 0 {
+		mob.Rotation.Yaw = yaw(delta)
+		mob.Rotation.HeadYaw = mob.Rotation.Yaw
+	}
+	mob.Position = position
+}
+
+// Teleport teleports the mob to the given position.
+// Viewers see the mob teleport at the end of the tick, instead of moving it there.
+func (mob *Mob) Teleport(position r3.Vector) {
+	mob.Position = position
+	mob.movement.Teleport()
+}
+
+// GetMotion returns the velocity of the mob in blocks per tick.
+func (mob *Mob) GetMotion() r3.Vector {
+	return mob.motion
+}
+
+// SetMotion sets the velocity of the mob in blocks per tick and sends it to all viewers.
+// The motion gets applied every tick, slowed down by friction and gravity,
+// until the mob lands at the height it was at when the motion was set.
+// Mobs do not collide with blocks while moving.
+func (mob *Mob) SetMotion(motion r3.Vector) {
+	if mob.motion == (r3.Vector{}) {
+		mob.groundY = mob.Position.Y
+	}
+	mob.motion = motion
+	for _, viewer := range mob.GetViewers() {
+		if session, ok := viewer.(*net.MinecraftSession); ok {
+			session.SendSetEntityMotion(mob.GetRuntimeId(), motion)
+		}
+	}
+}
+
+// LookAt makes the head of the mob look at the given position.
+func (mob *Mob) LookAt(position r3.Vector) {
+	var delta = position.Sub(mob.Position.Add(r3.Vector{Y: eyeHeight}))
+	mob.Rotation.HeadYaw = yaw(delta)
+	mob.Rotation.Pitch = -math.Atan2(delta.Y, math.Hypot(delta.X, delta.Z)) * 180 / math.Pi
+}
+
+// GetNearestPlayer returns the nearest player viewing the mob within the given distance.
+// A bool is returned indicating if any player was found.
+func (mob *Mob) GetNearestPlayer(maxDistance float64) (*net.MinecraftSession, bool) {
+	var nearest *net.MinecraftSession
+	for _, viewer := range mob.GetViewers() {
+		var session, ok = viewer.(*net.MinecraftSession)
+		if !ok {
+			continue
+		}
+		var distance = session.GetPlayer().Position.Sub(mob.Position).Norm()
+		if distance <= maxDistance {
+			nearest, maxDistance = session, distance
+		}
+	}
+	return nearest, nearest != nil
+}
+
+// tick ticks all behaviors of the mob if it may run its AI, applies its motion
+// and sends its movement to all viewers as selected by its movement tracker.
+// Behaviors are not ticked while the mob is moved by its motion.
+func (mob *Mob) tick(ai bool) {
+	if mob.motion != (r3.Vector{}) {
+		mob.applyMotion()
+	} else if ai {
+		for _, behavior := range mob.behaviors {
+			behavior.Tick(mob)
+		}
+	}
+	// The movement tracker sends the movement every update interval,
+	// even if the mob only moved in the ticks in between.
+	var mode, ok = mob.movement.Update(mob.Position, mob.Rotation)
+	if !ok {
+		return
+	}
+	for _, viewer := range mob.GetViewers() {
+		if session, ok := viewer.(*net.MinecraftSession); ok {
+			session.SendMoveEntity(mob.GetRuntimeId(), mob.Position, mob.Rotation, 0, mode == data.MoveTeleport)
+		}
+	}
+}
+
+// applyMotion moves the mob by its motion and slows the motion down.
+// The motion stops once the mob lands.
+func (mob *Mob) applyMotion() {
+	mob.Position = mob.Position.Add(mob.motion)
+	mob.motion.X *= friction
+	mob.motion.Z *= friction
+	mob.motion.Y = (mob.motion.Y - gravity) * drag
+	if mob.Position.Y <= mob.groundY && mob.motion.Y <= 0 {
+		mob.Position.Y = mob.groundY
+		mob.motion = r3.Vector{}
+	}
+}
+
+// yaw returns the yaw in degrees of something facing in the given direction.
+func yaw(direction r3.Vector) float64 {
+	return math.Atan2(-direction.X, direction.Z) * 180 / math.Pi
+}
@@ -0,0 +1,109 @@
+package mobs
+
+import (
+	"math"
+	"testing"
+
+	"github.com/golang/geo/r3"
+)
+
+func TestRegistry(t *testing.T) {
+	zombie, err := DefaultRegistry.Get("minecraft:zombie")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if byId, err := DefaultRegistry.GetByNetworkId(zombie.GetNetworkId()); err != nil || byId != zombie {
+		t.Error("mob type could not be found by its network ID:", err)
+	}
+	if _, err := DefaultRegistry.Get("minecraft:dragon"); err != UnknownType {
+		t.Error("expected unknown mob type, got:", err)
+	}
+}
+
+func TestWander(t *testing.T) {
+	var mob = DefaultRegistry.identifiers["minecraft:pig"].New()
+	mob.ClearBehaviors()
+	mob.AddBehavior(NewWander(4, 0.5))
+	var moved = false
+	for i := 0; i < 400; i++ {
+		mob.tick(true)
+		if mob.Position.Norm() > 4.0001 || mob.Position.Y != 0 {
+			t.Fatal("mob wandered outside of its radius:", mob.Position)
+		}
+		moved = moved || mob.Position.Norm() > 0
+	}
+	if !moved {
+		t.Error("mob did not wander")
+	}
+
+	var still = DefaultRegistry.identifiers["minecraft:pig"].New()
+	still.ClearBehaviors()
+	still.AddBehavior(NewWander(4, 0.5))
+	for i := 0; i < 400; i++ {
+		still.tick(false)
+	}
+	if still.Position != (r3.Vector{}) {
+		t.Error("mob without AI wandered:", still.Position)
+	}
+}
+
+func TestLookAt(t *testing.T) {
+	var mob = DefaultRegistry.identifiers["minecraft:cow"].New()
+	mob.LookAt(r3.Vector{X: 0, Y: eyeHeight, Z: 5})
+	if math.Abs(mob.Rotation.HeadYaw) > 0.0001 || math.Abs(mob.Rotation.Pitch) > 0.0001 {
+		t.Error("mob should look straight ahead, got:", mob.Rotation)
+	}
+	mob.LookAt(r3.Vector{X: 5, Y: eyeHeight, Z: 0})
+	if math.Abs(mob.Rotation.HeadYaw+90) > 0.0001 {
+		t.Error("mob should look to the east, got:", mob.Rotation)
+	}
+}
+
+func TestMotion(t *testing.T) {
+	var mob = DefaultRegistry.identifiers["minecraft:pig"].New()
+	mob.Position = r3.Vector{Y: 4}
+	mob.SetMotion(r3.Vector{X: 0.4, Y: 0.4})
+	mob.tick(true)
+	if mob.Position.X != 0.4 || math.Abs(mob.Position.Y-4.4) > 0.0001 {
+		t.Error("motion was not applied:", mob.Position)
+	}
+	for i := 0; i < 100 && mob.GetMotion() != (r3.Vector{}); i++ {
+		mob.tick(true)
+	}
+	if mob.GetMotion() != (r3.Vector{}) || mob.Position.Y != 4 || mob.Position.X <= 0.4 {
+		t.Error("mob did not land at the height it was knocked back from:", mob.Position, mob.GetMotion())
+	}
+}
+
+func TestPersistence(t *testing.T) {
+	var mob = DefaultRegistry.identifiers["minecraft:zombie"].New()
+	if mob.IsPersistent() || !mob.CanDespawn() {
+		t.Fatal("expected new mob to be despawnable")
+	}
+	mob.nameTag = "Bob"
+	if !mob.IsPersistent() || mob.CanDespawn() {
+		t.Error("expected name tagged mob to be persistent")
+	}
+	mob.SetPersistence(Despawnable)
+	if mob.IsPersistent() || !mob.CanDespawn() {
+		t.Error("expected name tagged mob without protection to be despawnable")
+	}
+	mob.SetPersistence(Persistent | Despawnable)
+	if !mob.IsPersistent() || mob.CanDespawn() {
+		t.Error("expected persistent mob to not despawn")
+	}
+}
+
+func TestQuery(t *testing.T) {
+	var zombie = DefaultRegistry.identifiers["minecraft:zombie"].New()
+	var cow = DefaultRegistry.identifiers["minecraft:cow"].New()
+	cow.SetPersistence(Persistent)
+
+	var query = Query{Identifier: "minecraft:zombie"}
+	if !query.Matches(zombie) || query.Matches(cow) {
+		t.Error("expected query to only match zombies")
+	}
+	if (Query{}).Matches(cow) || !(Query{IncludePersistent: true}).Matches(cow) {
+		t.Error("expected persistent mobs to only match queries including them")
+	}
+}
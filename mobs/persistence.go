@@ -0,0 +1,73 @@
+package mobs
+
+import (
+	"github.com/irmine/gomine/net"
+	"github.com/irmine/worlds/entities/data"
+)
+
+// entityDataNameTag is the key of the name tag in entity data.
+const entityDataNameTag = 4
+
+// Persistence is a set of flags of a mob, specifying if it may be despawned and if it counts towards the mob cap.
+type Persistence uint8
+
+const (
+	// Persistent mobs are never despawned, do not count towards the mob cap and are only butchered if asked to.
+	Persistent Persistence = 1 << iota
+	// Despawnable mobs are despawned once no player is within the despawn distance of the manager.
+	Despawnable
+	// NameTagProtected mobs are treated as persistent while they have a name tag.
+	NameTagProtected
+)
+
+// DefaultPersistence is the persistence of newly created mobs.
+const DefaultPersistence = Despawnable | NameTagProtected
+
+// GetPersistence returns the persistence flags of the mob.
+func (mob *Mob) GetPersistence() Persistence {
+	return mob.persistence
+}
+
+// SetPersistence sets the persistence flags of the mob.
+func (mob *Mob) SetPersistence(persistence Persistence) {
+	mob.persistence = persistence
+}
+
+// IsPersistent checks if the mob is persistent,
+// either because it has the Persistent flag, or because it is protected by its name tag.
+func (mob *Mob) IsPersistent() bool {
+	return mob.persistence&Persistent != 0 || (mob.persistence&NameTagProtected != 0 && mob.nameTag != "")
+}
+
+// CanDespawn checks if the mob is despawnable and not persistent.
+func (mob *Mob) CanDespawn() bool {
+	return mob.persistence&Despawnable != 0 && !mob.IsPersistent()
+}
+
+// GetNameTag returns the name tag shown above the mob, which is empty if the mob has none.
+func (mob *Mob) GetNameTag() string {
+	return mob.nameTag
+}
+
+// SetNameTag sets the name tag shown above the mob and sends it to all viewers.
+// An empty name tag removes the name tag of the mob.
+func (mob *Mob) SetNameTag(nameTag string) {
+	mob.nameTag = nameTag
+	var entityData = mob.GetEntityData()
+	for _, viewer := range mob.GetViewers() {
+		if session, ok := viewer.(*net.MinecraftSession); ok {
+			session.SendSetEntityData(mob.GetRuntimeId(), entityData)
+		}
+	}
+}
+
+// GetEntityData returns the entity data of the mob,
+// with the name tag of the mob set if it has one.
+func (mob *Mob) GetEntityData() map[uint32][]interface{} {
+	var entityData = make(map[uint32][]interface{})
+	for key, value := range mob.Entity.GetEntityData() {
+		entityData[key] = value
+	}
+	entityData[entityDataNameTag] = []interface{}{uint32(data.EntityDataString), mob.nameTag}
+	return entityData
+}
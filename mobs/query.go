@@ -0,0 +1,143 @@
+package mobs
+
+import (
+	"sort"
+
+	"github.com/golang/geo/r3"
+	"github.com/irmine/gomine/ecs"
+	"github.com/irmine/worlds"
+)
+
+// Query is a filter selecting spawned mobs by their world, position and type.
+// The zero value of a field matches all mobs.
+type Query struct {
+	// Level is the name of the level the mobs are in.
+	Level string
+	// Dimension is the dimension the mobs are in.
+	Dimension *worlds.Dimension
+	// Center is the center of the radius the mobs are in, in the dimension of the query.
+	Center r3.Vector
+	// Radius is the maximum distance between the mobs and the center.
+	// Mobs are only matched by radius if the query has a dimension.
+	Radius float64
+	// Identifier is the identifier of the type of the mobs, for example "minecraft:zombie".
+	Identifier string
+	// IncludePersistent specifies if persistent mobs are matched.
+	IncludePersistent bool
+}
+
+// Matches checks if the mob matches the query.
+func (query Query) Matches(mob *Mob) bool {
+	if !query.IncludePersistent && mob.IsPersistent() {
+		return false
+	}
+	if query.Identifier != "" && mob.GetType().GetIdentifier() != query.Identifier {
+		return false
+	}
+	var dimension = mob.GetDimension()
+	if query.Level != "" && (dimension == nil || dimension.GetLevel().GetName() != query.Level) {
+		return false
+	}
+	if query.Dimension != nil {
+		if dimension != query.Dimension {
+			return false
+		}
+		if query.Radius > 0 && mob.Position.Sub(query.Center).Norm() > query.Radius {
+			return false
+		}
+	}
+	return true
+}
+
+// Query returns all spawned mobs matching the query.
+func (manager *Manager) Query(query Query) []*Mob {
+	var mobs []*Mob
+	manager.eachMob(func(mob *Mob) bool {
+		if query.Matches(mob) {
+			mobs = append(mobs, mob)
+		}
+		return true
+	})
+	return mobs
+}
+
+// EachEntity calls the function for every spawned mob, until the function returns false.
+// The function must not spawn or remove mobs.
+func (manager *Manager) EachEntity(function func(entity ecs.Entity) bool) {
+	manager.eachMob(func(mob *Mob) bool {
+		return function(mob)
+	})
+}
+
+// eachMob calls the function for every spawned mob while holding the read lock of the manager,
+// until the function returns false.
+func (manager *Manager) eachMob(function func(mob *Mob) bool) {
+	manager.mutex.RLock()
+	defer manager.mutex.RUnlock()
+	for _, mob := range manager.mobs {
+		if !function(mob) {
+			return
+		}
+	}
+}
+
+// GetMobsWithin returns all spawned mobs in the dimension within the radius around the center.
+func (manager *Manager) GetMobsWithin(dimension *worlds.Dimension, center r3.Vector, radius float64) []*Mob {
+	return manager.Query(Query{Dimension: dimension, Center: center, Radius: radius, IncludePersistent: true})
+}
+
+// Butcher removes all spawned mobs matching the query, returning the amount of mobs removed.
+func (manager *Manager) Butcher(query Query) int {
+	var mobs = manager.Query(query)
+	for _, mob := range mobs {
+		manager.RemoveEntity(mob)
+	}
+	return len(mobs)
+}
+
+// GetIdentifier returns the identifier of the type of the mob, such as minecraft:zombie.
+func (mob *Mob) GetIdentifier() string {
+	return mob.mobType.GetIdentifier()
+}
+
+// HasComponent checks if the mob has the component with the given name.
+// Mobs have AI if they have behaviors, and may have a name tag or be persistent.
+func (mob *Mob) HasComponent(component string) bool {
+	switch component {
+	case ecs.ComponentAI:
+		return len(mob.behaviors) != 0
+	case ecs.ComponentNameTag:
+		return mob.nameTag != ""
+	case ecs.ComponentPersistent:
+		return mob.IsPersistent()
+	}
+	return false
+}
+
+// AddTag adds the tag to the mob, so that it can be selected by queries using ecs.Tag.
+func (mob *Mob) AddTag(tag string) {
+	if mob.tags == nil {
+		mob.tags = make(map[string]bool)
+	}
+	mob.tags[tag] = true
+}
+
+// RemoveTag removes the tag from the mob.
+func (mob *Mob) RemoveTag(tag string) {
+	delete(mob.tags, tag)
+}
+
+// HasTag checks if the mob has the tag.
+func (mob *Mob) HasTag(tag string) bool {
+	return mob.tags[tag]
+}
+
+// GetTags returns the tags of the mob, sorted alphabetically.
+func (mob *Mob) GetTags() []string {
+	var tags = make([]string, 0, len(mob.tags))
+	for tag := range mob.tags {
+		tags = append(tags, tag)
+	}
+	sort.Strings(tags)
+	return tags
+}
@@ -0,0 +1,138 @@
+package mobs
+
+import (
+	"errors"
+	"sort"
+	"sync"
+
+	"github.com/irmine/gomine/players"
+	"github.com/irmine/worlds/entities"
+)
+
+// UnknownType gets returned when a mob type
+// with a given identifier could not be found.
+var UnknownType = errors.New("unknown mob type")
+
+// Network IDs of the default mob types.
+const (
+	chickenId  = 10
+	cowId      = 11
+	pigId      = 12
+	sheepId    = 13
+	zombieId   = 32
+	skeletonId = 34
+)
+
+// Type is a type of mob that may be spawned.
+// Every type has an identifier, such as "minecraft:zombie",
+// the network ID of the entity and the behaviors new mobs of the type get.
+type Type struct {
+	identifier string
+	networkId  uint32
+	create     func() *entities.Entity
+	behaviors  func() []Behavior
+}
+
+// NewType returns a new mob type with the given identifier and network ID.
+// The create function gets called to create the entity of every new mob,
+// and the behaviors function to create the behaviors of every new mob.
+func NewType(identifier string, networkId uint32, create func() *entities.Entity, behaviors func() []Behavior) *Type {
+	return &Type{identifier, networkId, create, behaviors}
+}
+
+// GetIdentifier returns the identifier of the mob type.
+func (t *Type) GetIdentifier() string {
+	return t.identifier
+}
+
+// GetNetworkId returns the network ID of the entity of the mob type.
+func (t *Type) GetNetworkId() uint32 {
+	return t.networkId
+}
+
+// New returns a new mob of the type.
+// The mob is not yet spawned in any dimension.
+func (t *Type) New() *Mob {
+	return &Mob{Entity: t.create(), mobType: t, behaviors: t.behaviors(), movement: players.NewMovementTracker(players.DefaultMovementSettings), persistence: DefaultPersistence}
+}
+
+// Registry is a registry of all mob types that may be spawned.
+type Registry struct {
+	mutex       sync.RWMutex
+	identifiers map[string]*Type
+	networkIds  map[uint32]*Type
+}
+
+// DefaultRegistry is the default mob registry,
+// with all default mob types registered.
+var DefaultRegistry = NewRegistry()
+
+func init() {
+	DefaultRegistry.RegisterDefaults()
+}
+
+// NewRegistry returns a new mob registry.
+// New registries do not have default mob types registered.
+func NewRegistry() *Registry {
+	return &Registry{identifiers: make(map[string]*Type), networkIds: make(map[uint32]*Type)}
+}
+
+// Register registers a new mob type.
+// Types with the same identifier or network ID get overwritten.
+func (registry *Registry) Register(t *Type) {
+	registry.mutex.Lock()
+	registry.identifiers[t.identifier] = t
+	registry.networkIds[t.networkId] = t
+	registry.mutex.Unlock()
+}
+
+// Get returns a mob type by its identifier, for example "minecraft:zombie".
+func (registry *Registry) Get(identifier string) (*Type, error) {
+	registry.mutex.RLock()
+	defer registry.mutex.RUnlock()
+	var t, ok = registry.identifiers[identifier]
+	if !ok {
+		return nil, UnknownType
+	}
+	return t, nil
+}
+
+// GetByNetworkId returns a mob type by the network ID of its entity.
+func (registry *Registry) GetByNetworkId(networkId uint32) (*Type, error) {
+	registry.mutex.RLock()
+	defer registry.mutex.RUnlock()
+	var t, ok = registry.networkIds[networkId]
+	if !ok {
+		return nil, UnknownType
+	}
+	return t, nil
+}
+
+// GetIdentifiers returns the sorted identifiers of all registered mob types.
+func (registry *Registry) GetIdentifiers() []string {
+	registry.mutex.RLock()
+	var identifiers = make([]string, 0, len(registry.identifiers))
+	for identifier := range registry.identifiers {
+		identifiers = append(identifiers, identifier)
+	}
+	registry.mutex.RUnlock()
+	sort.Strings(identifiers)
+	return identifiers
+}
+
+// RegisterDefaults registers all default mob types.
+// All mobs wander around and look at nearby players, hostile mobs from further away.
+func (registry *Registry) RegisterDefaults() {
+	var passive = func() []Behavior {
+		return []Behavior{NewWander(8, 0.1), NewLookAtPlayer(6)}
+	}
+	var hostile = func() []Behavior {
+		return []Behavior{NewWander(8, 0.15), NewLookAtPlayer(16)}
+	}
+	registry.Register(NewType("minecraft:chicken", chickenId, func() *entities.Entity { return entities.New(chickenId) }, passive))
+	registry.Register(NewType("minecraft:cow", cowId, func() *entities.Entity { return entities.New(cowId) }, passive))
+	registry.Register(NewType("minecraft:pig", pigId, func() *entities.Entity { return entities.New(pigId) }, passive))
+	registry.Register(NewType("minecraft:sheep", sheepId, func() *entities.Entity { return entities.New(sheepId) }, passive))
+	registry.Register(NewType("minecraft:zombie", zombieId, func() *entities.Entity { return entities.New(zombieId) }, hostile))
+	registry.Register(NewType("minecraft:skeleton", skeletonId, func() *entities.Entity { return entities.New(skeletonId) }, hostile))
+}
@@ -0,0 +1,26 @@
+package motd
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Pong is the data of the unconnected pong, which is sent to clients
+// pinging the server to display it in the server list.
+type Pong struct {
+	Motd           string
+	Protocol       int32
+	Version        string
+	OnlinePlayers  int
+	MaximumPlayers int
+	ServerId       int64
+	SubMotd        string
+	GameMode       string
+}
+
+// String returns the pong data as sent in the unconnected pong.
+// Semicolons in the MOTD and sub MOTD are escaped, as they separate the fields.
+func (pong *Pong) String() string {
+	var escape = strings.NewReplacer(";", "\\;")
+	return fmt.Sprint("MCPE;", escape.Replace(pong.Motd), ";", pong.Protocol, ";", pong.Version, ";", pong.OnlinePlayers, ";", pong.MaximumPlayers, ";", pong.ServerId, ";", escape.Replace(pong.SubMotd), ";", pong.GameMode, ";")
+}
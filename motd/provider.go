@@ -0,0 +1,88 @@
+package motd
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Provider provides the MOTD and player counts shown in the server list.
+// The MOTD rotates through a list of messages on an interval,
+// and the player counts can be faked or hidden.
+// The following placeholders are replaced in messages:
+// {online}: The amount of players online.
+// {max}: The displayed maximum amount of players.
+type Provider struct {
+	// Messages are the messages rotated through.
+	// The default MOTD is used if there are no messages.
+	Messages []string
+	// Interval is the duration every message is shown for.
+	Interval time.Duration
+	// MaxPlayers is the maximum amount of players displayed.
+	// A max players of 0 or lower displays the real maximum.
+	MaxPlayers int
+	// MaxPlayersOffset makes the maximum amount of players displayed
+	// the amount of players online plus the offset, so that
+	// the server always appears to have free slots.
+	// An offset of 0 or lower disables this.
+	MaxPlayersOffset int
+	// HidePlayerCount hides the player counts, displaying 0 for both.
+	HidePlayerCount bool
+	// SubMotd is the second line shown in the server list on some platforms,
+	// in which the same placeholders are replaced as in messages.
+	SubMotd string
+
+	defaultMotd string
+}
+
+// NewProvider returns a new provider using the default MOTD if no messages are set.
+func NewProvider(defaultMotd string) *Provider {
+	return &Provider{Interval: time.Second * 10, defaultMotd: defaultMotd}
+}
+
+// GetMessage returns the message that should be shown at the given time.
+func (provider *Provider) GetMessage(now time.Time) string {
+	if len(provider.Messages) == 0 {
+		return provider.defaultMotd
+	}
+	if provider.Interval <= 0 {
+		return provider.Messages[0]
+	}
+	var index = (now.UnixNano() / int64(provider.Interval)) % int64(len(provider.Messages))
+	return provider.Messages[index]
+}
+
+// GetPlayerCounts returns the online and maximum player counts that should be displayed,
+// using the real amount of players online and the real maximum.
+func (provider *Provider) GetPlayerCounts(online int, max int) (int, int) {
+	if provider.HidePlayerCount {
+		return 0, 0
+	}
+	if provider.MaxPlayersOffset > 0 {
+		return online, online + provider.MaxPlayersOffset
+	}
+	if provider.MaxPlayers > 0 {
+		return online, provider.MaxPlayers
+	}
+	return online, max
+}
+
+// GetMotd returns the MOTD that should be shown at the given time,
+// with the placeholders replaced using the real player counts.
+func (provider *Provider) GetMotd(now time.Time, online int, max int) string {
+	return provider.format(provider.GetMessage(now), online, max)
+}
+
+// GetSubMotd returns the sub MOTD with the placeholders replaced using the real player counts.
+func (provider *Provider) GetSubMotd(online int, max int) string {
+	return provider.format(provider.SubMotd, online, max)
+}
+
+// format replaces the placeholders in a message using the real player counts.
+func (provider *Provider) format(message string, online int, max int) string {
+	var displayedOnline, displayedMax = provider.GetPlayerCounts(online, max)
+	return strings.NewReplacer(
+		"{online}", strconv.Itoa(displayedOnline),
+		"{max}", strconv.Itoa(displayedMax),
+	).Replace(message)
+}
@@ -0,0 +1,46 @@
+package motd
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRotation(t *testing.T) {
+	var provider = NewProvider("Default")
+	if provider.GetMessage(time.Now()) != "Default" {
+		t.Error("default MOTD was not used without messages")
+	}
+	provider.Messages = []string{"First", "Second {online}/{max}"}
+	provider.Interval = time.Minute
+
+	var start = time.Unix(0, 0)
+	if provider.GetMessage(start) != "First" || provider.GetMessage(start.Add(time.Minute)) != "Second {online}/{max}" || provider.GetMessage(start.Add(time.Minute*2)) != "First" {
+		t.Error("messages did not rotate every interval")
+	}
+	provider.MaxPlayersOffset = 1
+	if motd := provider.GetMotd(start.Add(time.Minute), 5, 20); motd != "Second 5/6" {
+		t.Error("unexpected MOTD:", motd)
+	}
+}
+
+func TestPlayerCounts(t *testing.T) {
+	var provider = NewProvider("")
+	if online, max := provider.GetPlayerCounts(5, 20); online != 5 || max != 20 {
+		t.Error("real player counts were not used:", online, max)
+	}
+	provider.MaxPlayers = 100
+	if _, max := provider.GetPlayerCounts(5, 20); max != 100 {
+		t.Error("custom max players were not used:", max)
+	}
+	provider.HidePlayerCount = true
+	if online, max := provider.GetPlayerCounts(5, 20); online != 0 || max != 0 {
+		t.Error("player counts were not hidden:", online, max)
+	}
+}
+
+func TestPong(t *testing.T) {
+	var pong = Pong{Motd: "A;B", Protocol: 332, Version: "1.9.0", OnlinePlayers: 1, MaximumPlayers: 20, ServerId: 5, SubMotd: "GoMine", GameMode: "Creative"}
+	if data := pong.String(); data != "MCPE;A\\;B;332;1.9.0;1;20;5;GoMine;Creative;" {
+		t.Error("unexpected pong data:", data)
+	}
+}
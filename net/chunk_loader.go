@@ -0,0 +1,160 @@
+package net
+
+import (
+	"sync"
+
+	"github.com/irmine/worlds"
+	"github.com/irmine/worlds/chunks"
+)
+
+// chunkPosition is the position of a chunk in a dimension.
+type chunkPosition struct {
+	x, z int32
+}
+
+// ChunkLoader streams the chunks around a player to its session.
+// Missing chunks are requested in a spiral around the chunk the player is in,
+// so that the closest terrain is sent first, and chunks outside the radius are unloaded.
+type ChunkLoader struct {
+	// LoadFunction gets called once a chunk in the radius has been loaded.
+	LoadFunction func(chunk *chunks.Chunk)
+	// UnloadFunction gets called for every loaded chunk that left the radius.
+	UnloadFunction func(chunk *chunks.Chunk)
+	// PublisherUpdateFunction gets called every time the center or radius of the loader changes.
+	PublisherUpdateFunction func()
+
+	mutex     sync.Mutex
+	dimension *worlds.Dimension
+	center    chunkPosition
+	radius    int32
+	loaded    map[chunkPosition]*chunks.Chunk
+	requested map[chunkPosition]bool
+	queue     []chunkPosition
+}
+
+// NewChunkLoader returns a new chunk loader without a dimension.
+// Chunks are only loaded once the loader has been moved to a dimension.
+func NewChunkLoader() *ChunkLoader {
+	return &ChunkLoader{
+		LoadFunction:            func(*chunks.Chunk) {},
+		UnloadFunction:          func(*chunks.Chunk) {},
+		PublisherUpdateFunction: func() {},
+		loaded:                  make(map[chunkPosition]*chunks.Chunk),
+		requested:               make(map[chunkPosition]bool),
+	}
+}
+
+// Move moves the center of the loader to the given chunk in the dimension, with the given radius in chunks.
+// Chunks outside the radius are unloaded immediately, and missing chunks in the radius get queued
+// in a spiral around the center, to be loaded by Request. Moving to another dimension unloads all chunks.
+func (loader *ChunkLoader) Move(dimension *worlds.Dimension, chunkX, chunkZ, radius int32) {
+	var center = chunkPosition{chunkX, chunkZ}
+	loader.mutex.Lock()
+	if loader.dimension == dimension && loader.center == center && loader.radius == radius {
+		loader.mutex.Unlock()
+		return
+	}
+	var unloaded []*chunks.Chunk
+	for position, chunk := range loader.loaded {
+		if dimension != loader.dimension || !inRadius(center, position, radius) {
+			unloaded = append(unloaded, chunk)
+			delete(loader.loaded, position)
+		}
+	}
+	if dimension != loader.dimension {
+		loader.requested = make(map[chunkPosition]bool)
+	}
+	loader.dimension, loader.center, loader.radius = dimension, center, radius
+
+	loader.queue = loader.queue[:0]
+	for _, offset := range spiral(radius) {
+		var position = chunkPosition{chunkX + offset.x, chunkZ + offset.z}
+		if _, ok := loader.loaded[position]; !ok && !loader.requested[position] {
+			loader.queue = append(loader.queue, position)
+		}
+	}
+	loader.mutex.Unlock()
+
+	for _, chunk := range unloaded {
+		loader.UnloadFunction(chunk)
+	}
+	loader.PublisherUpdateFunction()
+}
+
+// Request loads at most the given amount of queued chunks.
+// Chunks that have left the radius by the time they are loaded are ignored.
+func (loader *ChunkLoader) Request(maximum int) {
+	loader.mutex.Lock()
+	var dimension = loader.dimension
+	if dimension == nil {
+		loader.mutex.Unlock()
+		return
+	}
+	if maximum > len(loader.queue) {
+		maximum = len(loader.queue)
+	}
+	var positions = append([]chunkPosition(nil), loader.queue[:maximum]...)
+	loader.queue = loader.queue[maximum:]
+	for _, position := range positions {
+		loader.requested[position] = true
+	}
+	loader.mutex.Unlock()
+
+	for _, position := range positions {
+		var position = position
+		dimension.LoadChunk(position.x, position.z, func(chunk *chunks.Chunk) {
+			loader.mutex.Lock()
+			if loader.dimension != dimension || !loader.requested[position] {
+				loader.mutex.Unlock()
+				return
+			}
+			delete(loader.requested, position)
+			var current = inRadius(loader.center, position, loader.radius)
+			if current {
+				loader.loaded[position] = chunk
+			}
+			loader.mutex.Unlock()
+			if current {
+				loader.LoadFunction(chunk)
+			}
+		})
+	}
+}
+
+// GetLoadedChunks returns all chunks currently loaded by the loader.
+func (loader *ChunkLoader) GetLoadedChunks() []*chunks.Chunk {
+	loader.mutex.Lock()
+	defer loader.mutex.Unlock()
+	var loaded = make([]*chunks.Chunk, 0, len(loader.loaded))
+	for _, chunk := range loader.loaded {
+		loaded = append(loaded, chunk)
+	}
+	return loaded
+}
+
+// inRadius checks if the chunk at the position is within the circular radius around the center.
+func inRadius(center, position chunkPosition, radius int32) bool {
+	var x, z = position.x - center.x, position.z - center.z
+	return x*x+z*z <= radius*radius
+}
+
+// spiral returns the offsets of all chunks within the circular radius,
+// ordered in a spiral starting at the center.
+func spiral(radius int32) []chunkPosition {
+	var offsets = []chunkPosition{{}}
+	var x, z int32
+	var dx, dz int32 = 1, 0
+	for length := int32(1); length <= 2*radius+1; length++ {
+		// Every length is walked twice, turning after every walk.
+		for i := 0; i < 2; i++ {
+			for step := int32(0); step < length; step++ {
+				x, z = x+dx, z+dz
+				if x*x+z*z <= radius*radius {
+					offsets = append(offsets, chunkPosition{x, z})
+				}
+			}
+			dx, dz = -dz, dx
+		}
+	}
+	return offsets
+}
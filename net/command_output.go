@@ -0,0 +1,52 @@
+package net
+
+import (
+	"sync"
+
+	"github.com/irmine/gomine/net/packets/types"
+)
+
+// commandOutput collects the messages sent to a session while it executes a command request,
+// so that they can be sent as the output of the command rather than as chat messages.
+type commandOutput struct {
+	mutex     sync.Mutex
+	capturing bool
+	messages  []types.CommandOutputMessage
+}
+
+// add adds the message to the output if it is being captured.
+// A bool is returned indicating if the message was added.
+func (output *commandOutput) add(message string) bool {
+	output.mutex.Lock()
+	defer output.mutex.Unlock()
+	if !output.capturing {
+		return false
+	}
+	output.messages = append(output.messages, types.CommandOutputMessage{MessageId: message})
+	return true
+}
+
+// CaptureCommandOutput starts capturing all messages sent to the session as command output,
+// until the output gets sent with SendCommandOutput.
+// Commands requested by the client should be executed while capturing their output.
+func (session *MinecraftSession) CaptureCommandOutput() {
+	session.output.mutex.Lock()
+	session.output.capturing, session.output.messages = true, nil
+	session.output.mutex.Unlock()
+}
+
+// SendCommandOutput stops capturing command output, and sends the captured messages
+// as the output of the command with the origin. The success count is 1 if the command succeeded,
+// so that the client shows the output as successful or failed.
+func (session *MinecraftSession) SendCommandOutput(origin types.CommandOrigin, success bool) {
+	session.output.mutex.Lock()
+	var messages = session.output.messages
+	session.output.capturing, session.output.messages = false, nil
+	session.output.mutex.Unlock()
+
+	var successCount uint32
+	if success {
+		successCount = 1
+	}
+	session.SendCommandOutputPacket(origin, successCount, messages)
+}
@@ -0,0 +1,128 @@
+package net
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/irmine/gomine/net/packets/data"
+	"github.com/irmine/gomine/net/packets/types"
+	"github.com/irmine/gomine/text"
+)
+
+// sidebarObjective is the name of the scoreboard objective
+// used to display the sidebar of a session.
+const sidebarObjective = "gomine:sidebar"
+
+// display keeps track of the scoreboard and boss bar
+// currently shown to a session, so only changes get sent.
+type display struct {
+	mutex           sync.Mutex
+	scoreboardShown bool
+	scoreboardTitle string
+	scoreboardLines []string
+	bossBarShown    bool
+	bossBarTitle    string
+}
+
+// SetScoreboard shows a sidebar scoreboard with the given title and lines to the session.
+// Lines are shown from top to bottom. Calling SetScoreboard again with the same title
+// only sends the lines that changed, so it can be used to update the scoreboard every tick.
+func (session *MinecraftSession) SetScoreboard(title string, lines []string) {
+	session.display.mutex.Lock()
+	defer session.display.mutex.Unlock()
+
+	var old = session.display.scoreboardLines
+	if !session.display.scoreboardShown || session.display.scoreboardTitle != title {
+		if session.display.scoreboardShown {
+			session.SendRemoveObjective(sidebarObjective)
+		}
+		session.SendSetDisplayObjective(data.ScoreboardSlotSidebar, sidebarObjective, title, "dummy", data.ScoreboardSortAscending)
+		old = nil
+	}
+
+	var removed, changed []types.ScoreboardEntry
+	for i := 0; i < len(old) || i < len(lines); i++ {
+		if i < len(old) && i < len(lines) && old[i] == lines[i] {
+			continue
+		}
+		if i < len(old) {
+			removed = append(removed, newScoreboardEntry(i, old[i]))
+		}
+		if i < len(lines) {
+			changed = append(changed, newScoreboardEntry(i, lines[i]))
+		}
+	}
+	if len(removed) != 0 {
+		session.SendSetScore(data.ScoreboardActionRemove, removed)
+	}
+	if len(changed) != 0 {
+		session.SendSetScore(data.ScoreboardActionChange, changed)
+	}
+
+	session.display.scoreboardShown = true
+	session.display.scoreboardTitle = title
+	session.display.scoreboardLines = append([]string{}, lines...)
+}
+
+// RemoveScoreboard removes the sidebar scoreboard of the session.
+func (session *MinecraftSession) RemoveScoreboard() {
+	session.display.mutex.Lock()
+	defer session.display.mutex.Unlock()
+	if !session.display.scoreboardShown {
+		return
+	}
+	session.SendRemoveObjective(sidebarObjective)
+	session.display.scoreboardShown = false
+	session.display.scoreboardTitle = ""
+	session.display.scoreboardLines = nil
+}
+
+// newScoreboardEntry returns a new sidebar entry for the line at the given index.
+// Every line gets a unique invisible suffix, so equal lines are all displayed.
+func newScoreboardEntry(index int, line string) types.ScoreboardEntry {
+	return types.ScoreboardEntry{
+		ScoreboardId:  int64(index + 1),
+		ObjectiveName: sidebarObjective,
+		Score:         int32(index),
+		EntryType:     data.ScoreboardEntryFakePlayer,
+		CustomName:    line + strings.Repeat(text.Reset, index),
+	}
+}
+
+// SendBossBar shows a boss bar with the given text and percentage to the session.
+// The percentage ranges from 0 to 1, and is clamped if it exceeds this range.
+// Calling SendBossBar again updates the boss bar that is already shown.
+func (session *MinecraftSession) SendBossBar(title string, percentage float32) {
+	if percentage < 0 {
+		percentage = 0
+	} else if percentage > 1 {
+		percentage = 1
+	}
+	session.display.mutex.Lock()
+	defer session.display.mutex.Unlock()
+
+	var uniqueId = session.player.GetUniqueId()
+	if !session.display.bossBarShown {
+		session.SendBossEvent(uniqueId, data.BossEventShow, uniqueId, title, percentage)
+	} else {
+		if session.display.bossBarTitle != title {
+			session.SendBossEvent(uniqueId, data.BossEventTitle, uniqueId, title, percentage)
+		}
+		session.SendBossEvent(uniqueId, data.BossEventHealthPercentage, uniqueId, title, percentage)
+	}
+	session.display.bossBarShown = true
+	session.display.bossBarTitle = title
+}
+
+// RemoveBossBar removes the boss bar of the session.
+func (session *MinecraftSession) RemoveBossBar() {
+	session.display.mutex.Lock()
+	defer session.display.mutex.Unlock()
+	if !session.display.bossBarShown {
+		return
+	}
+	var uniqueId = session.player.GetUniqueId()
+	session.SendBossEvent(uniqueId, data.BossEventHide, uniqueId, "", 0)
+	session.display.bossBarShown = false
+	session.display.bossBarTitle = ""
+}
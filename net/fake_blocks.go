@@ -0,0 +1,123 @@
+package net
+
+import (
+	"sync"
+
+	"github.com/irmine/gomine/net/packets/bedrock"
+	"github.com/irmine/worlds"
+	"github.com/irmine/worlds/blocks"
+	"github.com/irmine/worlds/chunks"
+)
+
+// fakeBlock is a block only sent to a single session, without changing the world.
+type fakeBlock struct {
+	dimension *worlds.Dimension
+	runtimeId uint32
+}
+
+// fakeBlocks keeps track of the fake blocks sent to a session.
+type fakeBlocks struct {
+	mutex  sync.RWMutex
+	blocks map[blocks.Position]fakeBlock
+}
+
+// SetFakeBlock shows the block with the given runtime ID at the position in the current dimension
+// of the player to the session only, without changing the world.
+// Fake blocks are sent again every time their chunk gets resent, and block updates
+// of the world at their position are not sent to the session, until the fake block gets removed.
+func (session *MinecraftSession) SetFakeBlock(position blocks.Position, runtimeId uint32) {
+	var dimension = session.GetPlayer().GetDimension()
+	if dimension == nil {
+		return
+	}
+	session.fakeBlocks.mutex.Lock()
+	session.fakeBlocks.blocks[position] = fakeBlock{dimension, runtimeId}
+	session.fakeBlocks.mutex.Unlock()
+	session.SendPacket(session.GetProtocol().GetUpdateBlock(position, runtimeId, bedrock.DataLayerNormal))
+}
+
+// GetFakeBlock returns the runtime ID of the fake block at the position in the current dimension of the player.
+// A bool is returned indicating if a fake block was set at the position.
+func (session *MinecraftSession) GetFakeBlock(position blocks.Position) (uint32, bool) {
+	if session.player == nil {
+		return 0, false
+	}
+	session.fakeBlocks.mutex.RLock()
+	defer session.fakeBlocks.mutex.RUnlock()
+	var block, ok = session.fakeBlocks.blocks[position]
+	if !ok || block.dimension != session.GetPlayer().GetDimension() {
+		return 0, false
+	}
+	return block.runtimeId, true
+}
+
+// IsFakeBlock checks if a fake block is set at the position in the current dimension of the player.
+func (session *MinecraftSession) IsFakeBlock(position blocks.Position) bool {
+	var _, ok = session.GetFakeBlock(position)
+	return ok
+}
+
+// RemoveFakeBlock removes the fake block at the position,
+// and restores the block of the world by resending its chunk.
+func (session *MinecraftSession) RemoveFakeBlock(position blocks.Position) {
+	session.RemoveFakeBlocks([]blocks.Position{position})
+}
+
+// RemoveFakeBlocks removes the fake blocks at all positions,
+// and restores the blocks of the world by resending every chunk containing them once.
+func (session *MinecraftSession) RemoveFakeBlocks(positions []blocks.Position) {
+	var affected = make(map[chunkPosition]bool)
+	session.fakeBlocks.mutex.Lock()
+	for _, position := range positions {
+		if _, ok := session.fakeBlocks.blocks[position]; ok {
+			delete(session.fakeBlocks.blocks, position)
+			affected[chunkPosition{position.X >> 4, position.Z >> 4}] = true
+		}
+	}
+	session.fakeBlocks.mutex.Unlock()
+	session.resendChunks(affected)
+}
+
+// ClearFakeBlocks removes all fake blocks of the session,
+// and restores the blocks of the world in the current dimension of the player.
+func (session *MinecraftSession) ClearFakeBlocks() {
+	var dimension = session.GetPlayer().GetDimension()
+	var affected = make(map[chunkPosition]bool)
+	session.fakeBlocks.mutex.Lock()
+	for position, block := range session.fakeBlocks.blocks {
+		if block.dimension == dimension {
+			affected[chunkPosition{position.X >> 4, position.Z >> 4}] = true
+		}
+	}
+	session.fakeBlocks.blocks = make(map[blocks.Position]fakeBlock)
+	session.fakeBlocks.mutex.Unlock()
+	session.resendChunks(affected)
+}
+
+// sendFakeBlocks sends all fake blocks in the chunk, after the chunk was sent to the session.
+func (session *MinecraftSession) sendFakeBlocks(chunk *chunks.Chunk) {
+	if session.player == nil {
+		return
+	}
+	var dimension = session.GetPlayer().GetDimension()
+	session.fakeBlocks.mutex.RLock()
+	defer session.fakeBlocks.mutex.RUnlock()
+	for position, block := range session.fakeBlocks.blocks {
+		if block.dimension == dimension && position.X>>4 == chunk.X && position.Z>>4 == chunk.Z {
+			session.SendPacket(session.GetProtocol().GetUpdateBlock(position, block.runtimeId, bedrock.DataLayerNormal))
+		}
+	}
+}
+
+// resendChunks resends the chunks at the positions if the session has them loaded.
+// Other chunks get sent by the chunk loader once they are loaded.
+func (session *MinecraftSession) resendChunks(positions map[chunkPosition]bool) {
+	if len(positions) == 0 || session.chunkLoader == nil {
+		return
+	}
+	for _, chunk := range session.chunkLoader.GetLoadedChunks() {
+		if positions[chunkPosition{chunk.X, chunk.Z}] {
+			session.SendFullChunkData(chunk)
+		}
+	}
+}
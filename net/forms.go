@@ -0,0 +1,87 @@
+package net
+
+import (
+	"encoding/json"
+	"sync"
+
+	"github.com/irmine/gomine/forms"
+	"github.com/irmine/gomine/text"
+)
+
+// formQueue keeps track of all forms sent
+// to a session that await a response.
+type formQueue struct {
+	mutex   sync.Mutex
+	lastId  uint32
+	pending map[uint32]pendingForm
+}
+
+// pendingForm is a form awaiting a response,
+// with the callback to call once it arrives.
+type pendingForm struct {
+	form     forms.Form
+	callback func(response *forms.Response)
+}
+
+// newFormQueue returns a new empty form queue.
+func newFormQueue() *formQueue {
+	return &formQueue{pending: make(map[uint32]pendingForm)}
+}
+
+// SendForm sends a form to the session.
+// The callback gets called once the player responds to, or closes the form.
+func (session *MinecraftSession) SendForm(form forms.Form, callback func(response *forms.Response)) error {
+	var formData, err = json.Marshal(form)
+	if err != nil {
+		return err
+	}
+	var queue = session.formQueue
+	queue.mutex.Lock()
+	queue.lastId++
+	var id = queue.lastId
+	queue.pending[id] = pendingForm{form, callback}
+	queue.mutex.Unlock()
+
+	session.SendModalFormRequest(id, string(formData))
+	return nil
+}
+
+// SendMenu sends the first page of a paginated menu to the session.
+// Clicking a button to go to another page sends that page of the menu.
+// The callback gets called once the player clicks an item or closes the menu,
+// with the button of the response being the index of the clicked item in the menu.
+func (session *MinecraftSession) SendMenu(menu *forms.PaginatedMenu, callback func(response *forms.Response)) error {
+	return session.sendMenuPage(menu, 0, callback)
+}
+
+// sendMenuPage sends the page with the given index of the paginated menu to the session.
+func (session *MinecraftSession) sendMenuPage(menu *forms.PaginatedMenu, page int, callback func(response *forms.Response)) error {
+	return session.SendForm(menu.GetPage(page), func(response *forms.Response) {
+		if response.Navigated {
+			text.DefaultLogger.LogError(session.sendMenuPage(menu, response.Page, callback))
+			return
+		}
+		callback(response)
+	})
+}
+
+// HandleFormResponse handles the response of the player to the form with the given ID.
+// Returns false if no form with the ID was awaiting a response.
+// Internal. Not to be used by plugins.
+func (session *MinecraftSession) HandleFormResponse(formId uint32, formData string) bool {
+	var queue = session.formQueue
+	queue.mutex.Lock()
+	var pending, ok = queue.pending[formId]
+	delete(queue.pending, formId)
+	queue.mutex.Unlock()
+	if !ok {
+		return false
+	}
+	var response, err = pending.form.ParseResponse(formData)
+	if err != nil {
+		text.DefaultLogger.Debug(session.GetName(), "sent an invalid response to form", pending.form.GetTitle()+":", formData)
+		return true
+	}
+	pending.callback(response)
+	return true
+}
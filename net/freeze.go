@@ -0,0 +1,21 @@
+package net
+
+import (
+	"github.com/irmine/gomine/events"
+)
+
+const FreezeEventName events.Name = "PlayerFreezeEvent"
+
+// FreezeEvent gets called when a player gets frozen or unfrozen.
+// Cancelling the event keeps the player in its current state.
+type FreezeEvent struct {
+	events.Cancellable
+	Session *MinecraftSession
+	// Frozen is true if the player gets frozen, and false if it gets unfrozen.
+	Frozen bool
+}
+
+// GetName returns the name of the event.
+func (event *FreezeEvent) GetName() events.Name {
+	return FreezeEventName
+}
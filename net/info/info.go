@@ -4,6 +4,22 @@ const (
 	LatestProtocol           = 332
 	LatestGameVersion        = "v1.9.0"
 	LatestGameVersionNetwork = "1.9.0"
+
+	// GCMEncryptionProtocol is the first protocol encrypting batches using AES-GCM rather than AES-CFB8.
+	GCMEncryptionProtocol = 428
+
+	// MovementAuthorityProtocol is the first protocol sending the movement authority in the start game packet.
+	MovementAuthorityProtocol = 388
+	// PersonaSkinProtocol is the first protocol rendering persona skins made in the character creator.
+	PersonaSkinProtocol = 388
+	// ItemTableProtocol is the first protocol sending experiments, the movement authority as enum
+	// and the item table in the start game packet.
+	ItemTableProtocol = 419
+	// ItemStackRequestProtocol is the first protocol using item stack requests for inventory changes,
+	// rather than inventory transactions.
+	ItemStackRequestProtocol = 407
+	// ItemStackRequestGameVersion is the network game version of the item stack request protocol.
+	ItemStackRequestGameVersion = "1.16.0"
 )
 
 type PacketIdList map[PacketName]int
@@ -115,5 +131,12 @@ const (
 	ServerSettingsResponsePacket      PacketName = "ServerSettingsResponsePacket"
 	ShowProfilePacket                 PacketName = "ShowProfilePacket"
 	SetDefaultGameTypePacket          PacketName = "SetDefaultGameTypePacket"
+	RemoveObjectivePacket             PacketName = "RemoveObjectivePacket"
+	SetDisplayObjectivePacket         PacketName = "SetDisplayObjectivePacket"
+	SetScorePacket                    PacketName = "SetScorePacket"
+	UpdateSoftEnumPacket              PacketName = "UpdateSoftEnumPacket"
+	SpawnParticleEffectPacket         PacketName = "SpawnParticleEffectPacket"
 	NetworkChunkPublisherUpdatePacket PacketName = "NetworkChunkPublisherUpdatePacket"
+	ItemStackRequestPacket            PacketName = "ItemStackRequestPacket"
+	ItemStackResponsePacket           PacketName = "ItemStackResponsePacket"
 )
@@ -106,5 +106,17 @@ var PacketIds = PacketIdList{
 	ServerSettingsResponsePacket:      0x67,
 	ShowProfilePacket:                 0x68,
 	SetDefaultGameTypePacket:          0x69,
+	RemoveObjectivePacket:             0x6a,
+	SetDisplayObjectivePacket:         0x6b,
+	SetScorePacket:                    0x6c,
+	UpdateSoftEnumPacket:              0x72,
+	SpawnParticleEffectPacket:         0x76,
 	NetworkChunkPublisherUpdatePacket: 0x79,
 }
+
+// ItemStackPacketIds are the IDs of the item stack request packets,
+// which protocols from ItemStackRequestProtocol on have besides the packets of the latest protocol.
+var ItemStackPacketIds = PacketIdList{
+	ItemStackRequestPacket:  0x93,
+	ItemStackResponsePacket: 0x94,
+}
@@ -0,0 +1,21 @@
+package net
+
+import (
+	"github.com/irmine/gomine/events"
+)
+
+const InventorySortEventName events.Name = "PlayerInventorySortEvent"
+
+// InventorySortEvent gets called before the inventory of a player gets sorted.
+// Cancelling the event leaves the inventory unchanged.
+type InventorySortEvent struct {
+	events.Cancellable
+	Session *MinecraftSession
+	// IncludeHotbar is true if the hotbar slots get sorted along with the rest of the inventory.
+	IncludeHotbar bool
+}
+
+// GetName returns the name of the event.
+func (event *InventorySortEvent) GetName() events.Name {
+	return InventorySortEventName
+}
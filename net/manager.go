@@ -1,123 +1,176 @@
 package net
 
 import (
-	"fmt"
 	"github.com/google/uuid"
+	"github.com/irmine/gomine/ecs"
 	"github.com/irmine/goraklib/server"
 	"sync"
+	"sync/atomic"
 )
 
-// SessionManager is a struct managing Minecraft sessions.
-// A session manager holds multiple maps used to find sessions by given keys.
-type SessionManager struct {
-	mutex      sync.RWMutex
+// sessionMaps holds the maps used to find sessions by given keys.
+// Session maps are never modified once stored in a session manager.
+type sessionMaps struct {
 	nameMap    map[string]*MinecraftSession
 	uuidMap    map[uuid.UUID]*MinecraftSession
 	xuidMap    map[string]*MinecraftSession
-	sessionMap map[string]*MinecraftSession
+	sessionMap map[*server.Session]*MinecraftSession
+}
+
+// SessionManager is a struct managing Minecraft sessions.
+// A session manager holds multiple maps used to find sessions by given keys.
+// Sessions are looked up for every packet received, so lookups read an immutable snapshot of the maps without locking.
+// Adding or removing a session stores a modified copy of the maps.
+type SessionManager struct {
+	mutex sync.Mutex
+	maps  atomic.Value
 }
 
 // NewSessionManager returns a new session manager.
 func NewSessionManager() *SessionManager {
-	return &SessionManager{sync.RWMutex{}, make(map[string]*MinecraftSession), make(map[uuid.UUID]*MinecraftSession), make(map[string]*MinecraftSession), make(map[string]*MinecraftSession)}
+	var manager = &SessionManager{}
+	manager.maps.Store(&sessionMaps{make(map[string]*MinecraftSession), make(map[uuid.UUID]*MinecraftSession), make(map[string]*MinecraftSession), make(map[*server.Session]*MinecraftSession)})
+	return manager
+}
+
+// load returns the current snapshot of the session maps.
+func (manager *SessionManager) load() *sessionMaps {
+	return manager.maps.Load().(*sessionMaps)
+}
+
+// update stores a copy of the session maps, modified by the given function.
+func (manager *SessionManager) update(function func(maps *sessionMaps)) {
+	manager.mutex.Lock()
+	defer manager.mutex.Unlock()
+	var current = manager.load()
+	var maps = &sessionMaps{
+		make(map[string]*MinecraftSession, len(current.nameMap)+1),
+		make(map[uuid.UUID]*MinecraftSession, len(current.uuidMap)+1),
+		make(map[string]*MinecraftSession, len(current.xuidMap)+1),
+		make(map[*server.Session]*MinecraftSession, len(current.sessionMap)+1),
+	}
+	for key, session := range current.nameMap {
+		maps.nameMap[key] = session
+	}
+	for key, session := range current.uuidMap {
+		maps.uuidMap[key] = session
+	}
+	for key, session := range current.xuidMap {
+		maps.xuidMap[key] = session
+	}
+	for key, session := range current.sessionMap {
+		maps.sessionMap[key] = session
+	}
+	function(maps)
+	manager.maps.Store(maps)
 }
 
 // GetSessions returns the name => session map of the manager.
+// The map is a snapshot of the sessions at the time of calling, and must not be modified.
 func (manager *SessionManager) GetSessions() map[string]*MinecraftSession {
-	return manager.nameMap
+	return manager.load().nameMap
 }
 
 // AddMinecraftSession adds the given Minecraft session to the manager.
 func (manager *SessionManager) AddMinecraftSession(session *MinecraftSession) {
-	manager.mutex.Lock()
-	manager.nameMap[session.GetName()] = session
-	manager.uuidMap[session.GetUUID()] = session
-	manager.xuidMap[session.GetXUID()] = session
-	manager.sessionMap[fmt.Sprint(session.GetSession())] = session
-	manager.mutex.Unlock()
+	manager.update(func(maps *sessionMaps) {
+		maps.nameMap[session.GetName()] = session
+		maps.uuidMap[session.GetUUID()] = session
+		maps.xuidMap[session.GetXUID()] = session
+		maps.sessionMap[session.GetSession()] = session
+	})
 }
 
 // RemoveMinecraftSession removes a Minecraft session from the manager.
 func (manager *SessionManager) RemoveMinecraftSession(session *MinecraftSession) {
 	if session != nil {
-		manager.mutex.Lock()
-		delete(manager.nameMap, session.GetPlayer().GetName())
-		delete(manager.uuidMap, session.GetUUID())
-		delete(manager.xuidMap, session.GetXUID())
-		delete(manager.sessionMap, fmt.Sprint(session.GetSession()))
-		manager.mutex.Unlock()
+		manager.update(func(maps *sessionMaps) {
+			delete(maps.nameMap, session.GetPlayer().GetName())
+			delete(maps.uuidMap, session.GetUUID())
+			delete(maps.xuidMap, session.GetXUID())
+			delete(maps.sessionMap, session.GetSession())
+		})
 	}
 }
 
 // GetSessionCount returns the session count of the manager.
 func (manager *SessionManager) GetSessionCount() int {
-	return len(manager.nameMap)
+	return len(manager.load().nameMap)
 }
 
 // HasSession checks if the session manager has a session with the given name.
 func (manager *SessionManager) HasSession(name string) bool {
-	manager.mutex.RLock()
-	var _, ok = manager.nameMap[name]
-	manager.mutex.RUnlock()
+	var _, ok = manager.load().nameMap[name]
 	return ok
 }
 
 // GetSession attempts to retrieve a session by its name.
 // A bool is returned indicating success.
 func (manager *SessionManager) GetSession(name string) (*MinecraftSession, bool) {
-	manager.mutex.RLock()
-	var session, ok = manager.nameMap[name]
-	manager.mutex.RUnlock()
+	var session, ok = manager.load().nameMap[name]
 	return session, ok
 }
 
 // HasSessionWithRakNetSession checks if the session manager has a session with the given RakNet session.
 func (manager *SessionManager) HasSessionWithRakNetSession(rakNetSession *server.Session) bool {
-	manager.mutex.RLock()
-	var _, ok = manager.sessionMap[fmt.Sprint(rakNetSession)]
-	manager.mutex.RUnlock()
+	var _, ok = manager.load().sessionMap[rakNetSession]
 	return ok
 }
 
 // GetSessionByRakNetSession attempts to retrieve a session by its RakNet session.
 // A bool is returned indicating success.
 func (manager *SessionManager) GetSessionByRakNetSession(rakNetSession *server.Session) (*MinecraftSession, bool) {
-	manager.mutex.RLock()
-	var session, ok = manager.sessionMap[fmt.Sprint(rakNetSession)]
-	manager.mutex.RUnlock()
+	var session, ok = manager.load().sessionMap[rakNetSession]
 	return session, ok
 }
 
 // HasSessionWithXUID checks if the session manager has a session with the given XUID.
 func (manager *SessionManager) HasSessionWithXUID(xuid string) bool {
-	manager.mutex.RLock()
-	var _, ok = manager.xuidMap[xuid]
-	manager.mutex.RUnlock()
+	var _, ok = manager.load().xuidMap[xuid]
 	return ok
 }
 
 // GetSessionByXUID attempts to retrieve a session by its XUID.
 // A bool is returned indicating success.
 func (manager *SessionManager) GetSessionByXUID(xuid string) (*MinecraftSession, bool) {
-	manager.mutex.RLock()
-	var session, ok = manager.xuidMap[xuid]
-	manager.mutex.RUnlock()
+	var session, ok = manager.load().xuidMap[xuid]
 	return session, ok
 }
 
 // HasSessionWithUUID checks if the session manager has a session with the given UUID.
 func (manager *SessionManager) HasSessionWithUUID(uuid uuid.UUID) bool {
-	manager.mutex.RLock()
-	var _, ok = manager.uuidMap[uuid]
-	manager.mutex.RUnlock()
+	var _, ok = manager.load().uuidMap[uuid]
 	return ok
 }
 
 // GetSessionByUUID attempts to retrieve a session by its UUID.
 // A bool is returned indicating success.
 func (manager *SessionManager) GetSessionByUUID(uuid uuid.UUID) (*MinecraftSession, bool) {
-	manager.mutex.RLock()
-	var session, ok = manager.uuidMap[uuid]
-	manager.mutex.RUnlock()
+	var session, ok = manager.load().uuidMap[uuid]
 	return session, ok
 }
+
+// EachEntity calls the function with the player of every session that is spawned in a dimension,
+// until the function returns false.
+func (manager *SessionManager) EachEntity(function func(entity ecs.Entity) bool) {
+	for _, session := range manager.GetSessions() {
+		var player = session.GetPlayer()
+		if player == nil || player.GetDimension() == nil {
+			continue
+		}
+		if !function(player) {
+			return
+		}
+	}
+}
+
+// GetSessionByRuntimeId attempts to retrieve a session by the runtime ID of its player.
+// A bool is returned indicating success.
+func (manager *SessionManager) GetSessionByRuntimeId(runtimeId uint64) (*MinecraftSession, bool) {
+	for _, session := range manager.GetSessions() {
+		if session.GetPlayer() != nil && session.GetPlayer().GetRuntimeId() == runtimeId {
+			return session, true
+		}
+	}
+	return nil, false
+}
@@ -3,10 +3,11 @@ package net
 import (
 	"bytes"
 	"compress/zlib"
-	"crypto/cipher"
 	"encoding/hex"
 	"errors"
+	"fmt"
 	"io/ioutil"
+	"sync"
 
 	"github.com/irmine/binutils"
 	"github.com/irmine/gomine/net/packets"
@@ -15,12 +16,24 @@ import (
 
 const McpeFlag = 0xFE
 
+var (
+	// bufferPool pools the buffers batches get compressed into.
+	bufferPool = sync.Pool{New: func() interface{} {
+		return new(bytes.Buffer)
+	}}
+	// writerPools pools zlib writers per compression level,
+	// ranging from zlib.HuffmanOnly to zlib.BestCompression.
+	writerPools [zlib.BestCompression - zlib.HuffmanOnly + 1]sync.Pool
+)
+
 type MinecraftPacketBatch struct {
 	*binutils.Stream
-	raw             []byte
-	packets         []packets.IPacket
-	session         *MinecraftSession
-	needsEncryption bool
+	raw                  []byte
+	packets              []packets.IPacket
+	session              *MinecraftSession
+	needsEncryption      bool
+	compressionLevel     int
+	compressionThreshold int
 }
 
 // NewMinecraftPacketBatch returns a new Minecraft Packet Batch used to decode/encode batches from Encapsulated Packets.
@@ -29,36 +42,47 @@ func NewMinecraftPacketBatch(session *MinecraftSession) *MinecraftPacketBatch {
 	batch.Stream = binutils.NewStream()
 	batch.session = session
 
+	batch.compressionLevel = zlib.DefaultCompression
+
 	if session == nil {
 		batch.needsEncryption = false
 	} else {
 		batch.needsEncryption = session.UsesEncryption()
+		batch.compressionLevel = session.adapter.CompressionLevel
+		batch.compressionThreshold = session.adapter.CompressionThreshold
 	}
 
 	return batch
 }
 
 // Decode decodes the batch and separates packets. This does not decode the packets.
+// Errors decoding the batch are logged.
 func (batch *MinecraftPacketBatch) Decode() {
+	text.DefaultLogger.LogError(batch.decode())
+}
+
+// decode decodes the batch and separates packets, returning an error wrapping InvalidPacket
+// if the batch could not be decoded.
+func (batch *MinecraftPacketBatch) decode() (err error) {
 	defer func() {
-		if err := recover(); err != nil {
-			text.DefaultLogger.Debug(err)
+		if recovered := recover(); recovered != nil {
+			err = fmt.Errorf("%w: malformed batch: %v", InvalidPacket, recovered)
 		}
 	}()
 
 	var mcpeFlag = batch.GetByte()
 	if mcpeFlag != McpeFlag {
-		return
+		return fmt.Errorf("%w: unexpected batch flag %v", InvalidPacket, mcpeFlag)
 	}
 	batch.raw = batch.Buffer[batch.Offset:]
 
 	if batch.needsEncryption {
-		batch.decrypt()
+		if err := batch.decrypt(); err != nil {
+			return fmt.Errorf("%w: decrypt: %w", InvalidPacket, err)
+		}
 	}
-	var err = batch.decompress()
-	if err != nil {
-		text.DefaultLogger.LogError(err)
-		return
+	if err := batch.decompress(); err != nil {
+		return fmt.Errorf("%w: decompress: %w", InvalidPacket, err)
 	}
 
 	batch.ResetStream()
@@ -71,6 +95,7 @@ func (batch *MinecraftPacketBatch) Decode() {
 	}
 
 	batch.fetchPackets(packetData)
+	return nil
 }
 
 // Encode encodes all packets in the batch and zlib encodes them.
@@ -81,13 +106,14 @@ func (batch *MinecraftPacketBatch) Encode() {
 	var stream = binutils.NewStream()
 	batch.putPackets(stream)
 
-	var zlibData = batch.compress(stream)
-	var data = zlibData
+	var buff = batch.compress(stream)
+	var data = buff.Bytes()
 	if batch.needsEncryption {
 		data = batch.encrypt(data)
 	}
 
 	batch.PutBytes(data)
+	bufferPool.Put(buff)
 }
 
 // fetchPackets fetches all packets from the raw packet buffers.
@@ -98,11 +124,12 @@ func (batch *MinecraftPacketBatch) fetchPackets(packetData [][]byte) {
 		}
 		packetId := int(data[0])
 
-		if !batch.session.adapter.packetManager.IsPacketRegistered(packetId) {
-			text.DefaultLogger.Debug("Unknown Minecraft packet with ID:", packetId)
-			continue
+		var packet packets.IPacket
+		if batch.session.GetProtocol().IsPacketRegistered(packetId) {
+			packet = batch.session.GetProtocol().GetPacket(packetId)
+		} else {
+			packet = packets.NewUnknownPacket(packetId)
 		}
-		packet := batch.session.adapter.packetManager.GetPacket(packetId)
 
 		packet.SetBuffer(data)
 		batch.packets = append(batch.packets, packet)
@@ -125,55 +152,67 @@ func (batch *MinecraftPacketBatch) peekProtocol(packetData []byte) int32 {
 	return protocol
 }
 
-// encrypt encrypts the data passed to the function.
+// encrypt encrypts the data passed to the function, appending the send checksum.
 func (batch *MinecraftPacketBatch) encrypt(d []byte) []byte {
-	var data = batch.session.GetEncryptionHandler().Data
-	d = append(d, batch.session.GetEncryptionHandler().ComputeSendChecksum(d)...)
-
-	for i := range d {
-		var cfb = cipher.NewCFBEncrypter(data.EncryptCipher, data.EncryptIV)
-		cfb.XORKeyStream(d[i:i+1], d[i:i+1])
-		data.EncryptIV = append(data.EncryptIV[1:], d[i])
-	}
-
-	return d
+	return batch.session.GetEncryptionHandler().Encrypt(d)
 }
 
-// decrypt decrypts the buffer of the packet.
-func (batch *MinecraftPacketBatch) decrypt() {
-	var data = batch.session.GetEncryptionHandler().Data
-	for i, b := range batch.raw {
-		var cfb = cipher.NewCFBDecrypter(data.DecryptCipher, data.DecryptIV)
-		cfb.XORKeyStream(batch.raw[i:i+1], batch.raw[i:i+1])
-		data.DecryptIV = append(data.DecryptIV[1:], b)
-	}
+// decrypt decrypts the buffer of the packet and verifies its checksum.
+func (batch *MinecraftPacketBatch) decrypt() error {
+	var raw, err = batch.session.GetEncryptionHandler().Decrypt(batch.raw)
+	batch.raw = raw
+	return err
 }
 
 // putPackets puts all packets of the batch inside of the stream.
 func (batch *MinecraftPacketBatch) putPackets(stream *binutils.Stream) {
 	for _, packet := range batch.GetPackets() {
+		if batch.session != nil {
+			if packet = batch.session.GetProtocol().DowngradePacket(packet); packet == nil {
+				continue
+			}
+		}
 		packet.EncodeHeader()
 		packet.Encode()
 		stream.PutLengthPrefixedBytes(packet.GetBuffer())
+		if batch.session != nil {
+			batch.session.tracePacket(TraceSent, packet, 0)
+		}
 	}
 }
 
-// compress zlib compresses the data in the stream and returns it.
-func (batch *MinecraftPacketBatch) compress(stream *binutils.Stream) []byte {
-	var buff = bytes.Buffer{}
-	var writer = zlib.NewWriter(&buff)
+// compress zlib compresses the data in the stream into a pooled buffer and returns it.
+// Data smaller than the compression threshold is stored without compression.
+// The buffer should be put back in the buffer pool once it is no longer used.
+func (batch *MinecraftPacketBatch) compress(stream *binutils.Stream) *bytes.Buffer {
+	var buff = bufferPool.Get().(*bytes.Buffer)
+	buff.Reset()
+
+	var level = batch.compressionLevel
+	if level < zlib.HuffmanOnly || level > zlib.BestCompression {
+		level = zlib.DefaultCompression
+	}
+	if len(stream.Buffer) < batch.compressionThreshold {
+		level = zlib.NoCompression
+	}
+	var pool = &writerPools[level-zlib.HuffmanOnly]
+	var writer, ok = pool.Get().(*zlib.Writer)
+	if ok {
+		writer.Reset(buff)
+	} else {
+		writer, _ = zlib.NewWriterLevel(buff, level)
+	}
 	writer.Write(stream.Buffer)
 	writer.Close()
+	pool.Put(writer)
 
-	return buff.Bytes()
+	return buff
 }
 
 // decompress decompresses the zlib compressed buffer.
 func (batch *MinecraftPacketBatch) decompress() error {
 	var reader = bytes.NewReader(batch.raw)
 	zlibReader, err := zlib.NewReader(reader)
-	text.DefaultLogger.LogError(err)
-
 	if err != nil {
 		text.DefaultLogger.Debug(hex.EncodeToString(batch.raw))
 		return err
@@ -2,9 +2,13 @@ package net
 
 import (
 	"fmt"
+	"github.com/golang/geo/r3"
 	"github.com/google/uuid"
+	"github.com/irmine/gomine/net/info"
 	"github.com/irmine/gomine/net/packets"
+	data2 "github.com/irmine/gomine/net/packets/data"
 	"github.com/irmine/gomine/net/packets/types"
+	protocol2 "github.com/irmine/gomine/net/protocol"
 	"github.com/irmine/gomine/permissions"
 	"github.com/irmine/gomine/players"
 	"github.com/irmine/gomine/text"
@@ -14,8 +18,10 @@ import (
 	"github.com/irmine/worlds"
 	"github.com/irmine/worlds/blocks"
 	"github.com/irmine/worlds/chunks"
+	"github.com/irmine/worlds/entities/data"
 	"math"
 	"strings"
+	"sync"
 )
 
 type MinecraftSession struct {
@@ -30,8 +36,10 @@ type MinecraftSession struct {
 
 	protocolNumber   int32
 	minecraftVersion string
+	protocol         protocol2.Protocol
 
-	language string
+	language       string
+	clientLanguage string
 
 	clientPlatform int32
 
@@ -40,17 +48,29 @@ type MinecraftSession struct {
 	xboxLiveAuthenticated bool
 
 	viewDistance int32
-	chunkLoader  *worlds.Loader
+	chunkLoader  *ChunkLoader
 
 	permissions     map[string]*permissions.Permission
 	permissionGroup *permissions.Group
 
+	formQueue  *formQueue
+	display    *display
+	visibility *visibility
+	fakeBlocks *fakeBlocks
+	output     *commandOutput
+	trace      *packetTrace
+
+	gameMode int32
+
+	queueMutex sync.Mutex
+	queue      []packets.IPacket
+
 	Connected         bool
 }
 
 // NewMinecraftSession returns a new Minecraft session with the given RakNet session.
 func NewMinecraftSession(adapter *NetworkAdapter, session *server.Session) *MinecraftSession {
-	return &MinecraftSession{adapter, session, nil, uuid.New(), "", 0, 0, "", "", 0, utils.NewEncryptionHandler(), false, false, 0, nil, nil, nil, false}
+	return &MinecraftSession{adapter, session, nil, uuid.New(), "", 0, 0, "", nil, "", "", 0, utils.NewEncryptionHandler(), false, false, 0, nil, nil, nil, newFormQueue(), &display{}, &visibility{hidden: make(map[UpdateCategory]bool)}, &fakeBlocks{blocks: make(map[blocks.Position]fakeBlock)}, &commandOutput{}, &packetTrace{}, data2.GameModeCreative, sync.Mutex{}, nil, false}
 }
 
 // SetData sets the basic session data of the Minecraft Session
@@ -64,8 +84,9 @@ func (session *MinecraftSession) SetData(permissionManager *permissions.Manager,
 	session.protocolNumber = data.ProtocolNumber
 	session.minecraftVersion = data.GameVersion
 	session.language = data.Language
+	session.clientLanguage = data.Language
 	session.clientPlatform = int32(data.DeviceOS)
-	session.chunkLoader = worlds.NewLoader(nil, 0, 0)
+	session.chunkLoader = NewChunkLoader()
 	session.chunkLoader.PublisherUpdateFunction = func() {
 		var vector = session.player.Position
 		var position = blocks.NewPosition(int32(vector.X), uint32(vector.Y), int32(vector.Z))
@@ -115,7 +136,8 @@ func (session *MinecraftSession) HasSpawned() bool {
 	return session.GetPlayer().GetDimension() != nil
 }
 
-// SetViewDistance sets the view distance of this player.
+// SetViewDistance sets the view distance of this player in chunks.
+// The chunk loader picks up the new distance on the next tick.
 func (session *MinecraftSession) SetViewDistance(distance int32) {
 	session.viewDistance = distance
 }
@@ -126,7 +148,7 @@ func (session *MinecraftSession) GetViewDistance() int32 {
 }
 
 // GetChunkLoader returns the chunk loader of the session.
-func (session *MinecraftSession) GetChunkLoader() *worlds.Loader {
+func (session *MinecraftSession) GetChunkLoader() *ChunkLoader {
 	return session.chunkLoader
 }
 
@@ -140,6 +162,26 @@ func (session *MinecraftSession) GetProtocolNumber() int32 {
 	return session.protocolNumber
 }
 
+// UsesItemStackRequests checks if the session changes its inventories using item stack requests,
+// rather than inventory transactions.
+func (session *MinecraftSession) UsesItemStackRequests() bool {
+	return session.protocolNumber >= info.ItemStackRequestProtocol
+}
+
+// GetProtocol returns the protocol used to encode and decode packets of the session.
+// The latest protocol is returned if the session has not logged in yet.
+func (session *MinecraftSession) GetProtocol() protocol2.Protocol {
+	if session.protocol == nil {
+		return session.adapter.protocols.GetLatest()
+	}
+	return session.protocol
+}
+
+// SetProtocol sets the protocol used to encode and decode packets of the session.
+func (session *MinecraftSession) SetProtocol(protocol protocol2.Protocol) {
+	session.protocol = protocol
+}
+
 // GetGameVersion returns the Minecraft version the player used to join the server.
 func (session *MinecraftSession) GetGameVersion() string {
 	return session.minecraftVersion
@@ -175,6 +217,12 @@ func (session *MinecraftSession) GetLanguage() string {
 	return session.language
 }
 
+// GetClientLanguage returns the language the client logged in with,
+// which may differ from the language of the session if it was overridden.
+func (session *MinecraftSession) GetClientLanguage() string {
+	return session.clientLanguage
+}
+
 // GetClientId returns the client ID of this session.
 func (session *MinecraftSession) GetClientId() int {
 	return session.clientId
@@ -191,7 +239,9 @@ func (session *MinecraftSession) UsesEncryption() bool {
 }
 
 // EnableEncryption enables encryption for this session and computes secret key bytes.
+// Packets queued before encryption got enabled are sent unencrypted.
 func (session *MinecraftSession) EnableEncryption() {
+	session.Flush()
 	session.usesEncryption = true
 	session.encryptionHandler.Data.ComputeSharedSecret()
 	session.encryptionHandler.Data.ComputeSecretKeyBytes()
@@ -208,8 +258,114 @@ func (session *MinecraftSession) SetXBOXLiveAuthenticated(value bool) {
 }
 
 // SendMessage sends a text message to the Minecraft session.
+// Messages sent while the command output of the session is captured become part of the command output.
 func (session *MinecraftSession) SendMessage(message ...interface{}) {
-	session.SendText(types.Text{Message: strings.Trim(fmt.Sprint(message), "[]")})
+	var content = strings.Trim(fmt.Sprint(message), "[]")
+	if session.output.add(content) {
+		return
+	}
+	session.SendText(types.Text{Message: content})
+}
+
+// SendTranslation sends a translated message to the session.
+// The key gets translated by the client, for example "%commands.message.display.incoming",
+// and the parameters get filled in the translation.
+func (session *MinecraftSession) SendTranslation(key string, parameters ...string) {
+	session.SendText(types.Text{Message: key, TextType: data2.TextTranslation, IsTranslation: true, TranslationParameters: parameters})
+}
+
+// GetGameMode returns the game mode of the session.
+// Sessions are in creative mode by default.
+func (session *MinecraftSession) GetGameMode() int32 {
+	return session.gameMode
+}
+
+// SetGameMode sets the game mode of the session and sends it to the client.
+// Flight is allowed in creative and spectator mode, and disallowed in other game modes.
+func (session *MinecraftSession) SetGameMode(gameMode int32) {
+	session.gameMode = gameMode
+	session.SendSetPlayerGameType(gameMode)
+	session.player.SetAllowFlight(gameMode == data2.GameModeCreative || gameMode == data2.GameModeSpectator)
+	// The client resets its abilities once its game type changes,
+	// so the abilities need to be sent right after the game type.
+	session.player.HasAbilityUpdate()
+	session.SendAbilities()
+}
+
+// SetAllowFlight sets if the player of the session is allowed to fly,
+// and sends the abilities to the client so the fly button appears immediately.
+func (session *MinecraftSession) SetAllowFlight(value bool) {
+	session.player.SetAllowFlight(value)
+	if session.player.HasAbilityUpdate() {
+		session.SendAbilities()
+	}
+}
+
+// SetFlying makes the player of the session start or stop flying.
+// False is returned if flight is not allowed for the player.
+func (session *MinecraftSession) SetFlying(value bool) bool {
+	if !session.player.SetFlying(value) {
+		return false
+	}
+	if session.player.HasAbilityUpdate() {
+		session.SendAbilities()
+	}
+	return true
+}
+
+// SetImmobile sets if the player of the session is immobile, locking its movement input,
+// and sends the updated metadata to the session and all viewers.
+func (session *MinecraftSession) SetImmobile(value bool) {
+	session.player.SetImmobile(value)
+	session.sendEntityData()
+}
+
+// SetFrozen sets if the player of the session is frozen,
+// and sends the updated metadata to the session and all viewers.
+// Movement of frozen players is rejected by the server.
+func (session *MinecraftSession) SetFrozen(value bool) {
+	session.player.SetFrozen(value)
+	session.sendEntityData()
+}
+
+// sendEntityData sends the metadata of the player of the session to the session and all viewers.
+func (session *MinecraftSession) sendEntityData() {
+	session.SendSetEntityData(session.player.GetRuntimeId(), session.player.GetEntityData())
+	session.player.BroadcastUpdatedEntityData()
+}
+
+// SendAbilities sends the abilities of the player of the session to the client,
+// including if it is allowed to fly and the actions it may perform.
+func (session *MinecraftSession) SendAbilities() {
+	var flags uint32
+	if session.player.GetAllowFlight() {
+		flags |= data2.AdventureFlagAllowFlight
+	}
+	if session.player.IsFlying() {
+		flags |= data2.AdventureFlagFlying
+	}
+	switch session.gameMode {
+	case data2.GameModeAdventure:
+		flags |= data2.AdventureFlagWorldImmutable
+	case data2.GameModeSpectator:
+		flags |= data2.AdventureFlagWorldImmutable | data2.AdventureFlagNoClip
+	}
+
+	var actions uint32 = data2.ActionPermissionBuildAndMine | data2.ActionPermissionDoorsAndSwitches | data2.ActionPermissionOpenContainers | data2.ActionPermissionAttackPlayers | data2.ActionPermissionAttackMobs
+	var commandPermission uint32
+	var level = permissions.LevelMember
+	if session.permissionGroup != nil && session.permissionGroup.GetLevel() >= permissions.LevelOperator {
+		actions |= data2.ActionPermissionOperator | data2.ActionPermissionTeleport
+		commandPermission = 1
+		level = permissions.LevelOperator
+	}
+	session.SendAdventureSettings(flags, commandPermission, actions, uint32(level), session.player.GetUniqueId())
+}
+
+// IsSurvival checks if the session is in survival or adventure mode,
+// in which items get used up and blocks drop items.
+func (session *MinecraftSession) IsSurvival() bool {
+	return session.gameMode == data2.GameModeSurvival || session.gameMode == data2.GameModeAdventure
 }
 
 // GetPermissionGroup returns the permission group this session is in.
@@ -224,11 +380,18 @@ func (session *MinecraftSession) SetPermissionGroup(group *permissions.Group) {
 
 // HasPermission checks if this session has a permission.
 func (session *MinecraftSession) HasPermission(permission string) bool {
-	if session.GetPermissionGroup().HasPermission(permission) {
+	if session.GetPermissionGroup() != nil && session.GetPermissionGroup().HasPermission(permission) {
 		return true
 	}
-	var _, exists = session.permissions[permission]
-	return exists
+	if _, exists := session.permissions[permission]; exists {
+		return true
+	}
+	for granted := range session.permissions {
+		if permissions.Matches(granted, permission) {
+			return true
+		}
+	}
+	return false
 }
 
 // AddPermission adds a permission to the session.
@@ -252,33 +415,86 @@ func (session *MinecraftSession) RemovePermission(permission string) bool {
 	return true
 }
 
+// ClearPermissions deletes all permissions from the session.
+// This does not delete the permissions of the group the session is in.
+func (session *MinecraftSession) ClearPermissions() {
+	session.permissions = make(map[string]*permissions.Permission)
+}
+
+// SendSkin sends the skin of the player of the session to the target,
+// converted to a skin the target can render.
 func (session *MinecraftSession) SendSkin(target *MinecraftSession) {
 	var player = session.GetPlayer()
-	target.SendPlayerSkin(player.GetUUID(), player.GetSkinId(), player.GetGeometryName(), player.GetGeometryData(), player.GetSkinData(), player.GetCapeData())
+	var skin = session.adapter.SkinFunction(GetSkin(player), target)
+	target.SendPlayerSkin(player.GetUUID(), skin.Id, skin.GeometryName, skin.GeometryData, skin.Data, skin.CapeData)
+}
+
+// IsClosed checks if the session has no open connection to send packets to.
+func (session *MinecraftSession) IsClosed() bool {
+	return session.session == nil || session.adapter.IsClosed(session.session)
 }
 
 // SendPacket sends a packet to this session.
+// The packet gets queued until the session is flushed if the network adapter batches packets per tick.
+// Packets sent to closed sessions are dropped, reporting an error wrapping SessionClosed to the network adapter.
 func (session *MinecraftSession) SendPacket(packet packets.IPacket) {
-	if session.session == nil {
+	if session.IsClosed() {
+		session.reportError(fmt.Errorf("send packet %v: %w", packet.GetId(), SessionClosed))
+		return
+	}
+	if session.adapter.BatchPerTick {
+		session.queueMutex.Lock()
+		session.queue = append(session.queue, packet)
+		session.queueMutex.Unlock()
 		return
 	}
 	var b = NewMinecraftPacketBatch(session)
 	b.AddPacket(packet)
 
-	session.SendBatch(b)
+	if err := session.SendBatch(b); err != nil {
+		session.reportError(err)
+	}
+}
+
+// reportError passes the error to the error function of the network adapter of this session.
+// Sessions without network adapter, such as sessions created in tests, discard errors.
+func (session *MinecraftSession) reportError(err error) {
+	if session.adapter != nil {
+		session.adapter.ErrorFunction(session, err)
+	}
+}
+
+// Flush sends all queued packets to this session in a single batch.
+// An error wrapping SessionClosed is returned if packets were queued for a closed session.
+func (session *MinecraftSession) Flush() error {
+	session.queueMutex.Lock()
+	var queue = session.queue
+	session.queue = nil
+	session.queueMutex.Unlock()
+	if len(queue) == 0 {
+		return nil
+	}
+	var b = NewMinecraftPacketBatch(session)
+	for _, packet := range queue {
+		b.AddPacket(packet)
+	}
+	return session.SendBatch(b)
 }
 
 // SendBatch sends a batch to this session.
-func (session *MinecraftSession) SendBatch(batch *MinecraftPacketBatch) {
-	if session.session == nil {
-		return
+// An error wrapping SessionClosed is returned if the session is closed.
+func (session *MinecraftSession) SendBatch(batch *MinecraftPacketBatch) error {
+	if session.IsClosed() {
+		return fmt.Errorf("send batch of %v packets: %w", len(batch.GetPackets()), SessionClosed)
 	}
+	session.adapter.countSent(batch)
 	session.session.SendPacket(batch, protocol.ReliabilityReliable, server.PriorityMedium)
+	return nil
 }
 
 // HandlePacket handles packets of this session.
 func (session *MinecraftSession) HandlePacket(packet packets.IPacket) {
-	priorityHandlers := session.adapter.packetManager.GetHandlersById(packet.GetId())
+	priorityHandlers := session.GetProtocol().GetHandlersById(packet.GetId())
 
 	var handled = false
 handling:
@@ -318,6 +534,8 @@ func (session *MinecraftSession) Close(reason string, hideDisconnectionScreen bo
 		session.player.Close()
 	}
 	session.SendDisconnect(reason, hideDisconnectionScreen)
+	session.Flush()
+	session.StopPacketTrace()
 }
 
 func (session *MinecraftSession) Kick(reason string, hideDisconnectionScreen bool, isAdmin bool) {
@@ -331,14 +549,87 @@ func (session *MinecraftSession) Kick(reason string, hideDisconnectionScreen boo
 	}
 }
 
-// SyncMove synchronizes the server's player movement with the client movement.
+// SyncMove synchronizes the server's player movement with the client movement,
+// and queues the chunks missing around the new position to be sent to the client.
 func (session *MinecraftSession) SyncMove(x, y, z float64, pitch, yaw, headYaw float64, onGround bool) {
 	session.player.SyncMove(x, y, z, pitch, yaw, headYaw, onGround)
+	session.moveChunkLoader()
+}
+
+// moveChunkLoader moves the chunk loader of the session to the chunk the player is in.
+func (session *MinecraftSession) moveChunkLoader() {
+	if dimension := session.player.GetDimension(); dimension != nil {
+		session.chunkLoader.Move(dimension, int32(math.Floor(session.player.Position.X))>>4, int32(math.Floor(session.player.Position.Z))>>4, session.GetViewDistance())
+	}
+}
+
+// Teleport teleports the player of the session to the given position and rotation,
+// within the dimension the player is currently in. Viewers see the player teleport on the next tick.
+func (session *MinecraftSession) Teleport(position r3.Vector, rotation data.Rotation) {
+	session.player.SyncMove(position.X, position.Y, position.Z, rotation.Pitch, rotation.Yaw, rotation.HeadYaw, session.player.OnGround)
+	session.player.GetMovementTracker().Teleport()
+	session.SendMovePlayer(session.player.GetRuntimeId(), position, rotation, data2.MoveTeleport, session.player.OnGround, session.player.GetRidingId())
+}
+
+// ChangeDimension moves the player of the session to the given position in another dimension.
+// The player and the players it was viewing get despawned for each other, and the client shows
+// a loading screen until the chunks around the position were sent by the chunk loader.
+func (session *MinecraftSession) ChangeDimension(dimension *worlds.Dimension, position r3.Vector) {
+	for _, online := range session.adapter.sessionManager.GetSessions() {
+		if online == session || online.GetPlayer() == nil {
+			continue
+		}
+		if online.IsViewing(session.player.Entity) {
+			online.SendRemoveEntity(session.player.GetUniqueId())
+			session.player.RemoveViewer(online)
+		}
+		if session.IsViewing(online.player.Entity) {
+			session.SendRemoveEntity(online.player.GetUniqueId())
+			online.player.RemoveViewer(session)
+		}
+	}
+	dimension.AddEntity(session.player, position)
+	session.SendChangeDimension(dimension.GetDimensionId(), position, false)
+
+	var rotation = session.player.GetRotation()
+	session.player.SyncMove(position.X, position.Y, position.Z, rotation.Pitch, rotation.Yaw, rotation.HeadYaw, false)
+	session.player.GetMovementTracker().Teleport()
+	session.moveChunkLoader()
+}
+
+// GetPosition returns the position of the player of the session.
+func (session *MinecraftSession) GetPosition() r3.Vector {
+	return session.player.GetPosition()
+}
+
+// SetMotion sets the velocity of the player of the session in blocks per tick.
+// The motion is applied by the client of the session, and sent to all viewers of the player.
+func (session *MinecraftSession) SetMotion(motion r3.Vector) {
+	var runtimeId = session.player.GetRuntimeId()
+	session.SendSetEntityMotion(runtimeId, motion)
+	for _, viewer := range session.player.GetViewers() {
+		if viewer, ok := viewer.(*MinecraftSession); ok && viewer != session {
+			viewer.SendSetEntityMotion(runtimeId, motion)
+		}
+	}
+}
+
+// SendInventory sends the full inventory, cursor item and off hand item of the player to the session.
+func (session *MinecraftSession) SendInventory() {
+	session.SendInventoryContent(data2.ContainerInventory, session.player.GetInventory().GetAll())
+	session.SendInventoryContent(data2.ContainerCursor, session.player.GetCursorInventory().GetAll())
+	session.SendInventoryContent(data2.ContainerOffHand, session.player.GetOffHandInventory().GetAll())
 }
 
 func (session *MinecraftSession) Tick() {
 	if session.Connected {
-		session.GetChunkLoader().Warp(session.GetPlayer().GetDimension(), int32(math.Floor(session.player.Position.X))>>4, int32(math.Floor(session.player.Position.Z))>>4)
-		session.GetChunkLoader().Request(session.GetViewDistance(), 40)
+		session.moveChunkLoader()
+		session.chunkLoader.Request(40)
+		if session.player.HasAttributeUpdate() {
+			session.SendUpdateAttributes(session.player.GetRuntimeId(), session.player.GetAttributeMap())
+		}
+		if session.player.HasAbilityUpdate() {
+			session.SendAbilities()
+		}
 	}
 }
@@ -1,26 +1,88 @@
 package net
 
 import (
+	"compress/zlib"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"time"
+
 	"github.com/irmine/gomine/net/packets"
 	protocol2 "github.com/irmine/gomine/net/protocol"
+	"github.com/irmine/gomine/skins"
 	"github.com/irmine/gomine/text"
 	"github.com/irmine/goraklib/protocol"
 	"github.com/irmine/goraklib/server"
+	"github.com/irmine/worlds"
+	"github.com/irmine/worlds/chunks"
 	"net"
 )
 
+var (
+	// InvalidPacket gets returned when a batch or packet
+	// received from a session could not be decoded.
+	InvalidPacket = errors.New("invalid packet")
+	// SessionClosed gets returned when sending to a session
+	// that has no open connection.
+	SessionClosed = errors.New("session is closed")
+)
+
 type NetworkAdapter struct {
-	rakLibManager   *server.Manager
-	packetManager   protocol2.IPacketManager
-	sessionManager  *SessionManager
+	// CompressionLevel is the zlib compression level batches get compressed with,
+	// ranging from zlib.HuffmanOnly to zlib.BestCompression.
+	CompressionLevel int
+	// CompressionThreshold is the size in bytes below which batches get sent without compression.
+	CompressionThreshold int
+	// BatchPerTick makes sessions queue all packets sent to them,
+	// until they get sent in a single batch when the session gets flushed.
+	BatchPerTick bool
+	// UnknownPacketLogInterval is the minimum interval at which
+	// packets with the same unknown packet ID get logged.
+	UnknownPacketLogInterval time.Duration
+	// UnknownPacketFunction gets called for every packet
+	// with an ID not registered in the protocol of the session that sent it.
+	UnknownPacketFunction func(packet *packets.UnknownPacket, session *MinecraftSession)
+	// ChunkDataFunction returns the serialized chunk of the dimension as sent to sessions.
+	ChunkDataFunction func(dimension *worlds.Dimension, chunk *chunks.Chunk) []byte
+	// SkinFunction returns the skin as sent to the viewer,
+	// which may convert the skin to one the protocol of the viewer can render.
+	SkinFunction func(skin skins.Skin, viewer *MinecraftSession) skins.Skin
+	// ErrorFunction gets called for every error that occurs receiving from or sending to a session.
+	// Errors wrap InvalidPacket or SessionClosed, so that their cause can be checked using errors.Is.
+	ErrorFunction func(session *MinecraftSession, err error)
+
+	packetsReceived uint64
+	packetsSent     uint64
+
+	unknownLog     *unknownPacketLog
+	closed         closedSessions
+	rakLibManager  *server.Manager
+	protocols      *protocol2.Pool
+	sessionManager *SessionManager
 }
 
 // NewNetworkAdapter returns a new Network adapter to adapt to the RakNet server.
-func NewNetworkAdapter(packetManager protocol2.IPacketManager, sessionManager *SessionManager) *NetworkAdapter {
+// The given protocol is used as latest protocol, which handles all packets.
+func NewNetworkAdapter(latest protocol2.Protocol, sessionManager *SessionManager) *NetworkAdapter {
 	var manager = server.NewManager()
-	var adapter = &NetworkAdapter{manager, packetManager, sessionManager}
+	var adapter = &NetworkAdapter{
+		CompressionLevel:         zlib.DefaultCompression,
+		UnknownPacketLogInterval: time.Minute,
+		UnknownPacketFunction:    func(*packets.UnknownPacket, *MinecraftSession) {},
+		ChunkDataFunction:        serializeChunk,
+		SkinFunction:             func(skin skins.Skin, viewer *MinecraftSession) skins.Skin { return skin },
+		ErrorFunction:            logSessionError,
+		unknownLog:               &unknownPacketLog{logged: make(map[int]time.Time)},
+		rakLibManager:            manager,
+		protocols:                protocol2.NewPool(latest),
+		sessionManager:           sessionManager,
+		closed:                   closedSessions{sessions: make(map[*server.Session]time.Time)},
+	}
 
 	manager.PacketFunction = func(packet []byte, session *server.Session) {
+		if adapter.IsClosed(session) {
+			return
+		}
 		var minecraftSession *MinecraftSession
 		var ok bool
 		if minecraftSession, ok = adapter.sessionManager.GetSessionByRakNetSession(session); !ok {
@@ -30,6 +92,7 @@ func NewNetworkAdapter(packetManager protocol2.IPacketManager, sessionManager *S
 	}
 	manager.DisconnectFunction = func(session *server.Session) {
 		text.DefaultLogger.Debug(session, "disconnected!")
+		adapter.MarkClosed(session)
 
 	}
 	manager.ConnectFunction = func(session *server.Session) {
@@ -38,27 +101,93 @@ func NewNetworkAdapter(packetManager protocol2.IPacketManager, sessionManager *S
 	return adapter
 }
 
+// serializeChunk returns the chunk serialized as sent to sessions, regardless of its dimension.
+func serializeChunk(_ *worlds.Dimension, chunk *chunks.Chunk) []byte {
+	return chunk.ToBinary()
+}
+
+// logSessionError logs the error along with the name of the session.
+// Sending to closed sessions is common while they are being removed, so those errors are only logged for debugging.
+func logSessionError(session *MinecraftSession, err error) {
+	if errors.Is(err, SessionClosed) {
+		text.DefaultLogger.Debug(session.GetName(), err)
+		return
+	}
+	text.DefaultLogger.Error(session.GetName(), err)
+}
+
 // GetRakLibManager returns the GoRakLib manager of the network adapter.
 func (adapter *NetworkAdapter) GetRakLibManager() *server.Manager {
 	return adapter.rakLibManager
 }
 
+// GetProtocolPool returns the pool of all protocols supported by the network adapter.
+func (adapter *NetworkAdapter) GetProtocolPool() *protocol2.Pool {
+	return adapter.protocols
+}
+
 // HandlePackets handles all packets of the given session + player.
 func (adapter *NetworkAdapter) HandlePacket(session *MinecraftSession, buffer []byte) {
 	batch := NewMinecraftPacketBatch(session)
 	batch.Buffer = buffer
-	batch.Decode()
+	if err := batch.decode(); err != nil {
+		adapter.ErrorFunction(session, err)
+	}
 
+	atomic.AddUint64(&adapter.packetsReceived, uint64(len(batch.GetPackets())))
 	for _, packet := range batch.GetPackets() {
-		if session.GetProtocolNumber() < 120 {
-			packet.DecodeId()
-		} else {
-			packet.DecodeHeader()
+		if err := decodePacket(session, packet); err != nil {
+			adapter.ErrorFunction(session, err)
+			continue
 		}
-		packet.Decode()
 
-		session.HandlePacket(packet)
+		if unknown, ok := packet.(*packets.UnknownPacket); ok {
+			adapter.handleUnknownPacket(session, unknown)
+			continue
+		}
+		var handleStart = time.Now()
+		session.HandlePacket(session.GetProtocol().UpgradePacket(packet))
+		session.tracePacket(TraceReceived, packet, time.Since(handleStart))
+	}
+
+	// Sessions that have not been added to the session manager do not get flushed every tick.
+	if _, ok := adapter.sessionManager.GetSessionByRakNetSession(session.GetSession()); !ok {
+		if err := session.Flush(); err != nil {
+			adapter.ErrorFunction(session, err)
+		}
+	}
+}
+
+// decodePacket decodes the header and payload of the packet received from the session.
+// Packets with malformed payloads make the stream panic, which is returned as an error wrapping InvalidPacket.
+func decodePacket(session *MinecraftSession, packet packets.IPacket) (err error) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			err = fmt.Errorf("%w: packet %v: %v", InvalidPacket, packet.GetId(), recovered)
+		}
+	}()
+	if session.GetProtocolNumber() < 120 {
+		packet.DecodeId()
+	} else {
+		packet.DecodeHeader()
 	}
+	packet.Decode()
+	return nil
+}
+
+// GetPacketsReceived returns the total amount of packets received from all sessions.
+func (adapter *NetworkAdapter) GetPacketsReceived() uint64 {
+	return atomic.LoadUint64(&adapter.packetsReceived)
+}
+
+// GetPacketsSent returns the total amount of packets sent to all sessions.
+func (adapter *NetworkAdapter) GetPacketsSent() uint64 {
+	return atomic.LoadUint64(&adapter.packetsSent)
+}
+
+// countSent adds the packets in the batch to the amount of packets sent.
+func (adapter *NetworkAdapter) countSent(batch *MinecraftPacketBatch) {
+	atomic.AddUint64(&adapter.packetsSent, uint64(len(batch.GetPackets())))
 }
 
 // GetSession returns a GoRakLib session by an address and port.
@@ -77,5 +206,6 @@ func (adapter *NetworkAdapter) SendPacket(pk packets.IPacket, session *Minecraft
 
 // SendBatch sends a Minecraft packet batch to the given GoRakLib session with the given priority.
 func (adapter *NetworkAdapter) SendBatch(batch *MinecraftPacketBatch, session *server.Session, priority server.Priority) {
+	adapter.countSent(batch)
 	session.SendPacket(batch, protocol.ReliabilityReliableOrdered, priority)
 }
@@ -0,0 +1,158 @@
+package net
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/irmine/gomine/net/packets"
+)
+
+// Directions of traced packets.
+const (
+	TraceReceived = "recv"
+	TraceSent     = "sent"
+)
+
+// PacketTracer writes the name, size and timing of the packets sent to and received from a session to a writer,
+// until it gets stopped or its duration has passed. Tracers may be limited to packets with certain names.
+type PacketTracer struct {
+	// StopFunction gets called once the tracer stopped, either by being stopped or because its duration has passed.
+	StopFunction func()
+
+	mutex   sync.Mutex
+	writer  io.WriteCloser
+	filter  map[string]bool
+	started time.Time
+	timer   *time.Timer
+	stopped bool
+}
+
+// packetTrace holds the packet tracer of a session.
+type packetTrace struct {
+	mutex  sync.RWMutex
+	tracer *PacketTracer
+}
+
+// NewPacketTracer returns a new packet tracer writing to the writer, which stops after the given duration.
+// Only packets with the names in the filter are traced, such as MovePlayerPacket or movePlayer,
+// or all packets if the filter is empty.
+func NewPacketTracer(writer io.WriteCloser, duration time.Duration, filter []string) *PacketTracer {
+	var tracer = &PacketTracer{StopFunction: func() {}, writer: writer, filter: make(map[string]bool), started: time.Now()}
+	for _, name := range filter {
+		tracer.filter[normalizePacketName(name)] = true
+	}
+	tracer.timer = time.AfterFunc(duration, func() {
+		tracer.Stop()
+	})
+	return tracer
+}
+
+// normalizePacketName returns the packet name in lower case without Packet suffix,
+// so that MovePlayerPacket, moveplayer and movePlayer all match.
+func normalizePacketName(name string) string {
+	return strings.TrimSuffix(strings.ToLower(strings.TrimSpace(name)), "packet")
+}
+
+// Matches checks if packets with the given name are traced.
+func (tracer *PacketTracer) Matches(name string) bool {
+	return len(tracer.filter) == 0 || tracer.filter[normalizePacketName(name)]
+}
+
+// Trace writes a line for the packet with the given direction, name, ID and size in bytes,
+// holding the time since the tracer was started. Received packets also hold the time it took to handle them.
+// Nothing is written if the tracer was stopped or the packet does not match the filter.
+func (tracer *PacketTracer) Trace(direction string, name string, id int, size int, handleTime time.Duration) {
+	if !tracer.Matches(name) {
+		return
+	}
+	tracer.mutex.Lock()
+	defer tracer.mutex.Unlock()
+	if tracer.stopped {
+		return
+	}
+	var line = fmt.Sprintf("%10.3fs %v %-40v id %3d %7d bytes", time.Since(tracer.started).Seconds(), direction, name, id, size)
+	if direction == TraceReceived {
+		line += fmt.Sprint(" handled in ", handleTime)
+	}
+	fmt.Fprintln(tracer.writer, line)
+}
+
+// IsStopped checks if the tracer was stopped.
+func (tracer *PacketTracer) IsStopped() bool {
+	tracer.mutex.Lock()
+	defer tracer.mutex.Unlock()
+	return tracer.stopped
+}
+
+// Stop stops the tracer and closes its writer. Stopping a stopped tracer does nothing.
+func (tracer *PacketTracer) Stop() error {
+	tracer.mutex.Lock()
+	if tracer.stopped {
+		tracer.mutex.Unlock()
+		return nil
+	}
+	tracer.stopped = true
+	tracer.timer.Stop()
+	var err = tracer.writer.Close()
+	tracer.mutex.Unlock()
+	tracer.StopFunction()
+	return err
+}
+
+// StartPacketTrace starts tracing the packets of the session with the tracer,
+// stopping the tracer the session was traced with before.
+func (session *MinecraftSession) StartPacketTrace(tracer *PacketTracer) {
+	session.trace.mutex.Lock()
+	var previous = session.trace.tracer
+	session.trace.tracer = tracer
+	session.trace.mutex.Unlock()
+	if previous != nil {
+		previous.Stop()
+	}
+}
+
+// StopPacketTrace stops tracing the packets of the session.
+// A bool is returned indicating if the packets of the session were being traced.
+func (session *MinecraftSession) StopPacketTrace() bool {
+	session.trace.mutex.Lock()
+	var tracer = session.trace.tracer
+	session.trace.tracer = nil
+	session.trace.mutex.Unlock()
+	if tracer == nil {
+		return false
+	}
+	tracer.Stop()
+	return true
+}
+
+// IsTracingPackets checks if the packets of the session are being traced by a tracer that has not stopped yet.
+func (session *MinecraftSession) IsTracingPackets() bool {
+	session.trace.mutex.RLock()
+	defer session.trace.mutex.RUnlock()
+	return session.trace.tracer != nil && !session.trace.tracer.IsStopped()
+}
+
+// tracePacket traces the packet if the packets of the session are being traced.
+// The packet should be encoded or decoded, so that its buffer holds the packet.
+func (session *MinecraftSession) tracePacket(direction string, packet packets.IPacket, handleTime time.Duration) {
+	session.trace.mutex.RLock()
+	var tracer = session.trace.tracer
+	session.trace.mutex.RUnlock()
+	if tracer == nil {
+		return
+	}
+	tracer.Trace(direction, session.getPacketName(packet.GetId()), packet.GetId(), len(packet.GetBuffer()), handleTime)
+}
+
+// getPacketName returns the name of the packet with the given ID in the protocol of the session.
+func (session *MinecraftSession) getPacketName(id int) string {
+	for name, packetId := range session.GetProtocol().GetIdList() {
+		if packetId == id {
+			return string(name)
+		}
+	}
+	return "UnknownPacket"
+}
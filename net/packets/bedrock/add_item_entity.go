@@ -0,0 +1,43 @@
+package bedrock
+
+import (
+	"github.com/golang/geo/r3"
+	"github.com/irmine/gomine/items"
+	"github.com/irmine/gomine/net/info"
+	"github.com/irmine/gomine/net/packets"
+)
+
+type AddItemEntityPacket struct {
+	*packets.Packet
+	UniqueId    int64
+	RuntimeId   uint64
+	Item        *items.Stack
+	Position    r3.Vector
+	Motion      r3.Vector
+	EntityData  map[uint32][]interface{}
+	FromFishing bool
+}
+
+func NewAddItemEntityPacket() *AddItemEntityPacket {
+	return &AddItemEntityPacket{Packet: packets.NewPacket(info.PacketIds[info.AddItemEntityPacket]), EntityData: make(map[uint32][]interface{})}
+}
+
+func (pk *AddItemEntityPacket) Encode() {
+	pk.PutEntityUniqueId(pk.UniqueId)
+	pk.PutEntityRuntimeId(pk.RuntimeId)
+	pk.PutItem(pk.Item)
+	pk.PutVector(pk.Position)
+	pk.PutVector(pk.Motion)
+	pk.PutEntityData(pk.EntityData)
+	pk.PutBool(pk.FromFishing)
+}
+
+func (pk *AddItemEntityPacket) Decode() {
+	pk.UniqueId = pk.GetEntityUniqueId()
+	pk.RuntimeId = pk.GetEntityRuntimeId()
+	pk.Item = pk.GetItem()
+	pk.Position = pk.GetVector()
+	pk.Motion = pk.GetVector()
+	pk.EntityData = pk.GetEntityData()
+	pk.FromFishing = pk.GetBool()
+}
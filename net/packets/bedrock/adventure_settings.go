@@ -0,0 +1,38 @@
+package bedrock
+
+import (
+	"github.com/irmine/gomine/net/info"
+	"github.com/irmine/gomine/net/packets"
+)
+
+type AdventureSettingsPacket struct {
+	*packets.Packet
+	Flags                   uint32
+	CommandPermission       uint32
+	ActionPermissions       uint32
+	PermissionLevel         uint32
+	CustomStoredPermissions uint32
+	EntityUniqueId          int64
+}
+
+func NewAdventureSettingsPacket() *AdventureSettingsPacket {
+	return &AdventureSettingsPacket{Packet: packets.NewPacket(info.PacketIds[info.AdventureSettingsPacket])}
+}
+
+func (pk *AdventureSettingsPacket) Encode() {
+	pk.PutUnsignedVarInt(pk.Flags)
+	pk.PutUnsignedVarInt(pk.CommandPermission)
+	pk.PutUnsignedVarInt(pk.ActionPermissions)
+	pk.PutUnsignedVarInt(pk.PermissionLevel)
+	pk.PutUnsignedVarInt(pk.CustomStoredPermissions)
+	pk.PutLittleLong(pk.EntityUniqueId)
+}
+
+func (pk *AdventureSettingsPacket) Decode() {
+	pk.Flags = pk.GetUnsignedVarInt()
+	pk.CommandPermission = pk.GetUnsignedVarInt()
+	pk.ActionPermissions = pk.GetUnsignedVarInt()
+	pk.PermissionLevel = pk.GetUnsignedVarInt()
+	pk.CustomStoredPermissions = pk.GetUnsignedVarInt()
+	pk.EntityUniqueId = pk.GetLittleLong()
+}
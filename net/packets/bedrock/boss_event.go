@@ -0,0 +1,75 @@
+package bedrock
+
+import (
+	"github.com/irmine/gomine/net/info"
+	"github.com/irmine/gomine/net/packets"
+	"github.com/irmine/gomine/net/packets/data"
+)
+
+type BossEventPacket struct {
+	*packets.Packet
+	BossEntityUniqueId   int64
+	EventType            uint32
+	PlayerEntityUniqueId int64
+	HealthPercentage     float32
+	Title                string
+	Darken               int16
+	Color                uint32
+	Overlay              uint32
+}
+
+func NewBossEventPacket() *BossEventPacket {
+	return &BossEventPacket{Packet: packets.NewPacket(info.PacketIds[info.BossEventPacket])}
+}
+
+func (pk *BossEventPacket) Encode() {
+	pk.PutEntityUniqueId(pk.BossEntityUniqueId)
+	pk.PutUnsignedVarInt(pk.EventType)
+	switch pk.EventType {
+	case data.BossEventShow:
+		pk.PutString(pk.Title)
+		pk.PutLittleFloat(pk.HealthPercentage)
+		pk.PutLittleShort(pk.Darken)
+		pk.PutUnsignedVarInt(pk.Color)
+		pk.PutUnsignedVarInt(pk.Overlay)
+	case data.BossEventRegisterPlayer, data.BossEventUnregisterPlayer:
+		pk.PutEntityUniqueId(pk.PlayerEntityUniqueId)
+	case data.BossEventHealthPercentage:
+		pk.PutLittleFloat(pk.HealthPercentage)
+	case data.BossEventTitle:
+		pk.PutString(pk.Title)
+	case data.BossEventProperties:
+		pk.PutLittleShort(pk.Darken)
+		pk.PutUnsignedVarInt(pk.Color)
+		pk.PutUnsignedVarInt(pk.Overlay)
+	case data.BossEventTexture:
+		pk.PutUnsignedVarInt(pk.Color)
+		pk.PutUnsignedVarInt(pk.Overlay)
+	}
+}
+
+func (pk *BossEventPacket) Decode() {
+	pk.BossEntityUniqueId = pk.GetEntityUniqueId()
+	pk.EventType = pk.GetUnsignedVarInt()
+	switch pk.EventType {
+	case data.BossEventShow:
+		pk.Title = pk.GetString()
+		pk.HealthPercentage = pk.GetLittleFloat()
+		pk.Darken = pk.GetLittleShort()
+		pk.Color = pk.GetUnsignedVarInt()
+		pk.Overlay = pk.GetUnsignedVarInt()
+	case data.BossEventRegisterPlayer, data.BossEventUnregisterPlayer:
+		pk.PlayerEntityUniqueId = pk.GetEntityUniqueId()
+	case data.BossEventHealthPercentage:
+		pk.HealthPercentage = pk.GetLittleFloat()
+	case data.BossEventTitle:
+		pk.Title = pk.GetString()
+	case data.BossEventProperties:
+		pk.Darken = pk.GetLittleShort()
+		pk.Color = pk.GetUnsignedVarInt()
+		pk.Overlay = pk.GetUnsignedVarInt()
+	case data.BossEventTexture:
+		pk.Color = pk.GetUnsignedVarInt()
+		pk.Overlay = pk.GetUnsignedVarInt()
+	}
+}
@@ -0,0 +1,30 @@
+package bedrock
+
+import (
+	"github.com/golang/geo/r3"
+	"github.com/irmine/gomine/net/info"
+	"github.com/irmine/gomine/net/packets"
+)
+
+type ChangeDimensionPacket struct {
+	*packets.Packet
+	Dimension int32
+	Position  r3.Vector
+	Respawn   bool
+}
+
+func NewChangeDimensionPacket() *ChangeDimensionPacket {
+	return &ChangeDimensionPacket{Packet: packets.NewPacket(info.PacketIds[info.ChangeDimensionPacket]), Position: r3.Vector{}}
+}
+
+func (pk *ChangeDimensionPacket) Encode() {
+	pk.PutVarInt(pk.Dimension)
+	pk.PutVector(pk.Position)
+	pk.PutBool(pk.Respawn)
+}
+
+func (pk *ChangeDimensionPacket) Decode() {
+	pk.Dimension = pk.GetVarInt()
+	pk.Position = pk.GetVector()
+	pk.Respawn = pk.GetBool()
+}
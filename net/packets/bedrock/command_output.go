@@ -0,0 +1,58 @@
+package bedrock
+
+import (
+	"github.com/irmine/gomine/net/info"
+	"github.com/irmine/gomine/net/packets"
+	"github.com/irmine/gomine/net/packets/data"
+	"github.com/irmine/gomine/net/packets/types"
+)
+
+type CommandOutputPacket struct {
+	*packets.Packet
+	Origin       types.CommandOrigin
+	OutputType   byte
+	SuccessCount uint32
+	Messages     []types.CommandOutputMessage
+	// DataSet is only encoded for the data set output type.
+	DataSet string
+}
+
+func NewCommandOutputPacket() *CommandOutputPacket {
+	return &CommandOutputPacket{Packet: packets.NewPacket(info.PacketIds[info.CommandOutputPacket]), OutputType: data.CommandOutputAllMessages}
+}
+
+func (pk *CommandOutputPacket) Encode() {
+	pk.PutCommandOrigin(pk.Origin)
+	pk.PutByte(pk.OutputType)
+	pk.PutUnsignedVarInt(pk.SuccessCount)
+	pk.PutUnsignedVarInt(uint32(len(pk.Messages)))
+	for _, message := range pk.Messages {
+		pk.PutBool(message.Internal)
+		pk.PutString(message.MessageId)
+		pk.PutUnsignedVarInt(uint32(len(message.Parameters)))
+		for _, parameter := range message.Parameters {
+			pk.PutString(parameter)
+		}
+	}
+	if pk.OutputType == data.CommandOutputDataSet {
+		pk.PutString(pk.DataSet)
+	}
+}
+
+func (pk *CommandOutputPacket) Decode() {
+	pk.Origin = pk.GetCommandOrigin()
+	pk.OutputType = pk.GetByte()
+	pk.SuccessCount = pk.GetUnsignedVarInt()
+	var count = pk.GetUnsignedVarInt()
+	for i := uint32(0); i < count; i++ {
+		var message = types.CommandOutputMessage{Internal: pk.GetBool(), MessageId: pk.GetString()}
+		var parameters = pk.GetUnsignedVarInt()
+		for j := uint32(0); j < parameters; j++ {
+			message.Parameters = append(message.Parameters, pk.GetString())
+		}
+		pk.Messages = append(pk.Messages, message)
+	}
+	if pk.OutputType == data.CommandOutputDataSet {
+		pk.DataSet = pk.GetString()
+	}
+}
@@ -1,32 +1,30 @@
 package bedrock
 
 import (
-	"github.com/google/uuid"
 	"github.com/irmine/gomine/net/info"
 	"github.com/irmine/gomine/net/packets"
+	"github.com/irmine/gomine/net/packets/types"
 )
 
 type CommandRequestPacket struct {
 	*packets.Packet
 	CommandText string
-	Type        uint32
-	UUID        uuid.UUID
-	RequestId   string
+	Origin      types.CommandOrigin
 	Internal    bool
 }
 
 func NewCommandRequestPacket() *CommandRequestPacket {
-	return &CommandRequestPacket{packets.NewPacket(info.PacketIds[info.CommandRequestPacket]), "", 0, uuid.New(), "", false}
+	return &CommandRequestPacket{Packet: packets.NewPacket(info.PacketIds[info.CommandRequestPacket])}
 }
 
 func (pk *CommandRequestPacket) Encode() {
-
+	pk.PutString(pk.CommandText)
+	pk.PutCommandOrigin(pk.Origin)
+	pk.PutBool(pk.Internal)
 }
 
 func (pk *CommandRequestPacket) Decode() {
 	pk.CommandText = pk.GetString()
-	pk.Type = pk.GetUnsignedVarInt()
-	pk.UUID = pk.GetUUID()
-	pk.RequestId = pk.GetString()
+	pk.Origin = pk.GetCommandOrigin()
 	pk.Internal = pk.GetBool()
 }
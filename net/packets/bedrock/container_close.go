@@ -0,0 +1,23 @@
+package bedrock
+
+import (
+	"github.com/irmine/gomine/net/info"
+	"github.com/irmine/gomine/net/packets"
+)
+
+type ContainerClosePacket struct {
+	*packets.Packet
+	WindowId byte
+}
+
+func NewContainerClosePacket() *ContainerClosePacket {
+	return &ContainerClosePacket{packets.NewPacket(info.PacketIds[info.ContainerClosePacket]), 0}
+}
+
+func (pk *ContainerClosePacket) Encode() {
+	pk.PutByte(pk.WindowId)
+}
+
+func (pk *ContainerClosePacket) Decode() {
+	pk.WindowId = pk.GetByte()
+}
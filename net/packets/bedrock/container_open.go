@@ -0,0 +1,33 @@
+package bedrock
+
+import (
+	"github.com/irmine/gomine/net/info"
+	"github.com/irmine/gomine/net/packets"
+	"github.com/irmine/worlds/blocks"
+)
+
+type ContainerOpenPacket struct {
+	*packets.Packet
+	WindowId       byte
+	ContainerType  byte
+	Position       blocks.Position
+	EntityUniqueId int64
+}
+
+func NewContainerOpenPacket() *ContainerOpenPacket {
+	return &ContainerOpenPacket{Packet: packets.NewPacket(info.PacketIds[info.ContainerOpenPacket]), EntityUniqueId: -1}
+}
+
+func (pk *ContainerOpenPacket) Encode() {
+	pk.PutByte(pk.WindowId)
+	pk.PutByte(pk.ContainerType)
+	pk.PutBlockPosition(pk.Position)
+	pk.PutEntityUniqueId(pk.EntityUniqueId)
+}
+
+func (pk *ContainerOpenPacket) Decode() {
+	pk.WindowId = pk.GetByte()
+	pk.ContainerType = pk.GetByte()
+	pk.Position = pk.GetBlockPosition()
+	pk.EntityUniqueId = pk.GetEntityUniqueId()
+}
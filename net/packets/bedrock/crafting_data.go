@@ -1,21 +1,65 @@
 package bedrock
 
 import (
+	"github.com/irmine/gomine/items"
 	"github.com/irmine/gomine/net/info"
 	"github.com/irmine/gomine/net/packets"
+	"github.com/irmine/gomine/net/packets/data"
+	"github.com/irmine/gomine/net/packets/types"
 )
 
 type CraftingDataPacket struct {
 	*packets.Packet
+	Recipes      types.CraftingData
+	CleanRecipes bool
 }
 
 func NewCraftingDataPacket() *CraftingDataPacket {
-	return &CraftingDataPacket{packets.NewPacket(info.PacketIds[info.CraftingDataPacket])}
+	return &CraftingDataPacket{packets.NewPacket(info.PacketIds[info.CraftingDataPacket]), types.CraftingData{}, true}
 }
 
 func (pk *CraftingDataPacket) Encode() {
-	pk.PutUnsignedVarInt(0)
-	pk.PutBool(true)
+	pk.PutUnsignedVarInt(uint32(len(pk.Recipes.Shapeless) + len(pk.Recipes.Shaped) + len(pk.Recipes.Furnace)))
+	for _, recipe := range pk.Recipes.Shapeless {
+		pk.PutVarInt(data.CraftingEntryShapeless)
+		pk.PutUnsignedVarInt(uint32(len(recipe.Input)))
+		for _, ingredient := range recipe.Input {
+			pk.PutRecipeIngredient(ingredient)
+		}
+		pk.putResults(recipe.Output)
+		pk.PutUUID(recipe.UUID)
+	}
+	for _, recipe := range pk.Recipes.Shaped {
+		pk.PutVarInt(data.CraftingEntryShaped)
+		pk.PutVarInt(recipe.Width)
+		pk.PutVarInt(recipe.Height)
+		for _, ingredient := range recipe.Input {
+			pk.PutRecipeIngredient(ingredient)
+		}
+		pk.putResults(recipe.Output)
+		pk.PutUUID(recipe.UUID)
+	}
+	for _, recipe := range pk.Recipes.Furnace {
+		var id, itemData, _ = items.GetNetworkId(recipe.Input)
+		if itemData == 0 {
+			pk.PutVarInt(data.CraftingEntryFurnace)
+			pk.PutVarInt(int32(id))
+		} else {
+			pk.PutVarInt(data.CraftingEntryFurnaceData)
+			pk.PutVarInt(int32(id))
+			pk.PutVarInt(int32(itemData))
+		}
+		pk.PutItem(recipe.Output)
+	}
+	pk.PutBool(pk.CleanRecipes)
+}
+
+// putResults writes the results of a crafting recipe.
+func (pk *CraftingDataPacket) putResults(results []*items.Stack) {
+	pk.PutUnsignedVarInt(uint32(len(results)))
+	for _, result := range results {
+		pk.PutItem(result)
+	}
 }
 
 func (pk *CraftingDataPacket) Decode() {
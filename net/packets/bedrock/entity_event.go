@@ -0,0 +1,29 @@
+package bedrock
+
+import (
+	"github.com/irmine/gomine/net/info"
+	"github.com/irmine/gomine/net/packets"
+)
+
+type EntityEventPacket struct {
+	*packets.Packet
+	RuntimeId uint64
+	Event     byte
+	Data      int32
+}
+
+func NewEntityEventPacket() *EntityEventPacket {
+	return &EntityEventPacket{packets.NewPacket(info.PacketIds[info.EntityEventPacket]), 0, 0, 0}
+}
+
+func (pk *EntityEventPacket) Encode() {
+	pk.PutEntityRuntimeId(pk.RuntimeId)
+	pk.PutByte(pk.Event)
+	pk.PutVarInt(pk.Data)
+}
+
+func (pk *EntityEventPacket) Decode() {
+	pk.RuntimeId = pk.GetEntityRuntimeId()
+	pk.Event = pk.GetByte()
+	pk.Data = pk.GetVarInt()
+}
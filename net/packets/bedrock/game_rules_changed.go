@@ -0,0 +1,24 @@
+package bedrock
+
+import (
+	"github.com/irmine/gomine/net/info"
+	"github.com/irmine/gomine/net/packets"
+	"github.com/irmine/gomine/net/packets/types"
+)
+
+type GameRulesChangedPacket struct {
+	*packets.Packet
+	GameRules map[string]types.GameRuleEntry
+}
+
+func NewGameRulesChangedPacket() *GameRulesChangedPacket {
+	return &GameRulesChangedPacket{packets.NewPacket(info.PacketIds[info.GameRulesChangedPacket]), make(map[string]types.GameRuleEntry)}
+}
+
+func (pk *GameRulesChangedPacket) Encode() {
+	pk.PutGameRules(pk.GameRules)
+}
+
+func (pk *GameRulesChangedPacket) Decode() {
+
+}
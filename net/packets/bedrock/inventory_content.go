@@ -0,0 +1,33 @@
+package bedrock
+
+import (
+	"github.com/irmine/gomine/items"
+	"github.com/irmine/gomine/net/info"
+	"github.com/irmine/gomine/net/packets"
+)
+
+type InventoryContentPacket struct {
+	*packets.Packet
+	WindowId uint32
+	Items    []*items.Stack
+}
+
+func NewInventoryContentPacket() *InventoryContentPacket {
+	return &InventoryContentPacket{packets.NewPacket(info.PacketIds[info.InventoryContentPacket]), 0, []*items.Stack{}}
+}
+
+func (pk *InventoryContentPacket) Encode() {
+	pk.PutUnsignedVarInt(pk.WindowId)
+	pk.PutUnsignedVarInt(uint32(len(pk.Items)))
+	for _, item := range pk.Items {
+		pk.PutItem(item)
+	}
+}
+
+func (pk *InventoryContentPacket) Decode() {
+	pk.WindowId = pk.GetUnsignedVarInt()
+	var count = pk.GetUnsignedVarInt()
+	for i := uint32(0); i < count; i++ {
+		pk.Items = append(pk.Items, pk.GetItem())
+	}
+}
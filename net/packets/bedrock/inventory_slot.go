@@ -0,0 +1,30 @@
+package bedrock
+
+import (
+	"github.com/irmine/gomine/items"
+	"github.com/irmine/gomine/net/info"
+	"github.com/irmine/gomine/net/packets"
+)
+
+type InventorySlotPacket struct {
+	*packets.Packet
+	WindowId uint32
+	Slot     uint32
+	Item     *items.Stack
+}
+
+func NewInventorySlotPacket() *InventorySlotPacket {
+	return &InventorySlotPacket{packets.NewPacket(info.PacketIds[info.InventorySlotPacket]), 0, 0, &items.Stack{}}
+}
+
+func (pk *InventorySlotPacket) Encode() {
+	pk.PutUnsignedVarInt(pk.WindowId)
+	pk.PutUnsignedVarInt(pk.Slot)
+	pk.PutItem(pk.Item)
+}
+
+func (pk *InventorySlotPacket) Decode() {
+	pk.WindowId = pk.GetUnsignedVarInt()
+	pk.Slot = pk.GetUnsignedVarInt()
+	pk.Item = pk.GetItem()
+}
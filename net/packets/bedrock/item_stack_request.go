@@ -0,0 +1,89 @@
+package bedrock
+
+import (
+	"github.com/irmine/gomine/net/info"
+	"github.com/irmine/gomine/net/packets"
+	"github.com/irmine/gomine/net/packets/data"
+	"github.com/irmine/gomine/net/packets/types"
+)
+
+type ItemStackRequestPacket struct {
+	*packets.Packet
+	Requests []types.ItemStackRequest
+}
+
+func NewItemStackRequestPacket() *ItemStackRequestPacket {
+	return &ItemStackRequestPacket{packets.NewPacket(info.ItemStackPacketIds[info.ItemStackRequestPacket]), nil}
+}
+
+func (pk *ItemStackRequestPacket) Encode() {
+	pk.PutUnsignedVarInt(uint32(len(pk.Requests)))
+	for _, request := range pk.Requests {
+		pk.PutVarInt(request.RequestId)
+		pk.PutUnsignedVarInt(uint32(len(request.Actions)))
+		for _, action := range request.Actions {
+			pk.PutByte(action.ActionType)
+			switch action.ActionType {
+			case data.StackRequestActionTake, data.StackRequestActionPlace:
+				pk.PutByte(action.Count)
+				pk.putSlotInfo(action.Source)
+				pk.putSlotInfo(action.Destination)
+			case data.StackRequestActionSwap:
+				pk.putSlotInfo(action.Source)
+				pk.putSlotInfo(action.Destination)
+			case data.StackRequestActionDrop:
+				pk.PutByte(action.Count)
+				pk.putSlotInfo(action.Source)
+				pk.PutBool(action.Randomly)
+			case data.StackRequestActionDestroy:
+				pk.PutByte(action.Count)
+				pk.putSlotInfo(action.Source)
+			}
+		}
+	}
+}
+
+func (pk *ItemStackRequestPacket) Decode() {
+	var count = pk.GetUnsignedVarInt()
+	for i := uint32(0); i < count; i++ {
+		var request = types.ItemStackRequest{RequestId: pk.GetVarInt()}
+		var actionCount = pk.GetUnsignedVarInt()
+		for j := uint32(0); j < actionCount; j++ {
+			var action = types.StackRequestAction{ActionType: pk.GetByte()}
+			switch action.ActionType {
+			case data.StackRequestActionTake, data.StackRequestActionPlace:
+				action.Count = pk.GetByte()
+				action.Source = pk.getSlotInfo()
+				action.Destination = pk.getSlotInfo()
+			case data.StackRequestActionSwap:
+				action.Source = pk.getSlotInfo()
+				action.Destination = pk.getSlotInfo()
+			case data.StackRequestActionDrop:
+				action.Count = pk.GetByte()
+				action.Source = pk.getSlotInfo()
+				action.Randomly = pk.GetBool()
+			case data.StackRequestActionDestroy:
+				action.Count = pk.GetByte()
+				action.Source = pk.getSlotInfo()
+			default:
+				// The length of unknown actions is unknown,
+				// so the rest of the packet can not be decoded.
+				request.Unsupported = true
+				pk.Requests = append(pk.Requests, request)
+				return
+			}
+			request.Actions = append(request.Actions, action)
+		}
+		pk.Requests = append(pk.Requests, request)
+	}
+}
+
+func (pk *ItemStackRequestPacket) putSlotInfo(slot types.StackRequestSlotInfo) {
+	pk.PutByte(slot.ContainerId)
+	pk.PutByte(slot.Slot)
+	pk.PutVarInt(slot.StackNetworkId)
+}
+
+func (pk *ItemStackRequestPacket) getSlotInfo() types.StackRequestSlotInfo {
+	return types.StackRequestSlotInfo{ContainerId: pk.GetByte(), Slot: pk.GetByte(), StackNetworkId: pk.GetVarInt()}
+}
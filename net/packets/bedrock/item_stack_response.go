@@ -0,0 +1,58 @@
+package bedrock
+
+import (
+	"github.com/irmine/gomine/net/info"
+	"github.com/irmine/gomine/net/packets"
+	"github.com/irmine/gomine/net/packets/data"
+	"github.com/irmine/gomine/net/packets/types"
+)
+
+type ItemStackResponsePacket struct {
+	*packets.Packet
+	Responses []types.ItemStackResponse
+}
+
+func NewItemStackResponsePacket() *ItemStackResponsePacket {
+	return &ItemStackResponsePacket{packets.NewPacket(info.ItemStackPacketIds[info.ItemStackResponsePacket]), nil}
+}
+
+func (pk *ItemStackResponsePacket) Encode() {
+	pk.PutUnsignedVarInt(uint32(len(pk.Responses)))
+	for _, response := range pk.Responses {
+		pk.PutByte(response.Status)
+		pk.PutVarInt(response.RequestId)
+		if response.Status != data.StackResponseStatusOk {
+			continue
+		}
+		pk.PutUnsignedVarInt(uint32(len(response.ContainerInfos)))
+		for _, container := range response.ContainerInfos {
+			pk.PutByte(container.ContainerId)
+			pk.PutUnsignedVarInt(uint32(len(container.Slots)))
+			for _, slot := range container.Slots {
+				pk.PutByte(slot.Slot)
+				pk.PutByte(slot.HotbarSlot)
+				pk.PutByte(slot.Count)
+				pk.PutVarInt(slot.StackNetworkId)
+			}
+		}
+	}
+}
+
+func (pk *ItemStackResponsePacket) Decode() {
+	var count = pk.GetUnsignedVarInt()
+	for i := uint32(0); i < count; i++ {
+		var response = types.ItemStackResponse{Status: pk.GetByte(), RequestId: pk.GetVarInt()}
+		if response.Status == data.StackResponseStatusOk {
+			var containerCount = pk.GetUnsignedVarInt()
+			for j := uint32(0); j < containerCount; j++ {
+				var container = types.StackResponseContainerInfo{ContainerId: pk.GetByte()}
+				var slotCount = pk.GetUnsignedVarInt()
+				for k := uint32(0); k < slotCount; k++ {
+					container.Slots = append(container.Slots, types.StackResponseSlotInfo{Slot: pk.GetByte(), HotbarSlot: pk.GetByte(), Count: pk.GetByte(), StackNetworkId: pk.GetVarInt()})
+				}
+				response.ContainerInfos = append(response.ContainerInfos, container)
+			}
+		}
+		pk.Responses = append(pk.Responses, response)
+	}
+}
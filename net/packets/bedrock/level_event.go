@@ -0,0 +1,30 @@
+package bedrock
+
+import (
+	"github.com/golang/geo/r3"
+	"github.com/irmine/gomine/net/info"
+	"github.com/irmine/gomine/net/packets"
+)
+
+type LevelEventPacket struct {
+	*packets.Packet
+	EventId  int32
+	Position r3.Vector
+	Data     int32
+}
+
+func NewLevelEventPacket() *LevelEventPacket {
+	return &LevelEventPacket{packets.NewPacket(info.PacketIds[info.LevelEventPacket]), 0, r3.Vector{}, 0}
+}
+
+func (pk *LevelEventPacket) Encode() {
+	pk.PutVarInt(pk.EventId)
+	pk.PutVector(pk.Position)
+	pk.PutVarInt(pk.Data)
+}
+
+func (pk *LevelEventPacket) Decode() {
+	pk.EventId = pk.GetVarInt()
+	pk.Position = pk.GetVector()
+	pk.Data = pk.GetVarInt()
+}
@@ -31,11 +31,13 @@ type LoginPacket struct {
 	GeometryData string
 
 	ClientData types.ClientDataKeys
-	Chains     []types.Chain
+	// ClientDataJwt is the raw JWT the client data was decoded from.
+	ClientDataJwt string
+	Chains        []types.Chain
 }
 
 func NewLoginPacket() *LoginPacket {
-	pk := &LoginPacket{packets.NewPacket(info.PacketIds[info.LoginPacket]), "", 0, uuid.New(), 0, "", "", "", "", "", []byte{}, []byte{}, "", "", types.ClientDataKeys{}, []types.Chain{}}
+	pk := &LoginPacket{packets.NewPacket(info.PacketIds[info.LoginPacket]), "", 0, uuid.New(), 0, "", "", "", "", "", []byte{}, []byte{}, "", "", types.ClientDataKeys{}, "", []types.Chain{}}
 	return pk
 }
 
@@ -74,10 +76,10 @@ func (pk *LoginPacket) Decode() {
 		}
 	}
 
-	var clientDataJwt = stream.Get(int(stream.GetLittleInt()))
+	pk.ClientDataJwt = string(stream.Get(int(stream.GetLittleInt())))
 	var clientData = &types.ClientDataKeys{}
 
-	utils.DecodeJwtPayload(string(clientDataJwt), clientData)
+	utils.DecodeJwtPayload(pk.ClientDataJwt, clientData)
 
 	pk.ClientId = clientData.ClientRandomId
 	pk.ServerAddress = clientData.ServerAddress
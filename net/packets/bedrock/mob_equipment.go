@@ -0,0 +1,36 @@
+package bedrock
+
+import (
+	"github.com/irmine/gomine/items"
+	"github.com/irmine/gomine/net/info"
+	"github.com/irmine/gomine/net/packets"
+)
+
+type MobEquipmentPacket struct {
+	*packets.Packet
+	RuntimeId     uint64
+	Item          *items.Stack
+	InventorySlot byte
+	HotbarSlot    byte
+	WindowId      byte
+}
+
+func NewMobEquipmentPacket() *MobEquipmentPacket {
+	return &MobEquipmentPacket{Packet: packets.NewPacket(info.PacketIds[info.MobEquipmentPacket]), Item: &items.Stack{}}
+}
+
+func (pk *MobEquipmentPacket) Encode() {
+	pk.PutEntityRuntimeId(pk.RuntimeId)
+	pk.PutItem(pk.Item)
+	pk.PutByte(pk.InventorySlot)
+	pk.PutByte(pk.HotbarSlot)
+	pk.PutByte(pk.WindowId)
+}
+
+func (pk *MobEquipmentPacket) Decode() {
+	pk.RuntimeId = pk.GetEntityRuntimeId()
+	pk.Item = pk.GetItem()
+	pk.InventorySlot = pk.GetByte()
+	pk.HotbarSlot = pk.GetByte()
+	pk.WindowId = pk.GetByte()
+}
@@ -0,0 +1,26 @@
+package bedrock
+
+import (
+	"github.com/irmine/gomine/net/info"
+	"github.com/irmine/gomine/net/packets"
+)
+
+type ModalFormRequestPacket struct {
+	*packets.Packet
+	FormId   uint32
+	FormData string
+}
+
+func NewModalFormRequestPacket() *ModalFormRequestPacket {
+	return &ModalFormRequestPacket{packets.NewPacket(info.PacketIds[info.ModalFormRequestPacket]), 0, ""}
+}
+
+func (pk *ModalFormRequestPacket) Encode() {
+	pk.PutUnsignedVarInt(pk.FormId)
+	pk.PutString(pk.FormData)
+}
+
+func (pk *ModalFormRequestPacket) Decode() {
+	pk.FormId = pk.GetUnsignedVarInt()
+	pk.FormData = pk.GetString()
+}
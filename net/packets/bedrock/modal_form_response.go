@@ -0,0 +1,26 @@
+package bedrock
+
+import (
+	"github.com/irmine/gomine/net/info"
+	"github.com/irmine/gomine/net/packets"
+)
+
+type ModalFormResponsePacket struct {
+	*packets.Packet
+	FormId   uint32
+	FormData string
+}
+
+func NewModalFormResponsePacket() *ModalFormResponsePacket {
+	return &ModalFormResponsePacket{packets.NewPacket(info.PacketIds[info.ModalFormResponsePacket]), 0, ""}
+}
+
+func (pk *ModalFormResponsePacket) Encode() {
+	pk.PutUnsignedVarInt(pk.FormId)
+	pk.PutString(pk.FormData)
+}
+
+func (pk *ModalFormResponsePacket) Decode() {
+	pk.FormId = pk.GetUnsignedVarInt()
+	pk.FormData = pk.GetString()
+}
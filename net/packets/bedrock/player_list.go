@@ -25,7 +25,11 @@ func (pk *PlayerListPacket) Encode() {
 			pk.PutUUID(entry.UUID)
 			pk.PutEntityUniqueId(entry.EntityUniqueId)
 
-			pk.PutString(entry.Username)
+			if entry.DisplayName != "" {
+				pk.PutString(entry.DisplayName)
+			} else {
+				pk.PutString(entry.Username)
+			}
 
 			pk.PutString(entry.SkinId)
 			pk.PutLengthPrefixedBytes(entry.SkinData)
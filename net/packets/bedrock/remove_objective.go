@@ -0,0 +1,23 @@
+package bedrock
+
+import (
+	"github.com/irmine/gomine/net/info"
+	"github.com/irmine/gomine/net/packets"
+)
+
+type RemoveObjectivePacket struct {
+	*packets.Packet
+	ObjectiveName string
+}
+
+func NewRemoveObjectivePacket() *RemoveObjectivePacket {
+	return &RemoveObjectivePacket{Packet: packets.NewPacket(info.PacketIds[info.RemoveObjectivePacket])}
+}
+
+func (pk *RemoveObjectivePacket) Encode() {
+	pk.PutString(pk.ObjectiveName)
+}
+
+func (pk *RemoveObjectivePacket) Decode() {
+	pk.ObjectiveName = pk.GetString()
+}
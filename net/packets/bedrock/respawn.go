@@ -0,0 +1,24 @@
+package bedrock
+
+import (
+	"github.com/golang/geo/r3"
+	"github.com/irmine/gomine/net/info"
+	"github.com/irmine/gomine/net/packets"
+)
+
+type RespawnPacket struct {
+	*packets.Packet
+	Position r3.Vector
+}
+
+func NewRespawnPacket() *RespawnPacket {
+	return &RespawnPacket{packets.NewPacket(info.PacketIds[info.RespawnPacket]), r3.Vector{}}
+}
+
+func (pk *RespawnPacket) Encode() {
+	pk.PutVector(pk.Position)
+}
+
+func (pk *RespawnPacket) Decode() {
+	pk.Position = pk.GetVector()
+}
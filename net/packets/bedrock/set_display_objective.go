@@ -0,0 +1,36 @@
+package bedrock
+
+import (
+	"github.com/irmine/gomine/net/info"
+	"github.com/irmine/gomine/net/packets"
+	"github.com/irmine/gomine/net/packets/data"
+)
+
+type SetDisplayObjectivePacket struct {
+	*packets.Packet
+	DisplaySlot   string
+	ObjectiveName string
+	DisplayName   string
+	CriteriaName  string
+	SortOrder     int32
+}
+
+func NewSetDisplayObjectivePacket() *SetDisplayObjectivePacket {
+	return &SetDisplayObjectivePacket{Packet: packets.NewPacket(info.PacketIds[info.SetDisplayObjectivePacket]), DisplaySlot: data.ScoreboardSlotSidebar, CriteriaName: "dummy"}
+}
+
+func (pk *SetDisplayObjectivePacket) Encode() {
+	pk.PutString(pk.DisplaySlot)
+	pk.PutString(pk.ObjectiveName)
+	pk.PutString(pk.DisplayName)
+	pk.PutString(pk.CriteriaName)
+	pk.PutVarInt(pk.SortOrder)
+}
+
+func (pk *SetDisplayObjectivePacket) Decode() {
+	pk.DisplaySlot = pk.GetString()
+	pk.ObjectiveName = pk.GetString()
+	pk.DisplayName = pk.GetString()
+	pk.CriteriaName = pk.GetString()
+	pk.SortOrder = pk.GetVarInt()
+}
@@ -0,0 +1,27 @@
+package bedrock
+
+import (
+	"github.com/golang/geo/r3"
+	"github.com/irmine/gomine/net/info"
+	"github.com/irmine/gomine/net/packets"
+)
+
+type SetEntityMotionPacket struct {
+	*packets.Packet
+	RuntimeId uint64
+	Motion    r3.Vector
+}
+
+func NewSetEntityMotionPacket() *SetEntityMotionPacket {
+	return &SetEntityMotionPacket{Packet: packets.NewPacket(info.PacketIds[info.SetEntityMotionPacket]), Motion: r3.Vector{}}
+}
+
+func (pk *SetEntityMotionPacket) Encode() {
+	pk.PutEntityRuntimeId(pk.RuntimeId)
+	pk.PutVector(pk.Motion)
+}
+
+func (pk *SetEntityMotionPacket) Decode() {
+	pk.RuntimeId = pk.GetEntityRuntimeId()
+	pk.Motion = pk.GetVector()
+}
@@ -0,0 +1,23 @@
+package bedrock
+
+import (
+	"github.com/irmine/gomine/net/info"
+	"github.com/irmine/gomine/net/packets"
+)
+
+type SetPlayerGameTypePacket struct {
+	*packets.Packet
+	GameMode int32
+}
+
+func NewSetPlayerGameTypePacket() *SetPlayerGameTypePacket {
+	return &SetPlayerGameTypePacket{packets.NewPacket(info.PacketIds[info.SetPlayerGameTypePacket]), 0}
+}
+
+func (pk *SetPlayerGameTypePacket) Encode() {
+	pk.PutVarInt(pk.GameMode)
+}
+
+func (pk *SetPlayerGameTypePacket) Decode() {
+	pk.GameMode = pk.GetVarInt()
+}
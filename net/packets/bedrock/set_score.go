@@ -0,0 +1,59 @@
+package bedrock
+
+import (
+	"github.com/irmine/gomine/net/info"
+	"github.com/irmine/gomine/net/packets"
+	"github.com/irmine/gomine/net/packets/data"
+	"github.com/irmine/gomine/net/packets/types"
+)
+
+type SetScorePacket struct {
+	*packets.Packet
+	ActionType byte
+	Entries    []types.ScoreboardEntry
+}
+
+func NewSetScorePacket() *SetScorePacket {
+	return &SetScorePacket{Packet: packets.NewPacket(info.PacketIds[info.SetScorePacket])}
+}
+
+func (pk *SetScorePacket) Encode() {
+	pk.PutByte(pk.ActionType)
+	pk.PutUnsignedVarInt(uint32(len(pk.Entries)))
+	for _, entry := range pk.Entries {
+		pk.PutVarLong(entry.ScoreboardId)
+		pk.PutString(entry.ObjectiveName)
+		pk.PutLittleInt(entry.Score)
+		if pk.ActionType != data.ScoreboardActionChange {
+			continue
+		}
+		pk.PutByte(entry.EntryType)
+		switch entry.EntryType {
+		case data.ScoreboardEntryPlayer, data.ScoreboardEntryEntity:
+			pk.PutEntityUniqueId(entry.EntityUniqueId)
+		case data.ScoreboardEntryFakePlayer:
+			pk.PutString(entry.CustomName)
+		}
+	}
+}
+
+func (pk *SetScorePacket) Decode() {
+	pk.ActionType = pk.GetByte()
+	var count = pk.GetUnsignedVarInt()
+	for i := uint32(0); i < count; i++ {
+		var entry = types.ScoreboardEntry{}
+		entry.ScoreboardId = pk.GetVarLong()
+		entry.ObjectiveName = pk.GetString()
+		entry.Score = pk.GetLittleInt()
+		if pk.ActionType == data.ScoreboardActionChange {
+			entry.EntryType = pk.GetByte()
+			switch entry.EntryType {
+			case data.ScoreboardEntryPlayer, data.ScoreboardEntryEntity:
+				entry.EntityUniqueId = pk.GetEntityUniqueId()
+			case data.ScoreboardEntryFakePlayer:
+				entry.CustomName = pk.GetString()
+			}
+		}
+		pk.Entries = append(pk.Entries, entry)
+	}
+}
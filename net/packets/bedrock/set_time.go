@@ -0,0 +1,23 @@
+package bedrock
+
+import (
+	"github.com/irmine/gomine/net/info"
+	"github.com/irmine/gomine/net/packets"
+)
+
+type SetTimePacket struct {
+	*packets.Packet
+	Time int32
+}
+
+func NewSetTimePacket() *SetTimePacket {
+	return &SetTimePacket{packets.NewPacket(info.PacketIds[info.SetTimePacket]), 0}
+}
+
+func (pk *SetTimePacket) Encode() {
+	pk.PutVarInt(pk.Time)
+}
+
+func (pk *SetTimePacket) Decode() {
+	pk.Time = pk.GetVarInt()
+}
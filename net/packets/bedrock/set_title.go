@@ -0,0 +1,35 @@
+package bedrock
+
+import (
+	"github.com/irmine/gomine/net/info"
+	"github.com/irmine/gomine/net/packets"
+)
+
+type SetTitlePacket struct {
+	*packets.Packet
+	TitleType int32
+	Text      string
+	FadeIn    int32
+	Stay      int32
+	FadeOut   int32
+}
+
+func NewSetTitlePacket() *SetTitlePacket {
+	return &SetTitlePacket{packets.NewPacket(info.PacketIds[info.SetTitlePacket]), 0, "", 0, 0, 0}
+}
+
+func (pk *SetTitlePacket) Encode() {
+	pk.PutVarInt(pk.TitleType)
+	pk.PutString(pk.Text)
+	pk.PutVarInt(pk.FadeIn)
+	pk.PutVarInt(pk.Stay)
+	pk.PutVarInt(pk.FadeOut)
+}
+
+func (pk *SetTitlePacket) Decode() {
+	pk.TitleType = pk.GetVarInt()
+	pk.Text = pk.GetString()
+	pk.FadeIn = pk.GetVarInt()
+	pk.Stay = pk.GetVarInt()
+	pk.FadeOut = pk.GetVarInt()
+}
@@ -0,0 +1,30 @@
+package bedrock
+
+import (
+	"github.com/golang/geo/r3"
+	"github.com/irmine/gomine/net/info"
+	"github.com/irmine/gomine/net/packets"
+)
+
+type SpawnParticleEffectPacket struct {
+	*packets.Packet
+	DimensionId  byte
+	Position     r3.Vector
+	ParticleName string
+}
+
+func NewSpawnParticleEffectPacket() *SpawnParticleEffectPacket {
+	return &SpawnParticleEffectPacket{packets.NewPacket(info.PacketIds[info.SpawnParticleEffectPacket]), 0, r3.Vector{}, ""}
+}
+
+func (pk *SpawnParticleEffectPacket) Encode() {
+	pk.PutByte(pk.DimensionId)
+	pk.PutVector(pk.Position)
+	pk.PutString(pk.ParticleName)
+}
+
+func (pk *SpawnParticleEffectPacket) Decode() {
+	pk.DimensionId = pk.GetByte()
+	pk.Position = pk.GetVector()
+	pk.ParticleName = pk.GetString()
+}
@@ -10,6 +10,12 @@ import (
 	"github.com/irmine/worlds/blocks"
 )
 
+const (
+	MovementAuthorityClient = iota
+	MovementAuthorityServer
+	MovementAuthorityServerWithRewind
+)
+
 const (
 	GameBroadcastSettingNone = iota
 	GameBroadcastSettingInviteOnly
@@ -63,10 +69,17 @@ type StartGamePacket struct {
 	WorldTemplateOptionLocked      bool
 	RuntimeIdsTable                []byte
 	MultiplayerCorrelationID       string
+	// Protocol is the protocol number the packet is encoded for.
+	// Fields added in newer protocols are only encoded for those protocols.
+	Protocol                       int32
+	Experiments                    []types.Experiment
+	ExperimentsPreviouslyToggled   bool
+	MovementAuthority              int32
+	ItemTable                      []types.ItemEntry
 }
 
 func NewStartGamePacket() *StartGamePacket {
-	return &StartGamePacket{Packet: packets.NewPacket(info.PacketIds[info.StartGamePacket]), GameRules: make(map[string]types.GameRuleEntry)}
+	return &StartGamePacket{Packet: packets.NewPacket(info.PacketIds[info.StartGamePacket]), GameRules: make(map[string]types.GameRuleEntry), Protocol: info.LatestProtocol}
 }
 
 func (pk *StartGamePacket) Encode() {
@@ -104,6 +117,10 @@ func (pk *StartGamePacket) Encode() {
 	pk.PutBool(pk.ForcedResourcePacks) // Texture packs required
 
 	pk.PutGameRules(pk.GameRules) // Game rules
+	if pk.Protocol >= info.ItemTableProtocol {
+		pk.PutExperiments(pk.Experiments)
+		pk.PutBool(pk.ExperimentsPreviouslyToggled)
+	}
 
 	pk.PutBool(pk.BonusChest)                // Bonus chest
 	pk.PutBool(pk.StartMap)                  // Start map
@@ -120,9 +137,17 @@ func (pk *StartGamePacket) Encode() {
 	pk.PutString(pk.LevelName)                                               // Level name
 	pk.PutString("")                                                     // Premium world template ID
 	pk.PutBool(pk.IsTrial)                                                   // Is Trial
+	if pk.Protocol >= info.ItemTableProtocol {
+		pk.PutVarInt(pk.MovementAuthority)
+	} else if pk.Protocol >= info.MovementAuthorityProtocol {
+		pk.PutBool(pk.MovementAuthority != MovementAuthorityClient)
+	}
 	pk.PutLittleLong(pk.CurrentTick)                                         // Tick
 	pk.PutVarInt(pk.EnchantmentSeed)                                         // Enchantment seed
 	pk.PutBytes(pk.RuntimeIdsTable)
+	if pk.Protocol >= info.ItemTableProtocol {
+		pk.PutItemTable(pk.ItemTable)
+	}
 	pk.PutString(pk.MultiplayerCorrelationID)
 }
 
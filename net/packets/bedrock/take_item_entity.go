@@ -0,0 +1,26 @@
+package bedrock
+
+import (
+	"github.com/irmine/gomine/net/info"
+	"github.com/irmine/gomine/net/packets"
+)
+
+type TakeItemEntityPacket struct {
+	*packets.Packet
+	ItemRuntimeId   uint64
+	PlayerRuntimeId uint64
+}
+
+func NewTakeItemEntityPacket() *TakeItemEntityPacket {
+	return &TakeItemEntityPacket{Packet: packets.NewPacket(info.PacketIds[info.TakeItemEntityPacket])}
+}
+
+func (pk *TakeItemEntityPacket) Encode() {
+	pk.PutEntityRuntimeId(pk.ItemRuntimeId)
+	pk.PutEntityRuntimeId(pk.PlayerRuntimeId)
+}
+
+func (pk *TakeItemEntityPacket) Decode() {
+	pk.ItemRuntimeId = pk.GetEntityRuntimeId()
+	pk.PlayerRuntimeId = pk.GetEntityRuntimeId()
+}
@@ -0,0 +1,36 @@
+package bedrock
+
+import (
+	"github.com/irmine/gomine/net/info"
+	"github.com/irmine/gomine/net/packets"
+	"github.com/irmine/gomine/net/packets/data"
+)
+
+type UpdateSoftEnumPacket struct {
+	*packets.Packet
+	EnumName string
+	Values   []string
+	Type     byte
+}
+
+func NewUpdateSoftEnumPacket() *UpdateSoftEnumPacket {
+	return &UpdateSoftEnumPacket{Packet: packets.NewPacket(info.PacketIds[info.UpdateSoftEnumPacket]), Type: data.SoftEnumSet}
+}
+
+func (pk *UpdateSoftEnumPacket) Encode() {
+	pk.PutString(pk.EnumName)
+	pk.PutUnsignedVarInt(uint32(len(pk.Values)))
+	for _, value := range pk.Values {
+		pk.PutString(value)
+	}
+	pk.PutByte(pk.Type)
+}
+
+func (pk *UpdateSoftEnumPacket) Decode() {
+	pk.EnumName = pk.GetString()
+	var count = pk.GetUnsignedVarInt()
+	for i := uint32(0); i < count; i++ {
+		pk.Values = append(pk.Values, pk.GetString())
+	}
+	pk.Type = pk.GetByte()
+}
@@ -14,6 +14,42 @@ const (
 	StatusLoginFailedEduVanilla
 )
 
+const (
+	ContainerInventory = 0
+	ContainerOffHand   = 119
+	ContainerArmor     = 120
+	ContainerCreative  = 121
+	ContainerHotbar    = 122
+	ContainerFixed     = 123
+	ContainerCursor    = 124
+)
+
+const (
+	ContainerTypeContainer = 0
+)
+
+// Container slot types referenced by item stack requests.
+const (
+	ContainerSlotCombinedHotbarAndInventory = 12
+	ContainerSlotHotbar                     = 27
+	ContainerSlotInventory                  = 28
+	ContainerSlotOffHand                    = 33
+	ContainerSlotCursor                     = 58
+)
+
+const (
+	StackRequestActionTake = iota
+	StackRequestActionPlace
+	StackRequestActionSwap
+	StackRequestActionDrop
+	StackRequestActionDestroy
+)
+
+const (
+	StackResponseStatusOk = iota
+	StackResponseStatusError
+)
+
 const (
 	MoveNormal = iota
 	MoveReset
@@ -54,3 +90,129 @@ const (
 	ListTypeAdd = iota
 	ListTypeRemove
 )
+
+const (
+	ScoreboardSlotSidebar   = "sidebar"
+	ScoreboardSlotList      = "list"
+	ScoreboardSlotBelowName = "belowname"
+)
+
+const (
+	ScoreboardSortAscending = iota
+	ScoreboardSortDescending
+)
+
+const (
+	ScoreboardActionChange = iota
+	ScoreboardActionRemove
+)
+
+const (
+	ScoreboardEntryPlayer = iota + 1
+	ScoreboardEntryEntity
+	ScoreboardEntryFakePlayer
+)
+
+const (
+	BossEventShow = iota
+	BossEventRegisterPlayer
+	BossEventHide
+	BossEventUnregisterPlayer
+	BossEventHealthPercentage
+	BossEventTitle
+	BossEventProperties
+	BossEventTexture
+)
+
+const (
+	TitleClear = iota
+	TitleReset
+	TitleTitle
+	TitleSubtitle
+	TitleActionBar
+	TitleTimes
+)
+
+const (
+	GameModeSurvival = iota
+	GameModeCreative
+	GameModeAdventure
+	GameModeSpectator
+)
+
+const (
+	EntityEventHurt    = 2
+	EntityEventDeath   = 3
+	EntityEventRespawn = 18
+	EntityEventTotem   = 65
+)
+
+// Flags of the adventure settings packet.
+const (
+	AdventureFlagWorldImmutable = 0x01
+	AdventureFlagNoPvP          = 0x02
+	AdventureFlagAutoJump       = 0x20
+	AdventureFlagAllowFlight    = 0x40
+	AdventureFlagNoClip         = 0x80
+	AdventureFlagWorldBuilder   = 0x100
+	AdventureFlagFlying         = 0x200
+	AdventureFlagMuted          = 0x400
+)
+
+// Action permissions of the adventure settings packet.
+const (
+	ActionPermissionBuildAndMine     = 0x01
+	ActionPermissionDoorsAndSwitches = 0x02
+	ActionPermissionOpenContainers   = 0x04
+	ActionPermissionAttackPlayers    = 0x08
+	ActionPermissionAttackMobs       = 0x10
+	ActionPermissionOperator         = 0x20
+	ActionPermissionTeleport         = 0x80
+)
+
+// Level events changing the weather.
+const (
+	LevelEventStartRain    = 3001
+	LevelEventStartThunder = 3002
+	LevelEventStopRain     = 3003
+	LevelEventStopThunder  = 3004
+)
+
+// Types of recipe entries in the crafting data packet.
+const (
+	CraftingEntryShapeless   = 0
+	CraftingEntryShaped      = 1
+	CraftingEntryFurnace     = 2
+	CraftingEntryFurnaceData = 3
+)
+
+// Origins of commands in command origin data.
+const (
+	CommandOriginPlayer = iota
+	CommandOriginBlock
+	CommandOriginMinecartBlock
+	CommandOriginDevConsole
+	CommandOriginTest
+	CommandOriginAutomationPlayer
+	CommandOriginClientAutomation
+	CommandOriginDedicatedServer
+	CommandOriginEntity
+	CommandOriginVirtual
+	CommandOriginGameArgument
+	CommandOriginEntityServer
+)
+
+// Types of command output in the command output packet.
+const (
+	CommandOutputLastMessage = iota + 1
+	CommandOutputSilent
+	CommandOutputAllMessages
+	CommandOutputDataSet
+)
+
+// Types of soft enum updates in the update soft enum packet.
+const (
+	SoftEnumAdd = iota
+	SoftEnumRemove
+	SoftEnumSet
+)
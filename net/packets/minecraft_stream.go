@@ -6,6 +6,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/irmine/binutils"
 	"github.com/irmine/gomine/items"
+	data2 "github.com/irmine/gomine/net/packets/data"
 	"github.com/irmine/gomine/net/packets/types"
 	"github.com/irmine/gonbt"
 	"github.com/irmine/worlds/blocks"
@@ -175,8 +176,23 @@ func (stream *MinecraftStream) GetAttributeMap() data.AttributeMap {
 // PutItem writes an item stack.
 // Item stacks also get their NBT written to network,
 // through the call of Stack.EmitNBT().
+// Nil stacks, empty stacks and stacks without
+// registered conversion are written as air.
 func (stream *MinecraftStream) PutItem(item *items.Stack) {
-	id, v := items.FromKey(items.TypeToId[fmt.Sprint(item.Type)])
+	if item == nil || item.Count == 0 {
+		stream.PutVarInt(0)
+		return
+	}
+	key, ok := items.TypeToId[fmt.Sprint(item.Type)]
+	if !ok {
+		stream.PutVarInt(0)
+		return
+	}
+	id, v := items.FromKey(key)
+	if id == 0 {
+		stream.PutVarInt(0)
+		return
+	}
 	stream.PutVarInt(int32(id))
 	stream.PutVarInt(item.GetAuxValue(item, v))
 
@@ -194,6 +210,20 @@ func (stream *MinecraftStream) PutItem(item *items.Stack) {
 	stream.PutVarInt(0)
 }
 
+// PutRecipeIngredient writes an ingredient of a crafting recipe.
+// Nil stacks, empty stacks and stacks without
+// registered conversion are written as empty ingredients.
+func (stream *MinecraftStream) PutRecipeIngredient(item *items.Stack) {
+	id, itemData, ok := items.GetNetworkId(item)
+	if !ok || id == 0 || item.Count == 0 {
+		stream.PutVarInt(0)
+		return
+	}
+	stream.PutVarInt(int32(id))
+	stream.PutVarInt(int32(itemData))
+	stream.PutVarInt(int32(item.Count))
+}
+
 // GetItem reads a new item stack.
 // The item stack returned may have NBT properties.
 // If the item ID was unknown, an air item gets returned.
@@ -378,6 +408,25 @@ func (stream *MinecraftStream) PutGameRules(gameRules map[string]types.GameRuleE
 	}
 }
 
+// PutExperiments writes a list of experiments, prefixed by its length.
+func (stream *MinecraftStream) PutExperiments(experiments []types.Experiment) {
+	stream.PutLittleInt(int32(len(experiments)))
+	for _, experiment := range experiments {
+		stream.PutString(experiment.Name)
+		stream.PutBool(experiment.Enabled)
+	}
+}
+
+// PutItemTable writes the item table, prefixed by its length.
+func (stream *MinecraftStream) PutItemTable(table []types.ItemEntry) {
+	stream.PutUnsignedVarInt(uint32(len(table)))
+	for _, entry := range table {
+		stream.PutString(entry.Name)
+		stream.PutLittleShort(entry.RuntimeId)
+		stream.PutBool(entry.ComponentBased)
+	}
+}
+
 // PutPackInfo writes the info of an array of resource pack entries.
 // The UUID, version and pack size gets written.
 func (stream *MinecraftStream) PutPackInfo(packs []types.ResourcePackInfoEntry) {
@@ -426,3 +475,24 @@ func (stream *MinecraftStream) PutUUID(uuid uuid.UUID) {
 func (stream *MinecraftStream) GetUUID() uuid.UUID {
 	return uuid.Must(uuid.FromBytes(stream.Get(16)))
 }
+
+// PutCommandOrigin writes command origin data.
+// The UUID is written in the order it was read by GetUUID,
+// so that origins received with command requests are sent back unchanged.
+func (stream *MinecraftStream) PutCommandOrigin(origin types.CommandOrigin) {
+	stream.PutUnsignedVarInt(origin.Type)
+	stream.PutBytes(origin.UUID[:])
+	stream.PutString(origin.RequestId)
+	if origin.Type == data2.CommandOriginDevConsole || origin.Type == data2.CommandOriginTest {
+		stream.PutVarLong(origin.PlayerUniqueId)
+	}
+}
+
+// GetCommandOrigin reads command origin data.
+func (stream *MinecraftStream) GetCommandOrigin() types.CommandOrigin {
+	var origin = types.CommandOrigin{Type: stream.GetUnsignedVarInt(), UUID: stream.GetUUID(), RequestId: stream.GetString()}
+	if origin.Type == data2.CommandOriginDevConsole || origin.Type == data2.CommandOriginTest {
+		origin.PlayerUniqueId = stream.GetVarLong()
+	}
+	return origin
+}
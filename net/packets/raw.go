@@ -0,0 +1,26 @@
+package packets
+
+// RawPacket is a packet of which the payload is not decoded or encoded,
+// but kept as raw bytes following the packet header.
+// Raw packets can be used to send packets not yet supported by GoMine.
+type RawPacket struct {
+	*Packet
+	// Payload is the raw payload of the packet, excluding the packet header.
+	Payload []byte
+}
+
+// NewRawPacket returns a new raw packet with the given packet ID and payload.
+func NewRawPacket(id int, payload []byte) *RawPacket {
+	return &RawPacket{NewPacket(id), payload}
+}
+
+// Encode writes the raw payload of the packet.
+func (pk *RawPacket) Encode() {
+	pk.PutBytes(pk.Payload)
+}
+
+// Decode reads the remaining bytes of the packet as raw payload.
+func (pk *RawPacket) Decode() {
+	pk.Payload = append([]byte(nil), pk.Buffer[pk.Offset:]...)
+	pk.Offset = len(pk.Buffer)
+}
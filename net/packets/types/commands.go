@@ -0,0 +1,25 @@
+package types
+
+import (
+	"github.com/google/uuid"
+)
+
+// CommandOrigin is the origin of a command, sent by the client with a command request
+// and sent back in the command output, so that the client knows which command the output belongs to.
+type CommandOrigin struct {
+	// Type is the type of origin, such as data.CommandOriginPlayer.
+	Type      uint32
+	UUID      uuid.UUID
+	RequestId string
+	// PlayerUniqueId is only encoded for the dev console and test origins.
+	PlayerUniqueId int64
+}
+
+// CommandOutputMessage is a message in the output of a command.
+type CommandOutputMessage struct {
+	// Internal specifies if the message ID is a translation key of the client.
+	// Messages that are not internal are shown as they are.
+	Internal   bool
+	MessageId  string
+	Parameters []string
+}
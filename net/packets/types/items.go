@@ -0,0 +1,83 @@
+package types
+
+import (
+	"github.com/google/uuid"
+	"github.com/irmine/gomine/items"
+)
+
+// StackRequestSlotInfo is a slot referenced by an item stack request action.
+type StackRequestSlotInfo struct {
+	ContainerId    byte
+	Slot           byte
+	StackNetworkId int32
+}
+
+// StackRequestAction is a single action of an item stack request,
+// for example moving items from one slot to another.
+type StackRequestAction struct {
+	ActionType  byte
+	Count       byte
+	Source      StackRequestSlotInfo
+	Destination StackRequestSlotInfo
+	// Randomly specifies if dropped items should be thrown in a random direction.
+	Randomly bool
+}
+
+// ItemStackRequest is a request of the client to change its inventories.
+// All actions of a request must succeed, or none of them are applied.
+type ItemStackRequest struct {
+	RequestId int32
+	Actions   []StackRequestAction
+	// Unsupported is true if the request contained actions that could not be decoded.
+	Unsupported bool
+}
+
+// StackResponseSlotInfo is the new content of a slot changed by an item stack request.
+type StackResponseSlotInfo struct {
+	Slot           byte
+	HotbarSlot     byte
+	Count          byte
+	StackNetworkId int32
+}
+
+// StackResponseContainerInfo holds the changed slots of a container.
+type StackResponseContainerInfo struct {
+	ContainerId byte
+	Slots       []StackResponseSlotInfo
+}
+
+// ItemStackResponse is the response of the server to an item stack request.
+type ItemStackResponse struct {
+	Status         byte
+	RequestId      int32
+	ContainerInfos []StackResponseContainerInfo
+}
+
+// ShapedRecipeEntry is a shaped crafting recipe sent to the client.
+// The input holds width * height ingredients row by row, with nil for empty slots.
+type ShapedRecipeEntry struct {
+	Width, Height int32
+	Input         []*items.Stack
+	Output        []*items.Stack
+	UUID          uuid.UUID
+}
+
+// ShapelessRecipeEntry is a shapeless crafting recipe sent to the client.
+type ShapelessRecipeEntry struct {
+	Input  []*items.Stack
+	Output []*items.Stack
+	UUID   uuid.UUID
+}
+
+// FurnaceRecipeEntry is a furnace recipe sent to the client.
+type FurnaceRecipeEntry struct {
+	Input  *items.Stack
+	Output *items.Stack
+}
+
+// CraftingData holds all recipes sent to the client in the crafting data packet.
+type CraftingData struct {
+	Shaped    []ShapedRecipeEntry
+	Shapeless []ShapelessRecipeEntry
+	Furnace   []FurnaceRecipeEntry
+}
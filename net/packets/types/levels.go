@@ -4,3 +4,17 @@ type GameRuleEntry struct {
 	Name  string
 	Value interface{}
 }
+
+// Experiment is an experimental feature of a level, which may be enabled or disabled.
+type Experiment struct {
+	Name    string
+	Enabled bool
+}
+
+// ItemEntry is an entry of the item table sent to the client,
+// mapping the string ID of an item to its runtime ID.
+type ItemEntry struct {
+	Name           string
+	RuntimeId      int16
+	ComponentBased bool
+}
@@ -27,6 +27,8 @@ type ChainPayload struct {
 	Issuer               string `json:"iss"`
 	IssuedAt             int64  `json:"iat"`
 
+	ExtraData map[string]interface{} `json:"extraData"`
+
 	Raw string
 }
 
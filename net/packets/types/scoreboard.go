@@ -0,0 +1,10 @@
+package types
+
+type ScoreboardEntry struct {
+	ScoreboardId   int64
+	ObjectiveName  string
+	Score          int32
+	EntryType      byte
+	EntityUniqueId int64
+	CustomName     string
+}
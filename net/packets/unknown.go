@@ -0,0 +1,12 @@
+package packets
+
+// UnknownPacket is a packet with an ID not registered in the protocol of the session that sent it.
+// Its payload is kept as raw bytes, so that it can be inspected by plugins.
+type UnknownPacket struct {
+	*RawPacket
+}
+
+// NewUnknownPacket returns a new unknown packet with the given packet ID.
+func NewUnknownPacket(id int) *UnknownPacket {
+	return &UnknownPacket{NewRawPacket(id, nil)}
+}
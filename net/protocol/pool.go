@@ -0,0 +1,115 @@
+package protocol
+
+import (
+	"errors"
+	"sort"
+	"sync"
+
+	"github.com/irmine/gomine/net/info"
+	"github.com/irmine/gomine/net/packets"
+)
+
+// PacketRegistered gets returned when registering a custom packet
+// with an ID or name that is already used by any protocol in the pool.
+var PacketRegistered = errors.New("packet ID or name is already registered")
+
+// Pool is a collection of protocols, indexed by their protocol number.
+// The pool always has a latest protocol, which holds the handlers of all packets it knows.
+// Packets of other protocols get upgraded to the latest protocol before they get handled,
+// and packets sent get downgraded to the protocol of the session they are sent to.
+type Pool struct {
+	mutex     sync.RWMutex
+	latest    Protocol
+	protocols map[int32]Protocol
+}
+
+// NewPool returns a new protocol pool with the given latest protocol.
+func NewPool(latest Protocol) *Pool {
+	return &Pool{latest: latest, protocols: map[int32]Protocol{latest.GetProtocolNumber(): latest}}
+}
+
+// GetLatest returns the latest protocol of the pool.
+func (pool *Pool) GetLatest() Protocol {
+	return pool.latest
+}
+
+// RegisterProtocol registers a new protocol to the pool.
+// Protocols with the same protocol number get overwritten.
+func (pool *Pool) RegisterProtocol(protocol Protocol) {
+	pool.mutex.Lock()
+	pool.protocols[protocol.GetProtocolNumber()] = protocol
+	pool.mutex.Unlock()
+}
+
+// DeregisterProtocol deregisters the protocol with the given protocol number.
+// The latest protocol can not be deregistered.
+func (pool *Pool) DeregisterProtocol(protocolNumber int32) {
+	if protocolNumber == pool.latest.GetProtocolNumber() {
+		return
+	}
+	pool.mutex.Lock()
+	delete(pool.protocols, protocolNumber)
+	pool.mutex.Unlock()
+}
+
+// GetProtocol returns a protocol by its protocol number,
+// and a bool indicating if the protocol was registered.
+func (pool *Pool) GetProtocol(protocolNumber int32) (Protocol, bool) {
+	pool.mutex.RLock()
+	defer pool.mutex.RUnlock()
+	var protocol, ok = pool.protocols[protocolNumber]
+	return protocol, ok
+}
+
+// IsSupported checks if the pool has a protocol with the given protocol number.
+func (pool *Pool) IsSupported(protocolNumber int32) bool {
+	var _, ok = pool.GetProtocol(protocolNumber)
+	return ok
+}
+
+// GetProtocolNumbers returns all supported protocol numbers, sorted from low to high.
+func (pool *Pool) GetProtocolNumbers() []int32 {
+	pool.mutex.RLock()
+	defer pool.mutex.RUnlock()
+	var numbers = make([]int32, 0, len(pool.protocols))
+	for number := range pool.protocols {
+		numbers = append(numbers, number)
+	}
+	sort.Slice(numbers, func(i, j int) bool {
+		return numbers[i] < numbers[j]
+	})
+	return numbers
+}
+
+// GetOldest returns the protocol number of the oldest supported protocol.
+func (pool *Pool) GetOldest() int32 {
+	return pool.GetProtocolNumbers()[0]
+}
+
+// GetNewest returns the protocol number of the newest supported protocol.
+func (pool *Pool) GetNewest() int32 {
+	var numbers = pool.GetProtocolNumbers()
+	return numbers[len(numbers)-1]
+}
+
+// RegisterCustomPacket registers a custom packet with the given name and ID to every protocol in the pool,
+// so that handlers can be registered for it on the latest protocol using its name.
+// PacketRegistered gets returned if any protocol already has a packet with the ID,
+// or if the latest protocol already has a packet with the name.
+func (pool *Pool) RegisterCustomPacket(name info.PacketName, packetId int, packetFunc func() packets.IPacket) error {
+	pool.mutex.Lock()
+	defer pool.mutex.Unlock()
+	if _, ok := pool.latest.GetIdList()[name]; ok {
+		return PacketRegistered
+	}
+	for _, protocol := range pool.protocols {
+		if protocol.IsPacketRegistered(packetId) {
+			return PacketRegistered
+		}
+	}
+	for _, protocol := range pool.protocols {
+		protocol.RegisterPacket(packetId, packetFunc)
+	}
+	pool.latest.GetIdList()[name] = packetId
+	return nil
+}
@@ -3,6 +3,7 @@ package protocol
 import (
 	"github.com/golang/geo/r3"
 	"github.com/google/uuid"
+	"github.com/irmine/gomine/items"
 	"github.com/irmine/gomine/net/info"
 	"github.com/irmine/gomine/net/packets"
 	"github.com/irmine/gomine/net/packets/types"
@@ -12,6 +13,26 @@ import (
 	"github.com/irmine/worlds/entities/data"
 )
 
+// Protocol is a single Bedrock protocol version.
+// Every protocol has its own packet pool and packet ID table,
+// and builds all outgoing packets in its own format,
+// downgrading packets of the latest protocol where needed.
+type Protocol interface {
+	IPacketManager
+	// GetProtocolNumber returns the protocol number of the protocol.
+	GetProtocolNumber() int32
+	// GetGameVersion returns the network game version of the protocol.
+	GetGameVersion() string
+	// UpgradePacket upgrades a packet decoded by this protocol
+	// to the equivalent packet of the latest protocol,
+	// so it can be handled by the handlers of the latest protocol.
+	UpgradePacket(packet packets.IPacket) packets.IPacket
+	// DowngradePacket downgrades a packet built by the latest protocol
+	// to the format of this protocol, right before it gets encoded.
+	// Nil is returned if the packet can not be sent using this protocol.
+	DowngradePacket(packet packets.IPacket) packets.IPacket
+}
+
 type IPacketManager interface {
 	GetIdList() info.PacketIdList
 	GetHandlers(packet info.PacketName) [][]Handler
@@ -26,9 +47,9 @@ type IPacketManager interface {
 	GetAddEntity(AddEntityEntry) packets.IPacket
 	GetAddPlayer(uuid.UUID, AddPlayerEntry) packets.IPacket
 	GetChunkRadiusUpdated(int32) packets.IPacket
-	GetCraftingData() packets.IPacket
+	GetCraftingData(types.CraftingData) packets.IPacket
 	GetDisconnect(string, bool) packets.IPacket
-	GetFullChunkData(*chunks.Chunk) packets.IPacket
+	GetFullChunkData(chunk *chunks.Chunk, chunkData []byte) packets.IPacket
 	GetMovePlayer(uint64, r3.Vector, data.Rotation, byte, bool, uint64) packets.IPacket
 	GetPlayerList(byte, map[string]PlayerListEntry) packets.IPacket
 	GetPlayStatus(int32) packets.IPacket
@@ -49,6 +70,31 @@ type IPacketManager interface {
 	GetPlayerAction(runtimeId uint64, action int32, position blocks.Position, face int32) packets.IPacket
 	GetAnimate(action int32, runtimeId uint64, float float32) packets.IPacket
 	GetUpdateBlock(position blocks.Position, blockRuntimeId, dataLayerId uint32) packets.IPacket
+	GetContainerOpen(windowId byte, containerType byte, position blocks.Position, entityUniqueId int64) packets.IPacket
+	GetContainerClose(windowId byte) packets.IPacket
+	GetInventoryContent(windowId uint32, items []*items.Stack) packets.IPacket
+	GetInventorySlot(windowId uint32, slot uint32, item *items.Stack) packets.IPacket
+	GetModalFormRequest(formId uint32, formData string) packets.IPacket
+	GetRemoveObjective(objectiveName string) packets.IPacket
+	GetSetDisplayObjective(displaySlot, objectiveName, displayName, criteriaName string, sortOrder int32) packets.IPacket
+	GetSetScore(actionType byte, entries []types.ScoreboardEntry) packets.IPacket
+	GetBossEvent(bossUniqueId int64, eventType uint32, playerUniqueId int64, title string, healthPercentage float32) packets.IPacket
+	GetSpawnParticleEffect(position r3.Vector, particleName string) packets.IPacket
+	GetSetPlayerGameType(gameMode int32) packets.IPacket
+	GetSetTitle(titleType int32, text string, fadeIn, stay, fadeOut int32) packets.IPacket
+	GetEntityEvent(runtimeId uint64, event byte, data int32) packets.IPacket
+	GetRespawn(position r3.Vector) packets.IPacket
+	GetItemStackResponse(responses []types.ItemStackResponse) packets.IPacket
+	GetAdventureSettings(flags, commandPermission, actionPermissions, permissionLevel uint32, entityUniqueId int64) packets.IPacket
+	GetSetTime(time int32) packets.IPacket
+	GetLevelEvent(eventId int32, position r3.Vector, data int32) packets.IPacket
+	GetGameRulesChanged(gameRules map[string]types.GameRuleEntry) packets.IPacket
+	GetSetEntityMotion(runtimeId uint64, motion r3.Vector) packets.IPacket
+	GetAddItemEntity(uniqueId int64, runtimeId uint64, item *items.Stack, position, motion r3.Vector) packets.IPacket
+	GetTakeItemEntity(itemRuntimeId, playerRuntimeId uint64) packets.IPacket
+	GetCommandOutput(origin types.CommandOrigin, successCount uint32, messages []types.CommandOutputMessage) packets.IPacket
+	GetUpdateSoftEnum(enumName string, values []string, updateType byte) packets.IPacket
+	GetChangeDimension(dimension int32, position r3.Vector, respawn bool) packets.IPacket
 }
 
 // PacketManagerBase is a struct providing the base for a PacketManagerBase.
@@ -0,0 +1,179 @@
+package protocol
+
+import (
+	"github.com/irmine/gomine/net/info"
+	"github.com/irmine/gomine/net/packets"
+)
+
+// Version is a protocol version other than the latest protocol, derived from the latest protocol.
+// Every version has its own packet ID table and packet pool, but all outgoing packets get built by the latest protocol.
+// Packets received get upgraded to the format of the latest protocol before they are handled,
+// and packets sent get downgraded to the format of the version before they are encoded.
+// Packets without conversion function have the same format in both protocols,
+// and only get their ID changed if it differs in the ID table of the version.
+type Version struct {
+	Protocol
+	base           *PacketManagerBase
+	protocolNumber int32
+	gameVersion    string
+	// names are the packet names of all packet IDs of the version.
+	names map[int]info.PacketName
+	// latestNames are the packet names of all packet IDs of the latest protocol.
+	latestNames map[int]info.PacketName
+	upgrades    map[info.PacketName]func(packets.IPacket) packets.IPacket
+	downgrades  map[info.PacketName]func(packets.IPacket) packets.IPacket
+}
+
+// NewVersion returns a new version of the latest protocol with the given protocol number, game version and packet ID table.
+// All packets of the latest protocol that have the same ID in the table of the version are decoded by the version.
+// Packets with another ID, or packets that do not exist in the latest protocol, must be registered using RegisterPacket.
+func NewVersion(latest Protocol, protocolNumber int32, gameVersion string, idList info.PacketIdList) *Version {
+	var version = &Version{
+		Protocol:       latest,
+		base:           NewPacketManagerBase(idList, map[int]func() packets.IPacket{}, map[int][][]Handler{}),
+		protocolNumber: protocolNumber,
+		gameVersion:    gameVersion,
+		names:          make(map[int]info.PacketName, len(idList)),
+		latestNames:    make(map[int]info.PacketName, len(latest.GetIdList())),
+		upgrades:       make(map[info.PacketName]func(packets.IPacket) packets.IPacket),
+		downgrades:     make(map[info.PacketName]func(packets.IPacket) packets.IPacket),
+	}
+	for name, id := range latest.GetIdList() {
+		version.latestNames[id] = name
+	}
+	for name, id := range idList {
+		version.names[id] = name
+		if latestId, ok := latest.GetIdList()[name]; ok && latestId == id && latest.IsPacketRegistered(id) {
+			version.base.RegisterPacket(id, latest.GetPackets()[id])
+		}
+	}
+	return version
+}
+
+// GetProtocolNumber returns the protocol number of the version.
+func (version *Version) GetProtocolNumber() int32 {
+	return version.protocolNumber
+}
+
+// GetGameVersion returns the network game version of the version.
+func (version *Version) GetGameVersion() string {
+	return version.gameVersion
+}
+
+// RegisterUpgrade registers a function upgrading packets with the given name decoded by the version
+// to the equivalent packets of the latest protocol.
+// Conversion functions must be registered before the version is registered to a pool.
+func (version *Version) RegisterUpgrade(name info.PacketName, function func(packets.IPacket) packets.IPacket) {
+	version.upgrades[name] = function
+}
+
+// RegisterDowngrade registers a function downgrading packets with the given name built by the latest protocol
+// to the format of the version. The function may return nil if the packet can not be sent to the version.
+// Conversion functions must be registered before the version is registered to a pool.
+func (version *Version) RegisterDowngrade(name info.PacketName, function func(packets.IPacket) packets.IPacket) {
+	version.downgrades[name] = function
+}
+
+// UpgradePacket upgrades a packet decoded by the version using the upgrade function registered for it.
+// The packet keeps the packet ID of the version, by which its handlers are found.
+func (version *Version) UpgradePacket(packet packets.IPacket) packets.IPacket {
+	if function, ok := version.upgrades[version.names[packet.GetId()]]; ok {
+		return function(packet)
+	}
+	return packet
+}
+
+// DowngradePacket downgrades a packet built by the latest protocol using the downgrade function registered for it.
+// Packets unknown to the latest protocol are returned as is, and nil is returned for packets the version does not have.
+// Packets of which the ID differs in the version are returned as raw packet, so that the packet itself,
+// which may also be sent to sessions of other protocols, is left unchanged.
+func (version *Version) DowngradePacket(packet packets.IPacket) packets.IPacket {
+	var name, ok = version.latestNames[packet.GetId()]
+	if !ok {
+		return packet
+	}
+	var id, exists = version.base.idList[name]
+	if !exists {
+		return nil
+	}
+	if function, ok := version.downgrades[name]; ok {
+		if packet = function(packet); packet == nil {
+			return nil
+		}
+	}
+	if id != packet.GetId() {
+		return reassignId(packet, id)
+	}
+	return packet
+}
+
+// GetIdList returns the packet name => ID list of the version.
+func (version *Version) GetIdList() info.PacketIdList {
+	return version.base.GetIdList()
+}
+
+// GetHandlers returns all handlers registered for the given packet name.
+// Handlers of packets that also exist in the latest protocol are registered on the latest protocol.
+func (version *Version) GetHandlers(packet info.PacketName) [][]Handler {
+	if _, ok := version.Protocol.GetIdList()[packet]; ok {
+		return version.Protocol.GetHandlers(packet)
+	}
+	return version.base.GetHandlers(packet)
+}
+
+// GetHandlersById returns all handlers registered for the packet with the given ID of the version.
+// Handlers of packets unknown to the version, such as custom packets, are looked up on the latest protocol.
+func (version *Version) GetHandlersById(id int) [][]Handler {
+	if name, ok := version.names[id]; ok {
+		return version.GetHandlers(name)
+	}
+	return version.Protocol.GetHandlersById(id)
+}
+
+// RegisterHandler registers a new packet handler for the packet with the given name.
+// Handlers of packets that also exist in the latest protocol get registered on the latest protocol,
+// so that they handle the packets of all protocols.
+func (version *Version) RegisterHandler(packet info.PacketName, handler Handler) bool {
+	if _, ok := version.Protocol.GetIdList()[packet]; ok {
+		return version.Protocol.RegisterHandler(packet, handler)
+	}
+	return version.base.RegisterHandler(packet, handler)
+}
+
+// DeregisterPacketHandlers deregisters all packet handlers of the packet with the given name, on the given priority.
+func (version *Version) DeregisterPacketHandlers(packet info.PacketName, priority int) {
+	if _, ok := version.Protocol.GetIdList()[packet]; ok {
+		version.Protocol.DeregisterPacketHandlers(packet, priority)
+		return
+	}
+	version.base.DeregisterPacketHandlers(packet, priority)
+}
+
+// GetPackets returns a packet ID => packet function map containing all packets decoded by the version.
+func (version *Version) GetPackets() map[int]func() packets.IPacket {
+	return version.base.GetPackets()
+}
+
+// RegisterPacket registers a packet function with the given packet ID of the version.
+func (version *Version) RegisterPacket(packetId int, packetFunc func() packets.IPacket) {
+	version.base.RegisterPacket(packetId, packetFunc)
+}
+
+// GetPacket returns a packet with the given packet ID of the version.
+func (version *Version) GetPacket(packetId int) packets.IPacket {
+	return version.base.GetPacket(packetId)
+}
+
+// IsPacketRegistered checks if the version decodes packets with the given packet ID.
+func (version *Version) IsPacketRegistered(packetId int) bool {
+	return version.base.IsPacketRegistered(packetId)
+}
+
+// reassignId returns a raw packet with the given ID, holding the encoded payload of the packet.
+func reassignId(packet packets.IPacket, id int) packets.IPacket {
+	packet.ResetStream()
+	packet.EncodeHeader()
+	var headerLength = len(packet.GetBuffer())
+	packet.Encode()
+	return packets.NewRawPacket(id, append([]byte(nil), packet.GetBuffer()[headerLength:]...))
+}
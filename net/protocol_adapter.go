@@ -3,6 +3,7 @@ package net
 import (
 	"github.com/golang/geo/r3"
 	"github.com/google/uuid"
+	"github.com/irmine/gomine/items"
 	"github.com/irmine/gomine/net/packets/types"
 	"github.com/irmine/gomine/net/protocol"
 	"github.com/irmine/gomine/packs"
@@ -12,105 +13,211 @@ import (
 )
 
 func (session *MinecraftSession) SendAddEntity(entity protocol.AddEntityEntry) {
-	session.SendPacket(session.adapter.packetManager.GetAddEntity(entity))
+	session.SendPacket(session.GetProtocol().GetAddEntity(entity))
 }
 
 func (session *MinecraftSession) SendAddPlayer(uuid uuid.UUID, player protocol.AddPlayerEntry) {
-	session.SendPacket(session.adapter.packetManager.GetAddPlayer(uuid, player))
+	session.SendPacket(session.GetProtocol().GetAddPlayer(uuid, player))
 }
 
 func (session *MinecraftSession) SendChunkRadiusUpdated(radius int32) {
-	session.SendPacket(session.adapter.packetManager.GetChunkRadiusUpdated(radius))
+	session.SendPacket(session.GetProtocol().GetChunkRadiusUpdated(radius))
 }
 
-func (session *MinecraftSession) SendCraftingData() {
-	session.SendPacket(session.adapter.packetManager.GetCraftingData())
+func (session *MinecraftSession) SendCraftingData(recipes types.CraftingData) {
+	session.SendPacket(session.GetProtocol().GetCraftingData(recipes))
 }
 
 func (session *MinecraftSession) SendDisconnect(message string, hideDisconnect bool) {
-	session.SendPacket(session.adapter.packetManager.GetDisconnect(message, hideDisconnect))
+	session.SendPacket(session.GetProtocol().GetDisconnect(message, hideDisconnect))
 }
 
 func (session *MinecraftSession) SendFullChunkData(chunk *chunks.Chunk) {
-	session.SendPacket(session.adapter.packetManager.GetFullChunkData(chunk))
+	var chunkData = session.adapter.ChunkDataFunction(session.player.GetDimension(), chunk)
+	session.SendPacket(session.GetProtocol().GetFullChunkData(chunk, chunkData))
+	session.sendFakeBlocks(chunk)
 }
 
 func (session *MinecraftSession) SendMovePlayer(runtimeId uint64, position r3.Vector, rotation data.Rotation, mode byte, onGround bool, ridingRuntimeId uint64) {
-	session.SendPacket(session.adapter.packetManager.GetMovePlayer(runtimeId, position, rotation, mode, onGround, ridingRuntimeId))
+	session.SendPacket(session.GetProtocol().GetMovePlayer(runtimeId, position, rotation, mode, onGround, ridingRuntimeId))
 }
 
+// SendPlayerList sends a player list update, with the skins of added players converted for the session.
 func (session *MinecraftSession) SendPlayerList(listType byte, players map[string]protocol.PlayerListEntry) {
-	session.SendPacket(session.adapter.packetManager.GetPlayerList(listType, players))
+	session.SendPacket(session.GetProtocol().GetPlayerList(listType, session.convertSkins(listType, players)))
 }
 
 func (session *MinecraftSession) SendPlayStatus(status int32) {
-	session.SendPacket(session.adapter.packetManager.GetPlayStatus(status))
+	session.SendPacket(session.GetProtocol().GetPlayStatus(status))
 }
 
 func (session *MinecraftSession) SendRemoveEntity(uniqueId int64) {
-	session.SendPacket(session.adapter.packetManager.GetRemoveEntity(uniqueId))
+	session.SendPacket(session.GetProtocol().GetRemoveEntity(uniqueId))
 }
 
 func (session *MinecraftSession) SendResourcePackChunkData(packUUID string, chunkIndex int32, progress int64, data []byte) {
-	session.SendPacket(session.adapter.packetManager.GetResourcePackChunkData(packUUID, chunkIndex, progress, data))
+	session.SendPacket(session.GetProtocol().GetResourcePackChunkData(packUUID, chunkIndex, progress, data))
 }
 
 func (session *MinecraftSession) SendResourcePackDataInfo(pack packs.Pack) {
-	session.SendPacket(session.adapter.packetManager.GetResourcePackDataInfo(pack))
+	session.SendPacket(session.GetProtocol().GetResourcePackDataInfo(pack))
 }
 
 func (session *MinecraftSession) SendResourcePackInfo(mustAccept bool, resourcePacks *packs.Stack, behaviorPacks *packs.Stack) {
-	session.SendPacket(session.adapter.packetManager.GetResourcePackInfo(mustAccept, resourcePacks, behaviorPacks))
+	session.SendPacket(session.GetProtocol().GetResourcePackInfo(mustAccept, resourcePacks, behaviorPacks))
 }
 
 func (session *MinecraftSession) SendResourcePackStack(mustAccept bool, resourcePacks *packs.Stack, behaviorPacks *packs.Stack) {
-	session.SendPacket(session.adapter.packetManager.GetResourcePackStack(mustAccept, resourcePacks, behaviorPacks))
+	session.SendPacket(session.GetProtocol().GetResourcePackStack(mustAccept, resourcePacks, behaviorPacks))
 }
 
 func (session *MinecraftSession) SendServerHandshake(encryptionJwt string) {
-	session.SendPacket(session.adapter.packetManager.GetServerHandshake(encryptionJwt))
+	session.SendPacket(session.GetProtocol().GetServerHandshake(encryptionJwt))
 }
 
 func (session *MinecraftSession) SendSetEntityData(runtimeId uint64, data map[uint32][]interface{}) {
-	session.SendPacket(session.adapter.packetManager.GetSetEntityData(runtimeId, data))
+	session.SendPacket(session.GetProtocol().GetSetEntityData(runtimeId, data))
 }
 
 func (session *MinecraftSession) SendStartGame(player protocol.StartGameEntry, runtimeIdsTable []byte) {
-	session.SendPacket(session.adapter.packetManager.GetStartGame(player, runtimeIdsTable))
+	session.SendPacket(session.GetProtocol().GetStartGame(player, runtimeIdsTable))
 }
 
 func (session *MinecraftSession) SendText(text types.Text) {
-	session.SendPacket(session.adapter.packetManager.GetText(text))
+	session.SendPacket(session.GetProtocol().GetText(text))
 }
 
 func (session *MinecraftSession) Transfer(address string, port uint16) {
-	session.SendPacket(session.adapter.packetManager.GetTransfer(address, port))
+	session.SendPacket(session.GetProtocol().GetTransfer(address, port))
 }
 
 func (session *MinecraftSession) SendUpdateAttributes(runtimeId uint64, attributes data.AttributeMap) {
-	session.SendPacket(session.adapter.packetManager.GetUpdateAttributes(runtimeId, attributes))
+	session.SendPacket(session.GetProtocol().GetUpdateAttributes(runtimeId, attributes))
 }
 
 func (session *MinecraftSession) SendNetworkChunkPublisherUpdate(position blocks.Position, radius uint32) {
-	session.SendPacket(session.adapter.packetManager.GetNetworkChunkPublisherUpdatePacket(position, radius))
+	session.SendPacket(session.GetProtocol().GetNetworkChunkPublisherUpdatePacket(position, radius))
 }
 
 func (session *MinecraftSession) SendMoveEntity(runtimeId uint64, position r3.Vector, rot data.Rotation, flags byte, teleport bool) {
-	session.SendPacket(session.adapter.packetManager.GetMoveEntity(runtimeId, position, rot, flags, teleport))
+	session.SendPacket(session.GetProtocol().GetMoveEntity(runtimeId, position, rot, flags, teleport))
 }
 
 func (session *MinecraftSession) SendPlayerSkin(uuid2 uuid.UUID, skinId, geometryName, geometryData string, skinData, capeData []byte) {
-	session.SendPacket(session.adapter.packetManager.GetPlayerSkin(uuid2, skinId, geometryName, geometryData, skinData, capeData))
+	session.SendPacket(session.GetProtocol().GetPlayerSkin(uuid2, skinId, geometryName, geometryData, skinData, capeData))
 }
 
 func (session *MinecraftSession) SendPlayerAction(runtimeId uint64, action int32, position blocks.Position, face int32) {
-	session.SendPacket(session.adapter.packetManager.GetPlayerAction(runtimeId, action, position, face))
+	session.SendPacket(session.GetProtocol().GetPlayerAction(runtimeId, action, position, face))
 }
 
 func (session *MinecraftSession) SendAnimate(action int32, runtimeId uint64, float float32) {
-	session.SendPacket(session.adapter.packetManager.GetAnimate(action, runtimeId, float))
+	session.SendPacket(session.GetProtocol().GetAnimate(action, runtimeId, float))
 }
 
+// SendUpdateBlock sends a block update at the position, unless a fake block is set at the position.
 func (session *MinecraftSession) SendUpdateBlock(position blocks.Position, blockRuntimeId, dataLayerId uint32) {
-	session.SendPacket(session.adapter.packetManager.GetUpdateBlock(position, blockRuntimeId, dataLayerId))
-}
\ No newline at end of file
+	if session.IsFakeBlock(position) {
+		return
+	}
+	session.SendPacket(session.GetProtocol().GetUpdateBlock(position, blockRuntimeId, dataLayerId))
+}
+func (session *MinecraftSession) SendContainerOpen(windowId byte, containerType byte, position blocks.Position, entityUniqueId int64) {
+	session.SendPacket(session.GetProtocol().GetContainerOpen(windowId, containerType, position, entityUniqueId))
+}
+
+func (session *MinecraftSession) SendContainerClose(windowId byte) {
+	session.SendPacket(session.GetProtocol().GetContainerClose(windowId))
+}
+
+func (session *MinecraftSession) SendInventoryContent(windowId uint32, items []*items.Stack) {
+	session.SendPacket(session.GetProtocol().GetInventoryContent(windowId, items))
+}
+
+func (session *MinecraftSession) SendInventorySlot(windowId uint32, slot uint32, item *items.Stack) {
+	session.SendPacket(session.GetProtocol().GetInventorySlot(windowId, slot, item))
+}
+
+func (session *MinecraftSession) SendModalFormRequest(formId uint32, formData string) {
+	session.SendPacket(session.GetProtocol().GetModalFormRequest(formId, formData))
+}
+
+func (session *MinecraftSession) SendRemoveObjective(objectiveName string) {
+	session.SendPacket(session.GetProtocol().GetRemoveObjective(objectiveName))
+}
+
+func (session *MinecraftSession) SendSetDisplayObjective(displaySlot, objectiveName, displayName, criteriaName string, sortOrder int32) {
+	session.SendPacket(session.GetProtocol().GetSetDisplayObjective(displaySlot, objectiveName, displayName, criteriaName, sortOrder))
+}
+
+func (session *MinecraftSession) SendSetScore(actionType byte, entries []types.ScoreboardEntry) {
+	session.SendPacket(session.GetProtocol().GetSetScore(actionType, entries))
+}
+
+func (session *MinecraftSession) SendBossEvent(bossUniqueId int64, eventType uint32, playerUniqueId int64, title string, healthPercentage float32) {
+	session.SendPacket(session.GetProtocol().GetBossEvent(bossUniqueId, eventType, playerUniqueId, title, healthPercentage))
+}
+
+func (session *MinecraftSession) SendSpawnParticleEffect(position r3.Vector, particleName string) {
+	session.SendPacket(session.GetProtocol().GetSpawnParticleEffect(position, particleName))
+}
+
+func (session *MinecraftSession) SendSetPlayerGameType(gameMode int32) {
+	session.SendPacket(session.GetProtocol().GetSetPlayerGameType(gameMode))
+}
+
+func (session *MinecraftSession) SendSetTitle(titleType int32, text string, fadeIn, stay, fadeOut int32) {
+	session.SendPacket(session.GetProtocol().GetSetTitle(titleType, text, fadeIn, stay, fadeOut))
+}
+
+func (session *MinecraftSession) SendEntityEvent(runtimeId uint64, event byte, data int32) {
+	session.SendPacket(session.GetProtocol().GetEntityEvent(runtimeId, event, data))
+}
+
+func (session *MinecraftSession) SendRespawn(position r3.Vector) {
+	session.SendPacket(session.GetProtocol().GetRespawn(position))
+}
+
+func (session *MinecraftSession) SendAdventureSettings(flags, commandPermission, actionPermissions, permissionLevel uint32, entityUniqueId int64) {
+	session.SendPacket(session.GetProtocol().GetAdventureSettings(flags, commandPermission, actionPermissions, permissionLevel, entityUniqueId))
+}
+
+func (session *MinecraftSession) SendSetTime(time int32) {
+	session.SendPacket(session.GetProtocol().GetSetTime(time))
+}
+
+func (session *MinecraftSession) SendLevelEvent(eventId int32, position r3.Vector, data int32) {
+	session.SendPacket(session.GetProtocol().GetLevelEvent(eventId, position, data))
+}
+
+func (session *MinecraftSession) SendGameRulesChanged(gameRules map[string]types.GameRuleEntry) {
+	session.SendPacket(session.GetProtocol().GetGameRulesChanged(gameRules))
+}
+
+func (session *MinecraftSession) SendItemStackResponse(responses []types.ItemStackResponse) {
+	session.SendPacket(session.GetProtocol().GetItemStackResponse(responses))
+}
+
+func (session *MinecraftSession) SendSetEntityMotion(runtimeId uint64, motion r3.Vector) {
+	session.SendPacket(session.GetProtocol().GetSetEntityMotion(runtimeId, motion))
+}
+
+func (session *MinecraftSession) SendAddItemEntity(uniqueId int64, runtimeId uint64, item *items.Stack, position, motion r3.Vector) {
+	session.SendPacket(session.GetProtocol().GetAddItemEntity(uniqueId, runtimeId, item, position, motion))
+}
+
+func (session *MinecraftSession) SendTakeItemEntity(itemRuntimeId, playerRuntimeId uint64) {
+	session.SendPacket(session.GetProtocol().GetTakeItemEntity(itemRuntimeId, playerRuntimeId))
+}
+
+func (session *MinecraftSession) SendCommandOutputPacket(origin types.CommandOrigin, successCount uint32, messages []types.CommandOutputMessage) {
+	session.SendPacket(session.GetProtocol().GetCommandOutput(origin, successCount, messages))
+}
+
+func (session *MinecraftSession) SendUpdateSoftEnum(enumName string, values []string, updateType byte) {
+	session.SendPacket(session.GetProtocol().GetUpdateSoftEnum(enumName, values, updateType))
+}
+
+func (session *MinecraftSession) SendChangeDimension(dimension int32, position r3.Vector, respawn bool) {
+	session.SendPacket(session.GetProtocol().GetChangeDimension(dimension, position, respawn))
+}
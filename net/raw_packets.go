@@ -0,0 +1,55 @@
+package net
+
+import (
+	"errors"
+
+	"github.com/irmine/gomine/net/info"
+	"github.com/irmine/gomine/net/packets"
+)
+
+// MaxRawPayloadSize is the maximum size in bytes of the payload of raw packets.
+const MaxRawPayloadSize = 1 << 21
+
+var (
+	// NotLoggedIn gets returned when sending a raw packet
+	// to a session that has not finished logging in.
+	NotLoggedIn = errors.New("session has not logged in")
+	// ReservedPacketId gets returned when sending a raw packet with an ID
+	// that is out of range or used for the login sequence.
+	ReservedPacketId = errors.New("packet ID is reserved")
+	// PayloadTooLarge gets returned when sending a raw packet
+	// with a payload larger than MaxRawPayloadSize.
+	PayloadTooLarge = errors.New("raw packet payload is too large")
+)
+
+// reservedPackets are packets handling the login sequence and encryption,
+// which may not be sent as raw packets.
+var reservedPackets = []info.PacketName{
+	info.LoginPacket,
+	info.PlayStatusPacket,
+	info.ServerHandshakePacket,
+	info.ClientHandshakePacket,
+}
+
+// SendRawPacket sends a packet with the given ID and raw payload to the session.
+// The payload is sent as is, so it should be encoded in the format of the protocol of the session.
+// Raw packets can only be sent once the session has logged in,
+// and may not use the IDs of packets used to log in.
+func (session *MinecraftSession) SendRawPacket(packetId int, payload []byte) error {
+	if session.protocol == nil || session.player == nil {
+		return NotLoggedIn
+	}
+	if packetId <= 0 || packetId > 0xff {
+		return ReservedPacketId
+	}
+	for _, name := range reservedPackets {
+		if id, ok := session.GetProtocol().GetIdList()[name]; ok && id == packetId {
+			return ReservedPacketId
+		}
+	}
+	if len(payload) > MaxRawPayloadSize {
+		return PayloadTooLarge
+	}
+	session.SendPacket(packets.NewRawPacket(packetId, payload))
+	return nil
+}
@@ -0,0 +1,72 @@
+package net
+
+import (
+	"github.com/irmine/gomine/net/packets/data"
+	"github.com/irmine/gomine/net/protocol"
+	"github.com/irmine/gomine/skins"
+)
+
+// GetSkin returns the skin of the player list entry.
+func GetSkin(entry protocol.PlayerListEntry) skins.Skin {
+	return skins.Skin{
+		Id:           entry.GetSkinId(),
+		Data:         entry.GetSkinData(),
+		CapeData:     entry.GetCapeData(),
+		GeometryName: entry.GetGeometryName(),
+		GeometryData: entry.GetGeometryData(),
+	}
+}
+
+// skinnedEntry is a player list entry with the skin replaced by a converted skin.
+type skinnedEntry struct {
+	protocol.PlayerListEntry
+	skin skins.Skin
+}
+
+func (entry skinnedEntry) GetSkinId() string {
+	return entry.skin.Id
+}
+
+func (entry skinnedEntry) GetSkinData() []byte {
+	return entry.skin.Data
+}
+
+func (entry skinnedEntry) GetCapeData() []byte {
+	return entry.skin.CapeData
+}
+
+func (entry skinnedEntry) GetGeometryName() string {
+	return entry.skin.GeometryName
+}
+
+func (entry skinnedEntry) GetGeometryData() string {
+	return entry.skin.GeometryData
+}
+
+// convertSkins returns the player list entries with their skins converted for the session.
+// Entries are only replaced if their skin was converted, and removals have no skins to convert.
+func (session *MinecraftSession) convertSkins(listType byte, entries map[string]protocol.PlayerListEntry) map[string]protocol.PlayerListEntry {
+	if listType != data.ListTypeAdd {
+		return entries
+	}
+	var converted map[string]protocol.PlayerListEntry
+	for name, entry := range entries {
+		var skin = GetSkin(entry)
+		var sent = session.adapter.SkinFunction(skin, session)
+		// Converted skins differ in texture size, geometry or ID from the original skin.
+		if len(sent.Data) == len(skin.Data) && sent.Id == skin.Id && sent.GeometryName == skin.GeometryName {
+			continue
+		}
+		if converted == nil {
+			converted = make(map[string]protocol.PlayerListEntry, len(entries))
+			for name, entry := range entries {
+				converted[name] = entry
+			}
+		}
+		converted[name] = skinnedEntry{entry, sent}
+	}
+	if converted == nil {
+		return entries
+	}
+	return converted
+}
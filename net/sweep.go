@@ -0,0 +1,93 @@
+package net
+
+import (
+	"sync"
+	"time"
+
+	"github.com/irmine/goraklib/server"
+)
+
+// closedSessions holds the RakNet sessions that were closed,
+// so that Minecraft sessions still referring to them can be found and removed.
+type closedSessions struct {
+	mutex    sync.RWMutex
+	sessions map[*server.Session]time.Time
+}
+
+// MarkClosed marks the RakNet session as closed.
+// Packets of closed sessions are no longer handled,
+// and Minecraft sessions of closed sessions get removed by the next sweep.
+func (adapter *NetworkAdapter) MarkClosed(session *server.Session) {
+	adapter.closed.mutex.Lock()
+	adapter.closed.sessions[session] = time.Now()
+	adapter.closed.mutex.Unlock()
+}
+
+// IsClosed checks if the RakNet session was marked as closed.
+func (adapter *NetworkAdapter) IsClosed(session *server.Session) bool {
+	adapter.closed.mutex.RLock()
+	var _, ok = adapter.closed.sessions[session]
+	adapter.closed.mutex.RUnlock()
+	return ok
+}
+
+// ForgetClosed forgets all RakNet sessions closed longer than the given duration ago.
+// The amount of closed sessions still remembered is returned.
+func (adapter *NetworkAdapter) ForgetClosed(after time.Duration) int {
+	adapter.closed.mutex.Lock()
+	defer adapter.closed.mutex.Unlock()
+	for session, closedAt := range adapter.closed.sessions {
+		if time.Since(closedAt) > after {
+			delete(adapter.closed.sessions, session)
+		}
+	}
+	return len(adapter.closed.sessions)
+}
+
+// SweepResult holds the amount of stale sessions and orphaned entries removed by a sweep.
+type SweepResult struct {
+	// Stale are the sessions removed because their RakNet session was closed.
+	Stale []*MinecraftSession
+	// Orphaned is the amount of UUID, XUID and RakNet session entries removed
+	// that did not refer to a session found by its name.
+	Orphaned int
+}
+
+// Sweep removes all sessions of which the RakNet session was closed,
+// without the session being removed on disconnect.
+// Entries referring to sessions that can no longer be found by their name get removed too,
+// so that the lookups of the manager can not drift from the sessions online.
+func (manager *SessionManager) Sweep(isClosed func(session *server.Session) bool) SweepResult {
+	var result SweepResult
+	var stale = make(map[*MinecraftSession]bool)
+	manager.update(func(maps *sessionMaps) {
+		for name, session := range maps.nameMap {
+			if isClosed(session.GetSession()) {
+				delete(maps.nameMap, name)
+				stale[session] = true
+				result.Stale = append(result.Stale, session)
+			}
+		}
+		// remove deletes the entry if its session is not online,
+		// counting it as orphaned unless it belonged to a stale session.
+		var remove = func(session *MinecraftSession, drop func()) {
+			if named, ok := maps.nameMap[session.GetName()]; ok && named == session {
+				return
+			}
+			drop()
+			if !stale[session] {
+				result.Orphaned++
+			}
+		}
+		for id, session := range maps.uuidMap {
+			remove(session, func() { delete(maps.uuidMap, id) })
+		}
+		for xuid, session := range maps.xuidMap {
+			remove(session, func() { delete(maps.xuidMap, xuid) })
+		}
+		for key, session := range maps.sessionMap {
+			remove(session, func() { delete(maps.sessionMap, key) })
+		}
+	})
+	return result
+}
@@ -0,0 +1,53 @@
+package net
+
+import (
+	"sync"
+	"time"
+
+	"github.com/irmine/gomine/events"
+	"github.com/irmine/gomine/net/packets"
+	"github.com/irmine/gomine/text"
+)
+
+const UnknownPacketEventName events.Name = "UnknownPacketEvent"
+
+// UnknownPacketEvent gets called when a session sends a packet
+// with an ID that is not registered in its protocol.
+type UnknownPacketEvent struct {
+	Session *MinecraftSession
+	Packet  *packets.UnknownPacket
+}
+
+// GetName returns the name of the event.
+func (event *UnknownPacketEvent) GetName() events.Name {
+	return UnknownPacketEventName
+}
+
+// unknownPacketLog rate limits the logging of unknown packets,
+// logging every unknown packet ID at most once per interval.
+type unknownPacketLog struct {
+	mutex  sync.Mutex
+	logged map[int]time.Time
+}
+
+// log logs the unknown packet at debug level,
+// unless a packet with the same ID was logged within the interval.
+func (log *unknownPacketLog) log(packet *packets.UnknownPacket, interval time.Duration) {
+	log.mutex.Lock()
+	var last, ok = log.logged[packet.GetId()]
+	var now = time.Now()
+	if ok && now.Sub(last) < interval {
+		log.mutex.Unlock()
+		return
+	}
+	log.logged[packet.GetId()] = now
+	log.mutex.Unlock()
+	text.DefaultLogger.Debug("Unknown Minecraft packet with ID:", packet.GetId(), "and payload length:", len(packet.Payload))
+}
+
+// handleUnknownPacket logs the unknown packet sent by the session,
+// and passes it on to the unknown packet function of the adapter.
+func (adapter *NetworkAdapter) handleUnknownPacket(session *MinecraftSession, packet *packets.UnknownPacket) {
+	adapter.unknownLog.log(packet, adapter.UnknownPacketLogInterval)
+	adapter.UnknownPacketFunction(packet, session)
+}
@@ -0,0 +1,26 @@
+package net
+
+import (
+	"github.com/irmine/gomine/events"
+)
+
+const UnsupportedProtocolEventName events.Name = "UnsupportedProtocolEvent"
+
+// UnsupportedProtocolEvent gets called when a client tries to join with a protocol the server does not support,
+// right before it gets disconnected. Plugins may use it to keep track of the game versions players try to join with.
+type UnsupportedProtocolEvent struct {
+	Session     *MinecraftSession
+	Username    string
+	Protocol    int32
+	GameVersion string
+	// OutdatedClient is true if the protocol is older than the oldest supported protocol,
+	// and false if the server is outdated instead.
+	OutdatedClient bool
+	// Message is the translated message shown on the disconnect screen of the client, which may be changed.
+	Message string
+}
+
+// GetName returns the name of the event.
+func (event *UnsupportedProtocolEvent) GetName() events.Name {
+	return UnsupportedProtocolEventName
+}
@@ -0,0 +1,77 @@
+package net
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/irmine/worlds/entities"
+)
+
+// UpdateCategory is a category of updates a session may hide,
+// such as other players or particles. Hidden categories are not sent to the session,
+// which helps slower clients and allows lobbies to let players hide others.
+type UpdateCategory string
+
+const (
+	// CategoryPlayers are all other players.
+	CategoryPlayers UpdateCategory = "players"
+	// CategoryPets are the pets of other players.
+	CategoryPets UpdateCategory = "pets"
+	// CategoryParticles are particles spawned by cosmetics.
+	CategoryParticles UpdateCategory = "particles"
+	// CategoryMobs are all mobs spawned by the server.
+	CategoryMobs UpdateCategory = "mobs"
+)
+
+// UpdateCategories contains all update categories.
+var UpdateCategories = []UpdateCategory{CategoryPlayers, CategoryPets, CategoryParticles, CategoryMobs}
+
+// visibility keeps track of the update categories hidden from a session.
+type visibility struct {
+	mutex  sync.RWMutex
+	hidden map[UpdateCategory]bool
+}
+
+// SetHidden hides or shows the update category to the session.
+// Only updates sent after the change are affected, so entities already spawned
+// to the session need to be despawned or spawned by the caller.
+func (session *MinecraftSession) SetHidden(category UpdateCategory, hidden bool) {
+	session.visibility.mutex.Lock()
+	defer session.visibility.mutex.Unlock()
+	if hidden {
+		session.visibility.hidden[category] = true
+	} else {
+		delete(session.visibility.hidden, category)
+	}
+}
+
+// IsHidden checks if the update category is hidden from the session.
+func (session *MinecraftSession) IsHidden(category UpdateCategory) bool {
+	session.visibility.mutex.RLock()
+	defer session.visibility.mutex.RUnlock()
+	return session.visibility.hidden[category]
+}
+
+// GetHiddenCategories returns all update categories hidden from the session, sorted by name.
+func (session *MinecraftSession) GetHiddenCategories() []UpdateCategory {
+	session.visibility.mutex.RLock()
+	var categories = make([]UpdateCategory, 0, len(session.visibility.hidden))
+	for category := range session.visibility.hidden {
+		categories = append(categories, category)
+	}
+	session.visibility.mutex.RUnlock()
+	sort.Slice(categories, func(i, j int) bool {
+		return categories[i] < categories[j]
+	})
+	return categories
+}
+
+// IsViewing checks if the session is a viewer of the entity.
+func (session *MinecraftSession) IsViewing(entity *entities.Entity) bool {
+	for _, viewer := range entity.GetViewers() {
+		if viewer, ok := viewer.(*MinecraftSession); ok && viewer == session {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,259 @@
+package network
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"sync"
+	"time"
+)
+
+// NotConnected gets returned when a message is published while the bridge is not connected to a hub.
+var NotConnected = errors.New("not connected to a network hub")
+
+// Bridge connects a server to the hub of a network, exchanging the status of every server,
+// chat messages and commands with the other servers in the network.
+// Received messages are queued and handled on Tick, so the functions of the bridge
+// are called on the server tick and may safely modify server state.
+// Lost connections are restored on Tick every reconnect interval.
+type Bridge struct {
+	// ChatFunction gets called with the name of the server and the message for every chat message received.
+	ChatFunction func(server string, message string)
+	// CommandFunction gets called with the name of the server and the command for every command sent to this server.
+	CommandFunction func(server string, command string)
+	// ReconnectInterval is the interval at which connecting to the hub is retried after the connection was lost.
+	ReconnectInterval time.Duration
+	// StatusTimeout is the duration after which servers that did not send their status are removed.
+	StatusTimeout time.Duration
+
+	name        string
+	secret      string
+	mutex       sync.Mutex
+	ctx         context.Context
+	address     string
+	conn        net.Conn
+	received    []Message
+	servers     map[string]ServerStatus
+	lastAttempt time.Time
+	connecting  bool
+	closed      bool
+}
+
+// NewBridge returns a new bridge for the server with the given name, using the secret of the network.
+func NewBridge(name string, secret string) *Bridge {
+	return &Bridge{
+		ChatFunction:      func(string, string) {},
+		CommandFunction:   func(string, string) {},
+		ReconnectInterval: time.Second * 5,
+		StatusTimeout:     time.Second * 30,
+		name:              name,
+		secret:            secret,
+		ctx:               context.Background(),
+		servers:           make(map[string]ServerStatus),
+	}
+}
+
+// GetName returns the name of the server in the network.
+func (bridge *Bridge) GetName() string {
+	return bridge.name
+}
+
+// Connect connects the bridge to the hub at the given TCP address.
+// The address is remembered, so the bridge reconnects to it if the connection is lost.
+// Connecting is aborted once the context is done, after which the bridge no longer reconnects.
+// EmptySecret is returned if the bridge has no secret.
+func (bridge *Bridge) Connect(ctx context.Context, address string) error {
+	if bridge.secret == "" {
+		return EmptySecret
+	}
+	bridge.mutex.Lock()
+	bridge.ctx = ctx
+	bridge.address = address
+	bridge.closed = false
+	bridge.lastAttempt = time.Now()
+	bridge.mutex.Unlock()
+
+	var dialer = net.Dialer{Timeout: time.Second * 5}
+	var conn, err = dialer.DialContext(ctx, "tcp", address)
+	if err != nil {
+		return err
+	}
+	var hello, _ = json.Marshal(Message{Type: MessageHello, Server: bridge.name, Secret: bridge.secret})
+	if _, err := conn.Write(append(hello, '\n')); err != nil {
+		conn.Close()
+		return err
+	}
+	bridge.mutex.Lock()
+	if bridge.closed {
+		bridge.mutex.Unlock()
+		conn.Close()
+		return NotConnected
+	}
+	if bridge.conn != nil {
+		bridge.conn.Close()
+	}
+	bridge.conn = conn
+	bridge.mutex.Unlock()
+
+	go bridge.read(conn)
+	return nil
+}
+
+// IsConnected checks if the bridge is connected to a hub.
+func (bridge *Bridge) IsConnected() bool {
+	bridge.mutex.Lock()
+	defer bridge.mutex.Unlock()
+	return bridge.conn != nil
+}
+
+// Close disconnects the bridge from the hub, after which it no longer reconnects.
+func (bridge *Bridge) Close() error {
+	bridge.mutex.Lock()
+	defer bridge.mutex.Unlock()
+	bridge.closed = true
+	if bridge.conn == nil {
+		return nil
+	}
+	var err = bridge.conn.Close()
+	bridge.conn = nil
+	return err
+}
+
+// PublishStatus sends the player counts of the server to all other servers,
+// along with the address and port players can be transferred to.
+func (bridge *Bridge) PublishStatus(onlinePlayers, maximumPlayers int, address string, port uint16) error {
+	return bridge.publish(Message{Type: MessageStatus, OnlinePlayers: onlinePlayers, MaximumPlayers: maximumPlayers, Address: address, Port: port})
+}
+
+// PublishChat sends a chat message to all other servers.
+func (bridge *Bridge) PublishChat(message string) error {
+	return bridge.publish(Message{Type: MessageChat, Text: message})
+}
+
+// SendCommand sends a command to the server with the given name,
+// or to all other servers if the target is empty.
+func (bridge *Bridge) SendCommand(target string, command string) error {
+	return bridge.publish(Message{Type: MessageCommand, Target: target, Text: command})
+}
+
+// GetServers returns the last status of all other servers in the network.
+func (bridge *Bridge) GetServers() map[string]ServerStatus {
+	bridge.mutex.Lock()
+	defer bridge.mutex.Unlock()
+	var servers = make(map[string]ServerStatus, len(bridge.servers))
+	for name, status := range bridge.servers {
+		servers[name] = status
+	}
+	return servers
+}
+
+// GetServer returns the last status of the server in the network with the given name.
+// A bool is returned indicating if a status of the server was received.
+func (bridge *Bridge) GetServer(name string) (ServerStatus, bool) {
+	bridge.mutex.Lock()
+	defer bridge.mutex.Unlock()
+	var status, ok = bridge.servers[name]
+	return status, ok
+}
+
+// GetNetworkPlayerCount returns the amount of players online in the whole network,
+// using the given amount of players online on this server.
+func (bridge *Bridge) GetNetworkPlayerCount(onlinePlayers int) int {
+	for _, status := range bridge.GetServers() {
+		onlinePlayers += status.OnlinePlayers
+	}
+	return onlinePlayers
+}
+
+// Tick handles all received messages, removes servers of which the status timed out,
+// and reconnects to the hub if the connection was lost.
+func (bridge *Bridge) Tick() {
+	var now = time.Now()
+	bridge.mutex.Lock()
+	var received = bridge.received
+	bridge.received = nil
+	for name, status := range bridge.servers {
+		if now.Sub(status.LastUpdate) > bridge.StatusTimeout {
+			delete(bridge.servers, name)
+		}
+	}
+	var reconnect = bridge.conn == nil && !bridge.closed && !bridge.connecting && bridge.address != "" && bridge.ctx.Err() == nil && now.Sub(bridge.lastAttempt) >= bridge.ReconnectInterval
+	if reconnect {
+		bridge.connecting = true
+	}
+	var ctx, address = bridge.ctx, bridge.address
+	bridge.mutex.Unlock()
+
+	if reconnect {
+		go func() {
+			bridge.Connect(ctx, address)
+			bridge.mutex.Lock()
+			bridge.connecting = false
+			bridge.mutex.Unlock()
+		}()
+	}
+
+	for _, message := range received {
+		switch message.Type {
+		case MessageChat:
+			bridge.ChatFunction(message.Server, message.Text)
+		case MessageCommand:
+			if message.Target == "" || message.Target == bridge.name {
+				bridge.CommandFunction(message.Server, message.Text)
+			}
+		}
+	}
+}
+
+// publish sends the message to the hub with the name of the server.
+func (bridge *Bridge) publish(message Message) error {
+	message.Server = bridge.name
+	var encoded, err = json.Marshal(message)
+	if err != nil {
+		return err
+	}
+	bridge.mutex.Lock()
+	defer bridge.mutex.Unlock()
+	if bridge.conn == nil {
+		return NotConnected
+	}
+	bridge.conn.SetWriteDeadline(time.Now().Add(time.Second))
+	_, err = bridge.conn.Write(append(encoded, '\n'))
+	return err
+}
+
+// read reads messages from the connection until it is closed.
+// Statuses are stored immediately, while other messages are queued for the next tick.
+func (bridge *Bridge) read(conn net.Conn) {
+	var scanner = bufio.NewScanner(conn)
+	scanner.Buffer(make([]byte, 4096), MaxMessageSize)
+	for scanner.Scan() {
+		var message Message
+		if err := json.Unmarshal(scanner.Bytes(), &message); err != nil || message.Server == bridge.name {
+			continue
+		}
+		bridge.mutex.Lock()
+		if message.Type == MessageStatus {
+			bridge.servers[message.Server] = ServerStatus{
+				Name:           message.Server,
+				OnlinePlayers:  message.OnlinePlayers,
+				MaximumPlayers: message.MaximumPlayers,
+				Address:        message.Address,
+				Port:           message.Port,
+				LastUpdate:     time.Now(),
+			}
+		} else {
+			bridge.received = append(bridge.received, message)
+		}
+		bridge.mutex.Unlock()
+	}
+
+	bridge.mutex.Lock()
+	if bridge.conn == conn {
+		conn.Close()
+		bridge.conn = nil
+	}
+	bridge.mutex.Unlock()
+}
@@ -0,0 +1,94 @@
+package network
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestBridge(t *testing.T) {
+	var hub = NewHub("secret")
+	if err := hub.Listen(context.Background(), "127.0.0.1:0"); err != nil {
+		t.Fatal(err)
+	}
+	defer hub.Close()
+	var address = hub.GetAddress().String()
+
+	var lobby, survival, intruder = NewBridge("lobby", "secret"), NewBridge("survival", "secret"), NewBridge("intruder", "wrong")
+	for _, bridge := range []*Bridge{lobby, survival, intruder} {
+		if err := bridge.Connect(context.Background(), address); err != nil {
+			t.Fatal(err)
+		}
+		defer bridge.Close()
+	}
+	var chat, commands []string
+	survival.ChatFunction = func(server string, message string) {
+		chat = append(chat, server+": "+message)
+	}
+	survival.CommandFunction = func(server string, command string) {
+		commands = append(commands, command)
+	}
+	intruder.ChatFunction = func(server string, message string) {
+		t.Error("bridge with a wrong secret received a message")
+	}
+
+	// Messages published before the hub authenticated the connection could be lost,
+	// so publish until the status of the lobby arrived.
+	for i := 0; i < 100; i++ {
+		lobby.PublishStatus(5, 20, "127.0.0.1", 19133)
+		if _, ok := survival.GetServer("lobby"); ok {
+			break
+		}
+		time.Sleep(time.Millisecond * 10)
+	}
+	if status, ok := survival.GetServer("lobby"); !ok || status.OnlinePlayers != 5 || status.Port != 19133 {
+		t.Fatal("status of the lobby was not received:", status)
+	}
+	if count := survival.GetNetworkPlayerCount(2); count != 7 {
+		t.Error("unexpected network player count:", count)
+	}
+
+	lobby.PublishChat("hello")
+	lobby.SendCommand("survival", "say hi")
+	lobby.SendCommand("other", "stop")
+	for i := 0; i < 100 && (len(chat) == 0 || len(commands) == 0); i++ {
+		time.Sleep(time.Millisecond * 10)
+		survival.Tick()
+		intruder.Tick()
+	}
+	if len(chat) != 1 || chat[0] != "lobby: hello" {
+		t.Error("chat message was not received:", chat)
+	}
+	if len(commands) != 1 || commands[0] != "say hi" {
+		t.Error("only the command targeted at the server should be received:", commands)
+	}
+}
+
+func TestHubContext(t *testing.T) {
+	var hub = NewHub("secret")
+	var ctx, cancel = context.WithCancel(context.Background())
+	if err := hub.Listen(ctx, "127.0.0.1:0"); err != nil {
+		t.Fatal(err)
+	}
+	cancel()
+	for i := 0; i < 100 && hub.GetAddress() != nil; i++ {
+		time.Sleep(time.Millisecond)
+	}
+	if hub.GetAddress() != nil {
+		t.Error("hub is still listening after the context was cancelled")
+	}
+
+	var bridge = NewBridge("lobby", "secret")
+	if err := bridge.Connect(ctx, "127.0.0.1:1"); err == nil {
+		t.Error("bridge connected with a cancelled context")
+	}
+}
+
+func TestEmptySecret(t *testing.T) {
+	if err := NewHub("").Listen(context.Background(), "127.0.0.1:0"); err != EmptySecret {
+		t.Error("hub without a secret listened:", err)
+	}
+	if err := NewBridge("lobby", "").Connect(context.Background(), "127.0.0.1:1"); err != EmptySecret {
+		t.Error("bridge without a secret connected:", err)
+	}
+}
@@ -0,0 +1,144 @@
+package network
+
+import (
+	"bufio"
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"net"
+	"sync"
+	"time"
+)
+
+// InvalidSecret gets returned when a bridge connects to a hub with a different secret.
+var InvalidSecret = errors.New("invalid network secret")
+
+// EmptySecret gets returned when a hub listens or a bridge connects without a network secret,
+// which would let anyone able to reach the hub send commands to the servers of the network.
+var EmptySecret = errors.New("network secret must not be empty")
+
+// MaxMessageSize is the maximum size of a single encoded message.
+const MaxMessageSize = 64 * 1024
+
+// Hub relays messages between the servers of a network over plain TCP.
+// One server of the network hosts the hub, after which all servers,
+// including the one hosting it, connect to it with a bridge.
+// Connections must send a hello message with the secret of the network first,
+// and are closed if the secret is wrong.
+// The secret and all messages are sent unencrypted, so hubs should only be reachable
+// from a trusted network, or be connected to through a VPN or an SSH tunnel.
+type Hub struct {
+	secret   string
+	mutex    sync.Mutex
+	listener net.Listener
+	conns    map[net.Conn]bool
+}
+
+// NewHub returns a new hub only accepting bridges with the given secret.
+func NewHub(secret string) *Hub {
+	return &Hub{secret: secret, conns: make(map[net.Conn]bool)}
+}
+
+// Listen starts accepting connections on the given TCP address.
+// Connections are handled on separate goroutines until the hub is closed,
+// which happens automatically once the context is done.
+// EmptySecret is returned if the hub has no secret.
+func (hub *Hub) Listen(ctx context.Context, address string) error {
+	if hub.secret == "" {
+		return EmptySecret
+	}
+	var listener, err = net.Listen("tcp", address)
+	if err != nil {
+		return err
+	}
+	hub.mutex.Lock()
+	hub.listener = listener
+	hub.mutex.Unlock()
+	context.AfterFunc(ctx, func() {
+		hub.mutex.Lock()
+		var current = hub.listener == listener
+		hub.mutex.Unlock()
+		if current {
+			hub.Close()
+		}
+	})
+	go func() {
+		for {
+			var conn, err = listener.Accept()
+			if err != nil {
+				return
+			}
+			go hub.handle(conn)
+		}
+	}()
+	return nil
+}
+
+// GetAddress returns the address the hub listens on, or nil if it is not listening.
+func (hub *Hub) GetAddress() net.Addr {
+	hub.mutex.Lock()
+	defer hub.mutex.Unlock()
+	if hub.listener == nil {
+		return nil
+	}
+	return hub.listener.Addr()
+}
+
+// Close stops accepting connections and closes all connections.
+func (hub *Hub) Close() error {
+	hub.mutex.Lock()
+	defer hub.mutex.Unlock()
+	for conn := range hub.conns {
+		conn.Close()
+		delete(hub.conns, conn)
+	}
+	if hub.listener == nil {
+		return nil
+	}
+	var err = hub.listener.Close()
+	hub.listener = nil
+	return err
+}
+
+// handle authenticates the connection and relays all messages it sends to all other connections.
+func (hub *Hub) handle(conn net.Conn) {
+	defer conn.Close()
+	var scanner = bufio.NewScanner(conn)
+	scanner.Buffer(make([]byte, 4096), MaxMessageSize)
+
+	conn.SetReadDeadline(time.Now().Add(time.Second * 10))
+	if !scanner.Scan() {
+		return
+	}
+	var hello Message
+	if err := json.Unmarshal(scanner.Bytes(), &hello); err != nil || hello.Type != MessageHello || subtle.ConstantTimeCompare([]byte(hello.Secret), []byte(hub.secret)) != 1 {
+		return
+	}
+	conn.SetReadDeadline(time.Time{})
+
+	hub.mutex.Lock()
+	if hub.listener == nil {
+		hub.mutex.Unlock()
+		return
+	}
+	hub.conns[conn] = true
+	hub.mutex.Unlock()
+
+	for scanner.Scan() {
+		var line = append(scanner.Bytes(), '\n')
+		hub.mutex.Lock()
+		for other := range hub.conns {
+			if other != conn {
+				// Slow connections are skipped, rather than blocking the whole network.
+				other.SetWriteDeadline(time.Now().Add(time.Second))
+				other.Write(line)
+			}
+		}
+		hub.mutex.Unlock()
+	}
+
+	hub.mutex.Lock()
+	delete(hub.conns, conn)
+	hub.mutex.Unlock()
+}
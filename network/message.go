@@ -0,0 +1,44 @@
+package network
+
+import (
+	"time"
+)
+
+// Types of messages exchanged between servers.
+const (
+	MessageHello   = "hello"
+	MessageStatus  = "status"
+	MessageChat    = "chat"
+	MessageCommand = "command"
+)
+
+// Message is a message exchanged between the servers of a network.
+// Messages are encoded as JSON, one message per line.
+type Message struct {
+	Type string `json:"type"`
+	// Server is the name of the server that sent the message.
+	Server string `json:"server"`
+	// Secret is the secret of the network, which is only sent in hello messages.
+	Secret string `json:"secret,omitempty"`
+	// Target is the name of the server a command is sent to.
+	// Commands without a target are executed by all servers.
+	Target string `json:"target,omitempty"`
+	// Text is the chat message or the command of the message.
+	Text string `json:"text,omitempty"`
+
+	OnlinePlayers  int    `json:"online,omitempty"`
+	MaximumPlayers int    `json:"max,omitempty"`
+	Address        string `json:"address,omitempty"`
+	Port           uint16 `json:"port,omitempty"`
+}
+
+// ServerStatus is the last status received from a server in the network.
+// The address and port are those players can be transferred to.
+type ServerStatus struct {
+	Name           string
+	OnlinePlayers  int
+	MaximumPlayers int
+	Address        string
+	Port           uint16
+	LastUpdate     time.Time
+}
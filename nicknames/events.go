@@ -0,0 +1,24 @@
+package nicknames
+
+import (
+	"github.com/irmine/gomine/events"
+	"github.com/irmine/gomine/net"
+)
+
+const ChangeEventName events.Name = "PlayerNicknameChangeEvent"
+
+// ChangeEvent gets called when a player changes its nickname, after the nickname was validated.
+// Cancelling the event keeps the current nickname of the player.
+type ChangeEvent struct {
+	events.Cancellable
+	Session *net.MinecraftSession
+	// Previous is the current nickname of the player, or an empty string if it has none.
+	Previous string
+	// Nickname is the new nickname, or an empty string if the nickname is being removed.
+	Nickname string
+}
+
+// GetName returns the name of the event.
+func (event *ChangeEvent) GetName() events.Name {
+	return ChangeEventName
+}
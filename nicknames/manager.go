@@ -0,0 +1,268 @@
+package nicknames
+
+import (
+	"errors"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/irmine/gomine/chat"
+	"github.com/irmine/gomine/events"
+	"github.com/irmine/gomine/net"
+	"github.com/irmine/gomine/players"
+	"github.com/irmine/gomine/text"
+)
+
+const (
+	// ColorPermission is the permission players need to use formatting codes in their nickname.
+	// Formatting codes are stripped from nicknames of players without it.
+	ColorPermission = "gomine.nick.color"
+	// RevealPermission is the permission players need to see the real names of nicked players in chat.
+	RevealPermission = "gomine.nick.reveal"
+
+	// MinimumLength and MaximumLength are the minimum and maximum length of nicknames,
+	// not counting formatting codes.
+	MinimumLength = 3
+	MaximumLength = 16
+)
+
+var (
+	// InvalidNickname gets returned when a nickname is too short or long,
+	// or holds other characters than letters, digits, underscores and spaces.
+	InvalidNickname = errors.New("nicknames must be 3 to 16 letters, digits, underscores or spaces")
+	// NicknameTaken gets returned when a nickname is the name or nickname of another player.
+	NicknameTaken = errors.New("nickname is already taken")
+	// Cancelled gets returned when the nickname change event was cancelled.
+	Cancelled = errors.New("nickname change was cancelled")
+)
+
+// Manager manages the nicknames of players, which are shown in-game instead of their names.
+// Nicknames are unique, and never equal the name of another player.
+type Manager struct {
+	// RefreshFunction gets called with a session every time its display name changed,
+	// so that its player list entry and name tag can be updated for all viewers.
+	RefreshFunction func(session *net.MinecraftSession)
+
+	sessionManager *net.SessionManager
+	storage        players.DataStorage
+	eventManager   *events.Manager
+
+	mutex sync.RWMutex
+	// owners is a nickname => name map of the owner of every nickname.
+	// Both are in lower case, and nicknames are stripped of formatting codes.
+	owners map[string]string
+	// names holds the lower case names of all known players, including those online.
+	names map[string]bool
+}
+
+// NewManager returns a new nickname manager persisting nicknames in the data storage.
+// The real names of nicked players are shown in chat to receivers with the reveal permission.
+func NewManager(sessionManager *net.SessionManager, storage players.DataStorage, eventManager *events.Manager) *Manager {
+	var manager = &Manager{RefreshFunction: func(*net.MinecraftSession) {}, sessionManager: sessionManager, storage: storage, eventManager: eventManager, owners: make(map[string]string), names: make(map[string]bool)}
+	eventManager.Register(chat.ReceiveEventName, events.NewHandler(manager.revealRealName))
+	return manager
+}
+
+// Load loads the names and nicknames of all players from the data storage,
+// so that nicknames can be checked against players that are offline.
+func (manager *Manager) Load() error {
+	var all, err = manager.storage.LoadAll()
+	if err != nil {
+		return err
+	}
+	manager.mutex.Lock()
+	defer manager.mutex.Unlock()
+	for _, data := range all {
+		var name = strings.ToLower(data.Name)
+		manager.names[name] = true
+		if data.Nickname != "" {
+			manager.owners[getKey(data.Nickname)] = name
+		}
+	}
+	return nil
+}
+
+// Join applies the stored nickname of a session that has just joined.
+// If the name of the player is the nickname of another player,
+// the nickname of the other player is removed, as names take precedence.
+// Internal. Not to be used by plugins.
+func (manager *Manager) Join(session *net.MinecraftSession) {
+	var name = strings.ToLower(session.GetName())
+	var data = session.GetPlayer().GetData()
+	manager.mutex.Lock()
+	manager.names[name] = true
+	var collided, ok = manager.owners[name]
+	if ok && collided != name {
+		delete(manager.owners, name)
+	}
+	if data.Nickname != "" {
+		if owner, taken := manager.owners[getKey(data.Nickname)]; taken && owner != name {
+			data.Nickname = ""
+		} else {
+			manager.owners[getKey(data.Nickname)] = name
+			session.GetPlayer().SetDisplayName(data.Nickname)
+		}
+	}
+	manager.mutex.Unlock()
+
+	if ok && collided != name {
+		text.DefaultLogger.LogError(manager.clear(collided))
+	}
+}
+
+// GetOwner returns the name of the player that has the nickname, ignoring case and formatting.
+// A bool is returned indicating if a player has the nickname.
+func (manager *Manager) GetOwner(nickname string) (string, bool) {
+	manager.mutex.RLock()
+	defer manager.mutex.RUnlock()
+	var owner, ok = manager.owners[getKey(nickname)]
+	return owner, ok
+}
+
+// SetNickname sets the nickname of the player of the session and refreshes its display name.
+// An empty nickname, or the name of the player itself, removes the nickname.
+// Formatting codes are stripped if the player does not have the color permission.
+func (manager *Manager) SetNickname(session *net.MinecraftSession, nickname string) error {
+	if !session.HasPermission(ColorPermission) {
+		nickname = text.StripFormatting(nickname)
+	}
+	nickname = strings.TrimSpace(nickname)
+	if nickname == session.GetName() {
+		nickname = ""
+	}
+	if nickname != "" && !IsValid(nickname) {
+		return InvalidNickname
+	}
+	var data = session.GetPlayer().GetData()
+	if err := manager.check(session.GetName(), nickname); err != nil {
+		return err
+	}
+	var event = &ChangeEvent{Session: session, Previous: data.Nickname, Nickname: nickname}
+	if !manager.eventManager.Call(event) {
+		return Cancelled
+	}
+	if err := manager.reserve(session.GetName(), data.Nickname, nickname); err != nil {
+		return err
+	}
+	data.Nickname = nickname
+	manager.apply(session)
+	return manager.storage.Save(data)
+}
+
+// IsValid checks if the nickname, stripped of formatting codes, has a valid length
+// and only holds letters, digits, underscores and spaces.
+func IsValid(nickname string) bool {
+	var stripped = text.StripFormatting(nickname)
+	if length := utf8.RuneCountInString(stripped); length < MinimumLength || length > MaximumLength {
+		return false
+	}
+	if strings.TrimSpace(stripped) != stripped {
+		return false
+	}
+	for _, r := range stripped {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '_' && r != ' ' {
+			return false
+		}
+	}
+	return true
+}
+
+// check checks if the player with the given name may use the nickname.
+// NicknameTaken is returned if the nickname is the name or nickname of another player.
+func (manager *Manager) check(name string, nickname string) error {
+	if nickname == "" {
+		return nil
+	}
+	manager.mutex.RLock()
+	defer manager.mutex.RUnlock()
+	return manager.checkTaken(strings.ToLower(name), getKey(nickname))
+}
+
+// checkTaken checks if the nickname key is taken by another player than the one with the name.
+// The mutex of the manager must be locked.
+func (manager *Manager) checkTaken(name string, key string) error {
+	if owner, ok := manager.owners[key]; ok && owner != name {
+		return NicknameTaken
+	}
+	if key != name && manager.names[key] {
+		return NicknameTaken
+	}
+	return nil
+}
+
+// reserve replaces the previous nickname of the player with the given name with the new nickname,
+// after checking the nickname is not taken in the meantime.
+func (manager *Manager) reserve(name string, previous string, nickname string) error {
+	name = strings.ToLower(name)
+	manager.mutex.Lock()
+	defer manager.mutex.Unlock()
+	if nickname != "" {
+		if err := manager.checkTaken(name, getKey(nickname)); err != nil {
+			return err
+		}
+	}
+	if previous != "" && manager.owners[getKey(previous)] == name {
+		delete(manager.owners, getKey(previous))
+	}
+	if nickname != "" {
+		manager.owners[getKey(nickname)] = name
+	}
+	return nil
+}
+
+// clear removes the nickname of the player with the given name,
+// which is either online or loaded from the data storage.
+func (manager *Manager) clear(name string) error {
+	if session, ok := manager.getSession(name); ok {
+		var data = session.GetPlayer().GetData()
+		data.Nickname = ""
+		manager.apply(session)
+		return manager.storage.Save(data)
+	}
+	var data, err = manager.storage.Load(name)
+	if err != nil {
+		return err
+	}
+	data.Nickname = ""
+	return manager.storage.Save(data)
+}
+
+// getSession returns the online session with the name, ignoring case.
+// A bool is returned indicating if the session was found.
+func (manager *Manager) getSession(name string) (*net.MinecraftSession, bool) {
+	for _, session := range manager.sessionManager.GetSessions() {
+		if strings.EqualFold(session.GetName(), name) {
+			return session, true
+		}
+	}
+	return nil, false
+}
+
+// apply sets the display name of the session to its nickname, or its name if it has none,
+// and refreshes the display name for all viewers.
+func (manager *Manager) apply(session *net.MinecraftSession) {
+	var displayName = session.GetPlayer().GetData().Nickname
+	if displayName == "" {
+		displayName = session.GetName()
+	}
+	session.GetPlayer().SetDisplayName(displayName)
+	manager.RefreshFunction(session)
+}
+
+// revealRealName adds the real name of a nicked sender to the chat format
+// of receivers with the reveal permission.
+func (manager *Manager) revealRealName(event events.Event) {
+	var receive, ok = event.(*chat.ReceiveEvent)
+	if !ok || receive.Sender.GetDisplayName() == receive.Sender.GetName() {
+		return
+	}
+	if receive.Receiver.HasPermission(RevealPermission) {
+		receive.Format = strings.Replace(receive.Format, "{name}", "{name}"+text.Gray+" ({username})"+text.Reset, 1)
+	}
+}
+
+// getKey returns the key of a nickname, which is the nickname stripped of formatting in lower case.
+func getKey(nickname string) string {
+	return strings.ToLower(text.StripFormatting(nickname))
+}
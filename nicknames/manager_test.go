@@ -0,0 +1,61 @@
+package nicknames
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/irmine/gomine/events"
+	"github.com/irmine/gomine/net"
+	"github.com/irmine/gomine/players"
+	"github.com/irmine/gomine/text"
+)
+
+func TestIsValid(t *testing.T) {
+	for nickname, valid := range map[string]bool{
+		"Steve":                      true,
+		"Mr Steve_2":                 true,
+		text.Red + "Red" + text.Bold: true,
+		"St":                         false,
+		"ThisNicknameIsTooLong":      false,
+		" Steve":                     false,
+		"Steve!":                     false,
+	} {
+		if IsValid(nickname) != valid {
+			t.Errorf("nickname %q was expected to be valid: %v", nickname, valid)
+		}
+	}
+}
+
+func TestReserve(t *testing.T) {
+	dir, _ := ioutil.TempDir("", "nicknames")
+	defer os.RemoveAll(dir)
+	storage := players.NewFileDataStorage(dir)
+	alex := players.NewData("alex")
+	alex.Nickname = "Lexi"
+	storage.Save(alex)
+	storage.Save(players.NewData("notch"))
+
+	manager := NewManager(net.NewSessionManager(), storage, events.NewManager())
+	if err := manager.Load(); err != nil {
+		t.Fatal("could not load nicknames:", err)
+	}
+	if owner, ok := manager.GetOwner(text.Orange + "LEXI"); !ok || owner != "alex" {
+		t.Error("stored nickname was not loaded:", owner)
+	}
+	if err := manager.reserve("Steve", "", "lexi"); err != NicknameTaken {
+		t.Error("nickname of another player was not taken:", err)
+	}
+	if err := manager.reserve("Steve", "", "Notch"); err != NicknameTaken {
+		t.Error("name of another player was not taken:", err)
+	}
+	if err := manager.reserve("Alex", "Lexi", "Alex"); err != nil {
+		t.Error("own name could not be used as nickname:", err)
+	}
+	if err := manager.reserve("Steve", "", "Lexi"); err != nil {
+		t.Error("released nickname could not be reserved:", err)
+	}
+	if owner, _ := manager.GetOwner("Lexi"); owner != "steve" {
+		t.Error("nickname was not reserved:", owner)
+	}
+}
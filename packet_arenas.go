@@ -0,0 +1,65 @@
+package gomine
+
+import (
+	"github.com/irmine/gomine/arena"
+	"github.com/irmine/gomine/net/packets"
+	"github.com/irmine/gomine/net/packets/bedrock"
+)
+
+// packetArenas holds the arenas of packets that are sent to players every tick.
+// Packets obtained from the arenas are recycled two ticks later,
+// which leaves them valid until after the sessions were flushed at the end of the next tick.
+type packetArenas struct {
+	movePlayer       *arena.Arena[bedrock.MovePlayerPacket]
+	moveEntity       *arena.Arena[bedrock.MoveEntityPacket]
+	setEntityData    *arena.Arena[bedrock.SetEntityDataPacket]
+	updateAttributes *arena.Arena[bedrock.UpdateAttributesPacket]
+}
+
+// newPacketArenas returns new, empty packet arenas.
+func newPacketArenas() *packetArenas {
+	return &packetArenas{
+		movePlayer: arena.New(bedrock.NewMovePlayerPacket, func(pk *bedrock.MovePlayerPacket) {
+			clearPacket(pk)
+			*pk = bedrock.MovePlayerPacket{Packet: pk.Packet}
+		}),
+		moveEntity: arena.New(bedrock.NewMoveEntityPacket, func(pk *bedrock.MoveEntityPacket) {
+			clearPacket(pk)
+			*pk = bedrock.MoveEntityPacket{Packet: pk.Packet}
+		}),
+		setEntityData: arena.New(bedrock.NewSetEntityDataPacket, func(pk *bedrock.SetEntityDataPacket) {
+			clearPacket(pk)
+			pk.RuntimeId = 0
+			clear(pk.EntityData)
+		}),
+		updateAttributes: arena.New(bedrock.NewUpdateAttributesPacket, func(pk *bedrock.UpdateAttributesPacket) {
+			clearPacket(pk)
+			pk.RuntimeId = 0
+			pk.Attributes = nil
+		}),
+	}
+}
+
+// clearPacket empties the stream of the packet, keeping its buffer for the next time the packet gets encoded.
+func clearPacket(pk packets.IPacket) {
+	pk.SetBuffer(pk.GetBuffer()[:0])
+	pk.SetOffset(0)
+}
+
+// reset ends the tick for all packet arenas, recycling packets obtained two ticks ago.
+func (arenas *packetArenas) reset() {
+	arenas.movePlayer.Reset()
+	arenas.moveEntity.Reset()
+	arenas.setEntityData.Reset()
+	arenas.updateAttributes.Reset()
+}
+
+// getStats returns the combined amount of packets created and reused by the packet arenas.
+func (arenas *packetArenas) getStats() arena.Stats {
+	var stats arena.Stats
+	for _, s := range []arena.Stats{arenas.movePlayer.GetStats(), arenas.moveEntity.GetStats(), arenas.setEntityData.GetStats(), arenas.updateAttributes.GetStats()} {
+		stats.Created += s.Created
+		stats.Reused += s.Reused
+	}
+	return stats
+}
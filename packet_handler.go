@@ -1,27 +1,24 @@
 package gomine
 
 import (
-	"crypto/ecdsa"
-	"crypto/sha512"
-	"crypto/x509"
-	"encoding/base64"
 	"github.com/golang/geo/r3"
+	"github.com/irmine/gomine/auth"
+	"github.com/irmine/gomine/cosmetics"
+	"github.com/irmine/gomine/friends"
 	"github.com/irmine/gomine/net"
 	"github.com/irmine/gomine/net/info"
 	"github.com/irmine/gomine/net/packets"
 	"github.com/irmine/gomine/net/packets/bedrock"
 	"github.com/irmine/gomine/net/packets/data"
 	"github.com/irmine/gomine/net/packets/types"
-	"github.com/irmine/gomine/net/protocol"
+	"github.com/irmine/gomine/playerlist"
 	"github.com/irmine/gomine/players"
+	"github.com/irmine/gomine/skins"
 	"github.com/irmine/gomine/text"
 	"github.com/irmine/gomine/utils"
 	"github.com/irmine/worlds/blocks"
 	"github.com/irmine/worlds/chunks"
 	data2 "github.com/irmine/worlds/entities/data"
-	utils2 "github.com/irmine/worlds/utils"
-	"math/big"
-	"strings"
 	"time"
 )
 
@@ -39,24 +36,9 @@ func NewClientHandshakeHandler(server *Server) *net.PacketHandler {
 func NewCommandRequestHandler(server *Server) *net.PacketHandler {
 	return net.NewPacketHandler(func(packet packets.IPacket, session *net.MinecraftSession) bool {
 		if pk, ok := packet.(*bedrock.CommandRequestPacket); ok {
-			var args = strings.Split(pk.CommandText, " ")
-			var commandName = strings.TrimLeft(args[0], "/")
-			var i = 1
-			for !server.CommandManager.IsCommandRegistered(commandName) {
-				if i == len(args) {
-					break
-				}
-				commandName += " " + args[i]
-				i++
-			}
-			if !server.CommandManager.IsCommandRegistered(commandName) {
-				session.SendMessage("Command could not be found.")
-				return false
-			}
-			args = args[i:]
-			var command, _ = server.CommandManager.GetCommand(commandName)
-			command.Execute(session, args)
-
+			session.CaptureCommandOutput()
+			var success = server.DispatchCommand(session, pk.CommandText)
+			session.SendCommandOutput(pk.Origin, success)
 			return true
 		}
 
@@ -67,56 +49,74 @@ func NewCommandRequestHandler(server *Server) *net.PacketHandler {
 func NewLoginHandler(server *Server) *net.PacketHandler {
 	return net.NewPacketHandler(func(packet packets.IPacket, session *net.MinecraftSession) bool {
 		if loginPacket, ok := packet.(*bedrock.LoginPacket); ok {
-			var _, ok = server.SessionManager.GetSession(loginPacket.Username)
-			if ok {
-				return false
-			}
-
-			if loginPacket.Protocol > info.LatestProtocol {
-				session.Kick("Outdated server.", false, true)
-				return false
+			var proto, supported = server.NetworkAdapter.GetProtocolPool().GetProtocol(loginPacket.Protocol)
+			if !supported {
+				server.rejectProtocol(session, loginPacket)
+				return true
 			}
+			session.SetProtocol(proto)
 
-			if loginPacket.Protocol < info.LatestProtocol {
-				session.Kick("Outdated client.", false, true)
-				return false
+			var identity, err = auth.VerifyChain(loginPacket.Chains, time.Now())
+			if err == nil {
+				err = auth.VerifyClientData(loginPacket.ClientDataJwt, identity)
 			}
-
-			var successful, authenticated, pubKey = VerifyLoginRequest(loginPacket.Chains, server)
-
-			if !successful {
-				text.DefaultLogger.Debug(loginPacket.Username, "has joined with invalid login data.")
+			if err != nil {
+				text.DefaultLogger.Debug(loginPacket.Username, "has joined with invalid login data:", err)
+				session.Kick("Invalid login data.", false, false)
 				return true
 			}
 
-			if authenticated {
-				text.DefaultLogger.Debug(loginPacket.Username, "has joined while being logged into XBOX Live.")
+			if identity.Authenticated {
+				text.DefaultLogger.Debug(identity.DisplayName, "has joined while being logged into XBOX Live.")
 			} else {
 				if server.Config.XBOXLiveAuth {
-					text.DefaultLogger.Debug(loginPacket.Username, "has tried to join while not being logged into XBOX Live.")
+					text.DefaultLogger.Debug(identity.DisplayName, "has tried to join while not being logged into XBOX Live.")
 					session.Kick("XBOX Live account required.", false, false)
 					return true
 				}
-				text.DefaultLogger.Debug(loginPacket.Username, "has joined while not being logged into XBOX Live.")
+				text.DefaultLogger.Debug(identity.DisplayName, "has joined while not being logged into XBOX Live.")
+			}
+			if !players.IsValidName(identity.DisplayName) {
+				text.DefaultLogger.Debug(identity.DisplayName, "has joined with an invalid name.")
+				session.Kick("Invalid name.", false, false)
+				return true
+			}
+			if reason, ok := server.checkAccess(identity.DisplayName); !ok {
+				text.DefaultLogger.Debug(identity.DisplayName, "has been denied access:", reason)
+				session.Kick(reason, false, false)
+				return true
+			}
+			loginPacket.Username, loginPacket.ClientUUID, loginPacket.ClientXUID = identity.DisplayName, identity.UUID, identity.XUID
+			if _, ok := server.SessionManager.GetSession(loginPacket.Username); ok {
+				return false
 			}
 
 			session.SetData(server.PermissionManager, types.SessionData{ClientUUID: loginPacket.ClientUUID, ClientXUID: loginPacket.ClientXUID, ClientId: loginPacket.ClientId, ProtocolNumber: loginPacket.Protocol, GameVersion: loginPacket.ClientData.GameVersion, Language: loginPacket.Language, DeviceOS: loginPacket.ClientData.DeviceOS})
 			session.SetPlayer(players.NewPlayer(loginPacket.ClientUUID, loginPacket.ClientXUID, int32(loginPacket.ClientData.DeviceOS), loginPacket.Username))
 
 			session.GetEncryptionHandler().Data = &utils.EncryptionData{
-				ClientPublicKey:  pubKey,
+				ClientPublicKey:  identity.PublicKey,
 				ServerPrivateKey: server.GetPrivateKey(),
 				ServerToken:      server.GetServerToken(),
+				UseGCM:           loginPacket.Protocol >= info.GCMEncryptionProtocol,
 			}
 
 			session.GetPlayer().SetName(loginPacket.Username)
 			session.GetPlayer().SetDisplayName(loginPacket.Username)
-			session.GetPlayer().SetSkinId(loginPacket.SkinId)
-			session.GetPlayer().SetSkinData(loginPacket.SkinData)
-			session.GetPlayer().SetCapeData(loginPacket.CapeData)
-			session.GetPlayer().SetGeometryName(loginPacket.GeometryName)
-			session.GetPlayer().SetGeometryData(loginPacket.GeometryData)
-			session.SetXBOXLiveAuthenticated(authenticated)
+			var skin = skins.Skin{Id: loginPacket.SkinId, Data: loginPacket.SkinData, CapeData: loginPacket.CapeData, GeometryName: loginPacket.GeometryName, GeometryData: loginPacket.GeometryData}
+			if err := skin.Validate(); err != nil {
+				text.DefaultLogger.Debug(loginPacket.Username, "has joined with an invalid skin:", err)
+				session.Kick("Invalid skin.", false, false)
+				return true
+			}
+			skin = server.SkinService.Normalize(skin)
+			session.GetPlayer().SetSkinId(skin.Id)
+			session.GetPlayer().SetSkinData(skin.Data)
+			session.GetPlayer().SetCapeData(skin.CapeData)
+			session.GetPlayer().SetGeometryName(skin.GeometryName)
+			session.GetPlayer().SetGeometryData(skin.GeometryData)
+			session.SetXBOXLiveAuthenticated(identity.Authenticated)
+			server.applyPermissions(session)
 
 			if server.Config.UseEncryption {
 				var jwt = utils.ConstructEncryptionJwt(server.GetPrivateKey(), server.GetServerToken())
@@ -134,13 +134,27 @@ func NewLoginHandler(server *Server) *net.PacketHandler {
 	})
 }
 
-func NewMovePlayerHandler(_ *Server) *net.PacketHandler {
+func NewMovePlayerHandler(server *Server) *net.PacketHandler {
 	return net.NewPacketHandler(func(packet packets.IPacket, session *net.MinecraftSession) bool {
 		if pk, ok := packet.(*bedrock.MovePlayerPacket); ok {
 			if session.GetPlayer().GetDimension() == nil {
 				return false
 			}
-			session.SyncMove(pk.Position.X, pk.Position.Y, pk.Position.Z, pk.Rotation.Pitch, pk.Rotation.Yaw, pk.Rotation.HeadYaw, pk.OnGround)
+			if player := session.GetPlayer(); player.IsFrozen() {
+				// Frozen players may look around, but any movement gets reverted.
+				if pk.Position.Sub(player.Position).Norm() > 0.01 {
+					session.Teleport(player.Position, pk.Rotation)
+				} else {
+					session.SyncMove(player.Position.X, player.Position.Y, player.Position.Z, pk.Rotation.Pitch, pk.Rotation.Yaw, pk.Rotation.HeadYaw, pk.OnGround)
+				}
+				return true
+			}
+			if server.Config.MovementChecks || server.featureState.serverAuthMovement {
+				server.MovementProcessor.Process(session, pk.Position, pk.Rotation, pk.OnGround)
+			} else {
+				session.SyncMove(pk.Position.X, pk.Position.Y, pk.Position.Z, pk.Rotation.Pitch, pk.Rotation.Yaw, pk.Rotation.HeadYaw, pk.OnGround)
+			}
+			server.CosmeticManager.Move(session)
 			return true
 		}
 		return false
@@ -150,40 +164,55 @@ func NewMovePlayerHandler(_ *Server) *net.PacketHandler {
 func NewRequestChunkRadiusHandler(server *Server) *net.PacketHandler {
 	return net.NewPacketHandler(func(packet packets.IPacket, session *net.MinecraftSession) bool {
 		if chunkRadiusPacket, ok := packet.(*bedrock.RequestChunkRadiusPacket); ok {
-			var viewDistance = server.GetAllowedViewDistance(chunkRadiusPacket.Radius)
+			var viewDistance = server.limitViewDistance(session, server.GetAllowedViewDistance(chunkRadiusPacket.Radius))
 			session.SetViewDistance(viewDistance)
 			session.SendChunkRadiusUpdated(viewDistance)
-
-			var sessions = server.SessionManager.GetSessions()
-			var viewers = make(map[string]protocol.PlayerListEntry)
-			for name, online := range sessions {
-				if online.HasSpawned() {
-					viewers[name] = online.GetPlayer()
-					online.SendPlayerList(data.ListTypeAdd, map[string]protocol.PlayerListEntry{session.GetName(): session.GetPlayer()})
-				}
+			if session.Connected {
+				// The client changed its render distance after spawning.
+				return true
 			}
 
-			session.SendPlayerList(data.ListTypeAdd, viewers)
-
-			for _, online := range server.SessionManager.GetSessions() {
-				if session.GetUUID() != online.GetUUID() {
-					online.GetPlayer().SpawnPlayerTo(session)
-					online.GetPlayer().AddViewer(session)
-
-					session.GetPlayer().SpawnPlayerTo(online)
-					session.GetPlayer().AddViewer(online)
-
-					online.SendSkin(session)
-					session.SendSkin(online)
+			if playerData, err := server.PlayerStorage.Load(session.GetName()); err != nil {
+				text.DefaultLogger.LogError(err)
+			} else {
+				session.GetPlayer().SetData(playerData)
+				if playerData.Language != "" {
+					session.SetLanguage(playerData.Language)
 				}
 			}
 
+			// The nickname is applied before the player is added to the player list and spawned to others.
+			server.NicknameManager.Join(session)
+			server.PlayerListManager.Join(session)
+			server.PlayerListManager.Spawn(session)
+
 			session.SendSetEntityData(session.GetPlayer().GetRuntimeId(), session.GetPlayer().GetEntityData())
 			session.SendUpdateAttributes(session.GetPlayer().GetRuntimeId(), session.GetPlayer().GetAttributeMap())
 
-			server.BroadcastMessage(text.Yellow+session.GetDisplayName(), "has joined the server")
+			if _, err := server.AnnouncementManager.Join(session); err != nil {
+				text.DefaultLogger.LogError(err)
+			}
+			if err := server.FriendManager.Load(session); err != nil && err != friends.NoXUID {
+				text.DefaultLogger.LogError(err)
+			}
 			session.SendPlayStatus(data.StatusSpawn)
 
+			server.RewardManager.Join(session)
+			server.LobbyManager.Apply(session)
+			server.CosmeticManager.Join(session)
+			server.MobManager.Join(session)
+			server.ScoreboardManager.Join(session)
+			server.DropManager.Join(session)
+			server.BrandingManager.Join(session)
+			session.SendInventory()
+			server.sendWorld(session)
+			server.sendSoftEnums(session)
+
+			// Flight is allowed in creative and spectator mode. The lobby may have changed the game mode already.
+			if session.GetGameMode() == data.GameModeCreative || session.GetGameMode() == data.GameModeSpectator {
+				session.SetAllowFlight(true)
+			}
+
 			session.Connected = true
 			return true
 		}
@@ -195,12 +224,17 @@ func NewRequestChunkRadiusHandler(server *Server) *net.PacketHandler {
 func NewResourcePackChunkRequestHandler(server *Server) *net.PacketHandler {
 	return net.NewPacketHandler(func(packet packets.IPacket, session *net.MinecraftSession) bool {
 		if request, ok := packet.(*bedrock.ResourcePackChunkRequestPacket); ok {
-			if !server.PackManager.IsPackLoaded(request.PackUUID) {
-				// TODO: Kick the player. We can't kick yet.
-				return false
+			var pack = server.PackManager.GetPackById(request.PackUUID)
+			if pack == nil {
+				session.Kick("Unknown resource pack requested.", false, false)
+				return true
+			}
+			if request.ChunkIndex < 0 || request.ChunkIndex >= pack.GetChunkCount(data.ResourcePackChunkSize) {
+				session.Kick("Invalid resource pack chunk requested.", false, false)
+				return true
 			}
-			var pack = server.PackManager.GetPack(request.PackUUID)
-			session.SendResourcePackChunkData(request.PackUUID, request.ChunkIndex, int64(data.ResourcePackChunkSize*request.ChunkIndex), pack.GetChunk(int(data.ResourcePackChunkSize*request.ChunkIndex), data.ResourcePackChunkSize))
+			var offset = int64(data.ResourcePackChunkSize) * int64(request.ChunkIndex)
+			session.SendResourcePackChunkData(pack.GetUUID(), request.ChunkIndex, offset, pack.GetChunk(int(offset), data.ResourcePackChunkSize))
 			return true
 		}
 		return false
@@ -212,24 +246,29 @@ func NewResourcePackClientResponseHandler(server *Server) *net.PacketHandler {
 		if response, ok := packet.(*bedrock.ResourcePackClientResponsePacket); ok {
 			switch response.Status {
 			case data.StatusRefused:
-				// TODO: Kick the player. We can't kick yet.
-				return false
+				session.Kick("You must accept the resource packs of the server to join.", false, false)
 			case data.StatusSendPacks:
-				for _, packUUID := range response.PackUUIDs {
-					if !server.PackManager.IsPackLoaded(packUUID) {
-						// TODO: Kick the player. We can't kick yet.
-						return false
+				for _, packId := range response.PackUUIDs {
+					var pack = server.PackManager.GetPackById(packId)
+					if pack == nil {
+						session.Kick("Unknown resource pack requested.", false, false)
+						return true
 					}
-					session.SendResourcePackDataInfo(server.PackManager.GetPack(packUUID))
+					session.SendResourcePackDataInfo(pack)
 				}
 			case data.StatusHaveAllPacks:
 				session.SendResourcePackStack(server.Config.ForceResourcePacks, server.PackManager.GetResourceStack(), server.PackManager.GetBehaviorStack())
 			case data.StatusCompleted:
-				server.LevelManager.GetDefaultLevel().GetDefaultDimension().LoadChunk(0, 0, func(chunk *chunks.Chunk) {
-					server.LevelManager.GetDefaultLevel().GetDefaultDimension().AddEntity(session.GetPlayer(), r3.Vector{X: 0, Y: 7, Z: 0})
-					server.LevelManager.GetDefaultLevel().GetDefaultDimension().AddViewer(session, r3.Vector{X: 0, Y: 7, Z: 0})
-					session.SendStartGame(session.GetPlayer(), blocks.GetRuntimeIdsTable())
-					session.SendCraftingData()
+				var dimension = server.LevelManager.GetDefaultLevel().GetDefaultDimension()
+				var spawn = server.LevelStorage.GetSpawn(server.LevelManager.GetDefaultLevel().GetName())
+				// Joining players are scattered around the spawn if a spawn radius is configured.
+				server.CombatManager.Scatter.Scatter(dimension, spawn, func(spawn r3.Vector) {
+					dimension.LoadChunk(int32(spawn.X)>>4, int32(spawn.Z)>>4, func(chunk *chunks.Chunk) {
+						dimension.AddEntity(session.GetPlayer(), spawn)
+						dimension.AddViewer(session, spawn)
+						session.SendStartGame(session.GetPlayer(), blocks.GetRuntimeIdsTable())
+						session.SendCraftingData(server.CraftingManager.GetCraftingData())
+					})
 				})
 			}
 			return true
@@ -244,15 +283,21 @@ func NewTextHandler(server *Server) *net.PacketHandler {
 			if textPacket.TextType != data.TextChat {
 				return false
 			}
-			for _, receiver := range server.SessionManager.GetSessions() {
-				receiver.SendText(types.Text{
-					Message: "<" + session.GetDisplayName() + "> " + textPacket.Message,
-					PlatformChatId: textPacket.PlatformChatId,
-					SourceXUID: session.GetXUID(),
-					TextType: data.TextChat,
-				})
+			if session.GetPlayer().GetData().IsMuted() {
+				session.SendMessage(text.Red + "You are muted. " + session.GetPlayer().GetData().MuteReason)
+				return true
 			}
-			text.DefaultLogger.LogChat("<" + session.GetDisplayName() + "> " + textPacket.Message)
+			if party, ok := server.PartyManager.GetParty(session.GetName()); ok && server.PartyManager.IsPartyChat(session.GetName()) {
+				party.SendChat(session, textPacket.Message)
+				text.DefaultLogger.LogChat("[party] <" + session.GetDisplayName() + "> " + textPacket.Message)
+				return true
+			}
+			if game, ok := server.MinigameManager.GetGameOf(session.GetName()); ok {
+				game.SendChat(session, textPacket.Message)
+				text.DefaultLogger.LogChat("[" + game.GetName() + "] <" + session.GetDisplayName() + "> " + textPacket.Message)
+				return true
+			}
+			text.DefaultLogger.LogError(server.ChatManager.SendChat(session, textPacket.Message))
 			return true
 		}
 		return false
@@ -267,11 +312,17 @@ func NewInteractHandler(_ *Server) *net.PacketHandler {
 	})
 }
 
-func NewPlayerActionHandler(_ *Server) *net.PacketHandler {
+func NewPlayerActionHandler(server *Server) *net.PacketHandler {
 	return net.NewPacketHandler(func(packet packets.IPacket, session *net.MinecraftSession) bool {
 		//TODO: fix sending to others
 		if playerAction, ok := packet.(*bedrock.PlayerActionPacket); ok {
 			switch playerAction.Action {
+			case bedrock.PlayerStartBreak:
+				server.BuildingManager.StartBreak(session, playerAction.Position)
+				break
+			case bedrock.PlayerAbortBreak:
+				server.BuildingManager.AbortBreak(session)
+				break
 			case bedrock.PlayerStartSneak:
 				session.GetPlayer().SetEntityProperty(data2.EntityDataSneaking, true)
 				break
@@ -284,6 +335,9 @@ func NewPlayerActionHandler(_ *Server) *net.PacketHandler {
 			case bedrock.PlayerStopSprint:
 				session.GetPlayer().SetEntityProperty(data2.EntityDataSprinting, false)
 				break
+			case bedrock.PlayerRespawn:
+				server.CombatManager.Respawn(session)
+				break
 			}
 		}
 		return true
@@ -303,88 +357,168 @@ func NewAnimateHandler(_ *Server) *net.PacketHandler {
 	})
 }
 
-func NewInventoryTransactionHandler(_ *Server) *net.PacketHandler {
+func NewPlayerSkinHandler(server *Server) *net.PacketHandler {
+	return net.NewPacketHandler(func(packet packets.IPacket, session *net.MinecraftSession) bool {
+		if skin, ok := packet.(*bedrock.PlayerSkinPacket); ok {
+			var changed = skins.Skin{Id: skin.SkinId, Data: skin.SkinData, CapeData: skin.CapeData, GeometryName: skin.GeometryName, GeometryData: skin.GeometryData}
+			if err := changed.Validate(); err != nil {
+				text.DefaultLogger.Debug(session.GetName(), "has changed to an invalid skin:", err)
+				session.SendSkin(session)
+				return true
+			}
+			changed = server.SkinService.Normalize(changed)
+			server.PlayerListManager.ChangeSkin(session, playerlist.Skin{
+				Id:           changed.Id,
+				Data:         changed.Data,
+				CapeData:     changed.CapeData,
+				GeometryName: changed.GeometryName,
+				GeometryData: changed.GeometryData,
+			})
+			return true
+		}
+		return false
+	})
+}
+
+func NewMobEquipmentHandler(server *Server) *net.PacketHandler {
+	return net.NewPacketHandler(func(packet packets.IPacket, session *net.MinecraftSession) bool {
+		if equipment, ok := packet.(*bedrock.MobEquipmentPacket); ok {
+			if equipment.WindowId == data.ContainerInventory {
+				session.GetPlayer().SetHeldSlot(int(equipment.HotbarSlot))
+			}
+			return true
+		}
+		return false
+	})
+}
+
+func NewInventoryTransactionHandler(server *Server) *net.PacketHandler {
 	return net.NewPacketHandler(func(packet packets.IPacket, session *net.MinecraftSession) bool {
 		if invTransaction, ok := packet.(*bedrock.InventoryTransactionPacket); ok {
 			var clickPos = invTransaction.BlockPosition
 			switch invTransaction.TransactionType {
+			case bedrock.Normal:
+				if session.UsesItemStackRequests() {
+					// Inventories of sessions using item stack requests are server authoritative.
+					break
+				}
+				if server.TradeManager.HandleTransaction(session, invTransaction.ActionList.List) {
+					break
+				}
+				if server.CraftingManager.HandleTransaction(session, invTransaction.ActionList.List) {
+					break
+				}
+				if !server.applyInventoryActions(session, invTransaction.ActionList.List) {
+					session.SendInventory()
+				}
+				break
 			case bedrock.UseItem:
+				if invTransaction.ActionType != bedrock.ItemBreakBlock {
+					if command, ok := server.LobbyManager.GetCommand(session, invTransaction.ItemSlot); ok {
+						server.DispatchCommand(session, command)
+						break
+					}
+					if used, err := server.CosmeticManager.Use(session, invTransaction.ItemSlot); used {
+						if err == cosmetics.OnCooldown {
+							var gadget, _ = server.CosmeticManager.GetEquipped(session, cosmetics.CategoryGadget)
+							session.SendMessage(text.Red+"You can use this gadget again in", server.CosmeticManager.GetCooldown(session, gadget.(*cosmetics.Gadget)).Round(time.Second).String()+".")
+						}
+						break
+					}
+				}
 				switch invTransaction.ActionType {
 				case bedrock.ItemBreakBlock:
-					runtimeId, ok := blocks.GetRuntimeId(0, 0)
-					if ok {
-						var block= blocks.New(blocks.NewBlockState("air", int32(runtimeId), 0, 0))
-						session.GetPlayer().GetDimension().SetBlockAt(utils2.PositionToVector(clickPos), block)
+					if !server.LobbyManager.AllowsBlockChanges(session) {
+						break
 					}
+					server.BuildingManager.Break(session, clickPos)
 					break
 				case bedrock.ItemClickBlock:
-					// TODO: do block placing
+					if !server.LobbyManager.AllowsBlockChanges(session) {
+						break
+					}
+					// Only the item the server holds in the hotbar slot gets placed, never the item claimed by the client.
+					var slot = int(invTransaction.HotbarSlot)
+					if slot < 0 || slot >= players.HotbarSize {
+						break
+					}
+					var stack, err = session.GetPlayer().GetInventory().GetItem(slot)
+					if err != nil || stack == nil {
+						break
+					}
+					server.BuildingManager.Place(session, clickPos, invTransaction.Face, stack, slot)
 					break
 				}
 				break
+			case bedrock.UseItemOnEntity:
+				if invTransaction.ActionType != bedrock.ItemOnEntityAttack {
+					break
+				}
+				if victim, ok := server.SessionManager.GetSessionByRuntimeId(invTransaction.RuntimeId); ok {
+					server.CombatManager.Attack(session, victim, server.CombatManager.AttackDamage)
+				}
+				break
 			}
 		}
 		return true
 	})
 }
 
-func VerifyLoginRequest(chains []types.Chain, _ *Server) (successful bool, authenticated bool, clientPublicKey *ecdsa.PublicKey) {
-	var publicKey *ecdsa.PublicKey
-	var publicKeyRaw string
-	for _, chain := range chains {
-		if publicKeyRaw == "" {
-			if chain.Header.X5u == "" {
-				return
+func NewContainerCloseHandler(server *Server) *net.PacketHandler {
+	return net.NewPacketHandler(func(packet packets.IPacket, session *net.MinecraftSession) bool {
+		if containerClose, ok := packet.(*bedrock.ContainerClosePacket); ok {
+			// Items left in the crafting grid return to the inventory once the window holding the grid closes.
+			for _, leftover := range session.GetPlayer().ReturnCraftingGrid() {
+				server.dropItem(session, leftover, false)
 			}
-			publicKeyRaw = chain.Header.X5u
-		}
-
-		sig := []byte(chain.Signature)
-		d := []byte(chain.Header.Raw + "." + chain.Payload.Raw)
-
-		var b64, errB64 = base64.RawStdEncoding.DecodeString(publicKeyRaw)
-		text.DefaultLogger.LogError(errB64)
-
-		key, err := x509.ParsePKIXPublicKey(b64)
-		if err != nil {
-			text.DefaultLogger.LogError(err)
-			return
+			return server.TradeManager.HandleClose(session, containerClose.WindowId)
 		}
+		return false
+	})
+}
 
-		hash := sha512.New384()
-		hash.Write(d)
-
-		publicKey = key.(*ecdsa.PublicKey)
-		r := new(big.Int).SetBytes(sig[:len(sig)/2])
-		s := new(big.Int).SetBytes(sig[len(sig)/2:])
-
-		if !ecdsa.Verify(publicKey, hash.Sum(nil), r, s) {
-			return
+func NewItemStackRequestHandler(server *Server) *net.PacketHandler {
+	return net.NewPacketHandler(func(packet packets.IPacket, session *net.MinecraftSession) bool {
+		if request, ok := packet.(*bedrock.ItemStackRequestPacket); ok {
+			// Sessions using inventory transactions may not change their inventories with item stack requests as well.
+			if !session.UsesItemStackRequests() {
+				return true
+			}
+			var responses = make([]types.ItemStackResponse, 0, len(request.Requests))
+			var dropped []players.DroppedStack
+			for _, stackRequest := range request.Requests {
+				var response, stacks = session.GetPlayer().HandleItemStackRequest(stackRequest, session.GetGameMode() == data.GameModeCreative)
+				responses = append(responses, response)
+				dropped = append(dropped, stacks...)
+			}
+			session.SendItemStackResponse(responses)
+			for _, drop := range dropped {
+				server.dropItem(session, drop.Stack, drop.Randomly)
+			}
+			return true
 		}
+		return false
+	})
+}
 
-		if publicKeyRaw == data.MojangPublicKey {
-			authenticated = true
+func NewAdventureSettingsHandler(_ *Server) *net.PacketHandler {
+	return net.NewPacketHandler(func(packet packets.IPacket, session *net.MinecraftSession) bool {
+		if settings, ok := packet.(*bedrock.AdventureSettingsPacket); ok {
+			// Clients only toggle flying themselves, all other abilities are decided by the server.
+			if !session.SetFlying(settings.Flags&data.AdventureFlagFlying != 0) {
+				session.SendAbilities()
+			}
+			return true
 		}
+		return false
+	})
+}
 
-		t := time.Now().Unix()
-		if chain.Payload.ExpirationTime <= t && chain.Payload.ExpirationTime != 0 || chain.Payload.NotBefore > t || chain.Payload.IssuedAt > chain.Payload.ExpirationTime {
-			return
+func NewModalFormResponseHandler(_ *Server) *net.PacketHandler {
+	return net.NewPacketHandler(func(packet packets.IPacket, session *net.MinecraftSession) bool {
+		if response, ok := packet.(*bedrock.ModalFormResponsePacket); ok {
+			return session.HandleFormResponse(response.FormId, response.FormData)
 		}
-
-		publicKeyRaw = chain.Payload.IdentityPublicKey
-	}
-
-	var b64, errB64 = base64.RawStdEncoding.DecodeString(publicKeyRaw)
-	text.DefaultLogger.LogError(errB64)
-
-	key, err := x509.ParsePKIXPublicKey(b64)
-	if err != nil {
-		text.DefaultLogger.LogError(err)
-		return
-	}
-
-	clientPublicKey = key.(*ecdsa.PublicKey)
-
-	successful = true
-	return
+		return false
+	})
 }
@@ -3,6 +3,7 @@ package gomine
 import (
 	"github.com/golang/geo/r3"
 	"github.com/google/uuid"
+	"github.com/irmine/gomine/items"
 	"github.com/irmine/gomine/net/info"
 	"github.com/irmine/gomine/net/packets"
 	"github.com/irmine/gomine/net/packets/bedrock"
@@ -10,15 +11,18 @@ import (
 	"github.com/irmine/gomine/net/packets/types"
 	"github.com/irmine/gomine/net/protocol"
 	"github.com/irmine/gomine/packs"
-	"github.com/irmine/gomine/permissions"
 	"github.com/irmine/worlds/blocks"
 	"github.com/irmine/worlds/chunks"
 	data2 "github.com/irmine/worlds/entities/data"
-	"math"
 )
 
+// PacketManager is the protocol implementation of the latest supported Minecraft version.
+// Movement, entity data and attribute packets are obtained from per-tick arenas,
+// and must not be held on to after they were sent.
 type PacketManager struct {
 	*protocol.PacketManagerBase
+	server *Server
+	arenas *packetArenas
 }
 
 func NewPacketManager(server *Server) *PacketManager {
@@ -38,12 +42,60 @@ func NewPacketManager(server *Server) *PacketManager {
 		ids[info.PlayerActionPacket]:               func() packets.IPacket { return bedrock.NewPlayerActionPacket() },
 		ids[info.AnimatePacket]:                    func() packets.IPacket { return bedrock.NewAnimatePacket() },
 		ids[info.InventoryTransactionPacket]:       func() packets.IPacket { return bedrock.NewInventoryTransactionPacket() },
-	}, map[int][][]protocol.Handler{})}
+		ids[info.ContainerClosePacket]:             func() packets.IPacket { return bedrock.NewContainerClosePacket() },
+		ids[info.ModalFormResponsePacket]:          func() packets.IPacket { return bedrock.NewModalFormResponsePacket() },
+		ids[info.AdventureSettingsPacket]:          func() packets.IPacket { return bedrock.NewAdventureSettingsPacket() },
+		ids[info.PlayerSkinPacket]:                 func() packets.IPacket { return bedrock.NewPlayerSkinPacket() },
+		ids[info.MobEquipmentPacket]:               func() packets.IPacket { return bedrock.NewMobEquipmentPacket() },
+	}, map[int][][]protocol.Handler{}), server, server.packetArenas}
 	proto.initHandlers(server)
 
 	return proto
 }
 
+// GetProtocolNumber returns the protocol number of the packet manager.
+func (protocol *PacketManager) GetProtocolNumber() int32 {
+	return info.LatestProtocol
+}
+
+// GetGameVersion returns the network game version of the packet manager.
+func (protocol *PacketManager) GetGameVersion() string {
+	return info.LatestGameVersionNetwork
+}
+
+// UpgradePacket returns the packet as is,
+// as the packet manager implements the latest protocol.
+func (protocol *PacketManager) UpgradePacket(packet packets.IPacket) packets.IPacket {
+	return packet
+}
+
+// DowngradePacket returns the packet as is,
+// as the packet manager implements the latest protocol.
+func (protocol *PacketManager) DowngradePacket(packet packets.IPacket) packets.IPacket {
+	return packet
+}
+
+// NewItemStackRequestVersion returns the protocol version of clients changing their inventories using item stack requests,
+// which the latest protocol does not have. All other packets are shared with the latest protocol.
+func NewItemStackRequestVersion(server *Server, latest *PacketManager) *protocol.Version {
+	var ids = make(info.PacketIdList, len(info.PacketIds)+len(info.ItemStackPacketIds))
+	for name, id := range info.PacketIds {
+		ids[name] = id
+	}
+	for name, id := range info.ItemStackPacketIds {
+		ids[name] = id
+	}
+	var version = protocol.NewVersion(latest, info.ItemStackRequestProtocol, info.ItemStackRequestGameVersion, ids)
+	version.RegisterPacket(ids[info.ItemStackRequestPacket], func() packets.IPacket { return bedrock.NewItemStackRequestPacket() })
+	version.RegisterHandler(info.ItemStackRequestPacket, NewItemStackRequestHandler(server))
+	// Start game packets are built by the latest protocol, but encode the fields of the version.
+	version.RegisterDowngrade(info.StartGamePacket, func(packet packets.IPacket) packets.IPacket {
+		packet.(*bedrock.StartGamePacket).Protocol = info.ItemStackRequestProtocol
+		return packet
+	})
+	return version
+}
+
 func (protocol *PacketManager) initHandlers(server *Server) {
 	protocol.RegisterHandler(info.LoginPacket, NewLoginHandler(server))
 	protocol.RegisterHandler(info.ClientHandshakePacket, NewClientHandshakeHandler(server))
@@ -57,6 +109,11 @@ func (protocol *PacketManager) initHandlers(server *Server) {
 	protocol.RegisterHandler(info.PlayerActionPacket, NewPlayerActionHandler(server))
 	protocol.RegisterHandler(info.AnimatePacket, NewAnimateHandler(server))
 	protocol.RegisterHandler(info.InventoryTransactionPacket, NewInventoryTransactionHandler(server))
+	protocol.RegisterHandler(info.ContainerClosePacket, NewContainerCloseHandler(server))
+	protocol.RegisterHandler(info.ModalFormResponsePacket, NewModalFormResponseHandler(server))
+	protocol.RegisterHandler(info.AdventureSettingsPacket, NewAdventureSettingsHandler(server))
+	protocol.RegisterHandler(info.PlayerSkinPacket, NewPlayerSkinHandler(server))
+	protocol.RegisterHandler(info.MobEquipmentPacket, NewMobEquipmentHandler(server))
 }
 
 func (protocol *PacketManager) GetAddEntity(entity protocol.AddEntityEntry) packets.IPacket {
@@ -76,7 +133,7 @@ func (protocol *PacketManager) GetAddEntity(entity protocol.AddEntityEntry) pack
 func (protocol *PacketManager) GetAddPlayer(uuid uuid.UUID, player protocol.AddPlayerEntry) packets.IPacket {
 	var pk = bedrock.NewAddPlayerPacket()
 	pk.UUID = uuid
-	pk.Username = player.GetName()
+	pk.Username = player.GetDisplayName()
 	pk.EntityRuntimeId = player.GetRuntimeId()
 	pk.EntityUniqueId = player.GetUniqueId()
 	pk.Position = player.GetPosition()
@@ -93,8 +150,9 @@ func (protocol *PacketManager) GetChunkRadiusUpdated(radius int32) packets.IPack
 	return pk
 }
 
-func (protocol *PacketManager) GetCraftingData() packets.IPacket {
+func (protocol *PacketManager) GetCraftingData(recipes types.CraftingData) packets.IPacket {
 	var pk = bedrock.NewCraftingDataPacket()
+	pk.Recipes = recipes
 
 	return pk
 }
@@ -107,15 +165,15 @@ func (protocol *PacketManager) GetDisconnect(message string, hideDisconnectScree
 	return pk
 }
 
-func (protocol *PacketManager) GetFullChunkData(chunk *chunks.Chunk) packets.IPacket {
+func (protocol *PacketManager) GetFullChunkData(chunk *chunks.Chunk, chunkData []byte) packets.IPacket {
 	var pk = bedrock.NewFullChunkDataPacket()
 	pk.ChunkX, pk.ChunkZ = chunk.X, chunk.Z
-	pk.ChunkData = chunk.ToBinary()
+	pk.ChunkData = chunkData
 	return pk
 }
 
 func (protocol *PacketManager) GetMovePlayer(runtimeId uint64, position r3.Vector, rotation data2.Rotation, mode byte, onGround bool, ridingRuntimeId uint64) packets.IPacket {
-	var pk = bedrock.NewMovePlayerPacket()
+	var pk = protocol.arenas.movePlayer.Get()
 	pk.RuntimeId = runtimeId
 	pk.Position = position
 	pk.Rotation = rotation
@@ -178,7 +236,7 @@ func (protocol *PacketManager) GetResourcePackDataInfo(pack packs.Pack) packets.
 	var pk = bedrock.NewResourcePackDataInfoPacket()
 	pk.PackUUID = pack.GetUUID()
 	pk.MaxChunkSize = data.ResourcePackChunkSize
-	pk.ChunkCount = int32(math.Ceil(float64(pack.GetFileSize()) / float64(data.ResourcePackChunkSize)))
+	pk.ChunkCount = pack.GetChunkCount(data.ResourcePackChunkSize)
 	pk.CompressedPackSize = pack.GetFileSize()
 	pk.Sha256 = pack.GetSha256()
 
@@ -244,44 +302,17 @@ func (protocol *PacketManager) GetServerHandshake(encryptionJwt string) packets.
 }
 
 func (protocol *PacketManager) GetSetEntityData(runtimeId uint64, data map[uint32][]interface{}) packets.IPacket {
-	var pk = bedrock.NewSetEntityDataPacket()
+	var pk = protocol.arenas.setEntityData.Get()
 	pk.RuntimeId = runtimeId
-	pk.EntityData = data
+	for key, value := range data {
+		pk.EntityData[key] = value
+	}
 
 	return pk
 }
 
 func (protocol *PacketManager) GetStartGame(player protocol.StartGameEntry, runtimeIdsTable []byte) packets.IPacket {
-	var pk = bedrock.NewStartGamePacket()
-	pk.Generator = 1
-	pk.LevelSeed = 312402
-	pk.DefaultPermissionLevel = permissions.LevelMember
-	pk.EntityRuntimeId = player.GetRuntimeId()
-	pk.EntityUniqueId = player.GetUniqueId()
-	pk.PlayerGameMode = 1
-	pk.PlayerPosition = player.GetPosition()
-	pk.LevelGameMode = 1
-	pk.LevelSpawnPosition = blocks.NewPosition(0, 7, 0)
-	pk.CommandsEnabled = true
-
-	var gameRules = player.GetDimension().GetLevel().GetGameRules()
-	var gameRuleEntries = map[string]types.GameRuleEntry{}
-	for name, gameRule := range gameRules {
-		gameRuleEntries[string(name)] = types.GameRuleEntry{Name: string(gameRule.GetName()), Value: gameRule.GetValue()}
-	}
-
-	pk.GameRules = gameRuleEntries
-	pk.LevelName = player.GetDimension().GetLevel().GetName()
-	pk.CurrentTick = player.GetDimension().GetLevel().GetCurrentTick()
-	pk.Time = 0
-	pk.AchievementsDisabled = true
-	pk.BroadcastToLan = true
-	pk.RuntimeIdsTable = runtimeIdsTable
-
-	pk.PlatformBroadcastIntent = bedrock.GameBroadcastSettingPublic
-	pk.XBOXBroadcastIntent = bedrock.GameBroadcastSettingPublic
-
-	return pk
+	return NewStartGameBuilder(protocol.server, protocol.GetProtocolNumber()).Player(player).RuntimeIdsTable(runtimeIdsTable).Build()
 }
 
 func (protocol *PacketManager) GetText(text types.Text) packets.IPacket {
@@ -291,6 +322,7 @@ func (protocol *PacketManager) GetText(text types.Text) packets.IPacket {
 	pk.Params = text.TranslationParameters
 	pk.SourceName = text.SourceName
 	pk.XUID = text.SourceXUID
+	pk.PlatformChatId = text.PlatformChatId
 	pk.Message = text.Message
 
 	return pk
@@ -305,7 +337,7 @@ func (protocol *PacketManager) GetTransfer(address string, port uint16) packets.
 }
 
 func (protocol *PacketManager) GetUpdateAttributes(runtimeId uint64, attributeMap data2.AttributeMap) packets.IPacket {
-	var pk = bedrock.NewUpdateAttributesPacket()
+	var pk = protocol.arenas.updateAttributes.Get()
 	pk.RuntimeId = runtimeId
 	pk.Attributes = attributeMap
 
@@ -321,7 +353,7 @@ func (protocol *PacketManager) GetNetworkChunkPublisherUpdatePacket(position blo
 }
 
 func (protocol *PacketManager) GetMoveEntity(runtimeId uint64, position r3.Vector, rot data2.Rotation, flags byte, teleport bool) packets.IPacket {
-	var pk = bedrock.NewMoveEntityPacket()
+	var pk = protocol.arenas.moveEntity.Get()
 
 	pk.RuntimeId = runtimeId
 	pk.Position = position
@@ -377,4 +409,244 @@ func (protocol *PacketManager) GetUpdateBlock(position blocks.Position, blockRun
 	pk.DataLayerId = dataLayerId
 
 	return pk
-}
\ No newline at end of file
+}
+func (protocol *PacketManager) GetContainerOpen(windowId byte, containerType byte, position blocks.Position, entityUniqueId int64) packets.IPacket {
+	var pk = bedrock.NewContainerOpenPacket()
+
+	pk.WindowId = windowId
+	pk.ContainerType = containerType
+	pk.Position = position
+	pk.EntityUniqueId = entityUniqueId
+
+	return pk
+}
+
+func (protocol *PacketManager) GetContainerClose(windowId byte) packets.IPacket {
+	var pk = bedrock.NewContainerClosePacket()
+
+	pk.WindowId = windowId
+
+	return pk
+}
+
+func (protocol *PacketManager) GetInventoryContent(windowId uint32, items []*items.Stack) packets.IPacket {
+	var pk = bedrock.NewInventoryContentPacket()
+
+	pk.WindowId = windowId
+	pk.Items = items
+
+	return pk
+}
+
+func (protocol *PacketManager) GetInventorySlot(windowId uint32, slot uint32, item *items.Stack) packets.IPacket {
+	var pk = bedrock.NewInventorySlotPacket()
+
+	pk.WindowId = windowId
+	pk.Slot = slot
+	pk.Item = item
+
+	return pk
+}
+
+func (protocol *PacketManager) GetModalFormRequest(formId uint32, formData string) packets.IPacket {
+	var pk = bedrock.NewModalFormRequestPacket()
+
+	pk.FormId = formId
+	pk.FormData = formData
+
+	return pk
+}
+
+func (protocol *PacketManager) GetRemoveObjective(objectiveName string) packets.IPacket {
+	var pk = bedrock.NewRemoveObjectivePacket()
+
+	pk.ObjectiveName = objectiveName
+
+	return pk
+}
+
+func (protocol *PacketManager) GetSetDisplayObjective(displaySlot, objectiveName, displayName, criteriaName string, sortOrder int32) packets.IPacket {
+	var pk = bedrock.NewSetDisplayObjectivePacket()
+
+	pk.DisplaySlot = displaySlot
+	pk.ObjectiveName = objectiveName
+	pk.DisplayName = displayName
+	pk.CriteriaName = criteriaName
+	pk.SortOrder = sortOrder
+
+	return pk
+}
+
+func (protocol *PacketManager) GetSetScore(actionType byte, entries []types.ScoreboardEntry) packets.IPacket {
+	var pk = bedrock.NewSetScorePacket()
+
+	pk.ActionType = actionType
+	pk.Entries = entries
+
+	return pk
+}
+
+func (protocol *PacketManager) GetBossEvent(bossUniqueId int64, eventType uint32, playerUniqueId int64, title string, healthPercentage float32) packets.IPacket {
+	var pk = bedrock.NewBossEventPacket()
+
+	pk.BossEntityUniqueId = bossUniqueId
+	pk.EventType = eventType
+	pk.PlayerEntityUniqueId = playerUniqueId
+	pk.Title = title
+	pk.HealthPercentage = healthPercentage
+
+	return pk
+}
+
+func (protocol *PacketManager) GetSpawnParticleEffect(position r3.Vector, particleName string) packets.IPacket {
+	var pk = bedrock.NewSpawnParticleEffectPacket()
+
+	pk.Position = position
+	pk.ParticleName = particleName
+
+	return pk
+}
+
+func (protocol *PacketManager) GetSetPlayerGameType(gameMode int32) packets.IPacket {
+	var pk = bedrock.NewSetPlayerGameTypePacket()
+
+	pk.GameMode = gameMode
+
+	return pk
+}
+
+func (protocol *PacketManager) GetSetTitle(titleType int32, text string, fadeIn, stay, fadeOut int32) packets.IPacket {
+	var pk = bedrock.NewSetTitlePacket()
+
+	pk.TitleType = titleType
+	pk.Text = text
+	pk.FadeIn = fadeIn
+	pk.Stay = stay
+	pk.FadeOut = fadeOut
+
+	return pk
+}
+
+func (protocol *PacketManager) GetEntityEvent(runtimeId uint64, event byte, data int32) packets.IPacket {
+	var pk = bedrock.NewEntityEventPacket()
+
+	pk.RuntimeId = runtimeId
+	pk.Event = event
+	pk.Data = data
+
+	return pk
+}
+
+func (protocol *PacketManager) GetRespawn(position r3.Vector) packets.IPacket {
+	var pk = bedrock.NewRespawnPacket()
+
+	pk.Position = position
+
+	return pk
+}
+
+func (protocol *PacketManager) GetItemStackResponse(responses []types.ItemStackResponse) packets.IPacket {
+	var pk = bedrock.NewItemStackResponsePacket()
+
+	pk.Responses = responses
+
+	return pk
+}
+
+func (protocol *PacketManager) GetAdventureSettings(flags, commandPermission, actionPermissions, permissionLevel uint32, entityUniqueId int64) packets.IPacket {
+	var pk = bedrock.NewAdventureSettingsPacket()
+
+	pk.Flags = flags
+	pk.CommandPermission = commandPermission
+	pk.ActionPermissions = actionPermissions
+	pk.PermissionLevel = permissionLevel
+	pk.EntityUniqueId = entityUniqueId
+
+	return pk
+}
+
+func (protocol *PacketManager) GetSetTime(time int32) packets.IPacket {
+	var pk = bedrock.NewSetTimePacket()
+
+	pk.Time = time
+
+	return pk
+}
+
+func (protocol *PacketManager) GetLevelEvent(eventId int32, position r3.Vector, data int32) packets.IPacket {
+	var pk = bedrock.NewLevelEventPacket()
+
+	pk.EventId = eventId
+	pk.Position = position
+	pk.Data = data
+
+	return pk
+}
+
+func (protocol *PacketManager) GetGameRulesChanged(gameRules map[string]types.GameRuleEntry) packets.IPacket {
+	var pk = bedrock.NewGameRulesChangedPacket()
+
+	pk.GameRules = gameRules
+
+	return pk
+}
+
+func (protocol *PacketManager) GetSetEntityMotion(runtimeId uint64, motion r3.Vector) packets.IPacket {
+	var pk = bedrock.NewSetEntityMotionPacket()
+
+	pk.RuntimeId = runtimeId
+	pk.Motion = motion
+
+	return pk
+}
+
+func (protocol *PacketManager) GetAddItemEntity(uniqueId int64, runtimeId uint64, item *items.Stack, position, motion r3.Vector) packets.IPacket {
+	var pk = bedrock.NewAddItemEntityPacket()
+
+	pk.UniqueId = uniqueId
+	pk.RuntimeId = runtimeId
+	pk.Item = item
+	pk.Position = position
+	pk.Motion = motion
+
+	return pk
+}
+
+func (protocol *PacketManager) GetTakeItemEntity(itemRuntimeId, playerRuntimeId uint64) packets.IPacket {
+	var pk = bedrock.NewTakeItemEntityPacket()
+
+	pk.ItemRuntimeId = itemRuntimeId
+	pk.PlayerRuntimeId = playerRuntimeId
+
+	return pk
+}
+
+func (protocol *PacketManager) GetCommandOutput(origin types.CommandOrigin, successCount uint32, messages []types.CommandOutputMessage) packets.IPacket {
+	var pk = bedrock.NewCommandOutputPacket()
+
+	pk.Origin = origin
+	pk.SuccessCount = successCount
+	pk.Messages = messages
+
+	return pk
+}
+
+func (protocol *PacketManager) GetUpdateSoftEnum(enumName string, values []string, updateType byte) packets.IPacket {
+	var pk = bedrock.NewUpdateSoftEnumPacket()
+
+	pk.EnumName = enumName
+	pk.Values = values
+	pk.Type = updateType
+
+	return pk
+}
+
+func (protocol *PacketManager) GetChangeDimension(dimension int32, position r3.Vector, respawn bool) packets.IPacket {
+	var pk = bedrock.NewChangeDimensionPacket()
+
+	pk.Dimension = dimension
+	pk.Position = position
+	pk.Respawn = respawn
+
+	return pk
+}
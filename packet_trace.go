@@ -0,0 +1,44 @@
+package gomine
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/irmine/gomine/net"
+	"github.com/irmine/gomine/text"
+)
+
+const (
+	// DefaultPacketTraceDuration is the duration packets of a player are traced for if no duration is given.
+	DefaultPacketTraceDuration = time.Minute
+	// MaxPacketTraceDuration is the longest duration packets of a player may be traced for.
+	MaxPacketTraceDuration = 10 * time.Minute
+)
+
+// TracePackets starts tracing the packets of the session to a new file in the debug directory of the server,
+// for the given duration, which is limited to MaxPacketTraceDuration. Only packets with the names in the filter
+// are traced, or all packets if the filter is empty. The path of the file is returned.
+func (server *Server) TracePackets(session *net.MinecraftSession, duration time.Duration, filter []string) (string, error) {
+	if duration > MaxPacketTraceDuration {
+		duration = MaxPacketTraceDuration
+	}
+	var directory = server.ServerPath + "debug/"
+	if err := os.MkdirAll(directory, 0755); err != nil {
+		return "", err
+	}
+	var path = directory + "trace-" + session.GetName() + "-" + time.Now().Format("2006-01-02-15-04-05") + ".log"
+	var file, err = os.Create(path)
+	if err != nil {
+		return "", err
+	}
+	fmt.Fprintln(file, "Packet trace of", session.GetName(), "using protocol", session.GetProtocolNumber(), "("+session.GetGameVersion()+"), started at", time.Now().Format(time.RFC3339), "for", duration)
+
+	var tracer = net.NewPacketTracer(file, duration, filter)
+	var name = session.GetName()
+	tracer.StopFunction = func() {
+		text.DefaultLogger.Info("Packet trace of", name, "saved to", path)
+	}
+	session.StartPacketTrace(tracer)
+	return path, nil
+}
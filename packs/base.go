@@ -6,7 +6,6 @@ import (
 	"encoding/json"
 	"errors"
 	"io/ioutil"
-	"os"
 	"strconv"
 	"strings"
 )
@@ -26,6 +25,7 @@ type Pack interface {
 	GetFileSize() int64
 	GetSha256() string
 	GetChunk(offset int, length int) []byte
+	GetChunkCount(chunkSize int) int32
 	GetPath() string
 }
 
@@ -38,6 +38,7 @@ type Base struct {
 	size     int64
 	sha256   []byte
 	packType PackType
+	readErr  error
 }
 
 // Manifest is a struct that contains all information of a pack.
@@ -65,23 +66,26 @@ type Manifest struct {
 
 // newBase returns a new base at the given path and with the given pack type.
 func newBase(path string, packType PackType) *Base {
-	var reader, _ = os.Open(path)
-	var content, _ = ioutil.ReadAll(reader)
+	var content, err = ioutil.ReadFile(path)
 	var sha = sha256.Sum256(content)
 
 	var shaBytes []byte
 	for _, b := range sha {
 		shaBytes = append(shaBytes, b)
 	}
-	return &Base{path, &Manifest{}, content, int64(len(content)), shaBytes, packType}
+	return &Base{path, &Manifest{}, content, int64(len(content)), shaBytes, packType, err}
 }
 
 // Load loads the pack, and returns an error if any.
 func (pack *Base) Load() error {
+	if pack.readErr != nil {
+		return pack.readErr
+	}
 	var zipFile, err = zip.OpenReader(pack.packPath)
 	if err != nil {
-		panic(err)
+		return err
 	}
+	defer zipFile.Close()
 
 	for _, file := range zipFile.File {
 		if file.Name != "manifest.json" && file.Name != "pack_manifest.json" {
@@ -193,3 +197,9 @@ func (pack *Base) GetChunk(offset int, length int) []byte {
 	}
 	return pack.content[offset : offset+length]
 }
+
+// GetChunkCount returns the amount of chunks the pack is split in,
+// when sending it in chunks of the given size.
+func (pack *Base) GetChunkCount(chunkSize int) int32 {
+	return int32((pack.size + int64(chunkSize) - 1) / int64(chunkSize))
+}
@@ -0,0 +1,59 @@
+package packs
+
+import (
+	"archive/zip"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const testManifest = `{
+	"header": {"description": "Test", "name": "Test", "uuid": "0f6d9a53-b4a8-4b5e-9d21-5a1d6a1c1d01", "version": [1, 0, 0]},
+	"modules": [{"description": "Test", "type": "resources", "uuid": "0f6d9a53-b4a8-4b5e-9d21-5a1d6a1c1d02", "version": [1, 0, 0]}]
+}`
+
+func TestResourcePackChunks(t *testing.T) {
+	var dir, err = ioutil.TempDir("", "packs")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	var path = filepath.Join(dir, "test.mcpack")
+	file, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var writer = zip.NewWriter(file)
+	manifest, _ := writer.Create("manifest.json")
+	manifest.Write([]byte(testManifest))
+	writer.Close()
+	file.Close()
+
+	var pack = NewResourcePack(path)
+	if err := pack.Load(); err != nil {
+		t.Fatal(err)
+	}
+	if err := pack.ValidateManifest(); err != nil {
+		t.Fatal(err)
+	}
+	var chunkSize = int(pack.GetFileSize()/3) + 1
+	if count := pack.GetChunkCount(chunkSize); count != 3 {
+		t.Error("expected 3 chunks, got:", count)
+	}
+	if len(pack.GetChunk(chunkSize*2, chunkSize)) != int(pack.GetFileSize())-chunkSize*2 {
+		t.Error("last chunk should contain the remainder of the pack")
+	}
+
+	var manager = NewManager(dir)
+	manager.resourcePacks[pack.GetUUID()] = pack
+	if manager.GetPackById(pack.GetUUID()+"_"+pack.GetVersion()) == nil {
+		t.Error("pack should be found by its client pack ID")
+	}
+	if manager.GetPackById("unknown_1.0.0") != nil {
+		t.Error("unknown pack ID should return nil")
+	}
+	if NewResourcePack(filepath.Join(dir, "missing.mcpack")).Load() == nil {
+		t.Error("loading a missing pack should return an error")
+	}
+}
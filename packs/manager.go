@@ -4,6 +4,7 @@ import (
 	"github.com/irmine/gomine/text"
 	"io/ioutil"
 	"path/filepath"
+	"strings"
 )
 
 // Manager manages the loading of packs.
@@ -159,8 +160,17 @@ func (manager *Manager) GetBehaviorPack(uuid string) *BehaviorPack {
 
 // GetPack returns any pack that has the given UUID, or nil if none was found.
 func (manager *Manager) GetPack(uuid string) Pack {
-	if manager.GetResourcePack(uuid) != nil {
-		return manager.GetResourcePack(uuid)
+	if manager.IsResourcePackLoaded(uuid) {
+		return manager.resourcePacks[uuid]
 	}
-	return manager.GetBehaviorPack(uuid)
+	if manager.IsBehaviorPackLoaded(uuid) {
+		return manager.behaviorPacks[uuid]
+	}
+	return nil
+}
+
+// GetPackById returns any pack by the pack ID sent by clients, or nil if none was found.
+// Clients identify packs by their UUID and version, joined as "uuid_version".
+func (manager *Manager) GetPackById(id string) Pack {
+	return manager.GetPack(strings.Split(id, "_")[0])
 }
@@ -0,0 +1,87 @@
+package parties
+
+import (
+	"github.com/irmine/gomine/events"
+	"github.com/irmine/gomine/net"
+)
+
+const (
+	CreateEventName   events.Name = "PartyCreateEvent"
+	InviteEventName   events.Name = "PartyInviteEvent"
+	JoinEventName     events.Name = "PartyJoinEvent"
+	LeaveEventName    events.Name = "PartyLeaveEvent"
+	DisbandEventName  events.Name = "PartyDisbandEvent"
+	TeleportEventName events.Name = "PartyTeleportEvent"
+)
+
+// CreateEvent gets called when a player creates a new party.
+// Cancelling the event prevents the party from being created.
+type CreateEvent struct {
+	events.Cancellable
+	Party *Party
+}
+
+// GetName returns the name of the event.
+func (event *CreateEvent) GetName() events.Name {
+	return CreateEventName
+}
+
+// InviteEvent gets called when the leader of a party invites a player.
+// Cancelling the event prevents the invite from being sent.
+type InviteEvent struct {
+	events.Cancellable
+	Party  *Party
+	Target *net.MinecraftSession
+}
+
+// GetName returns the name of the event.
+func (event *InviteEvent) GetName() events.Name {
+	return InviteEventName
+}
+
+// JoinEvent gets called when a player accepts an invite and joins a party.
+// Cancelling the event prevents the player from joining.
+type JoinEvent struct {
+	events.Cancellable
+	Party   *Party
+	Session *net.MinecraftSession
+}
+
+// GetName returns the name of the event.
+func (event *JoinEvent) GetName() events.Name {
+	return JoinEventName
+}
+
+// LeaveEvent gets called after a player left, or got kicked from a party.
+type LeaveEvent struct {
+	Party      *Party
+	PlayerName string
+	Kicked     bool
+}
+
+// GetName returns the name of the event.
+func (event *LeaveEvent) GetName() events.Name {
+	return LeaveEventName
+}
+
+// DisbandEvent gets called after a party got disbanded.
+type DisbandEvent struct {
+	Party *Party
+}
+
+// GetName returns the name of the event.
+func (event *DisbandEvent) GetName() events.Name {
+	return DisbandEventName
+}
+
+// TeleportEvent gets called when all members of a party get teleported to the leader.
+// Cancelling the event prevents the teleport.
+type TeleportEvent struct {
+	events.Cancellable
+	Party *Party
+}
+
+// GetName returns the name of the event.
+func (event *TeleportEvent) GetName() events.Name {
+	return TeleportEventName
+}
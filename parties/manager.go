@@ -0,0 +1,237 @@
+package parties
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/irmine/gomine/events"
+	"github.com/irmine/gomine/net"
+)
+
+var (
+	// AlreadyInParty gets returned when a player tries to
+	// create or join a party while already being in one.
+	AlreadyInParty = errors.New("player is already in a party")
+	// NotInParty gets returned when a player is not in any party.
+	NotInParty = errors.New("player is not in a party")
+	// NotLeader gets returned when a member that is not
+	// the leader tries to perform a leader only action.
+	NotLeader = errors.New("player is not the party leader")
+	// NotInvited gets returned when a player tries to join
+	// a party without a pending invite.
+	NotInvited = errors.New("player has no pending invite")
+	// PartyFull gets returned when a player tries to join
+	// a party that has reached the max members.
+	PartyFull = errors.New("party is full")
+	// Cancelled gets returned when an action
+	// got cancelled by an event handler.
+	Cancelled = errors.New("cancelled by event handler")
+)
+
+// Manager manages all parties on the server.
+// It keeps track of which party every player is in,
+// and calls party events on the event manager.
+type Manager struct {
+	// MaxMembers is the maximum amount of members in a party, including the leader.
+	// A max members of 0 or lower means parties have no limit.
+	MaxMembers int
+	// InviteTimeout is the duration after which pending invites expire.
+	InviteTimeout time.Duration
+
+	mutex     sync.RWMutex
+	events    *events.Manager
+	parties   map[string]*Party
+	partyChat map[string]bool
+}
+
+// NewManager returns a new party manager, calling events on the given event manager.
+func NewManager(eventManager *events.Manager) *Manager {
+	return &Manager{8, time.Minute, sync.RWMutex{}, eventManager, make(map[string]*Party), make(map[string]bool)}
+}
+
+// GetParty returns the party the player with the given name is in.
+// A bool is returned indicating if the player was in a party.
+func (manager *Manager) GetParty(name string) (*Party, bool) {
+	manager.mutex.RLock()
+	defer manager.mutex.RUnlock()
+	var party, ok = manager.parties[name]
+	return party, ok
+}
+
+// GetParties returns all parties on the server.
+func (manager *Manager) GetParties() []*Party {
+	manager.mutex.RLock()
+	defer manager.mutex.RUnlock()
+	var parties []*Party
+	var seen = make(map[*Party]bool)
+	for _, party := range manager.parties {
+		if !seen[party] {
+			seen[party] = true
+			parties = append(parties, party)
+		}
+	}
+	return parties
+}
+
+// Create creates a new party with the given session as leader.
+func (manager *Manager) Create(leader *net.MinecraftSession) (*Party, error) {
+	if _, ok := manager.GetParty(leader.GetName()); ok {
+		return nil, AlreadyInParty
+	}
+	var party = NewParty(leader)
+	if !manager.events.Call(&CreateEvent{Party: party}) {
+		return nil, Cancelled
+	}
+	manager.mutex.Lock()
+	manager.parties[leader.GetName()] = party
+	manager.mutex.Unlock()
+	return party, nil
+}
+
+// Invite invites the target to the party of the inviter.
+// Only the leader of a party can invite players.
+func (manager *Manager) Invite(inviter *net.MinecraftSession, target *net.MinecraftSession) error {
+	var party, ok = manager.GetParty(inviter.GetName())
+	if !ok {
+		return NotInParty
+	}
+	if !party.IsLeader(inviter.GetName()) {
+		return NotLeader
+	}
+	if _, ok := manager.GetParty(target.GetName()); ok {
+		return AlreadyInParty
+	}
+	if !manager.events.Call(&InviteEvent{Party: party, Target: target}) {
+		return Cancelled
+	}
+	party.addInvite(target.GetName(), manager.InviteTimeout)
+	return nil
+}
+
+// Accept accepts a pending invite of the party of the given leader,
+// making the session join that party.
+func (manager *Manager) Accept(session *net.MinecraftSession, leaderName string) error {
+	if _, ok := manager.GetParty(session.GetName()); ok {
+		return AlreadyInParty
+	}
+	var party, ok = manager.GetParty(leaderName)
+	if !ok || !party.IsInvited(session.GetName()) {
+		return NotInvited
+	}
+	if manager.MaxMembers > 0 && party.GetMemberCount() >= manager.MaxMembers {
+		return PartyFull
+	}
+	if !manager.events.Call(&JoinEvent{Party: party, Session: session}) {
+		return Cancelled
+	}
+	party.addMember(session)
+	manager.mutex.Lock()
+	manager.parties[session.GetName()] = party
+	manager.mutex.Unlock()
+	return nil
+}
+
+// Leave removes the player with the given name from its party.
+// The party gets disbanded if no members are left,
+// and a new leader is picked if the leader left.
+func (manager *Manager) Leave(name string) error {
+	var party, ok = manager.GetParty(name)
+	if !ok {
+		return NotInParty
+	}
+	manager.removeMember(party, name, false)
+	return nil
+}
+
+// Kick kicks the player with the given name from the party of the leader.
+func (manager *Manager) Kick(leader *net.MinecraftSession, name string) error {
+	var party, ok = manager.GetParty(leader.GetName())
+	if !ok {
+		return NotInParty
+	}
+	if !party.IsLeader(leader.GetName()) {
+		return NotLeader
+	}
+	if !party.HasMember(name) || name == leader.GetName() {
+		return NotInParty
+	}
+	manager.removeMember(party, name, true)
+	return nil
+}
+
+// Disband disbands the party of the given leader, removing all its members.
+func (manager *Manager) Disband(leader *net.MinecraftSession) error {
+	var party, ok = manager.GetParty(leader.GetName())
+	if !ok {
+		return NotInParty
+	}
+	if !party.IsLeader(leader.GetName()) {
+		return NotLeader
+	}
+	manager.disband(party)
+	return nil
+}
+
+// Teleport teleports all members of the party of the leader to the leader.
+func (manager *Manager) Teleport(leader *net.MinecraftSession) error {
+	var party, ok = manager.GetParty(leader.GetName())
+	if !ok {
+		return NotInParty
+	}
+	if !party.IsLeader(leader.GetName()) {
+		return NotLeader
+	}
+	if !manager.events.Call(&TeleportEvent{Party: party}) {
+		return Cancelled
+	}
+	party.TeleportToLeader()
+	return nil
+}
+
+// SetPartyChat sets if chat messages of the player with
+// the given name should be sent to its party only.
+func (manager *Manager) SetPartyChat(name string, value bool) {
+	manager.mutex.Lock()
+	if value {
+		manager.partyChat[name] = true
+	} else {
+		delete(manager.partyChat, name)
+	}
+	manager.mutex.Unlock()
+}
+
+// IsPartyChat checks if chat messages of the player with
+// the given name should be sent to its party only.
+func (manager *Manager) IsPartyChat(name string) bool {
+	manager.mutex.RLock()
+	defer manager.mutex.RUnlock()
+	return manager.partyChat[name]
+}
+
+// removeMember removes a member from the party,
+// and disbands the party if it has no members left.
+func (manager *Manager) removeMember(party *Party, name string, kicked bool) {
+	party.removeMember(name)
+	manager.mutex.Lock()
+	delete(manager.parties, name)
+	delete(manager.partyChat, name)
+	manager.mutex.Unlock()
+
+	manager.events.Call(&LeaveEvent{Party: party, PlayerName: name, Kicked: kicked})
+	if party.GetMemberCount() == 0 {
+		manager.events.Call(&DisbandEvent{Party: party})
+	}
+}
+
+// disband removes all members from the party.
+func (manager *Manager) disband(party *Party) {
+	for name := range party.GetMembers() {
+		party.removeMember(name)
+		manager.mutex.Lock()
+		delete(manager.parties, name)
+		delete(manager.partyChat, name)
+		manager.mutex.Unlock()
+	}
+	manager.events.Call(&DisbandEvent{Party: party})
+}
@@ -0,0 +1,166 @@
+package parties
+
+import (
+	"sync"
+	"time"
+
+	"github.com/irmine/gomine/net"
+	"github.com/irmine/gomine/net/packets/data"
+	"github.com/irmine/gomine/net/packets/types"
+	"github.com/irmine/gomine/text"
+)
+
+// Party is a group of players led by a single leader.
+// Parties only exist in memory, and get disbanded
+// once the last member leaves.
+type Party struct {
+	mutex   sync.RWMutex
+	leader  string
+	members map[string]*net.MinecraftSession
+	invites map[string]time.Time
+}
+
+// NewParty returns a new party with the given session as leader.
+func NewParty(leader *net.MinecraftSession) *Party {
+	return &Party{leader: leader.GetName(), members: map[string]*net.MinecraftSession{leader.GetName(): leader}, invites: make(map[string]time.Time)}
+}
+
+// GetLeaderName returns the name of the leader of the party.
+func (party *Party) GetLeaderName() string {
+	party.mutex.RLock()
+	defer party.mutex.RUnlock()
+	return party.leader
+}
+
+// GetLeader returns the session of the leader of the party.
+func (party *Party) GetLeader() *net.MinecraftSession {
+	party.mutex.RLock()
+	defer party.mutex.RUnlock()
+	return party.members[party.leader]
+}
+
+// IsLeader checks if the player with the given name is the leader of the party.
+func (party *Party) IsLeader(name string) bool {
+	return party.GetLeaderName() == name
+}
+
+// SetLeader makes the member with the given name the leader of the party.
+// Returns false if the player was not a member of the party.
+func (party *Party) SetLeader(name string) bool {
+	party.mutex.Lock()
+	defer party.mutex.Unlock()
+	if _, ok := party.members[name]; !ok {
+		return false
+	}
+	party.leader = name
+	return true
+}
+
+// GetMembers returns a name => session map of all members of the party, including the leader.
+func (party *Party) GetMembers() map[string]*net.MinecraftSession {
+	party.mutex.RLock()
+	defer party.mutex.RUnlock()
+	var members = make(map[string]*net.MinecraftSession, len(party.members))
+	for name, session := range party.members {
+		members[name] = session
+	}
+	return members
+}
+
+// GetMemberCount returns the amount of members in the party, including the leader.
+func (party *Party) GetMemberCount() int {
+	party.mutex.RLock()
+	defer party.mutex.RUnlock()
+	return len(party.members)
+}
+
+// HasMember checks if the party has a member with the given name.
+func (party *Party) HasMember(name string) bool {
+	party.mutex.RLock()
+	defer party.mutex.RUnlock()
+	var _, ok = party.members[name]
+	return ok
+}
+
+// addMember adds a member to the party and removes its invite.
+func (party *Party) addMember(session *net.MinecraftSession) {
+	party.mutex.Lock()
+	party.members[session.GetName()] = session
+	delete(party.invites, session.GetName())
+	party.mutex.Unlock()
+}
+
+// removeMember removes a member from the party.
+// A new leader gets picked if the leader was removed.
+func (party *Party) removeMember(name string) {
+	party.mutex.Lock()
+	defer party.mutex.Unlock()
+	delete(party.members, name)
+	if party.leader != name {
+		return
+	}
+	party.leader = ""
+	for member := range party.members {
+		party.leader = member
+		break
+	}
+}
+
+// addInvite invites the player with the given name, valid for the given duration.
+func (party *Party) addInvite(name string, duration time.Duration) {
+	party.mutex.Lock()
+	party.invites[name] = time.Now().Add(duration)
+	party.mutex.Unlock()
+}
+
+// IsInvited checks if the player with the given name has a pending invite that has not yet expired.
+func (party *Party) IsInvited(name string) bool {
+	party.mutex.Lock()
+	defer party.mutex.Unlock()
+	var expiry, ok = party.invites[name]
+	if !ok {
+		return false
+	}
+	if time.Now().After(expiry) {
+		delete(party.invites, name)
+		return false
+	}
+	return true
+}
+
+// BroadcastMessage broadcasts a message to all members of the party.
+func (party *Party) BroadcastMessage(message ...interface{}) {
+	for _, session := range party.GetMembers() {
+		session.SendMessage(message...)
+	}
+}
+
+// SendChat sends a chat message of a member to all members of the party.
+func (party *Party) SendChat(sender *net.MinecraftSession, message string) {
+	for _, session := range party.GetMembers() {
+		session.SendText(types.Text{
+			Message:    text.BrightCyan + "[Party] " + text.Reset + "<" + sender.GetDisplayName() + "> " + message,
+			SourceXUID: sender.GetXUID(),
+			TextType:   data.TextChat,
+		})
+	}
+}
+
+// TeleportToLeader teleports all members of the party to the leader.
+// Members in a different dimension than the leader are not teleported.
+func (party *Party) TeleportToLeader() {
+	var leader = party.GetLeader()
+	if leader == nil || leader.GetPlayer() == nil {
+		return
+	}
+	var player = leader.GetPlayer()
+	for name, session := range party.GetMembers() {
+		if name == leader.GetName() || session.GetPlayer() == nil {
+			continue
+		}
+		if session.GetPlayer().GetDimension() != player.GetDimension() {
+			continue
+		}
+		session.Teleport(player.GetPosition(), player.GetRotation())
+	}
+}
@@ -1,16 +1,19 @@
 package permissions
 
 // Group is a struct used for basic permission managing.
-// Groups can be granted a set of permissions.
+// Groups can be granted a set of permissions,
+// and inherit the permissions of their parent groups.
 type Group struct {
 	name        string
 	level       int
+	prefix      string
 	permissions map[string]*Permission
+	parents     []*Group
 }
 
 // NewGroup returns a new group with the given name and permission level.
 func NewGroup(name string, level int) *Group {
-	return &Group{name, level, make(map[string]*Permission)}
+	return &Group{name, level, "", make(map[string]*Permission), nil}
 }
 
 // GetName returns the name of the group.
@@ -18,15 +21,86 @@ func (group *Group) GetName() string {
 	return group.name
 }
 
+// GetLevel returns the permission level of the group.
+func (group *Group) GetLevel() int {
+	return group.level
+}
+
+// SetLevel sets the permission level of the group.
+func (group *Group) SetLevel(level int) {
+	group.level = level
+}
+
+// GetPrefix returns the chat prefix of the group.
+func (group *Group) GetPrefix() string {
+	return group.prefix
+}
+
+// SetPrefix sets the chat prefix of the group,
+// which gets displayed in front of the names of its members in chat.
+func (group *Group) SetPrefix(prefix string) {
+	group.prefix = prefix
+}
+
 // GetPermissions returns a name => permission map of all permissions of the group.
 func (group *Group) GetPermissions() map[string]*Permission {
 	return group.permissions
 }
 
 // HasPermission checks if the group has a permission with the name.
+// Wildcard permissions such as `gomine.command.*` are matched,
+// and the permissions of all parent groups are checked too.
 func (group *Group) HasPermission(permission string) bool {
-	var _, ok = group.permissions[permission]
-	return ok
+	return group.hasPermission(permission, make(map[*Group]bool))
+}
+
+// hasPermission checks if the group or any of its parents has the permission.
+// Visited groups are skipped, so that inheritance cycles do not recurse forever.
+func (group *Group) hasPermission(permission string, visited map[*Group]bool) bool {
+	if visited[group] {
+		return false
+	}
+	visited[group] = true
+	if _, ok := group.permissions[permission]; ok {
+		return true
+	}
+	for granted := range group.permissions {
+		if Matches(granted, permission) {
+			return true
+		}
+	}
+	for _, parent := range group.parents {
+		if parent.hasPermission(permission, visited) {
+			return true
+		}
+	}
+	return false
+}
+
+// GetParents returns all parent groups the group inherits permissions from.
+func (group *Group) GetParents() []*Group {
+	return group.parents
+}
+
+// AddParent makes the group inherit the permissions of the parent group.
+// Unlike InheritGroup, permissions added to the parent later on are inherited too.
+func (group *Group) AddParent(parent *Group) {
+	for _, existing := range group.parents {
+		if existing == parent {
+			return
+		}
+	}
+	group.parents = append(group.parents, parent)
+}
+
+// RemoveParent removes the parent group with the given name.
+func (group *Group) RemoveParent(name string) {
+	for i, parent := range group.parents {
+		if parent.GetName() == name {
+			group.parents = append(group.parents[:i], group.parents[i+1:]...)
+			return
+		}
+	}
 }
 
 // AddPermission adds a permission to the group.
@@ -2,24 +2,49 @@ package permissions
 
 import (
 	"errors"
+	"sort"
+	"strings"
+	"sync"
 )
 
 // Manager is a struct used to manage permissions and groups.
 // It provides helper functions and functions to register groups and permissions.
+// Groups and the groups and permissions of players may be persisted using a store,
+// in which case every change made through the manager API gets saved automatically.
 type Manager struct {
+	// UpdateFunction gets called with the name of a player
+	// every time the group or permissions of the player changed,
+	// so that the changes can be applied to the player if online.
+	UpdateFunction func(player string)
+
+	mutex        sync.RWMutex
 	defaultGroup *Group
 	permissions  map[string]*Permission
 	groups       map[string]*Group
+	players      map[string]*PlayerEntry
+	store        Store
+}
+
+// PlayerEntry holds the group and the additional permissions of a single player.
+type PlayerEntry struct {
+	Group       string   `yaml:"Group,omitempty"`
+	Permissions []string `yaml:"Permissions,omitempty"`
 }
 
 var (
 	UnknownPermission = errors.New("unknown permission")
 	UnknownGroup      = errors.New("unknown group")
+	GroupExists       = errors.New("group already exists")
 )
 
 // NewManager returns a new permission manager.
 func NewManager() *Manager {
-	return &Manager{nil, make(map[string]*Permission), make(map[string]*Group)}
+	return &Manager{
+		UpdateFunction: func(string) {},
+		permissions:    make(map[string]*Permission),
+		groups:         make(map[string]*Group),
+		players:        make(map[string]*PlayerEntry),
+	}
 }
 
 // GetDefaultGroup returns the default group of the manager.
@@ -34,26 +59,46 @@ func (manager *Manager) SetDefaultGroup(group *Group) {
 
 // AddGroup adds a new group to the manager.
 func (manager *Manager) AddGroup(group *Group) {
+	manager.mutex.Lock()
 	manager.groups[group.GetName()] = group
+	manager.mutex.Unlock()
 }
 
 // GetGroup returns a group in the manager with the given name and an error if it could not be found.
 func (manager *Manager) GetGroup(name string) (*Group, error) {
-	if !manager.GroupExists(name) {
+	manager.mutex.RLock()
+	defer manager.mutex.RUnlock()
+	var group, ok = manager.groups[name]
+	if !ok {
 		return nil, UnknownGroup
 	}
-	return manager.groups[name], nil
+	return group, nil
+}
+
+// GetGroups returns a name => group map of all groups.
+func (manager *Manager) GetGroups() map[string]*Group {
+	manager.mutex.RLock()
+	defer manager.mutex.RUnlock()
+	var groups = make(map[string]*Group, len(manager.groups))
+	for name, group := range manager.groups {
+		groups[name] = group
+	}
+	return groups
 }
 
 // GroupExists checks if a group with the given name exists.
 func (manager *Manager) GroupExists(name string) bool {
+	manager.mutex.RLock()
+	defer manager.mutex.RUnlock()
 	var _, ok = manager.groups[name]
 	return ok
 }
 
 // RemoveGroup removes a group with the given name from the manager.
 func (manager *Manager) RemoveGroup(name string) {
+	manager.mutex.Lock()
 	delete(manager.groups, name)
+	manager.mutex.Unlock()
 }
 
 // GetPermission returns a permission by its name, and an error if it could not be found.
@@ -74,3 +119,212 @@ func (manager *Manager) IsPermissionRegistered(name string) bool {
 func (manager *Manager) RegisterPermission(permission *Permission) {
 	manager.permissions[permission.GetName()] = permission
 }
+
+// SetStore sets the store used to persist groups and players.
+func (manager *Manager) SetStore(store Store) {
+	manager.store = store
+}
+
+// GetStore returns the store used to persist groups and players, or nil if none was set.
+func (manager *Manager) GetStore() Store {
+	return manager.store
+}
+
+// Load loads all groups and players from the store of the manager.
+func (manager *Manager) Load() error {
+	if manager.store == nil {
+		return nil
+	}
+	return manager.store.Load(manager)
+}
+
+// Save saves all groups and players to the store of the manager.
+func (manager *Manager) Save() error {
+	if manager.store == nil {
+		return nil
+	}
+	return manager.store.Save(manager)
+}
+
+// CreateGroup creates a new group with the given name and permission level.
+// GroupExists gets returned if a group with the name already exists.
+func (manager *Manager) CreateGroup(name string, level int) (*Group, error) {
+	if manager.GroupExists(name) {
+		return nil, GroupExists
+	}
+	var group = NewGroup(name, level)
+	manager.AddGroup(group)
+	return group, manager.Save()
+}
+
+// DeleteGroup deletes the group with the given name.
+// Groups inheriting from the group no longer do,
+// and players in the group are moved to the default group.
+func (manager *Manager) DeleteGroup(name string) error {
+	var group, err = manager.GetGroup(name)
+	if err != nil {
+		return err
+	}
+	manager.RemoveGroup(name)
+	for _, other := range manager.GetGroups() {
+		other.RemoveParent(name)
+	}
+	var updated []string
+	manager.mutex.Lock()
+	for player, entry := range manager.players {
+		if entry.Group == group.GetName() {
+			entry.Group = ""
+			updated = append(updated, player)
+		}
+	}
+	manager.mutex.Unlock()
+	for _, player := range updated {
+		manager.UpdateFunction(player)
+	}
+	return manager.Save()
+}
+
+// AddGroupPermission grants the permission with the given name to a group.
+func (manager *Manager) AddGroupPermission(groupName string, permission string) error {
+	var group, err = manager.GetGroup(groupName)
+	if err != nil {
+		return err
+	}
+	group.AddPermission(manager.getPermission(permission))
+	return manager.Save()
+}
+
+// RemoveGroupPermission revokes the permission with the given name from a group.
+func (manager *Manager) RemoveGroupPermission(groupName string, permission string) error {
+	var group, err = manager.GetGroup(groupName)
+	if err != nil {
+		return err
+	}
+	group.RemovePermission(permission)
+	return manager.Save()
+}
+
+// AddGroupParent makes a group inherit all permissions of the parent group.
+func (manager *Manager) AddGroupParent(groupName string, parentName string) error {
+	var group, err = manager.GetGroup(groupName)
+	if err != nil {
+		return err
+	}
+	parent, err := manager.GetGroup(parentName)
+	if err != nil {
+		return err
+	}
+	group.AddParent(parent)
+	return manager.Save()
+}
+
+// RemoveGroupParent makes a group no longer inherit the permissions of the parent group.
+func (manager *Manager) RemoveGroupParent(groupName string, parentName string) error {
+	var group, err = manager.GetGroup(groupName)
+	if err != nil {
+		return err
+	}
+	group.RemoveParent(parentName)
+	return manager.Save()
+}
+
+// GetPlayerGroup returns the group of the player with the given name.
+// The default group gets returned if the player has no group.
+func (manager *Manager) GetPlayerGroup(player string) *Group {
+	manager.mutex.RLock()
+	var entry, ok = manager.players[strings.ToLower(player)]
+	manager.mutex.RUnlock()
+	if !ok || entry.Group == "" {
+		return manager.GetDefaultGroup()
+	}
+	var group, err = manager.GetGroup(entry.Group)
+	if err != nil {
+		return manager.GetDefaultGroup()
+	}
+	return group
+}
+
+// SetPlayerGroup moves the player with the given name to a group.
+func (manager *Manager) SetPlayerGroup(player string, groupName string) error {
+	if !manager.GroupExists(groupName) {
+		return UnknownGroup
+	}
+	manager.mutex.Lock()
+	manager.getEntry(player).Group = groupName
+	manager.mutex.Unlock()
+
+	manager.UpdateFunction(player)
+	return manager.Save()
+}
+
+// GetPlayerPermissions returns the permissions granted to the player with the given name,
+// not including the permissions of the group of the player.
+func (manager *Manager) GetPlayerPermissions(player string) []*Permission {
+	manager.mutex.RLock()
+	var entry, ok = manager.players[strings.ToLower(player)]
+	var names []string
+	if ok {
+		names = append(names, entry.Permissions...)
+	}
+	manager.mutex.RUnlock()
+
+	var permissions = make([]*Permission, len(names))
+	for i, name := range names {
+		permissions[i] = manager.getPermission(name)
+	}
+	return permissions
+}
+
+// AddPlayerPermission grants the permission with the given name to a player.
+func (manager *Manager) AddPlayerPermission(player string, permission string) error {
+	manager.mutex.Lock()
+	var entry = manager.getEntry(player)
+	var exists = false
+	for _, name := range entry.Permissions {
+		exists = exists || name == permission
+	}
+	if !exists {
+		entry.Permissions = append(entry.Permissions, permission)
+		sort.Strings(entry.Permissions)
+	}
+	manager.mutex.Unlock()
+
+	manager.UpdateFunction(player)
+	return manager.Save()
+}
+
+// RemovePlayerPermission revokes the permission with the given name from a player.
+func (manager *Manager) RemovePlayerPermission(player string, permission string) error {
+	manager.mutex.Lock()
+	var entry = manager.getEntry(player)
+	for i, name := range entry.Permissions {
+		if name == permission {
+			entry.Permissions = append(entry.Permissions[:i], entry.Permissions[i+1:]...)
+			break
+		}
+	}
+	manager.mutex.Unlock()
+
+	manager.UpdateFunction(player)
+	return manager.Save()
+}
+
+// getEntry returns the entry of the player with the given name, creating it if it does not exist.
+// The mutex of the manager must be locked while calling getEntry.
+func (manager *Manager) getEntry(player string) *PlayerEntry {
+	var entry, ok = manager.players[strings.ToLower(player)]
+	if !ok {
+		entry = &PlayerEntry{}
+		manager.players[strings.ToLower(player)] = entry
+	}
+	return entry
+}
+
+// getPermission returns the registered permission with the given name,
+// or a new custom permission if it was not registered.
+func (manager *Manager) getPermission(name string) *Permission {
+	if permission, err := manager.GetPermission(name); err == nil {
+		return permission
+	}
+	return NewPermission(name, LevelCustom)
+}
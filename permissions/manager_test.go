@@ -0,0 +1,87 @@
+package permissions
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestMatches(t *testing.T) {
+	var cases = []struct {
+		granted    string
+		permission string
+		matches    bool
+	}{
+		{"gomine.command.kit", "gomine.command.kit", true},
+		{"gomine.command.*", "gomine.command.kit", true},
+		{"gomine.*", "gomine.command.kit", true},
+		{"*", "anything", true},
+		{"gomine.command.*", "gomine.summon", false},
+		{"gomine.command.*", "gomine.commands", false},
+	}
+	for _, c := range cases {
+		if Matches(c.granted, c.permission) != c.matches {
+			t.Error("unexpected match result for", c.granted, c.permission)
+		}
+	}
+}
+
+func TestInheritance(t *testing.T) {
+	var member = NewGroup("member", LevelMember)
+	var operator = NewGroup("operator", LevelOperator)
+	member.AddPermission(NewPermission("gomine.command.kit", LevelMember))
+	operator.AddParent(member)
+	member.AddParent(operator)
+
+	if !operator.HasPermission("gomine.command.kit") {
+		t.Error("operator did not inherit permission of member")
+	}
+	if member.HasPermission("gomine.summon") {
+		t.Error("member has permission it was never granted")
+	}
+}
+
+func TestFileStore(t *testing.T) {
+	var dir, err = ioutil.TempDir("", "permissions")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	var store = NewFileStore(filepath.Join(dir, "groups.yml"), filepath.Join(dir, "players.yml"))
+
+	var manager = NewManager()
+	manager.SetStore(store)
+	if err := manager.Load(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := manager.CreateGroup("builder", LevelMember); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := manager.CreateGroup("builder", LevelMember); err != GroupExists {
+		t.Error("expected group exists error, got:", err)
+	}
+	manager.AddGroupPermission("builder", "gomine.build.*")
+	manager.AddGroupParent("builder", "member")
+	manager.SetPlayerGroup("Steve", "builder")
+	manager.AddPlayerPermission("Steve", "gomine.summon")
+
+	var loaded = NewManager()
+	loaded.SetStore(store)
+	if err := loaded.Load(); err != nil {
+		t.Fatal(err)
+	}
+	var group = loaded.GetPlayerGroup("steve")
+	if group.GetName() != "builder" || !group.HasPermission("gomine.build.place") {
+		t.Error("player group was not persisted:", group.GetName())
+	}
+	if len(group.GetParents()) != 1 || group.GetParents()[0].GetName() != "member" {
+		t.Error("group parents were not persisted")
+	}
+	if permissions := loaded.GetPlayerPermissions("Steve"); len(permissions) != 1 || permissions[0].GetName() != "gomine.summon" {
+		t.Error("player permissions were not persisted")
+	}
+	if loaded.GetPlayerGroup("Alex") != loaded.GetDefaultGroup() {
+		t.Error("unknown player did not get the default group")
+	}
+}
@@ -1,5 +1,9 @@
 package permissions
 
+import (
+	"strings"
+)
+
 // Permission is a struct with a name, a default level and children.
 // Every child permission can in turn have its own child permissions.
 type Permission struct {
@@ -43,3 +47,14 @@ func (permission *Permission) HasChild(name string) bool {
 	var _, ok = permission.children[name]
 	return ok
 }
+
+// Matches checks if the granted permission grants the given permission.
+// Granted permissions ending with `.*` grant all permissions starting with them,
+// for example `gomine.command.*` grants `gomine.command.stop`,
+// and the `*` permission grants every permission.
+func Matches(granted string, permission string) bool {
+	if granted == permission || granted == "*" {
+		return true
+	}
+	return strings.HasSuffix(granted, ".*") && strings.HasPrefix(permission, granted[:len(granted)-1])
+}
@@ -0,0 +1,151 @@
+package permissions
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sort"
+
+	"gopkg.in/yaml.v2"
+)
+
+// Store is used to persist the groups of a manager,
+// and the groups and permissions of players.
+type Store interface {
+	// Load loads all groups and players into the manager.
+	Load(manager *Manager) error
+	// Save saves all groups and players of the manager.
+	Save(manager *Manager) error
+}
+
+// FileStore is a store saving groups and players in two YAML files.
+// JSON files are accepted too, as JSON is a subset of YAML.
+type FileStore struct {
+	groupsPath  string
+	playersPath string
+}
+
+// groupsFile is the layout of the groups file.
+type groupsFile struct {
+	DefaultGroup string                  `yaml:"Default Group"`
+	Groups       map[string]*groupRecord `yaml:"Groups"`
+}
+
+// groupRecord is a single group in the groups file.
+type groupRecord struct {
+	Level       int      `yaml:"Level"`
+	Prefix      string   `yaml:"Prefix,omitempty"`
+	Inherits    []string `yaml:"Inherits,omitempty"`
+	Permissions []string `yaml:"Permissions,omitempty"`
+}
+
+// NewFileStore returns a new file store using the groups and players file at the given paths.
+func NewFileStore(groupsPath string, playersPath string) *FileStore {
+	return &FileStore{groupsPath, playersPath}
+}
+
+// Load loads all groups and players from the files into the manager.
+// A member and an operator group get created if the groups file does not exist.
+func (store *FileStore) Load(manager *Manager) error {
+	if _, err := os.Stat(store.groupsPath); os.IsNotExist(err) {
+		var member = NewGroup("member", LevelMember)
+		var operator = NewGroup("operator", LevelOperator)
+		operator.AddParent(member)
+		operator.AddPermission(manager.getPermission("gomine.*"))
+		manager.AddGroup(member)
+		manager.AddGroup(operator)
+		manager.SetDefaultGroup(member)
+		return store.Save(manager)
+	}
+	var file, err = ioutil.ReadFile(store.groupsPath)
+	if err != nil {
+		return err
+	}
+	var groups = groupsFile{}
+	if err := yaml.Unmarshal(file, &groups); err != nil {
+		return err
+	}
+	for name, record := range groups.Groups {
+		var group = NewGroup(name, record.Level)
+		group.SetPrefix(record.Prefix)
+		for _, permission := range record.Permissions {
+			group.AddPermission(manager.getPermission(permission))
+		}
+		manager.AddGroup(group)
+	}
+	for name, record := range groups.Groups {
+		var group, _ = manager.GetGroup(name)
+		for _, parentName := range record.Inherits {
+			var parent, err = manager.GetGroup(parentName)
+			if err != nil {
+				return fmt.Errorf("group %v inherits unknown group %v", name, parentName)
+			}
+			group.AddParent(parent)
+		}
+	}
+	if groups.DefaultGroup != "" {
+		var group, err = manager.GetGroup(groups.DefaultGroup)
+		if err != nil {
+			return fmt.Errorf("unknown default group %v", groups.DefaultGroup)
+		}
+		manager.SetDefaultGroup(group)
+	}
+
+	if _, err := os.Stat(store.playersPath); os.IsNotExist(err) {
+		return nil
+	}
+	file, err = ioutil.ReadFile(store.playersPath)
+	if err != nil {
+		return err
+	}
+	var players = make(map[string]*PlayerEntry)
+	if err := yaml.Unmarshal(file, &players); err != nil {
+		return err
+	}
+	manager.mutex.Lock()
+	for name, entry := range players {
+		*manager.getEntry(name) = *entry
+	}
+	manager.mutex.Unlock()
+	return nil
+}
+
+// Save saves all groups and players of the manager to the files.
+func (store *FileStore) Save(manager *Manager) error {
+	var groups = groupsFile{Groups: make(map[string]*groupRecord)}
+	if manager.GetDefaultGroup() != nil {
+		groups.DefaultGroup = manager.GetDefaultGroup().GetName()
+	}
+	for name, group := range manager.GetGroups() {
+		var record = &groupRecord{Level: group.GetLevel(), Prefix: group.GetPrefix()}
+		for _, parent := range group.GetParents() {
+			record.Inherits = append(record.Inherits, parent.GetName())
+		}
+		for permission := range group.GetPermissions() {
+			record.Permissions = append(record.Permissions, permission)
+		}
+		sort.Strings(record.Permissions)
+		groups.Groups[name] = record
+	}
+	var content, err = yaml.Marshal(groups)
+	if err != nil {
+		return err
+	}
+	if err := ioutil.WriteFile(store.groupsPath, content, 0644); err != nil {
+		return err
+	}
+
+	manager.mutex.RLock()
+	var players = make(map[string]PlayerEntry, len(manager.players))
+	for name, entry := range manager.players {
+		if entry.Group != "" || len(entry.Permissions) != 0 {
+			players[name] = *entry
+		}
+	}
+	manager.mutex.RUnlock()
+	content, err = yaml.Marshal(players)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(store.playersPath, content, 0644)
+}
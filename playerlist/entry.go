@@ -0,0 +1,118 @@
+package playerlist
+
+import (
+	"github.com/golang/geo/r3"
+	"github.com/google/uuid"
+	"github.com/irmine/worlds/entities/data"
+)
+
+// Skin is the skin of a player, as shown in the player list and on the player itself.
+type Skin struct {
+	Id           string
+	Data         []byte
+	CapeData     []byte
+	GeometryName string
+	GeometryData string
+}
+
+// FakeEntry is an entry in the player list without a player behind it,
+// used by plugins to show menus or information in the player list.
+// Fields of an entry may not be changed while it is added to a manager.
+type FakeEntry struct {
+	UUID        uuid.UUID
+	XUID        string
+	Name        string
+	DisplayName string
+	Skin        Skin
+}
+
+// NewFakeEntry returns a new fake entry with a random UUID and the given name.
+// The entry has a blank skin with the default humanoid geometry.
+func NewFakeEntry(name string) *FakeEntry {
+	return &FakeEntry{
+		UUID:        uuid.New(),
+		Name:        name,
+		DisplayName: name,
+		Skin: Skin{
+			Id:           "Standard_Custom",
+			Data:         make([]byte, 64*64*4),
+			CapeData:     []byte{},
+			GeometryName: "geometry.humanoid.custom",
+		},
+	}
+}
+
+// The following functions implement protocol.PlayerListEntry,
+// so that fake entries can be sent like players.
+
+func (entry *FakeEntry) GetUniqueId() int64 {
+	return 0
+}
+
+func (entry *FakeEntry) GetRuntimeId() uint64 {
+	return 0
+}
+
+func (entry *FakeEntry) GetEntityType() uint32 {
+	return 0
+}
+
+func (entry *FakeEntry) GetPosition() r3.Vector {
+	return r3.Vector{}
+}
+
+func (entry *FakeEntry) GetMotion() r3.Vector {
+	return r3.Vector{}
+}
+
+func (entry *FakeEntry) GetRotation() data.Rotation {
+	return data.Rotation{}
+}
+
+func (entry *FakeEntry) GetAttributeMap() data.AttributeMap {
+	return data.NewAttributeMap()
+}
+
+func (entry *FakeEntry) GetEntityData() map[uint32][]interface{} {
+	return map[uint32][]interface{}{}
+}
+
+func (entry *FakeEntry) GetDisplayName() string {
+	return entry.DisplayName
+}
+
+func (entry *FakeEntry) GetName() string {
+	return entry.Name
+}
+
+func (entry *FakeEntry) GetXUID() string {
+	return entry.XUID
+}
+
+func (entry *FakeEntry) GetUUID() uuid.UUID {
+	return entry.UUID
+}
+
+func (entry *FakeEntry) GetSkinId() string {
+	return entry.Skin.Id
+}
+
+func (entry *FakeEntry) GetSkinData() []byte {
+	return entry.Skin.Data
+}
+
+func (entry *FakeEntry) GetCapeData() []byte {
+	return entry.Skin.CapeData
+}
+
+func (entry *FakeEntry) GetGeometryName() string {
+	return entry.Skin.GeometryName
+}
+
+func (entry *FakeEntry) GetGeometryData() string {
+	return entry.Skin.GeometryData
+}
+
+func (entry *FakeEntry) GetPlatform() int32 {
+	return 0
+}
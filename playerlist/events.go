@@ -0,0 +1,21 @@
+package playerlist
+
+import (
+	"github.com/irmine/gomine/events"
+	"github.com/irmine/gomine/net"
+)
+
+const SkinChangeEventName events.Name = "PlayerSkinChangeEvent"
+
+// SkinChangeEvent gets called when a player changes its skin.
+// The skin can be modified, and cancelling the event keeps the current skin of the player.
+type SkinChangeEvent struct {
+	events.Cancellable
+	Session *net.MinecraftSession
+	Skin    Skin
+}
+
+// GetName returns the name of the event.
+func (event *SkinChangeEvent) GetName() events.Name {
+	return SkinChangeEventName
+}
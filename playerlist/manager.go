@@ -0,0 +1,258 @@
+package playerlist
+
+import (
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/irmine/gomine/events"
+	"github.com/irmine/gomine/net"
+	"github.com/irmine/gomine/net/packets/data"
+	"github.com/irmine/gomine/net/protocol"
+)
+
+// Manager keeps the player list of all players up to date.
+// All online players are listed with their skin, along with fake entries added by plugins.
+type Manager struct {
+	// BatchPerTick makes the manager queue player list updates and player spawns,
+	// until they get sent when the manager gets flushed. Updates queued for the same
+	// session are coalesced into a single packet per list type, which cuts the
+	// amount of packets sent when many players join or leave at once.
+	BatchPerTick bool
+
+	sessionManager *net.SessionManager
+	eventManager   *events.Manager
+
+	mutex       sync.RWMutex
+	fakeEntries map[uuid.UUID]*FakeEntry
+
+	queueMutex sync.Mutex
+	queue      map[*net.MinecraftSession]*update
+}
+
+// update holds the queued player list updates and player spawns of a session.
+type update struct {
+	removed map[string]protocol.PlayerListEntry
+	added   map[string]protocol.PlayerListEntry
+	// spawns holds the sessions of which the player gets spawned to the session.
+	spawns []*net.MinecraftSession
+}
+
+// NewManager returns a new player list manager.
+func NewManager(sessionManager *net.SessionManager, eventManager *events.Manager) *Manager {
+	return &Manager{sessionManager: sessionManager, eventManager: eventManager, fakeEntries: make(map[uuid.UUID]*FakeEntry), queue: make(map[*net.MinecraftSession]*update)}
+}
+
+// Join sends the player list to a session that has just spawned,
+// and adds the player of the session to the player list of all others.
+func (manager *Manager) Join(session *net.MinecraftSession) {
+	var entries = manager.getFakeEntries()
+	var entry = map[string]protocol.PlayerListEntry{session.GetName(): session.GetPlayer()}
+	for name, online := range manager.sessionManager.GetSessions() {
+		if online.HasSpawned() {
+			entries[name] = online.GetPlayer()
+			if online != session {
+				manager.send(online, data.ListTypeAdd, entry)
+			}
+		}
+	}
+	entries[session.GetName()] = session.GetPlayer()
+	manager.send(session, data.ListTypeAdd, entries)
+}
+
+// Spawn spawns the player of a session that has just joined to all other sessions,
+// and the players of all other sessions to it. Players are not spawned to sessions hiding players.
+func (manager *Manager) Spawn(session *net.MinecraftSession) {
+	for _, online := range manager.sessionManager.GetSessions() {
+		if session.GetUUID() != online.GetUUID() {
+			manager.spawn(online, session)
+			manager.spawn(session, online)
+		}
+	}
+}
+
+// Quit removes the player of a session that left from the player list of all others.
+// Updates and spawns queued for the session, or of its player, are discarded.
+func (manager *Manager) Quit(session *net.MinecraftSession) {
+	manager.broadcast(data.ListTypeRemove, map[string]protocol.PlayerListEntry{session.GetName(): session.GetPlayer()})
+	manager.queueMutex.Lock()
+	delete(manager.queue, session)
+	for _, update := range manager.queue {
+		for i := 0; i < len(update.spawns); i++ {
+			if update.spawns[i] == session {
+				update.spawns = append(update.spawns[:i], update.spawns[i+1:]...)
+				i--
+			}
+		}
+	}
+	manager.queueMutex.Unlock()
+}
+
+// Flush sends all queued player list updates and player spawns.
+// Removed entries are sent before added entries, followed by the spawns.
+// Internal. Not to be used by plugins.
+func (manager *Manager) Flush() {
+	manager.queueMutex.Lock()
+	var queue = manager.queue
+	manager.queue = make(map[*net.MinecraftSession]*update)
+	manager.queueMutex.Unlock()
+	for session, update := range queue {
+		if len(update.removed) != 0 {
+			session.SendPlayerList(data.ListTypeRemove, update.removed)
+		}
+		if len(update.added) != 0 {
+			session.SendPlayerList(data.ListTypeAdd, update.added)
+		}
+		for _, spawned := range update.spawns {
+			spawnTo(spawned, session)
+		}
+	}
+}
+
+// Update updates the entry of the player of the session for all sessions,
+// for example after its display name was changed.
+func (manager *Manager) Update(session *net.MinecraftSession) {
+	var entry = map[string]protocol.PlayerListEntry{session.GetName(): session.GetPlayer()}
+	manager.broadcast(data.ListTypeRemove, entry)
+	manager.broadcast(data.ListTypeAdd, entry)
+}
+
+// ChangeSkin changes the skin of the player of the session after calling a skin change event,
+// and sends the new skin to all other sessions.
+// The current skin is sent back to the session if the event was cancelled.
+// A bool is returned indicating if the skin was changed.
+func (manager *Manager) ChangeSkin(session *net.MinecraftSession, skin Skin) bool {
+	var event = &SkinChangeEvent{Session: session, Skin: skin}
+	if !manager.eventManager.Call(event) {
+		session.SendSkin(session)
+		return false
+	}
+	var player = session.GetPlayer()
+	player.SetSkinId(event.Skin.Id)
+	player.SetSkinData(event.Skin.Data)
+	player.SetCapeData(event.Skin.CapeData)
+	player.SetGeometryName(event.Skin.GeometryName)
+	player.SetGeometryData(event.Skin.GeometryData)
+	for _, online := range manager.sessionManager.GetSessions() {
+		if online != session && online.HasSpawned() {
+			session.SendSkin(online)
+		}
+	}
+	return true
+}
+
+// AddFakeEntry adds the fake entry to the player list of all sessions,
+// including those of sessions that join later on.
+func (manager *Manager) AddFakeEntry(entry *FakeEntry) {
+	manager.mutex.Lock()
+	manager.fakeEntries[entry.UUID] = entry
+	manager.mutex.Unlock()
+	manager.broadcast(data.ListTypeAdd, map[string]protocol.PlayerListEntry{entry.UUID.String(): entry})
+}
+
+// RemoveFakeEntry removes the fake entry with the given UUID from the player list of all sessions.
+func (manager *Manager) RemoveFakeEntry(uuid uuid.UUID) {
+	manager.mutex.Lock()
+	var entry, ok = manager.fakeEntries[uuid]
+	delete(manager.fakeEntries, uuid)
+	manager.mutex.Unlock()
+	if ok {
+		manager.broadcast(data.ListTypeRemove, map[string]protocol.PlayerListEntry{uuid.String(): entry})
+	}
+}
+
+// GetFakeEntry returns the fake entry with the given UUID, and a bool indicating if it was found.
+func (manager *Manager) GetFakeEntry(uuid uuid.UUID) (*FakeEntry, bool) {
+	manager.mutex.RLock()
+	defer manager.mutex.RUnlock()
+	var entry, ok = manager.fakeEntries[uuid]
+	return entry, ok
+}
+
+// GetFakeEntries returns all fake entries added to the player list.
+func (manager *Manager) GetFakeEntries() []*FakeEntry {
+	manager.mutex.RLock()
+	defer manager.mutex.RUnlock()
+	var entries = make([]*FakeEntry, 0, len(manager.fakeEntries))
+	for _, entry := range manager.fakeEntries {
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+// getFakeEntries returns all fake entries as player list entries, keyed by their UUID.
+func (manager *Manager) getFakeEntries() map[string]protocol.PlayerListEntry {
+	manager.mutex.RLock()
+	defer manager.mutex.RUnlock()
+	var entries = make(map[string]protocol.PlayerListEntry, len(manager.fakeEntries))
+	for uuid, entry := range manager.fakeEntries {
+		entries[uuid.String()] = entry
+	}
+	return entries
+}
+
+// broadcast sends the player list entries to all spawned sessions.
+func (manager *Manager) broadcast(listType byte, entries map[string]protocol.PlayerListEntry) {
+	for _, online := range manager.sessionManager.GetSessions() {
+		if online.HasSpawned() {
+			manager.send(online, listType, entries)
+		}
+	}
+}
+
+// send sends the player list entries to the session, or queues them if updates are batched.
+func (manager *Manager) send(session *net.MinecraftSession, listType byte, entries map[string]protocol.PlayerListEntry) {
+	if !manager.BatchPerTick {
+		session.SendPlayerList(listType, entries)
+		return
+	}
+	manager.queueMutex.Lock()
+	manager.getUpdate(session).queue(listType, entries)
+	manager.queueMutex.Unlock()
+}
+
+// spawn spawns the player of the session to the viewer, or queues the spawn if updates are batched.
+func (manager *Manager) spawn(session *net.MinecraftSession, viewer *net.MinecraftSession) {
+	if !manager.BatchPerTick {
+		spawnTo(session, viewer)
+		return
+	}
+	manager.queueMutex.Lock()
+	var update = manager.getUpdate(viewer)
+	update.spawns = append(update.spawns, session)
+	manager.queueMutex.Unlock()
+}
+
+// getUpdate returns the queued update of the session, creating it if it does not yet exist.
+// The queue mutex must be locked.
+func (manager *Manager) getUpdate(session *net.MinecraftSession) *update {
+	var u, ok = manager.queue[session]
+	if !ok {
+		u = &update{removed: make(map[string]protocol.PlayerListEntry), added: make(map[string]protocol.PlayerListEntry)}
+		manager.queue[session] = u
+	}
+	return u
+}
+
+// queue coalesces the player list entries with those already queued.
+// A removal discards a queued addition of the same entry, but the removal itself is kept,
+// so that an entry removed and added again is refreshed on the client.
+func (u *update) queue(listType byte, entries map[string]protocol.PlayerListEntry) {
+	for key, entry := range entries {
+		if listType == data.ListTypeRemove {
+			delete(u.added, key)
+			u.removed[key] = entry
+		} else {
+			u.added[key] = entry
+		}
+	}
+}
+
+// spawnTo spawns the player of the session to the viewer, unless the viewer hides players.
+func spawnTo(session *net.MinecraftSession, viewer *net.MinecraftSession) {
+	if viewer.IsHidden(net.CategoryPlayers) {
+		return
+	}
+	session.GetPlayer().SpawnPlayerTo(viewer)
+	session.GetPlayer().AddViewer(viewer)
+	session.SendSkin(viewer)
+}
@@ -0,0 +1,59 @@
+package playerlist
+
+import (
+	"testing"
+
+	"github.com/irmine/gomine/events"
+	"github.com/irmine/gomine/net"
+	"github.com/irmine/gomine/net/packets/data"
+	"github.com/irmine/gomine/net/protocol"
+)
+
+func TestFakeEntries(t *testing.T) {
+	manager := NewManager(net.NewSessionManager(), events.NewManager())
+	var _ protocol.PlayerListEntry = &FakeEntry{}
+
+	entry := NewFakeEntry("Menu")
+	manager.AddFakeEntry(entry)
+	manager.AddFakeEntry(NewFakeEntry("Menu"))
+	if len(manager.GetFakeEntries()) != 2 {
+		t.Fatal("expected 2 fake entries, got:", len(manager.GetFakeEntries()))
+	}
+	if found, ok := manager.GetFakeEntry(entry.UUID); !ok || found != entry {
+		t.Error("fake entry was not found by its UUID")
+	}
+	if entries := manager.getFakeEntries(); entries[entry.UUID.String()] != entry {
+		t.Error("fake entry was not keyed by its UUID:", entries)
+	}
+
+	manager.RemoveFakeEntry(entry.UUID)
+	if _, ok := manager.GetFakeEntry(entry.UUID); ok || len(manager.GetFakeEntries()) != 1 {
+		t.Error("fake entry was not removed")
+	}
+}
+
+func TestQueue(t *testing.T) {
+	manager := NewManager(net.NewSessionManager(), events.NewManager())
+	manager.BatchPerTick = true
+	session := &net.MinecraftSession{}
+	steve, alex := NewFakeEntry("Steve"), NewFakeEntry("Alex")
+
+	manager.send(session, data.ListTypeAdd, map[string]protocol.PlayerListEntry{"Steve": steve})
+	manager.send(session, data.ListTypeAdd, map[string]protocol.PlayerListEntry{"Alex": alex})
+	manager.send(session, data.ListTypeRemove, map[string]protocol.PlayerListEntry{"Steve": steve})
+	update := manager.queue[session]
+	if len(update.added) != 1 || update.added["Alex"] != alex {
+		t.Error("additions were not coalesced:", update.added)
+	}
+	if len(update.removed) != 1 || update.removed["Steve"] != steve {
+		t.Error("removal was not queued:", update.removed)
+	}
+
+	manager.send(session, data.ListTypeAdd, map[string]protocol.PlayerListEntry{"Steve": steve})
+	if update.added["Steve"] != steve || update.removed["Steve"] != steve {
+		t.Error("entry added after its removal was not refreshed")
+	}
+	if len(manager.queue) != 1 {
+		t.Error("expected a single queued update, got:", len(manager.queue))
+	}
+}
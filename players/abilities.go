@@ -0,0 +1,105 @@
+package players
+
+import (
+	"time"
+)
+
+// FlagImmobile is the metadata flag preventing the client from moving its player.
+const FlagImmobile = 16
+
+// GetAllowFlight checks if the player is allowed to fly.
+func (player *Player) GetAllowFlight() bool {
+	return player.allowFlight
+}
+
+// SetAllowFlight sets if the player is allowed to fly.
+// Disallowing flight also stops the player from flying.
+func (player *Player) SetAllowFlight(value bool) {
+	if player.allowFlight == value {
+		return
+	}
+	player.allowFlight = value
+	if !value {
+		player.flying = false
+	}
+	player.abilitiesChanged = true
+}
+
+// IsFlying checks if the player is currently flying.
+func (player *Player) IsFlying() bool {
+	return player.flying
+}
+
+// SetFlying sets if the player is flying.
+// Players can only fly if flight is allowed, so false is returned
+// if the player was made to fly while flight is not allowed.
+func (player *Player) SetFlying(value bool) bool {
+	if value && !player.allowFlight {
+		return false
+	}
+	if player.flying != value {
+		player.flying = value
+		player.abilitiesChanged = true
+	}
+	return true
+}
+
+// HasAbilityUpdate checks if the abilities of the player changed since
+// the last time the abilities were sent, and marks the abilities as sent.
+func (player *Player) HasAbilityUpdate() bool {
+	var changed = player.abilitiesChanged
+	player.abilitiesChanged = false
+	return changed
+}
+
+// IsInvulnerable checks if the player is invulnerable, in which case it does not take any damage.
+func (player *Player) IsInvulnerable() bool {
+	return player.invulnerable
+}
+
+// SetInvulnerable sets if the player is invulnerable.
+func (player *Player) SetInvulnerable(value bool) {
+	player.invulnerable = value
+}
+
+// SetImmunity makes the player immune to damage for the given duration,
+// for example after being hit or respawning. A duration of 0 removes the immunity.
+// Immunity does not replace a longer immunity the player already has,
+// unless the immunity is removed.
+func (player *Player) SetImmunity(duration time.Duration) {
+	var until = time.Now().Add(duration)
+	if duration <= 0 || until.After(player.immuneUntil) {
+		player.immuneUntil = until
+	}
+}
+
+// IsImmune checks if the player is immune to damage,
+// either because it is invulnerable or because its immunity did not expire yet.
+func (player *Player) IsImmune() bool {
+	return player.invulnerable || time.Now().Before(player.immuneUntil)
+}
+
+// IsImmobile checks if the player is immobile, in which case its client does not let it move.
+func (player *Player) IsImmobile() bool {
+	return player.immobile
+}
+
+// SetImmobile sets if the player is immobile by setting its immobile metadata flag.
+// The metadata must be sent to the player and its viewers for the change to take effect.
+func (player *Player) SetImmobile(value bool) {
+	player.immobile = value
+	player.SetEntityProperty(FlagImmobile, value)
+}
+
+// IsFrozen checks if the player is frozen.
+func (player *Player) IsFrozen() bool {
+	return player.frozen
+}
+
+// SetFrozen sets if the player is frozen. Frozen players are immobile,
+// and movement of frozen players is rejected by the server,
+// so that modified clients can not move either.
+func (player *Player) SetFrozen(value bool) {
+	player.frozen = value
+	player.SetImmobile(value)
+}
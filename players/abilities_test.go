@@ -0,0 +1,68 @@
+package players
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestAbilities(t *testing.T) {
+	var player = NewPlayer(uuid.New(), "", 0, "Steve")
+	if player.SetFlying(true) {
+		t.Fatal("player could fly without flight being allowed")
+	}
+	if player.HasAbilityUpdate() {
+		t.Error("rejected flight caused an ability update")
+	}
+
+	player.SetAllowFlight(true)
+	if !player.SetFlying(true) || !player.IsFlying() {
+		t.Fatal("player could not fly with flight allowed")
+	}
+	if !player.HasAbilityUpdate() || player.HasAbilityUpdate() {
+		t.Error("ability update was not marked once")
+	}
+
+	player.SetAllowFlight(false)
+	if player.IsFlying() {
+		t.Error("player kept flying after flight was disallowed")
+	}
+	if !player.HasAbilityUpdate() {
+		t.Error("disallowing flight did not cause an ability update")
+	}
+}
+
+func TestImmunity(t *testing.T) {
+	var player = NewPlayer(uuid.New(), "", 0, "Steve")
+	if player.IsImmune() {
+		t.Fatal("player was immune by default")
+	}
+	player.SetInvulnerable(true)
+	if !player.IsImmune() {
+		t.Error("invulnerable player was not immune")
+	}
+	player.SetInvulnerable(false)
+
+	player.SetImmunity(time.Hour)
+	player.SetImmunity(time.Nanosecond)
+	if !player.IsImmune() {
+		t.Error("shorter immunity replaced a longer immunity")
+	}
+	player.SetImmunity(0)
+	if player.IsImmune() {
+		t.Error("immunity was not removed")
+	}
+}
+
+func TestFreeze(t *testing.T) {
+	var player = NewPlayer(uuid.New(), "", 0, "Steve")
+	player.SetFrozen(true)
+	if !player.IsFrozen() || !player.IsImmobile() {
+		t.Error("frozen player was not immobile")
+	}
+	player.SetFrozen(false)
+	if player.IsFrozen() || player.IsImmobile() {
+		t.Error("unfrozen player was still immobile")
+	}
+}
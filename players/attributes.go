@@ -0,0 +1,132 @@
+package players
+
+import (
+	"math"
+
+	"github.com/irmine/worlds/entities/data"
+)
+
+// Attributes of players modified by the server.
+const (
+	AttributeHealth        data.AttributeName = "minecraft:health"
+	AttributeHunger        data.AttributeName = "minecraft:player.hunger"
+	AttributeAbsorption    data.AttributeName = "minecraft:absorption"
+	AttributeMovementSpeed data.AttributeName = "minecraft:movement"
+)
+
+// Default values of the attributes of players.
+const (
+	DefaultMaxHealth     = 20
+	DefaultMaxHunger     = 20
+	DefaultMovementSpeed = 0.1
+)
+
+// getAttribute returns the attribute of the player with the given name.
+// The attribute is created with the given default and maximum value if the player does not have it yet.
+func (player *Player) getAttribute(name data.AttributeName, defaultValue, maxValue float32) *data.Attribute {
+	for _, attribute := range player.GetAttributeMap() {
+		if attribute.GetName() == name {
+			return attribute
+		}
+	}
+	var attribute = data.NewAttribute(name, defaultValue, maxValue)
+	attribute.DefaultValue = defaultValue
+	player.GetAttributeMap().SetAttribute(attribute)
+	return attribute
+}
+
+// setAttribute sets the value of the attribute with the given name,
+// clamping it between the minimum and maximum value of the attribute.
+func (player *Player) setAttribute(name data.AttributeName, defaultValue, maxValue float32, value float32) {
+	var attribute = player.getAttribute(name, defaultValue, maxValue)
+	if value < attribute.MinValue {
+		value = attribute.MinValue
+	}
+	if value > attribute.MaxValue {
+		value = attribute.MaxValue
+	}
+	if attribute.Value != value {
+		attribute.Value = value
+		player.attributesChanged = true
+	}
+}
+
+// GetHealth returns the health of the player.
+func (player *Player) GetHealth() float32 {
+	return player.getAttribute(AttributeHealth, DefaultMaxHealth, DefaultMaxHealth).Value
+}
+
+// SetHealth sets the health of the player,
+// clamped between 0 and the maximum health of the player.
+func (player *Player) SetHealth(health float32) {
+	player.setAttribute(AttributeHealth, DefaultMaxHealth, DefaultMaxHealth, health)
+}
+
+// GetMaxHealth returns the maximum health of the player.
+func (player *Player) GetMaxHealth() float32 {
+	return player.getAttribute(AttributeHealth, DefaultMaxHealth, DefaultMaxHealth).MaxValue
+}
+
+// SetMaxHealth sets the maximum health of the player.
+// The health of the player is lowered if it exceeds the new maximum.
+func (player *Player) SetMaxHealth(maxHealth float32) {
+	var attribute = player.getAttribute(AttributeHealth, DefaultMaxHealth, DefaultMaxHealth)
+	attribute.MaxValue = maxHealth
+	attribute.DefaultValue = maxHealth
+	player.attributesChanged = true
+	if attribute.Value > maxHealth {
+		attribute.Value = maxHealth
+	}
+}
+
+// IsDead checks if the health of the player has dropped to 0.
+func (player *Player) IsDead() bool {
+	return player.GetHealth() <= 0
+}
+
+// GetHunger returns the hunger (food level) of the player.
+func (player *Player) GetHunger() float32 {
+	return player.getAttribute(AttributeHunger, DefaultMaxHunger, DefaultMaxHunger).Value
+}
+
+// SetHunger sets the hunger (food level) of the player, clamped between 0 and 20.
+func (player *Player) SetHunger(hunger float32) {
+	player.setAttribute(AttributeHunger, DefaultMaxHunger, DefaultMaxHunger, hunger)
+}
+
+// GetAbsorption returns the absorption health of the player,
+// which gets taken damage before the health of the player.
+func (player *Player) GetAbsorption() float32 {
+	return player.getAttribute(AttributeAbsorption, 0, 16).Value
+}
+
+// SetAbsorption sets the absorption health of the player.
+func (player *Player) SetAbsorption(absorption float32) {
+	player.setAttribute(AttributeAbsorption, 0, 16, absorption)
+}
+
+// GetMovementSpeed returns the movement speed of the player.
+func (player *Player) GetMovementSpeed() float32 {
+	return player.getAttribute(AttributeMovementSpeed, DefaultMovementSpeed, math.MaxFloat32).Value
+}
+
+// SetMovementSpeed sets the movement speed of the player.
+func (player *Player) SetMovementSpeed(speed float32) {
+	player.setAttribute(AttributeMovementSpeed, DefaultMovementSpeed, math.MaxFloat32, speed)
+}
+
+// ResetAttributes resets the health, hunger and absorption of the player to their defaults,
+// for example after the player respawned.
+func (player *Player) ResetAttributes() {
+	player.SetHealth(player.GetMaxHealth())
+	player.SetHunger(DefaultMaxHunger)
+	player.SetAbsorption(0)
+}
+
+// HasAttributeUpdate checks if any attributes of the player changed since
+// the last time the attributes were sent, and marks the attributes as sent.
+func (player *Player) HasAttributeUpdate() bool {
+	var changed = player.attributesChanged
+	player.attributesChanged = false
+	return changed
+}
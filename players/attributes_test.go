@@ -0,0 +1,34 @@
+package players
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestHealth(t *testing.T) {
+	var player = NewPlayer(uuid.New(), "", 0, "Steve")
+	if player.GetHealth() != DefaultMaxHealth {
+		t.Error("player did not start with full health:", player.GetHealth())
+	}
+	player.HasAttributeUpdate()
+
+	player.SetHealth(25)
+	if player.GetHealth() != DefaultMaxHealth {
+		t.Error("health was not clamped to the maximum health:", player.GetHealth())
+	}
+	player.SetHealth(-4)
+	if !player.IsDead() || player.GetHealth() != 0 {
+		t.Error("player was not dead after health dropped below 0:", player.GetHealth())
+	}
+	if !player.HasAttributeUpdate() || player.HasAttributeUpdate() {
+		t.Error("attribute update was not marked once")
+	}
+
+	player.SetMaxHealth(40)
+	player.SetHunger(3)
+	player.ResetAttributes()
+	if player.GetHealth() != 40 || player.GetHunger() != DefaultMaxHunger {
+		t.Error("attributes were not reset:", player.GetHealth(), player.GetHunger())
+	}
+}
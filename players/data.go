@@ -0,0 +1,215 @@
+package players
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v2"
+)
+
+// InvalidName gets returned when loading or saving the data of a player with a name
+// that can not be used as file name, such as a name containing path separators.
+var InvalidName = errors.New("invalid player name")
+
+// Data is the persisted data of a player,
+// which is kept between sessions.
+type Data struct {
+	Name string `yaml:"Name"`
+	// FirstJoined is the unix time at which the player first joined the server.
+	// A first joined time of 0 means the player has not yet joined.
+	FirstJoined int64 `yaml:"First Joined"`
+
+	Muted bool `yaml:"Muted"`
+	// MuteExpiry is the unix time at which the mute expires.
+	// A mute expiry of 0 means the mute is permanent.
+	MuteExpiry int64  `yaml:"Mute Expiry"`
+	MuteReason string `yaml:"Mute Reason"`
+
+	Banned bool `yaml:"Banned"`
+	// BanExpiry is the unix time at which the ban expires.
+	// A ban expiry of 0 means the ban is permanent.
+	BanExpiry int64  `yaml:"Ban Expiry"`
+	BanReason string `yaml:"Ban Reason"`
+	// Whitelisted specifies if the player may join while the whitelist is enabled.
+	Whitelisted bool `yaml:"Whitelisted"`
+	// Language is the language chosen by the player, overriding the language of the client.
+	// An empty language means the language of the client is used.
+	Language string `yaml:"Language"`
+	// Nickname is the name shown in-game instead of the name of the player.
+	// An empty nickname means the name of the player is shown.
+	Nickname string `yaml:"Nickname"`
+
+	// KitClaims is a kit name => unix time map of the last time every kit was claimed.
+	KitClaims map[string]int64 `yaml:"Kit Claims"`
+
+	// LastDailyClaim is the unix time at which the last daily reward was claimed.
+	LastDailyClaim int64 `yaml:"Last Daily Claim"`
+	// DailyStreak is the amount of consecutive days the daily reward was claimed.
+	DailyStreak int `yaml:"Daily Streak"`
+	// Playtime is the total time played in seconds, excluding the current session.
+	Playtime int64 `yaml:"Playtime"`
+	// ClaimedMilestones are the names of all claimed playtime milestones.
+	ClaimedMilestones []string `yaml:"Claimed Milestones"`
+
+	// Stats is a stat name => value map of statistics of the player,
+	// such as kills, which are kept by plugins and ranked by leaderboards.
+	Stats map[string]float64 `yaml:"Stats"`
+
+	// Cosmetics is a category => name map of the cosmetics the player has equipped.
+	Cosmetics map[string]string `yaml:"Cosmetics"`
+}
+
+// NewData returns new empty data for the player with the given name.
+func NewData(name string) *Data {
+	return &Data{Name: name, KitClaims: make(map[string]int64), Stats: make(map[string]float64), Cosmetics: make(map[string]string)}
+}
+
+// IsMuted checks if the player is muted and the mute has not yet expired.
+func (data *Data) IsMuted() bool {
+	return data.Muted && (data.MuteExpiry == 0 || time.Now().Unix() < data.MuteExpiry)
+}
+
+// Mute mutes the player for the given duration with a reason.
+// A duration of 0 or lower mutes the player permanently.
+func (data *Data) Mute(duration time.Duration, reason string) {
+	data.Muted = true
+	data.MuteExpiry = 0
+	if duration > 0 {
+		data.MuteExpiry = time.Now().Add(duration).Unix()
+	}
+	data.MuteReason = reason
+}
+
+// Unmute unmutes the player.
+func (data *Data) Unmute() {
+	data.Muted = false
+	data.MuteExpiry = 0
+	data.MuteReason = ""
+}
+
+// IsBanned checks if the player is banned and the ban has not yet expired.
+func (data *Data) IsBanned() bool {
+	return data.Banned && (data.BanExpiry == 0 || time.Now().Unix() < data.BanExpiry)
+}
+
+// Ban bans the player for the given duration with a reason.
+// A duration of 0 or lower bans the player permanently.
+func (data *Data) Ban(duration time.Duration, reason string) {
+	data.Banned = true
+	data.BanExpiry = 0
+	if duration > 0 {
+		data.BanExpiry = time.Now().Add(duration).Unix()
+	}
+	data.BanReason = reason
+}
+
+// Unban unbans the player.
+func (data *Data) Unban() {
+	data.Banned = false
+	data.BanExpiry = 0
+	data.BanReason = ""
+}
+
+// DataStorage is used to load and save the data of players.
+type DataStorage interface {
+	// Load loads the data of the player with the given name.
+	// New empty data gets returned if the player has no stored data.
+	Load(name string) (*Data, error)
+	// Save saves the given player data.
+	Save(data *Data) error
+	// LoadAll loads the data of all players that have stored data.
+	LoadAll() ([]*Data, error)
+}
+
+// FileDataStorage is a data storage saving the data
+// of every player in a separate YAML file.
+type FileDataStorage struct {
+	path string
+}
+
+// NewFileDataStorage returns a new file data storage saving in the given directory.
+func NewFileDataStorage(path string) *FileDataStorage {
+	os.MkdirAll(path, 0700)
+	return &FileDataStorage{path}
+}
+
+// Load loads the data of the player with the given name from its file.
+// InvalidName is returned if the name can not be used as file name.
+func (storage *FileDataStorage) Load(name string) (*Data, error) {
+	if !IsValidName(name) {
+		return nil, InvalidName
+	}
+	var file, err = ioutil.ReadFile(storage.getFile(name))
+	if os.IsNotExist(err) {
+		return NewData(name), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var data = NewData(name)
+	if err := yaml.Unmarshal(file, data); err != nil {
+		return nil, err
+	}
+	if data.KitClaims == nil {
+		data.KitClaims = make(map[string]int64)
+	}
+	if data.Stats == nil {
+		data.Stats = make(map[string]float64)
+	}
+	if data.Cosmetics == nil {
+		data.Cosmetics = make(map[string]string)
+	}
+	return data, nil
+}
+
+// Save saves the given player data to its file.
+// InvalidName is returned if the name of the player can not be used as file name.
+func (storage *FileDataStorage) Save(data *Data) error {
+	if !IsValidName(data.Name) {
+		return InvalidName
+	}
+	var content, err = yaml.Marshal(data)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(storage.getFile(data.Name), content, 0644)
+}
+
+// LoadAll loads the data of all players from their files.
+func (storage *FileDataStorage) LoadAll() ([]*Data, error) {
+	var files, err = ioutil.ReadDir(storage.path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var all []*Data
+	for _, file := range files {
+		var name = strings.TrimSuffix(file.Name(), ".yml")
+		if file.IsDir() || !strings.HasSuffix(file.Name(), ".yml") || !IsValidName(name) {
+			continue
+		}
+		var data, err = storage.Load(name)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, data)
+	}
+	return all, nil
+}
+
+// getFile returns the file of the player with the given name.
+// Names are case insensitive.
+func (storage *FileDataStorage) getFile(name string) string {
+	return storage.path + strings.ToLower(name) + ".yml"
+}
+
+// IsValidName checks if the name of a player can be used as file name,
+// which it can not if it is empty, holds path separators or refers to a directory.
+func IsValidName(name string) bool {
+	return name != "" && name != "." && name != ".." && !strings.ContainsAny(name, "/\\\x00")
+}
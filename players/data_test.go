@@ -0,0 +1,41 @@
+package players
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestDataStorage(t *testing.T) {
+	dir, _ := ioutil.TempDir("", "players")
+	defer os.RemoveAll(dir)
+	storage := NewFileDataStorage(dir + "/")
+
+	data, err := storage.Load("Steve")
+	if err != nil || data.IsMuted() {
+		t.Fatal("new player data is muted or could not be loaded:", err)
+	}
+	data.Mute(time.Hour, "spam")
+	if err := storage.Save(data); err != nil {
+		t.Fatal("could not save player data:", err)
+	}
+	data, err = storage.Load("steve")
+	if err != nil || !data.IsMuted() || data.MuteReason != "spam" {
+		t.Error("mute was not persisted:", data, err)
+	}
+	data.Mute(-time.Hour, "")
+	data.MuteExpiry = time.Now().Add(-time.Minute).Unix()
+	if data.IsMuted() {
+		t.Error("expired mute is still active")
+	}
+
+	for _, name := range []string{"", "..", "../../x", "a/b", "a\\b"} {
+		if _, err := storage.Load(name); err != InvalidName {
+			t.Error("data of invalid name", name, "was loaded:", err)
+		}
+		if err := storage.Save(NewData(name)); err != InvalidName {
+			t.Error("data of invalid name", name, "was saved:", err)
+		}
+	}
+}
@@ -0,0 +1,42 @@
+package players
+
+import (
+	"time"
+)
+
+// IDs of status effects that are taken into account by the server.
+const (
+	EffectHaste         = 3
+	EffectMiningFatigue = 4
+)
+
+// effect is a status effect applied on a player.
+type effect struct {
+	amplifier int32
+	until     time.Time
+}
+
+// AddEffect applies the status effect with the given ID and amplifier on the player for the given duration,
+// replacing the effect if it was already applied. Effects are only taken into account by the server,
+// and are not sent to the client.
+func (player *Player) AddEffect(id int32, amplifier int32, duration time.Duration) {
+	if player.effects == nil {
+		player.effects = make(map[int32]effect)
+	}
+	player.effects[id] = effect{amplifier: amplifier, until: time.Now().Add(duration)}
+}
+
+// RemoveEffect removes the status effect with the given ID from the player.
+func (player *Player) RemoveEffect(id int32) {
+	delete(player.effects, id)
+}
+
+// GetEffectLevel returns the level of the status effect with the given ID, which is its amplifier plus one,
+// or 0 if the effect is not applied on the player or has expired.
+func (player *Player) GetEffectLevel(id int32) int32 {
+	var effect, ok = player.effects[id]
+	if !ok || time.Now().After(effect.until) {
+		return 0
+	}
+	return effect.amplifier + 1
+}
@@ -0,0 +1,27 @@
+package players
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestEffects(t *testing.T) {
+	var player = NewPlayer(uuid.New(), "", 0, "Steve")
+	if level := player.GetEffectLevel(EffectHaste); level != 0 {
+		t.Error("player had haste without it being applied:", level)
+	}
+	player.AddEffect(EffectHaste, 1, time.Minute)
+	if level := player.GetEffectLevel(EffectHaste); level != 2 {
+		t.Error("expected haste level 2, got", level)
+	}
+	player.AddEffect(EffectMiningFatigue, 0, -time.Second)
+	if level := player.GetEffectLevel(EffectMiningFatigue); level != 0 {
+		t.Error("expired effect was still applied:", level)
+	}
+	player.RemoveEffect(EffectHaste)
+	if level := player.GetEffectLevel(EffectHaste); level != 0 {
+		t.Error("removed effect was still applied:", level)
+	}
+}
@@ -0,0 +1,90 @@
+package players
+
+import (
+	"math"
+
+	"github.com/golang/geo/r3"
+	"github.com/irmine/gomine/net/packets/data"
+	data2 "github.com/irmine/worlds/entities/data"
+)
+
+// MovementSettings control how often and how the movement of entities gets broadcast to viewers.
+// Sending movement less often saves bandwidth, but makes the interpolation of clients less smooth.
+type MovementSettings struct {
+	// Interval is the amount of ticks between movement updates of an entity.
+	// Teleports are always sent immediately.
+	Interval int
+	// PositionThreshold is the distance in blocks an entity must have moved since the last update
+	// for its position to be sent again.
+	PositionThreshold float64
+	// RotationThreshold is the angle in degrees an entity must have turned since the last update
+	// for its rotation to be sent again. Updates only changing the rotation are sent as rotation-only updates.
+	RotationThreshold float64
+	// TeleportDistance is the distance in blocks above which movement is sent as teleport,
+	// so that clients do not interpolate the entity through the world. 0 never sends movement as teleport.
+	TeleportDistance float64
+}
+
+// DefaultMovementSettings are the movement settings of entities created after they are set.
+// Movement is sent every tick by default, for the smoothest interpolation.
+var DefaultMovementSettings = MovementSettings{Interval: 1, PositionThreshold: 0.001, RotationThreshold: 0.5, TeleportDistance: 8}
+
+// MovementTracker tracks the movement last sent of an entity,
+// and selects the mode its next movement update is sent with.
+type MovementTracker struct {
+	Settings MovementSettings
+
+	position r3.Vector
+	rotation data2.Rotation
+	ticks    int
+	teleport bool
+	sent     bool
+}
+
+// NewMovementTracker returns a new movement tracker with the settings.
+func NewMovementTracker(settings MovementSettings) *MovementTracker {
+	return &MovementTracker{Settings: settings}
+}
+
+// Teleport marks the next movement update to be sent immediately as teleport.
+func (tracker *MovementTracker) Teleport() {
+	tracker.teleport = true
+}
+
+// Update updates the tracker with the position and rotation of the entity, which should happen every tick.
+// The mode the movement should be sent with is returned: data.MoveTeleport for teleports and movement
+// further than the teleport distance, data.MovePitch if only the rotation changed, or data.MoveNormal.
+// A bool is returned indicating if the movement should be sent at all.
+func (tracker *MovementTracker) Update(position r3.Vector, rotation data2.Rotation) (byte, bool) {
+	tracker.ticks++
+	if !tracker.teleport && tracker.ticks < tracker.Settings.Interval {
+		return 0, false
+	}
+	var distance = position.Sub(tracker.position).Norm()
+	var moved = !tracker.sent || distance > tracker.Settings.PositionThreshold
+	var rotated = getAngle(rotation.Yaw, tracker.rotation.Yaw) > tracker.Settings.RotationThreshold ||
+		getAngle(rotation.HeadYaw, tracker.rotation.HeadYaw) > tracker.Settings.RotationThreshold ||
+		getAngle(rotation.Pitch, tracker.rotation.Pitch) > tracker.Settings.RotationThreshold
+	if !tracker.teleport && !moved && !rotated {
+		return 0, false
+	}
+
+	var mode byte = data.MoveNormal
+	if tracker.teleport || (tracker.Settings.TeleportDistance > 0 && tracker.sent && distance > tracker.Settings.TeleportDistance) {
+		mode = data.MoveTeleport
+	} else if !moved {
+		mode = data.MovePitch
+	}
+	tracker.position, tracker.rotation = position, rotation
+	tracker.ticks, tracker.teleport, tracker.sent = 0, false, true
+	return mode, true
+}
+
+// getAngle returns the smallest angle in degrees between two angles.
+func getAngle(a, b float64) float64 {
+	var angle = math.Mod(math.Abs(a-b), 360)
+	if angle > 180 {
+		angle = 360 - angle
+	}
+	return angle
+}
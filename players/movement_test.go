@@ -0,0 +1,41 @@
+package players
+
+import (
+	"testing"
+
+	"github.com/golang/geo/r3"
+	"github.com/irmine/gomine/net/packets/data"
+	data2 "github.com/irmine/worlds/entities/data"
+)
+
+func TestMovementTracker(t *testing.T) {
+	var tracker = NewMovementTracker(MovementSettings{Interval: 2, PositionThreshold: 0.01, RotationThreshold: 1, TeleportDistance: 8})
+	if _, ok := tracker.Update(r3.Vector{}, data2.Rotation{}); ok {
+		t.Error("movement was sent before the interval passed")
+	}
+	if mode, ok := tracker.Update(r3.Vector{}, data2.Rotation{}); !ok || mode != data.MoveNormal {
+		t.Error("initial movement was not sent:", mode, ok)
+	}
+
+	tracker.Update(r3.Vector{X: 0.005}, data2.Rotation{Yaw: 0.5})
+	if _, ok := tracker.Update(r3.Vector{X: 0.005}, data2.Rotation{Yaw: 359.5}); ok {
+		t.Error("movement below the thresholds was sent")
+	}
+	// The interval already passed, so the next change is sent immediately.
+	if mode, ok := tracker.Update(r3.Vector{}, data2.Rotation{HeadYaw: 90}); !ok || mode != data.MovePitch {
+		t.Error("rotation was not sent as rotation-only update:", mode, ok)
+	}
+	tracker.Update(r3.Vector{X: 1}, data2.Rotation{HeadYaw: 90})
+	if mode, ok := tracker.Update(r3.Vector{X: 1}, data2.Rotation{HeadYaw: 90}); !ok || mode != data.MoveNormal {
+		t.Error("movement was not sent as normal update:", mode, ok)
+	}
+	tracker.Update(r3.Vector{X: 20}, data2.Rotation{HeadYaw: 90})
+	if mode, ok := tracker.Update(r3.Vector{X: 20}, data2.Rotation{HeadYaw: 90}); !ok || mode != data.MoveTeleport {
+		t.Error("movement further than the teleport distance was not sent as teleport:", mode, ok)
+	}
+
+	tracker.Teleport()
+	if mode, ok := tracker.Update(r3.Vector{X: 21}, data2.Rotation{HeadYaw: 90}); !ok || mode != data.MoveTeleport {
+		t.Error("teleport was not sent immediately:", mode, ok)
+	}
+}
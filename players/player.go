@@ -2,8 +2,14 @@ package players
 
 import (
 	"github.com/google/uuid"
+	"github.com/irmine/gomine/ecs"
+	"github.com/irmine/gomine/items"
+	"github.com/irmine/gomine/items/inventory"
+	"github.com/irmine/gomine/net/packets/data"
 	"github.com/irmine/worlds/entities"
+	data2 "github.com/irmine/worlds/entities/data"
 	"math"
+	"time"
 )
 
 type Player struct {
@@ -20,8 +26,49 @@ type Player struct {
 	capeData     []byte
 	geometryName string
 	geometryData string
+
+	inventory        *inventory.Inventory
+	cursorInventory  *inventory.Inventory
+	offHandInventory *inventory.Inventory
+	craftingGrid     *inventory.Inventory
+	heldSlot         int
+
+	data *Data
+
+	attributesChanged bool
+
+	allowFlight      bool
+	flying           bool
+	abilitiesChanged bool
+
+	invulnerable bool
+	immuneUntil  time.Time
+
+	immobile bool
+	frozen   bool
+
+	effects map[int32]effect
+
+	movement *MovementTracker
 }
 
+// InventorySize is the amount of slots in the inventory of a player,
+// including the hotbar.
+const InventorySize = 36
+
+// entityDataNameTag is the key of the name tag in entity data.
+const entityDataNameTag = 4
+
+// CraftingGridSize is the amount of slots in the crafting grid of a player,
+// which is large enough for the grid of a crafting table.
+const CraftingGridSize = 9
+
+// HotbarSize is the amount of hotbar slots, which are the first slots of the inventory of a player.
+const HotbarSize = 9
+
+// Identifier is the identifier of the entity type of players.
+const Identifier = "minecraft:player"
+
 // NewPlayer returns a new player with the given name.
 func NewPlayer(uuid uuid.UUID, xuid string, platform int32, name string) *Player {
 	var player = &Player{Entity: entities.New(entities.Player)}
@@ -33,6 +80,14 @@ func NewPlayer(uuid uuid.UUID, xuid string, platform int32, name string) *Player
 	player.playerName = name
 	player.displayName = name
 
+	player.inventory = inventory.NewInventory(InventorySize)
+	player.cursorInventory = inventory.NewInventory(1)
+	player.offHandInventory = inventory.NewInventory(1)
+	player.craftingGrid = inventory.NewInventory(CraftingGridSize)
+
+	player.data = NewData(name)
+	player.movement = NewMovementTracker(DefaultMovementSettings)
+
 	return player
 }
 
@@ -41,6 +96,17 @@ func (player *Player) GetName() string {
 	return player.playerName
 }
 
+// GetIdentifier returns the identifier of the entity type of players, minecraft:player.
+func (player *Player) GetIdentifier() string {
+	return Identifier
+}
+
+// HasComponent checks if the player has the component with the given name.
+// Players only have the inventory component.
+func (player *Player) HasComponent(component string) bool {
+	return component == ecs.ComponentInventory
+}
+
 // SetName sets the player name of this player.
 // Note: This function is internal, and should not be used by plugins.
 func (player *Player) SetName(name string) {
@@ -57,6 +123,17 @@ func (player *Player) SetDisplayName(name string) {
 	player.displayName = name
 }
 
+// GetEntityData returns the entity data of the player,
+// with the display name of the player set as its name tag.
+func (player *Player) GetEntityData() map[uint32][]interface{} {
+	var entityData = make(map[uint32][]interface{})
+	for key, value := range player.Entity.GetEntityData() {
+		entityData[key] = value
+	}
+	entityData[entityDataNameTag] = []interface{}{uint32(data2.EntityDataString), player.displayName}
+	return entityData
+}
+
 // GetUUID returns the UUID of the player.
 func (player *Player) GetUUID() uuid.UUID {
 	return player.uuid
@@ -139,6 +216,105 @@ func (player *Player) SetGeometryData(data string) {
 	player.geometryData = data
 }
 
+// GetInventory returns the inventory of the player.
+func (player *Player) GetInventory() *inventory.Inventory {
+	return player.inventory
+}
+
+// GetCursorInventory returns the inventory holding
+// the item the player is currently holding with the cursor.
+func (player *Player) GetCursorInventory() *inventory.Inventory {
+	return player.cursorInventory
+}
+
+// GetOffHandInventory returns the inventory holding
+// the item the player is holding in its off hand.
+func (player *Player) GetOffHandInventory() *inventory.Inventory {
+	return player.offHandInventory
+}
+
+// GetHeldSlot returns the hotbar slot of the item the player is holding in its main hand.
+func (player *Player) GetHeldSlot() int {
+	return player.heldSlot
+}
+
+// SetHeldSlot sets the hotbar slot of the item the player is holding in its main hand.
+// The slot is not changed if it is not a hotbar slot.
+func (player *Player) SetHeldSlot(slot int) {
+	if slot >= 0 && slot < HotbarSize {
+		player.heldSlot = slot
+	}
+}
+
+// GetHeldItem returns the item the player is holding in its main hand,
+// or nil if the player is not holding anything.
+func (player *Player) GetHeldItem() *items.Stack {
+	var stack, _ = player.inventory.GetItem(player.heldSlot)
+	return stack
+}
+
+// GetCraftingGrid returns the inventory holding the items
+// the player put in the crafting grid of its inventory or a crafting table.
+func (player *Player) GetCraftingGrid() *inventory.Inventory {
+	return player.craftingGrid
+}
+
+// ReturnCraftingGrid moves all items in the crafting grid back into the inventory of the player.
+// The items that did not fit in the inventory are returned.
+func (player *Player) ReturnCraftingGrid() []*items.Stack {
+	var leftovers []*items.Stack
+	for slot, stack := range player.craftingGrid.GetAll() {
+		if stack == nil {
+			continue
+		}
+		player.craftingGrid.ClearSlot(slot)
+		if leftover := player.inventory.AddItemReturningLeftovers(stack); leftover != nil {
+			leftovers = append(leftovers, leftover)
+		}
+	}
+	return leftovers
+}
+
+// GetInventoryById returns an inventory of the player by its container ID.
+// A bool is returned indicating if the player had an inventory with the ID.
+func (player *Player) GetInventoryById(windowId int32) (*inventory.Inventory, bool) {
+	switch windowId {
+	case data.ContainerInventory:
+		return player.inventory, true
+	case data.ContainerCursor:
+		return player.cursorInventory, true
+	case data.ContainerOffHand:
+		return player.offHandInventory, true
+	}
+	return nil, false
+}
+
+// SetInventorySlot sets an item in a slot of the inventory with the given container ID,
+// as a result of an inventory action sent by the client.
+// Air stacks clear the slot. ExceedingSlot gets returned
+// if the player has no inventory with the container ID.
+func (player *Player) SetInventorySlot(windowId int32, slot int, stack *items.Stack) error {
+	var inv, ok = player.GetInventoryById(windowId)
+	if !ok {
+		return inventory.ExceedingSlot
+	}
+	if stack == nil || stack.Count == 0 || stack.GetId() == "minecraft:air" {
+		stack = nil
+	}
+	return inv.SetItem(stack, slot)
+}
+
+// GetData returns the persisted data of the player.
+func (player *Player) GetData() *Data {
+	return player.data
+}
+
+// SetData sets the persisted data of the player,
+// usually after it has been loaded from a data storage.
+func (player *Player) SetData(data *Data) {
+	player.data = data
+}
+
 // SyncMove synchronizes the server's player movement with the client movement.
 func (player *Player) SyncMove(x, y, z, pitch, yaw, headYaw float64, onGround bool) {
 	player.Position.X = x
@@ -159,12 +335,23 @@ func (player *Player) SendMovement(viewer entities.Viewer) {
 
 // Sends updated player position and rotation to all viewers,
 // this overrides the base entity function.
+// Movement is only sent if the movement tracker of the player selects a mode for it,
+// so that viewers interpolate rotation-only updates and do not interpolate teleports.
 func (player *Player) BroadcastMovement() {
+	var mode, ok = player.movement.Update(player.Position, player.Rotation)
+	if !ok {
+		return
+	}
 	for _, viewer := range player.GetViewers() {
-		viewer.SendMovePlayer(player.GetRuntimeId(), player.Position, player.Rotation, 0, player.OnGround, player.GetRidingId())
+		viewer.SendMovePlayer(player.GetRuntimeId(), player.Position, player.Rotation, mode, player.OnGround, player.GetRidingId())
 	}
 }
 
+// GetMovementTracker returns the tracker selecting how the movement of the player is sent to viewers.
+func (player *Player) GetMovementTracker() *MovementTracker {
+	return player.movement
+}
+
 // Tick ticks the player, this overrides the base entity tick.
 func (player Player) Tick() {
 	if player.HasEntityDataUpdate {
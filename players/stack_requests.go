@@ -0,0 +1,181 @@
+package players
+
+import (
+	"errors"
+
+	"github.com/irmine/gomine/items"
+	"github.com/irmine/gomine/items/inventory"
+	"github.com/irmine/gomine/net/packets/data"
+	"github.com/irmine/gomine/net/packets/types"
+)
+
+// InvalidStackRequest gets returned when an action of an item stack request can not be applied,
+// for example because the source slot does not hold enough items.
+var InvalidStackRequest = errors.New("item stack request action is invalid")
+
+// DroppedStack is an item stack dropped out of the inventories of a player by an item stack request.
+type DroppedStack struct {
+	Stack *items.Stack
+	// Randomly specifies if the stack should be thrown in a random direction,
+	// rather than the direction the player looks in.
+	Randomly bool
+}
+
+// HandleItemStackRequest applies all actions of the item stack request to the inventories of the player.
+// If any of the actions is invalid, none of the actions are applied and an error response is returned.
+// Destroy actions are only allowed if creative is true.
+// The response holds the new content of every slot changed by the request.
+// The stacks taken out of the inventories by drop actions are returned,
+// so that they can be dropped into the world. They are not returned if the request failed.
+func (player *Player) HandleItemStackRequest(request types.ItemStackRequest, creative bool) (types.ItemStackResponse, []DroppedStack) {
+	var response = types.ItemStackResponse{Status: data.StackResponseStatusError, RequestId: request.RequestId}
+	if request.Unsupported || len(request.Actions) == 0 {
+		return response, nil
+	}
+	// Stacks are never modified in place by actions,
+	// so copying the slices is enough to restore the inventories.
+	var inventoryItems, cursorItems, offHandItems = player.inventory.GetAll(), player.cursorInventory.GetAll(), player.offHandInventory.GetAll()
+
+	var changed []types.StackRequestSlotInfo
+	var dropped []DroppedStack
+	for _, action := range request.Actions {
+		var sourceStack *items.Stack
+		if source, slot, ok := player.getStackRequestSlot(action.Source); ok {
+			sourceStack, _ = source.GetItem(slot)
+		}
+		if err := player.applyStackRequestAction(action, creative); err != nil {
+			player.inventory.SetAll(inventoryItems)
+			player.cursorInventory.SetAll(cursorItems)
+			player.offHandInventory.SetAll(offHandItems)
+			return response, nil
+		}
+		if action.ActionType == data.StackRequestActionDrop {
+			var stack = *sourceStack
+			stack.Count = int(action.Count)
+			dropped = append(dropped, DroppedStack{Stack: &stack, Randomly: action.Randomly})
+		}
+		changed = append(changed, action.Source)
+		if action.ActionType != data.StackRequestActionDrop && action.ActionType != data.StackRequestActionDestroy {
+			changed = append(changed, action.Destination)
+		}
+	}
+	response.Status = data.StackResponseStatusOk
+	response.ContainerInfos = player.getStackResponseContainers(changed)
+	return response, dropped
+}
+
+// getStackRequestSlot returns the inventory and slot referenced by the slot of a stack request.
+// A bool is returned indicating if the slot exists.
+func (player *Player) getStackRequestSlot(slot types.StackRequestSlotInfo) (*inventory.Inventory, int, bool) {
+	switch slot.ContainerId {
+	case data.ContainerSlotCombinedHotbarAndInventory, data.ContainerSlotHotbar, data.ContainerSlotInventory:
+		return player.inventory, int(slot.Slot), int(slot.Slot) < player.inventory.GetSize()
+	case data.ContainerSlotCursor:
+		return player.cursorInventory, 0, slot.Slot == 0
+	case data.ContainerSlotOffHand:
+		return player.offHandInventory, 0, slot.Slot == 1
+	}
+	return nil, 0, false
+}
+
+// applyStackRequestAction applies a single action of an item stack request.
+// InvalidStackRequest gets returned if the action could not be applied.
+func (player *Player) applyStackRequestAction(action types.StackRequestAction, creative bool) error {
+	var source, sourceSlot, ok = player.getStackRequestSlot(action.Source)
+	if !ok {
+		return InvalidStackRequest
+	}
+	var sourceStack, _ = source.GetItem(sourceSlot)
+
+	switch action.ActionType {
+	case data.StackRequestActionTake, data.StackRequestActionPlace:
+		var destination, destinationSlot, ok = player.getStackRequestSlot(action.Destination)
+		if !ok || sourceStack == nil || action.Count == 0 || int(action.Count) > sourceStack.Count {
+			return InvalidStackRequest
+		}
+		if destination == source && destinationSlot == sourceSlot {
+			return InvalidStackRequest
+		}
+		var moved = *sourceStack
+		moved.Count = int(action.Count)
+		if destinationStack, _ := destination.GetItem(destinationSlot); destinationStack != nil {
+			if canStack, count := moved.CanStackOn(destinationStack); !canStack || count < moved.Count {
+				return InvalidStackRequest
+			}
+			moved.Count += destinationStack.Count
+		} else if moved.Count > moved.GetMaximumStackSize() {
+			return InvalidStackRequest
+		}
+		destination.SetItem(&moved, destinationSlot)
+		takeStackCount(source, sourceSlot, sourceStack, int(action.Count))
+	case data.StackRequestActionSwap:
+		var destination, destinationSlot, ok = player.getStackRequestSlot(action.Destination)
+		if !ok {
+			return InvalidStackRequest
+		}
+		var destinationStack, _ = destination.GetItem(destinationSlot)
+		source.SetItem(destinationStack, sourceSlot)
+		destination.SetItem(sourceStack, destinationSlot)
+	case data.StackRequestActionDrop:
+		if sourceStack == nil || action.Count == 0 || int(action.Count) > sourceStack.Count {
+			return InvalidStackRequest
+		}
+		takeStackCount(source, sourceSlot, sourceStack, int(action.Count))
+	case data.StackRequestActionDestroy:
+		if !creative || sourceStack == nil || action.Count == 0 || int(action.Count) > sourceStack.Count {
+			return InvalidStackRequest
+		}
+		takeStackCount(source, sourceSlot, sourceStack, int(action.Count))
+	default:
+		return InvalidStackRequest
+	}
+	return nil
+}
+
+// takeStackCount takes the count of items from the stack in the slot,
+// clearing the slot if no items are left.
+// A copy of the stack is set in the slot, rather than modifying the stack.
+func takeStackCount(inv *inventory.Inventory, slot int, stack *items.Stack, count int) {
+	if count >= stack.Count {
+		inv.SetItem(nil, slot)
+		return
+	}
+	var left = *stack
+	left.Count -= count
+	inv.SetItem(&left, slot)
+}
+
+// getStackResponseContainers returns the new content of the changed slots,
+// grouped by the container they were referenced with.
+// Stack network IDs are not tracked by GoMine, so the container and slot
+// of a stack are used as its network ID.
+func (player *Player) getStackResponseContainers(changed []types.StackRequestSlotInfo) []types.StackResponseContainerInfo {
+	var containers []types.StackResponseContainerInfo
+	var seen = make(map[[2]byte]bool)
+	for _, slot := range changed {
+		if seen[[2]byte{slot.ContainerId, slot.Slot}] {
+			continue
+		}
+		seen[[2]byte{slot.ContainerId, slot.Slot}] = true
+
+		var info = types.StackResponseSlotInfo{Slot: slot.Slot, HotbarSlot: slot.Slot}
+		var inv, index, _ = player.getStackRequestSlot(slot)
+		if stack, err := inv.GetItem(index); err == nil {
+			info.Count = byte(stack.Count)
+			info.StackNetworkId = (int32(slot.ContainerId)<<8 | int32(slot.Slot)) + 1
+		}
+
+		var found bool
+		for i := range containers {
+			if containers[i].ContainerId == slot.ContainerId {
+				containers[i].Slots = append(containers[i].Slots, info)
+				found = true
+				break
+			}
+		}
+		if !found {
+			containers = append(containers, types.StackResponseContainerInfo{ContainerId: slot.ContainerId, Slots: []types.StackResponseSlotInfo{info}})
+		}
+	}
+	return containers
+}
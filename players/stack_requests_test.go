@@ -0,0 +1,94 @@
+package players
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/irmine/gomine/items"
+	"github.com/irmine/gomine/net/packets/data"
+	"github.com/irmine/gomine/net/packets/types"
+)
+
+func TestItemStackRequest(t *testing.T) {
+	var player = NewPlayer(uuid.New(), "", 0, "Steve")
+	var stone, _ = items.DefaultManager.Get("minecraft:stone", 10)
+	player.GetInventory().SetItem(stone, 0)
+
+	var slot = func(container, slot byte) types.StackRequestSlotInfo {
+		return types.StackRequestSlotInfo{ContainerId: container, Slot: slot}
+	}
+	var response, _ = player.HandleItemStackRequest(types.ItemStackRequest{RequestId: 1, Actions: []types.StackRequestAction{
+		{ActionType: data.StackRequestActionTake, Count: 4, Source: slot(data.ContainerSlotHotbar, 0), Destination: slot(data.ContainerSlotCursor, 0)},
+		{ActionType: data.StackRequestActionPlace, Count: 4, Source: slot(data.ContainerSlotCursor, 0), Destination: slot(data.ContainerSlotInventory, 9)},
+	}}, false)
+	if response.Status != data.StackResponseStatusOk || response.RequestId != 1 {
+		t.Fatal("valid request was rejected:", response)
+	}
+	if stack, _ := player.GetInventory().GetItem(0); stack == nil || stack.Count != 6 {
+		t.Error("items were not taken from the source slot:", stack)
+	}
+	if stack, _ := player.GetInventory().GetItem(9); stack == nil || stack.Count != 4 {
+		t.Error("items were not placed in the destination slot:", stack)
+	}
+	if !player.GetCursorInventory().IsEmpty(0) {
+		t.Error("cursor was not emptied")
+	}
+	if stone.Count != 10 {
+		t.Error("stack was modified in place")
+	}
+	if len(response.ContainerInfos) != 3 {
+		t.Error("expected changed slots of 3 containers, got", response.ContainerInfos)
+	}
+
+	response, _ = player.HandleItemStackRequest(types.ItemStackRequest{RequestId: 2, Actions: []types.StackRequestAction{
+		{ActionType: data.StackRequestActionSwap, Source: slot(data.ContainerSlotHotbar, 0), Destination: slot(data.ContainerSlotHotbar, 1)},
+		{ActionType: data.StackRequestActionDestroy, Count: 1, Source: slot(data.ContainerSlotHotbar, 1)},
+	}}, false)
+	if response.Status != data.StackResponseStatusError {
+		t.Error("destroy action was accepted outside of creative mode")
+	}
+	if stack, _ := player.GetInventory().GetItem(0); stack == nil || stack.Count != 6 || !player.GetInventory().IsEmpty(1) {
+		t.Error("actions of rejected request were not reverted")
+	}
+
+	response, _ = player.HandleItemStackRequest(types.ItemStackRequest{RequestId: 3, Actions: []types.StackRequestAction{
+		{ActionType: data.StackRequestActionTake, Count: 7, Source: slot(data.ContainerSlotHotbar, 0), Destination: slot(data.ContainerSlotCursor, 0)},
+	}}, true)
+	if response.Status != data.StackResponseStatusError {
+		t.Error("taking more items than the slot holds was accepted")
+	}
+
+	response, dropped := player.HandleItemStackRequest(types.ItemStackRequest{RequestId: 4, Actions: []types.StackRequestAction{
+		{ActionType: data.StackRequestActionDrop, Count: 2, Source: slot(data.ContainerSlotHotbar, 0)},
+	}}, false)
+	if response.Status != data.StackResponseStatusOk || len(dropped) != 1 || dropped[0].Stack.Count != 2 {
+		t.Fatal("dropped stack was not returned:", response, dropped)
+	}
+	if stack, _ := player.GetInventory().GetItem(0); stack == nil || stack.Count != 4 {
+		t.Error("dropped items were not taken from the source slot:", stack)
+	}
+}
+
+func TestOffHand(t *testing.T) {
+	var player = NewPlayer(uuid.New(), "", 0, "Steve")
+	var totem, _ = items.DefaultManager.Get("minecraft:totem", 1)
+	player.GetInventory().SetItem(totem, 2)
+	player.SetHeldSlot(2)
+	if player.GetHeldItem() != totem {
+		t.Error("held item was not returned:", player.GetHeldItem())
+	}
+	player.SetHeldSlot(9)
+	if player.GetHeldSlot() != 2 {
+		t.Error("held slot was set to a slot outside of the hotbar")
+	}
+
+	var response, _ = player.HandleItemStackRequest(types.ItemStackRequest{RequestId: 1, Actions: []types.StackRequestAction{
+		{ActionType: data.StackRequestActionSwap, Source: types.StackRequestSlotInfo{ContainerId: data.ContainerSlotHotbar, Slot: 2}, Destination: types.StackRequestSlotInfo{ContainerId: data.ContainerSlotOffHand, Slot: 1}},
+	}}, false)
+	if response.Status != data.StackResponseStatusOk {
+		t.Fatal("moving an item to the off hand was rejected:", response)
+	}
+	if stack, _ := player.GetOffHandInventory().GetItem(0); stack == nil || stack.GetId() != "minecraft:totem" || player.GetHeldItem() != nil {
+		t.Error("item was not moved to the off hand:", stack)
+	}
+}
@@ -1,5 +1,10 @@
 package gomine
 
+import (
+	"github.com/irmine/gomine/commands"
+	"github.com/irmine/gomine/events"
+)
+
 type Manifest struct {
 	Name         string
 	Description  string
@@ -21,6 +26,7 @@ type IManifest interface {
 type IPlugin interface {
 	GetServer() *Server
 	OnEnable()
+	OnDisable()
 
 	GetName() string
 	GetVersion() string
@@ -109,3 +115,20 @@ func (plug *Plugin) setManifest(manifest IManifest) {
 func (plug *Plugin) GetServer() *Server {
 	return plug.server
 }
+
+// GetEventManager returns the event manager of the server,
+// with which the plugin can register event handlers.
+func (plug *Plugin) GetEventManager() *events.Manager {
+	return plug.server.EventManager
+}
+
+// GetCommandManager returns the command manager of the server,
+// with which the plugin can register commands.
+func (plug *Plugin) GetCommandManager() *commands.Manager {
+	return plug.server.CommandManager
+}
+
+// OnDisable gets called when the plugin gets disabled,
+// for example when the server shuts down.
+// Plugins should override it to save their data.
+func (plug *Plugin) OnDisable() {}
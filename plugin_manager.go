@@ -2,12 +2,14 @@ package gomine
 
 import (
 	"errors"
+	"fmt"
 	"io/ioutil"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"plugin"
 	"strings"
+	"sync"
 
 	"github.com/google/uuid"
 	"github.com/irmine/gomine/text"
@@ -18,20 +20,54 @@ const (
 
 	OutdatedPlugin     = "plugin.Open: plugin was built with a different version of package"
 	NoPluginsSupported = "plugin: not implemented"
+
+	// PluginDirectory is the directory in the server path plugins get loaded from.
+	PluginDirectory = "plugins/"
+)
+
+var UnknownPlugin = errors.New("unknown plugin")
+
+// registeredPlugin is a plugin compiled into the server binary.
+type registeredPlugin struct {
+	manifest IManifest
+	create   func(server *Server) IPlugin
+}
+
+var (
+	registeredMutex   sync.Mutex
+	registeredPlugins []registeredPlugin
 )
 
+// RegisterPlugin registers a plugin compiled into the server binary.
+// Static builds and operating systems without support for Go plugins
+// should register their plugins in an init function instead.
+// Registered plugins get loaded before plugins in the plugin directory.
+func RegisterPlugin(manifest IManifest, create func(server *Server) IPlugin) {
+	registeredMutex.Lock()
+	registeredPlugins = append(registeredPlugins, registeredPlugin{manifest, create})
+	registeredMutex.Unlock()
+}
+
 type PluginManager struct {
+	mutex   sync.RWMutex
 	server  *Server
 	plugins map[string]IPlugin
+	enabled map[string]bool
 }
 
 func NewPluginManager(server *Server) *PluginManager {
-	return &PluginManager{server, make(map[string]IPlugin)}
+	return &PluginManager{server: server, plugins: make(map[string]IPlugin), enabled: make(map[string]bool)}
 }
 
 // GetPlugins returns all plugins currently loaded on the server.
 func (manager *PluginManager) GetPlugins() map[string]IPlugin {
-	return manager.plugins
+	manager.mutex.RLock()
+	defer manager.mutex.RUnlock()
+	var plugins = make(map[string]IPlugin, len(manager.plugins))
+	for name, plug := range manager.plugins {
+		plugins[name] = plug
+	}
+	return plugins
 }
 
 // GetServer returns the main server.
@@ -41,21 +77,39 @@ func (manager *PluginManager) GetServer() *Server {
 
 // GetPlugin returns a plugin with the given name, or nil if none could be found.
 func (manager *PluginManager) GetPlugin(name string) IPlugin {
-	if !manager.IsPluginLoaded(name) {
-		return nil
-	}
+	manager.mutex.RLock()
+	defer manager.mutex.RUnlock()
 	return manager.plugins[name]
 }
 
 // IsPluginLoaded checks if a plugin with the given name is loaded.
 func (manager *PluginManager) IsPluginLoaded(name string) bool {
+	manager.mutex.RLock()
+	defer manager.mutex.RUnlock()
 	var _, exists = manager.plugins[name]
 	return exists
 }
 
-// LoadPlugins loads all plugins in the 'extensions/plugins' folder.
+// IsPluginEnabled checks if a plugin with the given name is loaded and enabled.
+func (manager *PluginManager) IsPluginEnabled(name string) bool {
+	manager.mutex.RLock()
+	defer manager.mutex.RUnlock()
+	return manager.enabled[name]
+}
+
+// LoadPlugins loads all registered plugins and all plugins in the plugin directory,
+// after which all loaded plugins get enabled.
 func (manager *PluginManager) LoadPlugins() {
-	var path = manager.server.ServerPath + "extensions/plugins/"
+	registeredMutex.Lock()
+	var registered = make([]registeredPlugin, len(registeredPlugins))
+	copy(registered, registeredPlugins)
+	registeredMutex.Unlock()
+
+	for _, plug := range registered {
+		text.DefaultLogger.LogError(manager.addPlugin(plug.manifest, plug.create, "binary"))
+	}
+
+	var path = manager.server.ServerPath + PluginDirectory
 	var files, _ = ioutil.ReadDir(path)
 
 	for _, file := range files {
@@ -74,11 +128,15 @@ func (manager *PluginManager) LoadPlugins() {
 		if err != nil {
 			if err.Error() == NoPluginsSupported {
 				text.DefaultLogger.Error("Go does currently not support plugins for your operating system.")
-				return
+				break
 			}
 		}
 		text.DefaultLogger.LogError(err)
 	}
+
+	for name := range manager.GetPlugins() {
+		text.DefaultLogger.LogError(manager.EnablePlugin(name))
+	}
 }
 
 // CompilePlugin compiles a plugin.go at the given path during runtime, and opens it. This action is extremely time consuming.
@@ -138,11 +196,6 @@ func (manager *PluginManager) LoadPlugin(filePath string) error {
 		return errors.New("Plugin at '" + filePath + "' does not have a valid Manifest.")
 	}
 
-	err = manager.ValidateManifest(manifest, filePath)
-	if err != nil {
-		return err
-	}
-
 	newPluginSymbol, err := plug.Lookup("NewPlugin")
 	if err != nil {
 		return errors.New("Plugin at '" + filePath + "' does not have a NewPlugin function.")
@@ -153,12 +206,80 @@ func (manager *PluginManager) LoadPlugin(filePath string) error {
 		return errors.New("Plugin at '" + filePath + "' does not have a valid NewPlugin function.")
 	}
 
-	var finalPlugin = pluginFunc(manager.server)
+	return manager.addPlugin(manifest, pluginFunc, filePath)
+}
+
+// addPlugin validates the manifest, creates the plugin and adds it to the manager.
+// The plugin does not get enabled.
+func (manager *PluginManager) addPlugin(manifest IManifest, create func(server *Server) IPlugin, path string) error {
+	if err := manager.ValidateManifest(manifest, path); err != nil {
+		return err
+	}
+	var finalPlugin = create(manager.server)
 	finalPlugin.setManifest(manifest)
 
+	manager.mutex.Lock()
 	manager.plugins[finalPlugin.GetName()] = finalPlugin
-	finalPlugin.OnEnable()
+	manager.mutex.Unlock()
+	return nil
+}
+
+// EnablePlugin enables the loaded plugin with the given name.
+// Enabling a plugin that is already enabled does nothing.
+func (manager *PluginManager) EnablePlugin(name string) error {
+	var plug = manager.GetPlugin(name)
+	if plug == nil {
+		return UnknownPlugin
+	}
+	if manager.IsPluginEnabled(name) {
+		return nil
+	}
+	if err := call(plug, plug.OnEnable); err != nil {
+		return err
+	}
+	manager.mutex.Lock()
+	manager.enabled[name] = true
+	manager.mutex.Unlock()
+	text.DefaultLogger.Info("Enabled plugin", plug.GetName(), "v"+plug.GetVersion())
+	return nil
+}
 
+// DisablePlugin disables the loaded plugin with the given name.
+// Disabling a plugin that is not enabled does nothing.
+func (manager *PluginManager) DisablePlugin(name string) error {
+	var plug = manager.GetPlugin(name)
+	if plug == nil {
+		return UnknownPlugin
+	}
+	if !manager.IsPluginEnabled(name) {
+		return nil
+	}
+	manager.mutex.Lock()
+	delete(manager.enabled, name)
+	manager.mutex.Unlock()
+	if err := call(plug, plug.OnDisable); err != nil {
+		return err
+	}
+	text.DefaultLogger.Info("Disabled plugin", plug.GetName(), "v"+plug.GetVersion())
+	return nil
+}
+
+// DisablePlugins disables all enabled plugins.
+func (manager *PluginManager) DisablePlugins() {
+	for name := range manager.GetPlugins() {
+		text.DefaultLogger.LogError(manager.DisablePlugin(name))
+	}
+}
+
+// call calls a function of a plugin, returning an error if the function panicked,
+// so that a faulty plugin can not take down the server.
+func call(plug IPlugin, function func()) (err error) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			err = fmt.Errorf("plugin %v panicked: %v", plug.GetName(), recovered)
+		}
+	}()
+	function()
 	return nil
 }
 
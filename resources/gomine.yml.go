@@ -10,6 +10,19 @@ import (
 type GoMineConfig struct {
 	ServerName string `yaml:"Server LAN Name"`
 	ServerMotd string `yaml:"Server MOTD"`
+
+	MotdRotation         []string `yaml:"MOTD Rotation"`
+	MotdRotationInterval int      `yaml:"MOTD Rotation Interval"`
+	DisplayedMaxPlayers  int      `yaml:"Displayed Max Players"`
+	MaxPlayersOffset     int      `yaml:"Max Players Offset"`
+	HidePlayerCount      bool     `yaml:"Hide Player Count"`
+	SubMotd              string   `yaml:"Sub MOTD"`
+
+	WorldDisplayName string   `yaml:"World Display Name"`
+	JoinTitle        string   `yaml:"Join Title"`
+	JoinSubtitle     string   `yaml:"Join Subtitle"`
+	LoadingTips      []string `yaml:"Loading Tips"`
+
 	ServerIp   string `yaml:"Server IP"`
 	ServerPort uint16 `yaml:"Server Port"`
 
@@ -18,19 +31,113 @@ type GoMineConfig struct {
 
 	DebugMode bool `yaml:"Debug Mode"`
 
-	DefaultLevel     string `yaml:"Default Level"`
-	DefaultGenerator string `yaml:"Default Generator"`
+	TickRate int  `yaml:"Tick Rate"`
+	Headless bool `yaml:"Headless"`
+
+	DefaultLevel          string `yaml:"Default Level"`
+	DefaultGenerator      string `yaml:"Default Generator"`
+	GeneratorPreset       string `yaml:"Generator Preset"`
+	GeneratorBiome        string `yaml:"Generator Biome"`
+	WorldFormat           string `yaml:"World Format"`
+	AutosaveInterval      int    `yaml:"Autosave Interval"`
+	WorldCompressionLevel int    `yaml:"World Compression Level"`
+	SpawnSearchRadius     int    `yaml:"Spawn Search Radius"`
+	SpawnRadius           int32  `yaml:"Spawn Radius"`
+	ChunkCacheSize        int    `yaml:"Chunk Cache Size"`
 
 	ForceResourcePacks   bool   `yaml:"Forced Resource Packs"`
 	SelectedResourcePack string `yaml:"Selected Resource Pack"`
 
 	XBOXLiveAuth  bool `yaml:"XBOX Live Auth"`
 	UseEncryption bool `yaml:"Use Encryption"`
+	Whitelist     bool `yaml:"Whitelist"`
 
-	AllowQuery       bool `yaml:"Allow Query"`
-	AllowPluginQuery bool `yaml:"Allow Plugin Query"`
+	AllowQuery       bool   `yaml:"Allow Query"`
+	AllowPluginQuery bool   `yaml:"Allow Plugin Query"`
+	QueryPort        uint16 `yaml:"Query Port"`
+
+	MetricsAddress   string `yaml:"Metrics Address"`
+	MetricsProfiling bool   `yaml:"Metrics Profiling"`
 
 	MaxViewDistance int32 `yaml:"Max View Distance"`
+	SkinCacheSize   int   `yaml:"Skin Cache Size"`
+
+	MovementUpdateInterval    int     `yaml:"Movement Update Interval"`
+	MovementRotationThreshold float64 `yaml:"Movement Rotation Threshold"`
+	MovementTeleportDistance  float64 `yaml:"Movement Teleport Distance"`
+
+	SessionSweepInterval int `yaml:"Session Sweep Interval"`
+
+	MaxLoadedChunks      int   `yaml:"Max Loaded Chunks"`
+	MaxEntities          int   `yaml:"Max Entities"`
+	MaxPlayerChunks      int   `yaml:"Max Player Chunks"`
+	LimitAlertInterval   int   `yaml:"Limit Alert Interval"`
+	OverloadDegradation  bool  `yaml:"Overload Degradation"`
+	OverloadViewDistance int32 `yaml:"Overload View Distance"`
+
+	AntiXray map[string]string `yaml:"Anti Xray"`
+
+	PvP              PvPConfig            `yaml:"PvP"`
+	WorldPvP         map[string]PvPConfig `yaml:"World PvP"`
+	DamageIndicators bool                 `yaml:"Damage Indicators"`
+	RespawnImmunity  float64              `yaml:"Respawn Immunity"`
+
+	ItemPickupDelay int  `yaml:"Item Pickup Delay"`
+	ItemOwnerWindow int  `yaml:"Item Owner Window"`
+	DropBlockItems  bool `yaml:"Drop Block Items"`
+
+	MobCap             int     `yaml:"Mob Cap"`
+	MobDespawnDistance float64 `yaml:"Mob Despawn Distance"`
+
+	CompressionLevel     int  `yaml:"Compression Level"`
+	CompressionThreshold int  `yaml:"Compression Threshold"`
+	BatchPackets         bool `yaml:"Batch Packets"`
+
+	ForwardUnknownPackets bool `yaml:"Forward Unknown Packets"`
+
+	ChatFormat     string            `yaml:"Chat Format"`
+	ChatColorCodes bool              `yaml:"Chat Color Codes"`
+	ChatShortcodes map[string]string `yaml:"Chat Shortcodes"`
+
+	JoinMessage      string `yaml:"Join Message"`
+	FirstJoinMessage string `yaml:"First Join Message"`
+	QuitMessage      string `yaml:"Quit Message"`
+
+	FormItemsPerPage int `yaml:"Form Items Per Page"`
+
+	RandomTeleportRadius   int32 `yaml:"Random Teleport Radius"`
+	RandomTeleportCooldown int   `yaml:"Random Teleport Cooldown"`
+
+	PortalCooldown          float64 `yaml:"Portal Cooldown"`
+	DimensionChangeCooldown float64 `yaml:"Dimension Change Cooldown"`
+
+	WebMap          bool   `yaml:"Web Map"`
+	WebMapDirectory string `yaml:"Web Map Directory"`
+	WebMapInterval  int    `yaml:"Web Map Interval"`
+
+	MovementChecks  bool    `yaml:"Movement Checks"`
+	MaxMoveSpeed    float64 `yaml:"Max Move Speed"`
+	MaxFlySpeed     float64 `yaml:"Max Fly Speed"`
+	MaxAirTicks     int     `yaml:"Max Air Ticks"`
+	MaxMoveDistance float64 `yaml:"Max Move Distance"`
+
+	NetworkServerName    string `yaml:"Network Server Name"`
+	NetworkHub           string `yaml:"Network Hub"`
+	NetworkHubListen     string `yaml:"Network Hub Listen Address"`
+	NetworkSecret        string `yaml:"Network Secret"`
+	NetworkPublicAddress string `yaml:"Network Public Address"`
+	NetworkForwardChat   bool   `yaml:"Network Forward Chat"`
+
+	Features map[string]bool `yaml:"Features"`
+}
+
+// PvPConfig are the PvP parameters of a world.
+// Knockback and critical multipliers of 0 or lower are ignored.
+type PvPConfig struct {
+	AttackCooldown      int     `yaml:"Attack Cooldown Ticks"`
+	KnockbackHorizontal float64 `yaml:"Knockback Horizontal Multiplier"`
+	KnockbackVertical   float64 `yaml:"Knockback Vertical Multiplier"`
+	CriticalMultiplier  float32 `yaml:"Critical Multiplier"`
 }
 
 // NewGoMineConfig returns a new configuration struct.
@@ -49,6 +156,19 @@ func initializeConfig(serverPath string) {
 		var data, _ = yaml.Marshal(GoMineConfig{
 			ServerName: "GoMine Server",
 			ServerMotd: "GoMine Testing Server",
+
+			MotdRotation:         []string{},
+			MotdRotationInterval: 10,
+			DisplayedMaxPlayers:  0,
+			MaxPlayersOffset:     0,
+			HidePlayerCount:      false,
+			SubMotd:              "GoMine",
+
+			WorldDisplayName: "{world}",
+			JoinTitle:        "",
+			JoinSubtitle:     "",
+			LoadingTips:      []string{},
+
 			ServerIp:   "0.0.0.0",
 			ServerPort: 19132,
 
@@ -57,19 +177,116 @@ func initializeConfig(serverPath string) {
 
 			DebugMode: true,
 
-			DefaultLevel:     "world",
-			DefaultGenerator: "Flat",
+			TickRate: 20,
+			Headless: false,
+
+			DefaultLevel:          "world",
+			DefaultGenerator:      "Flat",
+			GeneratorPreset:       "default",
+			GeneratorBiome:        "",
+			WorldFormat:           "anvil",
+			AutosaveInterval:      300,
+			WorldCompressionLevel: 0,
+			SpawnSearchRadius:     4,
+			SpawnRadius:           0,
+			ChunkCacheSize:        256,
 
 			ForceResourcePacks:   false,
 			SelectedResourcePack: "",
 
 			XBOXLiveAuth:  true,
 			UseEncryption: false,
+			Whitelist:     false,
 
 			AllowQuery:       true,
 			AllowPluginQuery: true,
+			QueryPort:        19132,
+
+			MetricsAddress:   "",
+			MetricsProfiling: false,
 
 			MaxViewDistance: 8,
+			SkinCacheSize:   128,
+
+			MovementUpdateInterval:    1,
+			MovementRotationThreshold: 0.5,
+			MovementTeleportDistance:  8,
+
+			SessionSweepInterval: 30,
+
+			MaxLoadedChunks:      20000,
+			MaxEntities:          5000,
+			MaxPlayerChunks:      1200,
+			LimitAlertInterval:   60,
+			OverloadDegradation:  false,
+			OverloadViewDistance: 4,
+
+			AntiXray: map[string]string{},
+
+			PvP:              PvPConfig{AttackCooldown: 0, KnockbackHorizontal: 1, KnockbackVertical: 1, CriticalMultiplier: 1.5},
+			WorldPvP:         map[string]PvPConfig{},
+			DamageIndicators: true,
+			RespawnImmunity:  3,
+
+			ItemPickupDelay: 40,
+			ItemOwnerWindow: 100,
+			DropBlockItems:  false,
+
+			MobCap:             200,
+			MobDespawnDistance: 128,
+
+			CompressionLevel:     6,
+			CompressionThreshold: 256,
+			BatchPackets:         true,
+
+			ForwardUnknownPackets: false,
+
+			ChatFormat:     "{prefix}<{name}> {message}",
+			ChatColorCodes: true,
+			ChatShortcodes: map[string]string{
+				"heart": "❤",
+				"star":  "★",
+				"check": "✔",
+				"cross": "✖",
+				"arrow": "➜",
+				"skull": "☠",
+				"music": "♪",
+				"smile": "☺",
+			},
+
+			JoinMessage:      "§e{name} has joined the server",
+			FirstJoinMessage: "§eWelcome {name} to the server for the first time!",
+			QuitMessage:      "§e{name} has left the server",
+
+			FormItemsPerPage: 8,
+
+			RandomTeleportRadius:   2000,
+			RandomTeleportCooldown: 300,
+
+			PortalCooldown:          5,
+			DimensionChangeCooldown: 2,
+
+			WebMap:          false,
+			WebMapDirectory: "map/",
+			WebMapInterval:  30,
+
+			MovementChecks:  true,
+			MaxMoveSpeed:    12,
+			MaxFlySpeed:     25,
+			MaxAirTicks:     40,
+			MaxMoveDistance: 10,
+
+			NetworkServerName:    "lobby",
+			NetworkHub:           "",
+			NetworkHubListen:     "",
+			NetworkSecret:        "",
+			NetworkPublicAddress: "",
+			NetworkForwardChat:   true,
+
+			Features: map[string]bool{
+				"server-auth-movement":   false,
+				"parallel-chunk-ticking": false,
+			},
 		})
 		var file, _ = os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
 		file.WriteString(string(data))
@@ -77,6 +294,16 @@ func initializeConfig(serverPath string) {
 	}
 }
 
+// Save writes the configuration to the configuration file,
+// overwriting the existing file.
+func (config *GoMineConfig) Save(serverPath string) error {
+	var data, err = yaml.Marshal(config)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(serverPath+"gomine.yml", data, 0644)
+}
+
 // getGoMineConfig parses the configuration file into a struct.
 func getGoMineConfig(serverPath string) *GoMineConfig {
 	var yamlFile, _ = ioutil.ReadFile(serverPath + "gomine.yml")
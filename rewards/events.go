@@ -0,0 +1,27 @@
+package rewards
+
+import (
+	"github.com/irmine/gomine/events"
+	"github.com/irmine/gomine/net"
+)
+
+const (
+	ClaimEventName events.Name = "RewardClaimEvent"
+)
+
+// ClaimEvent gets called when a player claims a daily reward or playtime milestone.
+// The reward is a copy which can be modified to change what the player receives.
+// Cancelling the event prevents the reward from being claimed.
+type ClaimEvent struct {
+	events.Cancellable
+	Session *net.MinecraftSession
+	Reward  *Reward
+	// Milestone is the claimed playtime milestone,
+	// or nil if the daily reward was claimed.
+	Milestone *Milestone
+}
+
+// GetName returns the name of the event.
+func (event *ClaimEvent) GetName() events.Name {
+	return ClaimEventName
+}
@@ -0,0 +1,60 @@
+package rewards
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/irmine/gomine/forms"
+	"github.com/irmine/gomine/net"
+	"github.com/irmine/gomine/text"
+)
+
+// OpenMenu sends a form to the session showing the daily reward and all playtime milestones.
+// Clicking an available reward claims it.
+func (manager *Manager) OpenMenu(session *net.MinecraftSession) {
+	var now = time.Now()
+	var form = forms.NewSimpleForm("Rewards", "Playtime: "+manager.GetPlaytime(session).Round(time.Minute).String())
+
+	var daily = "Daily Reward (day " + strconv.Itoa(manager.GetNextStreak(session)) + ")\n"
+	if manager.CanClaimDaily(session) {
+		daily += text.BrightGreen + "Available"
+	} else {
+		var tomorrow = time.Date(now.Year(), now.Month(), now.Day()+1, 0, 0, 0, 0, now.Location())
+		daily += text.Gray + "Available in " + tomorrow.Sub(now).Round(time.Minute).String()
+	}
+	form.AddButton(daily)
+
+	var milestones = manager.GetMilestones()
+	for _, milestone := range milestones {
+		var status = text.Gray + "Play " + (milestone.Playtime - manager.GetPlaytime(session)).Round(time.Minute).String() + " more"
+		if manager.IsMilestoneClaimed(session, milestone) {
+			status = text.Gray + "Claimed"
+		} else if manager.GetPlaytime(session) >= milestone.Playtime {
+			status = text.BrightGreen + "Available"
+		}
+		form.AddButton(milestone.Name + "\n" + status)
+	}
+
+	var err = session.SendForm(form, func(response *forms.Response) {
+		if response.Closed || response.Button > len(milestones) {
+			return
+		}
+		var reward *Reward
+		var err error
+		if response.Button == 0 {
+			reward, err = manager.ClaimDaily(session)
+		} else {
+			reward, err = manager.ClaimMilestone(session, milestones[response.Button-1].Name)
+		}
+		if err != nil {
+			session.SendMessage(text.Red + "Could not claim reward: " + err.Error())
+			return
+		}
+		var message = text.BrightGreen + "You claimed your reward: " + reward.describe()
+		if economy, ok := manager.GetEconomy(); ok && reward.Money > 0 {
+			message += " " + economy.Format(reward.Money)
+		}
+		session.SendMessage(message)
+	})
+	text.DefaultLogger.LogError(err)
+}
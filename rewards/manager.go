@@ -0,0 +1,350 @@
+package rewards
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/irmine/gomine/economy"
+	"github.com/irmine/gomine/events"
+	"github.com/irmine/gomine/items"
+	"github.com/irmine/gomine/items/inventory"
+	"github.com/irmine/gomine/net"
+	"github.com/irmine/gomine/players"
+	"github.com/irmine/gomine/text"
+	"gopkg.in/yaml.v2"
+)
+
+var (
+	// NotAvailable gets returned when a player tries to claim
+	// a reward that is not yet available.
+	NotAvailable = errors.New("reward is not available yet")
+	// AlreadyClaimed gets returned when a player tries
+	// to claim a milestone that was already claimed.
+	AlreadyClaimed = errors.New("reward has already been claimed")
+	// UnknownMilestone gets returned when a milestone
+	// with a given name could not be found.
+	UnknownMilestone = errors.New("unknown milestone")
+	// Cancelled gets returned when claiming a reward got cancelled by an event handler.
+	Cancelled = errors.New("claiming the reward was cancelled")
+)
+
+// online is an online player of which the playtime gets tracked.
+type online struct {
+	session  *net.MinecraftSession
+	joined   time.Time
+	notified map[string]bool
+}
+
+// Manager manages the daily rewards and playtime milestones.
+// Rewards are loaded from a YAML file, and the claims
+// and playtime of players are kept in their player data.
+type Manager struct {
+	mutex        sync.RWMutex
+	path         string
+	storage      players.DataStorage
+	eventManager *events.Manager
+	economy      economy.Economy
+	daily        []*Reward
+	milestones   []*Milestone
+	online       map[string]*online
+	lastCheck    time.Time
+}
+
+// NewManager returns a new reward manager using the rewards file at the given path.
+// Claims and playtime of players get saved to the data storage.
+func NewManager(path string, storage players.DataStorage, eventManager *events.Manager) *Manager {
+	return &Manager{path: path, storage: storage, eventManager: eventManager, online: make(map[string]*online)}
+}
+
+// Load loads all rewards from the rewards file.
+// A file with default rewards gets created if it does not yet exist.
+func (manager *Manager) Load() error {
+	var file, err = ioutil.ReadFile(manager.path)
+	if os.IsNotExist(err) {
+		var stone, _ = items.DefaultManager.Get("minecraft:stone", 16)
+		var emerald, _ = items.DefaultManager.Get("minecraft:emerald", 1)
+		manager.SetDailyRewards([]*Reward{{0, []*items.Stack{stone}}})
+		manager.SetMilestones([]*Milestone{{"1 hour", time.Hour, &Reward{0, []*items.Stack{emerald}}}})
+		return manager.Save()
+	}
+	if err != nil {
+		return err
+	}
+	var record rewardsRecord
+	if err := yaml.Unmarshal(file, &record); err != nil {
+		return err
+	}
+	var daily []*Reward
+	for _, rewardRecord := range record.Daily {
+		daily = append(daily, rewardRecord.toReward())
+	}
+	var milestones []*Milestone
+	for _, milestoneRecord := range record.Milestones {
+		var playtime, err = time.ParseDuration(milestoneRecord.Playtime)
+		if err != nil {
+			return err
+		}
+		milestones = append(milestones, &Milestone{milestoneRecord.Name, playtime, milestoneRecord.toReward()})
+	}
+	manager.SetDailyRewards(daily)
+	manager.SetMilestones(milestones)
+	return nil
+}
+
+// Save saves all rewards to the rewards file.
+func (manager *Manager) Save() error {
+	var record rewardsRecord
+	for _, reward := range manager.GetDailyRewards() {
+		record.Daily = append(record.Daily, newRewardRecord(reward))
+	}
+	for _, milestone := range manager.GetMilestones() {
+		record.Milestones = append(record.Milestones, milestoneRecord{milestone.Name, milestone.Playtime.String(), newRewardRecord(milestone.Reward)})
+	}
+	var data, err = yaml.Marshal(record)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(manager.path, data, 0644)
+}
+
+// SetEconomy sets the economy service used to give money rewards.
+// Money rewards are not given if no economy service has been set.
+func (manager *Manager) SetEconomy(economy economy.Economy) {
+	manager.mutex.Lock()
+	manager.economy = economy
+	manager.mutex.Unlock()
+}
+
+// GetEconomy returns the economy service of the manager,
+// and a bool indicating if one has been set.
+func (manager *Manager) GetEconomy() (economy.Economy, bool) {
+	manager.mutex.RLock()
+	defer manager.mutex.RUnlock()
+	return manager.economy, manager.economy != nil
+}
+
+// SetDailyRewards sets the daily rewards. The reward given depends on
+// the daily streak of a player: the first reward is given on the first day,
+// the second reward on the second consecutive day and so on.
+// The last reward is given once the streak exceeds the amount of rewards.
+func (manager *Manager) SetDailyRewards(rewards []*Reward) {
+	manager.mutex.Lock()
+	manager.daily = rewards
+	manager.mutex.Unlock()
+}
+
+// GetDailyRewards returns all daily rewards.
+func (manager *Manager) GetDailyRewards() []*Reward {
+	manager.mutex.RLock()
+	defer manager.mutex.RUnlock()
+	return manager.daily
+}
+
+// SetMilestones sets the playtime milestones.
+func (manager *Manager) SetMilestones(milestones []*Milestone) {
+	manager.mutex.Lock()
+	manager.milestones = milestones
+	manager.mutex.Unlock()
+}
+
+// GetMilestones returns all playtime milestones.
+func (manager *Manager) GetMilestones() []*Milestone {
+	manager.mutex.RLock()
+	defer manager.mutex.RUnlock()
+	return manager.milestones
+}
+
+// GetMilestone returns a milestone by its name, and an error if it could not be found.
+func (manager *Manager) GetMilestone(name string) (*Milestone, error) {
+	for _, milestone := range manager.GetMilestones() {
+		if milestone.Name == name {
+			return milestone, nil
+		}
+	}
+	return nil, UnknownMilestone
+}
+
+// Join starts tracking the playtime of the session.
+// The player gets notified if the daily reward is available.
+func (manager *Manager) Join(session *net.MinecraftSession) {
+	manager.mutex.Lock()
+	manager.online[session.GetName()] = &online{session, time.Now(), make(map[string]bool)}
+	manager.mutex.Unlock()
+
+	if manager.CanClaimDaily(session) {
+		session.SendMessage(text.BrightGreen + "Your daily reward is available! Claim it with /rewards.")
+	}
+}
+
+// Leave stops tracking the playtime of the session,
+// and saves the playtime of the session in its player data.
+func (manager *Manager) Leave(session *net.MinecraftSession) error {
+	manager.mutex.Lock()
+	var player, ok = manager.online[session.GetName()]
+	delete(manager.online, session.GetName())
+	manager.mutex.Unlock()
+	if !ok {
+		return nil
+	}
+	var data = session.GetPlayer().GetData()
+	data.Playtime += int64(time.Now().Sub(player.joined).Seconds())
+	return manager.storage.Save(data)
+}
+
+// GetPlaytime returns the total time the session has played,
+// including the current session.
+func (manager *Manager) GetPlaytime(session *net.MinecraftSession) time.Duration {
+	var playtime = time.Duration(session.GetPlayer().GetData().Playtime) * time.Second
+	manager.mutex.RLock()
+	defer manager.mutex.RUnlock()
+	if player, ok := manager.online[session.GetName()]; ok {
+		playtime += time.Now().Sub(player.joined)
+	}
+	return playtime
+}
+
+// CanClaimDaily checks if the session can claim its daily reward.
+// The daily reward can be claimed once every calendar day.
+func (manager *Manager) CanClaimDaily(session *net.MinecraftSession) bool {
+	var last = session.GetPlayer().GetData().LastDailyClaim
+	return len(manager.GetDailyRewards()) != 0 && (last == 0 || !sameDay(time.Unix(last, 0), time.Now()))
+}
+
+// GetNextStreak returns the daily streak the session will have after claiming the daily reward.
+// The streak continues if the previous reward was claimed yesterday, and resets otherwise.
+func (manager *Manager) GetNextStreak(session *net.MinecraftSession) int {
+	var data = session.GetPlayer().GetData()
+	if data.LastDailyClaim != 0 && sameDay(time.Unix(data.LastDailyClaim, 0), time.Now().AddDate(0, 0, -1)) {
+		return data.DailyStreak + 1
+	}
+	return 1
+}
+
+// GetDailyReward returns the daily reward for the given streak.
+func (manager *Manager) GetDailyReward(streak int) *Reward {
+	var daily = manager.GetDailyRewards()
+	if len(daily) == 0 {
+		return &Reward{}
+	}
+	if streak > len(daily) {
+		streak = len(daily)
+	}
+	if streak < 1 {
+		streak = 1
+	}
+	return daily[streak-1]
+}
+
+// ClaimDaily makes the session claim its daily reward.
+// The claimed reward is returned, which may differ from
+// the configured reward if it was modified by an event handler.
+func (manager *Manager) ClaimDaily(session *net.MinecraftSession) (*Reward, error) {
+	if !manager.CanClaimDaily(session) {
+		return nil, NotAvailable
+	}
+	var streak = manager.GetNextStreak(session)
+	var reward, err = manager.give(session, manager.GetDailyReward(streak), nil)
+	if err != nil {
+		return nil, err
+	}
+	var data = session.GetPlayer().GetData()
+	data.LastDailyClaim = time.Now().Unix()
+	data.DailyStreak = streak
+	return reward, manager.storage.Save(data)
+}
+
+// IsMilestoneClaimed checks if the session has claimed the milestone.
+func (manager *Manager) IsMilestoneClaimed(session *net.MinecraftSession, milestone *Milestone) bool {
+	for _, name := range session.GetPlayer().GetData().ClaimedMilestones {
+		if name == milestone.Name {
+			return true
+		}
+	}
+	return false
+}
+
+// ClaimMilestone makes the session claim the milestone with the given name.
+// NotAvailable gets returned if the session has not yet played long enough.
+func (manager *Manager) ClaimMilestone(session *net.MinecraftSession, name string) (*Reward, error) {
+	var milestone, err = manager.GetMilestone(name)
+	if err != nil {
+		return nil, err
+	}
+	if manager.IsMilestoneClaimed(session, milestone) {
+		return nil, AlreadyClaimed
+	}
+	if manager.GetPlaytime(session) < milestone.Playtime {
+		return nil, NotAvailable
+	}
+	reward, err := manager.give(session, milestone.Reward, milestone)
+	if err != nil {
+		return nil, err
+	}
+	var data = session.GetPlayer().GetData()
+	data.ClaimedMilestones = append(data.ClaimedMilestones, milestone.Name)
+	return reward, manager.storage.Save(data)
+}
+
+// give calls a claim event and gives the reward to the session if it was not cancelled.
+// inventory.FullInventory gets returned if not all items fit in the inventory.
+func (manager *Manager) give(session *net.MinecraftSession, reward *Reward, milestone *Milestone) (*Reward, error) {
+	var event = &ClaimEvent{Session: session, Reward: reward.copy(), Milestone: milestone}
+	if !manager.eventManager.Call(event) {
+		return nil, Cancelled
+	}
+	reward = event.Reward
+
+	var inv = session.GetPlayer().GetInventory()
+	if !inv.CanAddItems(reward.Items...) {
+		return nil, inventory.FullInventory
+	}
+	if economy, ok := manager.GetEconomy(); ok && reward.Money > 0 {
+		if err := economy.Deposit(session.GetName(), reward.Money); err != nil {
+			return nil, err
+		}
+	}
+	for _, stack := range reward.Items {
+		inv.AddItemReturningLeftovers(stack)
+	}
+	session.SendInventory()
+	return reward, nil
+}
+
+// Tick notifies online players once they reach a playtime milestone.
+// Playtime is checked once every 10 seconds.
+// Internal. Not to be used by plugins.
+func (manager *Manager) Tick() {
+	var now = time.Now()
+	if now.Sub(manager.lastCheck) < time.Second*10 {
+		return
+	}
+	manager.lastCheck = now
+
+	manager.mutex.RLock()
+	var tracked = make([]*online, 0, len(manager.online))
+	for _, player := range manager.online {
+		tracked = append(tracked, player)
+	}
+	manager.mutex.RUnlock()
+
+	for _, player := range tracked {
+		var playtime = manager.GetPlaytime(player.session)
+		for _, milestone := range manager.GetMilestones() {
+			if player.notified[milestone.Name] || playtime < milestone.Playtime || manager.IsMilestoneClaimed(player.session, milestone) {
+				continue
+			}
+			player.notified[milestone.Name] = true
+			player.session.SendMessage(text.BrightGreen + "You reached the " + milestone.Name + " playtime milestone! Claim your reward with /rewards.")
+		}
+	}
+}
+
+// sameDay checks if both times are on the same calendar day.
+func sameDay(a time.Time, b time.Time) bool {
+	var y1, m1, d1 = a.Date()
+	var y2, m2, d2 = b.Date()
+	return y1 == y2 && m1 == m2 && d1 == d2
+}
@@ -0,0 +1,51 @@
+package rewards
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/irmine/gomine/events"
+	"github.com/irmine/gomine/net"
+	"github.com/irmine/gomine/players"
+)
+
+func TestDailyStreak(t *testing.T) {
+	dir, _ := ioutil.TempDir("", "rewards")
+	defer os.RemoveAll(dir)
+	manager := NewManager(dir+"/rewards.yml", players.NewFileDataStorage(dir+"/players/"), events.NewManager())
+	if err := manager.Load(); err != nil {
+		t.Fatal("could not create default rewards:", err)
+	}
+	manager.SetDailyRewards([]*Reward{{Money: 1}, {Money: 2}})
+
+	session := net.NewMinecraftSession(nil, nil)
+	session.SetPlayer(players.NewPlayer(uuid.New(), "", 0, "Steve"))
+	data := session.GetPlayer().GetData()
+	if !manager.CanClaimDaily(session) || manager.GetNextStreak(session) != 1 {
+		t.Error("daily reward of new player is not available")
+	}
+
+	data.LastDailyClaim = time.Now().Unix()
+	if manager.CanClaimDaily(session) {
+		t.Error("daily reward is available twice on the same day")
+	}
+	data.LastDailyClaim, data.DailyStreak = time.Now().AddDate(0, 0, -1).Unix(), 5
+	if manager.GetNextStreak(session) != 6 || manager.GetDailyReward(6).Money != 2 {
+		t.Error("streak did not continue after claiming yesterday")
+	}
+	data.LastDailyClaim = time.Now().AddDate(0, 0, -3).Unix()
+	if manager.GetNextStreak(session) != 1 {
+		t.Error("streak did not reset after missing a day")
+	}
+
+	data.Playtime = int64(time.Hour.Seconds())
+	if _, err := manager.ClaimMilestone(session, "unknown"); err != UnknownMilestone {
+		t.Error("expected unknown milestone, got:", err)
+	}
+	if manager.GetPlaytime(session) < time.Hour {
+		t.Error("playtime does not include stored playtime")
+	}
+}
@@ -0,0 +1,84 @@
+package rewards
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/irmine/gomine/items"
+)
+
+// Reward is a set of items and money given to a player.
+type Reward struct {
+	Money float64
+	Items []*items.Stack
+}
+
+// copy returns a copy of the reward, of which
+// the items can be modified without modifying the reward.
+func (reward *Reward) copy() *Reward {
+	var stacks = make([]*items.Stack, len(reward.Items))
+	for i, stack := range reward.Items {
+		var c = *stack
+		stacks[i] = &c
+	}
+	return &Reward{reward.Money, stacks}
+}
+
+// describe returns a short description of the items of the reward,
+// such as "x32 Stone, x3 Paper".
+func (reward *Reward) describe() string {
+	var descriptions []string
+	for _, stack := range reward.Items {
+		descriptions = append(descriptions, fmt.Sprint("x", stack.Count, " ", stack.GetDisplayName()))
+	}
+	return strings.Join(descriptions, ", ")
+}
+
+// Milestone is a reward players can claim once
+// after having played for a given amount of time.
+type Milestone struct {
+	Name     string
+	Playtime time.Duration
+	Reward   *Reward
+}
+
+// rewardRecord is the stored form of a reward in the rewards file.
+type rewardRecord struct {
+	Money float64        `yaml:"Money"`
+	Items []items.Record `yaml:"Items"`
+}
+
+// milestoneRecord is the stored form of a milestone in the rewards file.
+type milestoneRecord struct {
+	Name         string `yaml:"Name"`
+	Playtime     string `yaml:"Playtime"`
+	rewardRecord `yaml:",inline"`
+}
+
+// rewardsRecord is the stored form of the rewards file.
+type rewardsRecord struct {
+	Daily      []rewardRecord    `yaml:"Daily"`
+	Milestones []milestoneRecord `yaml:"Milestones"`
+}
+
+// newRewardRecord returns the stored form of a reward.
+func newRewardRecord(reward *Reward) rewardRecord {
+	var record = rewardRecord{Money: reward.Money}
+	for _, stack := range reward.Items {
+		record.Items = append(record.Items, items.NewRecord(stack))
+	}
+	return record
+}
+
+// toReward converts the record back to a reward.
+// Items with an unknown type are left out.
+func (record rewardRecord) toReward() *Reward {
+	var reward = &Reward{Money: record.Money}
+	for _, itemRecord := range record.Items {
+		if stack, ok := itemRecord.ToStack(); ok {
+			reward.Items = append(reward.Items, stack)
+		}
+	}
+	return reward
+}
@@ -0,0 +1,215 @@
+package scheduler
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"sync/atomic"
+
+	"github.com/irmine/gomine/text"
+)
+
+// Task is a handle of a scheduled task, which can be used to cancel it.
+type Task struct {
+	id        int64
+	function  func()
+	nextTick  int64
+	period    int64
+	cancelled int32
+	cancel    context.CancelFunc
+}
+
+// Cancel cancels the task. Cancelled tasks are no longer run,
+// and the callbacks of cancelled async tasks are not called.
+// The context of a running async task gets cancelled.
+func (task *Task) Cancel() {
+	atomic.StoreInt32(&task.cancelled, 1)
+	if task.cancel != nil {
+		task.cancel()
+	}
+}
+
+// IsCancelled checks if the task has been cancelled.
+func (task *Task) IsCancelled() bool {
+	return atomic.LoadInt32(&task.cancelled) == 1
+}
+
+// Scheduler runs tasks on the server tick after a delay, or repeatedly every amount of ticks.
+// Async tasks are run by a pool of workers, after which their callbacks
+// are run on the next tick, so they can safely modify server state.
+// Panics of tasks are recovered and logged, so one task can not crash the tick loop.
+type Scheduler struct {
+	mutex       sync.Mutex
+	currentTick int64
+	lastId      int64
+	tasks       map[int64]*Task
+	callbacks   []func()
+	closed      bool
+	ctx         context.Context
+	cancel      context.CancelFunc
+
+	// workers limits the amount of async tasks running at the same time.
+	workers chan struct{}
+}
+
+// NewScheduler returns a new scheduler running at most the given amount of async tasks at the same time.
+func NewScheduler(workers int) *Scheduler {
+	if workers < 1 {
+		workers = 1
+	}
+	var ctx, cancel = context.WithCancel(context.Background())
+	return &Scheduler{tasks: make(map[int64]*Task), workers: make(chan struct{}, workers), ctx: ctx, cancel: cancel}
+}
+
+// GetCurrentTick returns the amount of times the scheduler has been ticked.
+func (scheduler *Scheduler) GetCurrentTick() int64 {
+	scheduler.mutex.Lock()
+	defer scheduler.mutex.Unlock()
+	return scheduler.currentTick
+}
+
+// ScheduleDelayed runs the function once after the given amount of ticks.
+// Delays below 1 run the function on the next tick.
+func (scheduler *Scheduler) ScheduleDelayed(function func(), delay int64) *Task {
+	return scheduler.schedule(function, delay, 0)
+}
+
+// ScheduleRepeating runs the function after the given delay in ticks,
+// and then repeatedly every period of ticks until the task is cancelled.
+func (scheduler *Scheduler) ScheduleRepeating(function func(), delay, period int64) *Task {
+	if period < 1 {
+		period = 1
+	}
+	return scheduler.schedule(function, delay, period)
+}
+
+// RunAsync runs the function on a worker of the async pool.
+// The callback is called with the result of the function on the next tick after the function finished,
+// unless the task was cancelled or the function panicked. The callback may be nil.
+func (scheduler *Scheduler) RunAsync(function func() interface{}, callback func(result interface{})) *Task {
+	return scheduler.RunAsyncContext(context.Background(), func(context.Context) interface{} {
+		return function()
+	}, callback)
+}
+
+// RunAsyncContext runs the function on a worker of the async pool, like RunAsync.
+// The function receives a context derived from the given context, which is cancelled
+// once the task is cancelled or the scheduler is closed, so that long running work can stop early.
+// Tasks of which the context is done before a worker is available are not run.
+func (scheduler *Scheduler) RunAsyncContext(ctx context.Context, function func(ctx context.Context) interface{}, callback func(result interface{})) *Task {
+	var task = &Task{}
+	scheduler.mutex.Lock()
+	defer scheduler.mutex.Unlock()
+	if scheduler.closed {
+		task.Cancel()
+		return task
+	}
+	ctx, task.cancel = context.WithCancel(ctx)
+	var stop = context.AfterFunc(scheduler.ctx, task.cancel)
+	go func() {
+		defer stop()
+		defer task.cancel()
+		select {
+		case scheduler.workers <- struct{}{}:
+		case <-ctx.Done():
+			return
+		}
+		defer func() { <-scheduler.workers }()
+		if task.IsCancelled() || ctx.Err() != nil {
+			return
+		}
+		var result interface{}
+		if !recoverTask(func() { result = function(ctx) }) || callback == nil {
+			return
+		}
+		scheduler.mutex.Lock()
+		scheduler.callbacks = append(scheduler.callbacks, func() {
+			if !task.IsCancelled() {
+				callback(result)
+			}
+		})
+		scheduler.mutex.Unlock()
+	}()
+	return task
+}
+
+// Tick runs all tasks due this tick and the callbacks of finished async tasks.
+// Tick should be called every server tick.
+func (scheduler *Scheduler) Tick() {
+	scheduler.mutex.Lock()
+	scheduler.currentTick++
+	var tick = scheduler.currentTick
+	var callbacks = scheduler.callbacks
+	scheduler.callbacks = nil
+
+	var due []*Task
+	for id, task := range scheduler.tasks {
+		if task.IsCancelled() {
+			delete(scheduler.tasks, id)
+			continue
+		}
+		if task.nextTick > tick {
+			continue
+		}
+		due = append(due, task)
+		if task.period == 0 {
+			delete(scheduler.tasks, id)
+		} else {
+			task.nextTick = tick + task.period
+		}
+	}
+	scheduler.mutex.Unlock()
+
+	// Tasks due in the same tick run in the order they were scheduled.
+	sort.Slice(due, func(i, j int) bool {
+		return due[i].id < due[j].id
+	})
+	for _, task := range due {
+		if !task.IsCancelled() {
+			recoverTask(task.function)
+		}
+	}
+	for _, callback := range callbacks {
+		recoverTask(callback)
+	}
+}
+
+// Close cancels all scheduled tasks. The contexts of async tasks already submitted are cancelled,
+// and async tasks still waiting for a worker are not run. No new async tasks are accepted.
+func (scheduler *Scheduler) Close() {
+	scheduler.mutex.Lock()
+	defer scheduler.mutex.Unlock()
+	scheduler.cancel()
+	for id, task := range scheduler.tasks {
+		task.Cancel()
+		delete(scheduler.tasks, id)
+	}
+	scheduler.closed = true
+}
+
+// schedule adds a task running the function after the delay,
+// repeating every period if the period is not 0.
+func (scheduler *Scheduler) schedule(function func(), delay, period int64) *Task {
+	if delay < 1 {
+		delay = 1
+	}
+	scheduler.mutex.Lock()
+	defer scheduler.mutex.Unlock()
+	scheduler.lastId++
+	var task = &Task{id: scheduler.lastId, function: function, nextTick: scheduler.currentTick + delay, period: period}
+	scheduler.tasks[task.id] = task
+	return task
+}
+
+// recoverTask runs the function, recovering and logging it if it panics.
+// A bool is returned indicating if the function ran without panicking.
+func recoverTask(function func()) (ok bool) {
+	defer func() {
+		if err := recover(); err != nil {
+			text.DefaultLogger.Error("Scheduled task panicked:", err)
+			ok = false
+		}
+	}()
+	function()
+	return true
+}
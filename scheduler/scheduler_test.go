@@ -0,0 +1,112 @@
+package scheduler
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestScheduler(t *testing.T) {
+	var scheduler = NewScheduler(2)
+	defer scheduler.Close()
+
+	var order []string
+	scheduler.ScheduleDelayed(func() { order = append(order, "delayed") }, 2)
+	var repeating = scheduler.ScheduleRepeating(func() { order = append(order, "repeating") }, 1, 2)
+	scheduler.ScheduleDelayed(func() { panic("bad task") }, 1)
+	var cancelled = scheduler.ScheduleDelayed(func() { order = append(order, "cancelled") }, 1)
+	cancelled.Cancel()
+
+	for i := 0; i < 4; i++ {
+		scheduler.Tick()
+	}
+	repeating.Cancel()
+	scheduler.Tick()
+
+	var expected = []string{"repeating", "delayed", "repeating"}
+	if len(order) != len(expected) {
+		t.Fatalf("expected tasks %v to run, got %v", expected, order)
+	}
+	for i := range expected {
+		if order[i] != expected[i] {
+			t.Fatalf("expected tasks %v to run, got %v", expected, order)
+		}
+	}
+}
+
+func TestRunAsync(t *testing.T) {
+	var scheduler = NewScheduler(1)
+	defer scheduler.Close()
+
+	var done = make(chan struct{})
+	var result interface{}
+	scheduler.RunAsync(func() interface{} {
+		defer close(done)
+		return 5
+	}, func(r interface{}) {
+		result = r
+	})
+	var cancelled = scheduler.RunAsync(func() interface{} { return 6 }, func(r interface{}) {
+		t.Error("callback of cancelled task was called")
+	})
+	cancelled.Cancel()
+	scheduler.RunAsync(func() interface{} { panic("bad task") }, func(r interface{}) {
+		t.Error("callback of panicked task was called")
+	})
+
+	<-done
+	if result != nil {
+		t.Fatal("callback was called outside of a tick")
+	}
+	for i := 0; i < 100 && result == nil; i++ {
+		time.Sleep(time.Millisecond)
+		scheduler.Tick()
+	}
+	if result != 5 {
+		t.Error("callback was not called with the result, got", result)
+	}
+}
+
+func TestRunAsyncContext(t *testing.T) {
+	var scheduler = NewScheduler(1)
+
+	var started = make(chan struct{})
+	var stopped = make(chan error)
+	var task = scheduler.RunAsyncContext(context.Background(), func(ctx context.Context) interface{} {
+		close(started)
+		<-ctx.Done()
+		stopped <- ctx.Err()
+		return nil
+	}, nil)
+	<-started
+	task.Cancel()
+	if err := <-stopped; err != context.Canceled {
+		t.Error("context of cancelled task was not cancelled, got", err)
+	}
+
+	var ran = make(chan struct{}, 1)
+	var ctx, cancel = context.WithCancel(context.Background())
+	cancel()
+	scheduler.RunAsyncContext(ctx, func(context.Context) interface{} {
+		ran <- struct{}{}
+		return nil
+	}, nil)
+
+	started = make(chan struct{})
+	scheduler.RunAsyncContext(context.Background(), func(ctx context.Context) interface{} {
+		close(started)
+		<-ctx.Done()
+		stopped <- ctx.Err()
+		return nil
+	}, nil)
+	<-started
+	scheduler.Close()
+	if err := <-stopped; err != context.Canceled {
+		t.Error("context of task was not cancelled when closing, got", err)
+	}
+	select {
+	case <-ran:
+		t.Error("task with a done context was run")
+	default:
+	}
+}
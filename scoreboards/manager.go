@@ -0,0 +1,412 @@
+package scoreboards
+
+import (
+	"errors"
+	"io/ioutil"
+	"math"
+	"os"
+	"sort"
+	"strconv"
+	"sync"
+
+	"github.com/irmine/gomine/combat"
+	"github.com/irmine/gomine/events"
+	"github.com/irmine/gomine/net"
+	"github.com/irmine/gomine/players"
+	"github.com/irmine/gomine/text"
+	"gopkg.in/yaml.v2"
+)
+
+var (
+	// UnknownObjective gets returned when an objective
+	// with a given name could not be found.
+	UnknownObjective = errors.New("unknown objective")
+	// ObjectiveExists gets returned when an objective gets added
+	// with the name of an objective that already exists.
+	ObjectiveExists = errors.New("objective already exists")
+	// InvalidCriteria gets returned when an objective gets added with an unknown criteria.
+	InvalidCriteria = errors.New("invalid criteria")
+	// ReadOnly gets returned when the scores of an objective that can not be changed get changed.
+	ReadOnly = errors.New("scores of the objective can not be changed")
+)
+
+const (
+	// DeathsStat is the stat counting the deaths of a player.
+	DeathsStat = "deaths"
+	// KillsStat is the stat counting the players killed by a player.
+	KillsStat = "kills"
+)
+
+// SidebarLines is the maximum amount of scores shown on the sidebar.
+const SidebarLines = 15
+
+// objectiveRecord is the stored form of an objective in the scoreboard file.
+type objectiveRecord struct {
+	Criteria    string           `yaml:"Criteria"`
+	DisplayName string           `yaml:"Display Name"`
+	Scores      map[string]int32 `yaml:"Scores"`
+}
+
+// scoreboardRecord is the stored form of the scoreboard in the scoreboard file.
+type scoreboardRecord struct {
+	Sidebar    string                     `yaml:"Sidebar"`
+	Objectives map[string]objectiveRecord `yaml:"Objectives"`
+}
+
+// Manager manages the objectives of the server scoreboard, and the objective shown on the sidebar.
+// Objectives with a criteria other than dummy are kept up to date automatically:
+// deaths and kills are counted from death events, health follows the health of online players,
+// and stat criteria follow the stats in the player data, which are changed with AddStat.
+// Objectives are loaded from and saved to a YAML file.
+type Manager struct {
+	// FilterFunction gets called for every session before the sidebar gets sent to it.
+	// Sessions for which it returns false are not sent the sidebar, so that they can be shown another scoreboard.
+	// All sessions are sent the sidebar by default.
+	FilterFunction func(session *net.MinecraftSession) bool
+
+	mutex          sync.RWMutex
+	path           string
+	sessionManager *net.SessionManager
+	storage        players.DataStorage
+	objectives     map[string]*Objective
+	sidebar        string
+	changed        bool
+}
+
+// NewManager returns a new scoreboard manager using the scoreboard file at the given path.
+// Deaths and kills are counted as stats in the player data, which gets saved to the data storage.
+func NewManager(path string, sessionManager *net.SessionManager, storage players.DataStorage, eventManager *events.Manager) *Manager {
+	var manager = &Manager{FilterFunction: func(*net.MinecraftSession) bool { return true }, path: path, sessionManager: sessionManager, storage: storage, objectives: make(map[string]*Objective)}
+	eventManager.Register(combat.DeathEventName, events.NewHandler(manager.handleDeath))
+	return manager
+}
+
+// Load loads all objectives from the scoreboard file, if it exists.
+func (manager *Manager) Load() error {
+	var file, err = ioutil.ReadFile(manager.path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	var record scoreboardRecord
+	if err := yaml.Unmarshal(file, &record); err != nil {
+		return err
+	}
+	manager.mutex.Lock()
+	defer manager.mutex.Unlock()
+	for name, objectiveRecord := range record.Objectives {
+		if !IsValidCriteria(objectiveRecord.Criteria) {
+			text.DefaultLogger.Error("Skipping objective", name+":", InvalidCriteria)
+			continue
+		}
+		var objective = NewObjective(name, objectiveRecord.Criteria, objectiveRecord.DisplayName)
+		for player, score := range objectiveRecord.Scores {
+			objective.scores[player] = score
+		}
+		manager.objectives[name] = objective
+	}
+	if _, ok := manager.objectives[record.Sidebar]; ok {
+		manager.sidebar = record.Sidebar
+	}
+	manager.changed = true
+	return nil
+}
+
+// Save saves all objectives to the scoreboard file.
+func (manager *Manager) Save() error {
+	manager.mutex.RLock()
+	var record = scoreboardRecord{Sidebar: manager.sidebar, Objectives: make(map[string]objectiveRecord)}
+	for name, objective := range manager.objectives {
+		var objectiveRecord = objectiveRecord{Criteria: objective.criteria, DisplayName: objective.displayName, Scores: make(map[string]int32)}
+		for player, score := range objective.scores {
+			objectiveRecord.Scores[player] = score
+		}
+		record.Objectives[name] = objectiveRecord
+	}
+	manager.mutex.RUnlock()
+	var data, err = yaml.Marshal(record)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(manager.path, data, 0644)
+}
+
+// AddObjective adds a new objective with the criteria.
+// Objectives following a stat start out with the stats of all online players.
+// The name of the objective is used as display name if the display name is empty.
+func (manager *Manager) AddObjective(name, criteria, displayName string) (*Objective, error) {
+	if !IsValidCriteria(criteria) {
+		return nil, InvalidCriteria
+	}
+	manager.mutex.Lock()
+	defer manager.mutex.Unlock()
+	if _, ok := manager.objectives[name]; ok {
+		return nil, ObjectiveExists
+	}
+	var objective = NewObjective(name, criteria, displayName)
+	manager.objectives[name] = objective
+	for _, session := range manager.sessionManager.GetSessions() {
+		manager.syncSession(objective, session)
+	}
+	return objective, nil
+}
+
+// RemoveObjective removes the objective with the given name,
+// removing it from the sidebar if it was shown.
+func (manager *Manager) RemoveObjective(name string) error {
+	manager.mutex.Lock()
+	defer manager.mutex.Unlock()
+	if _, ok := manager.objectives[name]; !ok {
+		return UnknownObjective
+	}
+	delete(manager.objectives, name)
+	if manager.sidebar == name {
+		manager.sidebar = ""
+		manager.changed = true
+	}
+	return nil
+}
+
+// GetObjective returns the objective with the given name.
+func (manager *Manager) GetObjective(name string) (*Objective, error) {
+	manager.mutex.RLock()
+	defer manager.mutex.RUnlock()
+	var objective, ok = manager.objectives[name]
+	if !ok {
+		return nil, UnknownObjective
+	}
+	return objective, nil
+}
+
+// GetObjectives returns all objectives, sorted by name.
+func (manager *Manager) GetObjectives() []*Objective {
+	manager.mutex.RLock()
+	var objectives = make([]*Objective, 0, len(manager.objectives))
+	for _, objective := range manager.objectives {
+		objectives = append(objectives, objective)
+	}
+	manager.mutex.RUnlock()
+	sort.Slice(objectives, func(i, j int) bool {
+		return objectives[i].name < objectives[j].name
+	})
+	return objectives
+}
+
+// SetSidebar shows the objective with the given name on the sidebar of all players.
+// The sidebar is hidden if the name is empty.
+func (manager *Manager) SetSidebar(name string) error {
+	manager.mutex.Lock()
+	defer manager.mutex.Unlock()
+	if _, ok := manager.objectives[name]; !ok && name != "" {
+		return UnknownObjective
+	}
+	manager.sidebar = name
+	manager.changed = true
+	return nil
+}
+
+// GetSidebar returns the name of the objective shown on the sidebar,
+// or an empty string if the sidebar is hidden.
+func (manager *Manager) GetSidebar() string {
+	manager.mutex.RLock()
+	defer manager.mutex.RUnlock()
+	return manager.sidebar
+}
+
+// SetScore sets the score of the player with the given name on the objective.
+func (manager *Manager) SetScore(player, objectiveName string, score int32) error {
+	return manager.updateScore(player, objectiveName, func(int32) int32 {
+		return score
+	})
+}
+
+// AddScore adds the amount to the score of the player with the given name on the objective.
+// Players without a score on the objective start at 0.
+func (manager *Manager) AddScore(player, objectiveName string, amount int32) error {
+	return manager.updateScore(player, objectiveName, func(score int32) int32 {
+		return score + amount
+	})
+}
+
+// updateScore sets the score of the player on the objective to the result of the function.
+func (manager *Manager) updateScore(player, objectiveName string, function func(score int32) int32) error {
+	manager.mutex.Lock()
+	defer manager.mutex.Unlock()
+	var objective, ok = manager.objectives[objectiveName]
+	if !ok {
+		return UnknownObjective
+	}
+	if objective.IsReadOnly() {
+		return ReadOnly
+	}
+	manager.setScore(objective, player, function(objective.scores[player]))
+	return nil
+}
+
+// ResetScore removes the score of the player with the given name from the objective,
+// or from all objectives if the objective name is empty.
+func (manager *Manager) ResetScore(player, objectiveName string) error {
+	manager.mutex.Lock()
+	defer manager.mutex.Unlock()
+	if objectiveName == "" {
+		for _, objective := range manager.objectives {
+			manager.resetScore(objective, player)
+		}
+		return nil
+	}
+	var objective, ok = manager.objectives[objectiveName]
+	if !ok {
+		return UnknownObjective
+	}
+	manager.resetScore(objective, player)
+	return nil
+}
+
+// GetScores returns an objective name => score map of all scores of the player with the given name.
+func (manager *Manager) GetScores(player string) map[string]int32 {
+	manager.mutex.RLock()
+	defer manager.mutex.RUnlock()
+	var scores = make(map[string]int32)
+	for name, objective := range manager.objectives {
+		if score, ok := objective.scores[player]; ok {
+			scores[name] = score
+		}
+	}
+	return scores
+}
+
+// AddStat adds the amount to a stat in the player data of the session, such as "kills",
+// and updates the scores of all objectives following the stat.
+// The player data gets saved to the data storage.
+func (manager *Manager) AddStat(session *net.MinecraftSession, stat string, amount float64) error {
+	var data = session.GetPlayer().GetData()
+	if data == nil {
+		return nil
+	}
+	data.Stats[stat] += amount
+	manager.mutex.Lock()
+	for _, objective := range manager.objectives {
+		if objectiveStat, ok := GetStat(objective.criteria); ok && objectiveStat == stat {
+			manager.setScore(objective, session.GetName(), int32(data.Stats[stat]))
+		}
+	}
+	manager.mutex.Unlock()
+	return manager.storage.Save(data)
+}
+
+// Join sets the scores of the session that joined on objectives following its stats and health,
+// and shows the sidebar to it on the next tick.
+func (manager *Manager) Join(session *net.MinecraftSession) {
+	manager.mutex.Lock()
+	for _, objective := range manager.objectives {
+		manager.syncSession(objective, session)
+	}
+	manager.changed = true
+	manager.mutex.Unlock()
+}
+
+// Tick updates the scores of objectives following the health of players,
+// and sends the sidebar to all players once it changed.
+func (manager *Manager) Tick() {
+	manager.mutex.Lock()
+	defer manager.mutex.Unlock()
+	for _, objective := range manager.objectives {
+		if objective.criteria != CriteriaHealth {
+			continue
+		}
+		for _, session := range manager.sessionManager.GetSessions() {
+			manager.syncSession(objective, session)
+		}
+	}
+	if !manager.changed {
+		return
+	}
+	manager.changed = false
+	var objective, ok = manager.objectives[manager.sidebar]
+	var lines []string
+	if ok {
+		for _, score := range objective.GetScores() {
+			if len(lines) == SidebarLines {
+				break
+			}
+			lines = append(lines, text.White+score.Name+text.Gray+": "+text.Red+strconv.Itoa(int(score.Value)))
+		}
+	}
+	for _, session := range manager.sessionManager.GetSessions() {
+		if !session.HasSpawned() || !manager.FilterFunction(session) {
+			continue
+		}
+		if ok {
+			session.SetScoreboard(objective.displayName, lines)
+		} else {
+			session.RemoveScoreboard()
+		}
+	}
+}
+
+// syncSession sets the score of the session on the objective if it follows a stat or health.
+func (manager *Manager) syncSession(objective *Objective, session *net.MinecraftSession) {
+	if objective.criteria == CriteriaHealth {
+		manager.setScore(objective, session.GetName(), int32(math.Ceil(float64(session.GetPlayer().GetHealth()))))
+		return
+	}
+	var stat, ok = GetStat(objective.criteria)
+	if !ok || session.GetPlayer().GetData() == nil {
+		return
+	}
+	if value, ok := session.GetPlayer().GetData().Stats[stat]; ok {
+		manager.setScore(objective, session.GetName(), int32(value))
+	}
+}
+
+// setScore sets the score of the player on the objective,
+// marking the sidebar as changed if the objective is shown on it.
+func (manager *Manager) setScore(objective *Objective, player string, score int32) {
+	if old, ok := objective.scores[player]; ok && old == score {
+		return
+	}
+	objective.scores[player] = score
+	if objective.name == manager.sidebar {
+		manager.changed = true
+	}
+}
+
+// resetScore removes the score of the player from the objective,
+// marking the sidebar as changed if the objective is shown on it.
+func (manager *Manager) resetScore(objective *Objective, player string) {
+	if _, ok := objective.scores[player]; !ok {
+		return
+	}
+	delete(objective.scores, player)
+	if objective.name == manager.sidebar {
+		manager.changed = true
+	}
+}
+
+// handleDeath counts the death of the player and the kill of the killer,
+// in their stats and on all objectives counting deaths and kills.
+func (manager *Manager) handleDeath(event events.Event) {
+	var death = event.(*combat.DeathEvent)
+	manager.count(death.Session.GetName(), CriteriaDeathCount)
+	text.DefaultLogger.LogError(manager.AddStat(death.Session, DeathsStat, 1))
+	if death.Killer == nil {
+		return
+	}
+	manager.count(death.Killer.GetName(), CriteriaPlayerKillCount, CriteriaTotalKillCount)
+	text.DefaultLogger.LogError(manager.AddStat(death.Killer, KillsStat, 1))
+}
+
+// count adds one to the score of the player on all objectives with any of the criteria.
+func (manager *Manager) count(player string, criteria ...string) {
+	manager.mutex.Lock()
+	defer manager.mutex.Unlock()
+	for _, objective := range manager.objectives {
+		for _, c := range criteria {
+			if objective.criteria == c {
+				manager.setScore(objective, player, objective.scores[player]+1)
+			}
+		}
+	}
+}
@@ -0,0 +1,113 @@
+package scoreboards
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/irmine/gomine/combat"
+	"github.com/irmine/gomine/events"
+	"github.com/irmine/gomine/net"
+	"github.com/irmine/gomine/players"
+)
+
+func newSession(name string) *net.MinecraftSession {
+	session := net.NewMinecraftSession(nil, nil)
+	session.SetPlayer(players.NewPlayer(uuid.New(), "", 0, name))
+	session.GetPlayer().SetData(players.NewData(name))
+	return session
+}
+
+func TestCriteria(t *testing.T) {
+	dir, _ := ioutil.TempDir("", "scoreboards")
+	defer os.RemoveAll(dir)
+	storage := players.NewFileDataStorage(dir + "/")
+	eventManager := events.NewManager()
+	sessionManager := net.NewSessionManager()
+	manager := NewManager(dir+"/scoreboard.yml", sessionManager, storage, eventManager)
+
+	if _, err := manager.AddObjective("broken", "unknown", ""); err != InvalidCriteria {
+		t.Error("expected invalid criteria, got", err)
+	}
+	if _, err := manager.AddObjective("deaths", CriteriaDeathCount, "Deaths"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := manager.AddObjective("deaths", CriteriaDummy, ""); err != ObjectiveExists {
+		t.Error("expected the objective to exist, got", err)
+	}
+	manager.AddObjective("kills", CriteriaPlayerKillCount, "")
+	manager.AddObjective("total", StatCriteriaPrefix+KillsStat, "")
+	manager.AddObjective("health", CriteriaHealth, "")
+
+	steve, alex := newSession("Steve"), newSession("Alex")
+	steve.GetPlayer().GetData().Stats[KillsStat] = 4
+	manager.Join(steve)
+	manager.Join(alex)
+	if objective, _ := manager.GetObjective("total"); objective.GetDisplayName() != "total" {
+		t.Error("expected the name to be used as display name, got", objective.GetDisplayName())
+	} else if score, ok := objective.GetScore("Steve"); !ok || score != 4 {
+		t.Error("expected the stat to be synced on join, got", score, ok)
+	}
+
+	eventManager.Call(&combat.DeathEvent{Session: alex, Killer: steve})
+	eventManager.Call(&combat.DeathEvent{Session: alex})
+	var scores = manager.GetScores("Alex")
+	if scores["deaths"] != 2 {
+		t.Error("expected two deaths, got", scores["deaths"])
+	}
+	scores = manager.GetScores("Steve")
+	if scores["kills"] != 1 || scores["total"] != 5 {
+		t.Error("expected a kill to be counted, got", scores)
+	}
+	data, err := storage.Load("Steve")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if data.Stats[KillsStat] != 5 {
+		t.Error("expected the kill stat to be saved, got", data.Stats[KillsStat])
+	}
+
+	if err := manager.SetScore("Steve", "health", 1); err != ReadOnly {
+		t.Error("expected health to be read-only, got", err)
+	}
+	if err := manager.AddScore("Steve", "kills", -1); err != nil || manager.GetScores("Steve")["kills"] != 0 {
+		t.Error("expected the score to be changed, got", err, manager.GetScores("Steve")["kills"])
+	}
+	manager.ResetScore("Steve", "")
+	if len(manager.GetScores("Steve")) != 0 {
+		t.Error("expected all scores to be reset, got", manager.GetScores("Steve"))
+	}
+}
+
+func TestSave(t *testing.T) {
+	dir, _ := ioutil.TempDir("", "scoreboards")
+	defer os.RemoveAll(dir)
+	storage := players.NewFileDataStorage(dir + "/")
+	manager := NewManager(dir+"/scoreboard.yml", net.NewSessionManager(), storage, events.NewManager())
+	manager.AddObjective("points", CriteriaDummy, "Points")
+	manager.SetScore("Steve", "points", 12)
+	if err := manager.SetSidebar("missing"); err != UnknownObjective {
+		t.Error("expected an unknown objective, got", err)
+	}
+	manager.SetSidebar("points")
+	if err := manager.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded := NewManager(dir+"/scoreboard.yml", net.NewSessionManager(), storage, events.NewManager())
+	if err := loaded.Load(); err != nil {
+		t.Fatal(err)
+	}
+	objective, err := loaded.GetObjective("points")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if score, _ := objective.GetScore("Steve"); score != 12 || objective.GetDisplayName() != "Points" || loaded.GetSidebar() != "points" {
+		t.Error("expected the scoreboard to be loaded, got", score, objective.GetDisplayName(), loaded.GetSidebar())
+	}
+	loaded.RemoveObjective("points")
+	if loaded.GetSidebar() != "" {
+		t.Error("expected the sidebar to be cleared with its objective")
+	}
+}
@@ -0,0 +1,107 @@
+package scoreboards
+
+import (
+	"sort"
+	"strings"
+)
+
+// Criteria of objectives, which decide how the scores of an objective change.
+const (
+	// CriteriaDummy objectives only change through commands and plugins.
+	CriteriaDummy = "dummy"
+	// CriteriaDeathCount objectives count the deaths of players.
+	CriteriaDeathCount = "deathCount"
+	// CriteriaPlayerKillCount objectives count the players killed by players.
+	CriteriaPlayerKillCount = "playerKillCount"
+	// CriteriaTotalKillCount objectives count everything killed by players.
+	// Only kills of players are tracked, as mobs do not report their killers.
+	CriteriaTotalKillCount = "totalKillCount"
+	// CriteriaHealth objectives hold the health of players, rounded up. Their scores can not be changed.
+	CriteriaHealth = "health"
+	// StatCriteriaPrefix is the prefix of criteria of objectives holding a stat kept in the player data,
+	// such as "stat.kills". The scores of these objectives follow the stat of every player.
+	StatCriteriaPrefix = "stat."
+)
+
+// IsValidCriteria checks if the criteria is one of the built-in criteria or a stat criteria.
+func IsValidCriteria(criteria string) bool {
+	switch criteria {
+	case CriteriaDummy, CriteriaDeathCount, CriteriaPlayerKillCount, CriteriaTotalKillCount, CriteriaHealth:
+		return true
+	}
+	return strings.HasPrefix(criteria, StatCriteriaPrefix) && len(criteria) > len(StatCriteriaPrefix)
+}
+
+// GetStat returns the stat followed by objectives with the criteria,
+// and a bool indicating if the criteria is a stat criteria.
+func GetStat(criteria string) (string, bool) {
+	if !strings.HasPrefix(criteria, StatCriteriaPrefix) || len(criteria) == len(StatCriteriaPrefix) {
+		return "", false
+	}
+	return strings.TrimPrefix(criteria, StatCriteriaPrefix), true
+}
+
+// Score is the score of a single player on an objective.
+type Score struct {
+	Name  string
+	Value int32
+}
+
+// Objective is a named set of player scores, which change according to the criteria of the objective.
+type Objective struct {
+	name        string
+	displayName string
+	criteria    string
+	scores      map[string]int32
+}
+
+// NewObjective returns a new objective without scores.
+// The name of the objective is used as display name if the display name is empty.
+func NewObjective(name, criteria, displayName string) *Objective {
+	if displayName == "" {
+		displayName = name
+	}
+	return &Objective{name: name, displayName: displayName, criteria: criteria, scores: make(map[string]int32)}
+}
+
+// GetName returns the name of the objective.
+func (objective *Objective) GetName() string {
+	return objective.name
+}
+
+// GetDisplayName returns the name of the objective shown on the sidebar.
+func (objective *Objective) GetDisplayName() string {
+	return objective.displayName
+}
+
+// GetCriteria returns the criteria of the objective.
+func (objective *Objective) GetCriteria() string {
+	return objective.criteria
+}
+
+// IsReadOnly checks if the scores of the objective can not be changed by commands and plugins.
+func (objective *Objective) IsReadOnly() bool {
+	return objective.criteria == CriteriaHealth
+}
+
+// GetScore returns the score of the player with the given name,
+// and a bool indicating if the player has a score on the objective.
+func (objective *Objective) GetScore(name string) (int32, bool) {
+	var score, ok = objective.scores[name]
+	return score, ok
+}
+
+// GetScores returns the scores of all players on the objective, highest first.
+func (objective *Objective) GetScores() []Score {
+	var scores = make([]Score, 0, len(objective.scores))
+	for name, value := range objective.scores {
+		scores = append(scores, Score{name, value})
+	}
+	sort.Slice(scores, func(i, j int) bool {
+		if scores[i].Value != scores[j].Value {
+			return scores[i].Value > scores[j].Value
+		}
+		return scores[i].Name < scores[j].Name
+	})
+	return scores
+}
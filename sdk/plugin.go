@@ -0,0 +1,94 @@
+// Package sdk provides helpers for writing plugins, such as a plugin base handling the data folder,
+// configuration and logger of the plugin, and a generator scaffolding new plugin projects.
+package sdk
+
+import (
+	"io/ioutil"
+	"os"
+
+	"github.com/irmine/gomine"
+	"github.com/irmine/gomine/commands"
+	"github.com/irmine/gomine/events"
+	"github.com/irmine/gomine/text"
+	"gopkg.in/yaml.v2"
+)
+
+// ConfigFile is the name of the configuration file in the data folder of a plugin.
+const ConfigFile = "config.yml"
+
+// PluginBase is a base for plugins, which should be embedded by them.
+// It embeds gomine.Plugin, and adds a data folder, configuration, logger
+// and shorthands for registering commands and event handlers.
+type PluginBase struct {
+	*gomine.Plugin
+
+	logger *text.Logger
+}
+
+// NewPluginBase returns a new plugin base for the server.
+// It should be called in the NewPlugin function of the plugin.
+func NewPluginBase(server *gomine.Server) *PluginBase {
+	return &PluginBase{Plugin: gomine.NewPlugin(server)}
+}
+
+// GetDataFolder returns the directory the plugin stores its files in,
+// which is the directory named after the plugin in the plugin directory.
+// The directory gets created if it does not exist.
+func (plug *PluginBase) GetDataFolder() string {
+	var path = plug.GetServer().ServerPath + gomine.PluginDirectory + plug.GetName() + "/"
+	text.DefaultLogger.LogError(os.MkdirAll(path, 0755))
+	return path
+}
+
+// GetLogger returns the logger of the plugin, which is prefixed with the name of the plugin
+// and writes to the same outputs as the default logger.
+func (plug *PluginBase) GetLogger() *text.Logger {
+	if plug.logger == nil {
+		plug.logger = text.NewLogger(plug.GetName(), text.DefaultLogger.DebugMode)
+		plug.logger.OutputFunctions = text.DefaultLogger.OutputFunctions
+	}
+	return plug.logger
+}
+
+// LoadConfig loads the configuration file in the data folder into the config, which should be a pointer.
+// If the file does not exist yet, the config holding its default values is saved to it instead.
+func (plug *PluginBase) LoadConfig(config interface{}) error {
+	var data, err = ioutil.ReadFile(plug.GetDataFolder() + ConfigFile)
+	if os.IsNotExist(err) {
+		return plug.SaveConfig(config)
+	}
+	if err != nil {
+		return err
+	}
+	return yaml.Unmarshal(data, config)
+}
+
+// SaveConfig saves the config to the configuration file in the data folder.
+func (plug *PluginBase) SaveConfig(config interface{}) error {
+	var data, err = yaml.Marshal(config)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(plug.GetDataFolder()+ConfigFile, data, 0644)
+}
+
+// RegisterCommand creates a command with the given command function and registers it to the server.
+// The command is returned so that arguments can be appended to it.
+func (plug *PluginBase) RegisterCommand(name string, description string, permission string, aliases []string, function interface{}) *commands.Command {
+	var command = commands.NewCommand(name, description, permission, aliases, function)
+	plug.GetCommandManager().RegisterCommand(command)
+	return command
+}
+
+// On registers the function as handler of events with the given name, using the default priority.
+func (plug *PluginBase) On(name events.Name, function func(event events.Event)) {
+	plug.GetEventManager().Register(name, events.NewHandler(function))
+}
+
+// OnPriority registers the function as handler of events with the given name and priority, from 0 to 10.
+// Handlers with a lower priority are called first.
+func (plug *PluginBase) OnPriority(name events.Name, priority int, function func(event events.Event)) {
+	var handler = events.NewHandler(function)
+	handler.SetPriority(priority)
+	plug.GetEventManager().Register(name, handler)
+}
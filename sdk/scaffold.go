@@ -0,0 +1,136 @@
+package sdk
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"text/template"
+
+	"github.com/irmine/gomine"
+)
+
+var (
+	// InvalidPluginName gets returned when scaffolding a plugin with a name that is not a plain word, such as "My Plugin".
+	InvalidPluginName = errors.New("plugin names may only contain letters, digits, dashes and underscores, and must start with a letter")
+	// DirectoryNotEmpty gets returned when scaffolding a plugin into a directory that already holds files.
+	DirectoryNotEmpty = errors.New("the directory of the plugin is not empty")
+)
+
+// pluginName matches valid names of plugins.
+var pluginName = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_-]*$`)
+
+// scaffoldData is the data the templates of a scaffolded plugin are executed with.
+type scaffoldData struct {
+	Name       string
+	Permission string
+	APIVersion string
+}
+
+// scaffoldFiles are the templates of the files of a scaffolded plugin, indexed by their file names.
+var scaffoldFiles = map[string]*template.Template{
+	"plugin.go": template.Must(template.New("plugin.go").Parse(`package main
+
+import (
+	"github.com/irmine/gomine"
+	"github.com/irmine/gomine/announcements"
+	"github.com/irmine/gomine/commands"
+	"github.com/irmine/gomine/events"
+	"github.com/irmine/gomine/sdk"
+)
+
+// Manifest holds the information of the plugin, and gets looked up by the plugin loader of the server.
+var Manifest = gomine.Manifest{
+	Name:        "{{.Name}}",
+	Description: "An example plugin.",
+	Version:     "1.0.0",
+	APIVersion:  "{{.APIVersion}}",
+	Author:      "Your Name",
+}
+
+// Config is the configuration of the plugin, stored in config.yml in its data folder.
+type Config struct {
+	Greeting string ` + "`yaml:\"Greeting\"`" + `
+}
+
+// Plugin is the example plugin. It greets players using /hello, and logs every player joining.
+type Plugin struct {
+	*sdk.PluginBase
+
+	config Config
+}
+
+// NewPlugin returns the plugin, and gets looked up by the plugin loader of the server.
+func NewPlugin(server *gomine.Server) gomine.IPlugin {
+	return &Plugin{PluginBase: sdk.NewPluginBase(server), config: Config{Greeting: "Hello from {{.Name}}!"}}
+}
+
+// OnEnable gets called when the plugin gets enabled, after all plugins were loaded.
+func (plug *Plugin) OnEnable() {
+	plug.GetLogger().LogError(plug.LoadConfig(&plug.config))
+
+	var hello = plug.RegisterCommand("hello", "Greets you", "{{.Permission}}.hello", []string{}, func(sender commands.Sender) {
+		sender.SendMessage(plug.config.Greeting)
+	})
+	hello.ExemptFromPermissionCheck(true)
+
+	plug.On(announcements.JoinEventName, func(event events.Event) {
+		plug.GetLogger().Info(event.(*announcements.JoinEvent).Session.GetName(), "joined the server.")
+	})
+}
+
+// OnDisable gets called when the plugin gets disabled, for example when the server shuts down.
+func (plug *Plugin) OnDisable() {
+	plug.GetLogger().LogError(plug.SaveConfig(plug.config))
+}
+`)),
+	"README.md": template.Must(template.New("README.md").Parse(`# {{.Name}}
+A GoMine plugin.
+
+## Building
+Plugins are built as Go plugins, against the same version of GoMine as the server:
+
+    go build -buildmode=plugin -o {{.Name}}.so .
+
+Copy {{.Name}}.so to the plugins directory of the server, and start the server.
+The configuration of the plugin gets written to plugins/{{.Name}}/config.yml.
+
+On operating systems without support for Go plugins, the plugin can be compiled into the server binary instead,
+by calling gomine.RegisterPlugin(Manifest, NewPlugin) in an init function of a package imported by the server.
+`)),
+}
+
+// IsValidPluginName checks if the name may be used as the name of a plugin.
+func IsValidPluginName(name string) bool {
+	return pluginName.MatchString(name)
+}
+
+// Scaffold writes a buildable example plugin with the given name into the directory,
+// holding its source code and a README explaining how to build it.
+// The directory gets created if it does not exist, and must be empty otherwise.
+func Scaffold(directory string, name string) error {
+	if !IsValidPluginName(name) {
+		return InvalidPluginName
+	}
+	if files, err := ioutil.ReadDir(directory); err == nil && len(files) != 0 {
+		return DirectoryNotEmpty
+	}
+	if err := os.MkdirAll(directory, 0755); err != nil {
+		return err
+	}
+	var data = scaffoldData{Name: name, Permission: strings.ToLower(name), APIVersion: gomine.ApiVersion}
+	for fileName, tmpl := range scaffoldFiles {
+		var file, err = os.Create(filepath.Join(directory, fileName))
+		if err != nil {
+			return err
+		}
+		err = tmpl.Execute(file, data)
+		file.Close()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
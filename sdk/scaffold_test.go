@@ -0,0 +1,40 @@
+package sdk
+
+import (
+	"go/parser"
+	"go/token"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestScaffold(t *testing.T) {
+	dir, _ := ioutil.TempDir("", "sdk")
+	defer os.RemoveAll(dir)
+	var directory = filepath.Join(dir, "Greeter")
+
+	if err := Scaffold(directory, "My Plugin"); err != InvalidPluginName {
+		t.Error("expected an invalid plugin name, got", err)
+	}
+	if err := Scaffold(directory, "Greeter"); err != nil {
+		t.Fatal(err)
+	}
+	source, err := ioutil.ReadFile(filepath.Join(directory, "plugin.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := parser.ParseFile(token.NewFileSet(), "plugin.go", source, 0); err != nil {
+		t.Error("scaffolded plugin is not valid Go:", err)
+	}
+	if !strings.Contains(string(source), `Name:        "Greeter"`) || !strings.Contains(string(source), `"greeter.hello"`) {
+		t.Error("scaffolded plugin does not use the plugin name:", string(source))
+	}
+	if _, err := os.Stat(filepath.Join(directory, "README.md")); err != nil {
+		t.Error("README was not scaffolded:", err)
+	}
+	if err := Scaffold(directory, "Greeter"); err != DirectoryNotEmpty {
+		t.Error("expected the directory not to be empty, got", err)
+	}
+}
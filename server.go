@@ -1,30 +1,82 @@
 package gomine
 
 import (
+	"context"
 	"crypto/ecdsa"
 	"crypto/elliptic"
 	"crypto/rand"
 	"github.com/irmine/worlds/generation/defaults"
-	"github.com/irmine/worlds/providers"
 
 	"encoding/hex"
 	"errors"
 	"fmt"
+	"github.com/golang/geo/r3"
+	"github.com/irmine/gomine/announcements"
+	"github.com/irmine/gomine/anticheat"
+	"github.com/irmine/gomine/antixray"
+	"github.com/irmine/gomine/branding"
+	"github.com/irmine/gomine/building"
+	"github.com/irmine/gomine/chat"
+	"github.com/irmine/gomine/combat"
 	"github.com/irmine/gomine/commands"
+	"github.com/irmine/gomine/cosmetics"
+	"github.com/irmine/gomine/crafting"
+	"github.com/irmine/gomine/drops"
+	"github.com/irmine/gomine/economy"
+	"github.com/irmine/gomine/ecs"
+	"github.com/irmine/gomine/events"
+	"github.com/irmine/gomine/features"
+	"github.com/irmine/gomine/friends"
+	"github.com/irmine/gomine/gs4"
+	"github.com/irmine/gomine/items"
+	"github.com/irmine/gomine/items/inventory"
+	"github.com/irmine/gomine/items/inventory/io"
+	"github.com/irmine/gomine/kits"
+	"github.com/irmine/gomine/lang"
+	"github.com/irmine/gomine/leaderboards"
+	"github.com/irmine/gomine/levels"
+	"github.com/irmine/gomine/limits"
+	"github.com/irmine/gomine/lobby"
+	"github.com/irmine/gomine/market"
+	"github.com/irmine/gomine/metrics"
+	"github.com/irmine/gomine/minigames"
+	"github.com/irmine/gomine/mobs"
+	"github.com/irmine/gomine/motd"
 	"github.com/irmine/gomine/net"
 	"github.com/irmine/gomine/net/info"
+	"github.com/irmine/gomine/net/packets"
+	"github.com/irmine/gomine/net/packets/bedrock"
 	"github.com/irmine/gomine/net/packets/data"
-	"github.com/irmine/gomine/net/protocol"
+	"github.com/irmine/gomine/net/packets/types"
+	"github.com/irmine/gomine/network"
+	"github.com/irmine/gomine/nicknames"
 	"github.com/irmine/gomine/packs"
+	"github.com/irmine/gomine/parties"
 	"github.com/irmine/gomine/permissions"
+	"github.com/irmine/gomine/playerlist"
+	"github.com/irmine/gomine/players"
 	"github.com/irmine/gomine/resources"
+	"github.com/irmine/gomine/rewards"
+	"github.com/irmine/gomine/scheduler"
+	"github.com/irmine/gomine/scoreboards"
+	"github.com/irmine/gomine/skins"
+	"github.com/irmine/gomine/tags"
+	"github.com/irmine/gomine/teleports"
 	"github.com/irmine/gomine/text"
+	"github.com/irmine/gomine/trade"
+	"github.com/irmine/gomine/webmap"
 	"github.com/irmine/goraklib/server"
 	"github.com/irmine/query"
 	"github.com/irmine/worlds"
+	"github.com/irmine/worlds/blocks"
+	"github.com/irmine/worlds/chunks"
+	"math"
 	net2 "net"
 	"os"
+	"runtime"
+	"strconv"
 	"strings"
+	"time"
 )
 
 const (
@@ -32,22 +84,89 @@ const (
 	GoMineVersion = "0.0.1"
 )
 
+const (
+	// DefaultTickRate is the amount of ticks per second the server runs at,
+	// if no valid tick rate has been configured.
+	DefaultTickRate = 20
+	// defaultSweepInterval is the interval in seconds at which stale sessions are swept,
+	// if no valid interval has been configured.
+	defaultSweepInterval = 30
+	// closedSessionRetention is the duration closed RakNet sessions are remembered,
+	// so that sessions added after their RakNet session closed are still found by sweeps.
+	closedSessionRetention = time.Minute * 5
+	// shutdownTimeout is the duration requests in progress get to finish when the server shuts down.
+	shutdownTimeout = time.Second * 5
+)
+
 type Server struct {
-	isRunning         bool
-	tick              int64
-	privateKey        *ecdsa.PrivateKey
-	token             []byte
-	ServerPath        string
-	Config            *resources.GoMineConfig
-	CommandReader     *text.CommandReader
-	CommandManager    *commands.Manager
-	PackManager       *packs.Manager
-	PermissionManager *permissions.Manager
-	LevelManager      *worlds.Manager
-	SessionManager    *net.SessionManager
-	NetworkAdapter    *net.NetworkAdapter
-	PluginManager     *PluginManager
-	QueryManager      query.Manager
+	isRunning           bool
+	ctx                 context.Context
+	cancel              context.CancelFunc
+	economy             economy.Economy
+	tick                int64
+	privateKey          *ecdsa.PrivateKey
+	token               []byte
+	serverMetrics       serverMetrics
+	degradation         degradation
+	packetArenas        *packetArenas
+	featureState        featureState
+	ServerPath          string
+	Config              *resources.GoMineConfig
+	Features            *features.Flags
+	CommandReader       *text.CommandReader
+	CommandManager      *commands.Manager
+	PackManager         *packs.Manager
+	PermissionManager   *permissions.Manager
+	LevelManager        *worlds.Manager
+	LevelStorage        *levels.Manager
+	BuildingManager     *building.Manager
+	CombatManager       *combat.Manager
+	DropManager         *drops.Manager
+	SessionManager      *net.SessionManager
+	NetworkAdapter      *net.NetworkAdapter
+	AntiXray            *antixray.Engine
+	PluginManager       *PluginManager
+	QueryManager        query.Manager
+	QueryServer         *gs4.Server
+	NetworkHub          *network.Hub
+	NetworkBridge       *network.Bridge
+	MinigameManager     *minigames.Manager
+	EventManager        *events.Manager
+	PartyManager        *parties.Manager
+	FriendManager       *friends.Manager
+	TradeManager        *trade.Manager
+	MarketManager       *market.Manager
+	TeleportManager     *teleports.Manager
+	DimensionManager    *teleports.DimensionManager
+	MapRenderer         *webmap.Renderer
+	MovementProcessor   *anticheat.Processor
+	PlayerStorage       players.DataStorage
+	ChatManager         *chat.Manager
+	KitManager          *kits.Manager
+	CraftingManager     *crafting.Manager
+	RewardManager       *rewards.Manager
+	LeaderboardManager  *leaderboards.Manager
+	MotdProvider        *motd.Provider
+	BrandingManager     *branding.Manager
+	AnnouncementManager *announcements.Manager
+	CosmeticManager     *cosmetics.Manager
+	MobManager          *mobs.Manager
+	ScoreboardManager   *scoreboards.Manager
+	PlayerListManager   *playerlist.Manager
+	NicknameManager     *nicknames.Manager
+	LobbyManager        *lobby.Manager
+	Scheduler           *scheduler.Scheduler
+	Metrics             *metrics.Registry
+	TickMeter           *metrics.TickMeter
+	LogBuffer           *text.LogBuffer
+	LimitMonitor        *limits.Monitor
+	SkinService         *skins.Service
+	EntityRegistry      *ecs.Registry
+
+	// PongFunction gets called every time the pong data is generated,
+	// and may modify the pong to customize the server list entry of the server.
+	// The query status uses the MOTD and player counts of the modified pong.
+	PongFunction func(pong *motd.Pong)
 }
 
 // AlreadyStarted gets returned during server startup,
@@ -57,9 +176,13 @@ var AlreadyStarted = errors.New("server is already started")
 // NewServer returns a new server with the given server path.
 func NewServer(serverPath string, config *resources.GoMineConfig) *Server {
 	var s = &Server{}
+	s.ctx, s.cancel = context.WithCancel(context.Background())
 
 	s.ServerPath = serverPath
 	s.Config = config
+	s.Features = features.NewFlags()
+	s.Features.RegisterDefaults()
+	s.Features.Load(config.Features)
 	text.DefaultLogger.DebugMode = config.DebugMode
 	file, _ := os.OpenFile(serverPath+"gomine.log", os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0700)
 	text.DefaultLogger.AddOutput(func(message []byte) {
@@ -68,23 +191,206 @@ func NewServer(serverPath string, config *resources.GoMineConfig) *Server {
 			text.DefaultLogger.LogError(err)
 		}
 	})
+	s.LogBuffer = text.NewLogBuffer(DebugLogLines)
+	text.DefaultLogger.AddOutput(s.LogBuffer.Write)
+
+	setMovementSettings(config)
 
 	s.LevelManager = worlds.NewManager(serverPath)
+	s.LevelStorage = levels.NewManager(serverPath)
+	s.LevelStorage.Interval = time.Duration(config.AutosaveInterval) * time.Second
+	s.LevelStorage.SaveFunction = s.markLoadedChunks
+	if config.WorldCompressionLevel > 0 {
+		// Region files are only recompressed if a compression level was configured.
+		s.LevelStorage.CompressionLevel = config.WorldCompressionLevel
+	}
+	if generator, ok := levels.ParseGenerator(config.DefaultGenerator); ok {
+		s.LevelStorage.DefaultGenerator = generator
+	}
+	if config.GeneratorPreset != "" {
+		if err := levels.ValidatePreset(config.GeneratorPreset, config.GeneratorBiome); err != nil {
+			text.DefaultLogger.Warning("Invalid generator preset " + config.GeneratorPreset + ": " + err.Error() + ", using the default preset instead. Available presets: " + strings.Join(levels.GetPresetNames(), ", "))
+		} else {
+			s.LevelStorage.DefaultPreset, s.LevelStorage.DefaultBiome = config.GeneratorPreset, config.GeneratorBiome
+			if !strings.EqualFold(config.GeneratorPreset, levels.PresetDefault) && s.LevelStorage.DefaultGenerator != levels.GeneratorInfinite {
+				text.DefaultLogger.Warning("Generator preset " + config.GeneratorPreset + " only applies to the infinite generator, but the default generator is " + levels.GetGeneratorName(s.LevelStorage.DefaultGenerator) + ".")
+			}
+		}
+	}
+	s.LevelStorage.ChunkCache = levels.NewChunkCache(config.ChunkCacheSize)
+	s.LevelStorage.GetWriter().ErrorFunction = text.DefaultLogger.LogError
+	s.LevelStorage.TimeFunction = s.broadcastTime
+	s.LevelStorage.WeatherFunction = s.broadcastWeather
+	s.LevelStorage.GameRuleFunction = s.broadcastGameRule
 	s.CommandReader = text.NewCommandReader(os.Stdin)
 	s.CommandReader.AddReadFunc(s.attemptReadCommand)
 
 	s.CommandManager = commands.NewManager()
+	s.CommandManager.SoftEnumFunction = s.broadcastSoftEnum
 
 	s.SessionManager = net.NewSessionManager()
-	s.NetworkAdapter = net.NewNetworkAdapter(NewPacketManager(s), s.SessionManager)
-	s.NetworkAdapter.GetRakLibManager().PongData = s.GeneratePongData()
+	s.packetArenas = newPacketArenas()
+	var latest = NewPacketManager(s)
+	s.NetworkAdapter = net.NewNetworkAdapter(latest, s.SessionManager)
+	s.NetworkAdapter.GetProtocolPool().RegisterProtocol(NewItemStackRequestVersion(s, latest))
 	s.NetworkAdapter.GetRakLibManager().RawPacketFunction = s.HandleRaw
 	s.NetworkAdapter.GetRakLibManager().DisconnectFunction = s.HandleDisconnect
+	s.NetworkAdapter.CompressionLevel = config.CompressionLevel
+	s.NetworkAdapter.CompressionThreshold = config.CompressionThreshold
+	s.NetworkAdapter.BatchPerTick = config.BatchPackets
+	if config.ForwardUnknownPackets {
+		s.NetworkAdapter.UnknownPacketFunction = s.forwardUnknownPacket
+	}
+	s.AntiXray = antixray.NewEngine()
+	for levelName, modeName := range config.AntiXray {
+		if mode, ok := antixray.ParseMode(modeName); ok {
+			s.AntiXray.SetSettings(levelName, antixray.DefaultSettings(mode))
+		} else {
+			text.DefaultLogger.Error("Unknown anti-xray mode " + modeName + " for level " + levelName)
+		}
+	}
+	s.NetworkAdapter.ChunkDataFunction = s.AntiXray.Serialize
+	s.SkinService = skins.NewService(config.SkinCacheSize)
+	s.NetworkAdapter.SkinFunction = func(skin skins.Skin, viewer *net.MinecraftSession) skins.Skin {
+		return s.SkinService.Convert(skin, viewer.GetProtocolNumber())
+	}
 
 	s.PackManager = packs.NewManager(serverPath)
 	s.PermissionManager = permissions.NewManager()
+	s.PermissionManager.SetStore(permissions.NewFileStore(serverPath+"groups.yml", serverPath+"players.yml"))
+	s.PermissionManager.UpdateFunction = s.updatePermissions
 	s.PluginManager = NewPluginManager(s)
 	s.QueryManager = query.NewManager()
+	s.QueryServer = gs4.NewServer()
+	s.NetworkHub = network.NewHub(config.NetworkSecret)
+	s.NetworkBridge = network.NewBridge(config.NetworkServerName, config.NetworkSecret)
+	s.NetworkBridge.ChatFunction = s.handleNetworkChat
+	s.NetworkBridge.CommandFunction = s.handleNetworkCommand
+	s.Scheduler = scheduler.NewScheduler(runtime.NumCPU())
+	s.MinigameManager = minigames.NewManager(s.Scheduler)
+	s.EventManager = events.NewManager()
+	s.BuildingManager = building.NewManager(s.SessionManager, s.EventManager)
+	s.BuildingManager.ChangeFunction = s.handleBlockChange
+	s.CombatManager = combat.NewManager(s.SessionManager, s.EventManager)
+	s.CombatManager.SpawnFunction = s.getSpawn
+	s.CombatManager.Scatter.Radius = config.SpawnRadius
+	s.CombatManager.Scatter.SearchFunction = s.LevelStorage.FindRandomSpawn
+	s.CombatManager.Settings = getPvPSettings(config.PvP)
+	s.CombatManager.DamageIndicators = config.DamageIndicators
+	s.CombatManager.RespawnImmunity = time.Duration(config.RespawnImmunity * float64(time.Second))
+	for levelName, pvp := range config.WorldPvP {
+		s.CombatManager.SetSettings(levelName, getPvPSettings(pvp))
+	}
+	s.DropManager = drops.NewManager(s.SessionManager, s.EventManager)
+	if config.ItemPickupDelay > 0 {
+		s.DropManager.PickupDelay = config.ItemPickupDelay
+	}
+	s.DropManager.OwnerWindow = config.ItemOwnerWindow
+	if config.DropBlockItems {
+		s.BuildingManager.DropFunction = s.dropBlockItems
+	}
+	s.PartyManager = parties.NewManager(s.EventManager)
+	s.FriendManager = friends.NewManager(friends.NewFileStorage(serverPath + "friends/"))
+	s.TradeManager = trade.NewManager()
+	s.TradeManager.DropFunction = func(session *net.MinecraftSession, stack *items.Stack) {
+		s.dropItem(session, stack, false)
+	}
+	s.MarketManager = market.NewManager(market.NewFileStorage(serverPath + "market.yml"))
+	s.MarketManager.SoldFunction = s.handleMarketSale
+	if config.FormItemsPerPage > 0 {
+		s.MarketManager.ItemsPerPage = config.FormItemsPerPage
+	}
+	s.TeleportManager = teleports.NewManager(s.EventManager)
+	s.TeleportManager.SearchFunction = s.LevelStorage.FindRandomSpawn
+	if config.RandomTeleportRadius > 0 {
+		s.TeleportManager.Radius = config.RandomTeleportRadius
+	}
+	if config.RandomTeleportCooldown > 0 {
+		s.TeleportManager.Cooldown = time.Duration(config.RandomTeleportCooldown) * time.Second
+	}
+	s.DimensionManager = teleports.NewDimensionManager(s.EventManager)
+	if config.PortalCooldown > 0 {
+		s.DimensionManager.PortalCooldown = time.Duration(config.PortalCooldown * float64(time.Second))
+	}
+	if config.DimensionChangeCooldown > 0 {
+		s.DimensionManager.ChangeCooldown = time.Duration(config.DimensionChangeCooldown * float64(time.Second))
+	}
+	s.MapRenderer = webmap.NewRenderer(serverPath + config.WebMapDirectory)
+	s.MapRenderer.ErrorFunction = text.DefaultLogger.LogError
+	if config.WebMapInterval > 0 {
+		s.MapRenderer.Interval = int64(config.WebMapInterval) * 20
+	}
+	s.PlayerStorage = players.NewFileDataStorage(serverPath + "players/")
+	s.ChatManager = chat.NewManager(s.SessionManager, s.PlayerStorage, s.EventManager, config.ChatFormat)
+	s.ChatManager.ColorCodes = config.ChatColorCodes
+	s.ChatManager.Shortcodes = getShortcodes(config.ChatShortcodes)
+	if config.NetworkForwardChat {
+		s.ChatManager.SendFunction = s.forwardChat
+	}
+	s.ChatManager.FilterFunction = s.receivesChat
+	s.KitManager = kits.NewManager(serverPath+"kits.yml", s.PlayerStorage)
+	s.CraftingManager = crafting.NewManager(serverPath + "recipes.json")
+	s.RewardManager = rewards.NewManager(serverPath+"rewards.yml", s.PlayerStorage, s.EventManager)
+	s.CosmeticManager = cosmetics.NewManager(s.SessionManager, s.PlayerStorage)
+	s.CosmeticManager.RegisterDefaults()
+	s.MobManager = mobs.NewManager(s.SessionManager)
+	s.MobManager.Cap = config.MobCap
+	s.MobManager.DespawnDistance = config.MobDespawnDistance
+	s.EntityRegistry = ecs.NewRegistry()
+	s.EntityRegistry.AddSource("players", s.SessionManager)
+	s.EntityRegistry.AddSource("mobs", s.MobManager)
+	s.EntityRegistry.AddSource("items", s.DropManager)
+	s.ScoreboardManager = scoreboards.NewManager(serverPath+"scoreboard.yml", s.SessionManager, s.PlayerStorage, s.EventManager)
+	s.ScoreboardManager.FilterFunction = s.showsServerScoreboard
+	s.MinigameManager.LeaveFunction = s.ScoreboardManager.Join
+	s.PlayerListManager = playerlist.NewManager(s.SessionManager, s.EventManager)
+	s.PlayerListManager.BatchPerTick = config.BatchPackets
+	s.NicknameManager = nicknames.NewManager(s.SessionManager, s.PlayerStorage, s.EventManager)
+	s.NicknameManager.RefreshFunction = s.refreshDisplayName
+	s.LobbyManager = lobby.NewManager(serverPath + "lobby.yml")
+	s.MobManager.AIFunction = func(dimension *worlds.Dimension) bool {
+		return s.LobbyManager.AllowsEntityAI(dimension.GetLevel().GetName())
+	}
+	s.DropManager.MergeFunction = func(dimension *worlds.Dimension) bool {
+		return s.LobbyManager.AllowsItemMerging(dimension.GetLevel().GetName())
+	}
+	s.LeaderboardManager = leaderboards.NewManager()
+	s.MotdProvider = motd.NewProvider(config.ServerMotd)
+	s.MotdProvider.Messages = config.MotdRotation
+	if config.MotdRotationInterval > 0 {
+		s.MotdProvider.Interval = time.Duration(config.MotdRotationInterval) * time.Second
+	}
+	s.MotdProvider.MaxPlayers = config.DisplayedMaxPlayers
+	s.MotdProvider.MaxPlayersOffset = config.MaxPlayersOffset
+	s.MotdProvider.HidePlayerCount = config.HidePlayerCount
+	s.MotdProvider.SubMotd = config.SubMotd
+	s.BrandingManager = branding.NewManager(s.SessionManager)
+	s.BrandingManager.WorldName = config.WorldDisplayName
+	s.BrandingManager.Title = config.JoinTitle
+	s.BrandingManager.Subtitle = config.JoinSubtitle
+	s.BrandingManager.Tips = config.LoadingTips
+	s.AnnouncementManager = announcements.NewManager(s.SessionManager, s.PlayerStorage, s.EventManager)
+	if config.JoinMessage != "" {
+		s.AnnouncementManager.JoinMessage = config.JoinMessage
+	}
+	if config.FirstJoinMessage != "" {
+		s.AnnouncementManager.FirstJoinMessage = config.FirstJoinMessage
+	}
+	if config.QuitMessage != "" {
+		s.AnnouncementManager.QuitMessage = config.QuitMessage
+	}
+	s.registerLeaderboards()
+	s.Metrics = metrics.NewRegistry()
+	s.TickMeter = metrics.NewTickMeter()
+	s.registerMetrics()
+	s.LimitMonitor = s.newLimitMonitor()
+	s.MovementProcessor = anticheat.NewProcessor(s.EventManager, anticheat.Thresholds{
+		MaxSpeed:        config.MaxMoveSpeed,
+		MaxFlySpeed:     config.MaxFlySpeed,
+		MaxAirTicks:     config.MaxAirTicks,
+		MaxMoveDistance: config.MaxMoveDistance,
+	})
+	s.MovementProcessor.SolidFunction = levels.IsSolidAt
 
 	if config.UseEncryption {
 		var curve = elliptic.P384()
@@ -114,6 +420,38 @@ func (server *Server) RegisterDefaultCommands() {
 	server.CommandManager.RegisterCommand(NewList(server))
 	server.CommandManager.RegisterCommand(NewPing())
 	server.CommandManager.RegisterCommand(NewTest(server))
+	server.CommandManager.RegisterCommand(NewParty(server))
+	server.CommandManager.RegisterCommand(NewFriend(server))
+	server.CommandManager.RegisterCommand(NewTrade(server))
+	server.CommandManager.RegisterCommand(NewMarket(server))
+	server.CommandManager.RegisterCommand(NewMessage(server))
+	server.CommandManager.RegisterCommand(NewChat(server))
+	server.CommandManager.RegisterCommand(NewMute(server))
+	server.CommandManager.RegisterCommand(NewUnmute(server))
+	server.CommandManager.RegisterCommand(NewKit(server))
+	server.CommandManager.RegisterCommand(NewRewards(server))
+	server.CommandManager.RegisterCommand(NewTop(server))
+	server.CommandManager.RegisterCommand(NewCosmetics(server))
+	server.CommandManager.RegisterCommand(NewSummon(server))
+	server.CommandManager.RegisterCommand(NewButcher(server))
+	server.CommandManager.RegisterCommand(NewKill(server))
+	server.CommandManager.RegisterCommand(NewScoreboard(server))
+	server.CommandManager.RegisterCommand(NewTags())
+	server.CommandManager.RegisterCommand(NewFly(server))
+	server.CommandManager.RegisterCommand(NewGod(server))
+	server.CommandManager.RegisterCommand(NewFreeze(server))
+	server.CommandManager.RegisterCommand(NewSort(server))
+	server.CommandManager.RegisterCommand(NewRandomTeleport(server))
+	server.CommandManager.RegisterCommand(NewWorld(server))
+	server.CommandManager.RegisterCommand(NewTransfer(server))
+	server.CommandManager.RegisterCommand(NewServers(server))
+	server.CommandManager.RegisterCommand(NewNetworkCommand(server))
+	server.CommandManager.RegisterCommand(NewHide(server))
+	server.CommandManager.RegisterCommand(NewWorldInfo(server))
+	server.CommandManager.RegisterCommand(NewLanguage(server))
+	server.CommandManager.RegisterCommand(NewNick(server))
+	server.CommandManager.RegisterCommand(NewDebug(server))
+	server.CommandManager.RegisterCommand(NewTracePackets(server))
 }
 
 // IsRunning checks if the server is running.
@@ -121,6 +459,12 @@ func (server *Server) IsRunning() bool {
 	return server.isRunning
 }
 
+// GetContext returns the context of the server, which is cancelled once the server shuts down.
+// Asynchronous work started by plugins should use it, so that it stops when the server stops.
+func (server *Server) GetContext() context.Context {
+	return server.ctx
+}
+
 // Start starts the server and loads levels, plugins, resource packs etc.
 // Start returns an error if one occurred during starting.
 func (server *Server) Start() error {
@@ -130,28 +474,144 @@ func (server *Server) Start() error {
 	text.DefaultLogger.Info("GoMine "+GoMineVersion+" is now starting...", "("+server.ServerPath+")")
 
 	server.LevelManager.SetDefaultLevel(worlds.NewLevel("world", server.ServerPath))
+	if _, err := server.LevelStorage.Open(server.LevelManager.GetDefaultLevel(), server.getWorldFormat()); err != nil {
+		return err
+	}
 	var dimension = worlds.NewDimension("overworld", server.LevelManager.GetDefaultLevel(), worlds.OverworldId)
-	dimension.SetChunkProvider(providers.NewAnvil(server.ServerPath + "worlds/world/overworld/region/"))
+	if err := server.LevelStorage.AddDimension(dimension, "overworld"); err != nil {
+		return err
+	}
 	server.LevelManager.GetDefaultLevel().SetDefaultDimension(dimension)
 	dimension.SetGenerator(defaults.NewFlatGenerator())
+	if server.LevelStorage.IsCreated(dimension.GetLevel().GetName()) && server.Config.SpawnSearchRadius > 0 {
+		// The spawn of new levels is moved to the closest safe surface, so that players do not spawn in oceans or lava.
+		server.LevelStorage.FindSpawn(dimension, int32(server.Config.SpawnSearchRadius), func(spawn r3.Vector, err error) {
+			if err == levels.NoSafeSpawn {
+				text.DefaultLogger.Info("No safe spawn was found, using the configured spawn.")
+			} else if err != nil {
+				text.DefaultLogger.Error("Could not search a safe spawn:", err)
+			}
+		})
+	}
 
 	server.RegisterDefaultCommands()
-
-	server.PackManager.LoadResourcePacks() // Behavior packs may depend on resource packs, so always load resource packs first.
-	server.PackManager.LoadBehaviorPacks()
+	text.DefaultLogger.LogError(lang.DefaultTranslator.LoadDirectory(server.ServerPath + "lang/"))
+	text.DefaultLogger.LogError(server.PermissionManager.Load())
+	text.DefaultLogger.LogError(server.KitManager.Load())
+	server.CommandManager.RegisterSoftEnum(KitSoftEnum, server.KitManager.GetKitNames()...)
+	text.DefaultLogger.LogError(tags.DefaultRegistry.LoadDirectory(server.ServerPath + "tags/"))
+	text.DefaultLogger.LogError(server.CraftingManager.Load())
+	text.DefaultLogger.LogError(server.RewardManager.Load())
+	text.DefaultLogger.LogError(server.ScoreboardManager.Load())
+	text.DefaultLogger.LogError(server.LobbyManager.Load())
+	text.DefaultLogger.LogError(server.NicknameManager.Load())
+
+	for _, err := range server.PackManager.LoadResourcePacks() { // Behavior packs may depend on resource packs, so always load resource packs first.
+		text.DefaultLogger.LogError(err)
+	}
+	for _, err := range server.PackManager.LoadBehaviorPacks() {
+		text.DefaultLogger.LogError(err)
+	}
 
 	server.PluginManager.LoadPlugins()
+	// Plugins may register and set feature flags, so subsystems check the flags after loading plugins.
+	server.applyFeatures()
+	text.DefaultLogger.LogError(server.MarketManager.Load()) // Plugins may set a different market storage, so load the market after plugins.
+
+	// Queries are answered on the server port by default. A different query port gets its own listener.
+	if server.Config.AllowQuery && !server.IsHeadless() && server.Config.QueryPort != 0 && server.Config.QueryPort != server.Config.ServerPort {
+		text.DefaultLogger.LogError(server.QueryServer.Listen(net2.JoinHostPort(server.Config.ServerIp, strconv.Itoa(int(server.Config.QueryPort)))))
+	}
+
+	if server.Config.NetworkHubListen != "" && !server.IsHeadless() {
+		text.DefaultLogger.LogError(server.NetworkHub.Listen(server.ctx, server.Config.NetworkHubListen))
+	}
+	if server.Config.NetworkHub != "" && !server.IsHeadless() {
+		text.DefaultLogger.LogError(server.NetworkBridge.Connect(server.ctx, server.Config.NetworkHub))
+	}
 
+	if server.Config.MetricsAddress != "" && !server.IsHeadless() {
+		text.DefaultLogger.LogError(server.Metrics.Listen(server.ctx, server.Config.MetricsAddress, server.Config.MetricsProfiling))
+	}
+
+	server.UpdateStatus()
 	server.isRunning = true
+	if server.IsHeadless() {
+		text.DefaultLogger.Info("Running headless, networking is disabled.")
+		return nil
+	}
 	return server.NetworkAdapter.GetRakLibManager().Start(server.Config.ServerIp, int(server.Config.ServerPort))
 }
 
+// IsHeadless checks if the server runs in headless mode.
+// Headless servers do not accept players or open any listeners,
+// and tick as fast as possible rather than at the tick rate,
+// which makes them suited for offline world generation, simulations and tests.
+func (server *Server) IsHeadless() bool {
+	return server.Config.Headless
+}
+
+// GetTickRate returns the amount of ticks per second the server runs at.
+func (server *Server) GetTickRate() int {
+	if server.Config.TickRate <= 0 {
+		return DefaultTickRate
+	}
+	return server.Config.TickRate
+}
+
+// GetTickDuration returns the duration of a single tick at the tick rate of the server.
+func (server *Server) GetTickDuration() time.Duration {
+	return time.Second / time.Duration(server.GetTickRate())
+}
+
+// Run ticks the server until it shuts down.
+// The server is ticked at its tick rate, or without sleeping between ticks if the server is headless.
+func (server *Server) Run() {
+	if server.IsHeadless() {
+		for server.IsRunning() {
+			server.Tick()
+		}
+		return
+	}
+	var ticker = time.NewTicker(server.GetTickDuration())
+	defer ticker.Stop()
+	for range ticker.C {
+		if !server.IsRunning() {
+			return
+		}
+		server.Tick()
+	}
+}
+
+// FastForward ticks the server the given amount of times without sleeping between ticks,
+// regardless of the tick rate. Features based on durations rather than ticks,
+// such as damage immunity, still depend on the time passed.
+func (server *Server) FastForward(ticks int) {
+	for i := 0; i < ticks && server.IsRunning(); i++ {
+		server.Tick()
+	}
+}
+
 // Shutdown shuts down the server, saving and disabling everything.
 func (server *Server) Shutdown() {
 	if !server.isRunning {
 		return
 	}
 	text.DefaultLogger.Info("Server is shutting down.")
+	server.PluginManager.DisablePlugins()
+
+	// Requests in progress get a moment to finish, after which all work still using the context of the server is cancelled.
+	var ctx, cancel = context.WithTimeout(context.Background(), shutdownTimeout)
+	text.DefaultLogger.LogError(server.Metrics.Shutdown(ctx))
+	cancel()
+	server.cancel()
+
+	server.Scheduler.Close()
+	text.DefaultLogger.LogError(server.QueryServer.Close())
+	text.DefaultLogger.LogError(server.NetworkBridge.Close())
+	text.DefaultLogger.LogError(server.NetworkHub.Close())
+	text.DefaultLogger.LogError(server.LevelStorage.Close())
+	text.DefaultLogger.LogError(server.ScoreboardManager.Save())
 
 	text.DefaultLogger.Notice("Server stopped.")
 	text.DefaultLogger.Wait()
@@ -159,6 +619,578 @@ func (server *Server) Shutdown() {
 	server.isRunning = false
 }
 
+// Freeze freezes or unfreezes the player of the session after calling a freeze event.
+// A bool is returned indicating if the state of the player changed.
+func (server *Server) Freeze(session *net.MinecraftSession, frozen bool) bool {
+	if session.GetPlayer().IsFrozen() == frozen {
+		return false
+	}
+	if !server.EventManager.Call(&net.FreezeEvent{Session: session, Frozen: frozen}) {
+		return false
+	}
+	session.SetFrozen(frozen)
+	return true
+}
+
+// SortInventory compacts and sorts the inventory of the player of the session after calling an inventory sort event.
+// The hotbar is left untouched unless includeHotbar is true.
+// A bool is returned indicating if the inventory got sorted.
+func (server *Server) SortInventory(session *net.MinecraftSession, includeHotbar bool) bool {
+	if !server.EventManager.Call(&net.InventorySortEvent{Session: session, IncludeHotbar: includeHotbar}) {
+		return false
+	}
+	var inv = session.GetPlayer().GetInventory()
+	var from = players.HotbarSize
+	if includeHotbar {
+		from = 0
+	}
+	inv.SortSlots(from, inv.GetSize(), inventory.ByType)
+	session.SendInventory()
+	return true
+}
+
+// SetHidden hides or shows an update category to the session,
+// and spawns or despawns the players, pets or mobs the session can now see or no longer sees.
+func (server *Server) SetHidden(session *net.MinecraftSession, category net.UpdateCategory, hidden bool) {
+	session.SetHidden(category, hidden)
+	switch category {
+	case net.CategoryPlayers:
+		server.updatePlayerVisibility(session)
+	case net.CategoryPets:
+		server.CosmeticManager.UpdateVisibility(session)
+	case net.CategoryMobs:
+		server.MobManager.UpdateVisibility(session)
+	}
+}
+
+// handleBlockChange marks the chunk as changed after a block in it changed,
+// and reveals the real blocks around the changed block that were hidden by anti-xray.
+func (server *Server) handleBlockChange(dimension *worlds.Dimension, chunk *chunks.Chunk, position blocks.Position) {
+	server.LevelStorage.BlockChanged(dimension, chunk, position)
+	if server.Config.WebMap {
+		server.MapRenderer.MarkDirty(dimension, chunk)
+	}
+	var revealed = server.AntiXray.Reveal(dimension, chunk, position)
+	if len(revealed) == 0 {
+		return
+	}
+	for _, viewer := range server.BuildingManager.GetViewers(dimension, chunk) {
+		for neighbour, runtimeId := range revealed {
+			viewer.SendUpdateBlock(neighbour, runtimeId, bedrock.DataLayerNormal)
+		}
+	}
+}
+
+// updatePlayerVisibility spawns or despawns all other players to the session,
+// depending on if the session hides players.
+func (server *Server) updatePlayerVisibility(session *net.MinecraftSession) {
+	var hidden = session.IsHidden(net.CategoryPlayers)
+	for _, online := range server.SessionManager.GetSessions() {
+		if online == session || !online.HasSpawned() {
+			continue
+		}
+		var player = online.GetPlayer()
+		if viewing := session.IsViewing(player.Entity); !hidden && !viewing {
+			player.SpawnPlayerTo(session)
+			player.AddViewer(session)
+			online.SendSkin(session)
+		} else if hidden && viewing {
+			player.RemoveViewer(session)
+			session.SendRemoveEntity(player.GetUniqueId())
+		}
+	}
+}
+
+// SetEconomy sets the economy service of the server.
+// The economy service is used by the market to pay for listings,
+// and to give money rewards.
+func (server *Server) SetEconomy(economy economy.Economy) {
+	server.economy = economy
+	server.MarketManager.SetEconomy(economy)
+	server.RewardManager.SetEconomy(economy)
+}
+
+// GetEconomy returns the economy service of the server,
+// and a bool indicating if one has been set.
+func (server *Server) GetEconomy() (economy.Economy, bool) {
+	return server.economy, server.economy != nil
+}
+
+// handleMarketSale notifies the seller of a market listing once it got bought.
+func (server *Server) handleMarketSale(listing *market.Listing, buyer string) {
+	if session, ok := server.SessionManager.GetSession(listing.Seller); ok {
+		session.SendMessage(text.BrightGreen+buyer, "bought your listing of", listing.Item, "for", server.MarketManager.FormatPrice(listing.Price)+".")
+	}
+}
+
+// markLoadedChunks marks the changed parts of all chunks loaded by players as changed,
+// so that generated chunks and blocks set by plugins get saved along with chunks changed by players.
+func (server *Server) markLoadedChunks() {
+	for _, session := range server.SessionManager.GetSessions() {
+		var dimension = session.GetPlayer().GetDimension()
+		if dimension == nil {
+			continue
+		}
+		for _, chunk := range session.GetChunkLoader().GetLoadedChunks() {
+			server.LevelStorage.MarkLoaded(dimension, chunk)
+		}
+	}
+}
+
+// renderMap marks all chunks loaded by players to be rendered on the web map,
+// and renders all chunks that were loaded or changed since the last render.
+func (server *Server) renderMap() {
+	for _, session := range server.SessionManager.GetSessions() {
+		var dimension = session.GetPlayer().GetDimension()
+		if dimension == nil {
+			continue
+		}
+		for _, chunk := range session.GetChunkLoader().GetLoadedChunks() {
+			server.MapRenderer.MarkLoaded(dimension, chunk)
+		}
+	}
+	server.MapRenderer.Flush()
+}
+
+// getLevelViewers returns the sessions of all players in the level with the given name.
+func (server *Server) getLevelViewers(levelName string) []*net.MinecraftSession {
+	var viewers []*net.MinecraftSession
+	for _, session := range server.SessionManager.GetSessions() {
+		if dimension := session.GetPlayer().GetDimension(); dimension != nil && dimension.GetLevel().GetName() == levelName {
+			viewers = append(viewers, session)
+		}
+	}
+	return viewers
+}
+
+// broadcastTime sends the time of a level to all players in the level.
+func (server *Server) broadcastTime(levelName string, time int64) {
+	for _, session := range server.getLevelViewers(levelName) {
+		session.SendSetTime(int32(time))
+	}
+}
+
+// broadcastWeather sends the weather of a level to all players in the level.
+func (server *Server) broadcastWeather(levelName string, weather levels.Weather) {
+	for _, session := range server.getLevelViewers(levelName) {
+		sendWeather(session, weather)
+	}
+}
+
+// broadcastGameRule sends a changed game rule of a level to all players in the level.
+func (server *Server) broadcastGameRule(levelName string, name string, value interface{}) {
+	var gameRules = map[string]types.GameRuleEntry{name: {Name: name, Value: value}}
+	for _, session := range server.getLevelViewers(levelName) {
+		session.SendGameRulesChanged(gameRules)
+	}
+}
+
+// broadcastSoftEnum sends an update of a soft enum to all connected players,
+// so that their command completions stay in sync with the values of the soft enum.
+func (server *Server) broadcastSoftEnum(name string, updateType byte, values []string) {
+	for _, session := range server.SessionManager.GetSessions() {
+		if session.Connected {
+			session.SendUpdateSoftEnum(name, values, updateType)
+		}
+	}
+}
+
+// sendSoftEnums sends the complete set of values of every soft enum to the session.
+func (server *Server) sendSoftEnums(session *net.MinecraftSession) {
+	for name, values := range server.CommandManager.GetSoftEnums() {
+		session.SendUpdateSoftEnum(name, values, data.SoftEnumSet)
+	}
+}
+
+// sendWorld sends the time, weather and game rules of the level the session is in to the session.
+func (server *Server) sendWorld(session *net.MinecraftSession) {
+	var dimension = session.GetPlayer().GetDimension()
+	if dimension == nil {
+		return
+	}
+	var world, ok = server.LevelStorage.GetWorld(dimension.GetLevel().GetName())
+	if !ok {
+		return
+	}
+	session.SendSetTime(int32(world.GetTime()))
+	sendWeather(session, world.GetWeather())
+	var gameRules = make(map[string]types.GameRuleEntry)
+	for name, value := range world.GetGameRules() {
+		gameRules[name] = types.GameRuleEntry{Name: name, Value: value}
+	}
+	if len(gameRules) != 0 {
+		session.SendGameRulesChanged(gameRules)
+	}
+}
+
+// sendWeather sends the level events starting and stopping rain and thunder for the weather to the session.
+func sendWeather(session *net.MinecraftSession, weather levels.Weather) {
+	var position = session.GetPlayer().Position
+	switch weather {
+	case levels.WeatherClear:
+		session.SendLevelEvent(data.LevelEventStopRain, position, 0)
+		session.SendLevelEvent(data.LevelEventStopThunder, position, 0)
+	case levels.WeatherRain:
+		session.SendLevelEvent(data.LevelEventStartRain, position, math.MaxUint16)
+		session.SendLevelEvent(data.LevelEventStopThunder, position, 0)
+	case levels.WeatherThunder:
+		session.SendLevelEvent(data.LevelEventStartRain, position, math.MaxUint16)
+		session.SendLevelEvent(data.LevelEventStartThunder, position, math.MaxUint16)
+	}
+}
+
+// forwardUnknownPacket calls an unknown packet event for the packet,
+// so that plugins can handle packets not supported by GoMine.
+func (server *Server) forwardUnknownPacket(packet *packets.UnknownPacket, session *net.MinecraftSession) {
+	server.EventManager.Call(&net.UnknownPacketEvent{Session: session, Packet: packet})
+}
+
+// setMovementSettings sets the movement settings of players and mobs to those of the config,
+// keeping the default settings for values that were not configured.
+func setMovementSettings(config *resources.GoMineConfig) {
+	if config.MovementUpdateInterval > 0 {
+		players.DefaultMovementSettings.Interval = config.MovementUpdateInterval
+	}
+	if config.MovementRotationThreshold > 0 {
+		players.DefaultMovementSettings.RotationThreshold = config.MovementRotationThreshold
+	}
+	if config.MovementTeleportDistance > 0 {
+		players.DefaultMovementSettings.TeleportDistance = config.MovementTeleportDistance
+	}
+}
+
+// getPvPSettings returns the combat settings of the PvP configuration,
+// using the default settings for multipliers that were not configured.
+func getPvPSettings(config resources.PvPConfig) combat.Settings {
+	var settings = combat.DefaultSettings()
+	settings.AttackCooldown = config.AttackCooldown
+	if config.KnockbackHorizontal > 0 {
+		settings.KnockbackHorizontal = config.KnockbackHorizontal
+	}
+	if config.KnockbackVertical > 0 {
+		settings.KnockbackVertical = config.KnockbackVertical
+	}
+	if config.CriticalMultiplier > 0 {
+		settings.CriticalMultiplier = config.CriticalMultiplier
+	}
+	return settings
+}
+
+// getWorldFormat returns the format chunks of levels are stored in.
+func (server *Server) getWorldFormat() string {
+	if server.Config.WorldFormat == "" {
+		return levels.FormatAnvil
+	}
+	return server.Config.WorldFormat
+}
+
+// getSpawn returns the spawn point of the level the session is in.
+func (server *Server) getSpawn(session *net.MinecraftSession) r3.Vector {
+	var level = server.LevelManager.GetDefaultLevel()
+	if session.GetPlayer().GetDimension() != nil {
+		level = session.GetPlayer().GetDimension().GetLevel()
+	}
+	return server.LevelStorage.GetSpawn(level.GetName())
+}
+
+// applyInventoryActions validates and applies the actions of a normal inventory transaction of the session,
+// which may only move items between the inventories and the crafting grid of the player,
+// take items from the creative inventory outside of survival and drop items into the world.
+// The old item of every container action must be the item the server holds in the slot,
+// and the actions together may neither create nor destroy items,
+// so that only stacks taken from the slots of the player get dropped.
+// Returns false if any of the actions was not valid, in which case none are applied.
+func (server *Server) applyInventoryActions(session *net.MinecraftSession, actions []io.InventoryActionIO) bool {
+	var player = session.GetPlayer()
+	var grid = player.GetCraftingGrid()
+	var balance inventory.Balance
+	var drops []*items.Stack
+	for _, action := range actions {
+		switch action.Source {
+		case io.ContainerSource:
+			var inv, ok = player.GetInventoryById(action.WindowId)
+			if !ok || int(action.InventorySlot) >= inv.GetSize() {
+				return false
+			}
+			var current, _ = inv.GetItem(int(action.InventorySlot))
+			if !inventory.EqualStacks(current, action.OldItem) {
+				return false
+			}
+		case io.TodoSource:
+			if action.WindowId != io.CraftingAddIngredientWindow && action.WindowId != io.CraftingRemoveIngredientWindow {
+				return false
+			}
+			if int(action.InventorySlot) >= grid.GetSize() {
+				return false
+			}
+			var current, _ = grid.GetItem(int(action.InventorySlot))
+			if !inventory.EqualStacks(current, action.OldItem) {
+				return false
+			}
+		case io.CreativeSource:
+			if session.IsSurvival() {
+				return false
+			}
+		case io.WorldSource:
+			if action.SourceFlags != io.DropFlag || !inventory.IsAir(action.OldItem) || inventory.IsAir(action.NewItem) {
+				return false
+			}
+			drops = append(drops, action.NewItem)
+		default:
+			return false
+		}
+		balance.Change(action.OldItem, action.NewItem)
+	}
+	if !balance.IsZero() {
+		return false
+	}
+	for _, action := range actions {
+		switch action.Source {
+		case io.ContainerSource:
+			player.SetInventorySlot(action.WindowId, int(action.InventorySlot), action.NewItem)
+		case io.TodoSource:
+			if inventory.IsAir(action.NewItem) {
+				grid.ClearSlot(int(action.InventorySlot))
+			} else {
+				grid.SetItem(action.NewItem, int(action.InventorySlot))
+			}
+		}
+	}
+	for _, drop := range drops {
+		server.dropItem(session, drop, false)
+	}
+	return true
+}
+
+// dropItem drops the stack dropped by the session into the world.
+// If dropping the stack was cancelled, the stack is returned to the inventory of the player.
+func (server *Server) dropItem(session *net.MinecraftSession, stack *items.Stack, random bool) {
+	if server.DropManager.Drop(session, stack, random) {
+		return
+	}
+	session.GetPlayer().GetInventory().AddItemReturningLeftovers(stack)
+	session.SendInventory()
+}
+
+// dropBlockItems spawns the drops of a block broken by the session as items at the block,
+// which only the session can pick up during the owner window.
+func (server *Server) dropBlockItems(session *net.MinecraftSession, position blocks.Position, stacks []*items.Stack) {
+	var dimension = session.GetPlayer().GetDimension()
+	if dimension == nil {
+		return
+	}
+	var center = r3.Vector{X: float64(position.X) + 0.5, Y: float64(position.Y) + 0.25, Z: float64(position.Z) + 0.5}
+	for _, stack := range stacks {
+		server.DropManager.SpawnStack(dimension, stack, center, session.GetName())
+	}
+}
+
+// updatePermissions applies the permission group and permissions
+// of the player with the given name again if the player is online.
+func (server *Server) updatePermissions(player string) {
+	for name, session := range server.SessionManager.GetSessions() {
+		if strings.EqualFold(name, player) {
+			server.applyPermissions(session)
+		}
+	}
+}
+
+// applyPermissions sets the permission group and permissions
+// of a session to those stored in the permission manager.
+func (server *Server) applyPermissions(session *net.MinecraftSession) {
+	session.SetPermissionGroup(server.PermissionManager.GetPlayerGroup(session.GetName()))
+	session.ClearPermissions()
+	for _, permission := range server.PermissionManager.GetPlayerPermissions(session.GetName()) {
+		session.AddPermission(permission)
+	}
+}
+
+// getShortcodes returns the configured chat shortcodes in lower case,
+// leaving out shortcodes of which the replacement can not be rendered by the Bedrock font.
+func getShortcodes(configured map[string]string) map[string]string {
+	var shortcodes = make(map[string]string, len(configured))
+	for name, replacement := range configured {
+		if !text.IsFontSafe(replacement) {
+			text.DefaultLogger.Warning("Chat shortcode :" + name + ": can not be rendered by the Bedrock font and is ignored.")
+			continue
+		}
+		shortcodes[strings.ToLower(name)] = replacement
+	}
+	return shortcodes
+}
+
+// rejectProtocol disconnects the session logging in with an unsupported protocol.
+// The client gets an outdated client or outdated server status, followed by a disconnect screen
+// translated into its language, after an unsupported protocol event got called.
+func (server *Server) rejectProtocol(session *net.MinecraftSession, login *bedrock.LoginPacket) {
+	var pool = server.NetworkAdapter.GetProtocolPool()
+	var oldest, _ = pool.GetProtocol(pool.GetOldest())
+	var newest, _ = pool.GetProtocol(pool.GetNewest())
+	var event = &net.UnsupportedProtocolEvent{
+		Session:        session,
+		Username:       login.Username,
+		Protocol:       login.Protocol,
+		GameVersion:    login.ClientData.GameVersion,
+		OutdatedClient: login.Protocol < oldest.GetProtocolNumber(),
+	}
+	var key, status = "disconnect.outdatedServer", int32(data.StatusLoginFailedServer)
+	if event.OutdatedClient {
+		key, status = "disconnect.outdatedClient", int32(data.StatusLoginFailedClient)
+	}
+	event.Message = lang.DefaultTranslator.Translate(login.Language, key, event.GameVersion, oldest.GetGameVersion(), newest.GetGameVersion())
+	server.EventManager.Call(event)
+
+	text.DefaultLogger.Debug(login.Username, "has tried to join with unsupported protocol", login.Protocol, "("+event.GameVersion+")")
+	session.SendPlayStatus(status)
+	session.Close(event.Message, false)
+}
+
+// checkAccess checks if the player with the given name may join the server.
+// Banned players may never join, and only whitelisted players and operators
+// may join if the whitelist is enabled. Players of which the data could not be loaded may not join,
+// as their ban could not be checked. The reason the player may not join is returned.
+func (server *Server) checkAccess(name string) (string, bool) {
+	var playerData, err = server.PlayerStorage.Load(name)
+	if err != nil {
+		text.DefaultLogger.LogError(err)
+		return "Your player data could not be loaded, please try again later.", false
+	}
+	if playerData.IsBanned() {
+		if playerData.BanReason == "" {
+			return "You are banned from this server.", false
+		}
+		return "You are banned from this server: " + playerData.BanReason, false
+	}
+	if server.Config.Whitelist && !playerData.Whitelisted {
+		if group := server.PermissionManager.GetPlayerGroup(name); group == nil || group.GetLevel() < permissions.LevelOperator {
+			return "You are not whitelisted on this server.", false
+		}
+	}
+	return "", true
+}
+
+// getWhitelistStatus returns the whitelist status reported by query.
+func (server *Server) getWhitelistStatus() string {
+	if server.Config.Whitelist {
+		return "on"
+	}
+	return "off"
+}
+
+// serverMetrics holds the metrics of the server updated on the tick.
+type serverMetrics struct {
+	tickDuration    *metrics.Histogram
+	onlinePlayers   *metrics.Gauge
+	loadedChunks    *metrics.Gauge
+	receivedRate    *metrics.Gauge
+	sentRate        *metrics.Gauge
+	staleSessions   *metrics.Counter
+	orphanedEntries *metrics.Counter
+	lastReceived    uint64
+	lastSent        uint64
+	lastRateUpdate  time.Time
+}
+
+// registerMetrics registers the default metrics of the server,
+// which are exported at the metrics address if one has been configured.
+func (server *Server) registerMetrics() {
+	var m = &server.serverMetrics
+	var adapter = server.NetworkAdapter
+	m.tickDuration, _ = server.Metrics.NewHistogram("gomine_tick_duration_seconds", "Duration of server ticks.", []float64{0.001, 0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1})
+	server.Metrics.RegisterGaugeFunc("gomine_tps", "Ticks per second during the last second.", server.TickMeter.GetTPS)
+	m.onlinePlayers, _ = server.Metrics.NewGauge("gomine_online_players", "Number of players online.")
+	m.loadedChunks, _ = server.Metrics.NewGauge("gomine_loaded_chunks", "Number of chunks loaded by players.")
+	server.Metrics.RegisterCounterFunc("gomine_packets_received_total", "Number of packets received from players.", func() float64 {
+		return float64(adapter.GetPacketsReceived())
+	})
+	server.Metrics.RegisterCounterFunc("gomine_packets_sent_total", "Number of packets sent to players.", func() float64 {
+		return float64(adapter.GetPacketsSent())
+	})
+	m.receivedRate, _ = server.Metrics.NewGauge("gomine_packets_received_per_second", "Packets received from players per second.")
+	m.sentRate, _ = server.Metrics.NewGauge("gomine_packets_sent_per_second", "Packets sent to players per second.")
+	server.Metrics.RegisterGaugeFunc("gomine_level_writes_pending", "Number of chunk and level data writes waiting to be written to disk.", func() float64 {
+		return float64(server.LevelStorage.GetWriter().GetPending())
+	})
+	server.Metrics.RegisterGaugeFunc("gomine_cached_chunks", "Number of decoded chunks held by the chunk cache.", func() float64 {
+		return float64(server.LevelStorage.ChunkCache.GetLength())
+	})
+	m.staleSessions, _ = server.Metrics.NewCounter("gomine_stale_sessions_removed_total", "Number of sessions removed by sweeps after closing without leaving the server.")
+	m.orphanedEntries, _ = server.Metrics.NewCounter("gomine_orphaned_session_entries_removed_total", "Number of session lookup entries removed by sweeps that did not refer to an online session.")
+	server.Metrics.RegisterCounterFunc("gomine_arena_packets_created_total", "Number of transient packets allocated by the per-tick packet arenas.", func() float64 {
+		return float64(server.packetArenas.getStats().Created)
+	})
+	server.Metrics.RegisterCounterFunc("gomine_arena_packets_reused_total", "Number of transient packets reused by the per-tick packet arenas instead of being allocated.", func() float64 {
+		return float64(server.packetArenas.getStats().Reused)
+	})
+	server.Metrics.RegisterRuntimeMetrics()
+}
+
+// updateMetrics updates the player, chunk and packet rate metrics of the server.
+func (server *Server) updateMetrics(now time.Time) {
+	var m = &server.serverMetrics
+	var sessions = server.SessionManager.GetSessions()
+	var loaded = make(map[*chunks.Chunk]bool)
+	for _, session := range sessions {
+		for _, chunk := range session.GetChunkLoader().GetLoadedChunks() {
+			loaded[chunk] = true
+		}
+	}
+	m.onlinePlayers.Set(float64(len(sessions)))
+	m.loadedChunks.Set(float64(len(loaded)))
+
+	var received, sent = server.NetworkAdapter.GetPacketsReceived(), server.NetworkAdapter.GetPacketsSent()
+	if !m.lastRateUpdate.IsZero() {
+		var seconds = now.Sub(m.lastRateUpdate).Seconds()
+		m.receivedRate.Set(float64(received-m.lastReceived) / seconds)
+		m.sentRate.Set(float64(sent-m.lastSent) / seconds)
+	}
+	m.lastReceived, m.lastSent, m.lastRateUpdate = received, sent, now
+}
+
+// registerLeaderboards registers the default leaderboards of the server,
+// ranking players by kills, balance and playtime.
+func (server *Server) registerLeaderboards() {
+	server.LeaderboardManager.Register(leaderboards.NewStatProvider("kills", server.PlayerStorage))
+	server.LeaderboardManager.Register(leaderboards.NewFunctionProvider("balance", func() (map[string]float64, error) {
+		var values = make(map[string]float64)
+		var economy, ok = server.GetEconomy()
+		if !ok {
+			return values, nil
+		}
+		var all, err = server.PlayerStorage.LoadAll()
+		if err != nil {
+			return nil, err
+		}
+		for _, data := range all {
+			if values[data.Name], err = economy.GetBalance(data.Name); err != nil {
+				return nil, err
+			}
+		}
+		return values, nil
+	}, func(value float64) string {
+		if economy, ok := server.GetEconomy(); ok {
+			return economy.Format(value)
+		}
+		return fmt.Sprint(value)
+	}))
+	server.LeaderboardManager.Register(leaderboards.NewFunctionProvider("playtime", func() (map[string]float64, error) {
+		var all, err = server.PlayerStorage.LoadAll()
+		if err != nil {
+			return nil, err
+		}
+		var values = make(map[string]float64)
+		for _, data := range all {
+			values[data.Name] = float64(data.Playtime)
+		}
+		for _, session := range server.SessionManager.GetSessions() {
+			if session.GetPlayer().GetData() != nil {
+				values[session.GetName()] = server.RewardManager.GetPlaytime(session).Seconds()
+			}
+		}
+		return values, nil
+	}, leaderboards.FormatDuration))
+}
+
 // GetMinecraftVersion returns the latest Minecraft game version.
 // It is prefixed with a 'v', for example: "v1.2.10.1"
 func (server *Server) GetMinecraftVersion() string {
@@ -208,8 +1240,9 @@ func (server *Server) GetMaximumPlayers() uint {
 }
 
 // Returns the Message Of The Day of the server.
+// The MOTD rotates through the configured MOTD rotation, if any.
 func (server *Server) GetMotd() string {
-	return server.Config.ServerMotd
+	return server.MotdProvider.GetMotd(time.Now(), server.SessionManager.GetSessionCount(), int(server.Config.MaximumPlayers))
 }
 
 // Returns the max view distance allowed by the server
@@ -217,15 +1250,18 @@ func (server *Server) GetMaxViewDistance() int32 {
 	return server.Config.MaxViewDistance
 }
 
-// Returns the max view distance allowed by the server,
-// if it's 0 it returns the given distance which is the
-// distance given by a joining player
+// Returns the view distance allowed by the server for the given distance
+// requested by a player, which is clamped to the max view distance.
+// If the max view distance is 0, the requested distance is always allowed.
+// The allowed view distance is at least 1.
 func (server *Server) GetAllowedViewDistance(distance int32) int32 {
-	var maxViewDistance int32
-	if maxViewDistance = server.GetMaxViewDistance(); maxViewDistance <= 0 {
-		return distance
+	if maxViewDistance := server.GetMaxViewDistance(); maxViewDistance > 0 && distance > maxViewDistance {
+		distance = maxViewDistance
 	}
-	return maxViewDistance
+	if distance < 1 {
+		distance = 1
+	}
+	return distance
 }
 
 // GetCurrentTick returns the current tick the server is on.
@@ -264,8 +1300,9 @@ func (server *Server) GetServerToken() []byte {
 	return server.token
 }
 
-// GenerateQueryResult returns the query data of the server in a byte array.
-func (server *Server) GenerateQueryResult() query.Result {
+// GenerateQueryStatus returns the query status of the server,
+// using the MOTD and player counts of the given pong.
+func (server *Server) GenerateQueryStatus(pong *motd.Pong) gs4.Status {
 	var plugs []string
 	for _, plug := range server.PluginManager.GetPlugins() {
 		plugs = append(plugs, plug.GetName()+" v"+plug.GetVersion())
@@ -276,23 +1313,46 @@ func (server *Server) GenerateQueryResult() query.Result {
 		ps = append(ps, name)
 	}
 
-	var result = query.Result{
-		MOTD:           server.GetMotd(),
-		ListPlugins:    server.Config.AllowPluginQuery,
-		PluginNames:    plugs,
-		PlayerNames:    ps,
-		GameMode:       "SMP",
+	return gs4.Status{
+		Motd:           pong.Motd,
+		GameType:       "SMP",
+		GameId:         "MINECRAFTPE",
 		Version:        server.GetMinecraftVersion(),
 		ServerEngine:   server.GetEngineName(),
 		WorldName:      server.LevelManager.GetDefaultLevel().GetName(),
-		OnlinePlayers:  int(server.SessionManager.GetSessionCount()),
-		MaximumPlayers: int(server.Config.MaximumPlayers),
-		Whitelist:      "off",
-		Port:           server.Config.ServerPort,
+		ListPlugins:    server.Config.AllowPluginQuery,
+		PluginNames:    plugs,
+		PlayerNames:    ps,
+		OnlinePlayers:  pong.OnlinePlayers,
+		MaximumPlayers: pong.MaximumPlayers,
+		Whitelist:      server.getWhitelistStatus(),
 		Address:        server.Config.ServerIp,
+		Port:           server.Config.ServerPort,
 	}
+}
+
+// GenerateQueryResult returns the query data of the server in a byte array.
+func (server *Server) GenerateQueryResult() query.Result {
+	return toQueryResult(server.GenerateQueryStatus(server.GeneratePong()))
+}
 
-	return result
+// toQueryResult converts a query status to the result answered on the server port.
+func toQueryResult(status gs4.Status) query.Result {
+	return query.Result{
+		MOTD:           status.Motd,
+		ListPlugins:    status.ListPlugins,
+		PluginNames:    status.PluginNames,
+		PlayerNames:    status.PlayerNames,
+		GameMode:       status.GameType,
+		Version:        status.Version,
+		ServerEngine:   status.ServerEngine,
+		WorldName:      status.WorldName,
+		OnlinePlayers:  status.OnlinePlayers,
+		MaximumPlayers: status.MaximumPlayers,
+		Whitelist:      status.Whitelist,
+		Port:           status.Port,
+		Address:        status.Address,
+	}
 }
 
 // HandleRaw handles a raw packet, for instance a query packet.
@@ -314,28 +1374,150 @@ func (server *Server) HandleRaw(packet []byte, addr *net2.UDPAddr) {
 // HandleDisconnect handles a disconnection from a session.
 func (server *Server) HandleDisconnect(s *server.Session) {
 	text.DefaultLogger.Debug(s, "disconnected!")
+	server.NetworkAdapter.MarkClosed(s)
 	session, ok := server.SessionManager.GetSessionByRakNetSession(s)
 	server.SessionManager.RemoveMinecraftSession(session)
 
 	if !ok {
 		return
 	}
+	server.removeSession(session)
+}
+
+// removeSession removes the session that left the server from all managers,
+// after it was removed from the session manager.
+func (server *Server) removeSession(session *net.MinecraftSession) {
+	server.MinigameManager.Leave(session.GetName())
+	server.PartyManager.Leave(session.GetName())
+	text.DefaultLogger.LogError(server.FriendManager.Unload(session))
+	server.TradeManager.HandleDisconnect(session.GetName())
+	server.MovementProcessor.Remove(session.GetName())
+	server.ChatManager.Remove(session.GetName())
+	text.DefaultLogger.LogError(server.RewardManager.Leave(session))
+	server.CosmeticManager.Leave(session)
+	server.MobManager.Leave(session)
+	server.BuildingManager.Leave(session)
+	server.CombatManager.Leave(session)
+	server.DropManager.Leave(session)
 
 	if session.GetPlayer().Dimension != nil {
-		for _, online := range server.SessionManager.GetSessions() {
-			online.SendPlayerList(data.ListTypeRemove, map[string]protocol.PlayerListEntry{session.GetPlayer().GetName(): session.GetPlayer()})
-		}
+		server.PlayerListManager.Quit(session)
 
 		session.GetPlayer().Close()
 		session.Connected = false
 
-		server.BroadcastMessage(text.Yellow+session.GetDisplayName(), "has left the server")
+		server.AnnouncementManager.Quit(session)
+	}
+}
+
+// getSweepInterval returns the interval in ticks at which stale sessions are swept.
+func (server *Server) getSweepInterval() int64 {
+	var seconds = server.Config.SessionSweepInterval
+	if seconds <= 0 {
+		seconds = defaultSweepInterval
+	}
+	return int64(seconds * server.GetTickRate())
+}
+
+// sweepSessions removes all sessions of which the RakNet session closed
+// without the session being removed on disconnect, and logs the stale sessions and orphaned entries found.
+func (server *Server) sweepSessions() {
+	var result = server.SessionManager.Sweep(server.NetworkAdapter.IsClosed)
+	for _, session := range result.Stale {
+		text.DefaultLogger.Notice("Removing stale session of " + session.GetName() + ", which closed without leaving the server.")
+		server.removeSession(session)
+	}
+	var m = &server.serverMetrics
+	m.staleSessions.Add(uint64(len(result.Stale)))
+	m.orphanedEntries.Add(uint64(result.Orphaned))
+	var tracked = server.NetworkAdapter.ForgetClosed(closedSessionRetention)
+	if len(result.Stale) != 0 || result.Orphaned != 0 {
+		text.DefaultLogger.Notice("Session sweep removed", len(result.Stale), "stale sessions and", result.Orphaned, "orphaned entries,", tracked, "closed sessions tracked.")
+	}
+}
+
+// getSubMotd returns the sub MOTD shown in the server list,
+// which is the engine name if no sub MOTD has been configured.
+func (server *Server) getSubMotd() string {
+	if server.MotdProvider.SubMotd == "" {
+		return server.GetEngineName()
 	}
+	return server.MotdProvider.GetSubMotd(server.SessionManager.GetSessionCount(), int(server.Config.MaximumPlayers))
+}
+
+// GeneratePong generates the pong shown in the server list,
+// after which the pong function is called to customize it.
+func (server *Server) GeneratePong() *motd.Pong {
+	var online, max = server.MotdProvider.GetPlayerCounts(server.SessionManager.GetSessionCount(), int(server.Config.MaximumPlayers))
+	var pong = &motd.Pong{
+		Motd:           server.GetMotd(),
+		Protocol:       info.LatestProtocol,
+		Version:        server.GetMinecraftNetworkVersion(),
+		OnlinePlayers:  online,
+		MaximumPlayers: max,
+		ServerId:       int64(server.NetworkAdapter.GetRakLibManager().ServerId),
+		SubMotd:        server.getSubMotd(),
+		GameMode:       "Creative",
+	}
+	if server.PongFunction != nil {
+		server.PongFunction(pong)
+	}
+	return pong
 }
 
 // GeneratePongData generates the GoRakLib pong data for the UnconnectedPong RakNet packet.
 func (server *Server) GeneratePongData() string {
-	return fmt.Sprint("MCPE;", server.GetMotd(), ";", info.LatestProtocol, ";", server.GetMinecraftNetworkVersion(), ";", server.SessionManager.GetSessionCount(), ";", server.Config.MaximumPlayers, ";", server.NetworkAdapter.GetRakLibManager().ServerId, ";", server.GetEngineName(), ";Creative;")
+	return server.GeneratePong().String()
+}
+
+// UpdateStatus updates the pong data and query status of the server.
+// The status is updated every second, but UpdateStatus may be called
+// to apply changes of the pong function immediately.
+func (server *Server) UpdateStatus() {
+	var pong = server.GeneratePong()
+	var status = server.GenerateQueryStatus(pong)
+	server.NetworkAdapter.GetRakLibManager().PongData = pong.String()
+	server.QueryServer.SetStatus(status)
+	server.QueryManager.SetQueryResult(toQueryResult(status))
+	if server.NetworkBridge.IsConnected() {
+		server.NetworkBridge.PublishStatus(server.SessionManager.GetSessionCount(), int(server.Config.MaximumPlayers), server.Config.NetworkPublicAddress, server.Config.ServerPort)
+	}
+}
+
+// refreshDisplayName sends the display name of the player of the session to all sessions,
+// by updating its player list entry and its name tag for the session itself and its viewers.
+func (server *Server) refreshDisplayName(session *net.MinecraftSession) {
+	server.PlayerListManager.Update(session)
+	var player = session.GetPlayer()
+	var entityData = player.GetEntityData()
+	session.SendSetEntityData(player.GetRuntimeId(), entityData)
+	for _, viewer := range player.GetViewers() {
+		if viewer, ok := viewer.(*net.MinecraftSession); ok && viewer != session {
+			viewer.SendSetEntityData(player.GetRuntimeId(), entityData)
+		}
+	}
+}
+
+// forwardChat forwards chat messages sent in the global channel to the other servers in the network.
+func (server *Server) forwardChat(channel chat.Channel, sender *net.MinecraftSession, formatted string) {
+	if channel.GetName() == "global" && server.NetworkBridge.IsConnected() {
+		text.DefaultLogger.LogError(server.NetworkBridge.PublishChat(formatted))
+	}
+}
+
+// handleNetworkChat sends a chat message received from another server in the network to all players.
+func (server *Server) handleNetworkChat(serverName string, message string) {
+	var formatted = text.Gray + "[" + serverName + "] " + text.Reset + message
+	for _, session := range server.SessionManager.GetSessions() {
+		session.SendMessage(formatted)
+	}
+	text.DefaultLogger.LogChat(formatted)
+}
+
+// handleNetworkCommand executes a command received from another server in the network as the server.
+func (server *Server) handleNetworkCommand(serverName string, command string) {
+	text.DefaultLogger.Info("Executing command from " + serverName + ": " + command)
+	server.DispatchCommand(server, command)
 }
 
 // Tick ticks the entire server. (Levels, scheduler, GoRakLib server etc.)
@@ -344,20 +1526,75 @@ func (server *Server) Tick() {
 	if !server.isRunning {
 		return
 	}
-	if server.tick%20 == 0 {
-		server.QueryManager.SetQueryResult(server.GenerateQueryResult())
-		server.NetworkAdapter.GetRakLibManager().PongData = server.GeneratePongData()
+	var start = time.Now()
+	if server.tick%int64(server.GetTickRate()) == 0 {
+		server.UpdateStatus()
+		server.updateMetrics(start)
+		server.TickMeter.RecordSample(start)
+		server.checkLimits(start)
+	}
+	if server.tick%server.getSweepInterval() == 0 {
+		server.sweepSessions()
 	}
 
 	for _, session := range server.SessionManager.GetSessions() {
 		session.Tick()
 	}
 
-	for _, level := range server.LevelManager.GetLevels() {
-		level.Tick()
+	server.tickLevels()
+
+	server.TradeManager.Tick()
+	server.MarketManager.Tick()
+	server.TeleportManager.Tick()
+	server.DimensionManager.Tick()
+	if server.Config.WebMap && server.MapRenderer.Interval > 0 && server.tick%server.MapRenderer.Interval == 0 {
+		server.renderMap()
 	}
+	server.RewardManager.Tick()
+	server.LeaderboardManager.Tick()
+	text.DefaultLogger.LogError(server.LevelStorage.Tick())
+	server.CosmeticManager.Tick()
+	server.CombatManager.Tick()
+	server.MobManager.Tick()
+	server.ScoreboardManager.Tick()
+	server.DropManager.Tick()
+	server.NetworkBridge.Tick()
+	server.Scheduler.Tick()
+	server.PlayerListManager.Flush()
+
+	for _, session := range server.SessionManager.GetSessions() {
+		if err := session.Flush(); err != nil {
+			server.NetworkAdapter.ErrorFunction(session, err)
+		}
+	}
+	server.packetArenas.reset()
 
 	server.tick++
+	var duration = time.Since(start)
+	server.TickMeter.Tick(start, duration)
+	server.serverMetrics.tickDuration.Observe(duration.Seconds())
+}
+
+// DispatchCommand executes the command text as the given sender, as if the sender typed it.
+// A leading slash is optional. Returns false if the command could not be found or did not succeed.
+func (server *Server) DispatchCommand(sender commands.Sender, commandText string) bool {
+	var args = strings.Split(commandText, " ")
+	var commandName = strings.TrimLeft(args[0], "/")
+	var i = 1
+	for !server.CommandManager.IsCommandRegistered(commandName) {
+		if i == len(args) {
+			break
+		}
+		commandName += " " + args[i]
+		i++
+	}
+	if !server.CommandManager.IsCommandRegistered(commandName) {
+		commands.Tell(sender, "commands.generic.notFound")
+		return false
+	}
+	args = args[i:]
+	var command, _ = server.CommandManager.GetCommand(commandName)
+	return command.Execute(sender, args)
 }
 
 func (server *Server) attemptReadCommand(commandText string) {
@@ -0,0 +1,136 @@
+package skins
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"sync"
+
+	"github.com/irmine/gomine/net/info"
+)
+
+// DefaultCacheSize is the default amount of converted skin textures kept in the cache of a service.
+const DefaultCacheSize = 128
+
+// Capabilities are the skins a client of a protocol can render.
+type Capabilities struct {
+	// MaxFormat is the largest skin format the client renders.
+	MaxFormat Format
+	// Persona specifies if the client renders persona skins.
+	Persona bool
+}
+
+// GetCapabilities returns the skin capabilities of clients with the protocol number.
+func GetCapabilities(protocolNumber int32) Capabilities {
+	if protocolNumber >= info.PersonaSkinProtocol {
+		return Capabilities{MaxFormat: Formats[len(Formats)-1], Persona: true}
+	}
+	return Capabilities{MaxFormat: Format128x128}
+}
+
+// Service converts skins to skins viewers with an older protocol can render,
+// caching converted textures so that skins are converted once for all viewers.
+type Service struct {
+	// CapabilitiesFunction returns the skin capabilities of viewers with the protocol number.
+	CapabilitiesFunction func(protocolNumber int32) Capabilities
+	// Fallback is the skin sent in place of persona skins to viewers without persona support.
+	// Persona skins are converted to a classic skin if the fallback has no data.
+	Fallback Skin
+
+	mutex   sync.Mutex
+	size    int
+	order   *list.List
+	entries map[textureKey]*list.Element
+}
+
+// textureKey is the key of a converted texture in the cache.
+type textureKey struct {
+	sum    [sha256.Size]byte
+	format Format
+}
+
+// cacheEntry is a converted texture held by the cache of a service.
+type cacheEntry struct {
+	key  textureKey
+	data []byte
+}
+
+// NewService returns a new skin service caching at most the given amount of converted textures.
+// A size of 0 or lower disables caching.
+func NewService(cacheSize int) *Service {
+	return &Service{CapabilitiesFunction: GetCapabilities, size: cacheSize, order: list.New(), entries: make(map[textureKey]*list.Element)}
+}
+
+// Normalize converts legacy 64x32 skins to 64x64, so that all viewers receive the same format.
+// Normalize should be called for every skin received from a client, after validating it.
+func (service *Service) Normalize(skin Skin) Skin {
+	if len(skin.Data) == Format64x32.GetSize() {
+		skin.Data = Convert(skin.Data, Format64x32, Format64x64)
+	}
+	return skin
+}
+
+// Convert returns the skin as it should be sent to viewers with the protocol number.
+// Skins larger than the viewer renders get scaled down, and persona skins are replaced
+// with the fallback skin for viewers without persona support. The skin is returned as is
+// if the viewer can render it.
+func (service *Service) Convert(skin Skin, protocolNumber int32) Skin {
+	var capabilities = service.CapabilitiesFunction(protocolNumber)
+	var format, ok = GetFormat(skin.Data)
+	if !ok {
+		return skin
+	}
+	var persona = skin.IsPersona() && !capabilities.Persona
+	if !persona && format.Fits(capabilities.MaxFormat) {
+		return skin
+	}
+	if persona {
+		if len(service.Fallback.Data) != 0 {
+			return service.Fallback
+		}
+		// Persona textures do not follow the classic layout,
+		// but scaling them keeps their colours recognisable on the classic geometry.
+		skin.GeometryName, skin.GeometryData = DefaultGeometry, ""
+		format = Format64x64
+	}
+	for !format.Fits(capabilities.MaxFormat) {
+		format = Format{format.Width / 2, format.Height / 2}
+	}
+	skin.Data = service.convert(skin.Data, format)
+	return skin
+}
+
+// GetLength returns the amount of converted textures in the cache.
+func (service *Service) GetLength() int {
+	service.mutex.Lock()
+	defer service.mutex.Unlock()
+	return service.order.Len()
+}
+
+// convert converts the texture data to the format, using the cached texture if it was converted before.
+func (service *Service) convert(data []byte, format Format) []byte {
+	var key = textureKey{sha256.Sum256(data), format}
+	service.mutex.Lock()
+	if element, ok := service.entries[key]; ok {
+		service.order.MoveToFront(element)
+		service.mutex.Unlock()
+		return element.Value.(*cacheEntry).data
+	}
+	service.mutex.Unlock()
+
+	var from, _ = GetFormat(data)
+	var converted = Convert(data, from, format)
+	if service.size <= 0 {
+		return converted
+	}
+	service.mutex.Lock()
+	defer service.mutex.Unlock()
+	if _, ok := service.entries[key]; !ok {
+		service.entries[key] = service.order.PushFront(&cacheEntry{key, converted})
+	}
+	for service.order.Len() > service.size {
+		var oldest = service.order.Back()
+		service.order.Remove(oldest)
+		delete(service.entries, oldest.Value.(*cacheEntry).key)
+	}
+	return converted
+}
@@ -0,0 +1,142 @@
+package skins
+
+import (
+	"errors"
+	"strings"
+)
+
+// Skin is the skin of a player, as sent in the player list and skin packets.
+type Skin struct {
+	Id           string
+	Data         []byte
+	CapeData     []byte
+	GeometryName string
+	GeometryData string
+}
+
+// Format is the size of a skin texture in pixels. Textures are RGBA, using four bytes per pixel.
+type Format struct {
+	Width  int
+	Height int
+}
+
+var (
+	// Format64x32 is the legacy skin format, without separate left arm and leg or overlays.
+	Format64x32 = Format{64, 32}
+	// Format64x64 is the classic skin format.
+	Format64x64 = Format{64, 64}
+	// Format128x128 is the high resolution skin format.
+	Format128x128 = Format{128, 128}
+)
+
+// Formats holds all skin formats accepted from clients. Persona skins may use the larger formats.
+var Formats = []Format{Format64x32, Format64x64, {128, 64}, Format128x128, {256, 128}, {256, 256}, {512, 256}, {512, 512}}
+
+// CapeFormat is the format of cape textures.
+var CapeFormat = Format64x32
+
+// DefaultGeometry is the geometry of classic skins, which converted persona skins use.
+const DefaultGeometry = "geometry.humanoid.custom"
+
+var (
+	// InvalidSkinSize gets returned when the skin data does not have the size of a skin format.
+	InvalidSkinSize = errors.New("skin data does not have the size of a skin format")
+	// InvalidCapeSize gets returned when the cape data is neither empty nor 64x32.
+	InvalidCapeSize = errors.New("cape data does not have the size of a cape")
+)
+
+// GetSize returns the size in bytes of a texture of the format.
+func (format Format) GetSize() int {
+	return format.Width * format.Height * 4
+}
+
+// Fits checks if a texture of the format fits within the other format.
+func (format Format) Fits(other Format) bool {
+	return format.Width <= other.Width && format.Height <= other.Height
+}
+
+// GetFormat returns the skin format of the texture data, found by its size.
+// A bool is returned indicating if the size matched any format.
+func GetFormat(data []byte) (Format, bool) {
+	for _, format := range Formats {
+		if format.GetSize() == len(data) {
+			return format, true
+		}
+	}
+	return Format{}, false
+}
+
+// IsPersona checks if the skin was made in the character creator,
+// which clients before persona support can not render.
+func (skin Skin) IsPersona() bool {
+	return strings.Contains(strings.ToLower(skin.Id), "persona")
+}
+
+// Validate checks if the skin and cape data have the size of a known format.
+func (skin Skin) Validate() error {
+	if _, ok := GetFormat(skin.Data); !ok {
+		return InvalidSkinSize
+	}
+	if len(skin.CapeData) != 0 && len(skin.CapeData) != CapeFormat.GetSize() {
+		return InvalidCapeSize
+	}
+	return nil
+}
+
+// Convert converts the texture data of the format to the other format.
+// Legacy 64x32 textures get their left arm and leg mirrored from the right ones,
+// textures of another resolution get scaled, and 64x32 targets get the top half of a 64x64 texture.
+// A new slice is returned, unless the formats are equal.
+func Convert(data []byte, from, to Format) []byte {
+	if from == to {
+		return data
+	}
+	if from == Format64x32 {
+		data, from = convertLegacy(data), Format64x64
+	}
+	if to == Format64x32 {
+		return scale(data, from, Format64x64)[:Format64x32.GetSize()]
+	}
+	return scale(data, from, to)
+}
+
+// legacyAreas holds the areas copied from the right to the left arm and leg when converting
+// a legacy texture, as x, y, offset x, offset y, width and height. Areas are mirrored horizontally.
+var legacyAreas = [][6]int{
+	{4, 16, 16, 32, 4, 4}, {8, 16, 16, 32, 4, 4}, {0, 20, 24, 32, 4, 12}, {4, 20, 16, 32, 4, 12}, {8, 20, 8, 32, 4, 12}, {12, 20, 16, 32, 4, 12},
+	{44, 16, -8, 32, 4, 4}, {48, 16, -8, 32, 4, 4}, {40, 20, 0, 32, 4, 12}, {44, 20, -8, 32, 4, 12}, {48, 20, -16, 32, 4, 12}, {52, 20, -8, 32, 4, 12},
+}
+
+// convertLegacy converts a 64x32 texture to a 64x64 texture,
+// mirroring the right arm and leg to the left arm and leg.
+func convertLegacy(data []byte) []byte {
+	var converted = make([]byte, Format64x64.GetSize())
+	copy(converted, data)
+	for _, area := range legacyAreas {
+		var x, y, offsetX, offsetY, width, height = area[0], area[1], area[2], area[3], area[4], area[5]
+		for row := 0; row < height; row++ {
+			for column := 0; column < width; column++ {
+				var source = ((y+row)*64 + x + column) * 4
+				var target = ((y+offsetY+row)*64 + x + offsetX + width - 1 - column) * 4
+				copy(converted[target:target+4], data[source:source+4])
+			}
+		}
+	}
+	return converted
+}
+
+// scale scales the texture data of the format to the other format, using the nearest pixel.
+func scale(data []byte, from, to Format) []byte {
+	if from == to {
+		return data
+	}
+	var scaled = make([]byte, to.GetSize())
+	for y := 0; y < to.Height; y++ {
+		var sourceY = y * from.Height / to.Height
+		for x := 0; x < to.Width; x++ {
+			var source = (sourceY*from.Width + x*from.Width/to.Width) * 4
+			copy(scaled[(y*to.Width+x)*4:], data[source:source+4])
+		}
+	}
+	return scaled
+}
@@ -0,0 +1,67 @@
+package skins
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/irmine/gomine/net/info"
+)
+
+func TestValidate(t *testing.T) {
+	if err := (Skin{Data: make([]byte, Format64x64.GetSize())}).Validate(); err != nil {
+		t.Error("valid skin was invalid:", err)
+	}
+	if err := (Skin{Data: make([]byte, 1000)}).Validate(); err != InvalidSkinSize {
+		t.Error("expected invalid skin size, got:", err)
+	}
+	if err := (Skin{Data: make([]byte, Format64x64.GetSize()), CapeData: make([]byte, 10)}).Validate(); err != InvalidCapeSize {
+		t.Error("expected invalid cape size, got:", err)
+	}
+}
+
+func TestConvert(t *testing.T) {
+	var legacy = make([]byte, Format64x32.GetSize())
+	// The top right pixel of the front of the right leg.
+	copy(legacy[(20*64+4)*4:], []byte{1, 2, 3, 255})
+	var converted = Convert(legacy, Format64x32, Format64x64)
+	if len(converted) != Format64x64.GetSize() {
+		t.Fatal("legacy skin was not converted to 64x64:", len(converted))
+	}
+	// The front of the left leg is mirrored, so the pixel ends up top left.
+	if !bytes.Equal(converted[(52*64+23)*4:(52*64+24)*4], []byte{1, 2, 3, 255}) {
+		t.Error("right leg was not mirrored to the left leg")
+	}
+
+	var large = make([]byte, Format128x128.GetSize())
+	copy(large[(126*128+126)*4:], []byte{9, 9, 9, 255})
+	var scaled = Convert(large, Format128x128, Format64x64)
+	if len(scaled) != Format64x64.GetSize() || !bytes.Equal(scaled[(63*64+63)*4:], []byte{9, 9, 9, 255}) {
+		t.Error("128x128 skin was not scaled to 64x64")
+	}
+	if cropped := Convert(large, Format128x128, Format64x32); len(cropped) != Format64x32.GetSize() {
+		t.Error("128x128 skin was not converted to 64x32:", len(cropped))
+	}
+}
+
+func TestService(t *testing.T) {
+	var service = NewService(1)
+	var persona = Skin{Id: "abc.persona-skin", Data: make([]byte, 256*256*4), GeometryName: "geometry.persona"}
+	if skin := service.Convert(persona, info.PersonaSkinProtocol); len(skin.Data) != len(persona.Data) {
+		t.Error("persona skin was converted for a viewer with persona support")
+	}
+	var old = service.Convert(persona, info.LatestProtocol)
+	if len(old.Data) != Format64x64.GetSize() || old.GeometryName != DefaultGeometry {
+		t.Error("persona skin was not converted to a classic skin:", len(old.Data), old.GeometryName)
+	}
+	if again := service.Convert(persona, info.LatestProtocol); &again.Data[0] != &old.Data[0] || service.GetLength() != 1 {
+		t.Error("converted texture was not cached")
+	}
+
+	service.Fallback = Skin{Id: "fallback", Data: make([]byte, Format64x64.GetSize())}
+	if skin := service.Convert(persona, info.LatestProtocol); skin.Id != "fallback" {
+		t.Error("fallback skin was not used for a persona skin:", skin.Id)
+	}
+	if skin := service.Normalize(Skin{Data: make([]byte, Format64x32.GetSize())}); len(skin.Data) != Format64x64.GetSize() {
+		t.Error("legacy skin was not normalized to 64x64")
+	}
+}
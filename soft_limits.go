@@ -0,0 +1,137 @@
+package gomine
+
+import (
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/irmine/gomine/commands"
+	"github.com/irmine/gomine/ecs"
+	"github.com/irmine/gomine/lang"
+	"github.com/irmine/gomine/limits"
+	"github.com/irmine/gomine/net"
+	"github.com/irmine/gomine/net/packets/data"
+	"github.com/irmine/gomine/text"
+	"github.com/irmine/worlds/chunks"
+)
+
+// LimitAlertPermission is the permission players need to be alerted of exceeded soft limits.
+const LimitAlertPermission = "gomine.limits.alerts"
+
+// DefaultOverloadViewDistance is the view distance players are limited to while the server is overloaded.
+const DefaultOverloadViewDistance = 4
+
+// newLimitMonitor returns a new limit monitor with the soft limits of the config.
+func (server *Server) newLimitMonitor() *limits.Monitor {
+	var config = server.Config
+	var monitor = limits.NewMonitor(limits.Limits{
+		LoadedChunks: config.MaxLoadedChunks,
+		Entities:     config.MaxEntities,
+		PlayerChunks: config.MaxPlayerChunks,
+	})
+	if config.LimitAlertInterval > 0 {
+		monitor.AlertInterval = time.Duration(config.LimitAlertInterval) * time.Second
+	}
+	monitor.AlertFunction = server.alertLimit
+	if config.OverloadDegradation {
+		monitor.OverloadFunction = server.degrade
+	}
+	return monitor
+}
+
+// checkLimits checks the current usage of the server against the soft limits.
+func (server *Server) checkLimits(now time.Time) {
+	var sessions = server.SessionManager.GetSessions()
+	var usage = limits.Usage{
+		Entities:     server.EntityRegistry.Count(ecs.Filter{}),
+		PlayerChunks: make(map[string]int, len(sessions)),
+	}
+	var loaded = make(map[*chunks.Chunk]bool)
+	for _, session := range sessions {
+		var playerChunks = session.GetChunkLoader().GetLoadedChunks()
+		for _, chunk := range playerChunks {
+			loaded[chunk] = true
+		}
+		usage.PlayerChunks[session.GetName()] = len(playerChunks)
+	}
+	usage.LoadedChunks = len(loaded)
+	server.LimitMonitor.Check(usage, now)
+}
+
+// alertLimit logs a warning for the exceeded soft limit,
+// and shows it in the actionbar of all players with the alert permission.
+func (server *Server) alertLimit(violation limits.Violation) {
+	var key = "limits.alert." + violation.Kind
+	var parameters = []interface{}{violation.Value, violation.Limit}
+	if violation.Name != "" {
+		parameters = append([]interface{}{violation.Name}, parameters...)
+	}
+	text.DefaultLogger.Warning(lang.DefaultTranslator.Translate(lang.DefaultLanguage, key, parameters...))
+	for _, session := range server.SessionManager.GetSessions() {
+		if session.HasPermission(LimitAlertPermission) {
+			session.SendSetTitle(data.TitleActionBar, commands.Translate(session, key, parameters...), 0, 0, 0)
+		}
+	}
+}
+
+// degradation holds the view distances players had before they were limited by the overload degradation.
+type degradation struct {
+	mutex sync.Mutex
+	// distances is a session => view distance map, or nil if the server is not degraded.
+	distances map[*net.MinecraftSession]int32
+}
+
+// degrade limits the view distance of all players while the server is overloaded,
+// and restores the view distance of players once it recovers.
+func (server *Server) degrade(overloaded bool) {
+	var d = &server.degradation
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	if !overloaded {
+		text.DefaultLogger.Info("Server is no longer overloaded, restoring view distances.")
+		for _, session := range server.SessionManager.GetSessions() {
+			if distance, ok := d.distances[session]; ok {
+				session.SetViewDistance(distance)
+				session.SendChunkRadiusUpdated(distance)
+			}
+		}
+		d.distances = nil
+		return
+	}
+	var distance = server.getOverloadViewDistance()
+	text.DefaultLogger.Warning("Server is overloaded, limiting view distances to " + strconv.Itoa(int(distance)) + " chunks.")
+	d.distances = make(map[*net.MinecraftSession]int32)
+	for _, session := range server.SessionManager.GetSessions() {
+		if previous := session.GetViewDistance(); previous > distance {
+			d.distances[session] = previous
+			session.SetViewDistance(distance)
+			session.SendChunkRadiusUpdated(distance)
+		}
+	}
+}
+
+// limitViewDistance returns the view distance allowed for the session while the server is degraded,
+// remembering the given distance so that it can be restored once the server recovers.
+// The distance is returned as is if the server is not degraded.
+func (server *Server) limitViewDistance(session *net.MinecraftSession, distance int32) int32 {
+	var d = &server.degradation
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	if d.distances == nil {
+		return distance
+	}
+	delete(d.distances, session)
+	if limit := server.getOverloadViewDistance(); distance > limit {
+		d.distances[session] = distance
+		return limit
+	}
+	return distance
+}
+
+// getOverloadViewDistance returns the view distance players are limited to while the server is overloaded.
+func (server *Server) getOverloadViewDistance() int32 {
+	if server.Config.OverloadViewDistance > 0 {
+		return server.Config.OverloadViewDistance
+	}
+	return DefaultOverloadViewDistance
+}
@@ -0,0 +1,165 @@
+package gomine
+
+import (
+	"math"
+	"sort"
+
+	"github.com/golang/geo/r3"
+
+	"github.com/irmine/gomine/items"
+	"github.com/irmine/gomine/levels"
+	"github.com/irmine/gomine/net/packets/bedrock"
+	"github.com/irmine/gomine/net/packets/data"
+	"github.com/irmine/gomine/net/packets/types"
+	"github.com/irmine/gomine/net/protocol"
+	"github.com/irmine/gomine/permissions"
+	"github.com/irmine/worlds"
+	"github.com/irmine/worlds/blocks"
+)
+
+// StartGameBuilder assembles a start game packet from the state of the server,
+// the level the player spawns in and the player itself.
+// Fields only sent by newer protocols are only encoded
+// if the packet is built for one of those protocols.
+type StartGameBuilder struct {
+	server *Server
+	packet *bedrock.StartGamePacket
+}
+
+// NewStartGameBuilder returns a new start game builder for the given protocol number,
+// filled with the defaults of the server configuration.
+func NewStartGameBuilder(server *Server, protocolNumber int32) *StartGameBuilder {
+	var pk = bedrock.NewStartGamePacket()
+	pk.Protocol = protocolNumber
+	pk.DefaultPermissionLevel = permissions.LevelMember
+	// Sessions join in creative mode until their game mode is changed.
+	pk.PlayerGameMode = data.GameModeCreative
+	pk.LevelGameMode = int32(server.Config.DefaultGameMode)
+	pk.LevelSpawnPosition = toBlockPosition(levels.DefaultSpawn)
+	pk.CommandsEnabled = true
+	pk.AchievementsDisabled = true
+	pk.BroadcastToLan = true
+	pk.ForcedResourcePacks = server.Config.ForceResourcePacks
+	pk.PlatformBroadcastIntent = bedrock.GameBroadcastSettingPublic
+	pk.XBOXBroadcastIntent = bedrock.GameBroadcastSettingPublic
+	pk.MovementAuthority = bedrock.MovementAuthorityClient
+	pk.ItemTable = GetItemTable()
+
+	return &StartGameBuilder{server: server, packet: pk}
+}
+
+// Player sets the runtime ID, unique ID and position of the player,
+// and fills the level fields using the level of the dimension of the player.
+func (builder *StartGameBuilder) Player(player protocol.StartGameEntry) *StartGameBuilder {
+	builder.packet.EntityRuntimeId = player.GetRuntimeId()
+	builder.packet.EntityUniqueId = player.GetUniqueId()
+	builder.packet.PlayerPosition = player.GetPosition()
+	if dimension := player.GetDimension(); dimension != nil {
+		builder.Level(dimension.GetLevel())
+	}
+	return builder
+}
+
+// Level sets the name, current tick and game rules of the level,
+// and the seed, generator, spawn position, age, time and game rules of the world of the level.
+func (builder *StartGameBuilder) Level(level *worlds.Level) *StartGameBuilder {
+	var pk = builder.packet
+	pk.LevelName = builder.server.BrandingManager.GetWorldName(level.GetName())
+	pk.CurrentTick = level.GetCurrentTick()
+	for name, gameRule := range level.GetGameRules() {
+		builder.GameRule(string(name), gameRule.GetValue())
+	}
+	if world, ok := builder.server.LevelStorage.GetWorld(level.GetName()); ok {
+		pk.LevelSeed = int32(world.GetSeed())
+		pk.Generator = world.GetGenerator()
+		pk.LevelSpawnPosition = toBlockPosition(world.GetSpawn())
+		pk.CurrentTick = world.GetAge()
+		pk.Time = int32(world.GetTime())
+		for name, value := range world.GetGameRules() {
+			builder.GameRule(name, value)
+		}
+	}
+	return builder
+}
+
+// GameModes sets the game mode of the player and the default game mode of the level.
+func (builder *StartGameBuilder) GameModes(player, level int32) *StartGameBuilder {
+	builder.packet.PlayerGameMode = player
+	builder.packet.LevelGameMode = level
+	return builder
+}
+
+// Spawn sets the spawn position of the level.
+func (builder *StartGameBuilder) Spawn(position blocks.Position) *StartGameBuilder {
+	builder.packet.LevelSpawnPosition = position
+	return builder
+}
+
+// GameRule sets the game rule with the given name.
+// The value must be a bool, uint32 or float32.
+func (builder *StartGameBuilder) GameRule(name string, value interface{}) *StartGameBuilder {
+	builder.packet.GameRules[name] = types.GameRuleEntry{Name: name, Value: value}
+	return builder
+}
+
+// Experiment enables or disables the experiment with the given name.
+func (builder *StartGameBuilder) Experiment(name string, enabled bool) *StartGameBuilder {
+	var pk = builder.packet
+	for i, experiment := range pk.Experiments {
+		if experiment.Name == name {
+			pk.Experiments[i].Enabled = enabled
+			return builder
+		}
+	}
+	pk.Experiments = append(pk.Experiments, types.Experiment{Name: name, Enabled: enabled})
+	if enabled {
+		pk.ExperimentsPreviouslyToggled = true
+	}
+	return builder
+}
+
+// ItemTable sets the item table sent to the client.
+func (builder *StartGameBuilder) ItemTable(table []types.ItemEntry) *StartGameBuilder {
+	builder.packet.ItemTable = table
+	return builder
+}
+
+// MovementAuthority sets the authority of player movement,
+// which is one of the bedrock.MovementAuthority constants.
+func (builder *StartGameBuilder) MovementAuthority(authority int32) *StartGameBuilder {
+	builder.packet.MovementAuthority = authority
+	return builder
+}
+
+// RuntimeIdsTable sets the block runtime ID table sent to the client.
+func (builder *StartGameBuilder) RuntimeIdsTable(table []byte) *StartGameBuilder {
+	builder.packet.RuntimeIdsTable = table
+	return builder
+}
+
+// Build returns the assembled start game packet.
+func (builder *StartGameBuilder) Build() *bedrock.StartGamePacket {
+	return builder.packet
+}
+
+// GetItemTable returns the item table of all items with a registered network ID,
+// sorted by their runtime ID.
+func GetItemTable() []types.ItemEntry {
+	var table []types.ItemEntry
+	for key, t := range items.IdToType {
+		var id, meta = items.FromKey(key)
+		if meta != 0 {
+			continue
+		}
+		table = append(table, types.ItemEntry{Name: t.GetId(), RuntimeId: id})
+	}
+	sort.Slice(table, func(i, j int) bool {
+		return table[i].RuntimeId < table[j].RuntimeId
+	})
+	return table
+}
+
+// toBlockPosition returns the position of the block the vector is in.
+func toBlockPosition(vector r3.Vector) blocks.Position {
+	return blocks.NewPosition(int32(math.Floor(vector.X)), uint32(vector.Y), int32(math.Floor(vector.Z)))
+}
@@ -0,0 +1,99 @@
+package tags
+
+// Tags registered by default, which hold the identifiers of items and blocks of the game.
+const (
+	Logs     = "minecraft:logs"
+	Planks   = "minecraft:planks"
+	Wool     = "minecraft:wool"
+	Leaves   = "minecraft:leaves"
+	Sand     = "minecraft:sand"
+	Saplings = "minecraft:saplings"
+	Flowers  = "minecraft:flowers"
+	Stairs   = "minecraft:stairs"
+	Slabs    = "minecraft:slabs"
+
+	Pickaxes = "minecraft:pickaxes"
+	Axes     = "minecraft:axes"
+	Shovels  = "minecraft:shovels"
+	Hoes     = "minecraft:hoes"
+	Swords   = "minecraft:swords"
+
+	MineablePickaxe = "minecraft:mineable/pickaxe"
+	MineableAxe     = "minecraft:mineable/axe"
+	MineableShovel  = "minecraft:mineable/shovel"
+	MineableHoe     = "minecraft:mineable/hoe"
+)
+
+// ToolTags is a block tag => item tag map of the tools that are the correct tool
+// for the blocks of the block tag.
+var ToolTags = map[string]string{
+	MineablePickaxe: Pickaxes,
+	MineableAxe:     Axes,
+	MineableShovel:  Shovels,
+	MineableHoe:     Hoes,
+}
+
+// IsCorrectTool checks if the item is the correct tool to break the block with.
+// Any item is the correct tool for blocks that are not mineable by any tool.
+func (registry *Registry) IsCorrectTool(block string, item string) bool {
+	var mineable = false
+	for blockTag, itemTag := range ToolTags {
+		if !registry.Has(Blocks, blockTag, block) {
+			continue
+		}
+		if registry.Has(Items, itemTag, item) {
+			return true
+		}
+		mineable = true
+	}
+	return !mineable
+}
+
+// RegisterDefaults registers all default tags.
+// Tags shared by items and blocks are registered as both.
+func (registry *Registry) RegisterDefaults() {
+	for _, kind := range []Kind{Items, Blocks} {
+		registry.Register(kind, Logs, "minecraft:log", "minecraft:log2")
+		registry.Register(kind, Planks, "minecraft:planks")
+		registry.Register(kind, Wool, "minecraft:wool")
+		registry.Register(kind, Leaves, "minecraft:leaves", "minecraft:leaves2")
+		registry.Register(kind, Sand, "minecraft:sand")
+		registry.Register(kind, Saplings, "minecraft:sapling")
+		registry.Register(kind, Flowers, "minecraft:yellow_flower", "minecraft:red_flower")
+		registry.Register(kind, Stairs, "minecraft:oak_stairs", "minecraft:stone_stairs", "minecraft:brick_stairs",
+			"minecraft:stone_brick_stairs", "minecraft:nether_brick_stairs", "minecraft:sandstone_stairs", "minecraft:spruce_stairs",
+			"minecraft:birch_stairs", "minecraft:jungle_stairs", "minecraft:quartz_stairs", "minecraft:acacia_stairs",
+			"minecraft:dark_oak_stairs", "minecraft:red_sandstone_stairs", "minecraft:purpur_stairs")
+		registry.Register(kind, Slabs, "minecraft:stone_slab", "minecraft:wooden_slab", "minecraft:stone_slab2")
+	}
+
+	registry.Register(Items, Pickaxes, "minecraft:wooden_pickaxe", "minecraft:stone_pickaxe", "minecraft:iron_pickaxe", "minecraft:golden_pickaxe", "minecraft:diamond_pickaxe")
+	registry.Register(Items, Axes, "minecraft:wooden_axe", "minecraft:stone_axe", "minecraft:iron_axe", "minecraft:golden_axe", "minecraft:diamond_axe")
+	registry.Register(Items, Shovels, "minecraft:wooden_shovel", "minecraft:stone_shovel", "minecraft:iron_shovel", "minecraft:golden_shovel", "minecraft:diamond_shovel")
+	registry.Register(Items, Hoes, "minecraft:wooden_hoe", "minecraft:stone_hoe", "minecraft:iron_hoe", "minecraft:golden_hoe", "minecraft:diamond_hoe")
+	registry.Register(Items, Swords, "minecraft:wooden_sword", "minecraft:stone_sword", "minecraft:iron_sword", "minecraft:golden_sword", "minecraft:diamond_sword")
+
+	registry.Register(Blocks, MineablePickaxe, "minecraft:stone", "minecraft:cobblestone", "minecraft:mossy_cobblestone",
+		"minecraft:sandstone", "minecraft:red_sandstone", "minecraft:brick_block", "minecraft:stonebrick", "minecraft:nether_brick",
+		"minecraft:netherrack", "minecraft:obsidian", "minecraft:coal_ore", "minecraft:iron_ore", "minecraft:gold_ore",
+		"minecraft:diamond_ore", "minecraft:emerald_ore", "minecraft:lapis_ore", "minecraft:redstone_ore", "minecraft:lit_redstone_ore",
+		"minecraft:quartz_ore", "minecraft:coal_block", "minecraft:iron_block", "minecraft:gold_block", "minecraft:diamond_block",
+		"minecraft:emerald_block", "minecraft:lapis_block", "minecraft:redstone_block", "minecraft:quartz_block",
+		"minecraft:furnace", "minecraft:lit_furnace", "minecraft:stone_slab", "minecraft:stone_slab2", "minecraft:end_stone",
+		"minecraft:prismarine", "minecraft:purpur_block", "minecraft:hardened_clay", "minecraft:stained_hardened_clay",
+		"minecraft:ice", "minecraft:packed_ice", "minecraft:iron_bars", "minecraft:anvil", "minecraft:cauldron",
+		"minecraft:enchanting_table", "minecraft:ender_chest", "minecraft:hopper", "minecraft:dispenser", "minecraft:dropper",
+		"minecraft:stone_stairs", "minecraft:brick_stairs", "minecraft:stone_brick_stairs", "minecraft:nether_brick_stairs",
+		"minecraft:sandstone_stairs", "minecraft:quartz_stairs", "minecraft:red_sandstone_stairs", "minecraft:purpur_stairs",
+		"minecraft:double_stone_slab", "minecraft:double_stone_slab2", "minecraft:nether_brick_fence", "minecraft:cobblestone_wall",
+		"minecraft:brewing_stand", "minecraft:iron_door", "minecraft:iron_trapdoor")
+	registry.Register(Blocks, MineableAxe, ReferencePrefix+Logs, ReferencePrefix+Planks, "minecraft:wooden_slab", "minecraft:oak_stairs",
+		"minecraft:spruce_stairs", "minecraft:birch_stairs", "minecraft:jungle_stairs", "minecraft:acacia_stairs",
+		"minecraft:dark_oak_stairs", "minecraft:chest", "minecraft:trapped_chest", "minecraft:crafting_table",
+		"minecraft:bookshelf", "minecraft:fence", "minecraft:fence_gate", "minecraft:wooden_door", "minecraft:trapdoor",
+		"minecraft:ladder", "minecraft:noteblock", "minecraft:jukebox", "minecraft:pumpkin", "minecraft:melon_block")
+	registry.Register(Blocks, MineableShovel, "minecraft:dirt", "minecraft:grass", "minecraft:podzol", "minecraft:mycelium",
+		"minecraft:grass_path", ReferencePrefix+Sand, "minecraft:gravel", "minecraft:clay", "minecraft:farmland", "minecraft:snow",
+		"minecraft:snow_layer", "minecraft:soul_sand")
+	registry.Register(Blocks, MineableHoe, ReferencePrefix+Leaves, "minecraft:hay_block", "minecraft:sponge")
+}
@@ -0,0 +1,221 @@
+package tags
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Kind is the kind of identifiers a tag groups.
+type Kind string
+
+// Kinds of tags, which are also the names of the directories holding their data files.
+const (
+	Items  Kind = "items"
+	Blocks Kind = "blocks"
+)
+
+// ReferencePrefix is the prefix of tag values referencing another tag of the same kind,
+// such as "#minecraft:logs", of which all values are part of the tag.
+const ReferencePrefix = "#"
+
+// InvalidName gets returned when a tag gets registered with a name that is not namespaced,
+// such as "logs" rather than "minecraft:logs".
+var InvalidName = errors.New("tag names must be namespaced, such as minecraft:logs")
+
+// tagFile is the stored form of a tag in a data file.
+type tagFile struct {
+	// Replace specifies if the values replace the values of the tag registered before,
+	// rather than being added to them.
+	Replace bool     `json:"replace"`
+	Values  []string `json:"values"`
+}
+
+// Registry holds namespaced tags of items and blocks, such as minecraft:logs,
+// which group identifiers so that recipes, block behaviors and plugins can refer to all of them at once.
+// Tags hold identifiers and references to other tags, which are resolved when the tag is queried.
+type Registry struct {
+	mutex sync.RWMutex
+	tags  map[Kind]map[string][]string
+}
+
+// DefaultRegistry is the registry holding the tags of the server.
+// The default tags are registered upon the init function.
+var DefaultRegistry = NewRegistry()
+
+// init registers the default tags of the default registry.
+func init() {
+	DefaultRegistry.RegisterDefaults()
+}
+
+// NewRegistry returns a new registry without tags.
+func NewRegistry() *Registry {
+	return &Registry{tags: map[Kind]map[string][]string{Items: {}, Blocks: {}}}
+}
+
+// IsValidName checks if the name of a tag is namespaced.
+func IsValidName(name string) bool {
+	var separator = strings.Index(name, ":")
+	return separator > 0 && separator < len(name)-1
+}
+
+// Register adds the values to the tag of the kind with the given name, creating the tag if it does not yet exist.
+// Values are identifiers, or names of other tags prefixed with ReferencePrefix.
+func (registry *Registry) Register(kind Kind, name string, values ...string) error {
+	if !IsValidName(name) {
+		return InvalidName
+	}
+	registry.mutex.Lock()
+	defer registry.mutex.Unlock()
+	if registry.tags[kind] == nil {
+		registry.tags[kind] = make(map[string][]string)
+	}
+	registry.tags[kind][name] = append(registry.tags[kind][name], values...)
+	return nil
+}
+
+// Deregister removes the tag of the kind with the given name.
+func (registry *Registry) Deregister(kind Kind, name string) {
+	registry.mutex.Lock()
+	delete(registry.tags[kind], name)
+	registry.mutex.Unlock()
+}
+
+// IsRegistered checks if a tag of the kind with the given name is registered.
+func (registry *Registry) IsRegistered(kind Kind, name string) bool {
+	registry.mutex.RLock()
+	defer registry.mutex.RUnlock()
+	var _, ok = registry.tags[kind][name]
+	return ok
+}
+
+// Has checks if the tag of the kind with the given name holds the identifier,
+// either directly or through a referenced tag.
+func (registry *Registry) Has(kind Kind, name string, identifier string) bool {
+	registry.mutex.RLock()
+	defer registry.mutex.RUnlock()
+	var found bool
+	registry.walk(kind, name, make(map[string]bool), func(value string) bool {
+		found = value == identifier
+		return !found
+	})
+	return found
+}
+
+// GetValues returns the sorted identifiers of the tag of the kind with the given name,
+// including the identifiers of referenced tags.
+func (registry *Registry) GetValues(kind Kind, name string) []string {
+	registry.mutex.RLock()
+	var unique = make(map[string]bool)
+	registry.walk(kind, name, make(map[string]bool), func(value string) bool {
+		unique[value] = true
+		return true
+	})
+	registry.mutex.RUnlock()
+	var values = make([]string, 0, len(unique))
+	for value := range unique {
+		values = append(values, value)
+	}
+	sort.Strings(values)
+	return values
+}
+
+// GetTags returns the sorted names of all tags of the kind holding the identifier.
+func (registry *Registry) GetTags(kind Kind, identifier string) []string {
+	var names []string
+	for _, name := range registry.GetNames(kind) {
+		if registry.Has(kind, name, identifier) {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// GetNames returns the sorted names of all tags of the kind.
+func (registry *Registry) GetNames(kind Kind) []string {
+	registry.mutex.RLock()
+	var names = make([]string, 0, len(registry.tags[kind]))
+	for name := range registry.tags[kind] {
+		names = append(names, name)
+	}
+	registry.mutex.RUnlock()
+	sort.Strings(names)
+	return names
+}
+
+// walk calls the function with all identifiers of the tag, until the function returns false.
+// Tags that were already visited are skipped, so that tags referencing each other do not recurse forever.
+// A bool is returned indicating if walking should continue.
+func (registry *Registry) walk(kind Kind, name string, visited map[string]bool, function func(value string) bool) bool {
+	if visited[name] {
+		return true
+	}
+	visited[name] = true
+	for _, value := range registry.tags[kind][name] {
+		if strings.HasPrefix(value, ReferencePrefix) {
+			if !registry.walk(kind, strings.TrimPrefix(value, ReferencePrefix), visited, function) {
+				return false
+			}
+		} else if !function(value) {
+			return false
+		}
+	}
+	return true
+}
+
+// LoadDirectory loads all tags from the data files in the directory, if it exists.
+// Data files are stored as <namespace>/<kind>/<name>.json, such as minecraft/items/logs.json for minecraft:logs,
+// and hold the values of the tag, which are added to the registered values unless replace is set:
+// {"replace": false, "values": ["minecraft:log", "#minecraft:planks"]}
+func (registry *Registry) LoadDirectory(path string) error {
+	var namespaces, err = ioutil.ReadDir(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	for _, namespace := range namespaces {
+		if !namespace.IsDir() {
+			continue
+		}
+		for _, kind := range []Kind{Items, Blocks} {
+			var directory = filepath.Join(path, namespace.Name(), string(kind))
+			var err = filepath.Walk(directory, func(file string, info os.FileInfo, err error) error {
+				if os.IsNotExist(err) {
+					return nil
+				}
+				if err != nil || info.IsDir() || filepath.Ext(file) != ".json" {
+					return err
+				}
+				var relative, _ = filepath.Rel(directory, file)
+				return registry.loadFile(kind, namespace.Name()+":"+filepath.ToSlash(strings.TrimSuffix(relative, ".json")), file)
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// loadFile loads the tag of the kind with the given name from the data file at the path.
+func (registry *Registry) loadFile(kind Kind, name string, path string) error {
+	var data, err = ioutil.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	var file tagFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return err
+	}
+	if file.Replace {
+		registry.Deregister(kind, name)
+	}
+	return registry.Register(kind, name, file.Values...)
+}
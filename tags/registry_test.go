@@ -0,0 +1,64 @@
+package tags
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestRegistry(t *testing.T) {
+	registry := NewRegistry()
+	if err := registry.Register(Items, "logs", "minecraft:log"); err != InvalidName {
+		t.Error("expected an invalid name, got", err)
+	}
+	registry.Register(Items, "minecraft:logs", "minecraft:log", "minecraft:log2")
+	registry.Register(Items, "test:burnable", "#minecraft:logs", "minecraft:planks", "#test:burnable")
+
+	if !registry.Has(Items, "test:burnable", "minecraft:log2") || !registry.Has(Items, "test:burnable", "minecraft:planks") {
+		t.Error("expected referenced tags to be resolved")
+	}
+	if registry.Has(Items, "test:burnable", "minecraft:stone") || registry.Has(Blocks, "minecraft:logs", "minecraft:log") {
+		t.Error("expected values outside of the tag not to be part of it")
+	}
+	if values := registry.GetValues(Items, "test:burnable"); len(values) != 3 || values[0] != "minecraft:log" {
+		t.Error("unexpected values:", values)
+	}
+	if names := registry.GetTags(Items, "minecraft:log"); len(names) != 2 || names[0] != "minecraft:logs" {
+		t.Error("unexpected tags:", names)
+	}
+
+	dir, _ := ioutil.TempDir("", "tags")
+	defer os.RemoveAll(dir)
+	os.MkdirAll(filepath.Join(dir, "minecraft", "items"), 0755)
+	os.MkdirAll(filepath.Join(dir, "test", "blocks", "mineable"), 0755)
+	ioutil.WriteFile(filepath.Join(dir, "minecraft", "items", "logs.json"), []byte(`{"replace": true, "values": ["minecraft:stripped_log"]}`), 0644)
+	ioutil.WriteFile(filepath.Join(dir, "test", "blocks", "mineable", "drill.json"), []byte(`{"values": ["minecraft:stone"]}`), 0644)
+	if err := registry.LoadDirectory(dir); err != nil {
+		t.Fatal(err)
+	}
+	if registry.Has(Items, "minecraft:logs", "minecraft:log") || !registry.Has(Items, "minecraft:logs", "minecraft:stripped_log") {
+		t.Error("expected the values of the tag to be replaced")
+	}
+	if !registry.Has(Blocks, "test:mineable/drill", "minecraft:stone") {
+		t.Error("expected tags in sub directories to be loaded")
+	}
+	if err := registry.LoadDirectory(filepath.Join(dir, "missing")); err != nil {
+		t.Error("expected a missing directory to be ignored, got", err)
+	}
+}
+
+func TestCorrectTool(t *testing.T) {
+	if !DefaultRegistry.IsCorrectTool("minecraft:stone", "minecraft:iron_pickaxe") {
+		t.Error("expected a pickaxe to be the correct tool for stone")
+	}
+	if DefaultRegistry.IsCorrectTool("minecraft:stone", "minecraft:iron_shovel") {
+		t.Error("expected a shovel not to be the correct tool for stone")
+	}
+	if !DefaultRegistry.IsCorrectTool("minecraft:log2", "minecraft:wooden_axe") {
+		t.Error("expected tags referenced by mineable tags to be resolved")
+	}
+	if !DefaultRegistry.IsCorrectTool("minecraft:torch", "minecraft:stick") {
+		t.Error("expected any item to be the correct tool for blocks not mineable by a tool")
+	}
+}
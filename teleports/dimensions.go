@@ -0,0 +1,124 @@
+package teleports
+
+import (
+	"sync"
+	"time"
+
+	"github.com/golang/geo/r3"
+	"github.com/irmine/gomine/events"
+	"github.com/irmine/gomine/net"
+	"github.com/irmine/worlds"
+)
+
+// DimensionManager throttles players changing dimensions, either through portals or otherwise.
+// Every dimension change makes the client unload and request all chunks around it,
+// so players rapidly bouncing between dimensions would overload chunk sending.
+type DimensionManager struct {
+	// PortalCooldown is the duration players have to wait before using a portal again.
+	PortalCooldown time.Duration
+	// ChangeCooldown is the duration players have to wait between any dimension changes.
+	ChangeCooldown time.Duration
+
+	mutex        sync.Mutex
+	eventManager *events.Manager
+	portals      map[string]time.Time
+	changes      map[string]time.Time
+}
+
+// NewDimensionManager returns a new dimension manager,
+// with a portal cooldown of five seconds and a dimension change cooldown of two seconds by default.
+func NewDimensionManager(eventManager *events.Manager) *DimensionManager {
+	return &DimensionManager{
+		PortalCooldown: time.Second * 5,
+		ChangeCooldown: time.Second * 2,
+		eventManager:   eventManager,
+		portals:        make(map[string]time.Time),
+		changes:        make(map[string]time.Time),
+	}
+}
+
+// UsePortal moves the session to the position in the dimension a portal leads to.
+// OnCooldown is returned if the session used a portal or changed dimension too recently,
+// and Cancelled if the dimension change event got cancelled.
+func (manager *DimensionManager) UsePortal(session *net.MinecraftSession, dimension *worlds.Dimension, position r3.Vector) error {
+	if manager.GetPortalCooldown(session) > 0 {
+		return OnCooldown
+	}
+	if err := manager.changeDimension(session, dimension, position, true); err != nil {
+		return err
+	}
+	manager.mutex.Lock()
+	manager.portals[session.GetName()] = time.Now()
+	manager.mutex.Unlock()
+	return nil
+}
+
+// ChangeDimension moves the session to the position in another dimension.
+// OnCooldown is returned if the session changed dimension too recently,
+// and Cancelled if the dimension change event got cancelled.
+// Sessions already in the dimension are teleported to the position without cooldown.
+func (manager *DimensionManager) ChangeDimension(session *net.MinecraftSession, dimension *worlds.Dimension, position r3.Vector) error {
+	return manager.changeDimension(session, dimension, position, false)
+}
+
+// changeDimension moves the session to the position in the dimension after calling a dimension change event.
+func (manager *DimensionManager) changeDimension(session *net.MinecraftSession, dimension *worlds.Dimension, position r3.Vector, portal bool) error {
+	if session.GetPlayer().GetDimension() == dimension {
+		session.Teleport(position, session.GetPlayer().GetRotation())
+		return nil
+	}
+	if manager.GetChangeCooldown(session) > 0 {
+		return OnCooldown
+	}
+	var event = &DimensionChangeEvent{Session: session, From: session.GetPlayer().GetDimension(), To: dimension, Position: position, Portal: portal}
+	if !manager.eventManager.Call(event) {
+		return Cancelled
+	}
+	if event.To == session.GetPlayer().GetDimension() {
+		session.Teleport(event.Position, session.GetPlayer().GetRotation())
+	} else {
+		session.ChangeDimension(event.To, event.Position)
+	}
+
+	manager.mutex.Lock()
+	manager.changes[session.GetName()] = time.Now()
+	manager.mutex.Unlock()
+	return nil
+}
+
+// GetPortalCooldown returns the cooldown left before the session can use a portal again,
+// which is never less than the dimension change cooldown left.
+func (manager *DimensionManager) GetPortalCooldown(session *net.MinecraftSession) time.Duration {
+	var now = time.Now()
+	manager.mutex.Lock()
+	defer manager.mutex.Unlock()
+	var cooldown = getCooldown(manager.portals, session.GetName(), manager.PortalCooldown, now)
+	if change := getCooldown(manager.changes, session.GetName(), manager.ChangeCooldown, now); change > cooldown {
+		return change
+	}
+	return cooldown
+}
+
+// GetChangeCooldown returns the cooldown left before the session can change dimension again.
+func (manager *DimensionManager) GetChangeCooldown(session *net.MinecraftSession) time.Duration {
+	manager.mutex.Lock()
+	defer manager.mutex.Unlock()
+	return getCooldown(manager.changes, session.GetName(), manager.ChangeCooldown, time.Now())
+}
+
+// Tick removes the portal uses and dimension changes of which the cooldown has passed.
+func (manager *DimensionManager) Tick() {
+	var now = time.Now()
+	manager.mutex.Lock()
+	for name := range manager.portals {
+		if getCooldown(manager.portals, name, manager.PortalCooldown, now) == 0 {
+			delete(manager.portals, name)
+		}
+	}
+	for name := range manager.changes {
+		if getCooldown(manager.changes, name, manager.ChangeCooldown, now) == 0 {
+			delete(manager.changes, name)
+		}
+	}
+	manager.mutex.Unlock()
+}
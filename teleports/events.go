@@ -0,0 +1,46 @@
+package teleports
+
+import (
+	"github.com/golang/geo/r3"
+	"github.com/irmine/gomine/events"
+	"github.com/irmine/gomine/net"
+	"github.com/irmine/worlds"
+)
+
+const (
+	RandomTeleportEventName  events.Name = "PlayerRandomTeleportEvent"
+	DimensionChangeEventName events.Name = "PlayerDimensionChangeEvent"
+)
+
+// RandomTeleportEvent gets called once a random location has been found for a player, before it gets teleported.
+// The position may be changed to teleport the player elsewhere, and cancelling the event keeps the player in place.
+type RandomTeleportEvent struct {
+	events.Cancellable
+	Session   *net.MinecraftSession
+	Dimension *worlds.Dimension
+	// Position is the position the player gets teleported to, at eye height.
+	Position r3.Vector
+}
+
+// GetName returns the name of the event.
+func (event *RandomTeleportEvent) GetName() events.Name {
+	return RandomTeleportEventName
+}
+
+// DimensionChangeEvent gets called when a player changes dimension, before it gets moved.
+// The dimension and position may be changed, and cancelling the event keeps the player in place.
+type DimensionChangeEvent struct {
+	events.Cancellable
+	Session *net.MinecraftSession
+	From    *worlds.Dimension
+	To      *worlds.Dimension
+	// Position is the position in the dimension the player gets moved to.
+	Position r3.Vector
+	// Portal specifies if the player is changing dimension by using a portal.
+	Portal bool
+}
+
+// GetName returns the name of the event.
+func (event *DimensionChangeEvent) GetName() events.Name {
+	return DimensionChangeEventName
+}
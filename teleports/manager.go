@@ -0,0 +1,148 @@
+package teleports
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/golang/geo/r3"
+	"github.com/irmine/gomine/events"
+	"github.com/irmine/gomine/levels"
+	"github.com/irmine/gomine/net"
+	"github.com/irmine/worlds"
+)
+
+// eyeHeight is the height of the eyes of players above their feet.
+const eyeHeight = 1.62
+
+var (
+	// OnCooldown gets returned when a player teleports randomly or changes dimension before its cooldown has passed.
+	OnCooldown = errors.New("random teleport is on cooldown")
+	// Searching gets returned when a player teleports randomly while a location is still being searched for it.
+	Searching = errors.New("already searching a random location")
+	// Cancelled gets returned when the random teleport or dimension change event got cancelled.
+	Cancelled = errors.New("teleport was cancelled")
+)
+
+// Manager teleports players to random safe locations, such as for /rtp.
+// Locations are searched by loading or generating the chunks of random positions,
+// which happens asynchronously, and the cooldown of a player only starts once it got teleported.
+type Manager struct {
+	// Radius is the maximum distance in blocks between the center of the search and random locations.
+	Radius int32
+	// Attempts is the amount of random positions tried before giving up the search.
+	Attempts int
+	// Cooldown is the duration players have to wait between random teleports.
+	Cooldown time.Duration
+	// SearchFunction searches a safe spawn at a random position within the radius around the center,
+	// and calls the function once the search finishes. It is usually set to the FindRandomSpawn
+	// function of a level manager, and returns levels.NoSafeSpawn by default.
+	SearchFunction func(dimension *worlds.Dimension, center r3.Vector, radius int32, attempts int, function func(spawn r3.Vector, err error))
+
+	mutex        sync.Mutex
+	eventManager *events.Manager
+	teleports    map[string]time.Time
+	searching    map[string]bool
+}
+
+// NewManager returns a new random teleport manager,
+// searching within 2000 blocks with a cooldown of five minutes by default.
+func NewManager(eventManager *events.Manager) *Manager {
+	return &Manager{
+		Radius:   2000,
+		Attempts: 8,
+		Cooldown: time.Minute * 5,
+		SearchFunction: func(dimension *worlds.Dimension, center r3.Vector, radius int32, attempts int, function func(spawn r3.Vector, err error)) {
+			function(center, levels.NoSafeSpawn)
+		},
+		eventManager: eventManager,
+		teleports:    make(map[string]time.Time),
+		searching:    make(map[string]bool),
+	}
+}
+
+// Teleport searches a random safe location in the dimension within the radius around the center,
+// and teleports the session to it after calling a random teleport event.
+// The function gets called once the session got teleported or the teleport failed, with the position
+// teleported to and an error, which is OnCooldown, Searching, Cancelled, levels.NoSafeSpawn or net.SessionClosed.
+func (manager *Manager) Teleport(session *net.MinecraftSession, dimension *worlds.Dimension, center r3.Vector, function func(position r3.Vector, err error)) {
+	var name = session.GetName()
+	manager.mutex.Lock()
+	if manager.searching[name] {
+		manager.mutex.Unlock()
+		function(center, Searching)
+		return
+	}
+	if getCooldown(manager.teleports, name, manager.Cooldown, time.Now()) > 0 {
+		manager.mutex.Unlock()
+		function(center, OnCooldown)
+		return
+	}
+	manager.searching[name] = true
+	manager.mutex.Unlock()
+
+	manager.SearchFunction(dimension, center, manager.Radius, manager.Attempts, func(spawn r3.Vector, err error) {
+		manager.mutex.Lock()
+		delete(manager.searching, name)
+		manager.mutex.Unlock()
+		if err != nil {
+			function(spawn, err)
+			return
+		}
+		if session.IsClosed() {
+			function(spawn, net.SessionClosed)
+			return
+		}
+		var event = &RandomTeleportEvent{Session: session, Dimension: dimension, Position: spawn.Add(r3.Vector{Y: eyeHeight})}
+		if !manager.eventManager.Call(event) {
+			function(event.Position, Cancelled)
+			return
+		}
+		session.Teleport(event.Position, session.GetPlayer().GetRotation())
+
+		manager.mutex.Lock()
+		manager.teleports[name] = time.Now()
+		manager.mutex.Unlock()
+		function(event.Position, nil)
+	})
+}
+
+// IsSearching checks if a random location is being searched for the session.
+func (manager *Manager) IsSearching(session *net.MinecraftSession) bool {
+	manager.mutex.Lock()
+	defer manager.mutex.Unlock()
+	return manager.searching[session.GetName()]
+}
+
+// GetCooldown returns the cooldown left before the session can teleport randomly again.
+func (manager *Manager) GetCooldown(session *net.MinecraftSession) time.Duration {
+	manager.mutex.Lock()
+	defer manager.mutex.Unlock()
+	return getCooldown(manager.teleports, session.GetName(), manager.Cooldown, time.Now())
+}
+
+// getCooldown returns the cooldown left at the given time for the player with the given name,
+// of which the times it last did something are held by the map.
+func getCooldown(times map[string]time.Time, name string, cooldown time.Duration, now time.Time) time.Duration {
+	var last, ok = times[name]
+	if !ok {
+		return 0
+	}
+	var left = last.Add(cooldown).Sub(now)
+	if left < 0 {
+		return 0
+	}
+	return left
+}
+
+// Tick removes the teleports of which the cooldown has passed.
+func (manager *Manager) Tick() {
+	var now = time.Now()
+	manager.mutex.Lock()
+	for name := range manager.teleports {
+		if getCooldown(manager.teleports, name, manager.Cooldown, now) == 0 {
+			delete(manager.teleports, name)
+		}
+	}
+	manager.mutex.Unlock()
+}
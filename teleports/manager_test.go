@@ -0,0 +1,80 @@
+package teleports
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/geo/r3"
+	"github.com/google/uuid"
+	"github.com/irmine/gomine/events"
+	"github.com/irmine/gomine/levels"
+	"github.com/irmine/gomine/net"
+	"github.com/irmine/gomine/players"
+	"github.com/irmine/worlds"
+)
+
+func TestTeleport(t *testing.T) {
+	manager := NewManager(events.NewManager())
+	session := net.NewMinecraftSession(nil, nil)
+	session.SetPlayer(players.NewPlayer(uuid.New(), "", 0, "Steve"))
+
+	var result error
+	manager.Teleport(session, nil, r3.Vector{}, func(position r3.Vector, err error) {
+		result = err
+	})
+	if result != levels.NoSafeSpawn {
+		t.Error("expected no safe spawn by default, got", result)
+	}
+
+	var finish func(spawn r3.Vector, err error)
+	manager.SearchFunction = func(dimension *worlds.Dimension, center r3.Vector, radius int32, attempts int, function func(spawn r3.Vector, err error)) {
+		if radius != manager.Radius || attempts != manager.Attempts {
+			t.Error("unexpected search radius or attempts:", radius, attempts)
+		}
+		finish = function
+	}
+	manager.Teleport(session, nil, r3.Vector{}, func(position r3.Vector, err error) {
+		result = err
+	})
+	if !manager.IsSearching(session) {
+		t.Fatal("expected a location to be searched")
+	}
+	manager.Teleport(session, nil, r3.Vector{}, func(position r3.Vector, err error) {
+		result = err
+	})
+	if result != Searching {
+		t.Error("expected already searching, got", result)
+	}
+
+	finish(r3.Vector{X: 100, Y: 64, Z: -20}, nil)
+	if result != net.SessionClosed {
+		t.Error("expected closed session to not be teleported, got", result)
+	}
+	if manager.IsSearching(session) || manager.GetCooldown(session) != 0 {
+		t.Error("expected failed teleport to not start a cooldown")
+	}
+}
+
+func TestDimensionCooldown(t *testing.T) {
+	manager := NewDimensionManager(events.NewManager())
+	session := net.NewMinecraftSession(nil, nil)
+	session.SetPlayer(players.NewPlayer(uuid.New(), "", 0, "Steve"))
+	if manager.GetPortalCooldown(session) != 0 || manager.GetChangeCooldown(session) != 0 {
+		t.Fatal("expected no cooldown before changing dimension")
+	}
+
+	manager.changes[session.GetName()] = time.Now()
+	if manager.GetChangeCooldown(session) <= 0 {
+		t.Error("expected dimension change cooldown after changing dimension")
+	}
+	if manager.GetPortalCooldown(session) <= 0 {
+		t.Error("expected portal cooldown to include the dimension change cooldown")
+	}
+
+	manager.changes[session.GetName()] = time.Now().Add(-manager.ChangeCooldown)
+	manager.portals[session.GetName()] = time.Now().Add(-manager.PortalCooldown)
+	manager.Tick()
+	if len(manager.changes) != 0 || len(manager.portals) != 0 {
+		t.Error("expected passed cooldowns to be removed")
+	}
+}
@@ -0,0 +1,38 @@
+package text
+
+import (
+	"strings"
+	"sync"
+)
+
+// LogBuffer keeps the most recent lines logged, stripped of colors.
+// Its Write method may be added as output function of a logger.
+type LogBuffer struct {
+	mutex sync.Mutex
+	size  int
+	lines []string
+}
+
+// NewLogBuffer returns a new log buffer keeping at most the given amount of lines.
+func NewLogBuffer(size int) *LogBuffer {
+	return &LogBuffer{size: size}
+}
+
+// Write adds the lines of the logged message to the buffer,
+// dropping the oldest lines once the buffer is full.
+func (buffer *LogBuffer) Write(message []byte) {
+	var lines = strings.Split(strings.TrimRight(ColoredString(message).StripAll(), "\n"), "\n")
+	buffer.mutex.Lock()
+	defer buffer.mutex.Unlock()
+	buffer.lines = append(buffer.lines, lines...)
+	if len(buffer.lines) > buffer.size {
+		buffer.lines = append(buffer.lines[:0], buffer.lines[len(buffer.lines)-buffer.size:]...)
+	}
+}
+
+// GetLines returns a copy of the lines in the buffer, oldest first.
+func (buffer *LogBuffer) GetLines() []string {
+	buffer.mutex.Lock()
+	defer buffer.mutex.Unlock()
+	return append([]string(nil), buffer.lines...)
+}
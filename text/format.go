@@ -0,0 +1,116 @@
+package text
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// FormatCodes holds all characters that form a formatting code when following the § sign.
+const FormatCodes = "0123456789abcdefgklmnor"
+
+// LegacyPre is the sign used by players to type formatting codes,
+// such as &a for bright green, which get translated to § codes.
+const LegacyPre = "&"
+
+// TranslateColorCodes translates all &-style formatting codes in the message to § codes.
+// Ampersands not followed by a formatting code are left untouched.
+func TranslateColorCodes(message string) string {
+	if !strings.Contains(message, LegacyPre) {
+		return message
+	}
+	var builder strings.Builder
+	for i := 0; i < len(message); i++ {
+		if message[i] == LegacyPre[0] && i+1 < len(message) && isFormatCode(message[i+1]) {
+			builder.WriteString(Pre)
+			builder.WriteByte(toLower(message[i+1]))
+			i++
+			continue
+		}
+		builder.WriteByte(message[i])
+	}
+	return builder.String()
+}
+
+// StripFormatting strips all § formatting codes from the message, including unknown codes.
+// A new string is returned with the formatting stripped.
+func StripFormatting(message string) string {
+	if !strings.Contains(message, Pre) {
+		return message
+	}
+	var builder strings.Builder
+	for len(message) > 0 {
+		var index = strings.Index(message, Pre)
+		if index == -1 {
+			builder.WriteString(message)
+			break
+		}
+		builder.WriteString(message[:index])
+		// The character following the § is part of the code,
+		// even if it is not a known code, as the client hides it too.
+		var _, size = utf8.DecodeRuneInString(message[index+len(Pre):])
+		message = message[index+len(Pre)+size:]
+	}
+	return builder.String()
+}
+
+// ExpandShortcodes replaces all shortcodes in the message, such as :heart:,
+// with their replacement in the shortcode name => replacement map.
+// Shortcodes without a replacement are left untouched.
+func ExpandShortcodes(message string, shortcodes map[string]string) string {
+	if len(shortcodes) == 0 || strings.Count(message, ":") < 2 {
+		return message
+	}
+	var builder strings.Builder
+	for {
+		var start = strings.Index(message, ":")
+		if start == -1 {
+			break
+		}
+		var end = strings.Index(message[start+1:], ":")
+		if end == -1 {
+			break
+		}
+		end += start + 1
+		if replacement, ok := shortcodes[strings.ToLower(message[start+1:end])]; ok {
+			builder.WriteString(message[:start])
+			builder.WriteString(replacement)
+			message = message[end+1:]
+			continue
+		}
+		// The closing colon may open the next shortcode.
+		builder.WriteString(message[:end])
+		message = message[end:]
+	}
+	builder.WriteString(message)
+	return builder.String()
+}
+
+// IsFontSafe checks if the replacement of a shortcode can be rendered by the Bedrock font.
+// Characters outside of the basic multilingual plane, such as most emoji,
+// control characters and variation selectors have no glyphs and are not safe.
+// Formatting codes are not safe either, as they would bypass the permission to use formatting.
+func IsFontSafe(replacement string) bool {
+	if replacement == "" || strings.Contains(replacement, Pre) {
+		return false
+	}
+	for _, r := range replacement {
+		if r == unicode.ReplacementChar || r > 0xffff || unicode.IsControl(r) || unicode.Is(unicode.Variation_Selector, r) {
+			return false
+		}
+	}
+	return true
+}
+
+// isFormatCode checks if the character forms a formatting code, ignoring case.
+func isFormatCode(char byte) bool {
+	return strings.IndexByte(FormatCodes, toLower(char)) != -1
+}
+
+// toLower returns the ASCII character in lower case.
+func toLower(char byte) byte {
+	if char >= 'A' && char <= 'Z' {
+		return char + 'a' - 'A'
+	}
+	return char
+}
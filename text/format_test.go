@@ -0,0 +1,49 @@
+package text
+
+import (
+	"testing"
+)
+
+func TestTranslateColorCodes(t *testing.T) {
+	tests := map[string]string{
+		"&aGreen &LBold":  BrightGreen + "Green " + Bold + "Bold",
+		"Fish & Chips &z": "Fish & Chips &z",
+		"trailing &":      "trailing &",
+		"&&cred":          "&" + BrightRed + "red",
+	}
+	for message, expected := range tests {
+		if translated := TranslateColorCodes(message); translated != expected {
+			t.Errorf("%q translated to %q, expected %q", message, translated, expected)
+		}
+	}
+}
+
+func TestStripFormatting(t *testing.T) {
+	tests := map[string]string{
+		BrightGreen + "Green " + Bold + "Bold": "Green Bold",
+		"unknown §zcode and §":                 "unknown code and ",
+		"§é accent":                            " accent",
+	}
+	for message, expected := range tests {
+		if stripped := StripFormatting(message); stripped != expected {
+			t.Errorf("%q stripped to %q, expected %q", message, stripped, expected)
+		}
+	}
+}
+
+func TestExpandShortcodes(t *testing.T) {
+	shortcodes := map[string]string{"heart": "❤", "star": "★"}
+	tests := map[string]string{
+		"I :heart: you":         "I ❤ you",
+		"time 12:30 :STAR:":     "time 12:30 ★",
+		"a:b:heart: :unknown:c": "a:b❤ :unknown:c",
+	}
+	for message, expected := range tests {
+		if expanded := ExpandShortcodes(message, shortcodes); expanded != expected {
+			t.Errorf("%q expanded to %q, expected %q", message, expanded, expected)
+		}
+	}
+	if !IsFontSafe("❤") || IsFontSafe("😀") || IsFontSafe(Red+"x") || IsFontSafe("") {
+		t.Error("font safety of shortcode replacements is incorrect")
+	}
+}
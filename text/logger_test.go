@@ -40,3 +40,13 @@ func TestMultipleWait(t *testing.T) {
 	logger.LogStack()
 	logger.Wait()
 }
+
+func TestLogBuffer(t *testing.T) {
+	buffer := NewLogBuffer(3)
+	buffer.Write([]byte(Red + "first\n"))
+	buffer.Write([]byte("second\nthird\n"))
+	buffer.Write([]byte(AnsiGray + "fourth" + AnsiReset + "\n"))
+	if lines := buffer.GetLines(); len(lines) != 3 || lines[0] != "second" || lines[2] != "fourth" {
+		t.Error("unexpected buffered lines:", lines)
+	}
+}
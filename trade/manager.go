@@ -0,0 +1,357 @@
+package trade
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/irmine/gomine/items"
+	"github.com/irmine/gomine/items/inventory"
+	"github.com/irmine/gomine/items/inventory/io"
+	"github.com/irmine/gomine/net"
+	"github.com/irmine/gomine/text"
+)
+
+var (
+	// SelfTrade gets returned when a player tries to trade with themselves.
+	SelfTrade = errors.New("player can not trade with themselves")
+	// AlreadyTrading gets returned when a player is already in a trade.
+	AlreadyTrading = errors.New("player is already trading")
+	// OnCooldown gets returned when a player sends
+	// trade requests faster than the request cooldown.
+	OnCooldown = errors.New("please wait before sending another trade request")
+	// NoRequest gets returned when no pending trade request could be found.
+	NoRequest = errors.New("no pending trade request of player")
+	// NotTrading gets returned when a player is not in a trade.
+	NotTrading = errors.New("player is not trading")
+)
+
+// Manager manages all trade requests and trades on the server.
+type Manager struct {
+	// RequestCooldown is the minimum duration between
+	// two trade requests sent by the same player.
+	RequestCooldown time.Duration
+	// RequestTimeout is the duration after which trade requests expire.
+	RequestTimeout time.Duration
+	// LockDuration is the duration confirming a trade is locked
+	// for, after any of the two offers changed.
+	LockDuration time.Duration
+	// DropFunction gets called with every traded or returned item
+	// that did not fit in the inventory of the player receiving it.
+	// By default the item is logged as lost.
+	DropFunction func(session *net.MinecraftSession, stack *items.Stack)
+
+	mutex        sync.RWMutex
+	requests     map[string]map[string]time.Time
+	lastRequests map[string]time.Time
+	trades       map[string]*Trade
+}
+
+// NewManager returns a new trade manager.
+func NewManager() *Manager {
+	return &Manager{
+		RequestCooldown: time.Second * 10,
+		RequestTimeout:  time.Minute,
+		LockDuration:    time.Second * 3,
+		DropFunction: func(session *net.MinecraftSession, stack *items.Stack) {
+			text.DefaultLogger.Error("Could not return trade item", stack, "to", session.GetName()+":", inventory.FullInventory)
+		},
+		requests:     make(map[string]map[string]time.Time),
+		lastRequests: make(map[string]time.Time),
+		trades:       make(map[string]*Trade),
+	}
+}
+
+// GetTrade returns the trade the player with the given name is in.
+// A bool is returned indicating if the player was trading.
+func (manager *Manager) GetTrade(name string) (*Trade, bool) {
+	manager.mutex.RLock()
+	defer manager.mutex.RUnlock()
+	var trade, ok = manager.trades[name]
+	return trade, ok
+}
+
+// Request sends a trade request from the sender to the target.
+// If the target already sent a request to the sender, the trade gets started instead.
+func (manager *Manager) Request(sender *net.MinecraftSession, target *net.MinecraftSession) error {
+	if sender.GetName() == target.GetName() {
+		return SelfTrade
+	}
+	if _, ok := manager.GetTrade(sender.GetName()); ok {
+		return AlreadyTrading
+	}
+	if _, ok := manager.GetTrade(target.GetName()); ok {
+		return AlreadyTrading
+	}
+	if manager.hasRequest(sender.GetName(), target.GetName()) {
+		return manager.Accept(sender, target)
+	}
+
+	manager.mutex.Lock()
+	if time.Since(manager.lastRequests[sender.GetName()]) < manager.RequestCooldown {
+		manager.mutex.Unlock()
+		return OnCooldown
+	}
+	manager.lastRequests[sender.GetName()] = time.Now()
+	if manager.requests[target.GetName()] == nil {
+		manager.requests[target.GetName()] = make(map[string]time.Time)
+	}
+	manager.requests[target.GetName()][sender.GetName()] = time.Now().Add(manager.RequestTimeout)
+	manager.mutex.Unlock()
+
+	target.SendMessage(text.Yellow + sender.GetDisplayName() + " wants to trade with you. Use /trade accept " + sender.GetName() + " to accept.")
+	return nil
+}
+
+// Accept accepts a trade request of the sender, and starts the trade.
+func (manager *Manager) Accept(session *net.MinecraftSession, sender *net.MinecraftSession) error {
+	if !manager.hasRequest(session.GetName(), sender.GetName()) {
+		return NoRequest
+	}
+	if _, ok := manager.GetTrade(session.GetName()); ok {
+		return AlreadyTrading
+	}
+	if _, ok := manager.GetTrade(sender.GetName()); ok {
+		return AlreadyTrading
+	}
+	var trade = NewTrade(sender, session)
+	manager.mutex.Lock()
+	delete(manager.requests[session.GetName()], sender.GetName())
+	delete(manager.requests[sender.GetName()], session.GetName())
+	manager.trades[session.GetName()] = trade
+	manager.trades[sender.GetName()] = trade
+	manager.mutex.Unlock()
+
+	trade.open()
+	return nil
+}
+
+// Deny denies a trade request of the player with the given name.
+func (manager *Manager) Deny(session *net.MinecraftSession, senderName string) error {
+	if !manager.hasRequest(session.GetName(), senderName) {
+		return NoRequest
+	}
+	manager.mutex.Lock()
+	delete(manager.requests[session.GetName()], senderName)
+	manager.mutex.Unlock()
+	return nil
+}
+
+// Cancel cancels the trade of the player with the given name.
+// All offered items get returned to their owners.
+func (manager *Manager) Cancel(name string) error {
+	var trade, ok = manager.GetTrade(name)
+	if !ok {
+		return NotTrading
+	}
+	manager.remove(trade)
+	trade.rollback(manager.DropFunction)
+	trade.close()
+	for _, session := range trade.GetSessions() {
+		session.SendMessage(text.Red + "The trade has been cancelled.")
+	}
+	return nil
+}
+
+// HandleDisconnect cancels the trade and removes all requests of a player that left the server.
+// Internal. Not to be used by plugins.
+func (manager *Manager) HandleDisconnect(name string) {
+	manager.Cancel(name)
+	manager.mutex.Lock()
+	delete(manager.requests, name)
+	delete(manager.lastRequests, name)
+	for _, requests := range manager.requests {
+		delete(requests, name)
+	}
+	manager.mutex.Unlock()
+}
+
+// HandleClose handles the closing of a window by a session.
+// Closing the trade window cancels the trade.
+// Internal. Not to be used by plugins.
+func (manager *Manager) HandleClose(session *net.MinecraftSession, windowId byte) bool {
+	if windowId != WindowId {
+		return false
+	}
+	manager.Cancel(session.GetName())
+	return true
+}
+
+// HandleTransaction handles an inventory transaction of a session.
+// Returns false if the session is not trading, or the transaction
+// does not involve the trade window, in which case it should be handled as usual.
+// Transactions that are not valid get rejected, and the windows get resent.
+// Internal. Not to be used by plugins.
+func (manager *Manager) HandleTransaction(session *net.MinecraftSession, actions []io.InventoryActionIO) bool {
+	var trade, ok = manager.GetTrade(session.GetName())
+	if !ok || !involvesWindow(actions) {
+		return false
+	}
+	var name = session.GetName()
+	for _, action := range actions {
+		if action.Source != io.ContainerSource || action.WindowId != WindowId {
+			continue
+		}
+		switch action.InventorySlot {
+		case ownStatusSlot:
+			session.SendInventory()
+			manager.confirm(trade, session)
+			return true
+		case cancelSlot:
+			manager.Cancel(name)
+			return true
+		}
+	}
+
+	if !manager.applyActions(trade, session, actions) {
+		session.SendInventory()
+	} else {
+		trade.offerChanged(manager.LockDuration)
+	}
+	trade.update()
+	return true
+}
+
+// Tick refreshes the windows of trades of which the lock has just expired.
+// Internal. Not to be used by plugins.
+func (manager *Manager) Tick() {
+	manager.mutex.RLock()
+	var trades = make(map[*Trade]bool)
+	for _, trade := range manager.trades {
+		trades[trade] = true
+	}
+	manager.mutex.RUnlock()
+	for trade := range trades {
+		trade.mutex.Lock()
+		var expired = !trade.lockedUntil.IsZero() && time.Now().After(trade.lockedUntil)
+		if expired {
+			trade.lockedUntil = time.Time{}
+		}
+		trade.mutex.Unlock()
+		if expired {
+			trade.update()
+		}
+	}
+}
+
+// confirm toggles the confirmation of the session,
+// and completes the trade once both players confirmed.
+func (manager *Manager) confirm(trade *Trade, session *net.MinecraftSession) {
+	if trade.IsLocked() {
+		session.SendMessage(text.Red + "An offer changed recently, please review the trade before confirming.")
+		trade.update()
+		return
+	}
+	if !trade.confirm(session.GetName()) {
+		trade.update()
+		return
+	}
+	if !trade.canReceive() {
+		trade.offerChanged(0)
+		trade.update()
+		for _, s := range trade.GetSessions() {
+			s.SendMessage(text.Red + "Not enough inventory space to complete the trade.")
+		}
+		return
+	}
+	manager.remove(trade)
+	trade.complete(manager.DropFunction)
+	trade.close()
+	for _, s := range trade.GetSessions() {
+		s.SendMessage(text.BrightGreen + "The trade has been completed.")
+	}
+}
+
+// applyActions validates and applies the actions of a transaction involving the trade window.
+// Actions may only move items between the inventory, the cursor and the own offer.
+// Returns false if any of the actions was not valid, in which case none are applied.
+func (manager *Manager) applyActions(trade *Trade, session *net.MinecraftSession, actions []io.InventoryActionIO) bool {
+	var player = session.GetPlayer()
+	var offer = trade.offers[trade.index(session.GetName())]
+	var balance inventory.Balance
+
+	trade.mutex.Lock()
+	defer trade.mutex.Unlock()
+	for _, action := range actions {
+		if action.Source != io.ContainerSource {
+			return false
+		}
+		var current *items.Stack
+		if action.WindowId == WindowId {
+			var slot, ok = offerSlot(int(action.InventorySlot))
+			if !ok {
+				return false
+			}
+			current, _ = offer.GetItem(slot)
+		} else {
+			var inv, ok = player.GetInventoryById(action.WindowId)
+			if !ok {
+				return false
+			}
+			current, _ = inv.GetItem(int(action.InventorySlot))
+		}
+		if !inventory.EqualStacks(current, action.OldItem) {
+			return false
+		}
+		balance.Change(action.OldItem, action.NewItem)
+	}
+	if !balance.IsZero() {
+		return false
+	}
+
+	for _, action := range actions {
+		if action.WindowId == WindowId {
+			var slot, _ = offerSlot(int(action.InventorySlot))
+			if inventory.IsAir(action.NewItem) {
+				offer.ClearSlot(slot)
+			} else {
+				offer.SetItem(action.NewItem, slot)
+			}
+			continue
+		}
+		player.SetInventorySlot(action.WindowId, int(action.InventorySlot), action.NewItem)
+	}
+	return true
+}
+
+// hasRequest checks if the target has a pending trade request of the sender.
+func (manager *Manager) hasRequest(target string, sender string) bool {
+	manager.mutex.Lock()
+	defer manager.mutex.Unlock()
+	var expiry, ok = manager.requests[target][sender]
+	if ok && time.Now().After(expiry) {
+		delete(manager.requests[target], sender)
+		return false
+	}
+	return ok
+}
+
+// remove removes the trade from the manager.
+func (manager *Manager) remove(trade *Trade) {
+	manager.mutex.Lock()
+	for _, session := range trade.GetSessions() {
+		delete(manager.trades, session.GetName())
+	}
+	manager.mutex.Unlock()
+}
+
+// involvesWindow checks if any of the actions involves the trade window.
+func involvesWindow(actions []io.InventoryActionIO) bool {
+	for _, action := range actions {
+		if action.Source == io.ContainerSource && action.WindowId == WindowId {
+			return true
+		}
+	}
+	return false
+}
+
+// offerSlot converts a window slot to the slot in the offer of the viewer.
+// A bool is returned indicating if the window slot was part of the own offer.
+func offerSlot(windowSlot int) (int, bool) {
+	for slot, s := range ownSlots {
+		if s == windowSlot {
+			return slot, true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,75 @@
+package trade
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/irmine/gomine/items"
+	"github.com/irmine/gomine/items/enchantments"
+	"github.com/irmine/gomine/items/inventory/io"
+	"github.com/irmine/gomine/net"
+	"github.com/irmine/gomine/net/packets/data"
+	"github.com/irmine/gomine/players"
+)
+
+func newSession(name string) *net.MinecraftSession {
+	session := net.NewMinecraftSession(nil, nil)
+	session.SetPlayer(players.NewPlayer(uuid.New(), "", 0, name))
+	return session
+}
+
+func TestApplyActions(t *testing.T) {
+	manager := NewManager()
+	steve, alex := newSession("Steve"), newSession("Alex")
+	trade := NewTrade(steve, alex)
+
+	stone, _ := items.DefaultManager.Get("minecraft:stone", 16)
+	air, _ := items.DefaultManager.Get("minecraft:air", 0)
+	steve.GetPlayer().GetInventory().SetItem(stone, 0)
+
+	move := []io.InventoryActionIO{
+		{Source: io.ContainerSource, WindowId: data.ContainerInventory, InventorySlot: 0, OldItem: stone, NewItem: air},
+		{Source: io.ContainerSource, WindowId: WindowId, InventorySlot: uint32(ownSlots[0]), OldItem: air, NewItem: stone},
+	}
+	if !manager.applyActions(trade, steve, move) {
+		t.Fatal("valid move into offer was rejected")
+	}
+	if offer := trade.GetOffer("Steve"); offer[0] == nil || offer[0].Count != 16 {
+		t.Error("item was not escrowed in offer:", offer)
+	}
+	if !steve.GetPlayer().GetInventory().IsEmpty(0) {
+		t.Error("item was not removed from inventory")
+	}
+
+	more, _ := items.DefaultManager.Get("minecraft:stone", 64)
+	duplicate := []io.InventoryActionIO{
+		{Source: io.ContainerSource, WindowId: WindowId, InventorySlot: uint32(ownSlots[1]), OldItem: air, NewItem: more},
+	}
+	if manager.applyActions(trade, steve, duplicate) {
+		t.Error("transaction creating items was accepted")
+	}
+	partner := []io.InventoryActionIO{
+		{Source: io.ContainerSource, WindowId: WindowId, InventorySlot: uint32(partnerSlots[0]), OldItem: air, NewItem: air},
+	}
+	if manager.applyActions(trade, steve, partner) {
+		t.Error("transaction modifying the offer of the partner was accepted")
+	}
+
+	emerald, _ := items.DefaultManager.Get("minecraft:emerald", 1)
+	enchanted, _ := items.DefaultManager.Get("minecraft:emerald", 1)
+	sharpness, _ := enchantments.DefaultManager.GetById(enchantments.Sharpness)
+	enchanted.AddEnchantment(enchantments.Instance{Type: sharpness, Level: 5})
+	steve.GetPlayer().GetInventory().SetItem(emerald, 1)
+	swap := []io.InventoryActionIO{
+		{Source: io.ContainerSource, WindowId: data.ContainerInventory, InventorySlot: 1, OldItem: emerald, NewItem: air},
+		{Source: io.ContainerSource, WindowId: WindowId, InventorySlot: uint32(ownSlots[1]), OldItem: air, NewItem: enchanted},
+	}
+	if manager.applyActions(trade, steve, swap) {
+		t.Error("transaction enchanting a stack was accepted")
+	}
+
+	trade.rollback(manager.DropFunction)
+	if steve.GetPlayer().GetInventory().IsEmpty(0) {
+		t.Error("offered item was not returned on rollback")
+	}
+}
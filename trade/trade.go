@@ -0,0 +1,233 @@
+package trade
+
+import (
+	"math"
+	"sync"
+	"time"
+
+	"github.com/irmine/gomine/items"
+	"github.com/irmine/gomine/items/inventory"
+	"github.com/irmine/gomine/net"
+	"github.com/irmine/gomine/net/packets/data"
+	"github.com/irmine/gomine/text"
+	"github.com/irmine/worlds/blocks"
+)
+
+// WindowId is the ID of the window trades are displayed in.
+const WindowId = 90
+
+const (
+	// windowSize is the amount of slots in the trade window.
+	windowSize = 27
+	// offerSize is the amount of slots each player can offer.
+	offerSize = 12
+
+	ownStatusSlot     = 4
+	partnerStatusSlot = 13
+	cancelSlot        = 22
+)
+
+// ownSlots are the window slots of the offer of the viewer,
+// displayed in the left four columns of the window.
+var ownSlots = [offerSize]int{0, 1, 2, 3, 9, 10, 11, 12, 18, 19, 20, 21}
+
+// partnerSlots are the window slots of the offer of the partner,
+// displayed in the right four columns of the window.
+var partnerSlots = [offerSize]int{5, 6, 7, 8, 14, 15, 16, 17, 23, 24, 25, 26}
+
+// Trade is a trade between two players.
+// Offered items are held in escrow by the trade,
+// until either both players confirm or the trade gets cancelled.
+// Every change of an offer resets the confirmations of both players,
+// and locks confirming for a short duration,
+// so an offer can not be swapped right before the other player confirms.
+type Trade struct {
+	mutex       sync.Mutex
+	sessions    [2]*net.MinecraftSession
+	offers      [2]*inventory.Inventory
+	confirmed   [2]bool
+	positions   [2]blocks.Position
+	lockedUntil time.Time
+}
+
+// NewTrade returns a new trade between the two given sessions.
+func NewTrade(first *net.MinecraftSession, second *net.MinecraftSession) *Trade {
+	return &Trade{sessions: [2]*net.MinecraftSession{first, second}, offers: [2]*inventory.Inventory{inventory.NewInventory(offerSize), inventory.NewInventory(offerSize)}}
+}
+
+// GetSessions returns both sessions taking part in the trade.
+func (trade *Trade) GetSessions() [2]*net.MinecraftSession {
+	return trade.sessions
+}
+
+// GetPartner returns the trading partner of the player with the given name.
+func (trade *Trade) GetPartner(name string) *net.MinecraftSession {
+	return trade.sessions[1-trade.index(name)]
+}
+
+// GetOffer returns a copy of the items offered by the player with the given name.
+func (trade *Trade) GetOffer(name string) []*items.Stack {
+	trade.mutex.Lock()
+	defer trade.mutex.Unlock()
+	return trade.offers[trade.index(name)].GetAll()
+}
+
+// IsConfirmed checks if the player with the given name confirmed the trade.
+func (trade *Trade) IsConfirmed(name string) bool {
+	trade.mutex.Lock()
+	defer trade.mutex.Unlock()
+	return trade.confirmed[trade.index(name)]
+}
+
+// IsLocked checks if confirming the trade is currently locked,
+// because one of the offers changed recently.
+func (trade *Trade) IsLocked() bool {
+	trade.mutex.Lock()
+	defer trade.mutex.Unlock()
+	return time.Now().Before(trade.lockedUntil)
+}
+
+// index returns the index of the player with the given name in the trade.
+func (trade *Trade) index(name string) int {
+	if trade.sessions[0].GetName() == name {
+		return 0
+	}
+	return 1
+}
+
+// open opens the trade window for both players.
+// A fake chest gets set below both players to open the window on.
+func (trade *Trade) open() {
+	var runtimeId, _ = blocks.GetRuntimeId(54, 0)
+	for i, session := range trade.sessions {
+		var position = session.GetPlayer().GetPosition()
+		var y = math.Max(math.Floor(position.Y)-2, 0)
+		trade.positions[i] = blocks.NewPosition(int32(math.Floor(position.X)), uint32(y), int32(math.Floor(position.Z)))
+
+		session.SetFakeBlock(trade.positions[i], runtimeId)
+		session.SendContainerOpen(WindowId, data.ContainerTypeContainer, trade.positions[i], -1)
+	}
+	trade.update()
+}
+
+// close closes the trade window for both players,
+// removes the fake chests and resends their inventories.
+func (trade *Trade) close() {
+	for i, session := range trade.sessions {
+		session.SendContainerClose(WindowId)
+		session.RemoveFakeBlock(trade.positions[i])
+		session.SendInventory()
+	}
+}
+
+// update sends the contents of the trade window to both players.
+func (trade *Trade) update() {
+	trade.mutex.Lock()
+	defer trade.mutex.Unlock()
+	var locked = time.Now().Before(trade.lockedUntil)
+	for i, session := range trade.sessions {
+		var contents = make([]*items.Stack, windowSize)
+		var own, partner = trade.offers[i].GetAll(), trade.offers[1-i].GetAll()
+		for slot := 0; slot < offerSize; slot++ {
+			contents[ownSlots[slot]] = own[slot]
+			contents[partnerSlots[slot]] = partner[slot]
+		}
+		switch {
+		case trade.confirmed[i]:
+			contents[ownStatusSlot] = newButton("minecraft:emerald", text.BrightGreen+"Confirmed")
+		case locked:
+			contents[ownStatusSlot] = newButton("minecraft:redstone", text.Red+"Offers changed, please wait")
+		default:
+			contents[ownStatusSlot] = newButton("minecraft:redstone", text.Yellow+"Click to confirm")
+		}
+		if trade.confirmed[1-i] {
+			contents[partnerStatusSlot] = newButton("minecraft:emerald", text.BrightGreen+trade.sessions[1-i].GetDisplayName()+" confirmed")
+		} else {
+			contents[partnerStatusSlot] = newButton("minecraft:redstone", text.Yellow+trade.sessions[1-i].GetDisplayName()+" has not confirmed")
+		}
+		contents[cancelSlot] = newButton("minecraft:paper", text.Red+"Cancel trade")
+		session.SendInventoryContent(WindowId, contents)
+	}
+}
+
+// newButton returns a new item stack used as button in the trade window.
+func newButton(id string, name string) *items.Stack {
+	var stack, _ = items.DefaultManager.Get(id, 1)
+	stack.DisplayName = name
+	return stack
+}
+
+// offerChanged resets the confirmations of both players,
+// and locks confirming for the given duration.
+func (trade *Trade) offerChanged(lock time.Duration) {
+	trade.mutex.Lock()
+	trade.confirmed = [2]bool{}
+	trade.lockedUntil = time.Now().Add(lock)
+	trade.mutex.Unlock()
+}
+
+// confirm toggles the confirmation of the player with the given name.
+// Returns true if both players have confirmed the trade.
+func (trade *Trade) confirm(name string) bool {
+	trade.mutex.Lock()
+	defer trade.mutex.Unlock()
+	if time.Now().Before(trade.lockedUntil) {
+		return false
+	}
+	var i = trade.index(name)
+	trade.confirmed[i] = !trade.confirmed[i]
+	return trade.confirmed[0] && trade.confirmed[1]
+}
+
+// canReceive checks if both players have enough space
+// in their inventory to receive the offer of their partner.
+func (trade *Trade) canReceive() bool {
+	trade.mutex.Lock()
+	defer trade.mutex.Unlock()
+	for i, session := range trade.sessions {
+		var stacks []*items.Stack
+		for _, stack := range trade.offers[1-i].GetAll() {
+			if stack != nil {
+				stacks = append(stacks, stack)
+			}
+		}
+		if !session.GetPlayer().GetInventory().CanAddItems(stacks...) {
+			return false
+		}
+	}
+	return true
+}
+
+// complete gives both players the offer of their partner.
+// Items that do not fit in the inventory of a player get passed to the drop function.
+func (trade *Trade) complete(drop func(*net.MinecraftSession, *items.Stack)) {
+	trade.mutex.Lock()
+	defer trade.mutex.Unlock()
+	for i, session := range trade.sessions {
+		trade.give(session, trade.offers[1-i], drop)
+	}
+}
+
+// rollback returns the offers of both players to their own inventory.
+// Items that do not fit in the inventory of a player get passed to the drop function.
+func (trade *Trade) rollback(drop func(*net.MinecraftSession, *items.Stack)) {
+	trade.mutex.Lock()
+	defer trade.mutex.Unlock()
+	for i, session := range trade.sessions {
+		trade.give(session, trade.offers[i], drop)
+	}
+}
+
+// give moves all items of the offer into the inventory of the session, emptying the offer.
+// Items that do not fit in the inventory get passed to the drop function.
+func (trade *Trade) give(session *net.MinecraftSession, offer *inventory.Inventory, drop func(*net.MinecraftSession, *items.Stack)) {
+	for slot, stack := range offer.GetAll() {
+		if stack == nil {
+			continue
+		}
+		offer.ClearSlot(slot)
+		if leftover := session.GetPlayer().GetInventory().AddItemReturningLeftovers(stack); leftover != nil {
+			drop(session, leftover)
+		}
+	}
+}
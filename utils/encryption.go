@@ -5,9 +5,15 @@ import (
 	"crypto/cipher"
 	"crypto/ecdsa"
 	"crypto/sha256"
+	"crypto/subtle"
+	"errors"
+
 	"github.com/irmine/binutils"
 )
 
+// InvalidChecksum gets returned when the checksum of a decrypted batch does not match its payload.
+var InvalidChecksum = errors.New("invalid encryption checksum")
+
 type EncryptionData struct {
 	ClientPublicKey       *ecdsa.PublicKey
 	ServerPrivateKey      *ecdsa.PrivateKey
@@ -16,29 +22,54 @@ type EncryptionData struct {
 	DecryptSecretKeyBytes [32]byte
 	EncryptSecretKeyBytes [32]byte
 
+	// UseGCM specifies if batches are encrypted using AES-GCM, as used by newer protocols,
+	// rather than AES-CFB8.
+	UseGCM bool
+
 	DecryptIV     []byte
 	EncryptIV     []byte
 	DecryptCipher cipher.Block
 	EncryptCipher cipher.Block
+	DecryptStream cipher.Stream
+	EncryptStream cipher.Stream
 
-	SendCounter int64
+	SendCounter    int64
+	ReceiveCounter int64
 }
 
+// ComputeSharedSecret computes the ECDH shared secret of the client public key and server private key.
+// The secret is padded to the byte size of the curve.
 func (data *EncryptionData) ComputeSharedSecret() {
 	var x, _ = data.ClientPublicKey.Curve.ScalarMult(data.ClientPublicKey.X, data.ClientPublicKey.Y, data.ServerPrivateKey.D.Bytes())
-	data.SharedSecret = x.Bytes()
+	var size = (data.ClientPublicKey.Curve.Params().BitSize + 7) / 8
+	data.SharedSecret = make([]byte, size)
+	var bytes = x.Bytes()
+	copy(data.SharedSecret[size-len(bytes):], bytes)
 }
 
+// ComputeSecretKeyBytes derives the secret key from the server token and shared secret,
+// and sets up the streams used to encrypt and decrypt batches.
 func (data *EncryptionData) ComputeSecretKeyBytes() {
-	var secret = sha256.Sum256(append(data.ServerToken, data.SharedSecret...))
+	var secret = sha256.Sum256(append(append([]byte{}, data.ServerToken...), data.SharedSecret...))
 	data.DecryptSecretKeyBytes = secret
 	data.EncryptSecretKeyBytes = secret
 
 	data.DecryptCipher, _ = aes.NewCipher(data.DecryptSecretKeyBytes[:])
 	data.EncryptCipher, _ = aes.NewCipher(data.EncryptSecretKeyBytes[:])
 
-	data.DecryptIV = data.DecryptSecretKeyBytes[:aes.BlockSize]
-	data.EncryptIV = data.DecryptSecretKeyBytes[:aes.BlockSize]
+	if data.UseGCM {
+		// AES-GCM without authentication tags is equal to AES-CTR,
+		// with the counter starting at 2 after the 12 byte IV.
+		data.DecryptIV = append(append([]byte{}, secret[:12]...), 0, 0, 0, 2)
+		data.EncryptIV = append(append([]byte{}, secret[:12]...), 0, 0, 0, 2)
+		data.DecryptStream = cipher.NewCTR(data.DecryptCipher, data.DecryptIV)
+		data.EncryptStream = cipher.NewCTR(data.EncryptCipher, data.EncryptIV)
+		return
+	}
+	data.DecryptIV = append([]byte{}, secret[:aes.BlockSize]...)
+	data.EncryptIV = append([]byte{}, secret[:aes.BlockSize]...)
+	data.DecryptStream = NewCFB8(data.DecryptCipher, data.DecryptIV, true)
+	data.EncryptStream = NewCFB8(data.EncryptCipher, data.EncryptIV, false)
 }
 
 type EncryptionHandler struct {
@@ -49,12 +80,44 @@ func NewEncryptionHandler() *EncryptionHandler {
 	return &EncryptionHandler{&EncryptionData{}}
 }
 
-func (handler *EncryptionHandler) ComputeSendChecksum(d []byte) []byte {
-	var buffer []byte
-	var secret = handler.Data.EncryptSecretKeyBytes[:]
+// Encrypt appends the send checksum to the data and encrypts it in place.
+func (handler *EncryptionHandler) Encrypt(d []byte) []byte {
+	d = append(d, handler.ComputeSendChecksum(d)...)
+	handler.Data.EncryptStream.XORKeyStream(d, d)
+	return d
+}
+
+// Decrypt decrypts the data in place and verifies its checksum.
+// The data without checksum is returned, or InvalidChecksum if the checksum did not match.
+func (handler *EncryptionHandler) Decrypt(d []byte) ([]byte, error) {
+	handler.Data.DecryptStream.XORKeyStream(d, d)
+	if len(d) < 8 {
+		return nil, InvalidChecksum
+	}
+	var payload, checksum = d[:len(d)-8], d[len(d)-8:]
+	if subtle.ConstantTimeCompare(checksum, handler.ComputeReceiveChecksum(payload)) != 1 {
+		return nil, InvalidChecksum
+	}
+	return payload, nil
+}
 
-	binutils.WriteLittleLong(&buffer, handler.Data.SendCounter)
+func (handler *EncryptionHandler) ComputeSendChecksum(d []byte) []byte {
+	var checksum = computeChecksum(handler.Data.SendCounter, d, handler.Data.EncryptSecretKeyBytes[:])
 	handler.Data.SendCounter++
+	return checksum
+}
+
+// ComputeReceiveChecksum computes the checksum of data received from the client.
+func (handler *EncryptionHandler) ComputeReceiveChecksum(d []byte) []byte {
+	var checksum = computeChecksum(handler.Data.ReceiveCounter, d, handler.Data.DecryptSecretKeyBytes[:])
+	handler.Data.ReceiveCounter++
+	return checksum
+}
+
+// computeChecksum computes the checksum of the data with the given counter and secret.
+func computeChecksum(counter int64, d []byte, secret []byte) []byte {
+	var buffer []byte
+	binutils.WriteLittleLong(&buffer, counter)
 
 	var hash = sha256.New()
 	hash.Write(buffer)
@@ -64,3 +127,41 @@ func (handler *EncryptionHandler) ComputeSendChecksum(d []byte) []byte {
 	var sum = hash.Sum(nil)
 	return sum[:8]
 }
+
+// cfb8 is an AES-CFB8 stream, which encrypts one byte for every block cipher operation.
+type cfb8 struct {
+	block   cipher.Block
+	iv      []byte
+	out     []byte
+	decrypt bool
+}
+
+// NewCFB8 returns a new CFB8 stream with the given block cipher and IV,
+// encrypting or decrypting depending on the decrypt bool.
+func NewCFB8(block cipher.Block, iv []byte, decrypt bool) cipher.Stream {
+	var size = block.BlockSize()
+	var stream = &cfb8{block: block, iv: make([]byte, size*2), out: make([]byte, size), decrypt: decrypt}
+	copy(stream.iv, iv)
+	return stream
+}
+
+// XORKeyStream encrypts or decrypts the source into the destination.
+func (stream *cfb8) XORKeyStream(dst, src []byte) {
+	var size = stream.block.BlockSize()
+	var offset = 0
+	for i := range src {
+		stream.block.Encrypt(stream.out, stream.iv[offset:offset+size])
+		var cipherByte = src[i]
+		dst[i] = src[i] ^ stream.out[0]
+		if !stream.decrypt {
+			cipherByte = dst[i]
+		}
+		stream.iv[offset+size] = cipherByte
+		offset++
+		if offset == size {
+			copy(stream.iv, stream.iv[size:])
+			offset = 0
+		}
+	}
+	copy(stream.iv, stream.iv[offset:offset+size])
+}
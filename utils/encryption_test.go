@@ -0,0 +1,97 @@
+package utils
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"testing"
+)
+
+func newHandlers(t *testing.T, gcm bool) (*EncryptionHandler, *EncryptionHandler) {
+	var serverKey, err = ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	clientKey, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var token = []byte("salt")
+	var server = &EncryptionHandler{&EncryptionData{ClientPublicKey: &clientKey.PublicKey, ServerPrivateKey: serverKey, ServerToken: token, UseGCM: gcm}}
+	var client = &EncryptionHandler{&EncryptionData{ClientPublicKey: &serverKey.PublicKey, ServerPrivateKey: clientKey, ServerToken: token, UseGCM: gcm}}
+	for _, handler := range []*EncryptionHandler{server, client} {
+		handler.Data.ComputeSharedSecret()
+		handler.Data.ComputeSecretKeyBytes()
+	}
+	if !bytes.Equal(server.Data.SharedSecret, client.Data.SharedSecret) {
+		t.Fatal("shared secrets of both sides differ")
+	}
+	if len(server.Data.SharedSecret) != 48 {
+		t.Fatalf("expected shared secret of 48 bytes, got %v", len(server.Data.SharedSecret))
+	}
+	return server, client
+}
+
+func TestEncryption(t *testing.T) {
+	for _, gcm := range []bool{false, true} {
+		var server, client = newHandlers(t, gcm)
+		for i := 0; i < 3; i++ {
+			var payload = bytes.Repeat([]byte{byte(i)}, 100+i)
+			var encrypted = server.Encrypt(append([]byte{}, payload...))
+			if bytes.Equal(encrypted[:len(payload)], payload) {
+				t.Fatal("payload was not encrypted")
+			}
+			// The client validates checksums using its send counter.
+			client.Data.ReceiveCounter = client.Data.SendCounter
+			client.Data.SendCounter++
+			var decrypted, err = client.Decrypt(encrypted)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(decrypted, payload) {
+				t.Fatalf("decrypted payload %v does not match %v", decrypted, payload)
+			}
+		}
+
+		var encrypted = server.Encrypt([]byte("payload"))
+		encrypted[0] ^= 1
+		if _, err := client.Decrypt(encrypted); err != InvalidChecksum {
+			t.Fatalf("expected invalid checksum, got %v", err)
+		}
+	}
+}
+
+func TestCFB8(t *testing.T) {
+	var key, iv = make([]byte, 32), make([]byte, aes.BlockSize)
+	rand.Read(key)
+	rand.Read(iv)
+	var block, _ = aes.NewCipher(key)
+
+	var plain = make([]byte, 100)
+	rand.Read(plain)
+
+	// Reference implementation, encrypting every byte with a new CFB encrypter.
+	var expected = append([]byte{}, plain...)
+	var referenceIV = append([]byte{}, iv...)
+	for i := range expected {
+		cipher.NewCFBEncrypter(block, referenceIV).XORKeyStream(expected[i:i+1], expected[i:i+1])
+		referenceIV = append(referenceIV[1:], expected[i])
+	}
+
+	var encrypted = make([]byte, len(plain))
+	var stream = NewCFB8(block, iv, false)
+	stream.XORKeyStream(encrypted[:33], plain[:33])
+	stream.XORKeyStream(encrypted[33:], plain[33:])
+	if !bytes.Equal(encrypted, expected) {
+		t.Fatal("CFB8 encryption does not match reference")
+	}
+
+	var decrypted = append([]byte{}, encrypted...)
+	NewCFB8(block, iv, true).XORKeyStream(decrypted, decrypted)
+	if !bytes.Equal(decrypted, plain) {
+		t.Fatal("CFB8 decryption does not match plain text")
+	}
+}
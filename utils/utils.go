@@ -69,7 +69,14 @@ func ConstructEncryptionJwt(key *ecdsa.PrivateKey, token []byte) string {
 		fmt.Println(err)
 	}
 
-	var signature = base64.RawURLEncoding.EncodeToString(append(r.Bytes(), s.Bytes()...))
+	// R and S are both padded to the byte size of the curve, as required for ES384 signatures.
+	var size = (key.Curve.Params().BitSize + 7) / 8
+	var sig = make([]byte, size*2)
+	var rBytes, sBytes = r.Bytes(), s.Bytes()
+	copy(sig[size-len(rBytes):size], rBytes)
+	copy(sig[size*2-len(sBytes):], sBytes)
+
+	var signature = base64.RawURLEncoding.EncodeToString(sig)
 
 	return headerStr + "." + payloadStr + "." + signature
 }
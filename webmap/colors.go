@@ -0,0 +1,67 @@
+package webmap
+
+import (
+	"image/color"
+)
+
+// blockColors are the map colors of block IDs, based on the colors of blocks on maps in game.
+// Blocks without a color are drawn in the default color.
+var blockColors = map[byte]color.RGBA{
+	1:   {112, 112, 112, 255},
+	2:   {127, 178, 56, 255},
+	3:   {151, 109, 77, 255},
+	4:   {112, 112, 112, 255},
+	5:   {143, 119, 72, 255},
+	7:   {64, 64, 64, 255},
+	8:   {64, 64, 255, 255},
+	9:   {64, 64, 255, 255},
+	10:  {255, 90, 0, 255},
+	11:  {255, 90, 0, 255},
+	12:  {247, 233, 163, 255},
+	13:  {136, 136, 136, 255},
+	17:  {143, 119, 72, 255},
+	18:  {0, 124, 0, 255},
+	24:  {247, 233, 163, 255},
+	31:  {0, 124, 0, 255},
+	37:  {255, 255, 0, 255},
+	38:  {255, 0, 0, 255},
+	49:  {25, 25, 25, 255},
+	78:  {255, 255, 255, 255},
+	79:  {160, 160, 255, 255},
+	80:  {255, 255, 255, 255},
+	81:  {0, 124, 0, 255},
+	82:  {164, 168, 184, 255},
+	87:  {112, 2, 0, 255},
+	88:  {102, 76, 51, 255},
+	110: {127, 63, 178, 255},
+	121: {247, 233, 163, 255},
+	159: {209, 177, 161, 255},
+	161: {0, 124, 0, 255},
+	162: {102, 76, 51, 255},
+	172: {209, 177, 161, 255},
+	174: {160, 160, 255, 255},
+	243: {102, 76, 51, 255},
+}
+
+// defaultColor is the color of blocks without a map color.
+var defaultColor = color.RGBA{R: 127, G: 127, B: 127, A: 255}
+
+// GetBlockColor returns the color the block with the given ID and data is drawn with on the map.
+func GetBlockColor(id, data byte) color.RGBA {
+	if c, ok := blockColors[id]; ok {
+		return c
+	}
+	return defaultColor
+}
+
+// shade returns the color darkened or brightened by the factor, where 1 leaves the color unchanged.
+func shade(c color.RGBA, factor float64) color.RGBA {
+	var scale = func(value uint8) uint8 {
+		var scaled = float64(value) * factor
+		if scaled > 255 {
+			return 255
+		}
+		return uint8(scaled)
+	}
+	return color.RGBA{R: scale(c.R), G: scale(c.G), B: scale(c.B), A: c.A}
+}
@@ -0,0 +1,79 @@
+package webmap
+
+// page is the page showing the rendered tiles as a map that can be dragged around.
+// It reads the rendered dimensions from maps.json, and the tiles of a dimension from its tiles.json.
+const page = `<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>GoMine Map</title>
+<style>
+html, body { margin: 0; height: 100%; overflow: hidden; background: #1e1e1e; font-family: sans-serif; }
+#map { position: absolute; cursor: grab; }
+#map img { position: absolute; width: 512px; height: 512px; image-rendering: pixelated; }
+#controls { position: fixed; top: 8px; left: 8px; z-index: 1; }
+#position { position: fixed; bottom: 8px; left: 8px; color: #fff; z-index: 1; }
+</style>
+</head>
+<body>
+<div id="controls"><select id="dimension"></select></div>
+<div id="position"></div>
+<div id="map"></div>
+<script>
+var map = document.getElementById("map"), select = document.getElementById("dimension");
+var offsetX = window.innerWidth / 2, offsetZ = window.innerHeight / 2, dragging = null;
+
+function move() {
+	map.style.left = offsetX + "px";
+	map.style.top = offsetZ + "px";
+}
+
+function load(name) {
+	map.innerHTML = "";
+	fetch(name + "/tiles.json", {cache: "no-store"}).then(function (response) {
+		return response.json();
+	}).then(function (tiles) {
+		tiles.forEach(function (tile) {
+			var image = document.createElement("img");
+			image.src = name + "/" + tile[0] + "_" + tile[1] + ".png?" + Date.now();
+			image.style.left = tile[0] * 512 + "px";
+			image.style.top = tile[1] * 512 + "px";
+			image.draggable = false;
+			map.appendChild(image);
+		});
+	});
+}
+
+fetch("maps.json", {cache: "no-store"}).then(function (response) {
+	return response.json();
+}).then(function (maps) {
+	maps.forEach(function (name) {
+		select.add(new Option(name, name));
+	});
+	if (maps.length > 0) {
+		load(maps[0]);
+	}
+});
+
+select.onchange = function () {
+	load(select.value);
+};
+document.onmousedown = function (event) {
+	dragging = {x: event.clientX - offsetX, z: event.clientY - offsetZ};
+};
+document.onmouseup = function () {
+	dragging = null;
+};
+document.onmousemove = function (event) {
+	if (dragging) {
+		offsetX = event.clientX - dragging.x;
+		offsetZ = event.clientY - dragging.z;
+		move();
+	}
+	document.getElementById("position").textContent = "X: " + Math.floor(event.clientX - offsetX) + " Z: " + Math.floor(event.clientY - offsetZ);
+};
+move();
+</script>
+</body>
+</html>
+`
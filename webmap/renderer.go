@@ -0,0 +1,269 @@
+package webmap
+
+import (
+	"encoding/json"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/irmine/gomine/levels"
+	"github.com/irmine/worlds"
+	"github.com/irmine/worlds/chunks"
+)
+
+const (
+	// TileChunks is the amount of chunks along each side of a tile, making every tile cover one region.
+	TileChunks = 32
+	// TileSize is the size of tiles in pixels, with one pixel for every block.
+	TileSize = TileChunks * 16
+)
+
+// chunkKey is the key of a chunk in a dimension.
+type chunkKey struct {
+	dimension *worlds.Dimension
+	x, z      int32
+}
+
+// Renderer renders chunks to top-down PNG tiles in a directory that can be served by any web server,
+// along with a page showing the tiles as a map. Chunks are rendered once they get loaded by players,
+// and rendered again once they change. Chunks are snapshotted when the renderer gets flushed on the tick,
+// and rendered asynchronously, writing only the tiles holding changed chunks.
+type Renderer struct {
+	// Directory is the directory the map gets written to.
+	// Tiles of every dimension are written to a directory named after the level and dimension.
+	Directory string
+	// Interval is the interval in ticks at which the renderer should be flushed.
+	Interval int64
+	// ColorFunction returns the color of the block with the given ID and data on the map.
+	// It is GetBlockColor by default.
+	ColorFunction func(id, data byte) color.RGBA
+	// ErrorFunction gets called with errors writing tiles. It does nothing by default.
+	ErrorFunction func(err error)
+
+	mutex     sync.Mutex
+	dirty     map[chunkKey]*chunks.Chunk
+	rendered  map[chunkKey]bool
+	rendering bool
+}
+
+// NewRenderer returns a new renderer writing the map to the directory,
+// rendering changed chunks every 30 seconds by default.
+func NewRenderer(directory string) *Renderer {
+	return &Renderer{
+		Directory:     directory,
+		Interval:      600,
+		ColorFunction: GetBlockColor,
+		ErrorFunction: func(error) {},
+		dirty:         make(map[chunkKey]*chunks.Chunk),
+		rendered:      make(map[chunkKey]bool),
+	}
+}
+
+// GetName returns the name of the directory the tiles of the dimension are written to,
+// relative to the directory of the renderer.
+func GetName(dimension *worlds.Dimension) string {
+	return dimension.GetLevel().GetName() + "/" + dimension.GetName()
+}
+
+// MarkDirty marks the chunk of the dimension as changed, so that it gets rendered again on the next flush.
+func (renderer *Renderer) MarkDirty(dimension *worlds.Dimension, chunk *chunks.Chunk) {
+	renderer.mutex.Lock()
+	renderer.dirty[chunkKey{dimension, chunk.X, chunk.Z}] = chunk
+	renderer.mutex.Unlock()
+}
+
+// MarkLoaded marks the chunk of the dimension to be rendered on the next flush,
+// unless it was already rendered since the renderer was created.
+func (renderer *Renderer) MarkLoaded(dimension *worlds.Dimension, chunk *chunks.Chunk) {
+	var key = chunkKey{dimension, chunk.X, chunk.Z}
+	renderer.mutex.Lock()
+	if !renderer.rendered[key] {
+		renderer.dirty[key] = chunk
+	}
+	renderer.mutex.Unlock()
+}
+
+// Flush snapshots all changed chunks and renders them asynchronously.
+// Flush must be called on the tick. No chunks are snapshotted while
+// the chunks of the previous flush are still being rendered.
+func (renderer *Renderer) Flush() {
+	renderer.mutex.Lock()
+	if renderer.rendering || len(renderer.dirty) == 0 {
+		renderer.mutex.Unlock()
+		return
+	}
+	var snapshots = make(map[string][]*levels.Snapshot)
+	for key, chunk := range renderer.dirty {
+		var name = GetName(key.dimension)
+		snapshots[name] = append(snapshots[name], levels.NewSnapshot(chunk))
+		renderer.rendered[key] = true
+	}
+	renderer.dirty = make(map[chunkKey]*chunks.Chunk)
+	renderer.rendering = true
+	renderer.mutex.Unlock()
+
+	go func() {
+		for name, dimensionSnapshots := range snapshots {
+			if err := renderer.Render(name, dimensionSnapshots); err != nil {
+				renderer.ErrorFunction(err)
+			}
+		}
+		renderer.mutex.Lock()
+		renderer.rendering = false
+		renderer.mutex.Unlock()
+	}()
+}
+
+// Render renders the snapshots into the tiles of the dimension with the given name,
+// and updates the tile list of the dimension and the map page.
+// Snapshots of chunks of which the blocks can not be read are skipped.
+func (renderer *Renderer) Render(name string, snapshots []*levels.Snapshot) error {
+	var directory = filepath.Join(renderer.Directory, filepath.FromSlash(name))
+	if err := os.MkdirAll(directory, 0755); err != nil {
+		return err
+	}
+	var tiles = make(map[[2]int32][]*levels.Snapshot)
+	for _, snapshot := range snapshots {
+		if snapshot.IsSupported() {
+			var tile = [2]int32{snapshot.X >> 5, snapshot.Z >> 5}
+			tiles[tile] = append(tiles[tile], snapshot)
+		}
+	}
+	for tile, tileSnapshots := range tiles {
+		if err := renderer.renderTile(filepath.Join(directory, getTileName(tile[0], tile[1])), tile[0], tile[1], tileSnapshots); err != nil {
+			return err
+		}
+	}
+	if err := writeTileList(directory); err != nil {
+		return err
+	}
+	return renderer.writePage()
+}
+
+// renderTile draws the snapshots onto the tile at the path, which is created if it does not yet exist.
+func (renderer *Renderer) renderTile(path string, tileX, tileZ int32, snapshots []*levels.Snapshot) error {
+	var tile = image.NewRGBA(image.Rect(0, 0, TileSize, TileSize))
+	if file, err := os.Open(path); err == nil {
+		existing, err := png.Decode(file)
+		file.Close()
+		if err == nil {
+			draw.Draw(tile, tile.Bounds(), existing, image.Point{}, draw.Src)
+		}
+	}
+	for _, snapshot := range snapshots {
+		renderer.drawChunk(tile, int(snapshot.X-tileX*TileChunks)*16, int(snapshot.Z-tileZ*TileChunks)*16, snapshot)
+	}
+
+	var temporary = path + ".tmp"
+	file, err := os.Create(temporary)
+	if err != nil {
+		return err
+	}
+	if err := png.Encode(file, tile); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+	return os.Rename(temporary, path)
+}
+
+// drawChunk draws the highest block of every column of the snapshot onto the tile at the offset.
+// Blocks higher than the block north of them are drawn brighter, and blocks lower than it darker,
+// and water is drawn darker the deeper it is.
+func (renderer *Renderer) drawChunk(tile *image.RGBA, offsetX, offsetZ int, snapshot *levels.Snapshot) {
+	for x := 0; x < 16; x++ {
+		var northY = -1
+		for z := 0; z < 16; z++ {
+			var y, ok = snapshot.GetHighestBlock(x, z)
+			if !ok {
+				tile.SetRGBA(offsetX+x, offsetZ+z, color.RGBA{})
+				northY = -1
+				continue
+			}
+			var id = snapshot.GetBlockId(x, y, z)
+			var c = renderer.ColorFunction(id, snapshot.GetBlockData(x, y, z))
+			if id == 8 || id == 9 {
+				var depth = 0
+				for depth < 8 && y-depth > 0 && (snapshot.GetBlockId(x, y-depth-1, z) == 8 || snapshot.GetBlockId(x, y-depth-1, z) == 9) {
+					depth++
+				}
+				c = shade(c, 1-float64(depth)*0.05)
+			} else if northY != -1 && y > northY {
+				c = shade(c, 1.1)
+			} else if northY != -1 && y < northY {
+				c = shade(c, 0.85)
+			}
+			tile.SetRGBA(offsetX+x, offsetZ+z, c)
+			northY = y
+		}
+	}
+}
+
+// getTileName returns the file name of the tile at the tile coordinates.
+func getTileName(tileX, tileZ int32) string {
+	return strconv.Itoa(int(tileX)) + "_" + strconv.Itoa(int(tileZ)) + ".png"
+}
+
+// writeTileList writes the coordinates of all tiles in the directory to tiles.json in the directory,
+// which is read by the map page to find the tiles.
+func writeTileList(directory string) error {
+	var files, err = ioutil.ReadDir(directory)
+	if err != nil {
+		return err
+	}
+	var tiles = make([][2]int, 0, len(files))
+	for _, file := range files {
+		var parts = strings.Split(strings.TrimSuffix(file.Name(), ".png"), "_")
+		if !strings.HasSuffix(file.Name(), ".png") || len(parts) != 2 {
+			continue
+		}
+		var x, xErr = strconv.Atoi(parts[0])
+		var z, zErr = strconv.Atoi(parts[1])
+		if xErr == nil && zErr == nil {
+			tiles = append(tiles, [2]int{x, z})
+		}
+	}
+	content, err := json.Marshal(tiles)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(filepath.Join(directory, "tiles.json"), content, 0644)
+}
+
+// writePage writes the map page and the list of all rendered dimensions to the directory of the renderer.
+func (renderer *Renderer) writePage() error {
+	var maps []string
+	var err = filepath.Walk(renderer.Directory, func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() || info.Name() != "tiles.json" {
+			return err
+		}
+		var relative, relErr = filepath.Rel(renderer.Directory, filepath.Dir(path))
+		if relErr != nil {
+			return relErr
+		}
+		maps = append(maps, filepath.ToSlash(relative))
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	sort.Strings(maps)
+	content, err := json.Marshal(maps)
+	if err != nil {
+		return err
+	}
+	if err := ioutil.WriteFile(filepath.Join(renderer.Directory, "maps.json"), content, 0644); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(filepath.Join(renderer.Directory, "index.html"), []byte(page), 0644)
+}
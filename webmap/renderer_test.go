@@ -0,0 +1,82 @@
+package webmap
+
+import (
+	"image/color"
+	"image/png"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/irmine/gomine/levels"
+)
+
+// grassChunk returns a serialized chunk with one sub chunk holding a layer of grass at Y 0,
+// and water on top of it in the first column.
+func grassChunk() []byte {
+	var chunkData = make([]byte, 1+1+4096+2048+512+256)
+	chunkData[0] = 1
+	for x := 0; x < 16; x++ {
+		for z := 0; z < 16; z++ {
+			chunkData[2+(x<<8|z<<4)] = 2
+		}
+	}
+	chunkData[2+1] = 9
+	return chunkData
+}
+
+func TestRender(t *testing.T) {
+	var directory = t.TempDir()
+	var renderer = NewRenderer(directory)
+	var snapshots = []*levels.Snapshot{levels.NewBinarySnapshot(-1, 33, grassChunk())}
+	if err := renderer.Render("world/overworld", snapshots); err != nil {
+		t.Fatal(err)
+	}
+
+	file, err := os.Open(filepath.Join(directory, "world", "overworld", "-1_1.png"))
+	if err != nil {
+		t.Fatal("expected the tile holding the chunk to be written:", err)
+	}
+	tile, err := png.Decode(file)
+	file.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c := color.RGBAModel.Convert(tile.At(31*16+1, 16+1)); c != GetBlockColor(2, 0) {
+		t.Error("expected grass to be drawn at the chunk, got", c)
+	}
+	if c := color.RGBAModel.Convert(tile.At(31*16, 16)); c != GetBlockColor(9, 0) {
+		t.Error("expected water to be drawn on top of grass, got", c)
+	}
+	if c := color.RGBAModel.Convert(tile.At(0, 0)); c != (color.RGBA{}) {
+		t.Error("expected columns without chunks to be transparent, got", c)
+	}
+
+	content, _ := ioutil.ReadFile(filepath.Join(directory, "world", "overworld", "tiles.json"))
+	if string(content) != "[[-1,1]]" {
+		t.Error("unexpected tile list:", string(content))
+	}
+	content, _ = ioutil.ReadFile(filepath.Join(directory, "maps.json"))
+	if string(content) != `["world/overworld"]` {
+		t.Error("unexpected map list:", string(content))
+	}
+	if _, err := os.Stat(filepath.Join(directory, "index.html")); err != nil {
+		t.Error("expected the map page to be written:", err)
+	}
+
+	// Rendering a changed chunk in the same tile keeps the chunks rendered before.
+	var changed = grassChunk()
+	changed[2+(1<<8)] = 1
+	if err := renderer.Render("world/overworld", []*levels.Snapshot{levels.NewBinarySnapshot(-2, 33, changed)}); err != nil {
+		t.Fatal(err)
+	}
+	file, _ = os.Open(filepath.Join(directory, "world", "overworld", "-1_1.png"))
+	tile, _ = png.Decode(file)
+	file.Close()
+	if c := color.RGBAModel.Convert(tile.At(31*16+1, 16+1)); c != GetBlockColor(2, 0) {
+		t.Error("expected the previously rendered chunk to be kept, got", c)
+	}
+	if c := color.RGBAModel.Convert(tile.At(30*16+1, 16)); c != GetBlockColor(1, 0) {
+		t.Error("expected the changed chunk to be drawn, got", c)
+	}
+}